@@ -1,9 +1,13 @@
 package cmd
 
 import (
+	"encoding/json"
 	"fmt"
+	"os"
 	"strings"
 
+	"github.com/WaylonWalker/markata-go/pkg/aesthetic"
+	"github.com/WaylonWalker/markata-go/pkg/palettes"
 	"github.com/spf13/cobra"
 )
 
@@ -100,6 +104,19 @@ var builtinAesthetics = []Aesthetic{
 			Shadow:     tokenNone,
 		},
 	},
+	{
+		Name:        "fluid",
+		Description: "Balanced shapes with fluid type that scales with the viewport",
+		Tokens: AestheticTokens{
+			RadiusSm:   "0.25rem",
+			RadiusMd:   "0.375rem",
+			RadiusLg:   "0.5rem",
+			RadiusFull: "9999px",
+			Spacing:    "1x scale",
+			Border:     "1px solid",
+			Shadow:     "0 1px 3px rgba(0,0,0,0.1)",
+		},
+	},
 }
 
 // aestheticCmd represents the aesthetic command group.
@@ -112,8 +129,10 @@ Aesthetics define the visual style tokens for your site, including
 border radius, spacing scale, border styles, and shadow effects.
 
 Subcommands:
-  list  - List available aesthetics
-  show  - Show details of a specific aesthetic`,
+  list     - List available aesthetics
+  show     - Show details of a specific aesthetic
+  validate - Check an aesthetic's token values
+  preview  - Generate an HTML preview of an aesthetic`,
 }
 
 // aestheticListCmd lists available aesthetics.
@@ -145,6 +164,72 @@ Example usage:
 	RunE: runAestheticShowCommand,
 }
 
+var (
+	// aestheticValidateStrict treats warnings as failures.
+	aestheticValidateStrict bool
+
+	// aestheticValidateJSON outputs issues as JSON.
+	aestheticValidateJSON bool
+)
+
+// aestheticValidateCmd validates an aesthetic's token values.
+var aestheticValidateCmd = &cobra.Command{
+	Use:   "validate <name>",
+	Short: "Validate aesthetic tokens",
+	Long: `Check an aesthetic's token values for problems.
+
+Loads the aesthetic (resolving extends) and reports:
+  - Errors: negative border radius, spacing or border widths that are not
+    CSS lengths, invalid box-shadow syntax, invalid fluid typography
+  - Warnings: font stacks without a generic family fallback
+
+CSS functions such as calc() and var(--x) are accepted as values.
+
+Exit codes:
+  0 - No errors (and no warnings with --strict)
+  1 - One or more errors found
+
+Example usage:
+  markata-go aesthetic validate balanced
+  markata-go aesthetic validate my-aesthetic --strict  # Fail on warnings
+  markata-go aesthetic validate my-aesthetic --json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runAestheticValidateCommand,
+}
+
+var (
+	// aestheticPreviewOutput is the preview output file.
+	aestheticPreviewOutput string
+
+	// aestheticPreviewPalette is a palette to combine with the preview.
+	aestheticPreviewPalette string
+
+	// aestheticPreviewOpen opens the preview in a browser.
+	aestheticPreviewOpen bool
+)
+
+// aestheticPreviewCmd generates an HTML preview of an aesthetic.
+var aestheticPreviewCmd = &cobra.Command{
+	Use:   "preview <name>",
+	Short: "Generate aesthetic preview",
+	Long: `Generate a standalone HTML preview page for an aesthetic.
+
+The page shows sample headings, body text, buttons, cards, bordered
+elements, and code blocks, plus a sample of every radius, border, shadow,
+spacing, and typography token. The aesthetic's CSS is inlined, so the file
+opens directly in a browser.
+
+Pass --palette to combine the aesthetic with a palette's colors.
+
+Example usage:
+  markata-go aesthetic preview balanced
+  markata-go aesthetic preview brutal -o preview.html
+  markata-go aesthetic preview elevated --palette catppuccin-mocha
+  markata-go aesthetic preview my-aesthetic --open  # Open in browser`,
+	Args: cobra.ExactArgs(1),
+	RunE: runAestheticPreviewCommand,
+}
+
 func init() {
 	rootCmd.AddCommand(aestheticCmd)
 
@@ -153,6 +238,17 @@ func init() {
 
 	// Show subcommand
 	aestheticCmd.AddCommand(aestheticShowCmd)
+
+	// Validate subcommand
+	aestheticValidateCmd.Flags().BoolVar(&aestheticValidateStrict, "strict", false, "Treat warnings as failures")
+	aestheticValidateCmd.Flags().BoolVar(&aestheticValidateJSON, "json", false, "Output issues as JSON")
+	aestheticCmd.AddCommand(aestheticValidateCmd)
+
+	// Preview subcommand
+	aestheticPreviewCmd.Flags().StringVarP(&aestheticPreviewOutput, "output", "o", "", "Output file (default: aesthetic-preview.html)")
+	aestheticPreviewCmd.Flags().StringVarP(&aestheticPreviewPalette, "palette", "p", "", "Palette to combine with the aesthetic")
+	aestheticPreviewCmd.Flags().BoolVar(&aestheticPreviewOpen, "open", false, "Open preview in browser")
+	aestheticCmd.AddCommand(aestheticPreviewCmd)
 }
 
 // runAestheticListCommand lists available aesthetics.
@@ -232,3 +328,91 @@ func runAestheticShowCommand(_ *cobra.Command, args []string) error {
 
 	return nil
 }
+
+// runAestheticValidateCommand validates an aesthetic's token values.
+func runAestheticValidateCommand(_ *cobra.Command, args []string) error {
+	a, err := aesthetic.NewLoader().Load(args[0])
+	if err != nil {
+		return fmt.Errorf("failed to load aesthetic: %w", err)
+	}
+
+	issues := a.Validate()
+
+	errorCount, warningCount := 0, 0
+	for i := range issues {
+		if issues[i].Severity == aesthetic.SeverityError {
+			errorCount++
+		} else {
+			warningCount++
+		}
+	}
+
+	if aestheticValidateJSON {
+		data, err := json.MarshalIndent(map[string]interface{}{
+			"aesthetic": a.Name,
+			"errors":    errorCount,
+			"warnings":  warningCount,
+			"issues":    issues,
+		}, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal JSON: %w", err)
+		}
+		fmt.Println(string(data))
+	} else {
+		fmt.Printf("Validating aesthetic: %s\n", a.Name)
+		fmt.Println(strings.Repeat("-", 50))
+		for i := range issues {
+			fmt.Println(issues[i].Error())
+		}
+		if len(issues) > 0 {
+			fmt.Println()
+		}
+		fmt.Printf("%d error(s), %d warning(s)\n", errorCount, warningCount)
+	}
+
+	if errorCount > 0 {
+		return fmt.Errorf("aesthetic %s has %d error(s)", a.Name, errorCount)
+	}
+	if aestheticValidateStrict && warningCount > 0 {
+		return fmt.Errorf("aesthetic %s has %d warning(s)", a.Name, warningCount)
+	}
+
+	return nil
+}
+
+// runAestheticPreviewCommand generates an HTML preview of an aesthetic.
+func runAestheticPreviewCommand(_ *cobra.Command, args []string) error {
+	a, err := aesthetic.NewLoader().Load(args[0])
+	if err != nil {
+		return fmt.Errorf("failed to load aesthetic: %w", err)
+	}
+
+	var opts aesthetic.PreviewOptions
+	if aestheticPreviewPalette != "" {
+		p, err := palettes.NewLoader().Load(aestheticPreviewPalette)
+		if err != nil {
+			return fmt.Errorf("failed to load palette: %w", err)
+		}
+		opts.PaletteName = p.Name
+		opts.PaletteCSS = p.GenerateCSS()
+	}
+
+	html := aesthetic.GeneratePreviewHTMLWithOptions(a, opts)
+
+	outputFile := aestheticPreviewOutput
+	if outputFile == "" {
+		outputFile = "aesthetic-preview.html"
+	}
+
+	if err := os.WriteFile(outputFile, []byte(html), 0o644); err != nil { //nolint:gosec // preview files should be readable
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+
+	fmt.Printf("Preview generated: %s\n", outputFile)
+
+	if aestheticPreviewOpen {
+		openBrowser(outputFile)
+	}
+
+	return nil
+}
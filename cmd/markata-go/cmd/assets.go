@@ -4,11 +4,13 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"strings"
 	"text/tabwriter"
 	"time"
 
 	"github.com/WaylonWalker/markata-go/pkg/assets"
 	"github.com/WaylonWalker/markata-go/pkg/config"
+	"github.com/WaylonWalker/markata-go/pkg/models"
 	"github.com/spf13/cobra"
 )
 
@@ -30,11 +32,13 @@ downloaded from CDNs and served from your site's output directory.
 Available subcommands:
   download - Download all external assets to the cache
   list     - List all assets and their status
+  verify   - Check cached assets against their integrity hashes
   clean    - Remove the assets cache
 
 Example usage:
   markata-go assets download    # Download all CDN assets
   markata-go assets list        # List asset status
+  markata-go assets verify      # Verify cached asset hashes
   markata-go assets clean       # Clear the cache`,
 }
 
@@ -48,11 +52,22 @@ Assets are downloaded from their CDN URLs and stored in the cache directory
 (default: .markata/assets-cache). These cached assets can then be served
 from your site instead of loading from external CDNs.
 
-Example:
-  markata-go assets download`,
+Downloads run in parallel (assets.concurrency, default 4). Network errors and
+HTTP 408, 429, and 5xx responses are retried with exponential backoff up to
+assets.max_attempts times (default 3). An asset that still fails does not stop
+the others; failures are listed at the end and the command exits non-zero.
+
+Examples:
+  markata-go assets download
+  markata-go assets download --concurrency 8 --max-attempts 5`,
 	RunE: runAssetsDownload,
 }
 
+var (
+	assetsDownloadConcurrency int
+	assetsDownloadMaxAttempts int
+)
+
 // assetsListCmd lists all assets and their status.
 var assetsListCmd = &cobra.Command{
 	Use:   "list",
@@ -66,6 +81,27 @@ Example:
 	RunE: runAssetsList,
 }
 
+// assetsVerifyCmd verifies cached assets against their integrity hashes.
+var assetsVerifyCmd = &cobra.Command{
+	Use:   "verify",
+	Short: "Verify cached assets against their integrity hashes",
+	Long: `Re-hash every cached asset and compare it against the registry's SRI hash.
+
+Each hash algorithm (sha256, sha384, sha512) in an asset's integrity value
+is checked separately, and mismatches name the algorithm that failed.
+Assets that are not cached or have no registered hash are reported too.
+
+Use --repair to re-download missing and mismatched assets.
+
+Example:
+  markata-go assets verify
+  markata-go assets verify --repair`,
+	RunE: runAssetsVerify,
+}
+
+// assetsVerifyRepair re-downloads assets that fail verification.
+var assetsVerifyRepair bool
+
 // assetsCleanCmd cleans the assets cache.
 var assetsCleanCmd = &cobra.Command{
 	Use:   "clean",
@@ -84,32 +120,59 @@ func init() {
 	rootCmd.AddCommand(assetsCmd)
 	assetsCmd.AddCommand(assetsDownloadCmd)
 	assetsCmd.AddCommand(assetsListCmd)
+	assetsCmd.AddCommand(assetsVerifyCmd)
 	assetsCmd.AddCommand(assetsCleanCmd)
+
+	assetsDownloadCmd.Flags().IntVarP(&assetsDownloadConcurrency, "concurrency", "j", 0, "number of parallel downloads (default from assets.concurrency)")
+	assetsDownloadCmd.Flags().IntVar(&assetsDownloadMaxAttempts, "max-attempts", 0, "attempts per asset before giving up (default from assets.max_attempts)")
+	assetsVerifyCmd.Flags().BoolVar(&assetsVerifyRepair, "repair", false, "re-download missing and mismatched assets")
 }
 
-// getAssetsConfig loads the config and returns the assets configuration.
-func getAssetsConfig() (downloader *assets.Downloader, cacheDir string) {
+// getAssetsConfig loads the config, applies its custom assets and version
+// pins to the asset registry, and returns a downloader for the configured cache.
+func getAssetsConfig() (downloader *assets.Downloader, cacheDir string, err error) {
 	cfg, err := config.Load(cfgFile)
 	if err != nil {
 		// Use defaults if no config
-		cacheDir = ".markata/assets-cache"
-		return assets.NewDownloader(cacheDir, true), cacheDir
+		defaults := models.NewAssetsConfig()
+		return assets.NewDownloaderFromConfig(&defaults), defaults.GetCacheDir(), nil
 	}
 
-	cacheDir = cfg.Assets.GetCacheDir()
-	verifyIntegrity := cfg.Assets.IsVerifyIntegrityEnabled()
-	return assets.NewDownloader(cacheDir, verifyIntegrity), cacheDir
+	if err := assets.Configure(&cfg.Assets); err != nil {
+		return nil, "", err
+	}
+
+	return assets.NewDownloaderFromConfig(&cfg.Assets), cfg.Assets.GetCacheDir(), nil
 }
 
 func runAssetsDownload(_ *cobra.Command, _ []string) error {
-	downloader, _ := getAssetsConfig()
+	downloader, _, err := getAssetsConfig()
+	if err != nil {
+		return err
+	}
+
+	if assetsDownloadConcurrency > 0 {
+		downloader.SetConcurrency(assetsDownloadConcurrency)
+	}
+	if assetsDownloadMaxAttempts > 0 {
+		downloader.SetMaxAttempts(assetsDownloadMaxAttempts)
+	}
+
+	ctx := context.Background()
+	unreachable := checkPinnedAssets(ctx, downloader)
 
 	fmt.Println("Downloading external CDN assets...")
 	fmt.Println()
 
-	ctx := context.Background()
+	if outputIsTerminal() {
+		downloader.SetProgress(printDownloadProgress)
+	}
+
 	startTime := time.Now()
-	results := downloader.DownloadAll(ctx, 4)
+	results := downloader.DownloadAll(ctx, 0)
+	if outputIsTerminal() {
+		fmt.Print("\r\033[2K")
+	}
 
 	// Print results
 	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
@@ -151,6 +214,17 @@ func runAssetsDownload(_ *cobra.Command, _ []string) error {
 	fmt.Printf("Total: %d downloaded, %d cached, %d errors (%s in %v)\n",
 		successCount, cachedCount, errorCount, formatSize(totalSize), duration.Truncate(time.Millisecond))
 
+	if failed := assets.FailedDownloads(results); len(failed) > 0 {
+		fmt.Println()
+		fmt.Println("Failed assets:")
+		for i := range failed {
+			fmt.Printf("  %s (%s): %v\n", failed[i].Asset.Name, failed[i].Asset.URL, failed[i].Error)
+		}
+	}
+
+	if unreachable > 0 {
+		return fmt.Errorf("%d pinned asset versions are not reachable", unreachable)
+	}
 	if errorCount > 0 {
 		return fmt.Errorf("%d assets failed to download", errorCount)
 	}
@@ -158,8 +232,60 @@ func runAssetsDownload(_ *cobra.Command, _ []string) error {
 	return nil
 }
 
+// printDownloadProgress redraws a one-line progress bar as each asset
+// finishes downloading.
+func printDownloadProgress(p assets.DownloadProgress) {
+	const width = 30
+	filled := width
+	if p.Total > 0 {
+		filled = p.Completed * width / p.Total
+	}
+	status := "ok"
+	switch {
+	case p.Result.Error != nil:
+		status = "failed"
+	case p.Result.Cached:
+		status = "cached"
+	}
+	fmt.Printf("\r\033[2K[%s%s] %d/%d %s (%s)",
+		strings.Repeat("=", filled), strings.Repeat(" ", width-filled),
+		p.Completed, p.Total, p.Result.Asset.Name, status)
+}
+
+// checkPinnedAssets checks that every pinned asset version resolves to a
+// reachable URL, printing one line per pin. It returns the number of
+// unreachable pins.
+func checkPinnedAssets(ctx context.Context, downloader *assets.Downloader) int {
+	pinned := assets.PinnedAssets()
+	if len(pinned) == 0 {
+		return 0
+	}
+
+	fmt.Println("Checking pinned asset versions...")
+	var unreachable int
+	for i := range pinned {
+		asset := &pinned[i]
+		integrity := "integrity not checked"
+		if asset.Integrity != "" {
+			integrity = "integrity pinned"
+		}
+		if err := downloader.CheckReachable(ctx, *asset); err != nil {
+			fmt.Printf("  %s@%s: unreachable: %v\n", asset.Name, asset.Version, err)
+			unreachable++
+			continue
+		}
+		fmt.Printf("  %s@%s: ok (%s)\n", asset.Name, asset.Version, integrity)
+	}
+	fmt.Println()
+
+	return unreachable
+}
+
 func runAssetsList(_ *cobra.Command, _ []string) error {
-	downloader, cacheDir := getAssetsConfig()
+	downloader, cacheDir, err := getAssetsConfig()
+	if err != nil {
+		return err
+	}
 
 	fmt.Printf("Assets cache directory: %s\n\n", cacheDir)
 
@@ -200,8 +326,107 @@ func runAssetsList(_ *cobra.Command, _ []string) error {
 	return nil
 }
 
+func runAssetsVerify(_ *cobra.Command, _ []string) error {
+	downloader, cacheDir, err := getAssetsConfig()
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Assets cache directory: %s\n\n", cacheDir)
+
+	results := downloader.Verify()
+	printVerifyResults(results)
+
+	if assetsVerifyRepair {
+		var repairs []assets.VerifyResult
+		for i := range results {
+			if results[i].NeedsRepair() {
+				repairs = append(repairs, results[i])
+			}
+		}
+		if len(repairs) > 0 {
+			fmt.Printf("\nRepairing %d assets...\n", len(repairs))
+			for _, download := range downloader.Repair(context.Background(), repairs, 0) {
+				if download.Error != nil {
+					fmt.Printf("  %s: error: %v\n", download.Asset.Name, download.Error)
+				} else {
+					fmt.Printf("  %s: downloaded (%s)\n", download.Asset.Name, formatSize(download.Size))
+				}
+			}
+
+			fmt.Println()
+			results = downloader.Verify()
+			printVerifyResults(results)
+		}
+	}
+
+	var failed, missing int
+	for i := range results {
+		switch results[i].Status {
+		case assets.VerifyMismatch, assets.VerifyError:
+			failed++
+		case assets.VerifyMissing:
+			missing++
+		}
+	}
+
+	if missing > 0 && !assetsVerifyRepair {
+		fmt.Printf("\n%d assets are not cached; run with --repair or 'markata-go assets download'\n", missing)
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d assets failed verification", failed)
+	}
+
+	return nil
+}
+
+// printVerifyResults prints a table of verification results.
+func printVerifyResults(results []assets.VerifyResult) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ASSET\tSTATUS\tDETAIL")
+	fmt.Fprintln(w, "-----\t------\t------")
+
+	counts := make(map[assets.VerifyStatus]int)
+	for i := range results {
+		result := &results[i]
+		counts[result.Status]++
+
+		var detail string
+		switch result.Status {
+		case assets.VerifyOK:
+			algorithms := make([]string, len(result.Checks))
+			for j, check := range result.Checks {
+				algorithms[j] = check.Algorithm
+			}
+			detail = strings.Join(algorithms, ", ") + " match"
+		case assets.VerifyMismatch:
+			detail = strings.Join(result.FailedAlgorithms(), ", ") + " mismatch"
+		case assets.VerifyMissing:
+			detail = "not cached"
+		case assets.VerifyNoHash:
+			detail = "no registered hash"
+		case assets.VerifySkipped:
+			detail = "archive contents cannot be re-hashed"
+		case assets.VerifyError:
+			detail = result.Error.Error()
+		}
+
+		fmt.Fprintf(w, "%s\t%s\t%s\n", result.Asset.Name, result.Status, detail)
+	}
+
+	w.Flush()
+
+	fmt.Println()
+	fmt.Printf("Summary: %d ok, %d mismatched, %d missing, %d without hash, %d skipped, %d errors\n",
+		counts[assets.VerifyOK], counts[assets.VerifyMismatch], counts[assets.VerifyMissing],
+		counts[assets.VerifyNoHash], counts[assets.VerifySkipped], counts[assets.VerifyError])
+}
+
 func runAssetsClean(_ *cobra.Command, _ []string) error {
-	downloader, cacheDir := getAssetsConfig()
+	downloader, cacheDir, err := getAssetsConfig()
+	if err != nil {
+		return err
+	}
 
 	// Check if cache exists
 	if _, err := os.Stat(cacheDir); os.IsNotExist(err) {
@@ -308,6 +308,7 @@ func runScenarioBenchmark(scenario BenchmarkScenario) *BenchmarkResult {
 		lifecycle.StageRender,
 		lifecycle.StageCollect,
 		lifecycle.StageWrite,
+		lifecycle.StageVerify,
 		lifecycle.StageCleanup,
 	}
 
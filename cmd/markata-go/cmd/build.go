@@ -1,12 +1,15 @@
 package cmd
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"os"
+	"os/signal"
 	"path/filepath"
 	"strings"
+	"syscall"
 	"time"
 
 	"github.com/WaylonWalker/markata-go/pkg/buildstats"
@@ -40,6 +43,24 @@ var (
 
 	// buildBenchmarkDetailed prints per-stage benchmark detail.
 	buildBenchmarkDetailed bool
+
+	// buildProfile prints per-stage and per-plugin timings after the build.
+	buildProfile bool
+
+	// buildOnly limits the build to the named plugins for this invocation.
+	buildOnly []string
+
+	// buildNoCache renders every post without reading or writing the build cache.
+	buildNoCache bool
+
+	// buildFailFast stops per-post processing at the first failing post.
+	buildFailFast bool
+
+	// buildWatch keeps rebuilding when sources change.
+	buildWatch bool
+
+	// buildDebounce is how long --watch waits after the last change.
+	buildDebounce time.Duration
 )
 
 // buildCmd represents the build command.
@@ -65,18 +86,42 @@ Clean modes:
                (blogroll feeds, embeds metadata, mentions, webmentions).
                These are expensive to re-fetch from remote servers.
 
+  --no-cache   Ignore the build cache for this build without deleting it.
+               Use 'markata-go cache clear' to delete it.
+
+  --fail-fast  Stop at the first post that fails to load, transform, render,
+               or write. By default every post is processed and all failures
+               are reported together.
+
 	Fast mode:
 	  --fast       Skip minification (JS/CSS), CSS purging, Tailwind rebuilds,
 	               and Pagefind indexing for faster builds.
 	               Useful during development iteration when you don't need
 	               optimized output.
 
+Watch mode:
+  --watch      After building, watch content, templates, config, palettes,
+               and aesthetics and rebuild on change. Content changes rebuild
+               incrementally; the others trigger a full rebuild. Build errors
+               are reported and watching continues.
+  --debounce   Quiet period after the last change before rebuilding
+               (default 200ms).
+
+Plugin subsets:
+  --only a,b   Run every stage but only the named plugins' hooks. Unlike
+               disabled_hooks in the config this applies to one invocation.
+               Unknown names fail with the list of registered plugins.
+
 Example usage:
   markata-go build              # Standard build
   markata-go build --clean      # Clean build cache + output
   markata-go build --clean-all  # Also nuke external plugin caches
   markata-go build --fast       # Skip minification for faster builds
+  markata-go build --no-cache   # Re-render every post
   markata-go build --dry-run    # Show what would be built
+  markata-go build --watch      # Rebuild whenever sources change
+  markata-go build --profile    # Show per-stage and per-plugin timings
+  markata-go build --only glob,load,render_markdown  # Debug a few plugins
   markata-go build -v           # Build with verbose output`,
 	RunE: runBuildCommand,
 }
@@ -91,6 +136,12 @@ func init() {
 	buildCmd.Flags().StringVar(&buildBenchmarkJSON, "benchmark-json", "", "write benchmark details as JSON (use '-' for stdout)")
 	buildCmd.Flags().Lookup("benchmark-json").NoOptDefVal = "-"
 	buildCmd.Flags().BoolVar(&buildBenchmarkDetailed, "benchmark-detailed", false, "print per-stage benchmark resource summaries")
+	buildCmd.Flags().BoolVar(&buildProfile, "profile", false, "print per-stage and per-plugin timings")
+	buildCmd.Flags().BoolVar(&buildNoCache, "no-cache", false, "render every post without reading or writing the build cache")
+	buildCmd.Flags().BoolVar(&buildFailFast, "fail-fast", false, "stop processing posts at the first failure instead of reporting every failing post")
+	buildCmd.Flags().StringSliceVar(&buildOnly, "only", nil, "run only the named plugins in each stage (comma-separated)")
+	buildCmd.Flags().BoolVar(&buildWatch, "watch", false, "rebuild when content, templates, config, palettes, or aesthetics change")
+	buildCmd.Flags().DurationVar(&buildDebounce, "debounce", lifecycle.DefaultWatchDebounce, "with --watch, wait this long after the last change before rebuilding")
 }
 
 func runBuildCommand(_ *cobra.Command, _ []string) error {
@@ -98,20 +149,15 @@ func runBuildCommand(_ *cobra.Command, _ []string) error {
 
 	verbosef("Starting build...")
 
-	// Create the manager
-	m, err := createManager(cfgFile)
-	if err != nil {
-		return fmt.Errorf("initialization failed: %w", err)
+	if buildWatch && buildDryRun {
+		return fmt.Errorf("--watch cannot be combined with --dry-run")
 	}
-	configureLoggerForManager(m)
 
-	// Pass fast mode flag to plugins via config
-	if buildFast {
-		applyFastMode(m)
+	m, err := newBuildManager()
+	if err != nil {
+		return err
 	}
 
-	verbosef("Configuration loaded (output: %s, patterns: %v)", m.Config().OutputDir, m.Config().GlobPatterns)
-
 	// Clean directories if requested
 	if buildCleanAll || buildClean {
 		if err := cleanBuildDirs(m); err != nil {
@@ -124,8 +170,13 @@ func runBuildCommand(_ *cobra.Command, _ []string) error {
 		return runDryBuild(m)
 	}
 
-	// Run the build
-	result, err := runBuild(m)
+	// Run the build, canceling in-flight stages on Ctrl+C
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	if buildWatch {
+		return runBuildWatch(ctx, m)
+	}
+	result, err := runBuildContext(ctx, m)
 	if err != nil {
 		return fmt.Errorf("build failed: %w", err)
 	}
@@ -141,6 +192,9 @@ func runBuildCommand(_ *cobra.Command, _ []string) error {
 	} else {
 		// Print results
 		printBuildResult(result)
+		if buildProfile {
+			printStageProfile(result.Profile)
+		}
 	}
 
 	if buildBenchmarkJSON != "" && buildBenchmarkJSON != "-" {
@@ -149,8 +203,8 @@ func runBuildCommand(_ *cobra.Command, _ []string) error {
 		}
 	}
 
-	// Print warnings
-	if len(result.Warnings) > 0 && verbose {
+	// Print warnings; with --only they may explain missing output
+	if len(result.Warnings) > 0 && (verbose || len(buildOnly) > 0) {
 		errln("\nWarnings:")
 		for _, w := range result.Warnings {
 			errlnf("  - %s", w)
@@ -160,6 +214,89 @@ func runBuildCommand(_ *cobra.Command, _ []string) error {
 	return nil
 }
 
+// newBuildManager creates a manager from the config with the build flags
+// applied.
+func newBuildManager() (*lifecycle.Manager, error) {
+	m, err := createManager(cfgFile)
+	if err != nil {
+		return nil, fmt.Errorf("initialization failed: %w", err)
+	}
+	configureLoggerForManager(m)
+
+	// Pass fast mode flag to plugins via config
+	if buildFast {
+		applyFastMode(m)
+	}
+	if buildNoCache {
+		m.SetBuildCacheEnabled(false)
+	}
+	m.SetFailFast(buildFailFast)
+
+	verbosef("Configuration loaded (output: %s, patterns: %v)", m.Config().OutputDir, m.Config().GlobPatterns)
+
+	if len(buildOnly) > 0 {
+		if err := m.SetOnlyPlugins(buildOnly); err != nil {
+			return nil, fmt.Errorf("--only: %w", err)
+		}
+		verbosef("Running only plugins: %s", strings.Join(m.OnlyPlugins(), ", "))
+	}
+
+	return m, nil
+}
+
+// runBuildWatch builds once, then rebuilds whenever sources change until
+// ctx is canceled. Content changes rebuild incrementally; template, config,
+// palette, and aesthetic changes reload the config and rebuild everything.
+// Failed builds are reported without stopping the watch.
+func runBuildWatch(ctx context.Context, m *lifecycle.Manager) error {
+	startTime := time.Now()
+	result, err := runBuildContext(ctx, m)
+	if err != nil {
+		errlnf("Build failed: %v", err)
+	} else {
+		result.Duration = time.Since(startTime).Seconds()
+		printBuildResult(result)
+	}
+
+	current := m
+	var watcher *lifecycle.Watcher
+	watcher = lifecycle.NewWatcher(m, lifecycle.WatchOptions{
+		Debounce: buildDebounce,
+		Rebuild: func(ctx context.Context, change lifecycle.WatchChange) error {
+			infof("\nRebuilding (%d changed)...", len(change.Paths))
+			if !change.Full {
+				return current.RunIncrementalContext(ctx, change.Paths)
+			}
+			fresh, err := newBuildManager()
+			if err != nil {
+				return err
+			}
+			current = fresh
+			// The reloaded config may move the content, templates, or output
+			watcher.SetManager(current)
+			_, err = runBuildContext(ctx, current)
+			return err
+		},
+		OnRebuild: func(change lifecycle.WatchChange, err error) {
+			if err != nil {
+				errlnf("Rebuild failed: %v", err)
+				return
+			}
+			kind := "Incremental rebuild"
+			if change.Full {
+				kind = "Full rebuild"
+			}
+			outln(colorizeOutput(kind+" completed", currentLogTheme.Component))
+		},
+		OnError: func(err error) {
+			errlnf("Watcher error: %v", err)
+		},
+	})
+
+	infof("Watching for changes (debounce %s, Ctrl+C to stop)...", buildDebounce)
+	return watcher.Run(ctx)
+}
+
 // cleanBuildDirs removes build artifacts before a fresh build.
 // --clean removes output dir and .markata/ build cache (Tier 1).
 // --clean-all additionally removes external plugin caches (Tier 2).
@@ -169,14 +306,8 @@ func cleanBuildDirs(m *lifecycle.Manager) error {
 		outputPath = defaultOutputDir
 	}
 
-	// Build cache directory (.markata/) is a sibling of the output directory.
-	// Without cleaning it, the glob cache retains stale file lists.
-	cacheDir := filepath.Join(outputPath, "..", ".markata")
-	if extra := m.Config().Extra; extra != nil {
-		if dir, ok := extra["cache_dir"].(string); ok && dir != "" {
-			cacheDir = dir
-		}
-	}
+	// Without cleaning the build cache, the glob cache retains stale file lists.
+	cacheDir := m.BuildCacheDir()
 
 	if verbose {
 		verbosef("Cleaning output directory: %s", outputPath)
@@ -215,22 +346,10 @@ func cleanBuildDirs(m *lifecycle.Manager) error {
 func runDryBuild(m *lifecycle.Manager) error {
 	errln("Dry run mode - no files will be written")
 
-	// Run stages up to Write (but not Write)
-	stages := []lifecycle.Stage{
-		lifecycle.StageConfigure,
-		lifecycle.StageValidate,
-		lifecycle.StageGlob,
-		lifecycle.StageLoad,
-		lifecycle.StageTransform,
-		lifecycle.StageRender,
-		lifecycle.StageCollect,
-	}
-
-	for _, stage := range stages {
-		verbosef("Running stage: %s", stage)
-		if err := m.RunTo(stage); err != nil {
-			return fmt.Errorf("stage %s failed: %w", stage, err)
-		}
+	// Run stages up to Collect, then plan the Write stage instead of running it
+	plan, err := m.DryRun()
+	if err != nil {
+		return fmt.Errorf("dry run failed: %w", err)
 	}
 
 	// Print what would be written
@@ -270,10 +389,42 @@ func runDryBuild(m *lifecycle.Manager) error {
 		outputDir = defaultOutputDir
 	}
 	outlnf("\nOutput directory: %s", filepath.Clean(outputDir))
+	printWritePlan(plan)
 
 	return nil
 }
 
+// printWritePlan summarizes the files a build would write. Individual paths
+// are listed in verbose mode.
+func printWritePlan(plan *lifecycle.WritePlan) {
+	outlnf("Files to create: %d", plan.Count(lifecycle.WriteActionCreate))
+	outlnf("Files to overwrite: %d", plan.Count(lifecycle.WriteActionOverwrite))
+	outlnf("Files unchanged: %d", plan.Count(lifecycle.WriteActionUnchanged))
+	if n := plan.Count(lifecycle.WriteActionDelete); n > 0 {
+		outlnf("Files to delete: %d", n)
+	}
+
+	unknown := make([]string, 0)
+	for _, w := range plan.Writes() {
+		if w.Action == lifecycle.WriteActionUnknown {
+			unknown = append(unknown, w.Plugin)
+		}
+	}
+	if len(unknown) > 0 {
+		outlnf("Plugins with unknown writes: %s", strings.Join(unknown, ", "))
+	}
+
+	if verbose {
+		errln("\nPlanned writes:")
+		for _, w := range plan.Writes() {
+			if w.Action == lifecycle.WriteActionUnknown {
+				continue
+			}
+			errlnf("  %-9s %s (%s)", w.Action, w.Path, w.Plugin)
+		}
+	}
+}
+
 // printBuildResult prints a summary of the build result.
 func printBuildResult(result *BuildResult) {
 	outln("\n" + colorizeOutput("Build completed successfully!", currentLogTheme.Component))
@@ -342,6 +493,32 @@ func printStageBenchmarkSummary(summary buildstats.Summary) {
 	}
 }
 
+// printStageProfile prints the wall and CPU time of each stage and of the
+// plugins within it. CPU exceeds wall when a plugin processes posts concurrently.
+func printStageProfile(profile []lifecycle.StageTiming) {
+	if len(profile) == 0 {
+		return
+	}
+
+	outlnf("  %s", buildLabel("Stage profile:"))
+	for _, stage := range profile {
+		name := string(stage.Stage)
+		outlnf("    %s wall=%-8s cpu=%-8s posts=%d",
+			colorizeOutput(name, stageThemeColor(name)),
+			formatDuration(stage.Wall),
+			formatDuration(stage.CPU),
+			stage.Posts,
+		)
+		for _, plugin := range stage.Plugins {
+			line := fmt.Sprintf("      %-24s wall=%-8s cpu=%s", plugin.Plugin, formatDuration(plugin.Wall), formatDuration(plugin.CPU))
+			if plugin.Posts > 0 {
+				line += fmt.Sprintf("  posts=%d", plugin.Posts)
+			}
+			outln(line)
+		}
+	}
+}
+
 func nonZeroStages(stages []buildstats.StageTiming) []buildstats.StageTiming {
 	filtered := make([]buildstats.StageTiming, 0, len(stages))
 	for _, stage := range stages {
@@ -8,8 +8,61 @@ import (
 	"os"
 
 	"github.com/WaylonWalker/markata-go/pkg/config"
+	"github.com/spf13/cobra"
 )
 
+// cacheCmd groups build cache maintenance commands.
+var cacheCmd = &cobra.Command{
+	Use:   "cache",
+	Short: "Manage the build cache",
+	Long: `Commands for working with the persistent build cache.
+
+The build cache stores rendered HTML and other per-post results so unchanged
+posts are not processed again. Cached HTML is keyed on the post's markdown,
+the markdown extension and highlight settings, and the markata-go version,
+so changing any of them re-renders the affected posts automatically.
+
+The cache lives in .markata/ next to your content unless cache_dir is set.`,
+}
+
+// cacheClearCmd deletes the build cache directory.
+var cacheClearCmd = &cobra.Command{
+	Use:   "clear",
+	Short: "Delete the build cache",
+	Long: `Delete the build cache so the next build processes every post.
+
+Unlike 'markata-go build --clean', the output directory is left alone.
+
+Example usage:
+  markata-go cache clear`,
+	Args: cobra.NoArgs,
+	RunE: runCacheClearCommand,
+}
+
+func init() {
+	rootCmd.AddCommand(cacheCmd)
+	cacheCmd.AddCommand(cacheClearCmd)
+}
+
+// runCacheClearCommand removes the build cache directory of the current site.
+func runCacheClearCommand(_ *cobra.Command, _ []string) error {
+	m, err := createManager(cfgFile)
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	cacheDir := m.BuildCacheDir()
+	if _, err := os.Stat(cacheDir); os.IsNotExist(err) {
+		outlnf("No build cache at %s", cacheDir)
+		return nil
+	}
+	if err := os.RemoveAll(cacheDir); err != nil {
+		return fmt.Errorf("failed to clear build cache: %w", err)
+	}
+	outlnf("Cleared build cache at %s", cacheDir)
+	return nil
+}
+
 func configFilesHash(cfgPath string, mergeFiles []string) (string, error) {
 	paths := resolveConfigPaths(cfgPath, mergeFiles)
 	if len(paths) == 0 {
@@ -22,6 +75,12 @@ func configFilesHash(cfgPath string, mergeFiles []string) (string, error) {
 			return "", err
 		}
 	}
+	// The same files resolve differently per environment
+	if env := config.ActiveEnvironment(); env != "" {
+		if _, err := io.WriteString(h, "env="+env); err != nil {
+			return "", err
+		}
+	}
 
 	return hex.EncodeToString(h.Sum(nil)), nil
 }
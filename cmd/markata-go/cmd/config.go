@@ -36,10 +36,12 @@ var configCmd = &cobra.Command{
 
 Subcommands:
 	  show     - Display the resolved configuration
+	  dump     - Dump the effective configuration as JSON
 	  get      - Get a specific configuration value
 	  set      - Set a configuration value
 	  validate - Validate the configuration file
-	  init     - Create a new configuration file`,
+	  init     - Create a new configuration file
+	  schema   - Print a JSON Schema for editor autocompletion`,
 	RunE: runConfigCommand,
 }
 
@@ -63,6 +65,21 @@ Example usage:
 	RunE: runConfigShowCommand,
 }
 
+// configDumpCmd dumps the effective configuration as JSON.
+var configDumpCmd = &cobra.Command{
+	Use:   "dump",
+	Short: "Dump effective configuration as JSON",
+	Long: `Dump the fully resolved configuration as JSON for debugging.
+
+Unlike "config show --json", the dump includes plugin sections that are
+not part of the core config, and unset tri-state options are shown as null.
+
+Example usage:
+  markata-go config dump
+  markata-go config dump -c custom-config.toml > effective.json`,
+	RunE: runConfigDumpCommand,
+}
+
 // configGetCmd gets a specific configuration value.
 var configGetCmd = &cobra.Command{
 	Use:   "get <key>",
@@ -110,6 +127,24 @@ Example usage:
 	RunE: runConfigInitCommand,
 }
 
+// configSchemaCmd writes the JSON Schema for config files.
+var configSchemaCmd = &cobra.Command{
+	Use:   "schema [file]",
+	Short: "Print config JSON Schema",
+	Long: `Print a JSON Schema describing markata-go config files.
+
+Editors use the schema to autocomplete and validate keys in
+markata-go.toml (taplo / Even Better TOML) and markata-go.yaml
+(yaml-language-server). The schema is written to stdout, or to file
+when one is given.
+
+Example usage:
+  markata-go config schema
+  markata-go config schema markata-go.schema.json`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runConfigSchemaCommand,
+}
+
 // configSetCmd sets a configuration value.
 var configSetCmd = &cobra.Command{
 	Use:   "set <key> <value>",
@@ -158,10 +193,12 @@ func init() {
 	rootCmd.AddCommand(configCmd)
 
 	configCmd.AddCommand(configShowCmd)
+	configCmd.AddCommand(configDumpCmd)
 	configCmd.AddCommand(configGetCmd)
 	configCmd.AddCommand(configSetCmd)
 	configCmd.AddCommand(configValidateCmd)
 	configCmd.AddCommand(configInitCmd)
+	configCmd.AddCommand(configSchemaCmd)
 
 	configShowCmd.Flags().StringVar(&configFormat, "format", "yaml", "output format (yaml, json, toml)")
 	configShowCmd.Flags().Bool("json", false, "output as JSON (shorthand for --format=json)")
@@ -229,6 +266,38 @@ func runConfigShowCommand(cmd *cobra.Command, _ []string) error {
 	return nil
 }
 
+func runConfigDumpCommand(_ *cobra.Command, _ []string) error {
+	cfg, _, _, err := loadManagerConfig(cfgFile)
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	data, err := config.Dump(cfg)
+	if err != nil {
+		return err
+	}
+	outln(string(data))
+	return nil
+}
+
+func runConfigSchemaCommand(_ *cobra.Command, args []string) error {
+	data, err := config.JSONSchema()
+	if err != nil {
+		return err
+	}
+	if len(args) == 0 {
+		outln(string(data))
+		return nil
+	}
+
+	path := args[0]
+	if err := os.WriteFile(path, append(data, '\n'), 0o644); err != nil { //nolint:gosec // schema files should be readable
+		return fmt.Errorf("failed to write schema: %w", err)
+	}
+	outlnf("Wrote config schema to %s", path)
+	return nil
+}
+
 func resolveConfigShowFormat(cmd *cobra.Command) (string, error) {
 	format := strings.ToLower(strings.TrimSpace(configFormat))
 	if format == "" {
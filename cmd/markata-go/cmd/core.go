@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"path/filepath"
@@ -40,7 +41,7 @@ func createManager(cfgPath string) (*lifecycle.Manager, error) {
 
 	// Print warnings
 	for _, w := range warnings {
-		if verbose || isLicenseWarning(w) {
+		if verbose || isLicenseWarning(w) || isDeprecationWarning(cfg, w) {
 			warnf("%v", w)
 		}
 	}
@@ -161,6 +162,10 @@ func createManager(cfgPath string) (*lifecycle.Manager, error) {
 func loadManagerConfig(cfgPath string) (cfg *models.Config, configPathUsed string, configPaths []string, err error) {
 	configPathUsed = cfgPath
 
+	// Plugin config sections are valid top-level keys; register them so
+	// validation only flags genuinely unknown keys.
+	config.RegisterPluginSections(plugins.RegisteredPlugins()...)
+
 	if len(mergeConfigFiles) > 0 {
 		basePath := cfgPath
 		if basePath == "" {
@@ -235,6 +240,17 @@ func isLicenseWarning(err error) bool {
 	return vErr.IsWarn && vErr.Field == "license"
 }
 
+// isDeprecationWarning reports whether err warns about a deprecated key cfg
+// was loaded with. These are always shown so old keys get renamed.
+func isDeprecationWarning(cfg *models.Config, err error) bool {
+	var vErr config.ValidationError
+	if !errors.As(err, &vErr) {
+		return false
+	}
+	_, ok := cfg.DeprecatedKeys[vErr.Field]
+	return vErr.IsWarn && ok
+}
+
 // registerDefaultPlugins registers all default plugins to the manager.
 func registerDefaultPlugins(m *lifecycle.Manager) {
 	// Use the centralized DefaultPlugins() to ensure all plugins are registered
@@ -263,6 +279,9 @@ type BuildResult struct {
 	Duration       float64
 	Benchmark      buildstats.Summary
 
+	// Profile holds per-stage and per-plugin timings from the manager.
+	Profile []lifecycle.StageTiming
+
 	// BlogrollStatus holds blogroll feature status
 	BlogrollStatus BlogrollStatus
 }
@@ -280,7 +299,12 @@ type BlogrollStatus struct {
 }
 
 // runBuild executes a full build and returns the result.
-func runBuild(m *lifecycle.Manager) (result *BuildResult, err error) {
+func runBuild(m *lifecycle.Manager) (*BuildResult, error) {
+	return runBuildContext(context.Background(), m)
+}
+
+// runBuildContext executes a full build that stops when ctx is canceled.
+func runBuildContext(ctx context.Context, m *lifecycle.Manager) (result *BuildResult, err error) {
 	profile := buildstats.Start()
 	defer func() {
 		summary := profile.Stop()
@@ -299,6 +323,7 @@ func runBuild(m *lifecycle.Manager) (result *BuildResult, err error) {
 		lifecycle.StageRender,
 		lifecycle.StageCollect,
 		lifecycle.StageWrite,
+		lifecycle.StageVerify,
 		lifecycle.StageCleanup,
 	}
 
@@ -306,7 +331,7 @@ func runBuild(m *lifecycle.Manager) (result *BuildResult, err error) {
 		stageStart := time.Now()
 		buildstats.SetActiveStage(string(stage))
 		verbosef("  [%s] running...", stage)
-		if err := m.RunTo(stage); err != nil {
+		if err := m.RunToContext(ctx, stage); err != nil {
 			buildstats.SetActiveStage("")
 			return nil, fmt.Errorf("stage %s: %w", stage, err)
 		}
@@ -323,7 +348,7 @@ func runBuild(m *lifecycle.Manager) (result *BuildResult, err error) {
 			case lifecycle.StageCollect:
 				verbosef("  [%s] collected %d feeds", stage, len(m.Feeds()))
 			case lifecycle.StageConfigure, lifecycle.StageValidate, lifecycle.StageTransform,
-				lifecycle.StageRender, lifecycle.StageWrite, lifecycle.StageCleanup:
+				lifecycle.StageRender, lifecycle.StageWrite, lifecycle.StageVerify, lifecycle.StageCleanup:
 				// No extra logging for these stages
 			}
 		}
@@ -333,6 +358,7 @@ func runBuild(m *lifecycle.Manager) (result *BuildResult, err error) {
 	result = &BuildResult{
 		PostsProcessed: len(m.Posts()),
 		FeedsGenerated: len(m.Feeds()),
+		Profile:        m.Profile(),
 	}
 
 	// Collect blogroll status
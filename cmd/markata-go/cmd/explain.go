@@ -126,7 +126,7 @@ live reload development server, and extensive customization options.
 
 ## Key Features
 
-- **Plugin Architecture**: 9-stage lifecycle with 15+ built-in plugins
+- **Plugin Architecture**: 10-stage lifecycle with 15+ built-in plugins
 - **Feed System**: Automatic RSS/Atom/JSON feeds with filtering
 - **Live Reload**: Development server with automatic rebuilds
 - **Markdown Extensions**: Tables, syntax highlighting, wikilinks, admonitions
@@ -755,6 +755,17 @@ Plugins can specify execution priority within their stage:
 
 Default priority is 100. Use lower values to run early, higher to run late.
 
+## Plugin Dependencies
+
+Plugins that must run after other plugins in a stage can name them:
+
+    type DependencyPlugin interface {
+        DependsOn(stage Stage) []string  // plugins that must run first
+    }
+
+Dependencies are honored first; priorities break ties. Cycles fail the
+stage with an error naming the plugins involved.
+
 ## Plugin Configuration
 
 Plugins read from config sections:
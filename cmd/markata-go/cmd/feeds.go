@@ -0,0 +1,117 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/WaylonWalker/markata-go/pkg/lifecycle"
+	"github.com/WaylonWalker/markata-go/pkg/services"
+	"github.com/spf13/cobra"
+)
+
+// feedsCmd groups feed maintenance commands.
+var feedsCmd = &cobra.Command{
+	Use:   "feeds",
+	Short: "Check configured feeds",
+	Long: `Commands for working with configured feeds.
+
+Use 'markata-go list feeds' to list feeds and their posts.`,
+}
+
+// feedsCheckJSON selects JSON output for feeds check.
+var feedsCheckJSON bool
+
+// feedsCheckCmd validates feed filters and sorts without building.
+var feedsCheckCmd = &cobra.Command{
+	Use:   "check",
+	Short: "Check feed filters and sort fields",
+	Long: `Check every configured feed's filter and sort before a build.
+
+Posts are loaded and transformed, but feeds are not collected, so a feed
+with a broken filter is reported instead of failing the command.
+
+Reported problems:
+  - error:   the filter expression has a syntax error
+  - warning: the filter reads a field that no post has
+  - warning: the sort field is not a post field and is not set on any post
+  - warning: the filter matches none of the loaded posts
+
+The command exits with an error if any feed has an error.
+
+Example usage:
+  markata-go feeds check
+  markata-go feeds check --json`,
+	Args: cobra.NoArgs,
+	RunE: runFeedsCheckCommand,
+}
+
+func init() {
+	rootCmd.AddCommand(feedsCmd)
+	feedsCmd.AddCommand(feedsCheckCmd)
+	feedsCheckCmd.Flags().BoolVar(&feedsCheckJSON, "json", false, "Output as JSON")
+}
+
+// feedIssueJSON is the JSON form of a services.FeedIssue.
+type feedIssueJSON struct {
+	Slug     string `json:"slug"`
+	Severity string `json:"severity"`
+	Field    string `json:"field"`
+	Message  string `json:"message"`
+}
+
+// runFeedsCheckCommand loads posts and reports feed configuration problems.
+func runFeedsCheckCommand(cmd *cobra.Command, _ []string) error {
+	manager, err := createManager(cfgFile)
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	if err := manager.RunTo(lifecycle.StageTransform); err != nil {
+		return fmt.Errorf("failed to load posts: %w", err)
+	}
+
+	issues, err := services.NewApp(manager).Feeds.Validate(cmd.Context())
+	if err != nil {
+		return err
+	}
+
+	hasErrors := false
+	for i := range issues {
+		if issues[i].Severity == services.FeedIssueError {
+			hasErrors = true
+		}
+	}
+
+	if feedsCheckJSON {
+		out := make([]feedIssueJSON, len(issues))
+		for i, issue := range issues {
+			out[i] = feedIssueJSON{
+				Slug:     issue.Slug,
+				Severity: string(issue.Severity),
+				Field:    issue.Field,
+				Message:  issue.Message,
+			}
+		}
+		data, err := json.MarshalIndent(out, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal JSON: %w", err)
+		}
+		outln(string(data))
+	} else {
+		for i := range issues {
+			issue := &issues[i]
+			slug := issue.Slug
+			if slug == "" {
+				slug = "(home)"
+			}
+			outlnf("%s [feed %s] %s: %s", issue.Severity, slug, issue.Field, issue.Message)
+		}
+		if len(issues) == 0 {
+			outln("All feeds look good.")
+		}
+	}
+
+	if hasErrors {
+		return fmt.Errorf("feed check found errors")
+	}
+	return nil
+}
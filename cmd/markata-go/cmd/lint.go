@@ -9,7 +9,9 @@ import (
 	"strings"
 
 	"github.com/WaylonWalker/markata-go/pkg/config"
+	"github.com/WaylonWalker/markata-go/pkg/diagnostics"
 	"github.com/WaylonWalker/markata-go/pkg/lint"
+	"github.com/WaylonWalker/markata-go/pkg/plugins"
 	"github.com/bmatcuk/doublestar/v4"
 	"github.com/spf13/cobra"
 )
@@ -37,6 +39,13 @@ The linter detects:
 When run without arguments, lints all files matching the configured glob patterns
 (defaults to **/*.md). Explicit file arguments override config patterns.
 
+Rules can be disabled or re-graded in [markata-go.diagnostics.rules] or in
+a .markata-lint.toml file in the current directory:
+
+  [rules]
+  h1-in-content = { enabled = false }
+  missing-alt-text = { severity = "info" }
+
 Use --fix to automatically fix detected issues.
 Use --dry-run to see which files would be checked without actually linting them.
 
@@ -101,9 +110,11 @@ func runLintCommand(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
+	opts := diagnostics.Options{Config: loadLintDiagnosticsConfig()}
+
 	stats := &lintStats{}
 	for _, file := range files {
-		processFile(file, stats)
+		processFile(file, opts, stats)
 	}
 	processEncryptionPolicyLint(stats)
 
@@ -203,6 +214,11 @@ func getFilesFromConfig() ([]string, error) {
 	// Expand glob patterns
 	fileSet := make(map[string]struct{})
 	for _, pattern := range patterns {
+		if negated, ok := plugins.NegatedGlobPattern(pattern); ok {
+			plugins.RemoveGlobMatches(fileSet, absBaseDir, negated)
+			continue
+		}
+
 		fullPattern := pattern
 		if !filepath.IsAbs(pattern) {
 			fullPattern = filepath.Join(absBaseDir, pattern)
@@ -346,8 +362,34 @@ func expandGlobPatterns(args []string) ([]string, error) {
 	return files, nil
 }
 
+// loadLintDiagnosticsConfig loads the rule overrides from the site config's
+// [markata-go.diagnostics] section, layered under .markata-lint.toml, and
+// warns about unknown rules. Load errors are warned about and ignored.
+func loadLintDiagnosticsConfig() *diagnostics.Config {
+	var siteRules *diagnostics.Config
+	if cfg, err := config.Load(cfgFile); err == nil {
+		if raw, ok := cfg.Extra["diagnostics"].(map[string]any); ok {
+			siteRules, err = diagnostics.ConfigFromMap(raw)
+			if err != nil {
+				warnf("%v", err)
+			}
+		}
+	}
+
+	fileRules, err := diagnostics.LoadConfigFile(diagnostics.LintConfigFile)
+	if err != nil {
+		warnf("%v", err)
+	}
+
+	rules := siteRules.Merge(fileRules)
+	for _, warning := range rules.Validate() {
+		warnf("%s", warning)
+	}
+	return rules
+}
+
 // processFile lints a single file and updates stats.
-func processFile(file string, stats *lintStats) {
+func processFile(file string, opts diagnostics.Options, stats *lintStats) {
 	// Skip non-markdown files
 	ext := filepath.Ext(file)
 	if ext != ".md" && ext != ".markdown" {
@@ -364,9 +406,9 @@ func processFile(file string, stats *lintStats) {
 
 	var result *lint.Result
 	if lintFix {
-		result = lint.Fix(file, string(content))
+		result = lint.FixWithOptions(file, string(content), opts)
 	} else {
-		result = lint.Lint(file, string(content))
+		result = lint.WithOptions(file, string(content), opts)
 	}
 
 	if len(result.Issues) == 0 {
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"log/slog"
 	"path/filepath"
 	"strings"
 
@@ -12,8 +13,11 @@ import (
 
 var currentLogTheme = logging.DefaultTheme()
 
-func configureCommandLogger(theme logging.Theme) error {
-	format, err := logging.ParseFormat(logFormat)
+// configureCommandLogger routes the standard logger through the themed
+// log writer. extra is the [markata-go] config, which may set log_format
+// and log_level; the flags take precedence.
+func configureCommandLogger(theme logging.Theme, extra map[string]any) error {
+	format, level, err := logSettings(extra)
 	if err != nil {
 		return err
 	}
@@ -26,19 +30,75 @@ func configureCommandLogger(theme logging.Theme) error {
 		NoColor:    noColor,
 		IsTTY:      errorOutputIsTerminal(),
 		Theme:      theme,
+		Level:      level,
 	})
 
 	return nil
 }
 
+// configureLoggerForManager themes the log output with the site palette
+// and gives m a structured build logger in the configured format.
 func configureLoggerForManager(m *lifecycle.Manager) {
 	theme := logging.DefaultTheme()
 	if resolved, ok := resolveLoggerTheme(m); ok {
 		theme = resolved
 	}
-	if err := configureCommandLogger(theme); err != nil {
+	extra := managerConfigExtra(m)
+	if err := configureCommandLogger(theme, extra); err != nil {
 		errlnf("Warning: failed to configure themed logging: %v", err)
+		return
 	}
+
+	format, level, err := logSettings(extra)
+	if err != nil {
+		return
+	}
+	if format == logging.FormatJSON {
+		m.SetLogger(lifecycle.NewJSONLogger(errWriter(), level))
+	} else {
+		m.SetLogger(lifecycle.NewTextLogger(level))
+	}
+}
+
+// logSettings returns the log format and level from the --log-format and
+// --log-level flags, or from log_format and log_level in extra when the
+// flags are not given. Without a level, --verbose means debug.
+func logSettings(extra map[string]any) (logging.Format, slog.Level, error) {
+	rawFormat, rawLevel := logFormat, logLevel
+	changed := func(name string) bool {
+		cmd := activeCmd()
+		return cmd != nil && cmd.Flags().Changed(name)
+	}
+	if v, ok := extra["log_format"].(string); ok && v != "" && !changed("log-format") {
+		rawFormat = v
+	}
+	if v, ok := extra["log_level"].(string); ok && v != "" && !changed("log-level") {
+		rawLevel = v
+	}
+	if rawLevel == "" && verbose {
+		rawLevel = "debug"
+	}
+
+	format, err := logging.ParseFormat(rawFormat)
+	if err != nil {
+		return "", 0, err
+	}
+	level, err := lifecycle.ParseLogLevel(rawLevel)
+	if err != nil {
+		return "", 0, err
+	}
+	return format, level, nil
+}
+
+// managerConfigExtra returns the [markata-go] extra keys of m's config.
+func managerConfigExtra(m *lifecycle.Manager) map[string]any {
+	if m == nil || m.Config() == nil {
+		return nil
+	}
+	if modelsConfig, ok := m.Config().Extra["models_config"].(*models.Config); ok && modelsConfig != nil {
+		return modelsConfig.Extra
+	}
+	return nil
 }
 
 func resolveLoggerTheme(m *lifecycle.Manager) (logging.Theme, bool) {
@@ -32,6 +32,10 @@ var (
 	// migrateReport is the path to write a migration report file.
 	migrateReport string
 
+	// migrateRewriteTemplates rewrites templates in place with the
+	// automatic Jinja2 and variable name migrations.
+	migrateRewriteTemplates bool
+
 	// compareOldDir is the old site directory for comparison.
 	compareOldDir string
 
@@ -61,7 +65,8 @@ Example usage:
   markata-go migrate                    # Analyze and show migration report
   markata-go migrate --dry-run          # Show what would change
   markata-go migrate -o markata-go.toml # Write migrated config
-  markata-go migrate -i pyproject.toml  # Use specific input file`,
+  markata-go migrate -i pyproject.toml  # Use specific input file
+  markata-go migrate --rewrite-templates # Also rewrite templates in place`,
 	RunE: runMigrateCommand,
 }
 
@@ -103,16 +108,47 @@ var migrateTemplatesCmd = &cobra.Command{
 
 Identifies:
   - Unsupported Jinja2 features (macros, do statements, etc.)
-  - Variable name changes (post.markata -> config)
+  - Variable name changes (markata.articles -> posts, post.content -> body)
   - Python expression usage
   - Filter syntax differences
 
+With --rewrite, templates are rewritten in place first: known variable
+names are renamed and common Jinja2 constructs converted. References with
+no markata-go equivalent are left as written for manual review.
+
 Example:
   markata-go migrate templates
-  markata-go migrate templates ./templates`,
+  markata-go migrate templates ./templates
+  markata-go migrate templates --rewrite --dry-run  # Preview rewrites
+  markata-go migrate templates --rewrite`,
 	RunE: runMigrateTemplatesCommand,
 }
 
+// migrateCheckCmd checks a whole project.
+var migrateCheckCmd = &cobra.Command{
+	Use:   "check [dir]",
+	Short: "Check a whole Python markata project",
+	Long: `Scan a Python markata project and report everything that needs
+attention before migrating, grouped by file and severity.
+
+Checks:
+  - Configuration changes and unsupported plugins
+  - Filter expressions that need migrating
+  - Template compatibility issues
+  - Frontmatter of a sample of posts
+
+Example usage:
+  markata-go migrate check
+  markata-go migrate check ~/sites/blog
+  markata-go migrate check --json
+
+Exit codes:
+  0 = no blocking issues
+  2 = blocking issues found`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runMigrateCheckCommand,
+}
+
 // migrateCompareCmd compares two site output directories.
 var migrateCompareCmd = &cobra.Command{
 	Use:   "compare",
@@ -144,6 +180,7 @@ func init() {
 	migrateCmd.AddCommand(migrateFilterCmd)
 	migrateCmd.AddCommand(migrateTemplatesCmd)
 	migrateCmd.AddCommand(migrateCompareCmd)
+	migrateCmd.AddCommand(migrateCheckCmd)
 
 	// Flags for migrate command
 	migrateCmd.Flags().StringVarP(&migrateInput, "input", "i", "", "input config file (default: auto-detect)")
@@ -152,6 +189,7 @@ func init() {
 	migrateCmd.Flags().StringVarP(&migrateFormat, "format", "f", "toml", "output format (toml, yaml)")
 	migrateCmd.Flags().BoolVar(&migrateJSON, "json", false, "output results as JSON")
 	migrateCmd.Flags().StringVar(&migrateReport, "report", "", "write migration report to file")
+	migrateCmd.Flags().BoolVar(&migrateRewriteTemplates, "rewrite-templates", false, "rewrite templates in place with the automatic migrations")
 
 	// Flags for config subcommand
 	migrateConfigCmd.Flags().StringVarP(&migrateInput, "input", "i", "", "input config file (default: auto-detect)")
@@ -166,6 +204,12 @@ func init() {
 
 	// Flags for templates subcommand
 	migrateTemplatesCmd.Flags().BoolVar(&migrateJSON, "json", false, "output results as JSON")
+	migrateTemplatesCmd.Flags().BoolVar(&migrateRewriteTemplates, "rewrite", false, "rewrite templates in place with the automatic migrations")
+	migrateTemplatesCmd.Flags().BoolVarP(&migrateDryRun, "dry-run", "n", false, "with --rewrite, show rewrites without writing")
+
+	// Flags for check subcommand
+	migrateCheckCmd.Flags().BoolVar(&migrateJSON, "json", false, "output results as JSON")
+	migrateCheckCmd.Flags().StringVar(&migrateReport, "report", "", "write check report to file")
 
 	// Flags for compare subcommand
 	migrateCompareCmd.Flags().StringVar(&compareOldDir, "old", "", "old site output directory (required)")
@@ -215,6 +259,16 @@ func runMigrateCommand(_ *cobra.Command, _ []string) error {
 	// Check templates if directory exists
 	templatesDir := defaultTemplatesDir
 	if info, err := os.Stat(templatesDir); err == nil && info.IsDir() {
+		rewrites, err := migrate.MigrateTemplates(templatesDir, migrateRewriteTemplates && !migrateDryRun)
+		if err != nil {
+			result.Warnings = append(result.Warnings, migrate.Warning{
+				Category: "template",
+				Message:  fmt.Sprintf("Failed to rewrite templates: %v", err),
+			})
+		} else {
+			result.TemplateRewrites = rewrites
+		}
+
 		templateIssues, err := migrate.CheckTemplates(templatesDir)
 		if err != nil {
 			result.Warnings = append(result.Warnings, migrate.Warning{
@@ -387,6 +441,15 @@ func runMigrateTemplatesCommand(_ *cobra.Command, args []string) error {
 		return fmt.Errorf("not a directory: %s", templatesDir)
 	}
 
+	// Rewrite templates first so the check reports what is left
+	var rewrites []migrate.TemplateRewrite
+	if migrateRewriteTemplates {
+		rewrites, err = migrate.MigrateTemplates(templatesDir, !migrateDryRun)
+		if err != nil {
+			return fmt.Errorf("failed to rewrite templates: %w", err)
+		}
+	}
+
 	// Check templates
 	issues, err := migrate.CheckTemplates(templatesDir)
 	if err != nil {
@@ -394,11 +457,19 @@ func runMigrateTemplatesCommand(_ *cobra.Command, args []string) error {
 	}
 
 	if migrateJSON {
-		return outputJSON(map[string]interface{}{
+		data := map[string]interface{}{
 			"templates_dir": templatesDir,
 			"issues":        issues,
 			"issues_count":  len(issues),
-		})
+		}
+		if migrateRewriteTemplates {
+			data["rewrites"] = rewrites
+		}
+		return outputJSON(data)
+	}
+
+	if migrateRewriteTemplates {
+		printTemplateRewrites(rewrites)
 	}
 
 	if len(issues) == 0 {
@@ -492,6 +563,42 @@ func runMigrateCompareCommand(_ *cobra.Command, _ []string) error {
 	return nil
 }
 
+// runMigrateCheckCommand checks a whole project for migration issues.
+func runMigrateCheckCommand(_ *cobra.Command, args []string) error {
+	dir := "."
+	if len(args) > 0 {
+		dir = args[0]
+	}
+
+	report, err := migrate.CheckProject(dir)
+	if err != nil {
+		return fmt.Errorf("project check failed: %w", err)
+	}
+
+	if migrateJSON {
+		if err := outputJSON(report.JSONReport()); err != nil {
+			return err
+		}
+	} else {
+		text := report.Report()
+		fmt.Print(text)
+
+		if migrateReport != "" {
+			if err := os.WriteFile(migrateReport, []byte(text), 0o600); err != nil {
+				return fmt.Errorf("failed to write report: %w", err)
+			}
+			fmt.Printf("\nReport written to: %s\n", migrateReport)
+		}
+	}
+
+	// Exit non-zero when blocking issues exist
+	if report.HasErrors() {
+		return newExitCodeError(report.ExitCode(), nil)
+	}
+
+	return nil
+}
+
 // findInputConfig finds the input configuration file.
 func findInputConfig() (string, error) {
 	if migrateInput != "" {
@@ -501,21 +608,33 @@ func findInputConfig() (string, error) {
 		return migrateInput, nil
 	}
 
-	// Try common Python markata config files
-	candidates := []string{
-		"markata.toml",
-		"pyproject.toml",
-		"markata.yaml",
-		"markata.yml",
+	return migrate.FindConfig(".")
+}
+
+// printTemplateRewrites prints the rewrites made to each template.
+func printTemplateRewrites(rewrites []migrate.TemplateRewrite) {
+	if len(rewrites) == 0 {
+		fmt.Println("No template rewrites needed")
+		fmt.Println()
+		return
 	}
 
-	for _, candidate := range candidates {
-		if _, err := os.Stat(candidate); err == nil {
-			return candidate, nil
+	fmt.Println("REWRITES:")
+	for _, tr := range rewrites {
+		action := "would rewrite"
+		if tr.Written {
+			action = "rewrote"
+		}
+		fmt.Printf("  %s (%s)\n", tr.File, action)
+		for _, c := range tr.Changes {
+			if c.Manual {
+				fmt.Printf("    line %d: manual review: %s\n", c.Line, c.Description)
+			} else {
+				fmt.Printf("    line %d: %s\n", c.Line, c.Description)
+			}
 		}
 	}
-
-	return "", fmt.Errorf("no Python markata config file found (tried: %v)", candidates)
+	fmt.Println()
 }
 
 // printTemplateIssue prints a template issue.
@@ -18,11 +18,6 @@ import (
 
 // Common string constants to avoid goconst warnings.
 const (
-	paletteFormatCSS      = "css"
-	paletteFormatSCSS     = "scss"
-	paletteFormatJSON     = "json"
-	paletteFormatTailwind = "tailwind"
-
 	// Default palette colors (shared with theme.go).
 	defaultTextColor = "#e0e0e0"
 	defaultBgColor   = "#1e1e1e"
@@ -41,6 +36,7 @@ Subcommands:
   list     - List available palettes
   info     - Show palette details
   check    - Validate palette contrast ratios
+  contrast - Suggest fixes for failing contrast pairs
   preview  - Generate HTML preview
   export   - Export palette to different formats
   new      - Create a new palette
@@ -112,8 +108,11 @@ var paletteExportCmd = &cobra.Command{
 Supported formats:
   css      - CSS custom properties
   scss     - SCSS/Sass variables
-  json     - JSON with resolved colors
-  tailwind - Tailwind CSS config
+  json     - Flat JSON map of every color to its resolved hex value
+  tailwind - Tailwind CSS theme.extend.colors config
+
+All color references are resolved before export; a reference to an
+undefined color fails with the name of the offending entry.
 
 Example usage:
   markata-go palette export catppuccin-mocha --format css
@@ -255,7 +254,7 @@ func init() {
 
 	// Export subcommand
 	paletteCmd.AddCommand(paletteExportCmd)
-	paletteExportCmd.Flags().StringVarP(&paletteFormat, "format", "f", "css", "Export format (css, scss, json, tailwind)")
+	paletteExportCmd.Flags().StringVarP(&paletteFormat, "format", "f", palettes.ExportFormatCSS, "Export format (css, scss, json, tailwind)")
 	paletteExportCmd.Flags().StringVarP(&paletteOutput, "output", "o", "", "Output file (default: stdout)")
 
 	// Preview subcommand
@@ -494,22 +493,9 @@ func runPaletteExportCommand(_ *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to load palette: %w", err)
 	}
 
-	var output string
-	switch paletteFormat {
-	case paletteFormatCSS:
-		output = p.GenerateCSS()
-	case paletteFormatSCSS:
-		output = p.GenerateSCSS()
-	case paletteFormatJSON:
-		data, err := p.ExportJSON(true)
-		if err != nil {
-			return fmt.Errorf("failed to generate JSON: %w", err)
-		}
-		output = string(data)
-	case paletteFormatTailwind:
-		output = p.GenerateTailwind()
-	default:
-		return fmt.Errorf("unknown format: %s (supported: css, scss, json, tailwind)", paletteFormat)
+	output, err := p.Export(paletteFormat)
+	if err != nil {
+		return fmt.Errorf("failed to export palette: %w", err)
 	}
 
 	if paletteOutput != "" {
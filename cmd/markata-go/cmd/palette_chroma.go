@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/WaylonWalker/markata-go/pkg/palettes"
+	"github.com/spf13/cobra"
+)
+
+// paletteChromaOutput is the output file for the generated Chroma theme.
+var paletteChromaOutput string
+
+// paletteChromaCmd exports the Chroma theme derived from a palette.
+var paletteChromaCmd = &cobra.Command{
+	Use:   "chroma <name>",
+	Short: "Export the syntax highlighting theme derived from a palette",
+	Long: `Export the Chroma syntax highlighting theme derived from a palette.
+
+The palette's code colors (code-bg, code-text, code-comment, code-keyword,
+code-string, code-number, code-function, code-type, code-operator) are
+written as a Chroma XML style. Token colors without enough contrast against
+the code background are adjusted, keeping their hue.
+
+Example usage:
+  markata-go palette chroma catppuccin-mocha
+  markata-go palette chroma nord-dark -o styles/nord-dark.xml`,
+	Args: cobra.ExactArgs(1),
+	RunE: runPaletteChromaCommand,
+}
+
+func init() {
+	paletteCmd.AddCommand(paletteChromaCmd)
+	paletteChromaCmd.Flags().StringVarP(&paletteChromaOutput, "output", "o", "", "Output file (default: stdout)")
+}
+
+// runPaletteChromaCommand prints or writes the palette's Chroma theme.
+func runPaletteChromaCommand(_ *cobra.Command, args []string) error {
+	p, err := palettes.NewLoader().Load(args[0])
+	if err != nil {
+		return fmt.Errorf("failed to load palette: %w", err)
+	}
+
+	theme, err := palettes.GenerateChromaTheme(p)
+	if err != nil {
+		return fmt.Errorf("failed to generate chroma theme: %w", err)
+	}
+
+	if paletteChromaOutput == "" {
+		fmt.Print(theme)
+		return nil
+	}
+	if err := os.WriteFile(paletteChromaOutput, []byte(theme), 0o644); err != nil { //nolint:gosec // exported files should be readable
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+	fmt.Printf("Exported to %s\n", paletteChromaOutput)
+	return nil
+}
@@ -0,0 +1,130 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/WaylonWalker/markata-go/pkg/palettes"
+	"github.com/spf13/cobra"
+)
+
+// paletteContrastCmd suggests fixes for failing contrast pairs.
+var paletteContrastCmd = &cobra.Command{
+	Use:   "contrast <name>",
+	Short: "Suggest fixes for failing contrast pairs",
+	Long: `Suggest color fixes for the contrast checks a palette fails.
+
+For each failing pair, the smallest OKLCH lightness change that reaches the
+pair's required ratio is suggested, keeping hue and chroma. Whichever of the
+foreground or background needs to move least is adjusted.
+
+Example usage:
+  markata-go palette contrast catppuccin-latte
+  markata-go palette contrast catppuccin-latte --strict  # Include AAA checks
+  markata-go palette contrast catppuccin-latte --json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runPaletteContrastCommand,
+}
+
+// contrastFix is a suggested fix for one failing contrast check.
+type contrastFix struct {
+	Foreground string  `json:"foreground"`
+	Background string  `json:"background"`
+	Ratio      float64 `json:"ratio"`
+	Required   float64 `json:"required"`
+	Level      string  `json:"level"`
+
+	// Adjusted is "foreground" or "background", or empty when no fix exists
+	Adjusted string  `json:"adjusted,omitempty"`
+	Before   string  `json:"before,omitempty"`
+	After    string  `json:"after,omitempty"`
+	NewRatio float64 `json:"new_ratio,omitempty"`
+}
+
+func init() {
+	paletteCmd.AddCommand(paletteContrastCmd)
+	paletteContrastCmd.Flags().BoolVar(&paletteStrict, "strict", false, "Include AAA level checks")
+	paletteContrastCmd.Flags().BoolVar(&paletteJSON, "json", false, "Output as JSON")
+}
+
+// runPaletteContrastCommand prints suggested fixes for failing contrast pairs.
+func runPaletteContrastCommand(_ *cobra.Command, args []string) error {
+	p, err := palettes.NewLoader().Load(args[0])
+	if err != nil {
+		return fmt.Errorf("failed to load palette: %w", err)
+	}
+
+	var results []palettes.ContrastCheck
+	if paletteStrict {
+		results = p.CheckContrastStrict()
+	} else {
+		results = p.CheckContrast()
+	}
+
+	fixes := []contrastFix{}
+	for i := range results {
+		r := &results[i]
+		if r.Passed || r.ForegroundHex == "" || r.BackgroundHex == "" {
+			continue
+		}
+		fixes = append(fixes, suggestContrastFix(r))
+	}
+
+	if paletteJSON {
+		data, err := json.MarshalIndent(fixes, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal JSON: %w", err)
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+
+	fmt.Printf("\nContrast fixes for palette: %s\n\n", p.Name)
+	if len(fixes) == 0 {
+		fmt.Println("All contrast checks pass, nothing to fix.")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "PAIR\tRATIO\tNEED\tADJUST\tBEFORE\tAFTER\tNEW RATIO")
+	fmt.Fprintln(w, "----\t-----\t----\t------\t------\t-----\t---------")
+	for i := range fixes {
+		fix := &fixes[i]
+		pair := fix.Foreground + " on " + fix.Background
+		if fix.Adjusted == "" {
+			fmt.Fprintf(w, "%s\t%.2f:1\t%.1f:1\t-\t-\t-\tno fix found\n", pair, fix.Ratio, fix.Required)
+			continue
+		}
+		fmt.Fprintf(w, "%s\t%.2f:1\t%.1f:1\t%s\t%s\t%s\t%.2f:1\n",
+			pair, fix.Ratio, fix.Required, fix.Adjusted, fix.Before, fix.After, fix.NewRatio)
+	}
+	return w.Flush()
+}
+
+// suggestContrastFix builds the fix for a failing check.
+func suggestContrastFix(r *palettes.ContrastCheck) contrastFix {
+	fix := contrastFix{
+		Foreground: r.Foreground,
+		Background: r.Background,
+		Ratio:      r.Ratio,
+		Required:   r.Required,
+		Level:      r.Level,
+	}
+
+	adjusted, adjustedBackground := palettes.SuggestFix(r.ForegroundHex, r.BackgroundHex, r.Required)
+	if adjusted == "" {
+		return fix
+	}
+
+	fg, bg := adjusted, r.BackgroundHex
+	fix.Adjusted, fix.Before = "foreground", r.ForegroundHex
+	if adjustedBackground {
+		fg, bg = r.ForegroundHex, adjusted
+		fix.Adjusted, fix.Before = "background", r.BackgroundHex
+	}
+	fix.After = adjusted
+	fix.NewRatio, _ = palettes.ContrastRatioFromHex(fg, bg) //nolint:errcheck // both colors were already parsed
+	return fix
+}
@@ -0,0 +1,113 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/WaylonWalker/markata-go/pkg/palettes"
+	"github.com/spf13/cobra"
+)
+
+// paletteRepairLevel is the WCAG level palette repair targets.
+var paletteRepairLevel string
+
+// paletteRepairCmd adjusts a palette until its contrast checks pass.
+var paletteRepairCmd = &cobra.Command{
+	Use:   "repair <name>",
+	Short: "Adjust a palette so its contrast checks pass",
+	Long: `Adjust a palette's colors until every contrast check for a WCAG level passes.
+
+Colors are changed by OKLCH lightness only, so hue and chroma stay the same.
+Foreground text colors are adjusted first; a background changes only when its
+foreground cannot reach the ratio. A fix never breaks a check that passed.
+Pairs that would need a large change are left alone and reported as unfixed.
+
+Without --output the changes are only reported. With --output the repaired
+palette is written as TOML.
+
+Example usage:
+  markata-go palette repair my-theme
+  markata-go palette repair my-theme --level AAA -o palettes/my-theme-aaa.toml
+  markata-go palette repair my-theme --json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runPaletteRepairCommand,
+}
+
+func init() {
+	paletteCmd.AddCommand(paletteRepairCmd)
+	paletteRepairCmd.Flags().StringVar(&paletteRepairLevel, "level", "AA", "WCAG level to reach (AA or AAA)")
+	paletteRepairCmd.Flags().StringVarP(&paletteOutput, "output", "o", "", "Write the repaired palette to this TOML file")
+	paletteRepairCmd.Flags().BoolVar(&paletteJSON, "json", false, "Output the changes as JSON")
+}
+
+// runPaletteRepairCommand repairs a palette and reports the changes.
+func runPaletteRepairCommand(_ *cobra.Command, args []string) error {
+	level := strings.ToUpper(strings.TrimSpace(paletteRepairLevel))
+	if level != string(palettes.WCAGLevelAA) && level != string(palettes.WCAGLevelAAA) {
+		return fmt.Errorf("invalid --level %q: must be AA or AAA", paletteRepairLevel)
+	}
+
+	p, err := palettes.NewLoader().Load(args[0])
+	if err != nil {
+		return fmt.Errorf("failed to load palette: %w", err)
+	}
+
+	repaired, changes := p.AutoRepair(level)
+	if changes == nil {
+		changes = []palettes.Change{}
+	}
+
+	if paletteOutput != "" {
+		if err := palettes.SavePaletteToFile(repaired, paletteOutput); err != nil {
+			return fmt.Errorf("failed to write palette: %w", err)
+		}
+	}
+
+	if paletteJSON {
+		data, err := json.MarshalIndent(changes, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal JSON: %w", err)
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+
+	fmt.Printf("\nContrast repair for palette: %s (WCAG %s)\n\n", p.Name, level)
+	if len(changes) == 0 {
+		fmt.Println("All contrast checks pass, nothing to repair.")
+		return nil
+	}
+
+	unfixed := 0
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "PAIR\tNEED\tCOLOR\tBEFORE\tAFTER\tRATIO")
+	fmt.Fprintln(w, "----\t----\t-----\t------\t-----\t-----")
+	for i := range changes {
+		c := &changes[i]
+		pair := c.Foreground + " on " + c.Background
+		if c.Unfixed {
+			unfixed++
+			fmt.Fprintf(w, "%s\t%.1f:1\t-\t-\t-\tunfixed (%.2f:1)\n", pair, c.Required, c.Ratio)
+			continue
+		}
+		fmt.Fprintf(w, "%s\t%.1f:1\t%s\t%s\t%s\t%.2f:1 -> %.2f:1\n",
+			pair, c.Required, c.Color, c.Before, c.After, c.Ratio, c.NewRatio)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Println()
+	if unfixed > 0 {
+		fmt.Printf("%d pair(s) need more than a small lightness change; adjust them by hand.\n", unfixed)
+	}
+	if paletteOutput != "" {
+		fmt.Printf("Wrote repaired palette to %s\n", paletteOutput)
+	} else {
+		fmt.Println("Use --output to write the repaired palette.")
+	}
+	return nil
+}
@@ -198,3 +198,31 @@ func containsHelper(s, substr string) bool {
 	}
 	return false
 }
+
+func TestSuggestContrastFix(t *testing.T) {
+	check := palettes.ContrastCheck{
+		Foreground:    "text-primary",
+		Background:    "bg-primary",
+		ForegroundHex: "#5c6166",
+		BackgroundHex: "#fcfcfc",
+		Ratio:         5.99,
+		Required:      7.0,
+		Level:         "AAA",
+	}
+
+	fix := suggestContrastFix(&check)
+	if fix.Adjusted != "foreground" || fix.Before != "#5c6166" {
+		t.Fatalf("fix = %+v, want a foreground adjustment from #5c6166", fix)
+	}
+	if fix.NewRatio < check.Required {
+		t.Errorf("NewRatio = %.2f, want >= %.1f", fix.NewRatio, check.Required)
+	}
+	if ratio, _ := palettes.ContrastRatioFromHex(fix.After, check.BackgroundHex); ratio != fix.NewRatio {
+		t.Errorf("NewRatio = %.2f, but %s on %s is %.2f", fix.NewRatio, fix.After, check.BackgroundHex, ratio)
+	}
+
+	check.Required = 22
+	if fix := suggestContrastFix(&check); fix.Adjusted != "" || fix.After != "" {
+		t.Errorf("unreachable target: fix = %+v, want no adjustment", fix)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"os"
 	"runtime/pprof"
 
+	"github.com/WaylonWalker/markata-go/pkg/config"
 	"github.com/WaylonWalker/markata-go/pkg/logging"
 	"github.com/spf13/cobra"
 )
@@ -18,6 +19,9 @@ var (
 	// These are applied in order, with later files taking precedence over earlier ones.
 	mergeConfigFiles []string
 
+	// configEnv selects a [markata-go.environments.<name>] section via --env.
+	configEnv string
+
 	// outputDir is the output directory specified via --output flag.
 	outputDir string
 
@@ -36,6 +40,9 @@ var (
 	// logFormat controls centralized log formatting.
 	logFormat string
 
+	// logLevel is the minimum level of log messages shown.
+	logLevel string
+
 	// noInput disables prompts and interactive UI.
 	noInput bool
 
@@ -91,10 +98,18 @@ Profiling:
 			return fmt.Errorf("cannot use --color and --no-color together")
 		}
 
-		if err := configureCommandLogger(logging.DefaultTheme()); err != nil {
+		if err := configureCommandLogger(logging.DefaultTheme(), nil); err != nil {
 			return err
 		}
 
+		// --env takes precedence over MARKATA_GO_ENV; exporting it lets every
+		// config load in this process see the same environment.
+		if cmd.Flags().Changed("env") {
+			if err := os.Setenv(config.EnvironmentEnvVar, configEnv); err != nil {
+				return fmt.Errorf("failed to set %s: %w", config.EnvironmentEnvVar, err)
+			}
+		}
+
 		// Start CPU profiling if requested
 		if cpuProfile != "" {
 			f, err := os.Create(cpuProfile)
@@ -153,12 +168,14 @@ func init() {
 	// Global flags
 	rootCmd.PersistentFlags().StringVarP(&cfgFile, "config", "c", "", "config file path (default: auto-discover)")
 	rootCmd.PersistentFlags().StringSliceVarP(&mergeConfigFiles, "merge-config", "m", nil, "additional config file(s) to merge with base config (can be specified multiple times)")
+	rootCmd.PersistentFlags().StringVar(&configEnv, "env", "", "config environment to apply from [markata-go.environments] (env: MARKATA_GO_ENV)")
 	rootCmd.PersistentFlags().StringVarP(&outputDir, "output", "o", "", "output directory (overrides config)")
 	rootCmd.PersistentFlags().BoolVarP(&quiet, "quiet", "q", false, "suppress non-essential status output")
 	rootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "verbose output")
 	rootCmd.PersistentFlags().BoolVar(&forceColor, "color", false, "force ANSI color output")
 	rootCmd.PersistentFlags().BoolVar(&noColor, "no-color", false, "disable ANSI color on all streams")
-	rootCmd.PersistentFlags().StringVar(&logFormat, "log-format", "auto", "log formatting: auto, plain, or rich")
+	rootCmd.PersistentFlags().StringVar(&logFormat, "log-format", "auto", "log formatting: auto, plain, rich, or json")
+	rootCmd.PersistentFlags().StringVar(&logLevel, "log-level", "", "minimum log level: debug, info, warn, or error (default: info, or debug with --verbose)")
 	rootCmd.PersistentFlags().BoolVar(&noInput, "no-input", false, "disable prompts and interactive UI")
 
 	// Profiling flags
@@ -4,18 +4,15 @@ import (
 	"io/fs"
 	"os"
 	"path/filepath"
-	"regexp"
 	"sort"
 	"strings"
 
 	"github.com/WaylonWalker/markata-go/pkg/lifecycle"
+	"github.com/WaylonWalker/markata-go/pkg/plugins"
 	"github.com/fsnotify/fsnotify"
 )
 
-var (
-	searchWatchInternalPaths = []string{".markata", ".markata-cache", "cache", "markout", "public", "output"}
-	searchWatchGlobMagic     = regexp.MustCompile(`[*?\[{]`)
-)
+var searchWatchInternalPaths = []string{".markata", ".markata-cache", "cache", "markout", "public", "output"}
 
 func searchContentWatchRoots(config *lifecycle.Config) []string {
 	contentDir := config.ContentDir
@@ -30,7 +27,10 @@ func searchContentWatchRoots(config *lifecycle.Config) []string {
 	seen := map[string]struct{}{}
 	roots := make([]string, 0, len(config.GlobPatterns))
 	for _, pattern := range config.GlobPatterns {
-		root := searchWatchRootFromPattern(pattern)
+		if _, negated := plugins.NegatedGlobPattern(pattern); negated {
+			continue
+		}
+		root := lifecycle.WatchRootFromPattern(pattern)
 		if root == "" {
 			continue
 		}
@@ -49,30 +49,6 @@ func searchContentWatchRoots(config *lifecycle.Config) []string {
 	return roots
 }
 
-func searchWatchRootFromPattern(pattern string) string {
-	cleaned := filepath.Clean(filepath.FromSlash(pattern))
-	if cleaned == "." || cleaned == string(filepath.Separator) {
-		return "."
-	}
-
-	parts := strings.Split(cleaned, string(filepath.Separator))
-	rootParts := make([]string, 0, len(parts))
-	for _, part := range parts {
-		if part == "" || part == "." {
-			continue
-		}
-		if searchWatchGlobMagic.MatchString(part) {
-			break
-		}
-		rootParts = append(rootParts, part)
-	}
-
-	if len(rootParts) == 0 {
-		return "."
-	}
-	return filepath.Join(rootParts...)
-}
-
 func searchShouldIgnorePath(pathname string) bool {
 	absPath, err := filepath.Abs(pathname)
 	if err != nil {
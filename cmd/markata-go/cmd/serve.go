@@ -13,7 +13,6 @@ import (
 	"os/signal"
 	"path"
 	"path/filepath"
-	"regexp"
 	"sort"
 	"strings"
 	"sync"
@@ -24,6 +23,7 @@ import (
 	"github.com/WaylonWalker/markata-go/pkg/buildcache"
 	"github.com/WaylonWalker/markata-go/pkg/lifecycle"
 	"github.com/WaylonWalker/markata-go/pkg/models"
+	"github.com/WaylonWalker/markata-go/pkg/plugins"
 	"github.com/WaylonWalker/markata-go/pkg/searchapi"
 	"github.com/fsnotify/fsnotify"
 	"github.com/spf13/cobra"
@@ -200,6 +200,7 @@ func runServeCommand(cmd *cobra.Command, _ []string) error {
 		return fmt.Errorf("initialization failed: %w", err)
 	}
 	configureLoggerForManager(m)
+	lifecycle.SetServeMode(m, true)
 
 	// Apply fast mode if requested
 	if serveFast {
@@ -1131,8 +1132,6 @@ func shouldIgnoreEvent(event fsnotify.Event) bool {
 		strings.HasSuffix(event.Name, ".tmp")
 }
 
-var globMagicPattern = regexp.MustCompile(`[*?\[{]`)
-
 func contentWatchRoots(config *lifecycle.Config) []string {
 	contentDir := config.ContentDir
 	if contentDir == "" {
@@ -1146,7 +1145,10 @@ func contentWatchRoots(config *lifecycle.Config) []string {
 	seen := map[string]struct{}{}
 	roots := make([]string, 0, len(config.GlobPatterns))
 	for _, pattern := range config.GlobPatterns {
-		root := watchRootFromPattern(pattern)
+		if _, negated := plugins.NegatedGlobPattern(pattern); negated {
+			continue
+		}
+		root := lifecycle.WatchRootFromPattern(pattern)
 		if root == "" {
 			continue
 		}
@@ -1165,31 +1167,6 @@ func contentWatchRoots(config *lifecycle.Config) []string {
 	return roots
 }
 
-func watchRootFromPattern(pattern string) string {
-	cleaned := filepath.Clean(filepath.FromSlash(pattern))
-	if cleaned == "." || cleaned == string(filepath.Separator) {
-		return "."
-	}
-
-	parts := strings.Split(cleaned, string(filepath.Separator))
-	rootParts := make([]string, 0, len(parts))
-	for _, part := range parts {
-		if part == "" || part == "." {
-			continue
-		}
-		if globMagicPattern.MatchString(part) {
-			break
-		}
-		rootParts = append(rootParts, part)
-	}
-
-	if len(rootParts) == 0 {
-		return "."
-	}
-
-	return filepath.Join(rootParts...)
-}
-
 // handleNewDirectory adds newly created directories to the watcher.
 // This ensures new post folders, tag directories, etc. are watched.
 func handleNewDirectory(watcher *fsnotify.Watcher, event fsnotify.Event) {
@@ -1477,6 +1454,7 @@ func doRebuild(ctx context.Context, rebuildCh chan<- struct{}) {
 		return
 	}
 	configureLoggerForManager(m)
+	lifecycle.SetServeMode(m, true)
 
 	changedPaths, removedPaths, forceFull, globDirty := consumeServeChanges()
 	configureServeIncremental(m, changedPaths, removedPaths, forceFull, globDirty)
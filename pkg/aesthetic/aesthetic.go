@@ -21,6 +21,10 @@ type Aesthetic struct {
 	Name        string `json:"name" yaml:"name" toml:"name"`
 	Description string `json:"description,omitempty" yaml:"description,omitempty" toml:"description,omitempty"`
 
+	// Extends names a base aesthetic whose tokens this one overlays.
+	// Loader.Load resolves it; tokens omitted here are inherited.
+	Extends string `json:"extends,omitempty" yaml:"extends,omitempty" toml:"extends,omitempty"`
+
 	// Tokens organized by category
 	Tokens Tokens `json:"tokens" yaml:"tokens" toml:"tokens"`
 
@@ -111,6 +115,7 @@ func (a *Aesthetic) Clone() *Aesthetic {
 	clone := &Aesthetic{
 		Name:        a.Name,
 		Description: a.Description,
+		Extends:     a.Extends,
 		Source:      a.Source,
 		SourcePath:  a.SourcePath,
 		Tokens: Tokens{
@@ -175,18 +180,6 @@ func (a *Aesthetic) Clone() *Aesthetic {
 	return clone
 }
 
-// Validate checks if the aesthetic has required fields.
-// Returns a slice of validation errors (empty if valid).
-func (a *Aesthetic) Validate() []error {
-	var errs []error
-
-	if a.Name == "" {
-		errs = append(errs, NewValidationError("name", "aesthetic name is required"))
-	}
-
-	return errs
-}
-
 // Merge combines this aesthetic with an override aesthetic.
 // The override values take precedence. Returns a new aesthetic.
 func (a *Aesthetic) Merge(override *Aesthetic) *Aesthetic {
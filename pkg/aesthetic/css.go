@@ -156,10 +156,25 @@ func (a *Aesthetic) getTypographyMap() map[string]string {
 	if len(a.Tokens.Typography) > 0 {
 		result := make(map[string]string)
 		for k, v := range a.Tokens.Typography {
+			if isFluidKey(k) {
+				continue
+			}
 			// Convert token names to CSS var names
 			cssKey := tokenToCSSName(k, "")
 			result[cssKey] = v
 		}
+
+		// Fluid sizes replace the theme's static --text-* scale; invalid
+		// settings fall back to the static sizes (Validate reports them)
+		if fluid, err := a.FluidTypography(); err == nil && fluid != nil {
+			for k, v := range fluid.Scale() {
+				result[k] = v
+			}
+		}
+
+		if len(result) == 0 {
+			return nil
+		}
 		return result
 	}
 	return nil
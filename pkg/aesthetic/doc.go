@@ -11,12 +11,33 @@
 //
 // # Aesthetic Presets
 //
-// Five built-in aesthetic presets are available:
+// Six built-in aesthetic presets are available:
 //   - brutal: Sharp corners, tight spacing, bold borders
 //   - precision: Subtle corners, compact spacing, clean lines
 //   - balanced: Comfortable rounding, normal spacing (default)
 //   - elevated: Generous rounding, layered shadows
 //   - minimal: Maximum whitespace, flat design
+//   - fluid: Balanced shapes with a fluid type scale
+//
+// # Fluid Typography
+//
+// Setting typography.fluid = true makes GenerateCSS emit the --text-xs
+// through --text-4xl scale as clamp() expressions that grow linearly with the
+// viewport instead of relying on the theme's static sizes:
+//
+//	[tokens.typography]
+//	fluid = true
+//	fluid_min_size = "1rem"        # base size at fluid_min_viewport
+//	fluid_max_size = "1.125rem"    # base size at fluid_max_viewport
+//	fluid_min_viewport = "320px"
+//	fluid_max_viewport = "1280px"
+//	fluid_min_ratio = 1.2          # scale ratio at fluid_min_viewport
+//	fluid_max_ratio = 1.25         # scale ratio at fluid_max_viewport
+//
+// Each step is the base size times the ratio raised to the step's power
+// (xs is -2, base is 0, 4xl is 5). Unset fields use the defaults above.
+// With fluid off, or with invalid settings, no --text-* sizes are emitted
+// and the static scale applies; Validate reports invalid settings.
 //
 // # Aesthetic Discovery
 //
@@ -25,10 +46,41 @@
 //  2. User config: ~/.config/markata-go/aesthetics/{name}.toml
 //  3. Built-in: embedded aesthetics
 //
+// # Extending Aesthetics
+//
+// An aesthetic file can start from another aesthetic with extends and only
+// define the tokens it changes:
+//
+//	name = "mine"
+//	extends = "elevated"
+//
+//	[tokens.shadow]
+//	md = "0 8px 24px rgba(0,0,0,0.2)"
+//
+// Loader.Load loads the base by the same discovery rules and overlays every
+// token the file sets; token groups and keys it omits are inherited. Bases
+// may extend other aesthetics, and a cycle returns ErrCircularExtends.
+//
+// # Validation
+//
+// Validate checks token values and returns issues with a severity. Errors
+// are values that produce broken CSS: negative radii, spacing or border
+// widths that are not lengths, malformed box-shadows, and invalid fluid
+// settings. Warnings are likely mistakes, such as a font stack without a
+// generic family fallback. Values using calc(), var() and similar CSS
+// functions are accepted as written. Loading only fails on structural
+// problems, so broken tokens can still be loaded and reported.
+//
+// # Preview
+//
+// GeneratePreviewHTML renders a standalone HTML page with the aesthetic's
+// CSS inlined and a sample of every token. GeneratePreviewHTMLWithOptions
+// also inlines a palette's CSS so colors and tokens show together.
+//
 // # Usage
 //
 //	// Load an aesthetic by name
-//	a, err := aesthetic.LoadAesthetic("modern")
+//	a, err := aesthetic.Load("modern")
 //	if err != nil {
 //	    log.Fatal(err)
 //	}
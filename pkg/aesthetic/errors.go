@@ -9,6 +9,9 @@ var (
 
 	// ErrInvalidAesthetic is returned when an aesthetic file is malformed.
 	ErrInvalidAesthetic = errors.New("invalid aesthetic")
+
+	// ErrCircularExtends is returned when aesthetics extend each other in a cycle.
+	ErrCircularExtends = errors.New("circular extends")
 )
 
 // LoadError provides context for aesthetic loading failures.
@@ -0,0 +1,203 @@
+package aesthetic
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Typography token keys that configure fluid type. They are settings rather
+// than CSS values, so they are not emitted as custom properties themselves.
+const (
+	fluidKey            = "fluid"
+	fluidMinSizeKey     = "fluid_min_size"
+	fluidMaxSizeKey     = "fluid_max_size"
+	fluidMinViewportKey = "fluid_min_viewport"
+	fluidMaxViewportKey = "fluid_max_viewport"
+	fluidMinRatioKey    = "fluid_min_ratio"
+	fluidMaxRatioKey    = "fluid_max_ratio"
+)
+
+// rootFontSizePx converts between px and rem.
+const rootFontSizePx = 16.0
+
+// Defaults for fluid typography settings that are not configured.
+const (
+	defaultFluidMinSize     = 1.0    // rem
+	defaultFluidMaxSize     = 1.125  // rem
+	defaultFluidMinViewport = 320.0  // px
+	defaultFluidMaxViewport = 1280.0 // px
+	defaultFluidMinRatio    = 1.2
+	defaultFluidMaxRatio    = 1.25
+)
+
+// fluidStep is a step of the type scale, as a power of the scale ratio
+// relative to the base size.
+type fluidStep struct {
+	name  string
+	power int
+}
+
+// fluidSteps mirrors the theme's --text-* scale.
+var fluidSteps = []fluidStep{
+	{"text-xs", -2},
+	{"text-sm", -1},
+	{"text-base", 0},
+	{"text-lg", 1},
+	{"text-xl", 2},
+	{"text-2xl", 3},
+	{"text-3xl", 4},
+	{"text-4xl", 5},
+}
+
+// FluidTypography describes a type scale that grows linearly with the
+// viewport width between two breakpoints.
+type FluidTypography struct {
+	MinSize     float64 // Base font size at MinViewport, in rem
+	MaxSize     float64 // Base font size at MaxViewport, in rem
+	MinViewport float64 // Viewport width where growth starts, in px
+	MaxViewport float64 // Viewport width where growth stops, in px
+	MinRatio    float64 // Scale ratio between steps at MinViewport
+	MaxRatio    float64 // Scale ratio between steps at MaxViewport
+}
+
+// FluidTypography returns the aesthetic's fluid typography settings, or nil
+// if typography.fluid is not enabled. Unset fields use defaults.
+func (a *Aesthetic) FluidTypography() (*FluidTypography, error) {
+	tokens := a.Tokens.Typography
+	if enabled, err := strconv.ParseBool(tokens[fluidKey]); err != nil || !enabled {
+		if tokens[fluidKey] != "" && err != nil {
+			return nil, NewValidationError("typography."+fluidKey, fmt.Sprintf("expected true or false, got %q", tokens[fluidKey]))
+		}
+		return nil, nil
+	}
+
+	f := &FluidTypography{}
+	fields := []struct {
+		key   string
+		dest  *float64
+		def   float64
+		parse func(string) (float64, error)
+	}{
+		{fluidMinSizeKey, &f.MinSize, defaultFluidMinSize, parseRem},
+		{fluidMaxSizeKey, &f.MaxSize, defaultFluidMaxSize, parseRem},
+		{fluidMinViewportKey, &f.MinViewport, defaultFluidMinViewport, parsePx},
+		{fluidMaxViewportKey, &f.MaxViewport, defaultFluidMaxViewport, parsePx},
+		{fluidMinRatioKey, &f.MinRatio, defaultFluidMinRatio, parseRatio},
+		{fluidMaxRatioKey, &f.MaxRatio, defaultFluidMaxRatio, parseRatio},
+	}
+	for _, field := range fields {
+		raw := strings.TrimSpace(tokens[field.key])
+		if raw == "" {
+			*field.dest = field.def
+			continue
+		}
+		v, err := field.parse(raw)
+		if err != nil {
+			return nil, NewValidationError("typography."+field.key, err.Error())
+		}
+		*field.dest = v
+	}
+
+	if f.MaxViewport <= f.MinViewport {
+		return nil, NewValidationError("typography."+fluidMaxViewportKey, "must be greater than "+fluidMinViewportKey)
+	}
+
+	return f, nil
+}
+
+// Clamp returns a CSS clamp() expression that is minRem at MinViewport,
+// maxRem at MaxViewport, and interpolates linearly in between.
+//
+// The preferred value is the line through both points: with the slope
+// m = (maxRem - minRem) / (MaxViewport - MinViewport) in rem per rem of
+// viewport, the size at viewport width w is minRem + m*(w - MinViewport),
+// which is written as an intercept in rem plus m*100 vw.
+func (f *FluidTypography) Clamp(minRem, maxRem float64) string {
+	minVw := f.MinViewport / rootFontSizePx
+	maxVw := f.MaxViewport / rootFontSizePx
+
+	slope := (maxRem - minRem) / (maxVw - minVw)
+	intercept := minRem - slope*minVw
+
+	lower, upper := math.Min(minRem, maxRem), math.Max(minRem, maxRem)
+
+	sign := "+"
+	if slope < 0 {
+		sign = "-"
+	}
+	return fmt.Sprintf("clamp(%srem, %srem %s %svw, %srem)",
+		formatNumber(lower),
+		formatNumber(intercept),
+		sign,
+		formatNumber(math.Abs(slope*100)),
+		formatNumber(upper))
+}
+
+// Scale returns the --text-* sizes of the fluid type scale as clamp()
+// expressions, keyed by CSS variable name without the leading dashes.
+func (f *FluidTypography) Scale() map[string]string {
+	result := make(map[string]string, len(fluidSteps))
+	for _, step := range fluidSteps {
+		minRem := f.MinSize * math.Pow(f.MinRatio, float64(step.power))
+		maxRem := f.MaxSize * math.Pow(f.MaxRatio, float64(step.power))
+		result[step.name] = f.Clamp(minRem, maxRem)
+	}
+	return result
+}
+
+// isFluidKey reports whether a typography token configures fluid type.
+func isFluidKey(key string) bool {
+	return key == fluidKey || strings.HasPrefix(key, fluidKey+"_")
+}
+
+// parseRem parses a size in rem or px and returns it in rem.
+func parseRem(s string) (float64, error) {
+	switch {
+	case strings.HasSuffix(s, "rem"):
+		return parsePositive(strings.TrimSuffix(s, "rem"), s)
+	case strings.HasSuffix(s, "px"):
+		v, err := parsePositive(strings.TrimSuffix(s, "px"), s)
+		return v / rootFontSizePx, err
+	default:
+		return 0, fmt.Errorf("expected a size in rem or px, got %q", s)
+	}
+}
+
+// parsePx parses a viewport width in px or rem and returns it in px.
+func parsePx(s string) (float64, error) {
+	switch {
+	case strings.HasSuffix(s, "rem"):
+		v, err := parsePositive(strings.TrimSuffix(s, "rem"), s)
+		return v * rootFontSizePx, err
+	case strings.HasSuffix(s, "px"):
+		return parsePositive(strings.TrimSuffix(s, "px"), s)
+	default:
+		return 0, fmt.Errorf("expected a width in px or rem, got %q", s)
+	}
+}
+
+// parseRatio parses a unitless scale ratio.
+func parseRatio(s string) (float64, error) {
+	return parsePositive(s, s)
+}
+
+// parsePositive parses a positive number, reporting errors against original.
+func parsePositive(num, original string) (float64, error) {
+	v, err := strconv.ParseFloat(strings.TrimSpace(num), 64)
+	if err != nil || v <= 0 {
+		return 0, fmt.Errorf("expected a positive number, got %q", original)
+	}
+	return v, nil
+}
+
+// formatNumber formats a CSS number with at most four decimal places and
+// no trailing zeros.
+func formatNumber(v float64) string {
+	s := strconv.FormatFloat(math.Round(v*10000)/10000, 'f', -1, 64)
+	if s == "-0" {
+		return "0"
+	}
+	return s
+}
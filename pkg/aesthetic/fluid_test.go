@@ -0,0 +1,198 @@
+package aesthetic
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestFluidTypography_Clamp(t *testing.T) {
+	f := &FluidTypography{MinViewport: 320, MaxViewport: 1280}
+
+	tests := []struct {
+		name           string
+		minRem, maxRem float64
+		want           string
+	}{
+		// slope = 0.125rem / 60rem; intercept = 1 - slope*20
+		{"growing", 1, 1.125, "clamp(1rem, 0.9583rem + 0.2083vw, 1.125rem)"},
+		// slope = 1rem / 60rem; intercept = 2 - slope*20
+		{"large step", 2, 3, "clamp(2rem, 1.6667rem + 1.6667vw, 3rem)"},
+		{"shrinking", 1.125, 1, "clamp(1rem, 1.1667rem - 0.2083vw, 1.125rem)"},
+		{"constant", 1, 1, "clamp(1rem, 1rem + 0vw, 1rem)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := f.Clamp(tt.minRem, tt.maxRem); got != tt.want {
+				t.Errorf("Clamp(%v, %v) = %q, want %q", tt.minRem, tt.maxRem, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFluidTypography_Scale(t *testing.T) {
+	f := &FluidTypography{
+		MinSize: 1, MaxSize: 1.125,
+		MinViewport: 320, MaxViewport: 1280,
+		MinRatio: 1.2, MaxRatio: 1.25,
+	}
+
+	scale := f.Scale()
+	if len(scale) != len(fluidSteps) {
+		t.Fatalf("Scale() has %d sizes, want %d", len(scale), len(fluidSteps))
+	}
+	want := map[string]string{
+		"text-base": "clamp(1rem, 0.9583rem + 0.2083vw, 1.125rem)",
+		"text-lg":   "clamp(1.2rem, 1.1312rem + 0.3438vw, 1.4063rem)",
+		"text-xs":   "clamp(0.6944rem, 0.6859rem + 0.0426vw, 0.72rem)",
+	}
+	for key, value := range want {
+		if scale[key] != value {
+			t.Errorf("Scale()[%q] = %q, want %q", key, scale[key], value)
+		}
+	}
+}
+
+func TestAesthetic_FluidTypography(t *testing.T) {
+	tests := []struct {
+		name       string
+		typography map[string]string
+		want       *FluidTypography
+		wantErr    string
+	}{
+		{
+			name:       "disabled by default",
+			typography: map[string]string{"font_primary": "serif"},
+		},
+		{
+			name:       "explicitly disabled",
+			typography: map[string]string{"fluid": "false", "fluid_min_size": "bogus"},
+		},
+		{
+			name:       "defaults",
+			typography: map[string]string{"fluid": "true"},
+			want: &FluidTypography{
+				MinSize: 1, MaxSize: 1.125,
+				MinViewport: 320, MaxViewport: 1280,
+				MinRatio: 1.2, MaxRatio: 1.25,
+			},
+		},
+		{
+			name: "px and rem units",
+			typography: map[string]string{
+				"fluid":              "true",
+				"fluid_min_size":     "16px",
+				"fluid_max_size":     "1.25rem",
+				"fluid_min_viewport": "20rem",
+				"fluid_max_viewport": "1440px",
+				"fluid_min_ratio":    "1.125",
+				"fluid_max_ratio":    "1.333",
+			},
+			want: &FluidTypography{
+				MinSize: 1, MaxSize: 1.25,
+				MinViewport: 320, MaxViewport: 1440,
+				MinRatio: 1.125, MaxRatio: 1.333,
+			},
+		},
+		{
+			name:       "invalid flag",
+			typography: map[string]string{"fluid": "yes please"},
+			wantErr:    "typography.fluid",
+		},
+		{
+			name:       "invalid size unit",
+			typography: map[string]string{"fluid": "true", "fluid_min_size": "1em"},
+			wantErr:    "typography.fluid_min_size",
+		},
+		{
+			name:       "invalid ratio",
+			typography: map[string]string{"fluid": "true", "fluid_max_ratio": "0"},
+			wantErr:    "typography.fluid_max_ratio",
+		},
+		{
+			name: "viewports out of order",
+			typography: map[string]string{
+				"fluid":              "true",
+				"fluid_min_viewport": "1280px",
+				"fluid_max_viewport": "320px",
+			},
+			wantErr: "typography.fluid_max_viewport",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := NewAesthetic("test")
+			a.Tokens.Typography = tt.typography
+
+			got, err := a.FluidTypography()
+			if tt.wantErr != "" {
+				var verr *ValidationError
+				if !errors.As(err, &verr) || verr.Field != tt.wantErr {
+					t.Fatalf("FluidTypography() error = %v, want validation error for %s", err, tt.wantErr)
+				}
+				if errs := a.Validate(); len(errs) == 0 {
+					t.Error("Validate() should report invalid fluid settings")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("FluidTypography() error = %v", err)
+			}
+			if (got == nil) != (tt.want == nil) || (got != nil && *got != *tt.want) {
+				t.Errorf("FluidTypography() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGenerateCSS_FluidTypography(t *testing.T) {
+	a := NewAesthetic("test")
+	a.Tokens.Typography = map[string]string{
+		"font_primary":   "var(--font-sans)",
+		"fluid":          "true",
+		"fluid_min_size": "1rem",
+	}
+
+	css := a.GenerateCSS()
+	if !strings.Contains(css, "--text-base: clamp(1rem, 0.9583rem + 0.2083vw, 1.125rem);") {
+		t.Errorf("CSS should contain fluid --text-base\n%s", css)
+	}
+	if !strings.Contains(css, "--text-4xl: clamp(") {
+		t.Errorf("CSS should contain the full fluid scale\n%s", css)
+	}
+	if !strings.Contains(css, "--font-primary: var(--font-sans);") {
+		t.Errorf("CSS should keep other typography tokens\n%s", css)
+	}
+	if strings.Contains(css, "--fluid") {
+		t.Errorf("fluid settings should not be emitted as variables\n%s", css)
+	}
+
+	// Static sizes apply when fluid is off or misconfigured
+	for _, typography := range []map[string]string{
+		{"font_primary": "serif", "fluid": "false"},
+		{"font_primary": "serif", "fluid": "true", "fluid_min_size": "big"},
+	} {
+		a.Tokens.Typography = typography
+		css := a.GenerateCSS()
+		if strings.Contains(css, "--text-") || strings.Contains(css, "--fluid") {
+			t.Errorf("CSS for %v should not contain fluid sizes\n%s", typography, css)
+		}
+	}
+}
+
+func TestLoadBuiltin_Fluid(t *testing.T) {
+	a, err := LoadBuiltin("fluid")
+	if err != nil {
+		t.Fatalf("LoadBuiltin(fluid) error = %v", err)
+	}
+
+	f, err := a.FluidTypography()
+	if err != nil || f == nil {
+		t.Fatalf("fluid preset should enable fluid typography, got %+v, %v", f, err)
+	}
+	if !strings.Contains(a.GenerateCSS(), "--text-base: clamp(") {
+		t.Error("fluid preset should generate clamp() sizes")
+	}
+}
@@ -63,20 +63,54 @@ func (l *Loader) AddPath(path string) {
 
 // Load loads an aesthetic by name.
 // It searches built-in aesthetics first, then search paths in order.
-// Returns ErrAestheticNotFound if the aesthetic cannot be found.
+// If the aesthetic extends another, the base is loaded the same way and
+// the aesthetic's tokens are overlaid on it.
+// Returns ErrAestheticNotFound if the aesthetic cannot be found, and
+// ErrCircularExtends if extends forms a cycle.
 func (l *Loader) Load(name string) (*Aesthetic, error) {
+	return l.load(name, nil)
+}
+
+// load loads an aesthetic by name, resolving extends. chain holds the
+// normalized names of the aesthetics currently being resolved.
+func (l *Loader) load(name string, chain []string) (*Aesthetic, error) {
 	// Normalize name
 	normalized := normalizeAestheticName(name)
 
+	for _, seen := range chain {
+		if seen == normalized {
+			cycle := strings.Join(append(chain, normalized), " -> ")
+			return nil, NewLoadError(name, "", "circular extends: "+cycle, ErrCircularExtends)
+		}
+	}
+
 	// Check cache first
 	if a, ok := l.cache[normalized]; ok {
 		return a.Clone(), nil
 	}
 
+	a, err := l.find(name)
+	if err != nil {
+		return nil, err
+	}
+
+	if a.Extends != "" {
+		base, err := l.load(a.Extends, append(chain, normalized))
+		if err != nil {
+			return nil, err
+		}
+		a = extendAesthetic(base, a)
+	}
+
+	l.cache[normalized] = a
+	return a.Clone(), nil
+}
+
+// find loads an aesthetic by name without resolving extends.
+func (l *Loader) find(name string) (*Aesthetic, error) {
 	// Try built-in aesthetics first
 	if a, err := LoadBuiltin(name); err == nil {
-		l.cache[normalized] = a
-		return a.Clone(), nil
+		return a, nil
 	}
 
 	// Search paths in order (later paths override)
@@ -84,8 +118,7 @@ func (l *Loader) Load(name string) (*Aesthetic, error) {
 	for _, searchPath := range l.paths {
 		a, err := l.loadFromPath(name, searchPath)
 		if err == nil {
-			l.cache[normalized] = a
-			return a.Clone(), nil
+			return a, nil
 		}
 		lastErr = err
 	}
@@ -96,6 +129,16 @@ func (l *Loader) Load(name string) (*Aesthetic, error) {
 	return nil, NewLoadError(name, "", "aesthetic not found in any search path", ErrAestheticNotFound)
 }
 
+// extendAesthetic overlays the tokens of child on its resolved base. The
+// result keeps the child's name, extends, and source.
+func extendAesthetic(base, child *Aesthetic) *Aesthetic {
+	merged := base.Merge(child)
+	merged.Extends = child.Extends
+	merged.Source = child.Source
+	merged.SourcePath = child.SourcePath
+	return merged
+}
+
 // loadFromPath attempts to load an aesthetic from a specific path.
 func (l *Loader) loadFromPath(name, searchPath string) (*Aesthetic, error) {
 	// Try exact name with .toml extension
@@ -115,6 +158,7 @@ func (l *Loader) loadFromPath(name, searchPath string) (*Aesthetic, error) {
 }
 
 // LoadFromFile loads an aesthetic from a specific file path.
+// Extends is not resolved; use Loader.Load to get the merged aesthetic.
 func LoadFromFile(path string) (*Aesthetic, error) {
 	data, err := os.ReadFile(path)
 	if err != nil {
@@ -151,6 +195,7 @@ type rawTokens struct {
 type rawAesthetic struct {
 	Name        string    `toml:"name"`
 	Description string    `toml:"description"`
+	Extends     string    `toml:"extends"`
 	Tokens      rawTokens `toml:"tokens"`
 }
 
@@ -165,6 +210,7 @@ func parseAesthetic(data []byte, path, source string) (*Aesthetic, error) {
 	a := &Aesthetic{
 		Name:        raw.Name,
 		Description: raw.Description,
+		Extends:     raw.Extends,
 		Source:      source,
 		SourcePath:  path,
 		Tokens: Tokens{
@@ -194,8 +240,9 @@ func parseAesthetic(data []byte, path, source string) (*Aesthetic, error) {
 		a.Tokens.Effects = make(map[string]string)
 	}
 
-	// Validate the loaded aesthetic
-	if errs := a.Validate(); len(errs) > 0 {
+	// Validate the loaded aesthetic's structure; token values are checked
+	// by Validate so broken tokens can still be loaded and reported
+	if errs := a.validateStructure(); len(errs) > 0 {
 		return nil, NewLoadError(a.Name, path, fmt.Sprintf("validation failed: %v", errs[0]), errs[0])
 	}
 
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -395,3 +396,119 @@ func TestBuiltinAesthetics_Content(t *testing.T) {
 		})
 	}
 }
+
+// writeAesthetics writes aesthetic files into a temp directory and returns
+// a loader searching it.
+func writeAesthetics(t *testing.T, files map[string]string) *Loader {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name+".toml"), []byte(content), 0o600); err != nil {
+			t.Fatalf("Failed to write aesthetic: %v", err)
+		}
+	}
+	return NewLoaderWithPaths([]string{dir})
+}
+
+func TestLoader_Load_Extends(t *testing.T) {
+	loader := writeAesthetics(t, map[string]string{
+		"mine": `
+name = "Mine"
+extends = "elevated"
+
+[tokens.shadow]
+md = "0 8px 24px rgba(0,0,0,0.2)"
+`,
+		"mine-tight": `
+name = "Mine Tight"
+description = "Mine with tighter spacing"
+extends = "mine"
+
+[tokens.spacing]
+scale = 0.9
+`,
+	})
+
+	base, err := LoadBuiltin("elevated")
+	if err != nil {
+		t.Fatalf("LoadBuiltin(elevated) error = %v", err)
+	}
+
+	a, err := loader.Load("mine")
+	if err != nil {
+		t.Fatalf("Load(mine) error = %v", err)
+	}
+
+	if a.Name != "Mine" || a.Extends != "elevated" {
+		t.Errorf("Name, Extends = %q, %q; want Mine, elevated", a.Name, a.Extends)
+	}
+	if a.Description != base.Description {
+		t.Errorf("Description = %q, want inherited %q", a.Description, base.Description)
+	}
+	if filepath.Base(a.SourcePath) != "mine.toml" {
+		t.Errorf("SourcePath = %q, want the local file", a.SourcePath)
+	}
+
+	// Overridden token
+	if got := a.Tokens.Shadow["md"]; got != "0 8px 24px rgba(0,0,0,0.2)" {
+		t.Errorf("Shadow.md = %q, want override", got)
+	}
+	// Omitted keys of an overridden group are inherited
+	if got := a.Tokens.Shadow["lg"]; got != base.Tokens.Shadow["lg"] {
+		t.Errorf("Shadow.lg = %q, want inherited %q", got, base.Tokens.Shadow["lg"])
+	}
+	// Omitted token groups are inherited
+	for key, want := range base.Tokens.Radius {
+		if got := a.Tokens.Radius[key]; got != want {
+			t.Errorf("Radius[%q] = %q, want inherited %q", key, got, want)
+		}
+	}
+	if a.GetSpacingScale() != base.GetSpacingScale() {
+		t.Errorf("spacing scale = %v, want inherited %v", a.GetSpacingScale(), base.GetSpacingScale())
+	}
+	if got := a.Tokens.Typography["leading_scale"]; got != base.Tokens.Typography["leading_scale"] {
+		t.Errorf("Typography.leading_scale = %q, want inherited", got)
+	}
+
+	// GenerateCSS emits the merged result
+	css := a.GenerateCSS()
+	for _, want := range []string{
+		"--shadow-md: 0 8px 24px rgba(0,0,0,0.2);",
+		"--radius-md: " + base.Tokens.Radius["md"] + ";",
+		"--spacing-scale: 1.25;",
+	} {
+		if !strings.Contains(css, want) {
+			t.Errorf("CSS should contain %q\n%s", want, css)
+		}
+	}
+
+	// Extends chains through other local aesthetics
+	tight, err := loader.Load("mine-tight")
+	if err != nil {
+		t.Fatalf("Load(mine-tight) error = %v", err)
+	}
+	if tight.GetSpacingScale() != 0.9 {
+		t.Errorf("spacing scale = %v, want 0.9", tight.GetSpacingScale())
+	}
+	if tight.Tokens.Shadow["md"] != "0 8px 24px rgba(0,0,0,0.2)" || tight.Tokens.Radius["md"] != base.Tokens.Radius["md"] {
+		t.Errorf("mine-tight should inherit from mine and elevated, got %+v", tight.Tokens)
+	}
+}
+
+func TestLoader_Load_ExtendsErrors(t *testing.T) {
+	loader := writeAesthetics(t, map[string]string{
+		"a":       "name = \"a\"\nextends = \"b\"\n",
+		"b":       "name = \"b\"\nextends = \"a\"\n",
+		"self":    "name = \"self\"\nextends = \"self\"\n",
+		"missing": "name = \"missing\"\nextends = \"does-not-exist\"\n",
+	})
+
+	for _, name := range []string{"a", "self"} {
+		if _, err := loader.Load(name); !errors.Is(err, ErrCircularExtends) {
+			t.Errorf("Load(%q) error = %v, want ErrCircularExtends", name, err)
+		}
+	}
+	if _, err := loader.Load("missing"); !errors.Is(err, ErrAestheticNotFound) {
+		t.Errorf("Load(missing) error = %v, want ErrAestheticNotFound", err)
+	}
+}
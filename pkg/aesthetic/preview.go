@@ -0,0 +1,279 @@
+package aesthetic
+
+import (
+	"fmt"
+	"html"
+	"sort"
+	"strings"
+)
+
+// PreviewOptions controls how a preview page is generated.
+type PreviewOptions struct {
+	// PaletteName is shown alongside the aesthetic name when set.
+	PaletteName string
+
+	// PaletteCSS holds color custom properties, typically from
+	// palettes.Palette.GenerateCSS, inlined so colors and tokens show
+	// together. Without it the page uses neutral fallback colors.
+	PaletteCSS string
+}
+
+// GeneratePreviewHTML renders a standalone HTML document that exercises
+// every token of the aesthetic with its CSS inlined.
+func GeneratePreviewHTML(a *Aesthetic) string {
+	return GeneratePreviewHTMLWithOptions(a, PreviewOptions{})
+}
+
+// GeneratePreviewHTMLWithOptions renders a preview page like
+// GeneratePreviewHTML, combined with the palette in opts if one is set.
+func GeneratePreviewHTMLWithOptions(a *Aesthetic, opts PreviewOptions) string {
+	var sb strings.Builder
+
+	title := "Aesthetic Preview: " + a.Name
+	if opts.PaletteName != "" {
+		title += " + " + opts.PaletteName
+	}
+
+	sb.WriteString("<!DOCTYPE html>\n<html lang=\"en\">\n<head>\n")
+	sb.WriteString("  <meta charset=\"UTF-8\">\n")
+	sb.WriteString("  <meta name=\"viewport\" content=\"width=device-width, initial-scale=1\">\n")
+	fmt.Fprintf(&sb, "  <title>%s</title>\n", html.EscapeString(title))
+
+	// Font stacks that aesthetics reference but normally come from the theme
+	sb.WriteString("  <style>\n:root {\n  --font-sans: system-ui, sans-serif;\n  --font-mono: ui-monospace, monospace;\n}\n")
+	if opts.PaletteCSS != "" {
+		sb.WriteString(escapeStyle(opts.PaletteCSS))
+		sb.WriteString("\n")
+	}
+	sb.WriteString(escapeStyle(a.GenerateCSS()))
+	sb.WriteString(previewStyles)
+	sb.WriteString("  </style>\n</head>\n<body>\n<main>\n")
+
+	// Header
+	fmt.Fprintf(&sb, "  <h1>%s</h1>\n", html.EscapeString(a.Name))
+	if a.Description != "" {
+		fmt.Fprintf(&sb, "  <p class=\"meta\">%s</p>\n", html.EscapeString(a.Description))
+	}
+	if opts.PaletteName != "" {
+		fmt.Fprintf(&sb, "  <p class=\"meta\">Palette: %s</p>\n", html.EscapeString(opts.PaletteName))
+	}
+
+	writeSampleSection(&sb)
+
+	borders := a.getBordersMap()
+	writeTokenSection(&sb, "Radius", filterPrefix(borders, "radius-"), func(name string) string {
+		return fmt.Sprintf(`<div class="swatch" style="border-radius: var(--%s);"></div>`, name)
+	})
+	writeTokenSection(&sb, "Borders", filterPrefix(borders, "border-"), func(name string) string {
+		if strings.HasPrefix(name, "border-width") {
+			return fmt.Sprintf(`<div class="swatch bordered" style="border-width: var(--%s);"></div>`, name)
+		}
+		return fmt.Sprintf(`<div class="swatch bordered" style="border-style: var(--%s);"></div>`, name)
+	})
+	writeTokenSection(&sb, "Shadows", a.getShadowsMap(), func(name string) string {
+		return fmt.Sprintf(`<div class="swatch raised" style="box-shadow: var(--%s);"></div>`, name)
+	})
+	writeTokenSection(&sb, "Spacing", a.getSpacingMap(), func(name string) string {
+		if name == "spacing-scale" {
+			return `<div class="spacing-sample"><span></span><span></span><span></span></div>`
+		}
+		return fmt.Sprintf(`<div class="spacing-bar" style="width: var(--%s);"></div>`, name)
+	})
+	writeTokenSection(&sb, "Typography", a.getTypographyMap(), func(name string) string {
+		switch {
+		case strings.HasPrefix(name, "text-"):
+			return fmt.Sprintf(`<p class="type-sample" style="font-size: var(--%s);">The quick brown fox</p>`, name)
+		case strings.HasPrefix(name, "font"):
+			return fmt.Sprintf(`<p class="type-sample" style="font-family: var(--%s);">The quick brown fox</p>`, name)
+		}
+		return ""
+	})
+	writeTokenSection(&sb, "Effects", a.getEffectsMap(), func(string) string { return "" })
+
+	sb.WriteString("</main>\n</body>\n</html>\n")
+
+	return sb.String()
+}
+
+// previewStyles styles the preview page using the aesthetic's tokens, with
+// fallbacks for tokens and palette colors that may be missing.
+const previewStyles = `
+* { box-sizing: border-box; }
+body {
+  margin: 0;
+  font-family: var(--font-primary, var(--font-sans));
+  line-height: 1.6;
+  color: var(--color-text-primary, #1f2328);
+  background: var(--color-bg-primary, #ffffff);
+}
+main {
+  max-width: 960px;
+  margin: 0 auto;
+  padding: calc(2rem * var(--spacing-scale, 1));
+}
+h1, h2, h3 { line-height: 1.2; }
+h1 { font-size: var(--text-4xl, 2.25rem); margin-bottom: 0.25rem; }
+h2 {
+  font-size: var(--text-2xl, 1.5rem);
+  margin-top: calc(2.5rem * var(--spacing-scale, 1));
+  padding-bottom: 0.25rem;
+  border-bottom: var(--border-width-thin, 1px) var(--border-style, solid) var(--color-border, #d0d7de);
+}
+h3 { font-size: var(--text-xl, 1.25rem); }
+p { font-size: var(--text-base, 1rem); }
+a { color: var(--color-link, #0969da); }
+.meta { color: var(--color-text-muted, #656d76); margin-top: 0; }
+.row {
+  display: flex;
+  flex-wrap: wrap;
+  gap: calc(1rem * var(--spacing-scale, 1));
+  align-items: flex-start;
+}
+.button {
+  display: inline-block;
+  padding: calc(0.5rem * var(--spacing-scale, 1)) calc(1rem * var(--spacing-scale, 1));
+  border: var(--border-width-normal, 1px) var(--border-style, solid) transparent;
+  border-radius: var(--radius-md, 6px);
+  box-shadow: var(--shadow-sm, none);
+  font: inherit;
+  cursor: pointer;
+}
+.button-primary {
+  background: var(--button-primary-bg, var(--color-accent, #0969da));
+  color: var(--button-primary-text, #ffffff);
+}
+.button-secondary {
+  background: var(--button-secondary-bg, var(--color-bg-secondary, #f6f8fa));
+  color: var(--button-secondary-text, var(--color-text-primary, #1f2328));
+  border-color: var(--color-border, #d0d7de);
+}
+.card {
+  flex: 1 1 240px;
+  padding: calc(1.25rem * var(--spacing-scale, 1));
+  background: var(--card-bg, var(--color-bg-surface, #ffffff));
+  border: var(--border-width-thin, 1px) var(--border-style, solid) var(--card-border, var(--color-border, #d0d7de));
+  border-radius: var(--radius-lg, 8px);
+  box-shadow: var(--shadow-md, none);
+}
+.card h3 { margin-top: 0; }
+pre, code { font-family: var(--font-code, var(--font-mono)); }
+pre {
+  padding: calc(1rem * var(--spacing-scale, 1));
+  overflow-x: auto;
+  background: var(--code-bg, var(--color-bg-secondary, #f6f8fa));
+  color: var(--code-text, inherit);
+  border: var(--border-width-thin, 1px) var(--border-style, solid) var(--color-border, #d0d7de);
+  border-radius: var(--radius-md, 6px);
+}
+blockquote {
+  margin: 0;
+  padding: calc(0.5rem * var(--spacing-scale, 1)) calc(1rem * var(--spacing-scale, 1));
+  border-left: var(--border-width-thick, 3px) var(--border-style, solid) var(--color-accent, #0969da);
+  border-radius: var(--radius-sm, 0);
+  background: var(--color-bg-secondary, #f6f8fa);
+}
+input {
+  padding: calc(0.5rem * var(--spacing-scale, 1));
+  font: inherit;
+  color: inherit;
+  background: var(--color-bg-primary, #ffffff);
+  border: var(--border-width-normal, 1px) var(--border-style, solid) var(--color-border, #d0d7de);
+  border-radius: var(--radius-md, 6px);
+}
+.token {
+  display: flex;
+  flex-direction: column;
+  gap: 0.5rem;
+  min-width: 140px;
+  font-size: var(--text-sm, 0.875rem);
+}
+.token-name { font-weight: bold; }
+.token-value { font-family: var(--font-mono); color: var(--color-text-muted, #656d76); word-break: break-all; }
+.swatch {
+  width: 96px;
+  height: 64px;
+  background: var(--color-bg-secondary, #eaeef2);
+}
+.bordered {
+  background: transparent;
+  border: var(--border-width-normal, 1px) var(--border-style, solid) var(--color-text-primary, #1f2328);
+}
+.raised { background: var(--card-bg, var(--color-bg-surface, #ffffff)); }
+.spacing-sample { display: flex; gap: calc(1rem * var(--spacing-scale, 1)); }
+.spacing-sample span {
+  width: 24px;
+  height: 24px;
+  background: var(--color-accent, #0969da);
+}
+.spacing-bar { height: 16px; background: var(--color-accent, #0969da); }
+.type-sample { margin: 0; }
+`
+
+// writeSampleSection writes sample content that combines the tokens the
+// way a site would.
+func writeSampleSection(sb *strings.Builder) {
+	sb.WriteString(`  <h2>Sample Content</h2>
+  <h3>Heading level three</h3>
+  <p>Body text sets the rhythm of a page. This paragraph uses the primary font
+  and base size, with <a href="#">a link</a>, <strong>strong text</strong>,
+  <em>emphasis</em>, and <code>inline code</code>.</p>
+  <blockquote><p>A blockquote with a thick accent border.</p></blockquote>
+  <div class="row">
+    <button class="button button-primary" type="button">Primary button</button>
+    <button class="button button-secondary" type="button">Secondary button</button>
+    <input type="text" placeholder="Text input" aria-label="Text input">
+  </div>
+  <h3>Cards</h3>
+  <div class="row">
+    <div class="card"><h3>Card title</h3><p>Cards combine the large radius, thin border, and medium shadow.</p></div>
+    <div class="card"><h3>Another card</h3><p>Spacing between cards follows the spacing scale.</p></div>
+  </div>
+  <h3>Code block</h3>
+  <pre><code>func main() {
+    fmt.Println("hello, aesthetic")
+}</code></pre>
+`)
+}
+
+// writeTokenSection writes one labelled sample per token, sorted by name.
+// sample returns the sample markup for a token's CSS variable name, or ""
+// to show only the name and value.
+func writeTokenSection(sb *strings.Builder, heading string, tokens map[string]string, sample func(name string) string) {
+	if len(tokens) == 0 {
+		return
+	}
+
+	names := make([]string, 0, len(tokens))
+	for name := range tokens {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fmt.Fprintf(sb, "  <h2>%s</h2>\n  <div class=\"row\">\n", heading)
+	for _, name := range names {
+		sb.WriteString("    <div class=\"token\">")
+		if s := sample(html.EscapeString(name)); s != "" {
+			sb.WriteString(s)
+		}
+		fmt.Fprintf(sb, "<span class=\"token-name\">--%s</span><span class=\"token-value\">%s</span></div>\n",
+			html.EscapeString(name), html.EscapeString(tokens[name]))
+	}
+	sb.WriteString("  </div>\n")
+}
+
+// filterPrefix returns the entries of m whose keys start with prefix.
+func filterPrefix(m map[string]string, prefix string) map[string]string {
+	result := make(map[string]string)
+	for k, v := range m {
+		if strings.HasPrefix(k, prefix) {
+			result[k] = v
+		}
+	}
+	return result
+}
+
+// escapeStyle keeps token values from closing the surrounding <style>
+// element early.
+func escapeStyle(css string) string {
+	return strings.ReplaceAll(css, "</", `<\/`)
+}
@@ -0,0 +1,79 @@
+package aesthetic
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGeneratePreviewHTML(t *testing.T) {
+	a := NewAesthetic("test")
+	a.Description = "A <test> aesthetic"
+	a.Tokens.Radius["md"] = "8px"
+	a.Tokens.Border["width_thin"] = "1px"
+	a.Tokens.Border["style"] = "dashed"
+	a.Tokens.Shadow["lg"] = "0 10px 15px rgba(0,0,0,0.1)"
+	a.Tokens.Typography["font_primary"] = "Georgia, serif"
+	a.Tokens.Effects["transition"] = "150ms ease"
+
+	out := GeneratePreviewHTML(a)
+
+	for _, want := range []string{
+		"<!DOCTYPE html>",
+		"<title>Aesthetic Preview: test</title>",
+		"A &lt;test&gt; aesthetic",
+		"--radius-md: 8px;",
+		`style="border-radius: var(--radius-md);"`,
+		`style="border-width: var(--border-width-thin);"`,
+		`style="border-style: var(--border-style);"`,
+		`style="box-shadow: var(--shadow-lg);"`,
+		`style="font-family: var(--font-primary);"`,
+		"--effect-transition",
+		`class="card"`,
+		"<pre><code>",
+		"</html>",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("preview missing %q", want)
+		}
+	}
+	if strings.Contains(out, "Palette:") {
+		t.Error("preview without a palette should not name one")
+	}
+}
+
+func TestGeneratePreviewHTMLWithOptions_Palette(t *testing.T) {
+	a := NewAesthetic("test")
+	out := GeneratePreviewHTMLWithOptions(a, PreviewOptions{
+		PaletteName: "Nord",
+		PaletteCSS:  ":root { --color-accent: #88c0d0; }",
+	})
+
+	if !strings.Contains(out, "<title>Aesthetic Preview: test + Nord</title>") {
+		t.Error("title should include the palette name")
+	}
+	palette := strings.Index(out, "--color-accent: #88c0d0;")
+	aesthetic := strings.Index(out, "/* Aesthetic: test */")
+	if palette < 0 || aesthetic < 0 || palette > aesthetic {
+		t.Error("palette CSS should be inlined before the aesthetic CSS")
+	}
+}
+
+func TestGeneratePreviewHTML_EscapesStyle(t *testing.T) {
+	a := NewAesthetic("test")
+	a.Tokens.Shadow["md"] = "</style><script>alert(1)</script>"
+
+	out := GeneratePreviewHTML(a)
+	if strings.Count(out, "</style>") != 1 {
+		t.Error("token values should not close the <style> element")
+	}
+}
+
+func TestGeneratePreviewHTML_FluidScale(t *testing.T) {
+	a := NewAesthetic("test")
+	a.Tokens.Typography["fluid"] = "true"
+
+	out := GeneratePreviewHTML(a)
+	if !strings.Contains(out, `style="font-size: var(--text-base);"`) {
+		t.Error("fluid type scale should be sampled")
+	}
+}
@@ -0,0 +1,400 @@
+package aesthetic
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Severity indicates how serious a validation issue is.
+type Severity string
+
+const (
+	// SeverityError marks token values that produce broken CSS.
+	SeverityError Severity = "error"
+	// SeverityWarning marks values that work but are likely mistakes.
+	SeverityWarning Severity = "warning"
+)
+
+// Issue is a problem found by Validate.
+type Issue struct {
+	Severity Severity `json:"severity"`
+	Field    string   `json:"field"` // e.g., "radius.sm", "typography.font_primary"
+	Value    string   `json:"value,omitempty"`
+	Message  string   `json:"message"`
+}
+
+// Error implements the error interface.
+func (i Issue) Error() string {
+	if i.Value != "" {
+		return fmt.Sprintf("%s: %s: %s (%q)", i.Severity, i.Field, i.Message, i.Value)
+	}
+	return fmt.Sprintf("%s: %s: %s", i.Severity, i.Field, i.Message)
+}
+
+// HasErrors reports whether any issue has SeverityError.
+func HasErrors(issues []Issue) bool {
+	for _, issue := range issues {
+		if issue.Severity == SeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+// Validate checks the aesthetic's required fields and token values.
+// It reports negative radii, spacing and border values that are not CSS
+// lengths, malformed box-shadow values, invalid fluid typography settings,
+// and font stacks without a generic family fallback. CSS functions such as
+// calc() and var() are accepted without inspecting their arguments.
+// Returns the issues sorted by field (empty if valid).
+func (a *Aesthetic) Validate() []Issue {
+	var issues []Issue
+
+	for _, err := range a.validateStructure() {
+		field := ""
+		if verr, ok := err.(*ValidationError); ok {
+			field = verr.Field
+		}
+		issues = append(issues, Issue{Severity: SeverityError, Field: field, Message: err.Error()})
+	}
+
+	issues = append(issues, validateRadius(a.Tokens.Radius)...)
+	issues = append(issues, validateSpacing(a.Tokens.Spacing, a.Spacing)...)
+	issues = append(issues, validateBorder(a.Tokens.Border)...)
+	issues = append(issues, validateShadows(a.Tokens.Shadow)...)
+	issues = append(issues, validateTypography(a)...)
+
+	sort.SliceStable(issues, func(i, j int) bool {
+		return issues[i].Field < issues[j].Field
+	})
+	return issues
+}
+
+// validateStructure checks the fields an aesthetic needs to be loaded.
+func (a *Aesthetic) validateStructure() []error {
+	var errs []error
+
+	if a.Name == "" {
+		errs = append(errs, NewValidationError("name", "aesthetic name is required"))
+	}
+
+	return errs
+}
+
+// validateRadius checks that radius tokens are non-negative lengths. A
+// value may list several radii, e.g. "4px 8px / 2px".
+func validateRadius(radius map[string]string) []Issue {
+	var issues []Issue
+	for key, value := range radius {
+		field := "radius." + key
+		var parts []string
+		for _, side := range splitTopLevel(value, '/') {
+			parts = append(parts, splitTopLevel(side, ' ')...)
+		}
+		if len(parts) == 0 {
+			issues = append(issues, Issue{SeverityError, field, value, "empty border radius"})
+			continue
+		}
+		for _, part := range parts {
+			kind := classifyLength(part)
+			switch {
+			case kind == lengthInvalid:
+				issues = append(issues, Issue{SeverityError, field, value, "border radius is not a CSS length"})
+			case kind == lengthNegative:
+				issues = append(issues, Issue{SeverityError, field, value, "negative border radius"})
+			default:
+				continue
+			}
+			break
+		}
+	}
+	return issues
+}
+
+// validateSpacing checks the spacing scale and legacy spacing values.
+func validateSpacing(spacing *SpacingTokens, legacy map[string]string) []Issue {
+	var issues []Issue
+	if spacing != nil && spacing.Scale < 0 {
+		issues = append(issues, Issue{SeverityError, "spacing.scale", formatNumber(spacing.Scale), "spacing scale must not be negative"})
+	}
+	for key, value := range legacy {
+		if classifyLength(value) == lengthInvalid {
+			issues = append(issues, Issue{SeverityError, "spacing." + key, value, "spacing is not a CSS length"})
+		}
+	}
+	return issues
+}
+
+// borderStyles are the valid values of border-style.
+var borderStyles = map[string]bool{
+	"none": true, "hidden": true, "dotted": true, "dashed": true, "solid": true,
+	"double": true, "groove": true, "ridge": true, "inset": true, "outset": true,
+}
+
+// borderWidthKeywords are the keyword values of border-width.
+var borderWidthKeywords = map[string]bool{"thin": true, "medium": true, "thick": true}
+
+// validateBorder checks border widths and the border style.
+func validateBorder(border map[string]string) []Issue {
+	var issues []Issue
+	for key, value := range border {
+		field := "border." + key
+		switch {
+		case key == "style":
+			if !borderStyles[strings.ToLower(value)] && !isCSSFunction(value) {
+				issues = append(issues, Issue{SeverityError, field, value, "not a valid border style"})
+			}
+		case strings.HasPrefix(key, "width"):
+			if borderWidthKeywords[strings.ToLower(value)] {
+				continue
+			}
+			switch classifyLength(value) {
+			case lengthInvalid:
+				issues = append(issues, Issue{SeverityError, field, value, "border width is not a CSS length"})
+			case lengthNegative:
+				issues = append(issues, Issue{SeverityError, field, value, "negative border width"})
+			}
+		}
+	}
+	return issues
+}
+
+// validateShadows checks that shadow tokens use box-shadow syntax.
+func validateShadows(shadows map[string]string) []Issue {
+	var issues []Issue
+	for key, value := range shadows {
+		if msg := checkBoxShadow(value); msg != "" {
+			issues = append(issues, Issue{SeverityError, "shadow." + key, value, msg})
+		}
+	}
+	return issues
+}
+
+// checkBoxShadow returns why value is not a valid box-shadow, or "".
+// Each comma-separated shadow needs two to four lengths (offset-x, offset-y,
+// optional non-negative blur, optional spread), at most one color, and at
+// most one "inset". Shadows built with var() are not checked.
+func checkBoxShadow(value string) string {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return "empty box shadow"
+	}
+	if strings.EqualFold(value, "none") || strings.Contains(value, "var(") || isCSSWideKeyword(value) {
+		return ""
+	}
+
+	for _, shadow := range splitTopLevel(value, ',') {
+		parts := splitTopLevel(shadow, ' ')
+		if len(parts) == 0 {
+			return "empty shadow in list"
+		}
+
+		var lengths []lengthKind
+		colors, insets := 0, 0
+		lengthsDone := false
+		for _, part := range parts {
+			if strings.EqualFold(part, "inset") {
+				insets++
+				if len(lengths) > 0 {
+					lengthsDone = true
+				}
+				continue
+			}
+			if kind := classifyLength(part); kind != lengthInvalid {
+				if lengthsDone {
+					return "shadow lengths must be adjacent"
+				}
+				lengths = append(lengths, kind)
+				continue
+			}
+			if len(lengths) > 0 {
+				lengthsDone = true
+			}
+			colors++
+		}
+
+		switch {
+		case insets > 1:
+			return "\"inset\" may appear only once per shadow"
+		case colors > 1:
+			return "shadow has more than one color or an unknown value"
+		case len(lengths) < 2:
+			return "shadow needs at least an x and y offset"
+		case len(lengths) > 4:
+			return "shadow has more than four lengths"
+		case len(lengths) >= 3 && lengths[2] == lengthNegative:
+			return "shadow blur radius must not be negative"
+		}
+	}
+	return ""
+}
+
+// genericFontFamilies are the CSS generic font family keywords.
+var genericFontFamilies = map[string]bool{
+	"serif": true, "sans-serif": true, "monospace": true, "cursive": true,
+	"fantasy": true, "system-ui": true, "ui-serif": true, "ui-sans-serif": true,
+	"ui-monospace": true, "ui-rounded": true, "math": true, "emoji": true,
+	"fangsong": true,
+}
+
+// validateTypography checks fluid typography settings and font stacks.
+func validateTypography(a *Aesthetic) []Issue {
+	var issues []Issue
+
+	if _, err := a.FluidTypography(); err != nil {
+		field := "typography"
+		if verr, ok := err.(*ValidationError); ok {
+			field = verr.Field
+		}
+		issues = append(issues, Issue{Severity: SeverityError, Field: field, Message: err.Error()})
+	}
+
+	for key, value := range a.Tokens.Typography {
+		if !strings.HasPrefix(key, "font") {
+			continue
+		}
+		if msg := checkFontStack(value); msg != "" {
+			issues = append(issues, Issue{SeverityWarning, "typography." + key, value, msg})
+		}
+	}
+	return issues
+}
+
+// checkFontStack returns why a font stack may render badly, or "".
+// A stack ending in var() is assumed to resolve to a complete stack.
+func checkFontStack(value string) string {
+	families := splitTopLevel(value, ',')
+	if len(families) == 0 {
+		return "empty font stack"
+	}
+	last := strings.ToLower(strings.Trim(families[len(families)-1], `"'`))
+	if genericFontFamilies[last] || isCSSFunction(last) || isCSSWideKeyword(last) {
+		return ""
+	}
+	return "font stack has no generic family fallback (e.g., sans-serif)"
+}
+
+// lengthKind classifies a CSS value as a length.
+type lengthKind int
+
+const (
+	lengthInvalid lengthKind = iota
+	lengthNonNegative
+	lengthNegative
+)
+
+// lengthUnits are the CSS length units, plus percentages.
+var lengthUnits = map[string]bool{
+	"%": true, "px": true, "em": true, "rem": true, "ex": true, "ch": true,
+	"cap": true, "ic": true, "lh": true, "rlh": true,
+	"vw": true, "vh": true, "vi": true, "vb": true, "vmin": true, "vmax": true,
+	"svw": true, "svh": true, "lvw": true, "lvh": true, "dvw": true, "dvh": true,
+	"cqw": true, "cqh": true, "cqi": true, "cqb": true, "cqmin": true, "cqmax": true,
+	"cm": true, "mm": true, "q": true, "in": true, "pt": true, "pc": true,
+}
+
+// classifyLength reports whether value is a CSS length (or percentage) and
+// whether it is negative. Unitless zero and CSS functions such as calc()
+// and var() are accepted as non-negative lengths.
+func classifyLength(value string) lengthKind {
+	value = strings.TrimSpace(value)
+	if isCSSFunction(value) || isCSSWideKeyword(value) {
+		return lengthNonNegative
+	}
+
+	// Split the number from its unit
+	end := 0
+	for end < len(value) && strings.ContainsRune("+-.0123456789eE", rune(value[end])) {
+		// Stop at an "e" that starts a unit like "em" or "ex"
+		if (value[end] == 'e' || value[end] == 'E') && end+1 < len(value) && !strings.ContainsRune("+-0123456789", rune(value[end+1])) {
+			break
+		}
+		end++
+	}
+	num, unit := value[:end], strings.ToLower(value[end:])
+
+	n, err := strconv.ParseFloat(num, 64)
+	if err != nil {
+		return lengthInvalid
+	}
+	if unit == "" && n != 0 {
+		return lengthInvalid
+	}
+	if unit != "" && !lengthUnits[unit] {
+		return lengthInvalid
+	}
+	if n < 0 {
+		return lengthNegative
+	}
+	return lengthNonNegative
+}
+
+// cssFunctions are functions whose result is accepted without inspection.
+var cssFunctions = []string{"calc(", "var(", "env(", "min(", "max(", "clamp(", "attr("}
+
+// isCSSFunction reports whether value is a single CSS function call like
+// calc(...) or var(--x).
+func isCSSFunction(value string) bool {
+	lower := strings.ToLower(strings.TrimSpace(value))
+	for _, fn := range cssFunctions {
+		if strings.HasPrefix(lower, fn) && strings.HasSuffix(lower, ")") {
+			return true
+		}
+	}
+	return false
+}
+
+// isCSSWideKeyword reports whether value is a keyword valid for any property.
+func isCSSWideKeyword(value string) bool {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "inherit", "initial", "unset", "revert", "revert-layer":
+		return true
+	}
+	return false
+}
+
+// splitTopLevel splits value on sep outside parentheses and quotes,
+// trimming and dropping empty parts. A space separator splits on any
+// whitespace.
+func splitTopLevel(value string, sep byte) []string {
+	var parts []string
+	add := func(part string) {
+		if part = strings.TrimSpace(part); part != "" {
+			parts = append(parts, part)
+		}
+	}
+
+	start, depth := 0, 0
+	var quote byte
+	for i := 0; i < len(value); i++ {
+		ch := value[i]
+		if quote != 0 {
+			if ch == quote {
+				quote = 0
+			}
+			continue
+		}
+		switch {
+		case ch == '"' || ch == '\'':
+			quote = ch
+		case ch == '(':
+			depth++
+		case ch == ')':
+			if depth > 0 {
+				depth--
+			}
+		case depth == 0 && (ch == sep || (sep == ' ' && isSpace(ch))):
+			add(value[start:i])
+			start = i + 1
+		}
+	}
+	add(value[start:])
+	return parts
+}
+
+// isSpace returns true for CSS whitespace characters.
+func isSpace(ch byte) bool {
+	return ch == ' ' || ch == '\t' || ch == '\n' || ch == '\r'
+}
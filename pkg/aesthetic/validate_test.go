@@ -0,0 +1,142 @@
+package aesthetic
+
+import (
+	"testing"
+)
+
+func TestValidate_Tokens(t *testing.T) {
+	tests := []struct {
+		name     string
+		setup    func(a *Aesthetic)
+		field    string
+		severity Severity
+	}{
+		{"negative radius", func(a *Aesthetic) { a.Tokens.Radius["md"] = "-4px" }, "radius.md", SeverityError},
+		{"radius without unit", func(a *Aesthetic) { a.Tokens.Radius["md"] = "4" }, "radius.md", SeverityError},
+		{"negative radius in list", func(a *Aesthetic) { a.Tokens.Radius["md"] = "4px / -2px" }, "radius.md", SeverityError},
+		{"negative spacing scale", func(a *Aesthetic) { a.Tokens.Spacing.Scale = -1 }, "spacing.scale", SeverityError},
+		{"spacing not a length", func(a *Aesthetic) { a.Spacing = map[string]string{"gap": "wide"} }, "spacing.gap", SeverityError},
+		{"border width not a length", func(a *Aesthetic) { a.Tokens.Border["width_thin"] = "thinnish" }, "border.width_thin", SeverityError},
+		{"negative border width", func(a *Aesthetic) { a.Tokens.Border["width_thin"] = "-1px" }, "border.width_thin", SeverityError},
+		{"invalid border style", func(a *Aesthetic) { a.Tokens.Border["style"] = "wavy" }, "border.style", SeverityError},
+		{"shadow missing offset", func(a *Aesthetic) { a.Tokens.Shadow["md"] = "4px black" }, "shadow.md", SeverityError},
+		{"shadow negative blur", func(a *Aesthetic) { a.Tokens.Shadow["md"] = "0 4px -2px black" }, "shadow.md", SeverityError},
+		{"shadow too many lengths", func(a *Aesthetic) { a.Tokens.Shadow["md"] = "1px 2px 3px 4px 5px black" }, "shadow.md", SeverityError},
+		{"shadow two colors", func(a *Aesthetic) { a.Tokens.Shadow["md"] = "0 1px 2px red blue" }, "shadow.md", SeverityError},
+		{"shadow bad entry in list", func(a *Aesthetic) { a.Tokens.Shadow["md"] = "0 1px 2px black, bogus" }, "shadow.md", SeverityError},
+		{"font stack without generic", func(a *Aesthetic) { a.Tokens.Typography["font_primary"] = "Inter, Helvetica" }, "typography.font_primary", SeverityWarning},
+		{"invalid fluid setting", func(a *Aesthetic) {
+			a.Tokens.Typography["fluid"] = "true"
+			a.Tokens.Typography["fluid_min_size"] = "big"
+		}, "typography.fluid_min_size", SeverityError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := NewAesthetic("test")
+			tt.setup(a)
+
+			issues := a.Validate()
+			if len(issues) != 1 {
+				t.Fatalf("Validate() = %v, want one issue", issues)
+			}
+			if issues[0].Field != tt.field {
+				t.Errorf("Field = %q, want %q", issues[0].Field, tt.field)
+			}
+			if issues[0].Severity != tt.severity {
+				t.Errorf("Severity = %q, want %q", issues[0].Severity, tt.severity)
+			}
+		})
+	}
+}
+
+func TestValidate_AcceptsValidValues(t *testing.T) {
+	a := NewAesthetic("test")
+	a.Tokens.Radius = map[string]string{
+		"none": "0",
+		"sm":   "0.25rem",
+		"pill": "9999px",
+		"pct":  "50%",
+		"list": "4px 8px / 2px",
+		"calc": "calc(var(--radius-md) * 2)",
+		"var":  "var(--radius)",
+	}
+	a.Tokens.Border = map[string]string{
+		"width_thin":   "1px",
+		"width_medium": "medium",
+		"width_calc":   "calc(1px + 0.1rem)",
+		"style":        "dashed",
+	}
+	a.Tokens.Shadow = map[string]string{
+		"none":   "none",
+		"sm":     "0 1px 2px rgba(0, 0, 0, 0.05)",
+		"inset":  "inset 0 2px 4px 0 rgb(0 0 0 / 0.05)",
+		"color":  "black 0 1px 2px",
+		"layers": "0 1px 3px rgba(0,0,0,0.1), 0 1px 2px -1px rgba(0,0,0,0.1)",
+		"var":    "0 0 0 var(--ring-width) var(--color-primary)",
+		"calc":   "0 calc(1px + 1px) 4px #000",
+	}
+	a.Tokens.Typography = map[string]string{
+		"font_primary": `"Inter", system-ui, sans-serif`,
+		"font_code":    "var(--font-mono)",
+		"font_display": "Georgia, var(--font-fallback)",
+		"line_height":  "1.5",
+	}
+	a.Spacing = map[string]string{"gap": "clamp(1rem, 2vw, 2rem)"}
+
+	if issues := a.Validate(); len(issues) != 0 {
+		t.Errorf("Validate() = %v, want no issues", issues)
+	}
+}
+
+func TestValidate_BuiltinAesthetics(t *testing.T) {
+	loader := NewLoader()
+	for _, name := range []string{"balanced", "brutal", "elevated", "fluid", "minimal", "precision"} {
+		t.Run(name, func(t *testing.T) {
+			a, err := loader.Load(name)
+			if err != nil {
+				t.Fatalf("Load(%q) error = %v", name, err)
+			}
+			if issues := a.Validate(); len(issues) != 0 {
+				t.Errorf("Validate() = %v, want no issues", issues)
+			}
+		})
+	}
+}
+
+func TestValidate_MissingName(t *testing.T) {
+	issues := NewAesthetic("").Validate()
+	if !HasErrors(issues) {
+		t.Fatalf("Validate() = %v, want an error", issues)
+	}
+	if issues[0].Field != "name" {
+		t.Errorf("Field = %q, want %q", issues[0].Field, "name")
+	}
+}
+
+func TestClassifyLength(t *testing.T) {
+	tests := []struct {
+		value string
+		want  lengthKind
+	}{
+		{"0", lengthNonNegative},
+		{"1px", lengthNonNegative},
+		{"1.5em", lengthNonNegative},
+		{".5rem", lengthNonNegative},
+		{"1e2px", lengthNonNegative},
+		{"100%", lengthNonNegative},
+		{"calc(100% - 1rem)", lengthNonNegative},
+		{"var(--x)", lengthNonNegative},
+		{"-2px", lengthNegative},
+		{"2", lengthInvalid},
+		{"2pz", lengthInvalid},
+		{"px", lengthInvalid},
+		{"red", lengthInvalid},
+	}
+
+	for _, tt := range tests {
+		if got := classifyLength(tt.value); got != tt.want {
+			t.Errorf("classifyLength(%q) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+}
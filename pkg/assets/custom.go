@@ -0,0 +1,118 @@
+package assets
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/WaylonWalker/markata-go/pkg/models"
+)
+
+// Configure applies the custom assets and version pins from an assets
+// config to the registry.
+func Configure(cfg *models.AssetsConfig) error {
+	custom := make([]Asset, len(cfg.Custom))
+	for i := range cfg.Custom {
+		custom[i] = NewCustomAsset(cfg.Custom[i])
+	}
+	if err := SetCustomAssets(custom); err != nil {
+		return err
+	}
+	return SetVersionPins(cfg.Versions, cfg.Integrity)
+}
+
+// NewCustomAsset converts a [[markata-go.assets.custom]] entry into an Asset.
+// An empty Type is inferred from the LocalPath extension.
+func NewCustomAsset(c models.CustomAssetConfig) Asset {
+	assetType := c.Type
+	if assetType == "" {
+		switch path.Ext(c.LocalPath) {
+		case ".js", ".mjs":
+			assetType = "js"
+		case ".css":
+			assetType = "css"
+		default:
+			assetType = "other"
+		}
+	}
+
+	return Asset{
+		Name:      strings.TrimSpace(c.Key),
+		URL:       strings.TrimSpace(c.URL),
+		LocalPath: strings.Trim(path.Clean(strings.TrimSpace(c.LocalPath)), "/"),
+		Integrity: strings.TrimSpace(c.Integrity),
+		Version:   c.Version,
+		Type:      assetType,
+	}
+}
+
+// SetCustomAssets adds user-defined assets to the registry, replacing any
+// previously set. Each asset needs a name, an http(s) URL, and a relative
+// local path. Names and local paths must not collide with built-in assets
+// or each other; collisions are errors rather than overrides. Passing nil
+// removes all custom assets.
+func SetCustomAssets(custom []Asset) error {
+	builtin := make(map[string]bool, len(assetRegistry))
+	names := make(map[string]bool, len(custom))
+	localPaths := make(map[string]string, len(assetRegistry)+len(custom))
+	for i := range assetRegistry {
+		builtin[assetRegistry[i].Name] = true
+		localPaths[assetRegistry[i].LocalPath] = assetRegistry[i].Name
+	}
+
+	for i := range custom {
+		asset := &custom[i]
+		if err := validateCustomAsset(asset); err != nil {
+			return fmt.Errorf("assets.custom[%d]: %w", i, err)
+		}
+		if builtin[asset.Name] {
+			return fmt.Errorf("assets.custom[%d]: key %q conflicts with a built-in asset", i, asset.Name)
+		}
+		if names[asset.Name] {
+			return fmt.Errorf("assets.custom[%d]: duplicate key %q", i, asset.Name)
+		}
+		if owner, ok := localPaths[asset.LocalPath]; ok {
+			return fmt.Errorf("assets.custom[%d]: local_path %q is already used by %s", i, asset.LocalPath, owner)
+		}
+		names[asset.Name] = true
+		localPaths[asset.LocalPath] = asset.Name
+	}
+
+	customMu.Lock()
+	defer customMu.Unlock()
+	if len(custom) == 0 {
+		customAssets = nil
+		return nil
+	}
+	customAssets = make([]Asset, len(custom))
+	copy(customAssets, custom)
+	return nil
+}
+
+// validateCustomAsset checks the required fields of a custom asset.
+func validateCustomAsset(asset *Asset) error {
+	if asset.Name == "" {
+		return errors.New("key is required")
+	}
+	if asset.URL == "" {
+		return fmt.Errorf("%s: url is required", asset.Name)
+	}
+	parsed, err := url.Parse(asset.URL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return fmt.Errorf("%s: url %q must be an absolute http(s) URL", asset.Name, asset.URL)
+	}
+	if asset.LocalPath == "" || asset.LocalPath == "." {
+		return fmt.Errorf("%s: local_path is required", asset.Name)
+	}
+	if path.IsAbs(asset.LocalPath) || asset.LocalPath == ".." || strings.HasPrefix(asset.LocalPath, "../") {
+		return fmt.Errorf("%s: local_path %q must stay inside the vendor directory", asset.Name, asset.LocalPath)
+	}
+	switch asset.Type {
+	case "js", "css", "other":
+	default:
+		return fmt.Errorf("%s: type %q must be js, css, or other", asset.Name, asset.Type)
+	}
+	return nil
+}
@@ -0,0 +1,183 @@
+package assets
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/WaylonWalker/markata-go/pkg/models"
+)
+
+// setTestCustomAssets registers custom assets for the duration of a test.
+func setTestCustomAssets(t *testing.T, custom ...Asset) {
+	t.Helper()
+	if err := SetCustomAssets(custom); err != nil {
+		t.Fatalf("SetCustomAssets() error = %v", err)
+	}
+	t.Cleanup(func() {
+		_ = SetCustomAssets(nil) //nolint:errcheck // clearing custom assets cannot fail
+	})
+}
+
+func TestNewCustomAsset(t *testing.T) {
+	tests := []struct {
+		localPath string
+		assetType string
+		wantType  string
+		wantPath  string
+	}{
+		{"mylib/mylib.min.js", "", "js", "mylib/mylib.min.js"},
+		{"/mylib/mylib.css", "", "css", "mylib/mylib.css"},
+		{"mylib/font.woff2", "", "other", "mylib/font.woff2"},
+		{"mylib/loader", "js", "js", "mylib/loader"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.localPath, func(t *testing.T) {
+			asset := NewCustomAsset(models.CustomAssetConfig{
+				Key:       "mylib",
+				URL:       "https://example.com/mylib.js",
+				LocalPath: tt.localPath,
+				Type:      tt.assetType,
+			})
+			if asset.Type != tt.wantType {
+				t.Errorf("Type = %q, want %q", asset.Type, tt.wantType)
+			}
+			if asset.LocalPath != tt.wantPath {
+				t.Errorf("LocalPath = %q, want %q", asset.LocalPath, tt.wantPath)
+			}
+		})
+	}
+}
+
+func TestSetCustomAssets_Registry(t *testing.T) {
+	setTestCustomAssets(t, Asset{
+		Name:      "mylib",
+		URL:       "https://example.com/mylib@1.0.0/mylib.min.js",
+		LocalPath: "mylib/mylib.min.js",
+		Version:   "1.0.0",
+		Type:      "js",
+	})
+
+	asset := GetAsset("mylib")
+	if asset == nil {
+		t.Fatal("GetAsset() should find custom assets")
+	}
+	if asset.URL != "https://example.com/mylib@1.0.0/mylib.min.js" {
+		t.Errorf("URL = %q", asset.URL)
+	}
+
+	var inRegistry bool
+	for _, a := range Registry() {
+		if a.Name == "mylib" {
+			inRegistry = true
+		}
+	}
+	if !inRegistry {
+		t.Error("Registry() should include custom assets")
+	}
+	if _, ok := AssetGroups()["mylib"]; !ok {
+		t.Error("AssetGroups() should include custom assets")
+	}
+	if names := strings.Join(AssetNames(), ","); !strings.Contains(names, "mylib") {
+		t.Errorf("AssetNames() = %s, want mylib", names)
+	}
+
+	if err := SetVersionPins(map[string]string{"mylib": "2.0.0"}, nil); err != nil {
+		t.Fatalf("SetVersionPins() error = %v", err)
+	}
+	t.Cleanup(func() {
+		_ = SetVersionPins(nil, nil) //nolint:errcheck // clearing pins cannot fail
+	})
+	if got := GetAsset("mylib").URL; got != "https://example.com/mylib@2.0.0/mylib.min.js" {
+		t.Errorf("pinned custom URL = %q", got)
+	}
+}
+
+func TestSetCustomAssets_Errors(t *testing.T) {
+	valid := Asset{Name: "mylib", URL: "https://example.com/mylib.js", LocalPath: "mylib/mylib.js", Type: "js"}
+	with := func(change func(*Asset)) Asset {
+		asset := valid
+		change(&asset)
+		return asset
+	}
+
+	tests := []struct {
+		name    string
+		custom  []Asset
+		wantErr string
+	}{
+		{"missing key", []Asset{with(func(a *Asset) { a.Name = "" })}, "key is required"},
+		{"missing url", []Asset{with(func(a *Asset) { a.URL = "" })}, "url is required"},
+		{"relative url", []Asset{with(func(a *Asset) { a.URL = "mylib.js" })}, "absolute http(s) URL"},
+		{"missing local path", []Asset{with(func(a *Asset) { a.LocalPath = "" })}, "local_path is required"},
+		{"escaping local path", []Asset{with(func(a *Asset) { a.LocalPath = "../mylib.js" })}, "inside the vendor directory"},
+		{"bad type", []Asset{with(func(a *Asset) { a.Type = "archive" })}, "must be js, css, or other"},
+		{"built-in key", []Asset{with(func(a *Asset) { a.Name = "htmx" })}, "conflicts with a built-in asset"},
+		{"duplicate key", []Asset{valid, with(func(a *Asset) { a.LocalPath = "other/mylib.js" })}, "duplicate key"},
+		{"built-in local path", []Asset{with(func(a *Asset) { a.LocalPath = "htmx/htmx.min.js" })}, "already used by htmx"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := SetCustomAssets(tt.custom)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("SetCustomAssets() error = %v, want containing %q", err, tt.wantErr)
+			}
+			if GetAsset("mylib") != nil {
+				t.Error("invalid custom assets should not be registered")
+			}
+		})
+	}
+}
+
+func TestConfigure_CustomAssetDownloadAndVerify(t *testing.T) {
+	content := []byte("window.mylib = true;")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if _, err := w.Write(content); err != nil {
+			t.Logf("failed to write response: %v", err)
+		}
+	}))
+	defer server.Close()
+
+	cfg := models.AssetsConfig{
+		Custom: []models.CustomAssetConfig{{
+			Key:       "mylib",
+			URL:       server.URL + "/mylib.js",
+			LocalPath: "mylib/mylib.js",
+			Integrity: sriHash("sha384", content),
+		}},
+	}
+	if err := Configure(&cfg); err != nil {
+		t.Fatalf("Configure() error = %v", err)
+	}
+	t.Cleanup(func() {
+		_ = Configure(&models.AssetsConfig{}) //nolint:errcheck // clearing config cannot fail
+	})
+
+	cacheDir := t.TempDir()
+	d := NewDownloader(cacheDir, true)
+	asset := *GetAsset("mylib")
+
+	results := d.DownloadAssets(context.Background(), []Asset{asset}, 1)
+	if results[0].Error != nil {
+		t.Fatalf("download error = %v", results[0].Error)
+	}
+
+	verified := d.VerifyAssets([]Asset{asset})
+	if verified[0].Status != VerifyOK {
+		t.Errorf("verify status = %s, want ok", verified[0].Status)
+	}
+
+	outputDir := t.TempDir()
+	if err := d.CopyToOutput(asset, outputDir); err != nil {
+		t.Fatalf("CopyToOutput() error = %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(outputDir, "mylib", "mylib.js")); err != nil {
+		t.Errorf("custom asset not copied to output: %v", err)
+	}
+}
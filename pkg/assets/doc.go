@@ -27,6 +27,59 @@
 //	mode = "self-hosted"  # "cdn", "self-hosted", or "auto"
 //	cache_dir = ".markata/assets-cache"
 //	verify_integrity = true
+//	concurrency = 4   # parallel downloads
+//	max_attempts = 3  # retries transient HTTP and network errors
+//
+//	[markata-go.assets.versions]
+//	glightbox = "3.2.0"  # pin every glightbox asset to another version
+//
+// ResolveMode decides what "auto" means for a build: ModeCDN in development
+// and ModeSelfHosted in production:
+//
+//	mode := assets.ResolveMode(&cfg.Assets, assets.EnvDevelopment) // "cdn"
+//
+// Pinned versions are applied with SetVersionPins, which Registry and
+// GetAsset consult. Pinning clears the registered SRI hash unless one is
+// supplied under [markata-go.assets.integrity].
+//
+// User-defined assets from [[markata-go.assets.custom]] are added with
+// SetCustomAssets and then behave like built-in ones. Configure applies both
+// custom assets and version pins from an AssetsConfig:
+//
+//	[[markata-go.assets.custom]]
+//	key = "mylib"
+//	url = "https://cdn.example.com/mylib@1.2.0/mylib.min.js"
+//	local_path = "mylib/mylib.min.js"
+//
+// # Downloading
+//
+// NewDownloaderFromConfig builds a Downloader from an AssetsConfig.
+// DownloadAssets and DownloadAll fetch in parallel. Transient failures are
+// retried with exponential backoff, and each file's integrity is verified
+// once it arrives. A failing asset does not stop the others, and
+// FailedDownloads picks out the results that failed. SetProgress receives a
+// DownloadProgress as each asset finishes:
+//
+//	d := assets.NewDownloaderFromConfig(&cfg.Assets)
+//	d.SetProgress(func(p assets.DownloadProgress) {
+//		fmt.Printf("%d/%d %s\n", p.Completed, p.Total, p.Result.Asset.Name)
+//	})
+//	results := d.DownloadAll(ctx, 0) // 0 uses the configured concurrency
+//	for _, r := range assets.FailedDownloads(results) {
+//		log.Printf("%s: %v", r.Asset.Name, r.Error)
+//	}
+//
+// # Subresource Integrity
+//
+// IntegrityAttr returns the integrity and crossorigin attributes for a
+// self-hosted asset, hashing the copy under the directory set with
+// SetIntegrityDir when the registry has no matching hash. InjectIntegrity adds
+// those attributes to the <script> and <link> tags that reference self-hosted
+// URLs:
+//
+//	assets.SetIntegrityDir("public/assets/vendor")
+//	attr, err := assets.IntegrityAttr("htmx")
+//	// integrity="sha384-..." crossorigin="anonymous"
 //
 // # CLI Commands
 //
@@ -34,5 +87,6 @@
 //
 //	markata-go assets download   # Download all CDN assets to cache
 //	markata-go assets list       # Show status of all assets
+//	markata-go assets verify     # Re-hash cached assets against the registry
 //	markata-go assets clean      # Remove cached assets
 package assets
@@ -5,13 +5,10 @@ import (
 	"bytes"
 	"compress/gzip"
 	"context"
-	"crypto/sha256"
-	"crypto/sha512"
-	"encoding/base64"
 	"errors"
 	"fmt"
-	"hash"
 	"io"
+	"net"
 	"net/http"
 	"os"
 	"path"
@@ -20,11 +17,19 @@ import (
 	"sync"
 	"time"
 
+	"github.com/WaylonWalker/markata-go/pkg/models"
 	"github.com/WaylonWalker/markata-go/pkg/runtimeenv"
 )
 
 const maxArchiveExtractBytes = 64 << 20
 
+// Download defaults used when the config does not set them.
+const (
+	DefaultConcurrency  = 4
+	DefaultMaxAttempts  = 3
+	defaultRetryBackoff = 500 * time.Millisecond
+)
+
 // Common errors for asset downloading.
 var (
 	ErrAssetNotFound     = errors.New("asset not found in registry")
@@ -39,6 +44,16 @@ type DownloadResult struct {
 	Error    error
 	Size     int64
 	Duration time.Duration
+	// Attempts is the number of requests made; zero for cached assets.
+	Attempts int
+}
+
+// DownloadProgress reports that one asset of a batch has finished,
+// successfully or not.
+type DownloadProgress struct {
+	Completed int
+	Total     int
+	Result    DownloadResult
 }
 
 // Downloader handles downloading and caching of CDN assets.
@@ -49,6 +64,10 @@ type Downloader struct {
 	offline           bool
 	httpClient        *http.Client
 	userAgent         string
+	concurrency       int
+	maxAttempts       int
+	retryBackoff      time.Duration
+	onProgress        func(DownloadProgress)
 }
 
 // NewDownloader creates a new asset downloader.
@@ -60,7 +79,10 @@ func NewDownloader(cacheDir string, verifyIntegrity bool) *Downloader {
 		httpClient: &http.Client{
 			Timeout: 60 * time.Second,
 		},
-		userAgent: "markata-go/1.0 (CDN Asset Downloader)",
+		userAgent:    "markata-go/1.0 (CDN Asset Downloader)",
+		concurrency:  DefaultConcurrency,
+		maxAttempts:  DefaultMaxAttempts,
+		retryBackoff: defaultRetryBackoff,
 	}
 	if bundledDir := runtimeenv.BundledAssetsCacheDir(); bundledDir != "" && bundledDir != cacheDir {
 		d.fallbackCacheDirs = append(d.fallbackCacheDirs, bundledDir)
@@ -68,6 +90,41 @@ func NewDownloader(cacheDir string, verifyIntegrity bool) *Downloader {
 	return d
 }
 
+// NewDownloaderFromConfig creates a downloader using the cache directory,
+// integrity, parallelism, and retry settings from an AssetsConfig.
+func NewDownloaderFromConfig(cfg *models.AssetsConfig) *Downloader {
+	d := NewDownloader(cfg.GetCacheDir(), cfg.IsVerifyIntegrityEnabled())
+	d.SetConcurrency(cfg.GetConcurrency())
+	d.SetMaxAttempts(cfg.GetMaxAttempts())
+	return d
+}
+
+// SetConcurrency sets how many downloads run at once when a batch method
+// is called with a concurrency of zero. Values below 1 restore the default.
+func (d *Downloader) SetConcurrency(n int) {
+	if n < 1 {
+		n = DefaultConcurrency
+	}
+	d.concurrency = n
+}
+
+// SetMaxAttempts sets how many times a download is tried before it fails.
+// Only transient failures are retried: network errors other than unknown
+// hosts, and HTTP 408, 429, and 5xx responses. Values below 1 restore the default.
+func (d *Downloader) SetMaxAttempts(n int) {
+	if n < 1 {
+		n = DefaultMaxAttempts
+	}
+	d.maxAttempts = n
+}
+
+// SetProgress registers a callback invoked once per asset as batch
+// downloads finish. Calls are serialized, so fn need not be safe for
+// concurrent use.
+func (d *Downloader) SetProgress(fn func(DownloadProgress)) {
+	d.onProgress = fn
+}
+
 // Download downloads a single asset to the cache directory.
 // Returns the cached file path on success.
 func (d *Downloader) Download(ctx context.Context, asset Asset) (*DownloadResult, error) {
@@ -81,13 +138,20 @@ func (d *Downloader) Download(ctx context.Context, asset Asset) (*DownloadResult
 		}
 		return result, nil
 	}
+
+	return d.refresh(ctx, asset, result, start)
+}
+
+// refresh downloads an asset into the cache directory, replacing any
+// cached copy.
+func (d *Downloader) refresh(ctx context.Context, asset Asset, result *DownloadResult, start time.Time) (*DownloadResult, error) {
 	if d.offline {
 		result.Error = fmt.Errorf("%w: %s not available in cache while offline", ErrDownloadFailed, asset.Name)
 		return result, result.Error
 	}
 
 	cachedPath := d.getCachePath(asset)
-	data, err := d.fetchAsset(ctx, asset, cachedPath)
+	data, err := d.fetchWithRetry(ctx, asset, cachedPath, result)
 	if err != nil {
 		result.Error = err
 		return result, result.Error
@@ -127,6 +191,62 @@ func (d *Downloader) restoreCachedResult(result *DownloadResult, asset Asset, st
 	return true, nil
 }
 
+// fetchWithRetry fetches an asset, retrying transient failures with
+// exponential backoff until maxAttempts is reached or ctx is done.
+func (d *Downloader) fetchWithRetry(ctx context.Context, asset Asset, cachedPath string, result *DownloadResult) ([]byte, error) {
+	backoff := d.retryBackoff
+	for attempt := 1; ; attempt++ {
+		result.Attempts = attempt
+		data, err := d.fetchAsset(ctx, asset, cachedPath)
+		if err == nil {
+			return data, nil
+		}
+		if !isTransient(err) || ctx.Err() != nil {
+			return nil, err
+		}
+		if attempt >= d.maxAttempts {
+			if attempt > 1 {
+				return nil, fmt.Errorf("%w (after %d attempts)", err, attempt)
+			}
+			return nil, err
+		}
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, fmt.Errorf("%w: %w", ErrDownloadFailed, ctx.Err())
+		case <-timer.C:
+		}
+		backoff *= 2
+	}
+}
+
+// httpStatusError is a non-200 response from the asset's CDN.
+type httpStatusError int
+
+func (e httpStatusError) Error() string {
+	return fmt.Sprintf("HTTP %d", int(e))
+}
+
+// transientError marks a network failure that may succeed on retry.
+type transientError struct {
+	err error
+}
+
+func (e *transientError) Error() string { return e.err.Error() }
+func (e *transientError) Unwrap() error { return e.err }
+
+// isTransient reports whether a fetch error is worth retrying.
+func isTransient(err error) bool {
+	var status httpStatusError
+	if errors.As(err, &status) {
+		return status == http.StatusRequestTimeout || status == http.StatusTooManyRequests || status >= 500
+	}
+	var transient *transientError
+	return errors.As(err, &transient)
+}
+
 func (d *Downloader) fetchAsset(ctx context.Context, asset Asset, cachedPath string) ([]byte, error) {
 	if err := d.ensureCacheDir(asset, cachedPath); err != nil {
 		return nil, err
@@ -140,17 +260,21 @@ func (d *Downloader) fetchAsset(ctx context.Context, asset Asset, cachedPath str
 
 	resp, err := d.httpClient.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("%w: %w", ErrDownloadFailed, err)
+		if dnsErr := (*net.DNSError)(nil); errors.As(err, &dnsErr) && dnsErr.IsNotFound {
+			// An unknown host will not resolve on retry, e.g. when offline.
+			return nil, fmt.Errorf("%w: %w", ErrDownloadFailed, err)
+		}
+		return nil, fmt.Errorf("%w: %w", ErrDownloadFailed, &transientError{err})
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("%w: HTTP %d", ErrDownloadFailed, resp.StatusCode)
+		return nil, fmt.Errorf("%w: %w", ErrDownloadFailed, httpStatusError(resp.StatusCode))
 	}
 
 	data, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("read response: %w", err)
+		return nil, fmt.Errorf("read response: %w", &transientError{err})
 	}
 	if d.verifyIntegrity && asset.Integrity != "" {
 		if err := verifyIntegrity(data, asset.Integrity); err != nil {
@@ -194,15 +318,34 @@ func (d *Downloader) writeAssetToCache(asset Asset, data []byte, cachedPath stri
 	return nil
 }
 
-// DownloadAssets downloads the provided assets concurrently.
+// DownloadAssets downloads the provided assets concurrently. A concurrency
+// of zero or less uses the downloader's configured parallelism. A failed
+// asset does not stop the others; check each result's Error.
 func (d *Downloader) DownloadAssets(ctx context.Context, assets []Asset, concurrency int) []DownloadResult {
+	return d.downloadConcurrently(ctx, assets, concurrency, d.Download)
+}
+
+// downloadConcurrently runs download for each asset with at most
+// concurrency downloads in flight, reporting each finished asset to the
+// progress callback.
+func (d *Downloader) downloadConcurrently(
+	ctx context.Context,
+	assets []Asset,
+	concurrency int,
+	download func(context.Context, Asset) (*DownloadResult, error),
+) []DownloadResult {
+	if concurrency <= 0 {
+		concurrency = d.concurrency
+	}
 	if concurrency <= 0 {
-		concurrency = 4
+		concurrency = DefaultConcurrency
 	}
 
 	results := make([]DownloadResult, len(assets))
 	semaphore := make(chan struct{}, concurrency)
 	var wg sync.WaitGroup
+	var progressMu sync.Mutex
+	completed := 0
 
 	for i := range assets {
 		asset := assets[i]
@@ -212,11 +355,18 @@ func (d *Downloader) DownloadAssets(ctx context.Context, assets []Asset, concurr
 			semaphore <- struct{}{}
 			defer func() { <-semaphore }()
 
-			result, err := d.Download(ctx, a)
+			result, err := download(ctx, a)
 			if err != nil {
 				result.Error = err
 			}
 			results[idx] = *result
+
+			if d.onProgress != nil {
+				progressMu.Lock()
+				completed++
+				d.onProgress(DownloadProgress{Completed: completed, Total: len(assets), Result: *result})
+				progressMu.Unlock()
+			}
 		}(i, asset)
 	}
 
@@ -224,7 +374,8 @@ func (d *Downloader) DownloadAssets(ctx context.Context, assets []Asset, concurr
 	return results
 }
 
-// DownloadAll downloads all registered assets concurrently.
+// DownloadAll downloads all registered assets concurrently. A concurrency
+// of zero or less uses the downloader's configured parallelism.
 func (d *Downloader) DownloadAll(ctx context.Context, concurrency int) []DownloadResult {
 	return d.DownloadAssets(ctx, Registry(), concurrency)
 }
@@ -317,6 +468,17 @@ func (d *Downloader) Status() []AssetStatus {
 	return statuses
 }
 
+// FailedDownloads returns the results that ended in an error.
+func FailedDownloads(results []DownloadResult) []DownloadResult {
+	var failed []DownloadResult
+	for i := range results {
+		if results[i].Error != nil {
+			failed = append(failed, results[i])
+		}
+	}
+	return failed
+}
+
 // AssetStatus represents the status of an asset.
 type AssetStatus struct {
 	Asset    Asset
@@ -339,11 +501,11 @@ func (d *Downloader) isArchiveAsset(asset Asset) bool {
 }
 
 func (d *Downloader) getCachePathForRoot(root string, asset Asset) string {
-	return filepath.Join(root, asset.LocalPath)
+	return filepath.Join(root, asset.cachePath())
 }
 
 func (d *Downloader) getArchiveMarkerPathForRoot(root string, asset Asset) string {
-	return filepath.Join(root, asset.LocalPath+".complete")
+	return filepath.Join(root, asset.cachePath()+".complete")
 }
 
 func (d *Downloader) cacheRoots() []string {
@@ -506,34 +668,18 @@ func copyDir(dst, src string) error {
 }
 
 // verifyIntegrity verifies the integrity of data against an SRI hash.
-// Supports sha256, sha384, and sha512 prefixed hashes.
+// Supports sha256, sha384, and sha512 prefixed hashes; when the value lists
+// several hashes, every one must match.
 func verifyIntegrity(data []byte, integrity string) error {
-	// SRI format: algorithm-base64hash
-	parts := strings.SplitN(integrity, "-", 2)
-	if len(parts) != 2 {
-		return fmt.Errorf("invalid integrity format: %s", integrity)
-	}
-
-	algorithm := parts[0]
-	expectedHash := parts[1]
-
-	var hasher hash.Hash
-	switch algorithm {
-	case "sha256":
-		hasher = sha256.New()
-	case "sha384":
-		hasher = sha512.New384()
-	case "sha512":
-		hasher = sha512.New()
-	default:
-		return fmt.Errorf("unsupported hash algorithm: %s", algorithm)
+	checks, err := checkIntegrity(data, integrity)
+	if err != nil {
+		return err
 	}
 
-	hasher.Write(data)
-	actualHash := base64.StdEncoding.EncodeToString(hasher.Sum(nil))
-
-	if actualHash != expectedHash {
-		return fmt.Errorf("hash mismatch: expected %s, got %s", expectedHash, actualHash)
+	for _, check := range checks {
+		if !check.OK {
+			return fmt.Errorf("%s hash mismatch: expected %s, got %s", check.Algorithm, check.Expected, check.Actual)
+		}
 	}
 
 	return nil
@@ -5,10 +5,14 @@ import (
 	"bytes"
 	"compress/gzip"
 	"context"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -440,6 +444,202 @@ func TestDownloader_DownloadAll(t *testing.T) {
 	_ = d.DownloadAll(ctx, 2)
 }
 
+// flakyServer fails the first failures requests for each path with status,
+// then serves "test content". It returns the server and a per-path hit count.
+func flakyServer(t *testing.T, failures, status int) (*httptest.Server, func(path string) int) {
+	t.Helper()
+	var mu sync.Mutex
+	hits := map[string]int{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		hits[r.URL.Path]++
+		n := hits[r.URL.Path]
+		mu.Unlock()
+		if n <= failures || strings.Contains(r.URL.Path, "always-fails") {
+			w.WriteHeader(status)
+			return
+		}
+		if _, err := w.Write([]byte("test content")); err != nil {
+			t.Logf("failed to write response: %v", err)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server, func(path string) int {
+		mu.Lock()
+		defer mu.Unlock()
+		return hits[path]
+	}
+}
+
+func newRetryingDownloader(t *testing.T, maxAttempts int) *Downloader {
+	t.Helper()
+	d := NewDownloader(t.TempDir(), true)
+	d.offline = false
+	d.SetMaxAttempts(maxAttempts)
+	d.retryBackoff = time.Millisecond
+	return d
+}
+
+func TestDownloader_Download_RetriesTransientErrors(t *testing.T) {
+	server, hits := flakyServer(t, 2, http.StatusServiceUnavailable)
+	d := newRetryingDownloader(t, 3)
+
+	asset := Asset{Name: "flaky", URL: server.URL + "/flaky.js", LocalPath: "flaky/flaky.js", Type: "js"}
+	result, err := d.Download(context.Background(), asset)
+	if err != nil {
+		t.Fatalf("Download() error = %v", err)
+	}
+	if result.Attempts != 3 || hits("/flaky.js") != 3 {
+		t.Errorf("Attempts = %d, hits = %d, want 3 and 3", result.Attempts, hits("/flaky.js"))
+	}
+	if !d.IsCached(asset) {
+		t.Error("expected asset to be cached after retry succeeded")
+	}
+}
+
+func TestDownloader_Download_GivesUpAfterMaxAttempts(t *testing.T) {
+	server, hits := flakyServer(t, 5, http.StatusBadGateway)
+	d := newRetryingDownloader(t, 2)
+
+	asset := Asset{Name: "flaky", URL: server.URL + "/flaky.js", LocalPath: "flaky/flaky.js", Type: "js"}
+	result, err := d.Download(context.Background(), asset)
+	if !errors.Is(err, ErrDownloadFailed) {
+		t.Fatalf("Download() error = %v, want ErrDownloadFailed", err)
+	}
+	if !strings.Contains(err.Error(), "HTTP 502 (after 2 attempts)") {
+		t.Errorf("error = %q, want it to mention the status and attempts", err)
+	}
+	if result.Attempts != 2 || hits("/flaky.js") != 2 {
+		t.Errorf("Attempts = %d, hits = %d, want 2 and 2", result.Attempts, hits("/flaky.js"))
+	}
+}
+
+func TestDownloader_Download_DoesNotRetryPermanentErrors(t *testing.T) {
+	server, hits := flakyServer(t, 1, http.StatusNotFound)
+	d := newRetryingDownloader(t, 3)
+
+	asset := Asset{Name: "gone", URL: server.URL + "/gone.js", LocalPath: "gone/gone.js", Type: "js"}
+	if _, err := d.Download(context.Background(), asset); err == nil {
+		t.Fatal("expected error for 404 response")
+	}
+	if got := hits("/gone.js"); got != 1 {
+		t.Errorf("hits = %d, want 1", got)
+	}
+}
+
+func TestDownloader_Download_DoesNotRetryUnknownHost(t *testing.T) {
+	d := newRetryingDownloader(t, 3)
+	d.retryBackoff = time.Hour
+
+	asset := Asset{Name: "nowhere", URL: "http://assets.invalid/nowhere.js", LocalPath: "nowhere.js", Type: "js"}
+	result, err := d.Download(context.Background(), asset)
+	if err == nil {
+		t.Fatal("expected error for unknown host")
+	}
+	if result.Attempts != 1 {
+		t.Errorf("Attempts = %d, want 1", result.Attempts)
+	}
+}
+
+func TestDownloader_Download_VerifiesIntegrityAfterRetry(t *testing.T) {
+	server, hits := flakyServer(t, 1, http.StatusServiceUnavailable)
+	d := newRetryingDownloader(t, 3)
+
+	asset := Asset{
+		Name:      "tampered",
+		URL:       server.URL + "/tampered.js",
+		LocalPath: "tampered/tampered.js",
+		Type:      "js",
+		Integrity: "sha256-invalidhash",
+	}
+	_, err := d.Download(context.Background(), asset)
+	if !errors.Is(err, ErrIntegrityMismatch) {
+		t.Fatalf("Download() error = %v, want ErrIntegrityMismatch", err)
+	}
+	if got := hits("/tampered.js"); got != 2 {
+		t.Errorf("hits = %d, want 2 (mismatches are not retried)", got)
+	}
+	if d.IsCached(asset) {
+		t.Error("asset failing integrity must not be cached")
+	}
+}
+
+func TestDownloader_Download_RetryStopsWhenCanceled(t *testing.T) {
+	server, _ := flakyServer(t, 5, http.StatusServiceUnavailable)
+	d := newRetryingDownloader(t, 5)
+	d.retryBackoff = time.Hour
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	asset := Asset{Name: "slow", URL: server.URL + "/slow.js", LocalPath: "slow/slow.js", Type: "js"}
+	_, err := d.Download(ctx, asset)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Download() error = %v, want context.DeadlineExceeded", err)
+	}
+}
+
+func TestDownloader_DownloadAssets_ProgressAndFailures(t *testing.T) {
+	server, hits := flakyServer(t, 1, http.StatusServiceUnavailable)
+	d := newRetryingDownloader(t, 3)
+	d.SetConcurrency(2)
+
+	var inFlight, maxInFlight atomic.Int32
+	d.httpClient = &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			peak := maxInFlight.Load()
+			if n <= peak || maxInFlight.CompareAndSwap(peak, n) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		return http.DefaultTransport.RoundTrip(req)
+	})}
+
+	var list []Asset
+	for _, name := range []string{"a", "b", "always-fails", "c", "d"} {
+		list = append(list, Asset{Name: name, URL: server.URL + "/" + name + ".js", LocalPath: name + ".js", Type: "js"})
+	}
+
+	var progress []DownloadProgress
+	d.SetProgress(func(p DownloadProgress) {
+		progress = append(progress, p)
+	})
+
+	results := d.DownloadAssets(context.Background(), list, 0)
+
+	if len(progress) != len(list) {
+		t.Fatalf("progress called %d times, want %d", len(progress), len(list))
+	}
+	for i, p := range progress {
+		if p.Completed != i+1 || p.Total != len(list) {
+			t.Errorf("progress[%d] = %d/%d, want %d/%d", i, p.Completed, p.Total, i+1, len(list))
+		}
+	}
+	if peak := maxInFlight.Load(); peak > 2 {
+		t.Errorf("max concurrent requests = %d, want at most 2", peak)
+	}
+
+	failed := FailedDownloads(results)
+	if len(failed) != 1 || failed[0].Asset.Name != "always-fails" {
+		t.Fatalf("FailedDownloads() = %v, want only always-fails", failed)
+	}
+	if got := hits("/always-fails.js"); got != 3 {
+		t.Errorf("always-fails hits = %d, want 3", got)
+	}
+	for i := range results {
+		if results[i].Error == nil && !d.IsCached(results[i].Asset) {
+			t.Errorf("%s: expected asset to be cached", results[i].Asset.Name)
+		}
+	}
+}
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) { return f(req) }
+
 func TestVerifyIntegrity(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -477,6 +677,12 @@ func TestVerifyIntegrity(t *testing.T) {
 			integrity: "sha384-WeF0h3dEjGnea4ANejO7+5/xtGPkQ1TDVTvNucZm+pASWjx5+QOXvfX2oT3oKGhP",
 			wantErr:   false,
 		},
+		{
+			name:      "multiple hashes with one mismatch",
+			data:      []byte("hello"),
+			integrity: "sha256-LPJNul+wow4m6DsqxbninhsWHlwfp0JecwQzYpOLmCQ= sha384-invalidhash",
+			wantErr:   true,
+		},
 		{
 			name:      "valid sha512",
 			data:      []byte("hello"),
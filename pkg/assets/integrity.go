@@ -0,0 +1,141 @@
+package assets
+
+import (
+	"crypto/sha512"
+	"encoding/base64"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+var (
+	integrityMu sync.Mutex
+
+	// integrityDir is the directory holding self-hosted copies, as passed
+	// to CopyAssetsToOutput
+	integrityDir string
+
+	// integrityValues caches SRI values by asset name
+	integrityValues = map[string]string{}
+)
+
+var (
+	// Matches opening <script> and <link> tags
+	integrityTagRe = regexp.MustCompile(`(?is)<(?:script|link)\b[^>]*>`)
+
+	// Captures the src or href of a tag
+	integrityURLRe = regexp.MustCompile(`(?is)\s(?:src|href)\s*=\s*(?:"([^"]*)"|'([^']*)')`)
+
+	// Matches existing integrity and crossorigin attributes
+	integrityAttrRe   = regexp.MustCompile(`(?i)\sintegrity\s*=`)
+	crossOriginAttrRe = regexp.MustCompile(`(?i)\scrossorigin\b`)
+)
+
+// SetIntegrityDir sets the directory holding self-hosted asset copies that
+// IntegrityAttr hashes, and clears previously computed values.
+func SetIntegrityDir(dir string) {
+	integrityMu.Lock()
+	defer integrityMu.Unlock()
+	integrityDir = dir
+	integrityValues = map[string]string{}
+}
+
+// IntegrityAttr returns the SRI attributes for a self-hosted asset, e.g.
+// `integrity="sha384-..." crossorigin="anonymous"`.
+//
+// The registered hash is used when it matches the copy under the directory
+// from SetIntegrityDir. When the asset has no registered hash, or the copy was
+// changed after download (e.g. by minification), the sha384 of the copy is
+// used instead. Without a readable copy only a registered hash can be used.
+func IntegrityAttr(key string) (string, error) {
+	value, err := integrityValue(key)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("integrity=%q crossorigin=%q", value, "anonymous"), nil
+}
+
+// integrityValue returns the SRI value for the named asset, caching it.
+func integrityValue(key string) (string, error) {
+	integrityMu.Lock()
+	defer integrityMu.Unlock()
+
+	if value, ok := integrityValues[key]; ok {
+		return value, nil
+	}
+
+	asset := GetAsset(key)
+	if asset == nil {
+		return "", fmt.Errorf("unknown asset %q", key)
+	}
+	if asset.ExtractPath != "" {
+		return "", fmt.Errorf("%s: archive assets have no single file to hash", key)
+	}
+
+	var value string
+	data, err := os.ReadFile(filepath.Join(integrityDir, asset.PublishPath()))
+	switch {
+	case err == nil && asset.Integrity != "" && verifyIntegrity(data, asset.Integrity) == nil:
+		value = asset.Integrity
+	case err == nil:
+		sum := sha512.Sum384(data)
+		value = "sha384-" + base64.StdEncoding.EncodeToString(sum[:])
+	case asset.Integrity != "":
+		value = asset.Integrity
+	default:
+		return "", fmt.Errorf("%s: no registered hash and no local copy: %w", key, err)
+	}
+
+	integrityValues[key] = value
+	return value, nil
+}
+
+// InjectIntegrity adds integrity and crossorigin attributes to the <script>
+// and <link> tags in htmlContent whose src or href is a key of urls, which maps
+// self-hosted URLs to asset names. Tags that already have an integrity
+// attribute are left alone, and an existing crossorigin attribute is kept.
+// Assets whose hash cannot be determined are skipped.
+func InjectIntegrity(htmlContent string, urls map[string]string) string {
+	if len(urls) == 0 {
+		return htmlContent
+	}
+
+	return integrityTagRe.ReplaceAllStringFunc(htmlContent, func(tag string) string {
+		if integrityAttrRe.MatchString(tag) {
+			return tag
+		}
+		match := integrityURLRe.FindStringSubmatch(tag)
+		if match == nil {
+			return tag
+		}
+		ref := match[1] + match[2]
+		key, ok := urls[ref]
+		if !ok {
+			key, ok = urls[strings.SplitN(ref, "?", 2)[0]]
+		}
+		if !ok {
+			return tag
+		}
+
+		value, err := integrityValue(key)
+		if err != nil {
+			return tag
+		}
+		attrs := fmt.Sprintf(" integrity=%q", value)
+		if !crossOriginAttrRe.MatchString(tag) {
+			attrs += fmt.Sprintf(" crossorigin=%q", "anonymous")
+		}
+
+		end := len(tag) - 1
+		if strings.HasSuffix(tag, "/>") {
+			end = len(tag) - 2
+			for end > 0 && tag[end-1] == ' ' {
+				end--
+			}
+		}
+		return tag[:end] + attrs + tag[end:]
+	})
+}
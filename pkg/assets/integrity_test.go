@@ -0,0 +1,171 @@
+package assets
+
+import (
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeIntegrityFixture writes a self-hosted copy under dir and points
+// SetIntegrityDir at it for the duration of the test.
+func writeIntegrityFixture(t *testing.T, dir, localPath, content string) {
+	t.Helper()
+	path := filepath.Join(dir, filepath.FromSlash(localPath))
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatalf("MkdirAll: %v", err)
+	}
+	//nolint:gosec // Test files need 0644
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	SetIntegrityDir(dir)
+	t.Cleanup(func() { SetIntegrityDir("") })
+}
+
+func sha384Integrity(content string) string {
+	sum := sha512.Sum384([]byte(content))
+	return "sha384-" + base64.StdEncoding.EncodeToString(sum[:])
+}
+
+func TestIntegrityAttr_ComputedFromLocalCopy(t *testing.T) {
+	setTestCustomAssets(t, Asset{
+		Name:      "mylib",
+		URL:       "https://example.com/mylib.min.js",
+		LocalPath: "mylib/mylib.min.js",
+		Type:      "js",
+	})
+	writeIntegrityFixture(t, t.TempDir(), "mylib/mylib.min.js", "console.log(1)")
+
+	got, err := IntegrityAttr("mylib")
+	if err != nil {
+		t.Fatalf("IntegrityAttr() error = %v", err)
+	}
+	want := `integrity="` + sha384Integrity("console.log(1)") + `" crossorigin="anonymous"`
+	if got != want {
+		t.Errorf("IntegrityAttr() = %q, want %q", got, want)
+	}
+}
+
+func TestIntegrityAttr_RegisteredHash(t *testing.T) {
+	content := "body{}"
+	sum := sha256.Sum256([]byte(content))
+	registered := "sha256-" + base64.StdEncoding.EncodeToString(sum[:])
+	setTestCustomAssets(t, Asset{
+		Name:      "mylib",
+		URL:       "https://example.com/mylib.css",
+		LocalPath: "mylib/mylib.css",
+		Integrity: registered,
+		Type:      "css",
+	})
+
+	dir := t.TempDir()
+	writeIntegrityFixture(t, dir, "mylib/mylib.css", content)
+	got, err := IntegrityAttr("mylib")
+	if err != nil {
+		t.Fatalf("IntegrityAttr() error = %v", err)
+	}
+	if want := `integrity="` + registered + `" crossorigin="anonymous"`; got != want {
+		t.Errorf("IntegrityAttr() = %q, want %q", got, want)
+	}
+
+	// A copy changed after download gets a hash of what is actually served
+	writeIntegrityFixture(t, dir, "mylib/mylib.css", "body{color:red}")
+	got, err = IntegrityAttr("mylib")
+	if err != nil {
+		t.Fatalf("IntegrityAttr() error = %v", err)
+	}
+	if want := `integrity="` + sha384Integrity("body{color:red}") + `" crossorigin="anonymous"`; got != want {
+		t.Errorf("IntegrityAttr() after change = %q, want %q", got, want)
+	}
+}
+
+func TestIntegrityAttr_Errors(t *testing.T) {
+	SetIntegrityDir(t.TempDir())
+	t.Cleanup(func() { SetIntegrityDir("") })
+
+	if _, err := IntegrityAttr("no-such-asset"); err == nil {
+		t.Error("expected an error for an unknown asset")
+	}
+
+	setTestCustomAssets(t, Asset{
+		Name:      "mylib",
+		URL:       "https://example.com/mylib.js",
+		LocalPath: "mylib/mylib.js",
+		Type:      "js",
+	})
+	if _, err := IntegrityAttr("mylib"); err == nil {
+		t.Error("expected an error without a registered hash or local copy")
+	}
+}
+
+func TestInjectIntegrity(t *testing.T) {
+	setTestCustomAssets(t, Asset{
+		Name:      "mylib",
+		URL:       "https://example.com/mylib.min.js",
+		LocalPath: "mylib/mylib.min.js",
+		Type:      "js",
+	}, Asset{
+		Name:      "mylib-css",
+		URL:       "https://example.com/mylib.min.css",
+		LocalPath: "mylib/mylib.min.css",
+		Type:      "css",
+	})
+	dir := t.TempDir()
+	writeIntegrityFixture(t, dir, "mylib/mylib.min.js", "js")
+	writeIntegrityFixture(t, dir, "mylib/mylib.min.css", "css")
+	jsHash := sha384Integrity("js")
+	cssHash := sha384Integrity("css")
+
+	urls := map[string]string{
+		"/assets/vendor/mylib/mylib.min.js":  "mylib",
+		"/assets/vendor/mylib/mylib.min.css": "mylib-css",
+	}
+
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{
+			name: "script",
+			in:   `<script src="/assets/vendor/mylib/mylib.min.js"></script>`,
+			want: `<script src="/assets/vendor/mylib/mylib.min.js" integrity="` + jsHash + `" crossorigin="anonymous"></script>`,
+		},
+		{
+			name: "self-closing link with query",
+			in:   `<link rel="stylesheet" href='/assets/vendor/mylib/mylib.min.css?v=1' />`,
+			want: `<link rel="stylesheet" href='/assets/vendor/mylib/mylib.min.css?v=1' integrity="` + cssHash + `" crossorigin="anonymous" />`,
+		},
+		{
+			name: "existing crossorigin is kept",
+			in:   `<script defer crossorigin="use-credentials" src="/assets/vendor/mylib/mylib.min.js"></script>`,
+			want: `<script defer crossorigin="use-credentials" src="/assets/vendor/mylib/mylib.min.js" integrity="` + jsHash + `"></script>`,
+		},
+		{
+			name: "existing integrity is left alone",
+			in:   `<script src="/assets/vendor/mylib/mylib.min.js" integrity="sha384-x"></script>`,
+			want: `<script src="/assets/vendor/mylib/mylib.min.js" integrity="sha384-x"></script>`,
+		},
+		{
+			name: "other tags are untouched",
+			in:   `<script src="/js/site.js"></script><a href="/assets/vendor/mylib/mylib.min.js">x</a>`,
+			want: `<script src="/js/site.js"></script><a href="/assets/vendor/mylib/mylib.min.js">x</a>`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := InjectIntegrity(tt.in, urls); got != tt.want {
+				t.Errorf("InjectIntegrity() =\n%s\nwant:\n%s", got, tt.want)
+			}
+		})
+	}
+
+	if got := InjectIntegrity(tests[0].in, nil); !strings.Contains(got, "></script>") || strings.Contains(got, "integrity") {
+		t.Errorf("InjectIntegrity() without urls = %q, want input unchanged", got)
+	}
+}
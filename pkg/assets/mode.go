@@ -0,0 +1,53 @@
+package assets
+
+import (
+	"strings"
+
+	"github.com/WaylonWalker/markata-go/pkg/models"
+)
+
+// Asset modes for AssetsConfig.Mode.
+const (
+	ModeCDN        = "cdn"
+	ModeSelfHosted = "self-hosted"
+	ModeAuto       = "auto"
+)
+
+// Build environments ResolveMode tells apart.
+const (
+	EnvDevelopment = "development"
+	EnvProduction  = "production"
+)
+
+// ResolveMode returns the mode a build in env uses, ModeCDN or
+// ModeSelfHosted. ModeAuto loads assets from the CDN in development, for
+// fast iteration without downloads, and self-hosts them with integrity
+// attributes in production. env is EnvDevelopment (or "dev" or "local");
+// any other value, including "", is production. Other modes are returned
+// as configured, with an empty mode meaning ModeCDN.
+func ResolveMode(cfg *models.AssetsConfig, env string) string {
+	if cfg == nil {
+		return ModeCDN
+	}
+	switch cfg.Mode {
+	case ModeAuto:
+		if IsDevelopmentEnv(env) {
+			return ModeCDN
+		}
+		return ModeSelfHosted
+	case ModeSelfHosted:
+		return ModeSelfHosted
+	default:
+		return ModeCDN
+	}
+}
+
+// IsDevelopmentEnv reports whether env names a development environment.
+func IsDevelopmentEnv(env string) bool {
+	switch strings.ToLower(strings.TrimSpace(env)) {
+	case EnvDevelopment, "dev", "local":
+		return true
+	default:
+		return false
+	}
+}
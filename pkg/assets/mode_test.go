@@ -0,0 +1,35 @@
+package assets
+
+import (
+	"testing"
+
+	"github.com/WaylonWalker/markata-go/pkg/models"
+)
+
+func TestResolveMode(t *testing.T) {
+	tests := []struct {
+		mode string
+		env  string
+		want string
+	}{
+		{ModeAuto, EnvDevelopment, ModeCDN},
+		{ModeAuto, "dev", ModeCDN},
+		{ModeAuto, "Local", ModeCDN},
+		{ModeAuto, EnvProduction, ModeSelfHosted},
+		{ModeAuto, "", ModeSelfHosted},
+		{ModeAuto, "staging", ModeSelfHosted},
+		{ModeSelfHosted, EnvDevelopment, ModeSelfHosted},
+		{ModeCDN, EnvProduction, ModeCDN},
+		{"", EnvProduction, ModeCDN},
+	}
+	for _, tt := range tests {
+		cfg := &models.AssetsConfig{Mode: tt.mode}
+		if got := ResolveMode(cfg, tt.env); got != tt.want {
+			t.Errorf("ResolveMode(%q, %q) = %q, want %q", tt.mode, tt.env, got, tt.want)
+		}
+	}
+
+	if got := ResolveMode(nil, EnvProduction); got != ModeCDN {
+		t.Errorf("ResolveMode(nil) = %q, want %q", got, ModeCDN)
+	}
+}
@@ -0,0 +1,204 @@
+package assets
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Pin overrides the version of a registry asset.
+type Pin struct {
+	// Version replaces the registered version in the asset's CDN URL
+	Version string
+
+	// Integrity is the SRI hash for the pinned version. Empty disables
+	// integrity verification for the asset, since the registered hash
+	// belongs to a different version.
+	Integrity string
+}
+
+var (
+	pinsMu sync.RWMutex
+	pins   map[string]Pin
+)
+
+// SetVersionPins configures the version pins consulted by Registry and
+// GetAsset. Call it after SetCustomAssets so custom assets can be pinned. Keys are asset names (e.g. "glightbox-js") or library names
+// (e.g. "glightbox"), which pin every asset of that library; an asset name
+// takes precedence over its library. integrity supplies SRI hashes for
+// pinned assets, keyed the same way. Passing nil maps clears all pins.
+func SetVersionPins(versions, integrity map[string]string) error {
+	resolved := make(map[string]Pin, len(versions))
+	for key, version := range versions {
+		version = strings.TrimSpace(version)
+		if version == "" {
+			return fmt.Errorf("assets.versions: empty version for %q", key)
+		}
+		if !isPinKey(key) {
+			return fmt.Errorf("assets.versions: unknown asset %q", key)
+		}
+		resolved[key] = Pin{Version: version, Integrity: integrity[key]}
+	}
+	for key := range integrity {
+		if _, ok := resolved[key]; !ok {
+			return fmt.Errorf("assets.integrity: %q has no pinned version in assets.versions", key)
+		}
+	}
+
+	// Reject pins whose version cannot be placed in the asset's URL.
+	registered := registeredAssets()
+	for i := range registered {
+		asset := registered[i]
+		if pin, ok := lookupPin(resolved, asset); ok {
+			if _, err := asset.WithVersion(pin.Version, pin.Integrity); err != nil {
+				return err
+			}
+		}
+	}
+
+	pinsMu.Lock()
+	defer pinsMu.Unlock()
+	if len(resolved) == 0 {
+		pins = nil
+		return nil
+	}
+	pins = resolved
+	return nil
+}
+
+// PinnedAssets returns the registry assets affected by version pins,
+// with the pins applied, sorted by name.
+func PinnedAssets() []Asset {
+	pinsMu.RLock()
+	defer pinsMu.RUnlock()
+
+	var result []Asset
+	registered := registeredAssets()
+	for i := range registered {
+		asset := registered[i]
+		if pin, ok := lookupPin(pins, asset); ok {
+			result = append(result, applyPin(asset, pin))
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+	return result
+}
+
+// WithVersion returns a copy of the asset pointing at a different version.
+// The version in the CDN URL is rewritten and the asset is cached under a
+// version-specific path so it never reuses a download of another version.
+// The registered SRI hash is replaced by integrity, which may be empty.
+func (a Asset) WithVersion(version, integrity string) (Asset, error) {
+	if version == a.Version {
+		if integrity != "" {
+			a.Integrity = integrity
+		}
+		return a, nil
+	}
+	if a.Version == "" {
+		return a, fmt.Errorf("asset %s has no registered version to replace", a.Name)
+	}
+
+	// Versions appear in CDN URLs as "pkg@1.2.3", "pkg-1.2.3.tgz", or "pkg.v7.js"
+	patterns := [][2]string{
+		{"@" + a.Version + "/", "@" + version + "/"},
+		{"-" + a.Version + ".tgz", "-" + version + ".tgz"},
+		{".v" + a.Version + ".", ".v" + version + "."},
+	}
+	rewritten := ""
+	for _, pattern := range patterns {
+		if strings.Contains(a.URL, pattern[0]) {
+			rewritten = strings.Replace(a.URL, pattern[0], pattern[1], 1)
+			break
+		}
+	}
+	if rewritten == "" && strings.HasSuffix(a.URL, "@"+a.Version) {
+		rewritten = strings.TrimSuffix(a.URL, a.Version) + version
+	}
+	if rewritten == "" {
+		return a, fmt.Errorf("asset %s: cannot pin version %s, URL %s does not contain version %s",
+			a.Name, version, a.URL, a.Version)
+	}
+
+	a.URL = rewritten
+	a.Version = version
+	a.Integrity = integrity
+	a.CachePath = path.Join("pinned", version, a.LocalPath)
+	return a, nil
+}
+
+// CheckReachable verifies that the asset's URL responds successfully
+// without downloading it. It is used to validate pinned versions.
+func (d *Downloader) CheckReachable(ctx context.Context, asset Asset) error {
+	if d.offline {
+		return fmt.Errorf("%w: cannot check %s while offline", ErrDownloadFailed, asset.Name)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, asset.URL, http.NoBody)
+	if err != nil {
+		return fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("User-Agent", d.userAgent)
+
+	resp, err := d.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrDownloadFailed, err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%w: %s returned HTTP %d", ErrDownloadFailed, asset.URL, resp.StatusCode)
+	}
+	return nil
+}
+
+// applyPins returns the asset with its configured pin applied, if any.
+func applyPins(asset Asset) Asset {
+	pinsMu.RLock()
+	defer pinsMu.RUnlock()
+
+	if pin, ok := lookupPin(pins, asset); ok {
+		return applyPin(asset, pin)
+	}
+	return asset
+}
+
+// applyPin applies a pin that SetVersionPins has already validated.
+func applyPin(asset Asset, pin Pin) Asset {
+	pinned, err := asset.WithVersion(pin.Version, pin.Integrity)
+	if err != nil {
+		return asset
+	}
+	return pinned
+}
+
+// lookupPin finds the pin for an asset by name, then by library name.
+func lookupPin(set map[string]Pin, asset Asset) (Pin, bool) {
+	if pin, ok := set[asset.Name]; ok {
+		return pin, true
+	}
+	pin, ok := set[libraryName(asset)]
+	return pin, ok
+}
+
+// isPinKey reports whether key names a registry asset or library.
+func isPinKey(key string) bool {
+	registered := registeredAssets()
+	for i := range registered {
+		if registered[i].Name == key || libraryName(registered[i]) == key {
+			return true
+		}
+	}
+	return false
+}
+
+// libraryName returns the first directory of the asset's LocalPath,
+// e.g. "glightbox" for "glightbox/glightbox.min.js".
+func libraryName(asset Asset) string {
+	name, _, _ := strings.Cut(asset.LocalPath, "/")
+	return name
+}
@@ -0,0 +1,227 @@
+package assets
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// setTestPins applies version pins for the duration of a test.
+func setTestPins(t *testing.T, versions, integrity map[string]string) {
+	t.Helper()
+	if err := SetVersionPins(versions, integrity); err != nil {
+		t.Fatalf("SetVersionPins() error = %v", err)
+	}
+	t.Cleanup(func() {
+		_ = SetVersionPins(nil, nil) //nolint:errcheck // clearing pins cannot fail
+	})
+}
+
+func TestAsset_WithVersion(t *testing.T) {
+	tests := []struct {
+		name      string
+		assetName string
+		version   string
+		wantURL   string
+	}{
+		{
+			name:      "npm path",
+			assetName: "glightbox-js",
+			version:   "3.2.0",
+			wantURL:   "https://cdn.jsdelivr.net/npm/glightbox@3.2.0/dist/js/glightbox.min.js",
+		},
+		{
+			name:      "trailing version",
+			assetName: "htmx",
+			version:   "2.0.4",
+			wantURL:   "https://unpkg.com/htmx.org@2.0.4",
+		},
+		{
+			name:      "scoped package",
+			assetName: "popper",
+			version:   "2.11.6",
+			wantURL:   "https://unpkg.com/@popperjs/core@2.11.6/dist/umd/popper.min.js",
+		},
+		{
+			name:      "npm tarball",
+			assetName: "webawesome",
+			version:   "3.4.0",
+			wantURL:   "https://registry.npmjs.org/@awesome.me/webawesome/-/webawesome-3.4.0.tgz",
+		},
+		{
+			name:      "major version in filename",
+			assetName: "d3",
+			version:   "6",
+			wantURL:   "https://d3js.org/d3.v6.min.js",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			asset := GetAsset(tt.assetName)
+			if asset == nil {
+				t.Fatalf("asset %q not in registry", tt.assetName)
+			}
+
+			pinned, err := asset.WithVersion(tt.version, "")
+			if err != nil {
+				t.Fatalf("WithVersion() error = %v", err)
+			}
+			if pinned.URL != tt.wantURL {
+				t.Errorf("URL = %q, want %q", pinned.URL, tt.wantURL)
+			}
+			if pinned.Version != tt.version {
+				t.Errorf("Version = %q, want %q", pinned.Version, tt.version)
+			}
+			if pinned.Integrity != "" {
+				t.Errorf("Integrity = %q, want stale hash cleared", pinned.Integrity)
+			}
+			if pinned.LocalPath != asset.LocalPath {
+				t.Errorf("LocalPath = %q, want unchanged %q", pinned.LocalPath, asset.LocalPath)
+			}
+			if !strings.Contains(pinned.CachePath, tt.version) {
+				t.Errorf("CachePath = %q, want version-specific path", pinned.CachePath)
+			}
+		})
+	}
+}
+
+func TestAsset_WithVersion_KeepsProvidedIntegrity(t *testing.T) {
+	asset := GetAsset("webawesome")
+	pinned, err := asset.WithVersion("3.4.0", "sha384-abc")
+	if err != nil {
+		t.Fatalf("WithVersion() error = %v", err)
+	}
+	if pinned.Integrity != "sha384-abc" {
+		t.Errorf("Integrity = %q, want provided hash", pinned.Integrity)
+	}
+}
+
+func TestAsset_WithVersion_UnversionedURL(t *testing.T) {
+	asset := GetAsset("tailwindcss-js")
+	if _, err := asset.WithVersion("3.4.0", ""); err == nil {
+		t.Error("expected error pinning an asset whose URL has no version")
+	}
+}
+
+func TestSetVersionPins_GetAsset(t *testing.T) {
+	setTestPins(t, map[string]string{
+		"glightbox":     "3.2.0",
+		"glightbox-css": "3.1.0",
+		"webawesome":    "3.4.0",
+	}, map[string]string{
+		"webawesome": "sha512-pinned",
+	})
+
+	js := GetAsset("glightbox-js")
+	if js.URL != "https://cdn.jsdelivr.net/npm/glightbox@3.2.0/dist/js/glightbox.min.js" {
+		t.Errorf("library pin: URL = %q", js.URL)
+	}
+
+	css := GetAsset("glightbox-css")
+	if css.Version != "3.1.0" {
+		t.Errorf("asset pin should win over library pin: Version = %q", css.Version)
+	}
+
+	wa := GetAsset("webawesome")
+	if wa.Integrity != "sha512-pinned" {
+		t.Errorf("Integrity = %q, want pinned hash", wa.Integrity)
+	}
+
+	if htmx := GetAsset("htmx"); htmx.Version != "1.9.10" {
+		t.Errorf("unpinned asset changed: Version = %q", htmx.Version)
+	}
+
+	names := make([]string, 0, 3)
+	for _, asset := range PinnedAssets() {
+		names = append(names, asset.Name)
+	}
+	if got := strings.Join(names, ","); got != "glightbox-css,glightbox-js,webawesome" {
+		t.Errorf("PinnedAssets() = %s", got)
+	}
+
+	var registryPinned bool
+	for _, asset := range Registry() {
+		if asset.Name == "glightbox-js" {
+			registryPinned = asset.Version == "3.2.0"
+		}
+	}
+	if !registryPinned {
+		t.Error("Registry() should apply version pins")
+	}
+}
+
+func TestSetVersionPins_Errors(t *testing.T) {
+	tests := []struct {
+		name      string
+		versions  map[string]string
+		integrity map[string]string
+		wantErr   string
+	}{
+		{"unknown asset", map[string]string{"nope": "1.0.0"}, nil, "unknown asset"},
+		{"empty version", map[string]string{"htmx": " "}, nil, "empty version"},
+		{"integrity without version", nil, map[string]string{"htmx": "sha384-x"}, "no pinned version"},
+		{"unversioned url", map[string]string{"tailwindcss-js": "3.4.0"}, nil, "cannot pin"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := SetVersionPins(tt.versions, tt.integrity)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("SetVersionPins() error = %v, want containing %q", err, tt.wantErr)
+			}
+			if len(PinnedAssets()) != 0 {
+				t.Error("invalid pins should not be applied")
+			}
+		})
+	}
+}
+
+func TestDownloader_PinnedVersionCachedSeparately(t *testing.T) {
+	tmpDir := t.TempDir()
+	downloader := NewDownloader(tmpDir, true)
+
+	asset := *GetAsset("htmx")
+	writeCachedAsset(t, tmpDir, asset, "old version")
+
+	pinned, err := asset.WithVersion("2.0.4", "")
+	if err != nil {
+		t.Fatalf("WithVersion() error = %v", err)
+	}
+	if downloader.IsCached(pinned) {
+		t.Error("pinned version should not reuse the cached registered version")
+	}
+	if got := downloader.getCachePath(pinned); got != filepath.Join(tmpDir, "pinned", "2.0.4", "htmx", "htmx.min.js") {
+		t.Errorf("cache path = %q", got)
+	}
+}
+
+func TestDownloader_CheckReachable(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodHead {
+			t.Errorf("method = %s, want HEAD", r.Method)
+		}
+		if strings.Contains(r.URL.Path, "@9.9.9") {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	downloader := NewDownloader(t.TempDir(), true)
+
+	ok := Asset{Name: "lib", URL: server.URL + "/lib@1.0.0/lib.js"}
+	if err := downloader.CheckReachable(context.Background(), ok); err != nil {
+		t.Errorf("CheckReachable() error = %v", err)
+	}
+
+	missing := Asset{Name: "lib", URL: server.URL + "/lib@9.9.9/lib.js"}
+	err := downloader.CheckReachable(context.Background(), missing)
+	if err == nil || !strings.Contains(err.Error(), "404") {
+		t.Errorf("CheckReachable() error = %v, want HTTP 404", err)
+	}
+}
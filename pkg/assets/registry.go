@@ -1,6 +1,9 @@
 package assets
 
-import "strings"
+import (
+	"strings"
+	"sync"
+)
 
 // Asset represents an external CDN asset that can be self-hosted.
 type Asset struct {
@@ -19,6 +22,11 @@ type Asset struct {
 	// using LocalPath.
 	OutputPath string
 
+	// CachePath optionally overrides where the asset is cached relative to
+	// the cache directory. Empty means LocalPath. Pinned versions use it to
+	// keep their downloads apart from the registered version.
+	CachePath string
+
 	// Integrity is the SRI hash (e.g., "sha384-...")
 	// Empty string means no integrity verification
 	Integrity string
@@ -43,6 +51,14 @@ func (a Asset) PublishPath() string {
 	return a.LocalPath
 }
 
+// cachePath returns the path of the asset relative to the cache directory.
+func (a Asset) cachePath() string {
+	if a.CachePath != "" {
+		return a.CachePath
+	}
+	return a.LocalPath
+}
+
 // assetRegistry holds all known CDN assets.
 var assetRegistry = []Asset{
 	// GLightbox - image lightbox
@@ -214,18 +230,39 @@ var assetRegistry = []Asset{
 	NewWebAwesomeAsset("3.5.0", "assets/vendor/webawesome"),
 }
 
-// Registry returns a copy of all registered assets.
+var (
+	customMu     sync.RWMutex
+	customAssets []Asset
+)
+
+// registeredAssets returns the built-in assets followed by the custom
+// assets from SetCustomAssets.
+func registeredAssets() []Asset {
+	customMu.RLock()
+	defer customMu.RUnlock()
+
+	result := make([]Asset, 0, len(assetRegistry)+len(customAssets))
+	result = append(result, assetRegistry...)
+	return append(result, customAssets...)
+}
+
+// Registry returns a copy of all registered assets, including custom
+// assets, with any version pins from SetVersionPins applied.
 func Registry() []Asset {
-	result := make([]Asset, len(assetRegistry))
-	copy(result, assetRegistry)
+	result := registeredAssets()
+	for i := range result {
+		result[i] = applyPins(result[i])
+	}
 	return result
 }
 
 // GetAsset returns an asset by name, or nil if not found.
+// A pinned version from SetVersionPins replaces the registered one.
 func GetAsset(name string) *Asset {
-	for i := range assetRegistry {
-		if assetRegistry[i].Name == name {
-			asset := assetRegistry[i]
+	registered := registeredAssets()
+	for i := range registered {
+		if registered[i].Name == name {
+			asset := applyPins(registered[i])
 			return &asset
 		}
 	}
@@ -235,8 +272,9 @@ func GetAsset(name string) *Asset {
 // GetAssetsByType returns all assets of a given type.
 func GetAssetsByType(assetType string) []Asset {
 	var result []Asset
-	for i := range assetRegistry {
-		asset := assetRegistry[i]
+	registered := registeredAssets()
+	for i := range registered {
+		asset := applyPins(registered[i])
 		if asset.Type == assetType {
 			result = append(result, asset)
 		}
@@ -278,16 +316,10 @@ func trimVendorOutputPath(outputDir string) string {
 // For example: {"glightbox": [...], "htmx": [...], ...}
 func AssetGroups() map[string][]Asset {
 	groups := make(map[string][]Asset)
-	for i := range assetRegistry {
-		asset := assetRegistry[i]
-		// Extract library name from LocalPath (first directory)
-		libName := asset.LocalPath
-		for i, c := range asset.LocalPath {
-			if c == '/' {
-				libName = asset.LocalPath[:i]
-				break
-			}
-		}
+	registered := registeredAssets()
+	for i := range registered {
+		asset := applyPins(registered[i])
+		libName := libraryName(asset)
 		groups[libName] = append(groups[libName], asset)
 	}
 	return groups
@@ -295,9 +327,10 @@ func AssetGroups() map[string][]Asset {
 
 // AssetNames returns the names of all registered assets.
 func AssetNames() []string {
-	names := make([]string, len(assetRegistry))
-	for i := range assetRegistry {
-		names[i] = assetRegistry[i].Name
+	registered := registeredAssets()
+	names := make([]string, len(registered))
+	for i := range registered {
+		names[i] = registered[i].Name
 	}
 	return names
 }
@@ -0,0 +1,200 @@
+package assets
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"fmt"
+	"hash"
+	"os"
+	"strings"
+	"time"
+)
+
+// VerifyStatus is the outcome of verifying a cached asset.
+type VerifyStatus string
+
+// Verify statuses.
+const (
+	// VerifyOK means every registered hash matches the cached file
+	VerifyOK VerifyStatus = "ok"
+
+	// VerifyMismatch means at least one registered hash does not match
+	VerifyMismatch VerifyStatus = "mismatch"
+
+	// VerifyMissing means the asset is not in the cache
+	VerifyMissing VerifyStatus = "missing"
+
+	// VerifyNoHash means the asset is cached but has no registered hash
+	VerifyNoHash VerifyStatus = "no-hash"
+
+	// VerifySkipped means the asset cannot be re-hashed, e.g. archives,
+	// which are extracted rather than cached as downloaded
+	VerifySkipped VerifyStatus = "skipped"
+
+	// VerifyError means the cached file or registered hash could not be read
+	VerifyError VerifyStatus = "error"
+)
+
+// IntegrityCheck is the result of checking one hash of an SRI value.
+type IntegrityCheck struct {
+	// Algorithm is the hash algorithm: "sha256", "sha384", or "sha512"
+	Algorithm string
+
+	// Expected is the base64 digest from the registry
+	Expected string
+
+	// Actual is the base64 digest of the cached file
+	Actual string
+
+	// OK is true if Actual matches Expected
+	OK bool
+}
+
+// VerifyResult is the result of verifying a single cached asset.
+type VerifyResult struct {
+	Asset  Asset
+	Status VerifyStatus
+
+	// Path is the cached file that was verified, empty if missing
+	Path string
+
+	// Checks holds one entry per hash in the asset's Integrity value
+	Checks []IntegrityCheck
+
+	// Error is set when Status is VerifyError
+	Error error
+}
+
+// FailedAlgorithms returns the algorithms whose hashes did not match.
+func (r VerifyResult) FailedAlgorithms() []string {
+	var failed []string
+	for _, check := range r.Checks {
+		if !check.OK {
+			failed = append(failed, check.Algorithm)
+		}
+	}
+	return failed
+}
+
+// NeedsRepair returns true if the asset should be downloaded again.
+func (r VerifyResult) NeedsRepair() bool {
+	return r.Status == VerifyMismatch || r.Status == VerifyMissing
+}
+
+// Verify re-hashes every registered asset in the cache and compares it
+// against the registry's SRI hashes.
+func (d *Downloader) Verify() []VerifyResult {
+	return d.VerifyAssets(Registry())
+}
+
+// VerifyAssets re-hashes the provided assets in the cache and compares
+// them against their SRI hashes.
+func (d *Downloader) VerifyAssets(assets []Asset) []VerifyResult {
+	results := make([]VerifyResult, len(assets))
+	for i := range assets {
+		results[i] = d.verifyAsset(assets[i])
+	}
+	return results
+}
+
+// verifyAsset verifies a single cached asset.
+func (d *Downloader) verifyAsset(asset Asset) VerifyResult {
+	result := VerifyResult{Asset: asset}
+
+	root, ok := d.findCachedRoot(asset)
+	if !ok {
+		result.Status = VerifyMissing
+		return result
+	}
+	result.Path = d.getCachePathForRoot(root, asset)
+
+	switch {
+	case d.isArchiveAsset(asset):
+		result.Status = VerifySkipped
+		return result
+	case asset.Integrity == "":
+		result.Status = VerifyNoHash
+		return result
+	}
+
+	data, err := os.ReadFile(result.Path)
+	if err != nil {
+		result.Status = VerifyError
+		result.Error = fmt.Errorf("read cached file: %w", err)
+		return result
+	}
+
+	checks, err := checkIntegrity(data, asset.Integrity)
+	if err != nil {
+		result.Status = VerifyError
+		result.Error = err
+		return result
+	}
+	result.Checks = checks
+
+	result.Status = VerifyOK
+	if len(result.FailedAlgorithms()) > 0 {
+		result.Status = VerifyMismatch
+	}
+	return result
+}
+
+// Repair downloads the assets that verification found missing or
+// mismatched, replacing any cached copy. A concurrency of zero or less
+// uses the downloader's configured parallelism.
+func (d *Downloader) Repair(ctx context.Context, results []VerifyResult, concurrency int) []DownloadResult {
+	var assets []Asset
+	for i := range results {
+		if results[i].NeedsRepair() {
+			assets = append(assets, results[i].Asset)
+		}
+	}
+
+	return d.downloadConcurrently(ctx, assets, concurrency, func(ctx context.Context, asset Asset) (*DownloadResult, error) {
+		return d.refresh(ctx, asset, &DownloadResult{Asset: asset}, time.Now())
+	})
+}
+
+// checkIntegrity hashes data with each algorithm in an SRI value, which
+// may list several space-separated hashes (e.g. "sha256-... sha384-...").
+func checkIntegrity(data []byte, integrity string) ([]IntegrityCheck, error) {
+	fields := strings.Fields(integrity)
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("invalid integrity format: %s", integrity)
+	}
+
+	checks := make([]IntegrityCheck, 0, len(fields))
+	for _, field := range fields {
+		// SRI format: algorithm-base64hash[?options]
+		algorithm, expected, ok := strings.Cut(field, "-")
+		if !ok {
+			return nil, fmt.Errorf("invalid integrity format: %s", field)
+		}
+		expected, _, _ = strings.Cut(expected, "?")
+
+		var hasher hash.Hash
+		switch algorithm {
+		case "sha256":
+			hasher = sha256.New()
+		case "sha384":
+			hasher = sha512.New384()
+		case "sha512":
+			hasher = sha512.New()
+		default:
+			return nil, fmt.Errorf("unsupported hash algorithm: %s", algorithm)
+		}
+
+		hasher.Write(data)
+		actual := base64.StdEncoding.EncodeToString(hasher.Sum(nil))
+		checks = append(checks, IntegrityCheck{
+			Algorithm: algorithm,
+			Expected:  expected,
+			Actual:    actual,
+			OK:        actual == expected,
+		})
+	}
+
+	return checks, nil
+}
@@ -0,0 +1,125 @@
+package assets
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func sriHash(algorithm string, data []byte) string {
+	switch algorithm {
+	case "sha256":
+		sum := sha256.Sum256(data)
+		return "sha256-" + base64.StdEncoding.EncodeToString(sum[:])
+	case "sha384":
+		sum := sha512.Sum384(data)
+		return "sha384-" + base64.StdEncoding.EncodeToString(sum[:])
+	default:
+		sum := sha512.Sum512(data)
+		return "sha512-" + base64.StdEncoding.EncodeToString(sum[:])
+	}
+}
+
+func writeCachedAsset(t *testing.T, cacheDir string, asset Asset, content string) {
+	t.Helper()
+	path := filepath.Join(cacheDir, asset.LocalPath)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDownloader_VerifyAssets(t *testing.T) {
+	good := []byte("console.log('ok')")
+	cacheDir := t.TempDir()
+	d := NewDownloader(cacheDir, true)
+	d.fallbackCacheDirs = nil
+
+	okAsset := Asset{
+		Name:      "ok-js",
+		LocalPath: "lib/ok.js",
+		Integrity: sriHash("sha256", good) + " " + sriHash("sha384", good) + " " + sriHash("sha512", good),
+	}
+	// sha256 still matches, sha384 was registered for different content
+	mismatchAsset := Asset{
+		Name:      "mismatch-js",
+		LocalPath: "lib/mismatch.js",
+		Integrity: sriHash("sha256", good) + " " + sriHash("sha384", []byte("other")),
+	}
+	missingAsset := Asset{Name: "missing-js", LocalPath: "lib/missing.js", Integrity: sriHash("sha256", good)}
+	noHashAsset := Asset{Name: "nohash-css", LocalPath: "lib/nohash.css"}
+	badHashAsset := Asset{Name: "bad-js", LocalPath: "lib/bad.js", Integrity: "md5-abc"}
+	archiveAsset := Asset{Name: "archive", LocalPath: "archive", ExtractPath: "package", Integrity: sriHash("sha512", good)}
+
+	writeCachedAsset(t, cacheDir, okAsset, string(good))
+	writeCachedAsset(t, cacheDir, mismatchAsset, string(good))
+	writeCachedAsset(t, cacheDir, noHashAsset, "body{}")
+	writeCachedAsset(t, cacheDir, badHashAsset, string(good))
+	writeCachedAsset(t, cacheDir, Asset{LocalPath: "archive.complete"}, "1")
+
+	results := d.VerifyAssets([]Asset{okAsset, mismatchAsset, missingAsset, noHashAsset, badHashAsset, archiveAsset})
+
+	want := []VerifyStatus{VerifyOK, VerifyMismatch, VerifyMissing, VerifyNoHash, VerifyError, VerifySkipped}
+	for i, status := range want {
+		if results[i].Status != status {
+			t.Errorf("%s: Status = %s, want %s", results[i].Asset.Name, results[i].Status, status)
+		}
+	}
+
+	if len(results[0].Checks) != 3 {
+		t.Errorf("ok asset: %d checks, want 3", len(results[0].Checks))
+	}
+	if got := results[1].FailedAlgorithms(); !reflect.DeepEqual(got, []string{"sha384"}) {
+		t.Errorf("FailedAlgorithms() = %v, want [sha384]", got)
+	}
+	if results[3].NeedsRepair() || !results[1].NeedsRepair() || !results[2].NeedsRepair() {
+		t.Error("only mismatched and missing assets should need repair")
+	}
+}
+
+func TestDownloader_Repair(t *testing.T) {
+	good := []byte("fresh content")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if _, err := w.Write(good); err != nil {
+			t.Logf("failed to write response: %v", err)
+		}
+	}))
+	defer server.Close()
+
+	cacheDir := t.TempDir()
+	d := NewDownloader(cacheDir, true)
+	d.fallbackCacheDirs = nil
+	d.offline = false
+
+	corrupted := Asset{Name: "corrupted", URL: server.URL, LocalPath: "lib/corrupted.js", Integrity: sriHash("sha384", good)}
+	missing := Asset{Name: "missing", URL: server.URL, LocalPath: "lib/missing.js", Integrity: sriHash("sha256", good)}
+	healthy := Asset{Name: "healthy", URL: server.URL + "/unused", LocalPath: "lib/healthy.js", Integrity: sriHash("sha256", []byte("ok"))}
+	writeCachedAsset(t, cacheDir, corrupted, "truncated")
+	writeCachedAsset(t, cacheDir, healthy, "ok")
+
+	assets := []Asset{corrupted, missing, healthy}
+	downloads := d.Repair(context.Background(), d.VerifyAssets(assets), 2)
+	if len(downloads) != 2 {
+		t.Fatalf("Repair() downloaded %d assets, want 2", len(downloads))
+	}
+	for i := range downloads {
+		if downloads[i].Error != nil {
+			t.Errorf("%s: %v", downloads[i].Asset.Name, downloads[i].Error)
+		}
+	}
+
+	for _, r := range d.VerifyAssets(assets) {
+		if r.Status != VerifyOK {
+			t.Errorf("%s: Status after repair = %s, want ok", r.Asset.Name, r.Status)
+		}
+	}
+}
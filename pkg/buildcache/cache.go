@@ -34,6 +34,7 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"runtime/debug"
 	"sort"
 	"strings"
 	"sync"
@@ -1355,6 +1356,24 @@ func ContentHash(content string) string {
 	return hex.EncodeToString(h.Sum(nil))
 }
 
+// BinaryVersion identifies the running markata-go build: its module version
+// and, when built from a checkout, the VCS revision. Cache keys include it so
+// upgrading markata-go (and the plugins compiled into it) re-renders output
+// instead of reusing HTML from the previous release.
+var BinaryVersion = sync.OnceValue(func() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return ""
+	}
+	parts := []string{info.Main.Version}
+	for _, setting := range info.Settings {
+		if setting.Key == "vcs.revision" || setting.Key == "vcs.modified" {
+			parts = append(parts, setting.Value)
+		}
+	}
+	return strings.Join(parts, "+")
+})
+
 // GetFeedHash returns the cached hash for a feed, or empty string if not cached.
 func (c *Cache) GetFeedHash(slug string) string {
 	c.mu.RLock()
@@ -15,39 +15,62 @@ import (
 	"github.com/WaylonWalker/markata-go/pkg/models"
 )
 
-// loadResolvedConfig loads a config file, resolves recursive includes, then
-// materializes the merged raw config into a typed models.Config.
-func loadResolvedConfig(configPath string) (*models.Config, error) {
-	rawWrapper, err := loadResolvedRawConfig(configPath)
-	if err != nil {
-		return nil, err
-	}
-
+// configFromResolvedRaw layers a resolved raw config over the defaults and
+// decodes it into a typed models.Config.
+func configFromResolvedRaw(rawWrapper map[string]any) (*models.Config, error) {
 	defaultRaw, err := rawWrapperFromConfig(DefaultConfig())
 	if err != nil {
 		return nil, fmt.Errorf("failed to encode default config: %w", err)
 	}
 
-	rawWrapper = mergeRawMaps(nil, defaultRaw, rawWrapper)
+	return configFromRawWrapper(mergeRawMaps(nil, defaultRaw, rawWrapper))
+}
 
-	config, err := configFromRawWrapper(rawWrapper)
+// loadResolvedRawConfig loads a config file, recursively resolves extends and
+// include entries relative to the declaring file, and merges the results.
+func loadResolvedRawConfig(configPath string) (map[string]any, error) {
+	absPath, err := filepath.Abs(configPath)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("failed to resolve config path %s: %w", configPath, err)
 	}
 
-	return config, nil
+	loader := &rawConfigLoader{loaded: make(map[string]bool)}
+	return loader.load(absPath, nil, relationInclude)
 }
 
-// loadResolvedRawConfig loads a config file, recursively resolves include
-// entries relative to the declaring file, and merges the results.
-func loadResolvedRawConfig(configPath string) (map[string]any, error) {
+// loadResolvedRawConfigFileData resolves config data already read from
+// configPath, treating it exactly like a file loaded by loadResolvedRawConfig.
+func loadResolvedRawConfigFileData(configPath string, data []byte, format Format) (map[string]any, error) {
 	absPath, err := filepath.Abs(configPath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to resolve config path %s: %w", configPath, err)
 	}
+	absPath = filepath.Clean(absPath)
+
+	rawWrapper, err := loadRawConfigData(data, format)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse config file %s: %w", configPath, err)
+	}
 
 	loader := &rawConfigLoader{loaded: make(map[string]bool)}
-	return loader.load(absPath, nil)
+	return loader.resolve(absPath, filepath.Dir(absPath), rawWrapper, nil)
+}
+
+// loadResolvedRawConfigData parses config data that did not come from a file
+// and resolves its extends and include entries relative to baseDir.
+func loadResolvedRawConfigData(data []byte, format Format, baseDir string) (map[string]any, error) {
+	rawWrapper, err := loadRawConfigData(data, format)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse config: %w", err)
+	}
+
+	absDir, err := filepath.Abs(baseDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve config directory %s: %w", baseDir, err)
+	}
+
+	loader := &rawConfigLoader{loaded: make(map[string]bool)}
+	return loader.resolve("", absDir, rawWrapper, nil)
 }
 
 // DiscoverIncludedConfigPaths returns the root config file plus any recursively
@@ -62,6 +85,12 @@ func DiscoverIncludedConfigPaths(configPath string) ([]string, error) {
 	return collector.collect(absPath, nil)
 }
 
+// Relations between config files, used in cycle error messages.
+const (
+	relationInclude = "include"
+	relationExtends = "extends"
+)
+
 type rawConfigLoader struct {
 	loaded map[string]bool
 }
@@ -70,12 +99,12 @@ type rawConfigPathCollector struct {
 	seen map[string]bool
 }
 
-func (l *rawConfigLoader) load(configPath string, stack []string) (map[string]any, error) {
+func (l *rawConfigLoader) load(configPath string, stack []string, relation string) (map[string]any, error) {
 	configPath = filepath.Clean(configPath)
 
 	if containsPath(stack, configPath) {
 		cycle := append(append([]string{}, stack...), configPath)
-		return nil, fmt.Errorf("config include cycle detected: %s", strings.Join(cycle, " -> "))
+		return nil, fmt.Errorf("config %s cycle detected: %s", relation, strings.Join(cycle, " -> "))
 	}
 
 	if l.loaded[configPath] {
@@ -86,24 +115,67 @@ func (l *rawConfigLoader) load(configPath string, stack []string) (map[string]an
 	if err != nil {
 		return nil, err
 	}
+	anchorFileReferences(rawWrapper, filepath.Dir(configPath))
+
+	merged, err := l.resolve(configPath, filepath.Dir(configPath), rawWrapper, stack)
+	if err != nil {
+		return nil, err
+	}
+
+	l.loaded[configPath] = true
+	return merged, nil
+}
+
+// resolve merges the extends and include entries of rawWrapper, resolved
+// relative to baseDir. configPath names the source in errors and cycle
+// detection and is empty for config data that did not come from a file.
+func (l *rawConfigLoader) resolve(configPath, baseDir string, rawWrapper map[string]any, stack []string) (map[string]any, error) {
+	source := configPath
+	if source == "" {
+		source = "config"
+	}
+
+	extends, err := extractExtendsPaths(rawWrapper)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse extends in %s: %w", source, err)
+	}
 
 	includes, err := extractIncludePatterns(rawWrapper)
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse includes in %s: %w", configPath, err)
+		return nil, fmt.Errorf("failed to parse includes in %s: %w", source, err)
 	}
 
-	merged := cloneMap(rawWrapper)
-	childStack := append(append([]string{}, stack...), configPath)
-	baseDir := filepath.Dir(configPath)
+	childStack := append([]string{}, stack...)
+	if configPath != "" {
+		childStack = append(childStack, configPath)
+	}
+
+	// Extended files form the base; this file is merged on top of them.
+	var merged map[string]any
+	for _, pattern := range extends {
+		matches, err := resolveIncludePattern(baseDir, pattern)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve extends %q in %s: %w", pattern, source, err)
+		}
+
+		for _, match := range matches {
+			parent, err := l.load(match, childStack, relationExtends)
+			if err != nil {
+				return nil, err
+			}
+			merged = mergeRawMaps(nil, merged, parent)
+		}
+	}
+	merged = mergeRawMaps(nil, merged, rawWrapper)
 
 	for _, pattern := range includes {
 		matches, err := resolveIncludePattern(baseDir, pattern)
 		if err != nil {
-			return nil, fmt.Errorf("failed to resolve include %q in %s: %w", pattern, configPath, err)
+			return nil, fmt.Errorf("failed to resolve include %q in %s: %w", pattern, source, err)
 		}
 
 		for _, match := range matches {
-			child, err := l.load(match, childStack)
+			child, err := l.load(match, childStack, relationInclude)
 			if err != nil {
 				return nil, err
 			}
@@ -111,7 +183,6 @@ func (l *rawConfigLoader) load(configPath string, stack []string) (map[string]an
 		}
 	}
 
-	l.loaded[configPath] = true
 	return merged, nil
 }
 
@@ -137,15 +208,20 @@ func (c *rawConfigPathCollector) collect(configPath string, stack []string) ([]s
 	childStack := append(append([]string{}, stack...), configPath)
 	baseDir := filepath.Dir(configPath)
 
+	extends, err := extractExtendsPaths(rawWrapper)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse extends in %s: %w", configPath, err)
+	}
+
 	includes, err := extractIncludePatterns(rawWrapper)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse includes in %s: %w", configPath, err)
 	}
 
-	for _, pattern := range includes {
+	for _, pattern := range append(extends, includes...) {
 		matches, err := resolveIncludePattern(baseDir, pattern)
 		if err != nil {
-			return nil, fmt.Errorf("failed to resolve include %q in %s: %w", pattern, configPath, err)
+			return nil, fmt.Errorf("failed to resolve %q in %s: %w", pattern, configPath, err)
 		}
 
 		for _, match := range matches {
@@ -216,6 +292,34 @@ func extractIncludePatterns(rawWrapper map[string]any) ([]string, error) {
 	return stringSliceFromValue(includeValue)
 }
 
+// extractExtendsPaths returns the files a config extends, read from a
+// top-level extends key or from markata-go.extends. The top-level key is
+// removed from rawWrapper so it does not leak into the merged config.
+func extractExtendsPaths(rawWrapper map[string]any) ([]string, error) {
+	var paths []string
+
+	if value, ok := rawWrapper["extends"]; ok {
+		delete(rawWrapper, "extends")
+		extends, err := stringSliceFromValue(value)
+		if err != nil {
+			return nil, err
+		}
+		paths = append(paths, extends...)
+	}
+
+	if markataGo, ok := rawWrapper["markata-go"].(map[string]any); ok {
+		if value, ok := markataGo["extends"]; ok {
+			extends, err := stringSliceFromValue(value)
+			if err != nil {
+				return nil, err
+			}
+			paths = append(paths, extends...)
+		}
+	}
+
+	return paths, nil
+}
+
 func resolveIncludePattern(baseDir, pattern string) ([]string, error) {
 	resolvedPattern := pattern
 	if !filepath.IsAbs(resolvedPattern) {
@@ -295,31 +399,14 @@ func rawWrapperFromConfig(config *models.Config) (map[string]any, error) {
 	return rawWrapper, nil
 }
 
+// populateExtra copies the [markata-go] keys that knownKeys does not keep out
+// of Config.Extra, using the same list as ParseTOML.
 func populateExtra(config *models.Config, rawWrapper map[string]any) {
 	markataGoRaw, ok := rawWrapper["markata-go"].(map[string]any)
 	if !ok {
 		return
 	}
 
-	knownKeys := map[string]bool{
-		"output_dir": true, "url": true, "title": true, "description": true,
-		"author": true, "language": true, "author_url": true, "managing_editor": true,
-		"webmaster": true, "copyright": true, "license": true, "assets_dir": true,
-		"templates_dir": true, "templates": true, "nav": true, "footer": true,
-		"hooks": true, "disabled_hooks": true, "glob": true, "markdown": true,
-		"feeds": true, "feed_defaults": true, "concurrency": true, "theme": true,
-		"post_formats": true, "well_known": true, "seo": true, "indieauth": true,
-		"webmention": true, "components": true, "layout": true, "sidebar": true,
-		"toc": true, "header": true, "blogroll": true, "mentions": true,
-		"template_presets": true, "default_templates": true, "head": true,
-		"content_templates": true, "footer_layout": true, "search": true,
-		"plugins": true, "thoughts": true, "wikilinks": true, "tags": true,
-		"tag_aggregator": true, "websub": true, "shortcuts": true, "view_transitions": true,
-		"encryption": true, "authors": true, "garden": true, "feeds_page": true,
-		"assets": true, "resource_hints": true, "error_pages": true, "theme_calendar": true,
-		"include": true,
-	}
-
 	if config.Extra == nil {
 		config.Extra = make(map[string]any)
 	}
@@ -429,13 +516,13 @@ func stringSliceFromValue(value any) ([]string, error) {
 		for _, item := range typed {
 			text, ok := item.(string)
 			if !ok {
-				return nil, fmt.Errorf("include entries must be strings")
+				return nil, fmt.Errorf("entries must be strings")
 			}
 			result = append(result, text)
 		}
 		return result, nil
 	default:
-		return nil, fmt.Errorf("include must be a string or list of strings")
+		return nil, fmt.Errorf("must be a string or list of strings")
 	}
 }
 
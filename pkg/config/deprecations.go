@@ -0,0 +1,120 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/WaylonWalker/markata-go/pkg/models"
+)
+
+// Deprecation maps a renamed config key to its replacement. Both are dotted
+// paths below the [markata-go] section.
+type Deprecation struct {
+	Key         string
+	Replacement string
+}
+
+// deprecations lists config keys renamed between markata-go releases.
+// Loading moves their values to the replacement and validation warns
+// about them. Keys from Python markata are handled by the migrate package.
+var deprecations = []Deprecation{
+	{Key: "glob_patterns", Replacement: "glob.patterns"},
+	{Key: "mentions_css_class", Replacement: "mentions.css_class"},
+}
+
+// Deprecations returns the deprecated config keys and their replacements.
+func Deprecations() []Deprecation {
+	return append([]Deprecation(nil), deprecations...)
+}
+
+// migrateDeprecatedKeys moves the value of each deprecated key in the
+// [markata-go] section of rawWrapper to its replacement, returning the
+// deprecated keys found mapped to their replacements. When both keys are
+// set the replacement wins and the deprecated value is dropped.
+func migrateDeprecatedKeys(rawWrapper map[string]any) map[string]string {
+	section, ok := rawWrapper["markata-go"].(map[string]any)
+	if !ok {
+		return nil
+	}
+
+	var found map[string]string
+	for _, dep := range deprecations {
+		value, ok := takeRawPath(section, strings.Split(dep.Key, "."))
+		if !ok {
+			continue
+		}
+		setRawPathIfMissing(section, strings.Split(dep.Replacement, "."), value)
+		if found == nil {
+			found = make(map[string]string)
+		}
+		found[dep.Key] = dep.Replacement
+	}
+	return found
+}
+
+// takeRawPath removes and returns the value at path in raw.
+func takeRawPath(raw map[string]any, path []string) (any, bool) {
+	for _, key := range path[:len(path)-1] {
+		next, ok := raw[key].(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		raw = next
+	}
+	last := path[len(path)-1]
+	value, ok := raw[last]
+	if ok {
+		delete(raw, last)
+	}
+	return value, ok
+}
+
+// setRawPathIfMissing sets the value at path in raw, creating intermediate
+// tables, unless a value is already there.
+func setRawPathIfMissing(raw map[string]any, path []string, value any) {
+	for _, key := range path[:len(path)-1] {
+		next, ok := raw[key].(map[string]any)
+		if !ok {
+			if _, exists := raw[key]; exists {
+				return
+			}
+			next = make(map[string]any)
+			raw[key] = next
+		}
+		raw = next
+	}
+	last := path[len(path)-1]
+	if _, exists := raw[last]; !exists {
+		raw[last] = value
+	}
+}
+
+// deprecationMessage describes a deprecated key found in the config.
+func deprecationMessage(replacement string) string {
+	return fmt.Sprintf("deprecated key, renamed to %s", replacement)
+}
+
+// validateDeprecatedKeys warns about each deprecated key the config was
+// loaded with.
+func validateDeprecatedKeys(config *models.Config) []error {
+	keys := sortedDeprecatedKeys(config)
+	errs := make([]error, 0, len(keys))
+	for _, key := range keys {
+		errs = append(errs, ValidationError{
+			Field:   key,
+			Message: deprecationMessage(config.DeprecatedKeys[key]),
+			IsWarn:  true,
+		})
+	}
+	return errs
+}
+
+func sortedDeprecatedKeys(config *models.Config) []string {
+	keys := make([]string, 0, len(config.DeprecatedKeys))
+	for key := range config.DeprecatedKeys {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
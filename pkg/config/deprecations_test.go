@@ -0,0 +1,109 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestLoad_DeprecatedKeysHonoredWithWarning(t *testing.T) {
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "markata-go.toml")
+	content := `
+[markata-go]
+glob_patterns = ["notes/**/*.md"]
+mentions_css_class = "h-card"
+`
+	if err := os.WriteFile(configPath, []byte(content), 0o644); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+
+	config, validationErrs, err := LoadAndValidate(configPath)
+	if err != nil {
+		t.Fatalf("LoadAndValidate() error = %v", err)
+	}
+
+	if want := []string{"notes/**/*.md"}; !reflect.DeepEqual(config.GlobConfig.Patterns, want) {
+		t.Errorf("GlobConfig.Patterns = %v, want %v", config.GlobConfig.Patterns, want)
+	}
+	if config.Mentions.CSSClass != "h-card" {
+		t.Errorf("Mentions.CSSClass = %q, want %q", config.Mentions.CSSClass, "h-card")
+	}
+	for _, key := range []string{"glob_patterns", "mentions_css_class"} {
+		if _, ok := config.Extra[key]; ok {
+			t.Errorf("Extra[%q] is set, want the deprecated key removed", key)
+		}
+	}
+
+	if HasErrors(validationErrs) {
+		t.Errorf("unexpected validation errors: %v", validationErrs)
+	}
+	var fields []string
+	for _, verr := range validationErrs {
+		var ve ValidationError
+		if !errors.As(verr, &ve) {
+			continue
+		}
+		if strings.Contains(ve.Message, "unknown key") {
+			t.Errorf("deprecated key reported as unknown: %v", ve)
+		}
+		if strings.Contains(ve.Message, "deprecated") {
+			if !ve.IsWarn {
+				t.Errorf("deprecation reported as error: %v", ve)
+			}
+			fields = append(fields, ve.Field)
+		}
+	}
+	if want := []string{"glob_patterns", "mentions_css_class"}; !reflect.DeepEqual(fields, want) {
+		t.Errorf("deprecation warnings for %v, want %v", fields, want)
+	}
+}
+
+func TestLoadFromString_ReplacementWinsOverDeprecatedKey(t *testing.T) {
+	config, err := LoadFromString(`
+markata-go:
+  glob_patterns: ["old/*.md"]
+  glob:
+    patterns: ["new/*.md"]
+`, FormatYAML)
+	if err != nil {
+		t.Fatalf("LoadFromString() error = %v", err)
+	}
+
+	if want := []string{"new/*.md"}; !reflect.DeepEqual(config.GlobConfig.Patterns, want) {
+		t.Errorf("GlobConfig.Patterns = %v, want %v", config.GlobConfig.Patterns, want)
+	}
+	if got := config.DeprecatedKeys["glob_patterns"]; got != "glob.patterns" {
+		t.Errorf("DeprecatedKeys[glob_patterns] = %q, want %q", got, "glob.patterns")
+	}
+}
+
+func TestLoadFromString_NoDeprecatedKeys(t *testing.T) {
+	config, err := LoadFromString("[markata-go]\ntitle = \"Site\"\n", FormatTOML)
+	if err != nil {
+		t.Fatalf("LoadFromString() error = %v", err)
+	}
+	if len(config.DeprecatedKeys) != 0 {
+		t.Errorf("DeprecatedKeys = %v, want none", config.DeprecatedKeys)
+	}
+	for _, verr := range ValidateConfig(config) {
+		if strings.Contains(verr.Error(), "deprecated") {
+			t.Errorf("unexpected deprecation warning: %v", verr)
+		}
+	}
+}
+
+func TestDeprecations_ReplacementsAreKnownKeys(t *testing.T) {
+	for _, dep := range Deprecations() {
+		top := strings.Split(dep.Replacement, ".")[0]
+		if !IsKnownKey(top) {
+			t.Errorf("replacement %q for %q is not under a known key", dep.Replacement, dep.Key)
+		}
+		if IsKnownKey(dep.Key) {
+			t.Errorf("deprecated key %q is still a known key", dep.Key)
+		}
+	}
+}
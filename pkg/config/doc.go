@@ -57,6 +57,22 @@
 //
 //	MARKATA_GO_HOOKS=markdown,template,sitemap
 //
+// # Secret References
+//
+// String values may reference environment variables or files instead of
+// inlining secrets. References are resolved after parsing and before
+// validation, and only in strings that contain them:
+//
+//	[markata-go]
+//	url = "${SITE_URL:-http://localhost:8000}" # default when unset or empty
+//
+//	[markata-go.webmentions]
+//	webmention_io_token = "${WEBMENTION_IO_TOKEN}" # error when unset
+//	# or read it from a file, relative to the config file:
+//	# webmention_io_token = "$(file:secrets/webmention-io.txt)"
+//
+// Variable names must be upper case. Write $${ to keep a literal ${.
+//
 // # Usage
 //
 // Basic usage:
@@ -67,6 +83,9 @@
 //	// Load from specific file
 //	config, err := config.Load("/path/to/config.toml")
 //
+//	// Load from any reader with an explicit format
+//	config, err := config.LoadFromReader(strings.NewReader(data), "yaml")
+//
 //	// Load with defaults only
 //	config, err := config.LoadWithDefaults()
 //
@@ -102,4 +121,18 @@
 //   - Feed slugs are required
 //   - Warning on empty glob patterns
 //   - Warning on feeds with no output formats
+//   - Warning on deprecated keys
+//
+// # Deprecated Keys
+//
+// Keys renamed between markata-go releases are listed by Deprecations.
+// Loading moves a deprecated key's value to its replacement, unless the
+// replacement is also set, and records it in Config.DeprecatedKeys so
+// validation can warn about it.
+//
+// # JSON Schema
+//
+// JSONSchema generates a draft-07 JSON Schema from the json tags of
+// models.Config, for editor autocompletion in markata-go.toml and
+// markata-go.yaml. It is exposed as "markata-go config schema".
 package config
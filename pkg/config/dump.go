@@ -0,0 +1,172 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/WaylonWalker/markata-go/pkg/models"
+)
+
+var jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+
+// Dump serializes the fully resolved configuration to indented JSON.
+//
+// Unlike json.Marshal, the output includes the plugin sections held in
+// cfg.Extra under their original top-level keys, and tri-state *bool fields
+// are always rendered as true, false, or null so unset values stay visible.
+// Keys are emitted in sorted order so dumps can be diffed between builds.
+func Dump(cfg *models.Config) ([]byte, error) {
+	if cfg == nil {
+		return nil, fmt.Errorf("cannot dump nil config")
+	}
+
+	out, ok := dumpValue(reflect.ValueOf(cfg)).(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("unexpected config dump shape")
+	}
+
+	for key, value := range cfg.Extra {
+		if _, exists := out[key]; exists {
+			continue
+		}
+		out[key] = dumpValue(reflect.ValueOf(value))
+	}
+
+	data, err := json.MarshalIndent(out, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal config dump: %w", err)
+	}
+	return data, nil
+}
+
+// dumpValue converts v into plain maps, slices, and scalars following the
+// json struct tags, except that *bool fields ignore omitempty.
+func dumpValue(v reflect.Value) any {
+	if !v.IsValid() {
+		return nil
+	}
+
+	if v.Type().Implements(jsonMarshalerType) && v.CanInterface() {
+		if v.Kind() == reflect.Pointer && v.IsNil() {
+			return nil
+		}
+		data, err := v.Interface().(json.Marshaler).MarshalJSON()
+		if err != nil {
+			return nil
+		}
+		return json.RawMessage(data)
+	}
+
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+		return dumpValue(v.Elem())
+	case reflect.Struct:
+		out := make(map[string]any)
+		dumpStructFields(v, out)
+		return out
+	case reflect.Map:
+		if v.IsNil() {
+			return nil
+		}
+		out := make(map[string]any, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			out[fmt.Sprint(iter.Key().Interface())] = dumpValue(iter.Value())
+		}
+		return out
+	case reflect.Slice:
+		if v.IsNil() {
+			return nil
+		}
+		fallthrough
+	case reflect.Array:
+		out := make([]any, v.Len())
+		for i := range out {
+			out[i] = dumpValue(v.Index(i))
+		}
+		return out
+	default:
+		if !v.CanInterface() {
+			return nil
+		}
+		return v.Interface()
+	}
+}
+
+// dumpStructFields writes the exported fields of struct v into out.
+// Embedded structs without a json name are flattened, as encoding/json does.
+func dumpStructFields(v reflect.Value, out map[string]any) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() && !field.Anonymous {
+			continue
+		}
+
+		name, omitEmpty := parseJSONTag(field.Tag.Get("json"))
+		if name == "-" {
+			continue
+		}
+
+		value := v.Field(i)
+		if field.Anonymous && name == "" {
+			if value.Kind() == reflect.Pointer {
+				if value.IsNil() {
+					continue
+				}
+				value = value.Elem()
+			}
+			if value.Kind() == reflect.Struct {
+				dumpStructFields(value, out)
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		if omitEmpty && !isBoolPointer(field.Type) && isEmptyJSONValue(value) {
+			continue
+		}
+		out[name] = dumpValue(value)
+	}
+}
+
+// parseJSONTag returns the field name and omitempty option of a json tag.
+func parseJSONTag(tag string) (name string, omitEmpty bool) {
+	parts := strings.Split(tag, ",")
+	for _, opt := range parts[1:] {
+		if opt == "omitempty" {
+			omitEmpty = true
+		}
+	}
+	return parts[0], omitEmpty
+}
+
+func isBoolPointer(t reflect.Type) bool {
+	return t.Kind() == reflect.Pointer && t.Elem().Kind() == reflect.Bool
+}
+
+// isEmptyJSONValue mirrors the omitempty rules of encoding/json.
+func isEmptyJSONValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64,
+		reflect.Interface, reflect.Pointer:
+		return v.IsZero()
+	default:
+		return false
+	}
+}
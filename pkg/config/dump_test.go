@@ -0,0 +1,102 @@
+package config
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/WaylonWalker/markata-go/pkg/models"
+)
+
+func TestDump_IncludesExtraAndTriStateBools(t *testing.T) {
+	content := `
+[markata-go]
+title = "Dump Site"
+
+[markata-go.footer]
+show_copyright = false
+
+[markata-go.my_plugin]
+enabled = true
+depth = 3
+`
+	cfg, err := LoadFromReader(strings.NewReader(content), "toml")
+	if err != nil {
+		t.Fatalf("LoadFromReader() error = %v", err)
+	}
+
+	data, err := Dump(cfg)
+	if err != nil {
+		t.Fatalf("Dump() error = %v", err)
+	}
+
+	var got map[string]any
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("Dump() produced invalid JSON: %v\n%s", err, data)
+	}
+
+	if got["title"] != "Dump Site" {
+		t.Errorf("title = %v, want %q", got["title"], "Dump Site")
+	}
+
+	plugin, ok := got["my_plugin"].(map[string]any)
+	if !ok {
+		t.Fatalf("my_plugin = %#v, want Extra section re-injected", got["my_plugin"])
+	}
+	if plugin["enabled"] != true || plugin["depth"] != float64(3) {
+		t.Errorf("my_plugin = %#v, want enabled=true depth=3", plugin)
+	}
+
+	footer, ok := got["footer"].(map[string]any)
+	if !ok {
+		t.Fatalf("footer = %#v, want object", got["footer"])
+	}
+	if v, ok := footer["show_copyright"]; !ok || v != false {
+		t.Errorf("footer.show_copyright = %#v (present=%v), want false", v, ok)
+	}
+}
+
+func TestDump_UnsetBoolRendersNull(t *testing.T) {
+	data, err := Dump(&models.Config{})
+	if err != nil {
+		t.Fatalf("Dump() error = %v", err)
+	}
+
+	var got map[string]any
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("Dump() produced invalid JSON: %v", err)
+	}
+	footer, ok := got["footer"].(map[string]any)
+	if !ok {
+		t.Fatalf("footer = %#v, want object", got["footer"])
+	}
+	if v, ok := footer["show_copyright"]; !ok || v != nil {
+		t.Errorf("footer.show_copyright = %#v (present=%v), want null", v, ok)
+	}
+}
+
+func TestDump_ExtraDoesNotShadowCoreFields(t *testing.T) {
+	cfg := &models.Config{
+		Title: "Core",
+		Extra: map[string]any{"title": "Extra"},
+	}
+
+	data, err := Dump(cfg)
+	if err != nil {
+		t.Fatalf("Dump() error = %v", err)
+	}
+
+	var got map[string]any
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("Dump() produced invalid JSON: %v", err)
+	}
+	if got["title"] != "Core" {
+		t.Errorf("title = %v, want core field to win", got["title"])
+	}
+}
+
+func TestDump_NilConfig(t *testing.T) {
+	if _, err := Dump(nil); err == nil {
+		t.Error("Dump(nil) error = nil, want error")
+	}
+}
@@ -0,0 +1,78 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// EnvironmentEnvVar selects the active [markata-go.environments.<name>]
+// section. The CLI's --env flag sets it for the current process.
+const EnvironmentEnvVar = envPrefix + "ENV"
+
+// environmentsKey is the markata-go key holding per-environment overrides.
+const environmentsKey = "environments"
+
+// ActiveEnvironment returns the environment selected via MARKATA_GO_ENV,
+// or "" when none is selected.
+func ActiveEnvironment() string {
+	return strings.TrimSpace(os.Getenv(EnvironmentEnvVar))
+}
+
+// applyEnvironment deep-merges the named section of markata-go.environments
+// over the rest of the config and removes the environments table, so it never
+// reaches the decoded config. An empty name only removes the table. Naming an
+// environment that is not defined is an error listing the defined ones.
+func applyEnvironment(rawWrapper map[string]any, name string) (map[string]any, error) {
+	markataGo, ok := rawWrapper["markata-go"].(map[string]any)
+	if !ok {
+		if name != "" {
+			return nil, unknownEnvironmentError(name, nil)
+		}
+		return rawWrapper, nil
+	}
+
+	environmentsValue, hasEnvironments := markataGo[environmentsKey]
+	environments, ok := environmentsValue.(map[string]any)
+	if hasEnvironments && !ok {
+		return nil, fmt.Errorf("markata-go.%s must be a table of environment names", environmentsKey)
+	}
+
+	base := cloneMap(markataGo)
+	delete(base, environmentsKey)
+	result := cloneMap(rawWrapper)
+	result["markata-go"] = base
+
+	if name == "" {
+		return result, nil
+	}
+
+	overrideValue, ok := environments[name]
+	if !ok {
+		return nil, unknownEnvironmentError(name, environments)
+	}
+	override, ok := overrideValue.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("markata-go.%s.%s must be a table", environmentsKey, name)
+	}
+
+	// Nested environments tables have no meaning inside an environment.
+	override = cloneMap(override)
+	delete(override, environmentsKey)
+
+	return mergeRawMaps(nil, result, map[string]any{"markata-go": override}), nil
+}
+
+func unknownEnvironmentError(name string, environments map[string]any) error {
+	if len(environments) == 0 {
+		return fmt.Errorf("unknown environment %q: no environments are defined in markata-go.%s", name, environmentsKey)
+	}
+
+	names := make([]string, 0, len(environments))
+	for envName := range environments {
+		names = append(names, envName)
+	}
+	sort.Strings(names)
+	return fmt.Errorf("unknown environment %q (valid environments: %s)", name, strings.Join(names, ", "))
+}
@@ -0,0 +1,166 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// interpolationRe matches the secret references resolved in config strings:
+//
+//	${NAME}           the environment variable NAME, which must be set
+//	${NAME:-default}  NAME, or default when NAME is unset or empty
+//	$(file:path)      the contents of path, without trailing newlines
+//
+// Names must be upper case so that shell or JavaScript snippets such as
+// ${name} in head scripts are left alone. A doubled dollar ($${ or $$(file:)
+// escapes a reference and is written with a single dollar.
+var interpolationRe = regexp.MustCompile(`\$\$\{|\$\$\(file:|\$\{([A-Z_][A-Z0-9_]*)(:-[^}]*)?\}|\$\(file:([^)]+)\)`)
+
+// interpolateRaw resolves secret references in every string value of a raw
+// config, so tokens and keys can live in the environment or in files outside
+// the repository instead of being committed in the config. Relative file
+// paths are resolved from baseDir. Only strings are rewritten; keys and other
+// values are left untouched.
+func interpolateRaw(rawWrapper map[string]any, baseDir string) (map[string]any, error) {
+	for key, value := range rawWrapper {
+		resolved, err := interpolateValue(value, key, baseDir)
+		if err != nil {
+			return nil, err
+		}
+		rawWrapper[key] = resolved
+	}
+	return rawWrapper, nil
+}
+
+// anchorFileReferences rewrites the relative $(file:path) references in value
+// and any values nested in it to start at baseDir. Extended and included
+// files are anchored before they are merged, so their references resolve from
+// their own directory instead of the top-level config's. The references are
+// only rewritten here; interpolateRaw reads them after the merge.
+func anchorFileReferences(value any, baseDir string) any {
+	switch typed := value.(type) {
+	case string:
+		if !strings.Contains(typed, "$(file:") {
+			return typed
+		}
+		return interpolationRe.ReplaceAllStringFunc(typed, func(match string) string {
+			path := strings.TrimSpace(interpolationRe.FindStringSubmatch(match)[3])
+			if path == "" || filepath.IsAbs(path) || strings.HasPrefix(path, "~/") {
+				return match
+			}
+			return "$(file:" + filepath.Join(baseDir, path) + ")"
+		})
+	case map[string]any:
+		for key, item := range typed {
+			typed[key] = anchorFileReferences(item, baseDir)
+		}
+		return typed
+	case []any:
+		for i, item := range typed {
+			typed[i] = anchorFileReferences(item, baseDir)
+		}
+		return typed
+	default:
+		return value
+	}
+}
+
+// interpolateValue resolves references in value and any values nested in it.
+// path is the dotted key of value, used in error messages.
+func interpolateValue(value any, path, baseDir string) (any, error) {
+	switch typed := value.(type) {
+	case string:
+		return interpolateString(typed, path, baseDir)
+	case map[string]any:
+		for key, item := range typed {
+			resolved, err := interpolateValue(item, path+"."+key, baseDir)
+			if err != nil {
+				return nil, err
+			}
+			typed[key] = resolved
+		}
+		return typed, nil
+	case []any:
+		for i, item := range typed {
+			resolved, err := interpolateValue(item, fmt.Sprintf("%s[%d]", path, i), baseDir)
+			if err != nil {
+				return nil, err
+			}
+			typed[i] = resolved
+		}
+		return typed, nil
+	default:
+		return value, nil
+	}
+}
+
+// interpolateString resolves the references in a single string value.
+func interpolateString(value, path, baseDir string) (string, error) {
+	if !strings.Contains(value, "$") {
+		return value, nil
+	}
+
+	var firstErr error
+	result := interpolationRe.ReplaceAllStringFunc(value, func(match string) string {
+		if firstErr != nil {
+			return match
+		}
+
+		switch {
+		case match == "$${":
+			return "${"
+		case match == "$$(file:":
+			return "$(file:"
+		}
+
+		groups := interpolationRe.FindStringSubmatch(match)
+		if filePath := groups[3]; filePath != "" {
+			contents, err := readSecretFile(filePath, baseDir)
+			if err != nil {
+				firstErr = fmt.Errorf("%s: %w", path, err)
+				return match
+			}
+			return contents
+		}
+
+		name, fallback := groups[1], groups[2]
+		if envValue := os.Getenv(name); envValue != "" {
+			return envValue
+		}
+		if fallback != "" {
+			return strings.TrimPrefix(fallback, ":-")
+		}
+		if _, isSet := os.LookupEnv(name); isSet {
+			return ""
+		}
+		firstErr = fmt.Errorf("%s: environment variable %s is not set (use ${%s:-default} to make it optional)", path, name, name)
+		return match
+	})
+	if firstErr != nil {
+		return "", firstErr
+	}
+	return result, nil
+}
+
+// readSecretFile returns the contents of a $(file:path) reference with
+// trailing newlines removed.
+func readSecretFile(path, baseDir string) (string, error) {
+	path = strings.TrimSpace(path)
+	if strings.HasPrefix(path, "~/") {
+		if home, err := os.UserHomeDir(); err == nil {
+			path = filepath.Join(home, path[2:])
+		}
+	}
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(baseDir, path)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read secret file: %w", err)
+	}
+	return strings.TrimRight(string(data), "\r\n"), nil
+}
@@ -0,0 +1,209 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestInterpolateString(t *testing.T) {
+	t.Setenv("MG_TEST_TOKEN", "s3cret")
+	t.Setenv("MG_TEST_EMPTY", "")
+
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "token.txt"), []byte("from-file\n"), 0o600); err != nil {
+		t.Fatalf("failed to write secret file: %v", err)
+	}
+
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"plain string", "hello", "hello"},
+		{"env var", "${MG_TEST_TOKEN}", "s3cret"},
+		{"embedded env var", "https://${MG_TEST_TOKEN}.example.com/", "https://s3cret.example.com/"},
+		{"default when unset", "${MG_TEST_UNSET:-fallback}", "fallback"},
+		{"default when empty", "${MG_TEST_EMPTY:-fallback}", "fallback"},
+		{"empty default", "${MG_TEST_UNSET:-}", ""},
+		{"set but empty", "${MG_TEST_EMPTY}", ""},
+		{"file", "$(file:token.txt)", "from-file"},
+		{"escaped env var", "$${MG_TEST_TOKEN}", "${MG_TEST_TOKEN}"},
+		{"escaped file", "$$(file:token.txt)", "$(file:token.txt)"},
+		{"lowercase is not a reference", "`${image}`", "`${image}`"},
+		{"lone dollar", "costs $5", "costs $5"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := interpolateString(tt.input, "field", dir)
+			if err != nil {
+				t.Fatalf("interpolateString(%q) error: %v", tt.input, err)
+			}
+			if got != tt.want {
+				t.Errorf("interpolateString(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInterpolateString_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantErr []string
+	}{
+		{"unset env var", "${MG_TEST_UNSET}", []string{"markata-go.token", "MG_TEST_UNSET", "not set"}},
+		{"missing file", "$(file:missing.txt)", []string{"markata-go.token", "missing.txt"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := interpolateString(tt.input, "markata-go.token", t.TempDir())
+			if err == nil {
+				t.Fatalf("interpolateString(%q) expected error", tt.input)
+			}
+			for _, want := range tt.wantErr {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("error %q should contain %q", err, want)
+				}
+			}
+		})
+	}
+}
+
+func TestLoad_InterpolatesEnvAndFiles(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("MG_TEST_SITE_URL", "https://example.com")
+	t.Setenv("MG_TEST_WM_TOKEN", "wm-token")
+
+	if err := os.MkdirAll(filepath.Join(dir, "secrets"), 0o755); err != nil {
+		t.Fatalf("failed to create secrets dir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "secrets", "title.txt"), []byte("Secret Title\n"), 0o600); err != nil {
+		t.Fatalf("failed to write secret file: %v", err)
+	}
+
+	content := `
+[markata-go]
+url = "${MG_TEST_SITE_URL}"
+title = "$(file:secrets/title.txt)"
+hooks = ["${MG_TEST_HOOK:-default}"]
+
+[markata-go.feed_defaults]
+items_per_page = 7
+
+[markata-go.webmentions]
+webmention_io_token = "${MG_TEST_WM_TOKEN}"
+`
+	configPath := filepath.Join(dir, "markata-go.toml")
+	if err := os.WriteFile(configPath, []byte(content), 0o644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	config, validationErrs, err := LoadAndValidate(configPath)
+	if err != nil {
+		t.Fatalf("LoadAndValidate() error: %v", err)
+	}
+	for _, vErr := range validationErrs {
+		if strings.Contains(vErr.Error(), "url") {
+			t.Errorf("url should be validated as the resolved value, got %v", vErr)
+		}
+	}
+
+	if config.URL != "https://example.com" {
+		t.Errorf("URL = %q, want %q", config.URL, "https://example.com")
+	}
+	if config.Title != "Secret Title" {
+		t.Errorf("Title = %q, want %q", config.Title, "Secret Title")
+	}
+	if len(config.Hooks) != 1 || config.Hooks[0] != "default" {
+		t.Errorf("Hooks = %v, want [default]", config.Hooks)
+	}
+	if config.FeedDefaults.ItemsPerPage != 7 {
+		t.Errorf("ItemsPerPage = %d, want 7", config.FeedDefaults.ItemsPerPage)
+	}
+
+	webmentions, ok := config.Extra["webmentions"].(map[string]any)
+	if !ok {
+		t.Fatalf("Extra[webmentions] = %#v, want a map", config.Extra["webmentions"])
+	}
+	if webmentions["webmention_io_token"] != "wm-token" {
+		t.Errorf("webmention_io_token = %v, want %q", webmentions["webmention_io_token"], "wm-token")
+	}
+}
+
+func TestLoad_InterpolationMissingEnvVar(t *testing.T) {
+	dir := t.TempDir()
+	content := `
+[markata-go]
+title = "Site"
+
+[markata-go.webmentions]
+webmention_io_token = "${MG_TEST_MISSING_TOKEN}"
+`
+	configPath := filepath.Join(dir, "markata-go.toml")
+	if err := os.WriteFile(configPath, []byte(content), 0o644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	_, err := Load(configPath)
+	if err == nil {
+		t.Fatal("Load() expected an error for an unset variable")
+	}
+	for _, want := range []string{"markata-go.webmentions.webmention_io_token", "MG_TEST_MISSING_TOKEN", "not set"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q should contain %q", err, want)
+		}
+	}
+}
+
+//nolint:gosec // Test file permissions are fine at 0644
+func TestLoad_ResolvesFileReferencesFromExtendedFileDirectory(t *testing.T) {
+	dir := t.TempDir()
+	baseDir := filepath.Join(dir, "base")
+	if err := os.MkdirAll(baseDir, 0o755); err != nil {
+		t.Fatalf("failed to create base dir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(baseDir, "title.txt"), []byte("Base Title\n"), 0o600); err != nil {
+		t.Fatalf("failed to write secret file: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "description.txt"), []byte("Site Description\n"), 0o600); err != nil {
+		t.Fatalf("failed to write secret file: %v", err)
+	}
+
+	baseContent := `
+[markata-go]
+title = "$(file:title.txt)"
+author = "$$(file:title.txt)"
+`
+	if err := os.WriteFile(filepath.Join(baseDir, "markata-go.toml"), []byte(baseContent), 0o644); err != nil {
+		t.Fatalf("failed to write base config: %v", err)
+	}
+
+	siteContent := `
+extends = "base/markata-go.toml"
+
+[markata-go]
+description = "$(file:description.txt)"
+`
+	sitePath := filepath.Join(dir, "markata-go.toml")
+	if err := os.WriteFile(sitePath, []byte(siteContent), 0o644); err != nil {
+		t.Fatalf("failed to write site config: %v", err)
+	}
+
+	config, err := Load(sitePath)
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	if config.Title != "Base Title" {
+		t.Errorf("Title = %q, want %q", config.Title, "Base Title")
+	}
+	if config.Description != "Site Description" {
+		t.Errorf("Description = %q, want %q", config.Description, "Site Description")
+	}
+	if config.Author != "$(file:title.txt)" {
+		t.Errorf("Author = %q, want the escaped reference kept literally", config.Author)
+	}
+}
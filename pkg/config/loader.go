@@ -3,6 +3,7 @@ package config
 import (
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"strings"
@@ -32,7 +33,9 @@ var ErrConfigNotFound = errors.New("no configuration file found")
 
 // Load loads configuration from the specified file path.
 // If configPath is empty, it will attempt to discover a config file.
-// Environment variable overrides are applied after loading the file.
+// The environment selected by MARKATA_GO_ENV is merged over the file,
+// ${VAR} and $(file:path) references in string values are resolved, and
+// environment variable overrides are applied after that.
 // A .env file in the current directory is loaded first (if present)
 // so that encryption keys and other settings can be stored there.
 func Load(configPath string) (*models.Config, error) {
@@ -41,7 +44,6 @@ func Load(configPath string) (*models.Config, error) {
 	// Errors loading .env are non-fatal (file may not exist).
 	_ = LoadDotEnv() //nolint:errcheck // .env loading is best-effort
 
-	var config *models.Config
 	var err error
 
 	if configPath == "" {
@@ -56,10 +58,81 @@ func Load(configPath string) (*models.Config, error) {
 		}
 	}
 
-	config, err = loadResolvedConfig(configPath)
+	f, err := os.Open(configPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file %s: %w", configPath, err)
+	}
+	defer f.Close()
+
+	return loadFromReader(f, formatFromPath(configPath), configPath)
+}
+
+// LoadFromReader loads configuration from r, which must contain a config
+// document in the given format ("toml", "yaml", "yml", or "json").
+// Defaults and environment variable overrides are applied exactly as in Load.
+// Relative extends and include paths are resolved from the working directory.
+func LoadFromReader(r io.Reader, format string) (*models.Config, error) {
+	f, err := ParseFormat(format)
+	if err != nil {
+		return nil, err
+	}
+
+	return loadFromReader(r, f, "")
+}
+
+// ParseFormat converts a format name such as "toml" or "yaml" into a Format.
+func ParseFormat(name string) (Format, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "toml":
+		return FormatTOML, nil
+	case "yaml", "yml":
+		return FormatYAML, nil
+	case "json":
+		return FormatJSON, nil
+	default:
+		return "", fmt.Errorf("unsupported config format %q (expected toml, yaml, or json)", name)
+	}
+}
+
+// loadFromReader parses, resolves, and materializes a config document.
+// configPath is the file r was opened from, or empty for other readers.
+func loadFromReader(r io.Reader, format Format, configPath string) (*models.Config, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config: %w", err)
+	}
+
+	var rawWrapper map[string]any
+	if configPath == "" {
+		rawWrapper, err = loadResolvedRawConfigData(data, format, ".")
+	} else {
+		rawWrapper, err = loadResolvedRawConfigFileData(configPath, data, format)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	rawWrapper, err = applyEnvironment(rawWrapper, ActiveEnvironment())
+	if err != nil {
+		return nil, err
+	}
+
+	baseDir := "."
+	if configPath != "" {
+		baseDir = filepath.Dir(configPath)
+	}
+	rawWrapper, err = interpolateRaw(rawWrapper, baseDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to interpolate config: %w", err)
+	}
+
+	deprecated := migrateDeprecatedKeys(rawWrapper)
+
+	config, err := configFromResolvedRaw(rawWrapper)
 	if err != nil {
 		return nil, err
 	}
+	config.DeprecatedKeys = deprecated
 
 	// Apply environment variable overrides
 	if err := ApplyEnvOverrides(config); err != nil {
@@ -161,6 +234,23 @@ func LoadWithMerge(basePath string, overridePaths ...string) (*models.Config, er
 		mergedRaw = mergeRawMaps(nil, mergedRaw, overrideRaw)
 	}
 
+	// Apply the selected environment over the merged files
+	mergedRaw, err = applyEnvironment(mergedRaw, ActiveEnvironment())
+	if err != nil {
+		return nil, err
+	}
+
+	baseDir := "."
+	if basePath != "" {
+		baseDir = filepath.Dir(basePath)
+	}
+	mergedRaw, err = interpolateRaw(mergedRaw, baseDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to interpolate config: %w", err)
+	}
+
+	deprecated := migrateDeprecatedKeys(mergedRaw)
+
 	defaultRaw, err := rawWrapperFromConfig(DefaultConfig())
 	if err != nil {
 		return nil, fmt.Errorf("failed to encode default config: %w", err)
@@ -172,6 +262,7 @@ func LoadWithMerge(basePath string, overridePaths ...string) (*models.Config, er
 	if err != nil {
 		return nil, fmt.Errorf("failed to decode merged config: %w", err)
 	}
+	baseConfig.DeprecatedKeys = deprecated
 
 	// Apply environment variable overrides last (highest precedence)
 	if err := ApplyEnvOverrides(baseConfig); err != nil {
@@ -237,10 +328,13 @@ func LoadFromString(data string, format Format) (*models.Config, error) {
 		return nil, fmt.Errorf("failed to encode default config: %w", err)
 	}
 
+	deprecated := migrateDeprecatedKeys(resolvedRaw)
+
 	config, err := configFromRawWrapper(mergeRawMaps(nil, defaultRaw, resolvedRaw))
 	if err != nil {
 		return nil, err
 	}
+	config.DeprecatedKeys = deprecated
 
 	return config, nil
 }
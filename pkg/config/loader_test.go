@@ -294,6 +294,42 @@ func TestLoadFromString_PreservesUnknownPluginConfig(t *testing.T) {
 	}
 }
 
+func TestLoadFromString_ExtraMatchesParseTOML(t *testing.T) {
+	content := `[markata-go]
+language = "en"
+auto_feeds = { tags = true }
+tailwind = { enabled = true }
+
+[markata-go.theme_calendar]
+enabled = true
+
+[markata-go.custom_plugin]
+enabled = true
+`
+	loaded, err := LoadFromString(content, FormatTOML)
+	if err != nil {
+		t.Fatalf("LoadFromString() error = %v", err)
+	}
+	parsed, err := ParseTOML([]byte(content))
+	if err != nil {
+		t.Fatalf("ParseTOML() error = %v", err)
+	}
+
+	for _, config := range []*models.Config{loaded, parsed} {
+		for _, key := range []string{"auto_feeds", "tailwind", "theme_calendar", "custom_plugin"} {
+			if _, ok := config.Extra[key]; !ok {
+				t.Errorf("Extra[%q] missing", key)
+			}
+		}
+		if _, ok := config.Extra["language"]; ok {
+			t.Error("language is parsed into a field and should not be copied to Extra")
+		}
+		if config.Language != "en" {
+			t.Errorf("Language = %q, want %q", config.Language, "en")
+		}
+	}
+}
+
 //nolint:gosec // Test file permissions are fine at 0644
 func TestLoadAndValidate(t *testing.T) {
 	dir := t.TempDir()
@@ -1506,3 +1542,386 @@ include_content = false
 		t.Error("FeedDefaults.Syndication.IncludeContent should be false")
 	}
 }
+
+//nolint:gosec // Test file permissions are fine at 0644
+func TestLoad_WithExtendsMergesCurrentFileOnTop(t *testing.T) {
+	dir := t.TempDir()
+	baseDir := filepath.Join(dir, "base")
+	siteDir := filepath.Join(dir, "site")
+	for _, d := range []string{baseDir, siteDir} {
+		if err := os.MkdirAll(d, 0o755); err != nil {
+			t.Fatalf("failed to create dir: %v", err)
+		}
+	}
+
+	baseContent := `
+[markata-go]
+title = "Base"
+description = "Shared description"
+output_dir = "base-out"
+
+[[markata-go.feeds]]
+slug = "blog"
+title = "Base Blog"
+filter = "published == True"
+`
+	if err := os.WriteFile(filepath.Join(baseDir, "markata-go.toml"), []byte(baseContent), 0o644); err != nil {
+		t.Fatalf("failed to write base config: %v", err)
+	}
+
+	siteContent := `
+extends = "../base/markata-go.toml"
+
+[markata-go]
+title = "Site"
+
+[[markata-go.feeds]]
+slug = "blog"
+title = "Site Blog"
+`
+	sitePath := filepath.Join(siteDir, "markata-go.toml")
+	if err := os.WriteFile(sitePath, []byte(siteContent), 0o644); err != nil {
+		t.Fatalf("failed to write site config: %v", err)
+	}
+
+	t.Setenv("MARKATA_GO_OUTPUT_DIR", "env-out")
+
+	config, err := Load(sitePath)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	if config.Title != "Site" {
+		t.Errorf("Title = %q, want %q", config.Title, "Site")
+	}
+	if config.Description != "Shared description" {
+		t.Errorf("Description = %q, want %q", config.Description, "Shared description")
+	}
+	if config.OutputDir != "env-out" {
+		t.Errorf("OutputDir = %q, want env override %q", config.OutputDir, "env-out")
+	}
+	feed := findFeedBySlug(t, config.Feeds, "blog")
+	if feed.Title != "Site Blog" {
+		t.Errorf("feed.Title = %q, want %q", feed.Title, "Site Blog")
+	}
+	if feed.Filter != "published == True" {
+		t.Errorf("feed.Filter = %q, want inherited filter", feed.Filter)
+	}
+	if _, ok := config.Extra["extends"]; ok {
+		t.Error("extends should not be exposed in Extra")
+	}
+}
+
+//nolint:gosec // Test file permissions are fine at 0644
+func TestLoad_WithExtendsListAcrossFormats(t *testing.T) {
+	dir := t.TempDir()
+
+	yamlContent := "markata-go:\n  title: YAML\n  description: From YAML\n  output_dir: yaml-out\n"
+	if err := os.WriteFile(filepath.Join(dir, "a.yaml"), []byte(yamlContent), 0o644); err != nil {
+		t.Fatalf("failed to write yaml config: %v", err)
+	}
+
+	jsonContent := `{"markata-go": {"title": "JSON", "output_dir": "json-out"}}`
+	if err := os.WriteFile(filepath.Join(dir, "b.json"), []byte(jsonContent), 0o644); err != nil {
+		t.Fatalf("failed to write json config: %v", err)
+	}
+
+	rootContent := "extends:\n  - a.yaml\n  - b.json\nmarkata-go:\n  output_dir: root-out\n"
+	rootPath := filepath.Join(dir, "markata-go.yaml")
+	if err := os.WriteFile(rootPath, []byte(rootContent), 0o644); err != nil {
+		t.Fatalf("failed to write root config: %v", err)
+	}
+
+	config, err := Load(rootPath)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	if config.Title != "JSON" {
+		t.Errorf("Title = %q, want later extends to win (%q)", config.Title, "JSON")
+	}
+	if config.Description != "From YAML" {
+		t.Errorf("Description = %q, want %q", config.Description, "From YAML")
+	}
+	if config.OutputDir != "root-out" {
+		t.Errorf("OutputDir = %q, want current file to win (%q)", config.OutputDir, "root-out")
+	}
+}
+
+//nolint:gosec // Test file permissions are fine at 0644
+func TestLoad_WithExtendsCycleReturnsError(t *testing.T) {
+	dir := t.TempDir()
+	rootPath := filepath.Join(dir, "markata-go.json")
+
+	if err := os.WriteFile(rootPath, []byte(`{"extends": "base.toml", "markata-go": {}}`), 0o644); err != nil {
+		t.Fatalf("failed to write root config: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "base.toml"), []byte(`extends = "markata-go.json"`), 0o644); err != nil {
+		t.Fatalf("failed to write base config: %v", err)
+	}
+
+	_, err := Load(rootPath)
+	if err == nil {
+		t.Fatal("Load() error = nil, want cycle error")
+	}
+	if !strings.Contains(err.Error(), "config extends cycle detected") {
+		t.Fatalf("Load() error = %v, want extends cycle error", err)
+	}
+}
+
+//nolint:gosec // Test file permissions are fine at 0644
+func TestLoad_WithEnvironmentAcrossFormats(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{"markata-go.toml", `
+[markata-go]
+title = "My Site"
+url = "http://localhost:8000"
+output_dir = "public"
+
+[markata-go.feed_defaults]
+items_per_page = 25
+
+[markata-go.environments.prod]
+url = "https://example.com"
+output_dir = "dist"
+
+[markata-go.environments.staging]
+url = "https://staging.example.com"
+`},
+		{"markata-go.yaml", `
+markata-go:
+  title: My Site
+  url: http://localhost:8000
+  output_dir: public
+  feed_defaults:
+    items_per_page: 25
+  environments:
+    prod:
+      url: https://example.com
+      output_dir: dist
+    staging:
+      url: https://staging.example.com
+`},
+		{"markata-go.json", `{"markata-go": {
+  "title": "My Site",
+  "url": "http://localhost:8000",
+  "output_dir": "public",
+  "feed_defaults": {"items_per_page": 25},
+  "environments": {
+    "prod": {"url": "https://example.com", "output_dir": "dist"},
+    "staging": {"url": "https://staging.example.com"}
+  }
+}}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), tt.name)
+			if err := os.WriteFile(path, []byte(tt.content), 0o644); err != nil {
+				t.Fatalf("failed to write config: %v", err)
+			}
+
+			t.Setenv(EnvironmentEnvVar, "prod")
+			config, err := Load(path)
+			if err != nil {
+				t.Fatalf("Load() error = %v", err)
+			}
+
+			if config.URL != "https://example.com" {
+				t.Errorf("URL = %q, want prod override", config.URL)
+			}
+			if config.OutputDir != "dist" {
+				t.Errorf("OutputDir = %q, want prod override", config.OutputDir)
+			}
+			if config.Title != "My Site" {
+				t.Errorf("Title = %q, want inherited base title", config.Title)
+			}
+			if config.FeedDefaults.ItemsPerPage != 25 {
+				t.Errorf("FeedDefaults.ItemsPerPage = %d, want inherited 25", config.FeedDefaults.ItemsPerPage)
+			}
+			if _, ok := config.Extra["environments"]; ok {
+				t.Error("environments should not be exposed in Extra")
+			}
+
+			t.Setenv(EnvironmentEnvVar, "")
+			config, err = Load(path)
+			if err != nil {
+				t.Fatalf("Load() without environment error = %v", err)
+			}
+			if config.URL != "http://localhost:8000" || config.OutputDir != "public" {
+				t.Errorf("URL, OutputDir = %q, %q; want base values without an environment", config.URL, config.OutputDir)
+			}
+		})
+	}
+}
+
+//nolint:gosec // Test file permissions are fine at 0644
+func TestLoad_WithEnvironmentFromExtendsAndEnvOverrides(t *testing.T) {
+	dir := t.TempDir()
+
+	baseContent := `
+[markata-go]
+title = "Base"
+url = "http://localhost:8000"
+
+[markata-go.environments.prod]
+url = "https://example.com"
+description = "Production"
+`
+	if err := os.WriteFile(filepath.Join(dir, "base.toml"), []byte(baseContent), 0o644); err != nil {
+		t.Fatalf("failed to write base config: %v", err)
+	}
+
+	siteContent := `
+extends = "base.toml"
+
+[markata-go]
+title = "Site"
+
+[markata-go.environments.prod]
+output_dir = "dist"
+`
+	sitePath := filepath.Join(dir, "markata-go.toml")
+	if err := os.WriteFile(sitePath, []byte(siteContent), 0o644); err != nil {
+		t.Fatalf("failed to write site config: %v", err)
+	}
+
+	t.Setenv(EnvironmentEnvVar, "prod")
+	t.Setenv("MARKATA_GO_URL", "https://env.example.com")
+
+	config, err := Load(sitePath)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	if config.Title != "Site" {
+		t.Errorf("Title = %q, want %q", config.Title, "Site")
+	}
+	if config.Description != "Production" || config.OutputDir != "dist" {
+		t.Errorf("Description, OutputDir = %q, %q; want prod sections from both files merged", config.Description, config.OutputDir)
+	}
+	if config.URL != "https://env.example.com" {
+		t.Errorf("URL = %q, want env var to win over the environment section", config.URL)
+	}
+}
+
+//nolint:gosec // Test file permissions are fine at 0644
+func TestLoad_WithUnknownEnvironment(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "markata-go.toml")
+	content := `
+[markata-go.environments.staging]
+url = "https://staging.example.com"
+
+[markata-go.environments.prod]
+url = "https://example.com"
+`
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	t.Setenv(EnvironmentEnvVar, "production")
+	_, err := Load(path)
+	if err == nil {
+		t.Fatal("Load() error = nil, want unknown environment error")
+	}
+	if !strings.Contains(err.Error(), `unknown environment "production" (valid environments: prod, staging)`) {
+		t.Errorf("Load() error = %v, want the valid environments listed", err)
+	}
+}
+
+//nolint:gosec // Test file permissions are fine at 0644
+func TestLoadWithMerge_WithEnvironment(t *testing.T) {
+	dir := t.TempDir()
+	basePath := filepath.Join(dir, "markata-go.toml")
+	localPath := filepath.Join(dir, "markata-go.local.toml")
+	if err := os.WriteFile(basePath, []byte("[markata-go]\ntitle = \"Base\"\n\n[markata-go.environments.prod]\noutput_dir = \"dist\"\n"), 0o644); err != nil {
+		t.Fatalf("failed to write base config: %v", err)
+	}
+	if err := os.WriteFile(localPath, []byte("[markata-go.environments.prod]\nurl = \"https://example.com\"\n"), 0o644); err != nil {
+		t.Fatalf("failed to write local config: %v", err)
+	}
+
+	t.Setenv(EnvironmentEnvVar, "prod")
+	config, err := LoadWithMerge(basePath, localPath)
+	if err != nil {
+		t.Fatalf("LoadWithMerge() error = %v", err)
+	}
+	if config.OutputDir != "dist" || config.URL != "https://example.com" || config.Title != "Base" {
+		t.Errorf("OutputDir, URL, Title = %q, %q, %q; want merged prod environment", config.OutputDir, config.URL, config.Title)
+	}
+}
+
+func TestLoadFromReader_RoundTripsFormats(t *testing.T) {
+	tests := []struct {
+		format  string
+		content string
+	}{
+		{
+			format: "toml",
+			content: `
+[markata-go]
+title = "Reader Site"
+output_dir = "reader-out"
+
+[[markata-go.feeds]]
+slug = "blog"
+`,
+		},
+		{
+			format:  "yaml",
+			content: "markata-go:\n  title: Reader Site\n  output_dir: reader-out\n  feeds:\n    - slug: blog\n",
+		},
+		{
+			format:  "json",
+			content: `{"markata-go": {"title": "Reader Site", "output_dir": "reader-out", "feeds": [{"slug": "blog"}]}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			config, err := LoadFromReader(strings.NewReader(tt.content), tt.format)
+			if err != nil {
+				t.Fatalf("LoadFromReader() error = %v", err)
+			}
+
+			if config.Title != "Reader Site" {
+				t.Errorf("Title = %q, want %q", config.Title, "Reader Site")
+			}
+			if config.OutputDir != "reader-out" {
+				t.Errorf("OutputDir = %q, want %q", config.OutputDir, "reader-out")
+			}
+			findFeedBySlug(t, config.Feeds, "blog")
+
+			// Defaults still apply to keys the document leaves out.
+			if config.TemplatesDir != DefaultConfig().TemplatesDir {
+				t.Errorf("TemplatesDir = %q, want default %q", config.TemplatesDir, DefaultConfig().TemplatesDir)
+			}
+		})
+	}
+}
+
+func TestLoadFromReader_AppliesEnvOverrides(t *testing.T) {
+	t.Setenv("MARKATA_GO_TITLE", "From Env")
+
+	config, err := LoadFromReader(strings.NewReader(`{"markata-go": {"title": "From File"}}`), "json")
+	if err != nil {
+		t.Fatalf("LoadFromReader() error = %v", err)
+	}
+	if config.Title != "From Env" {
+		t.Errorf("Title = %q, want %q", config.Title, "From Env")
+	}
+}
+
+func TestLoadFromReader_UnsupportedFormat(t *testing.T) {
+	_, err := LoadFromReader(strings.NewReader(""), "ini")
+	if err == nil {
+		t.Fatal("LoadFromReader() error = nil, want unsupported format error")
+	}
+	if !strings.Contains(err.Error(), `unsupported config format "ini"`) {
+		t.Errorf("LoadFromReader() error = %v, want unsupported format error", err)
+	}
+}
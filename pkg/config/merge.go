@@ -143,6 +143,9 @@ func MergeConfigs(base, override *models.Config) *models.Config {
 	// Feeds page - merge
 	result.FeedsPage = mergeFeedsPageConfig(base.FeedsPage, override.FeedsPage)
 
+	// Assets - merge
+	result.Assets = mergeAssetsConfig(base.Assets, override.Assets)
+
 	// Extra (plugin configs) - merge
 	result.Extra = mergeExtra(base.Extra, override.Extra)
 
@@ -1209,6 +1212,58 @@ func mergeMentionsConfig(base, override models.MentionsConfig) models.MentionsCo
 	return result
 }
 
+// mergeAssetsConfig merges AssetsConfig values.
+// Version pins and integrity hashes merge per asset key.
+func mergeAssetsConfig(base, override models.AssetsConfig) models.AssetsConfig {
+	result := base
+
+	if override.Mode != "" {
+		result.Mode = override.Mode
+	}
+	if override.CacheDir != "" {
+		result.CacheDir = override.CacheDir
+	}
+	if override.OutputDir != "" {
+		result.OutputDir = override.OutputDir
+	}
+	if override.VerifyIntegrity != nil {
+		result.VerifyIntegrity = override.VerifyIntegrity
+	}
+	if override.Concurrency > 0 {
+		result.Concurrency = override.Concurrency
+	}
+	if override.MaxAttempts > 0 {
+		result.MaxAttempts = override.MaxAttempts
+	}
+
+	result.Versions = mergeStringMap(base.Versions, override.Versions)
+	result.Integrity = mergeStringMap(base.Integrity, override.Integrity)
+
+	// Custom assets - replace if non-empty
+	if len(override.Custom) > 0 {
+		result.Custom = override.Custom
+	}
+
+	return result
+}
+
+// mergeStringMap returns a new map holding base's entries overlaid with
+// override's.
+func mergeStringMap(base, override map[string]string) map[string]string {
+	if len(override) == 0 {
+		return base
+	}
+
+	result := make(map[string]string, len(base)+len(override))
+	for k, v := range base {
+		result[k] = v
+	}
+	for k, v := range override {
+		result[k] = v
+	}
+	return result
+}
+
 // mergeGardenConfig merges GardenConfig values.
 // Since parser converters apply defaults (from NewGardenConfig()),
 // the override always has valid values for pointer fields.
@@ -2,6 +2,7 @@ package config
 
 import (
 	"encoding/json"
+	"sort"
 
 	"github.com/BurntSushi/toml"
 	"gopkg.in/yaml.v3"
@@ -23,6 +24,7 @@ type configSource interface {
 	getWebmention() webmentionConverter
 	getComponents() componentsConverter
 	getSearch() models.SearchConfig
+	getAssets() models.AssetsConfig
 	getLayout() layoutConverter
 	getSidebar() sidebarConverter
 	getToc() tocConverter
@@ -245,6 +247,9 @@ func buildConfig(src configSource) *models.Config {
 	// Convert Search config
 	config.Search = src.getSearch()
 
+	// Convert Assets config
+	config.Assets = src.getAssets()
+
 	// Convert Layout config
 	config.Layout = src.getLayout().toLayoutConfig()
 
@@ -296,6 +301,50 @@ func buildConfig(src configSource) *models.Config {
 	return config
 }
 
+// knownKeys lists the recognized top-level keys of the [markata-go] section.
+// Keys mapped to true are parsed into struct fields or consumed by the loader
+// and are kept out of Config.Extra; keys mapped to false are recognized but
+// still copied to Config.Extra for the plugins that read them.
+var knownKeys = map[string]bool{
+	"output_dir": true, "url": true, "title": true, "description": true,
+	"author": true, "license": true, "assets_dir": true, "templates_dir": true,
+	"nav": true, "footer": true, "hooks": true, "disabled_hooks": true,
+	"glob": true, "markdown": true, "feeds": true, "feed_defaults": true,
+	"concurrency": true, "theme": true, "post_formats": true, "well_known": true,
+	"seo": true, "indieauth": true, "webmention": true, "components": true,
+	"layout": true, "sidebar": true, "toc": true, "header": true,
+	"blogroll": true, "mentions": true, "template_presets": true,
+	"slug_conflicts":    false,
+	"default_templates": true, "auto_feeds": false, "head": true,
+	"content_templates": true, "footer_layout": true, "search": true,
+	"plugins": true, "thoughts": true, "wikilinks": true, "tags": true,
+	"tag_aggregator": true, "websub": true, "shortcuts": true, "view_transitions": true, "encryption": true,
+	"authors": true, "garden": true, "include": true, "tailwind": false, "css_purge": false,
+	"language": true, "author_url": true, "managing_editor": true, "webmaster": true,
+	"copyright": true, "templates": true, "feeds_page": true, "assets": true,
+	"resource_hints": false, "error_pages": false, "theme_calendar": false,
+	"extends": true, "environments": true, "strict_config": false, "diagnostics": false,
+	"log_level": false, "log_format": false,
+}
+
+// KnownKeys returns the sorted list of recognized top-level keys in the
+// [markata-go] section.
+func KnownKeys() []string {
+	keys := make([]string, 0, len(knownKeys))
+	for key := range knownKeys {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// IsKnownKey reports whether key is a recognized top-level key in the
+// [markata-go] section.
+func IsKnownKey(key string) bool {
+	_, ok := knownKeys[key]
+	return ok
+}
+
 // ParseTOML parses TOML configuration data into a Config struct.
 // The TOML data is expected to have a top-level [markata-go] section.
 func ParseTOML(data []byte) (*models.Config, error) {
@@ -327,24 +376,6 @@ func ParseTOML(data []byte) (*models.Config, error) {
 			config.Extra = make(map[string]any)
 		}
 
-		// List of known top-level keys that are already parsed into struct fields
-		knownKeys := map[string]bool{
-			"output_dir": true, "url": true, "title": true, "description": true,
-			"author": true, "license": true, "assets_dir": true, "templates_dir": true,
-			"nav": true, "footer": true, "hooks": true, "disabled_hooks": true,
-			"glob": true, "markdown": true, "feeds": true, "feed_defaults": true,
-			"concurrency": true, "theme": true, "post_formats": true, "well_known": true,
-			"seo": true, "indieauth": true, "webmention": true, "components": true,
-			"layout": true, "sidebar": true, "toc": true, "header": true,
-			"blogroll": true, "mentions": true, "template_presets": true,
-			"slug_conflicts":    false,
-			"default_templates": true, "auto_feeds": true, "head": true,
-			"content_templates": true, "footer_layout": true, "search": true,
-			"plugins": true, "thoughts": true, "wikilinks": true, "tags": true,
-			"tag_aggregator": true, "websub": true, "shortcuts": true, "view_transitions": true, "encryption": true,
-			"authors": true, "garden": true, "include": true, "tailwind": false, "css_purge": false,
-		}
-
 		// Copy unknown sections to Extra
 		for key, value := range markataGoRaw {
 			if !knownKeys[key] {
@@ -445,6 +476,7 @@ type tomlConfig struct {
 	IndieAuth       tomlIndieAuthConfig       `toml:"indieauth"`
 	Webmention      tomlWebmentionConfig      `toml:"webmention"`
 	Search          models.SearchConfig       `toml:"search"`
+	Assets          models.AssetsConfig       `toml:"assets"`
 	Components      tomlComponentsConfig      `toml:"components"`
 	Layout          tomlLayoutConfig          `toml:"layout"`
 	Sidebar         tomlSidebarConfig         `toml:"sidebar"`
@@ -472,7 +504,10 @@ type tomlNavItem struct {
 }
 
 type tomlTemplatesConfig struct {
-	Media tomlTemplatesMediaConfig `toml:"media"`
+	Media             tomlTemplatesMediaConfig `toml:"media"`
+	TimesinceMaxDays  *int                     `toml:"timesince_max_days"`
+	MaxLoopIterations *int                     `toml:"max_loop_iterations"`
+	MaxOutputBytes    *int                     `toml:"max_output_bytes"`
 }
 
 type tomlTemplatesMediaConfig struct {
@@ -484,6 +519,9 @@ func (t *tomlTemplatesConfig) toTemplatesConfig() models.TemplatesConfig {
 	if t.Media.TrustedDomains != nil {
 		cfg.Media.TrustedDomains = append([]string{}, t.Media.TrustedDomains...)
 	}
+	cfg.TimesinceMaxDays = t.TimesinceMaxDays
+	cfg.MaxLoopIterations = t.MaxLoopIterations
+	cfg.MaxOutputBytes = t.MaxOutputBytes
 	return cfg
 }
 
@@ -1772,6 +1810,7 @@ func (c *tomlConfig) getSEO() seoConverter                         { return &c.S
 func (c *tomlConfig) getIndieAuth() indieAuthConverter             { return &c.IndieAuth }
 func (c *tomlConfig) getWebmention() webmentionConverter           { return &c.Webmention }
 func (c *tomlConfig) getSearch() models.SearchConfig               { return c.Search }
+func (c *tomlConfig) getAssets() models.AssetsConfig               { return c.Assets }
 func (c *tomlConfig) getComponents() componentsConverter           { return &c.Components }
 func (c *tomlConfig) getLayout() layoutConverter                   { return &c.Layout }
 func (c *tomlConfig) getSidebar() sidebarConverter                 { return &c.Sidebar }
@@ -1976,6 +2015,7 @@ type yamlConfig struct {
 	IndieAuth       yamlIndieAuthConfig       `yaml:"indieauth"`
 	Webmention      yamlWebmentionConfig      `yaml:"webmention"`
 	Search          models.SearchConfig       `yaml:"search"`
+	Assets          models.AssetsConfig       `yaml:"assets"`
 	SEO             yamlSEOConfig             `yaml:"seo"`
 	Components      yamlComponentsConfig      `yaml:"components"`
 	Layout          yamlLayoutConfig          `yaml:"layout"`
@@ -2003,7 +2043,10 @@ type yamlNavItem struct {
 }
 
 type yamlTemplatesConfig struct {
-	Media yamlTemplatesMediaConfig `yaml:"media"`
+	Media             yamlTemplatesMediaConfig `yaml:"media"`
+	TimesinceMaxDays  *int                     `yaml:"timesince_max_days"`
+	MaxLoopIterations *int                     `yaml:"max_loop_iterations"`
+	MaxOutputBytes    *int                     `yaml:"max_output_bytes"`
 }
 
 type yamlTemplatesMediaConfig struct {
@@ -2015,6 +2058,9 @@ func (t *yamlTemplatesConfig) toTemplatesConfig() models.TemplatesConfig {
 	if t.Media.TrustedDomains != nil {
 		cfg.Media.TrustedDomains = append([]string{}, t.Media.TrustedDomains...)
 	}
+	cfg.TimesinceMaxDays = t.TimesinceMaxDays
+	cfg.MaxLoopIterations = t.MaxLoopIterations
+	cfg.MaxOutputBytes = t.MaxOutputBytes
 	return cfg
 }
 
@@ -3336,6 +3382,7 @@ func (c *yamlConfig) getSEO() seoConverter                         { return &c.S
 func (c *yamlConfig) getIndieAuth() indieAuthConverter             { return &c.IndieAuth }
 func (c *yamlConfig) getWebmention() webmentionConverter           { return &c.Webmention }
 func (c *yamlConfig) getSearch() models.SearchConfig               { return c.Search }
+func (c *yamlConfig) getAssets() models.AssetsConfig               { return c.Assets }
 func (c *yamlConfig) getComponents() componentsConverter           { return &c.Components }
 func (c *yamlConfig) getLayout() layoutConverter                   { return &c.Layout }
 func (c *yamlConfig) getSidebar() sidebarConverter                 { return &c.Sidebar }
@@ -3474,6 +3521,7 @@ type jsonConfig struct {
 	IndieAuth       jsonIndieAuthConfig       `json:"indieauth"`
 	Webmention      jsonWebmentionConfig      `json:"webmention"`
 	Search          models.SearchConfig       `json:"search"`
+	Assets          models.AssetsConfig       `json:"assets"`
 	SEO             jsonSEOConfig             `json:"seo"`
 	Components      jsonComponentsConfig      `json:"components"`
 	Layout          jsonLayoutConfig          `json:"layout"`
@@ -3501,7 +3549,10 @@ type jsonNavItem struct {
 }
 
 type jsonTemplatesConfig struct {
-	Media jsonTemplatesMediaConfig `json:"media"`
+	Media             jsonTemplatesMediaConfig `json:"media"`
+	TimesinceMaxDays  *int                     `json:"timesince_max_days"`
+	MaxLoopIterations *int                     `json:"max_loop_iterations"`
+	MaxOutputBytes    *int                     `json:"max_output_bytes"`
 }
 
 type jsonTemplatesMediaConfig struct {
@@ -3513,6 +3564,9 @@ func (t *jsonTemplatesConfig) toTemplatesConfig() models.TemplatesConfig {
 	if t.Media.TrustedDomains != nil {
 		cfg.Media.TrustedDomains = append([]string{}, t.Media.TrustedDomains...)
 	}
+	cfg.TimesinceMaxDays = t.TimesinceMaxDays
+	cfg.MaxLoopIterations = t.MaxLoopIterations
+	cfg.MaxOutputBytes = t.MaxOutputBytes
 	return cfg
 }
 
@@ -4858,6 +4912,7 @@ func (c *jsonConfig) getSEO() seoConverter                         { return &c.S
 func (c *jsonConfig) getIndieAuth() indieAuthConverter             { return &c.IndieAuth }
 func (c *jsonConfig) getWebmention() webmentionConverter           { return &c.Webmention }
 func (c *jsonConfig) getSearch() models.SearchConfig               { return c.Search }
+func (c *jsonConfig) getAssets() models.AssetsConfig               { return c.Assets }
 func (c *jsonConfig) getComponents() componentsConverter           { return &c.Components }
 func (c *jsonConfig) getLayout() layoutConverter                   { return &c.Layout }
 func (c *jsonConfig) getSidebar() sidebarConverter                 { return &c.Sidebar }
@@ -427,6 +427,67 @@ cors_origins = ["*"]
 	}
 }
 
+func TestParseTOML_AssetsConfig(t *testing.T) {
+	data := []byte(`
+[markata-go]
+title = "Test Site"
+
+[markata-go.assets]
+mode = "self-hosted"
+
+[markata-go.assets.versions]
+glightbox = "3.2.0"
+htmx = "2.0.4"
+
+[markata-go.assets.integrity]
+htmx = "sha384-abc"
+`)
+
+	config, err := ParseTOML(data)
+	if err != nil {
+		t.Fatalf("ParseTOML() error = %v", err)
+	}
+
+	if config.Assets.Mode != "self-hosted" {
+		t.Fatalf("Assets.Mode = %q, want %q", config.Assets.Mode, "self-hosted")
+	}
+	if config.Assets.Versions["glightbox"] != "3.2.0" || config.Assets.Versions["htmx"] != "2.0.4" {
+		t.Fatalf("Assets.Versions = %v", config.Assets.Versions)
+	}
+	if config.Assets.Integrity["htmx"] != "sha384-abc" {
+		t.Fatalf("Assets.Integrity = %v", config.Assets.Integrity)
+	}
+	if _, ok := config.Extra["assets"]; ok {
+		t.Fatal("assets should be parsed into Config.Assets, not Extra")
+	}
+}
+
+func TestParseTOML_CustomAssets(t *testing.T) {
+	data := []byte(`
+[markata-go]
+title = "Test Site"
+
+[[markata-go.assets.custom]]
+key = "mylib"
+url = "https://cdn.example.com/mylib@1.0.0/mylib.min.js"
+local_path = "mylib/mylib.min.js"
+integrity = "sha384-abc"
+`)
+
+	config, err := ParseTOML(data)
+	if err != nil {
+		t.Fatalf("ParseTOML() error = %v", err)
+	}
+
+	if len(config.Assets.Custom) != 1 {
+		t.Fatalf("len(Assets.Custom) = %d, want 1", len(config.Assets.Custom))
+	}
+	custom := config.Assets.Custom[0]
+	if custom.Key != "mylib" || custom.LocalPath != "mylib/mylib.min.js" || custom.Integrity != "sha384-abc" {
+		t.Fatalf("Assets.Custom[0] = %+v", custom)
+	}
+}
+
 func TestParseYAML_SearchConfig(t *testing.T) {
 	data := []byte(`
 markata-go:
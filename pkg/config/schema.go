@@ -0,0 +1,332 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/WaylonWalker/markata-go/pkg/diagnostics"
+	"github.com/WaylonWalker/markata-go/pkg/models"
+)
+
+// schemaDraft is the JSON Schema dialect JSONSchema emits. Draft-07 is the
+// newest draft the common TOML and YAML language servers fully support.
+const schemaDraft = "http://json-schema.org/draft-07/schema#"
+
+var (
+	timeType         = reflect.TypeOf(time.Time{})
+	licenseValueType = reflect.TypeOf(models.LicenseValue{})
+)
+
+// stringListSchema accepts a single string or a list of strings, as the
+// include and extends keys do.
+var stringListSchema = map[string]any{
+	"oneOf": []any{
+		map[string]any{"type": "string"},
+		map[string]any{"type": "array", "items": map[string]any{"type": "string"}},
+	},
+}
+
+// schemaDescriptions describes the top-level keys of the [markata-go]
+// section. Nested fields are left undescribed; their names mirror the docs.
+var schemaDescriptions = map[string]string{
+	"output_dir":        `Directory where generated files are written (default: "output").`,
+	"url":               "Base URL of the site.",
+	"title":             "Site title.",
+	"description":       "Site description.",
+	"author":            "Site author.",
+	"language":          "Site language used in feed and metadata outputs.",
+	"author_url":        "Canonical URL for the site author.",
+	"managing_editor":   "RSS managing editor contact.",
+	"webmaster":         "RSS webmaster contact.",
+	"copyright":         "Copyright notice for syndication outputs.",
+	"license":           "License key for the footer attribution, or false to hide it.",
+	"assets_dir":        `Directory containing static assets (default: "static").`,
+	"templates_dir":     `Directory containing templates (default: "templates").`,
+	"templates":         "Settings for template helpers such as media sizing and timesince.",
+	"nav":               "Navigation links.",
+	"footer":            "Site footer.",
+	"hooks":             `Hooks to run (default: ["default"]).`,
+	"disabled_hooks":    "Hooks to disable.",
+	"glob":              "Content file discovery.",
+	"markdown":          "Markdown processing and extensions.",
+	"feeds":             "Feed definitions.",
+	"feed_defaults":     "Default values for every feed.",
+	"concurrency":       "Number of concurrent workers (default: 0 = auto).",
+	"theme":             "Site theme and palette.",
+	"theme_calendar":    "Seasonal theme switching based on date ranges.",
+	"post_formats":      "Output formats for individual posts.",
+	"well_known":        "Auto-generated .well-known endpoints.",
+	"seo":               "SEO metadata generation.",
+	"indieauth":         "IndieAuth link tags for identity and authentication.",
+	"webmention":        "Webmention endpoint for receiving mentions.",
+	"websub":            "WebSub discovery links for feeds.",
+	"components":        "Layout components such as nav, footer, and sidebars.",
+	"head":              "Elements added to the HTML <head>.",
+	"search":            "Site-wide search using Pagefind.",
+	"layout":            "Layout system for page structure.",
+	"sidebar":           "Sidebar navigation component.",
+	"toc":               "Table of contents component.",
+	"header":            "Header component for layouts.",
+	"footer_layout":     "Footer component for layouts.",
+	"content_templates": "Content templates for the new command.",
+	"blogroll":          "Blogroll and RSS reader.",
+	"mentions":          "@mentions resolution.",
+	"error_pages":       "Custom error pages such as 404.",
+	"resource_hints":    "Automatic resource hints (preconnect, dns-prefetch, etc.).",
+	"encryption":        "Content encryption for private posts.",
+	"shortcuts":         "User-defined keyboard shortcuts.",
+	"view_transitions":  "Client-side animated page navigation.",
+	"tags":              "Tags listing page at /tags.",
+	"feeds_page":        "Feeds listing page at /feeds.",
+	"garden":            "Garden view for knowledge graph export and visualization.",
+	"tag_aggregator":    "Tag normalization and hierarchical expansion.",
+	"assets":            "Self-hosting of external CDN assets.",
+	"template_presets":  "Named template presets, each setting templates for all output formats.",
+	"default_templates": `Default templates per output format ("html", "txt", "markdown", "og").`,
+	"authors":           "Multi-author support.",
+	"include":           "Config files or globs merged into this one, relative to this file.",
+	"extends":           "Base config files loaded before this one.",
+	"strict_config":     "Treat unknown keys as errors instead of warnings.",
+	"diagnostics":       "Per-rule overrides for lint and editor diagnostics.",
+	"log_level":         `Minimum build log level: "debug", "info", "warn", or "error" (default: "info").`,
+	"log_format":        `Build log format: "auto", "plain", "rich", or "json" (default: "auto").`,
+	"tailwind":          "Tailwind CSS plugin.",
+	"css_purge":         "Unused CSS removal.",
+	"slug_conflicts":    "Slug conflict detection.",
+	"plugins":           "Plugin settings.",
+	"thoughts":          "Thoughts plugin.",
+	"wikilinks":         "Wikilink resolution.",
+	"auto_feeds":        "Automatically generated feeds.",
+}
+
+// extraKeySchemas types the known top-level keys that are not fields of
+// models.Config. Keys missing here accept any value.
+var extraKeySchemas = map[string]map[string]any{
+	"include":        stringListSchema,
+	"extends":        stringListSchema,
+	"strict_config":  {"type": "boolean"},
+	"diagnostics":    diagnosticsSchema(),
+	"log_level":      {"type": "string", "enum": []string{"debug", "info", "warn", "warning", "error"}},
+	"log_format":     {"type": "string", "enum": []string{"auto", "plain", "rich", "json"}},
+	"tailwind":       {"type": "object"},
+	"css_purge":      {"type": "object"},
+	"slug_conflicts": {"type": "object"},
+	"thoughts":       {"type": "object"},
+	"wikilinks":      {"type": "object"},
+}
+
+// diagnosticsSchema describes [markata-go.diagnostics], whose rules table
+// maps rule IDs to diagnostics.RuleConfig.
+func diagnosticsSchema() map[string]any {
+	rule := map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"enabled":  map[string]any{"type": "boolean"},
+			"severity": map[string]any{"type": "string", "enum": []string{"error", "warning", "info"}},
+		},
+	}
+	rules := make(map[string]any)
+	for _, id := range diagnostics.RuleIDs() {
+		rules[id] = rule
+	}
+	return map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"rules": map[string]any{"type": "object", "properties": rules},
+		},
+	}
+}
+
+// typeEnums lists the values of named string types.
+var typeEnums = map[reflect.Type][]string{
+	reflect.TypeOf(models.PaginationType("")): {
+		string(models.PaginationManual), string(models.PaginationHTMX),
+		string(models.PaginationHTMXInfinite), string(models.PaginationJS),
+	},
+	reflect.TypeOf(models.FeedType("")): {
+		string(models.FeedTypeBlog), string(models.FeedTypeSeries), string(models.FeedTypeGuide),
+	},
+	reflect.TypeOf(models.ReaderFeedType("")): {
+		string(models.ReaderFeedTypeWritten), string(models.ReaderFeedTypeVideo), string(models.ReaderFeedTypePodcast),
+	},
+}
+
+// fieldEnums lists the accepted values of plain string fields, keyed by
+// "StructName.FieldName". For slices the values apply to each item.
+var fieldEnums = map[string][]string{
+	"GlobConfig.SlugMode":                    {"flat", "path", "directory", "dir", "nested", "hierarchical"},
+	"SlugRule.Mode":                          {"flat", "path", "directory", "dir", "nested", "hierarchical"},
+	"NavComponentConfig.Position":            {"header", "sidebar"},
+	"NavComponentConfig.Style":               {"horizontal", "vertical"},
+	"DocSidebarConfig.Position":              {"left", "right"},
+	"FeedSidebarConfig.Position":             {"left", "right"},
+	"ContentSidebarConfig.Position":          {"left", "right"},
+	"PostConnectionsComponentConfig.Display": {"graph", "list"},
+	"AlternateFeed.Type":                     {"rss", "atom", "json"},
+	"SearchConfig.Position":                  {"navbar", "sidebar", "footer", "custom"},
+	"ThemeConfig.FallbackMode":               {"dark", "light"},
+	"ThemeSwitcherConfig.Position":           {"header", "footer"},
+	"DomainHint.HintTypes":                   {"preconnect", "dns-prefetch", "preload", "prefetch"},
+	"DomainHint.CrossOrigin":                 {"", "anonymous", "use-credentials"},
+	"DocsLayoutConfig.SidebarPosition":       {"left", "right"},
+	"DocsLayoutConfig.TocPosition":           {"left", "right"},
+	"DocsLayoutConfig.HeaderStyle":           {"full", "minimal", "transparent", "none"},
+	"DocsLayoutConfig.FooterStyle":           {"full", "minimal", "none"},
+	"BlogLayoutConfig.TocPosition":           {"left", "right"},
+	"BlogLayoutConfig.HeaderStyle":           {"full", "minimal", "transparent", "none"},
+	"BlogLayoutConfig.FooterStyle":           {"full", "minimal", "none"},
+	"LandingLayoutConfig.HeaderStyle":        {"full", "minimal", "transparent", "none"},
+	"LandingLayoutConfig.FooterStyle":        {"full", "minimal", "none"},
+	"SidebarConfig.Position":                 {"left", "right"},
+	"SidebarAutoGenerate.OrderBy":            {"title", "date", "nav_order", "filename"},
+	"TocConfig.Position":                     {"left", "right"},
+	"HeaderLayoutConfig.Style":               {"full", "minimal", "transparent", "none"},
+	"FooterLayoutConfig.Style":               {"full", "minimal", "none"},
+}
+
+// JSONSchema returns a JSON Schema (draft-07) for markata-go config files,
+// generated from the json tags of models.Config. Editors with TOML or YAML
+// schema support use it to complete and validate markata-go.toml and
+// markata-go.yaml.
+//
+// Tri-state *bool fields are plain booleans that may be omitted, known
+// string fields carry enum constraints, and every top-level key from
+// KnownKeys is present. Unknown keys stay allowed because plugins read their
+// own [markata-go.<plugin>] sections.
+func JSONSchema() ([]byte, error) {
+	g := &schemaGenerator{definitions: make(map[string]any)}
+	site := g.structSchema(reflect.TypeOf(models.Config{}))
+	site["description"] = "markata-go site configuration."
+
+	props, ok := site["properties"].(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("unexpected config schema shape")
+	}
+	for _, key := range KnownKeys() {
+		if _, exists := props[key]; exists {
+			continue
+		}
+		prop := map[string]any{}
+		for k, v := range extraKeySchemas[key] {
+			prop[k] = v
+		}
+		props[key] = prop
+	}
+	for key, description := range schemaDescriptions {
+		if prop, ok := props[key].(map[string]any); ok {
+			props[key] = withDescription(prop, description)
+		}
+	}
+
+	schema := map[string]any{
+		"$schema":     schemaDraft,
+		"title":       "markata-go configuration",
+		"type":        "object",
+		"definitions": g.definitions,
+		"properties": map[string]any{
+			"markata-go": site,
+			"extends": withDescription(stringListSchema,
+				"Base config files loaded before this one, relative to this file."),
+		},
+	}
+
+	data, err := json.MarshalIndent(schema, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal config schema: %w", err)
+	}
+	return data, nil
+}
+
+// schemaGenerator builds schemas for Go types, collecting named structs in
+// definitions so shared and recursive types are described once.
+type schemaGenerator struct {
+	definitions map[string]any
+}
+
+// structSchema describes the config fields of struct type t: exported
+// fields with both a json and a toml tag. Fields with only a json tag hold
+// runtime state, such as fetched blogroll entries, and are skipped.
+func (g *schemaGenerator) structSchema(t reflect.Type) map[string]any {
+	props := make(map[string]any)
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name, _ := parseJSONTag(field.Tag.Get("json"))
+		if name == "-" || field.Tag.Get("toml") == "" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		props[name] = g.typeSchema(field.Type, t.Name()+"."+field.Name)
+	}
+	return map[string]any{"type": "object", "properties": props}
+}
+
+// typeSchema describes type t for the struct field named by fieldKey.
+func (g *schemaGenerator) typeSchema(t reflect.Type, fieldKey string) map[string]any {
+	switch t {
+	case timeType:
+		return map[string]any{"type": "string", "format": "date-time"}
+	case licenseValueType:
+		return map[string]any{
+			"oneOf": []any{
+				map[string]any{"type": "string", "enum": models.LicenseKeys()},
+				map[string]any{"const": false},
+			},
+		}
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		// Unset pointers such as *bool tri-states are omitted, not null
+		return g.typeSchema(t.Elem(), fieldKey)
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]any{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}
+	case reflect.String:
+		schema := map[string]any{"type": "string"}
+		if values, ok := typeEnums[t]; ok {
+			schema["enum"] = values
+		} else if values, ok := fieldEnums[fieldKey]; ok {
+			schema["enum"] = values
+		}
+		return schema
+	case reflect.Slice, reflect.Array:
+		return map[string]any{"type": "array", "items": g.typeSchema(t.Elem(), fieldKey)}
+	case reflect.Map:
+		return map[string]any{"type": "object", "additionalProperties": g.typeSchema(t.Elem(), "")}
+	case reflect.Struct:
+		name := t.Name()
+		if _, ok := g.definitions[name]; !ok {
+			// Reserve the name first so recursive types terminate
+			g.definitions[name] = nil
+			g.definitions[name] = g.structSchema(t)
+		}
+		return map[string]any{"$ref": "#/definitions/" + name}
+	default:
+		return map[string]any{}
+	}
+}
+
+// withDescription returns a copy of schema with a description. A $ref
+// schema is wrapped in allOf, since draft-07 ignores keywords beside $ref.
+func withDescription(schema map[string]any, description string) map[string]any {
+	if _, ok := schema["$ref"]; ok {
+		return map[string]any{"description": description, "allOf": []any{schema}}
+	}
+	out := make(map[string]any, len(schema)+1)
+	for k, v := range schema {
+		out[k] = v
+	}
+	out["description"] = description
+	return out
+}
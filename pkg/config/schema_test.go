@@ -0,0 +1,144 @@
+package config
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func loadSchema(t *testing.T) map[string]any {
+	t.Helper()
+	data, err := JSONSchema()
+	if err != nil {
+		t.Fatalf("JSONSchema() error = %v", err)
+	}
+	var schema map[string]any
+	if err := json.Unmarshal(data, &schema); err != nil {
+		t.Fatalf("JSONSchema() produced invalid JSON: %v", err)
+	}
+	return schema
+}
+
+// resolveSchema follows a $ref, or an allOf wrapping one, to its definition.
+func resolveSchema(t *testing.T, root, node map[string]any) map[string]any {
+	t.Helper()
+	if allOf, ok := node["allOf"].([]any); ok && len(allOf) == 1 {
+		node, _ = allOf[0].(map[string]any)
+	}
+	ref, ok := node["$ref"].(string)
+	if !ok {
+		return node
+	}
+	defs, _ := root["definitions"].(map[string]any)
+	def, ok := defs[strings.TrimPrefix(ref, "#/definitions/")].(map[string]any)
+	if !ok {
+		t.Fatalf("unresolved $ref %q", ref)
+	}
+	return def
+}
+
+func siteProperties(t *testing.T, schema map[string]any) map[string]any {
+	t.Helper()
+	props, _ := schema["properties"].(map[string]any)
+	site, ok := props["markata-go"].(map[string]any)
+	if !ok {
+		t.Fatal("schema has no markata-go property")
+	}
+	siteProps, ok := site["properties"].(map[string]any)
+	if !ok {
+		t.Fatal("markata-go schema has no properties")
+	}
+	return siteProps
+}
+
+func TestJSONSchema_IsValidDraft07(t *testing.T) {
+	schema := loadSchema(t)
+	if schema["$schema"] != schemaDraft {
+		t.Errorf("$schema = %v, want %q", schema["$schema"], schemaDraft)
+	}
+
+	validTypes := map[string]bool{
+		"object": true, "array": true, "string": true, "integer": true,
+		"number": true, "boolean": true, "null": true,
+	}
+	var walk func(path string, node any)
+	walk = func(path string, node any) {
+		switch v := node.(type) {
+		case map[string]any:
+			if ref, ok := v["$ref"]; ok {
+				resolveSchema(t, schema, map[string]any{"$ref": ref})
+			}
+			if typ, ok := v["type"].(string); ok && !validTypes[typ] {
+				t.Errorf("%s: invalid type %q", path, typ)
+			}
+			if enum, ok := v["enum"]; ok {
+				if values, ok := enum.([]any); !ok || len(values) == 0 {
+					t.Errorf("%s: enum must be a non-empty array", path)
+				}
+			}
+			for k, child := range v {
+				walk(path+"/"+k, child)
+			}
+		case []any:
+			for _, child := range v {
+				walk(path+"/[]", child)
+			}
+		}
+	}
+	walk("#", schema)
+}
+
+func TestJSONSchema_CoversKnownKeys(t *testing.T) {
+	props := siteProperties(t, loadSchema(t))
+	for _, key := range KnownKeys() {
+		if _, ok := props[key]; !ok {
+			t.Errorf("schema missing top-level key %q", key)
+		}
+	}
+	for _, key := range []string{"output_dir", "url", "feeds"} {
+		prop, _ := props[key].(map[string]any)
+		if prop["description"] == nil {
+			t.Errorf("%s has no description", key)
+		}
+	}
+}
+
+func TestJSONSchema_FieldTypes(t *testing.T) {
+	schema := loadSchema(t)
+	props := siteProperties(t, schema)
+
+	// *bool tri-states are plain booleans
+	components := resolveSchema(t, schema, props["components"].(map[string]any))
+	nav := resolveSchema(t, schema, components["properties"].(map[string]any)["nav"].(map[string]any))
+	enabled, _ := nav["properties"].(map[string]any)["enabled"].(map[string]any)
+	if enabled["type"] != "boolean" {
+		t.Errorf("components.nav.enabled type = %v, want boolean", enabled["type"])
+	}
+
+	// Named string types carry their enum
+	defaults := resolveSchema(t, schema, props["feed_defaults"].(map[string]any))
+	pagination, _ := defaults["properties"].(map[string]any)["pagination_type"].(map[string]any)
+	if !strings.Contains(strings.Join(toStrings(pagination["enum"]), ","), "htmx-infinite") {
+		t.Errorf("feed_defaults.pagination_type enum = %v, want htmx-infinite included", pagination["enum"])
+	}
+
+	// Known string fields carry curated enums
+	position, _ := nav["properties"].(map[string]any)["position"].(map[string]any)
+	if got := strings.Join(toStrings(position["enum"]), ","); got != "header,sidebar" {
+		t.Errorf("components.nav.position enum = %q, want %q", got, "header,sidebar")
+	}
+
+	if props["concurrency"].(map[string]any)["type"] != "integer" {
+		t.Errorf("concurrency type = %v, want integer", props["concurrency"])
+	}
+}
+
+func toStrings(v any) []string {
+	items, _ := v.([]any)
+	out := make([]string, 0, len(items))
+	for _, item := range items {
+		s, _ := item.(string)
+		out = append(out, s)
+	}
+	return out
+}
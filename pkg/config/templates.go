@@ -0,0 +1,85 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/WaylonWalker/markata-go/pkg/models"
+	"github.com/WaylonWalker/markata-go/pkg/themes"
+)
+
+// ErrTemplateNotFound is returned by ResolveTemplate when no layer of the
+// fallback chain has the template.
+var ErrTemplateNotFound = errors.New("template not found")
+
+// ResolveTemplate finds the template name through the same fallback chain
+// the template engine uses, so a template missing from the project falls
+// back to the active theme and then to the built-in default theme:
+//
+//  1. the project templates directory (templates_dir)
+//  2. themes/<theme>/templates on disk
+//  3. the theme's embedded templates (see themes.RegisterTemplates)
+//  4. the built-in default theme
+//
+// It returns the template source and its origin, which names the layer
+// that provided it: "project:<path>", "theme:<name>:<path>" for a theme
+// directory on disk, "theme:<name>" for embedded theme templates, or
+// "builtin". A template found nowhere returns an error wrapping
+// ErrTemplateNotFound that lists the places searched.
+func ResolveTemplate(cfg *models.Config, name string) (source, origin string, err error) {
+	clean := path.Clean(filepath.ToSlash(name))
+	if name == "" || path.IsAbs(clean) || clean == ".." || strings.HasPrefix(clean, "../") {
+		return "", "", fmt.Errorf("invalid template name %q", name)
+	}
+
+	templatesDir := "templates"
+	themeName := themes.DefaultThemeName
+	if cfg != nil {
+		if cfg.TemplatesDir != "" {
+			templatesDir = cfg.TemplatesDir
+		}
+		if cfg.Theme.Name != "" {
+			themeName = cfg.Theme.Name
+		}
+	}
+
+	type templateDir struct{ dir, origin string }
+	dirs := []templateDir{{templatesDir, "project"}}
+	if themeName != themes.DefaultThemeName {
+		dirs = append(dirs, templateDir{filepath.Join("themes", themeName, "templates"), "theme:" + themeName})
+	}
+
+	var searched []string
+	for _, d := range dirs {
+		file := filepath.Join(d.dir, filepath.FromSlash(clean))
+		searched = append(searched, file)
+		data, readErr := os.ReadFile(file)
+		if readErr == nil {
+			return string(data), d.origin + ":" + file, nil
+		}
+		if !errors.Is(readErr, fs.ErrNotExist) {
+			return "", "", fmt.Errorf("reading template %q: %w", file, readErr)
+		}
+	}
+
+	if themeName != themes.DefaultThemeName {
+		if fsys, ok := themes.Templates(themeName); ok {
+			searched = append(searched, "theme "+themeName+" (embedded)")
+			if data, readErr := fs.ReadFile(fsys, clean); readErr == nil {
+				return string(data), "theme:" + themeName, nil
+			}
+		}
+	}
+
+	searched = append(searched, "built-in default theme")
+	if data, readErr := themes.ReadTemplate(clean); readErr == nil {
+		return string(data), "builtin", nil
+	}
+
+	return "", "", fmt.Errorf("%w: %q (searched %s)", ErrTemplateNotFound, name, strings.Join(searched, ", "))
+}
@@ -0,0 +1,76 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/WaylonWalker/markata-go/pkg/models"
+	"github.com/WaylonWalker/markata-go/pkg/themes"
+)
+
+func TestResolveTemplate(t *testing.T) {
+	themes.RegisterTemplates("resolve-test", fstest.MapFS{
+		"feed.html": {Data: []byte("theme feed")},
+		"post.html": {Data: []byte("theme post")},
+	})
+
+	projectDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(projectDir, "post.html"), []byte("project post"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	cfg := &models.Config{TemplatesDir: projectDir, Theme: models.ThemeConfig{Name: "resolve-test"}}
+
+	tests := []struct {
+		name       string
+		template   string
+		wantSource string
+		wantOrigin string
+	}{
+		{"project overrides theme", "post.html", "project post", "project:" + filepath.Join(projectDir, "post.html")},
+		{"only in theme", "feed.html", "theme feed", "theme:resolve-test"},
+		{"only built in", "base.html", "", "builtin"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			source, origin, err := ResolveTemplate(cfg, tt.template)
+			if err != nil {
+				t.Fatalf("ResolveTemplate() error = %v", err)
+			}
+			if origin != tt.wantOrigin {
+				t.Errorf("origin = %q, want %q", origin, tt.wantOrigin)
+			}
+			if tt.wantSource != "" && source != tt.wantSource {
+				t.Errorf("source = %q, want %q", source, tt.wantSource)
+			}
+			if source == "" {
+				t.Error("source is empty")
+			}
+		})
+	}
+}
+
+func TestResolveTemplate_Missing(t *testing.T) {
+	cfg := &models.Config{TemplatesDir: t.TempDir(), Theme: models.ThemeConfig{Name: "no-such-theme"}}
+
+	_, _, err := ResolveTemplate(cfg, "nope.html")
+	if !errors.Is(err, ErrTemplateNotFound) {
+		t.Fatalf("ResolveTemplate() error = %v, want ErrTemplateNotFound", err)
+	}
+	for _, want := range []string{`"nope.html"`, cfg.TemplatesDir, "built-in default theme"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q should mention %q", err, want)
+		}
+	}
+}
+
+func TestResolveTemplate_InvalidName(t *testing.T) {
+	for _, name := range []string{"", "../secret.html", "/etc/passwd"} {
+		if _, _, err := ResolveTemplate(nil, name); err == nil || errors.Is(err, ErrTemplateNotFound) {
+			t.Errorf("ResolveTemplate(%q) error = %v, want invalid name", name, err)
+		}
+	}
+}
@@ -4,7 +4,10 @@ import (
 	"errors"
 	"fmt"
 	"net/url"
+	"regexp"
+	"sort"
 	"strings"
+	"sync"
 
 	"github.com/WaylonWalker/markata-go/pkg/models"
 )
@@ -112,6 +115,14 @@ func ValidateConfig(config *models.Config) []error {
 		errs = append(errs, feedErrs...)
 	}
 
+	// Two feeds, or a feed and a built-in page, writing the same path
+	for _, c := range findFeedSlugCollisions(config) {
+		errs = append(errs, ValidationError{
+			Field:   fmt.Sprintf("feeds[%d].slug", c.index),
+			Message: c.message,
+		})
+	}
+
 	// Validate feed defaults
 	if config.FeedDefaults.ItemsPerPage < 0 {
 		errs = append(errs, ValidationError{
@@ -135,6 +146,12 @@ func ValidateConfig(config *models.Config) []error {
 		})
 	}
 
+	// Flag unknown top-level keys (typos land in Extra and are silently ignored)
+	errs = append(errs, validateUnknownKeys(config)...)
+
+	// Warn about renamed keys the loader migrated
+	errs = append(errs, validateDeprecatedKeys(config)...)
+
 	// Sort errors first, then warnings
 	sortErrors(errs)
 
@@ -223,6 +240,15 @@ func ValidateConfigWithPositions(config *models.Config, tracker *PositionTracker
 		feedWithDefaults.ApplyDefaults(config.FeedDefaults)
 		validateFeedConfigWithPositions(i, &feedWithDefaults, tracker, configErrors)
 	}
+	for _, c := range findFeedSlugCollisions(config) {
+		configErrors.Add(NewConfigError(
+			tracker,
+			fmt.Sprintf("feeds[%d].slug", c.index),
+			c.slug,
+			c.message,
+			false,
+		))
+	}
 
 	// Validate feed defaults
 	if config.FeedDefaults.ItemsPerPage < 0 {
@@ -258,6 +284,19 @@ func ValidateConfigWithPositions(config *models.Config, tracker *PositionTracker
 		))
 	}
 
+	// Warn about renamed keys the loader migrated
+	for _, key := range sortedDeprecatedKeys(config) {
+		replacement := config.DeprecatedKeys[key]
+		configErrors.Add(NewConfigErrorWithFix(
+			tracker,
+			key,
+			"",
+			deprecationMessage(replacement),
+			fmt.Sprintf("Rename %s to %s", key, replacement),
+			true,
+		))
+	}
+
 	return configErrors
 }
 
@@ -451,6 +490,225 @@ func validateFeedConfig(index int, feed *models.FeedConfig) []error {
 	return errs
 }
 
+// feedSlugCollision is a feed whose output path is already taken.
+type feedSlugCollision struct {
+	index   int
+	slug    string
+	message string
+}
+
+// reservedPath is an output path owned by a built-in page.
+type reservedPath struct {
+	field   string
+	pattern *regexp.Regexp
+}
+
+// reservedFeedPaths returns the paths the enabled built-in pages write to,
+// so feeds cannot take them.
+func reservedFeedPaths(config *models.Config) []reservedPath {
+	var paths []reservedPath
+	addPrefix := func(field, prefix, fallback string) {
+		prefix = normalizeFeedSlug(prefix)
+		if prefix == "" {
+			prefix = fallback
+		}
+		paths = append(paths, reservedPath{field: field, pattern: regexp.MustCompile("^" + regexp.QuoteMeta(prefix) + "$")})
+	}
+
+	if config.Tags.IsEnabled() {
+		addPrefix("tags.slug_prefix", config.Tags.SlugPrefix, "tags")
+	}
+	if config.FeedsPage.IsEnabled() {
+		addPrefix("feeds_page.slug_prefix", config.FeedsPage.SlugPrefix, "feeds")
+	}
+	if config.Garden.IsEnabled() && config.Garden.IsRenderPage() {
+		addPrefix("garden.path", config.Garden.GetPath(), "garden")
+	}
+	if config.Authors.GeneratePages {
+		pattern := config.Authors.URLPattern
+		if pattern == "" {
+			pattern = "/authors/{author}/"
+		}
+		// {author} and {id} match any single path segment
+		expr := regexp.QuoteMeta(normalizeFeedSlug(pattern))
+		expr = strings.NewReplacer(`\{author\}`, `[^/]+`, `\{id\}`, `[^/]+`).Replace(expr)
+		paths = append(paths, reservedPath{field: "authors.url_pattern", pattern: regexp.MustCompile("^" + expr + "$")})
+	}
+	return paths
+}
+
+// normalizeFeedSlug returns slug as it appears in output paths, without
+// surrounding slashes and lowercased.
+func normalizeFeedSlug(slug string) string {
+	return strings.ToLower(strings.Trim(strings.TrimSpace(slug), "/"))
+}
+
+// findFeedSlugCollisions reports feeds that share a slug with an earlier
+// feed or whose slug is a path reserved by a built-in page. Either way one
+// feed's output overwrites the other's.
+func findFeedSlugCollisions(config *models.Config) []feedSlugCollision {
+	var collisions []feedSlugCollision
+	reserved := reservedFeedPaths(config)
+	seen := make(map[string]int, len(config.Feeds))
+
+	for i := range config.Feeds {
+		slug := normalizeFeedSlug(config.Feeds[i].Slug)
+		if first, ok := seen[slug]; ok {
+			collisions = append(collisions, feedSlugCollision{
+				index:   i,
+				slug:    config.Feeds[i].Slug,
+				message: fmt.Sprintf("duplicate feed slug %q (also used by feeds[%d]); one feed would overwrite the other", slug, first),
+			})
+			continue
+		}
+		seen[slug] = i
+
+		if slug == "" {
+			continue
+		}
+		for _, r := range reserved {
+			if r.pattern.MatchString(slug) {
+				collisions = append(collisions, feedSlugCollision{
+					index:   i,
+					slug:    config.Feeds[i].Slug,
+					message: fmt.Sprintf("feed slug %q collides with the path reserved by %s", slug, r.field),
+				})
+				break
+			}
+		}
+	}
+	return collisions
+}
+
+// ValidateFeedPostSlugs warns about feeds whose slug matches the slug of a
+// post, since both write <slug>/index.html. Post slugs are not known when
+// the config is loaded, so callers run this once posts are loaded.
+func ValidateFeedPostSlugs(feeds []models.FeedConfig, postSlugs []string) []error {
+	posts := make(map[string]bool, len(postSlugs))
+	for _, slug := range postSlugs {
+		posts[normalizeFeedSlug(slug)] = true
+	}
+
+	var errs []error
+	for i := range feeds {
+		slug := normalizeFeedSlug(feeds[i].Slug)
+		if slug == "" || !posts[slug] {
+			continue
+		}
+		errs = append(errs, ValidationError{
+			Field:   fmt.Sprintf("feeds[%d].slug", i),
+			Message: fmt.Sprintf("feed slug %q is also a post slug; the feed and the post write the same page", slug),
+			IsWarn:  true,
+		})
+	}
+	return errs
+}
+
+// pluginSections holds the names of plugin config sections that may appear
+// as top-level keys in the [markata-go] section.
+var pluginSections = struct {
+	sync.RWMutex
+	names map[string]bool
+}{
+	names: make(map[string]bool),
+}
+
+// RegisterPluginSections marks names as valid plugin config sections so that
+// ValidateConfig does not report them as unknown keys.
+func RegisterPluginSections(names ...string) {
+	pluginSections.Lock()
+	defer pluginSections.Unlock()
+	for _, name := range names {
+		pluginSections.names[name] = true
+	}
+}
+
+func isPluginSection(name string) bool {
+	pluginSections.RLock()
+	defer pluginSections.RUnlock()
+	return pluginSections.names[name]
+}
+
+// isStrictConfig reports whether strict_config = true is set, which upgrades
+// unknown-key warnings to errors.
+func isStrictConfig(config *models.Config) bool {
+	strict, ok := config.Extra["strict_config"].(bool)
+	return ok && strict
+}
+
+// validateUnknownKeys reports top-level keys that are neither known config
+// keys nor registered plugin sections, suggesting the closest known key.
+func validateUnknownKeys(config *models.Config) []error {
+	if len(config.Extra) == 0 {
+		return nil
+	}
+
+	strict := isStrictConfig(config)
+	keys := make([]string, 0, len(config.Extra))
+	for key := range config.Extra {
+		if IsKnownKey(key) || isPluginSection(key) {
+			continue
+		}
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	errs := make([]error, 0, len(keys))
+	for _, key := range keys {
+		message := "unknown key"
+		if suggestion := suggestKnownKey(key); suggestion != "" {
+			message = fmt.Sprintf("unknown key (did you mean %s?)", suggestion)
+		}
+		errs = append(errs, ValidationError{
+			Field:   key,
+			Message: message,
+			IsWarn:  !strict,
+		})
+	}
+	return errs
+}
+
+// suggestKnownKey returns the known key closest to key by Levenshtein
+// distance, or "" if nothing is close enough to be a likely typo.
+func suggestKnownKey(key string) string {
+	maxDistance := len(key) / 2
+	if maxDistance < 2 {
+		maxDistance = 2
+	}
+
+	best := ""
+	bestDistance := maxDistance + 1
+	for _, candidate := range KnownKeys() {
+		if d := levenshtein(key, candidate); d < bestDistance {
+			best, bestDistance = candidate, d
+		}
+	}
+	return best
+}
+
+// levenshtein returns the edit distance between a and b.
+func levenshtein(a, b string) int {
+	ar, br := []rune(a), []rune(b)
+	prev := make([]int, len(br)+1)
+	curr := make([]int, len(br)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ar); i++ {
+		curr[0] = i
+		for j := 1; j <= len(br); j++ {
+			cost := 1
+			if ar[i-1] == br[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(br)]
+}
+
 // hasAnyFormat returns true if any feed format is enabled.
 func hasAnyFormat(formats models.FeedFormats) bool {
 	return formats.HTML || formats.SimpleHTML || formats.RSS || formats.Atom ||
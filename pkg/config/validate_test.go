@@ -2,6 +2,7 @@ package config
 
 import (
 	"errors"
+	"strings"
 	"testing"
 
 	"github.com/WaylonWalker/markata-go/pkg/models"
@@ -181,6 +182,86 @@ func TestValidateConfig_FeedEmptySlugAllowed(t *testing.T) {
 	}
 }
 
+func TestValidateConfig_DuplicateFeedSlugs(t *testing.T) {
+	config := &models.Config{
+		GlobConfig: models.GlobConfig{
+			Patterns: []string{"**/*.md"},
+		},
+		Feeds: []models.FeedConfig{
+			{Slug: "blog", Formats: models.FeedFormats{HTML: true}},
+			{Slug: "notes", Formats: models.FeedFormats{HTML: true}},
+			{Slug: "/Blog/", Formats: models.FeedFormats{HTML: true}},
+		},
+	}
+
+	errs := ValidateConfig(config)
+	actualErrors, _ := SplitErrorsAndWarnings(errs)
+	if len(actualErrors) != 1 {
+		t.Fatalf("ValidateConfig() errors = %v, want one duplicate slug error", actualErrors)
+	}
+	got := actualErrors[0].Error()
+	if !strings.Contains(got, "feeds[2].slug") || !strings.Contains(got, "feeds[0]") {
+		t.Errorf("error = %q, want feeds[2].slug reported as a duplicate of feeds[0]", got)
+	}
+}
+
+func TestValidateConfig_FeedSlugReservedPath(t *testing.T) {
+	tests := []struct {
+		name    string
+		slug    string
+		modify  func(*models.Config)
+		wantErr bool
+	}{
+		{name: "tags prefix", slug: "tags", wantErr: true},
+		{name: "custom tags prefix", slug: "topics", modify: func(c *models.Config) { c.Tags.SlugPrefix = "topics" }, wantErr: true},
+		{name: "tag feed below prefix", slug: "tags/python", wantErr: false},
+		{name: "tags page disabled", slug: "tags", modify: func(c *models.Config) {
+			disabled := false
+			c.Tags.Enabled = &disabled
+		}, wantErr: false},
+		{name: "feeds page", slug: "feeds", wantErr: true},
+		{name: "garden", slug: "garden", wantErr: true},
+		{name: "author pattern", slug: "authors/waylon", modify: func(c *models.Config) { c.Authors.GeneratePages = true }, wantErr: true},
+		{name: "author pages off", slug: "authors/waylon", wantErr: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := &models.Config{
+				GlobConfig: models.GlobConfig{
+					Patterns: []string{"**/*.md"},
+				},
+				Feeds: []models.FeedConfig{
+					{Slug: tt.slug, Formats: models.FeedFormats{HTML: true}},
+				},
+			}
+			if tt.modify != nil {
+				tt.modify(config)
+			}
+
+			errs := ValidateConfig(config)
+			if HasErrors(errs) != tt.wantErr {
+				t.Errorf("ValidateConfig() errors = %v, wantErr %v", errs, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateFeedPostSlugs(t *testing.T) {
+	feeds := []models.FeedConfig{
+		{Slug: ""},
+		{Slug: "blog"},
+		{Slug: "about"},
+	}
+
+	errs := ValidateFeedPostSlugs(feeds, []string{"", "about", "hello-world"})
+	if len(errs) != 1 {
+		t.Fatalf("ValidateFeedPostSlugs() = %v, want one warning", errs)
+	}
+	if !isWarning(errs[0]) || !strings.Contains(errs[0].Error(), "feeds[2].slug") {
+		t.Errorf("ValidateFeedPostSlugs() = %v, want a warning for feeds[2].slug", errs[0])
+	}
+}
+
 func TestValidateConfig_FeedNegativeItemsPerPage(t *testing.T) {
 	config := &models.Config{
 		GlobConfig: models.GlobConfig{
@@ -645,3 +726,75 @@ func TestValidateConfigWithPositions_NilConfig(t *testing.T) {
 		t.Errorf("Expected 'config' field, got %q", configErrors.Errors[0].Field)
 	}
 }
+
+func TestValidateConfig_UnknownKeys(t *testing.T) {
+	RegisterPluginSections("my_plugin")
+
+	config := &models.Config{
+		GlobConfig: models.GlobConfig{Patterns: []string{"**/*.md"}},
+		Extra: map[string]any{
+			"output_directory": "public",
+			"tailwind":         map[string]any{},
+			"my_plugin":        map[string]any{"enabled": true},
+			"zzzzqqq":          true,
+		},
+	}
+
+	errs := ValidateConfig(config)
+	if HasErrors(errs) {
+		t.Fatalf("unknown keys should be warnings by default, got errors: %v", errs)
+	}
+
+	var unknown []ValidationError
+	for _, err := range errs {
+		var ve ValidationError
+		if errors.As(err, &ve) && strings.HasPrefix(ve.Message, "unknown key") {
+			unknown = append(unknown, ve)
+		}
+	}
+
+	if len(unknown) != 2 {
+		t.Fatalf("got %d unknown key warnings, want 2: %v", len(unknown), unknown)
+	}
+	if unknown[0].Field != "output_directory" || !strings.Contains(unknown[0].Message, "did you mean output_dir?") {
+		t.Errorf("unexpected warning for output_directory: %v", unknown[0])
+	}
+	if unknown[1].Field != "zzzzqqq" || strings.Contains(unknown[1].Message, "did you mean") {
+		t.Errorf("unexpected warning for zzzzqqq: %v", unknown[1])
+	}
+}
+
+func TestValidateConfig_UnknownKeysStrict(t *testing.T) {
+	config := &models.Config{
+		GlobConfig: models.GlobConfig{Patterns: []string{"**/*.md"}},
+		Extra: map[string]any{
+			"strict_config": true,
+			"titel":         "Typo",
+		},
+	}
+
+	errs := ValidateConfig(config)
+	if !HasErrors(errs) {
+		t.Fatalf("strict_config should turn unknown keys into errors, got: %v", errs)
+	}
+	if !strings.Contains(errs[0].Error(), "did you mean title?") {
+		t.Errorf("error = %v, want suggestion for title", errs[0])
+	}
+}
+
+func TestLevenshtein(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"", "", 0},
+		{"abc", "", 3},
+		{"kitten", "sitting", 3},
+		{"output_dir", "output_directory", 6},
+	}
+	for _, tt := range tests {
+		if got := levenshtein(tt.a, tt.b); got != tt.want {
+			t.Errorf("levenshtein(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
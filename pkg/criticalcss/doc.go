@@ -19,6 +19,47 @@
 //   - Media elements: img, video, figure
 //   - Layout elements: .page-wrapper, .main-content
 //
+// # Layout Profiles
+//
+// Different layouts put different things above the fold, so selectors can
+// be chosen per layout. Built-in profiles cover the "docs", "blog",
+// "landing", and "bare" layouts; RegisterProfile adds or replaces others:
+//
+//	ext, ok := criticalcss.NewExtractorForLayout("docs")
+//	ext := criticalcss.NewExtractorWithSelectors([]string{"body", ".hero"})
+//
+// The critical_css plugin picks the profile from each post's layout.
+//
+// # Deduplication
+//
+// Result.Hash identifies the critical CSS independent of formatting and of
+// reordering that cannot change the result, so callers can share one copy
+// between pages that inline the same rules. The critical_css plugin tags
+// each inlined block with data-critical-hash and reports how many bytes
+// were inlined more than once.
+//
+// # Media Queries
+//
+// Critical CSS targets the first paint on a mobile screen. Rules inside an
+// @media query are critical only if the query can match a mobile viewport:
+// queries for print, or requiring a min-width at or above MobileBreakpoint
+// (768px by default), stay in the remaining CSS with their enclosing
+// @media and @supports rules intact. @font-face and top-level base rules
+// are always critical.
+//
+// # Multiple Stylesheets
+//
+// ExtractMany takes several named sheets, matches them in order as one
+// cascade, and returns the remaining CSS per sheet so each file can still
+// be loaded asynchronously on its own. Local @import rules are inlined
+// first, from the other sheets or from files under ImportRoot:
+//
+//	ext := criticalcss.NewExtractor().WithImportRoot(outputDir)
+//	critical, remaining, err := ext.ExtractMany([]criticalcss.NamedCSS{
+//		{Name: "css/base.css", CSS: base},
+//		{Name: "css/theme.css", CSS: theme},
+//	})
+//
 // # Usage
 //
 // The Extractor type provides the main API:
@@ -2,6 +2,8 @@ package criticalcss
 
 import (
 	"regexp"
+	"sort"
+	"strconv"
 	"strings"
 )
 
@@ -108,6 +110,11 @@ var defaultCriticalSelectors = []string{
 	".sr-only",
 }
 
+// DefaultMobileBreakpoint is the default width, in CSS pixels, at which
+// @media queries stop counting as mobile. It matches the common 768px
+// tablet breakpoint.
+const DefaultMobileBreakpoint = 768
+
 // Extractor handles CSS parsing and critical CSS extraction.
 type Extractor struct {
 	// CriticalSelectors is the list of selectors considered critical
@@ -118,6 +125,16 @@ type Extractor struct {
 
 	// MinifyOutput controls whether to minify the output CSS
 	MinifyOutput bool
+
+	// MobileBreakpoint is the width in CSS pixels from which @media queries
+	// target larger screens. Rules under a query requiring a min-width at or
+	// above it are never critical. Zero or less disables the check.
+	MobileBreakpoint int
+
+	// ImportRoot is the directory local @import URLs are read from when
+	// the imported sheet is not passed to ExtractMany, usually the site's
+	// output directory. Empty keeps such imports as @import rules.
+	ImportRoot string
 }
 
 // NewExtractor creates a new Extractor with default settings.
@@ -126,6 +143,7 @@ func NewExtractor() *Extractor {
 		CriticalSelectors: defaultCriticalSelectors,
 		ExcludeSelectors:  []string{},
 		MinifyOutput:      true,
+		MobileBreakpoint:  DefaultMobileBreakpoint,
 	}
 }
 
@@ -147,6 +165,19 @@ func (e *Extractor) WithMinify(minify bool) *Extractor {
 	return e
 }
 
+// WithMobileBreakpoint sets the width at which @media queries stop being
+// considered mobile.
+func (e *Extractor) WithMobileBreakpoint(px int) *Extractor {
+	e.MobileBreakpoint = px
+	return e
+}
+
+// WithImportRoot sets the directory local @import URLs are read from.
+func (e *Extractor) WithImportRoot(dir string) *Extractor {
+	e.ImportRoot = dir
+	return e
+}
+
 // Result holds the extracted CSS parts.
 type Result struct {
 	// Critical is the CSS that should be inlined
@@ -160,21 +191,71 @@ type Result struct {
 
 	// TotalSize is the total size of all CSS in bytes
 	TotalSize int
+
+	// Hash identifies the critical CSS so callers can share identical blocks
+	// between pages. It ignores whitespace, comments, and the order of
+	// selectors in a list and of independent declarations in a rule; see
+	// HashCSS. Empty when there is no critical CSS.
+	Hash string
 }
 
 // Extract separates CSS into critical and non-critical parts.
 // It parses the CSS and identifies rules matching critical selectors.
+// It is ExtractMany for a single unnamed sheet.
 func (e *Extractor) Extract(css string) (*Result, error) {
-	// Build selector sets for fast lookup
-	criticalSet := e.buildSelectorSet(e.CriticalSelectors)
-	excludeSet := e.buildSelectorSet(e.ExcludeSelectors)
+	critical, remaining, err := e.ExtractMany([]NamedCSS{{CSS: css}})
+	if err != nil {
+		return nil, err
+	}
+	return e.newResult(critical, remaining[""], len(css)), nil
+}
+
+// ExtractMultiple extracts critical CSS from multiple CSS sources.
+// Sources are processed in order of their names, so the output is the same
+// on every run, and their non-critical parts are joined into one.
+// Use ExtractMany to keep the declared order and per-file output.
+func (e *Extractor) ExtractMultiple(cssFiles map[string]string) (*Result, error) {
+	names := make([]string, 0, len(cssFiles))
+	for name := range cssFiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
 
-	// Parse CSS into rules
-	rules := e.parseRules(css)
+	sheets := make([]NamedCSS, 0, len(names))
+	total := 0
+	for _, name := range names {
+		sheets = append(sheets, NamedCSS{Name: name, CSS: cssFiles[name]})
+		total += len(cssFiles[name]) + 1
+	}
+
+	critical, remaining, err := e.ExtractMany(sheets)
+	if err != nil {
+		return nil, err
+	}
+
+	parts := make([]string, 0, len(names))
+	for _, name := range names {
+		if remaining[name] != "" {
+			parts = append(parts, remaining[name])
+		}
+	}
+	return e.newResult(critical, strings.Join(parts, "\n"), total), nil
+}
 
-	var criticalRules []string
-	var nonCriticalRules []string
+// newResult builds a Result for extracted CSS from totalSize bytes of input.
+func (e *Extractor) newResult(critical, nonCritical string, totalSize int) *Result {
+	return &Result{
+		Critical:     critical,
+		NonCritical:  nonCritical,
+		CriticalSize: len(critical),
+		TotalSize:    totalSize,
+		Hash:         e.HashCSS(critical),
+	}
+}
 
+// splitRules separates rules into critical and non-critical rules,
+// descending into conditional group rules such as @media and @supports.
+func (e *Extractor) splitRules(rules []string, criticalSet, excludeSet map[string]bool) (criticalRules, nonCriticalRules []string) {
 	for _, rule := range rules {
 		if e.isAtRule(rule) {
 			// Handle @rules specially
@@ -204,33 +285,7 @@ func (e *Extractor) Extract(css string) (*Result, error) {
 		}
 	}
 
-	// Build output
-	critical := strings.Join(criticalRules, "\n")
-	nonCritical := strings.Join(nonCriticalRules, "\n")
-
-	if e.MinifyOutput {
-		critical = e.minify(critical)
-		nonCritical = e.minify(nonCritical)
-	}
-
-	return &Result{
-		Critical:     critical,
-		NonCritical:  nonCritical,
-		CriticalSize: len(critical),
-		TotalSize:    len(css),
-	}, nil
-}
-
-// ExtractMultiple extracts critical CSS from multiple CSS sources.
-func (e *Extractor) ExtractMultiple(cssFiles map[string]string) (*Result, error) {
-	// Combine all CSS content
-	var combined strings.Builder
-	for _, content := range cssFiles {
-		combined.WriteString(content)
-		combined.WriteString("\n")
-	}
-
-	return e.Extract(combined.String())
+	return criticalRules, nonCriticalRules
 }
 
 // buildSelectorSet creates a map for fast selector lookup.
@@ -276,6 +331,17 @@ func (e *Extractor) parseRules(css string) []string {
 			continue
 		}
 
+		// Statement at-rules such as @import end at a top-level semicolon
+		if c == ';' && depth == 0 {
+			current.WriteRune(c)
+			rule := strings.TrimSpace(current.String())
+			if rule != ";" {
+				rules = append(rules, rule)
+			}
+			current.Reset()
+			continue
+		}
+
 		// Handle braces
 		if c == '{' {
 			depth++
@@ -329,6 +395,9 @@ func (e *Extractor) isAtRuleWithSelectors(rule string) bool {
 }
 
 // splitAtRule splits an @rule into critical and non-critical parts.
+// Nested @rules are split recursively, each part keeping its enclosing
+// headers. An @media rule that excludes mobile viewports is entirely
+// non-critical.
 func (e *Extractor) splitAtRule(rule string, criticalSet, excludeSet map[string]bool) (critical, nonCritical string) {
 	// Find the opening brace
 	braceIdx := strings.Index(rule, "{")
@@ -339,6 +408,10 @@ func (e *Extractor) splitAtRule(rule string, criticalSet, excludeSet map[string]
 	// Extract the at-rule header (e.g., "@media (max-width: 768px)")
 	header := rule[:braceIdx+1]
 
+	if !e.includesMobileViewport(rule[:braceIdx]) {
+		return "", rule
+	}
+
 	// Extract the content between the first { and last }
 	lastBrace := strings.LastIndex(rule, "}")
 	if lastBrace == -1 || lastBrace <= braceIdx {
@@ -349,16 +422,7 @@ func (e *Extractor) splitAtRule(rule string, criticalSet, excludeSet map[string]
 	// Parse the inner rules
 	innerRules := e.parseRules(content)
 
-	var criticalInner []string
-	var nonCriticalInner []string
-
-	for _, innerRule := range innerRules {
-		if e.isCriticalRule(innerRule, criticalSet, excludeSet) {
-			criticalInner = append(criticalInner, innerRule)
-		} else {
-			nonCriticalInner = append(nonCriticalInner, innerRule)
-		}
-	}
+	criticalInner, nonCriticalInner := e.splitRules(innerRules, criticalSet, excludeSet)
 
 	// Rebuild at-rules with their respective contents
 	if len(criticalInner) > 0 {
@@ -371,6 +435,71 @@ func (e *Extractor) splitAtRule(rule string, criticalSet, excludeSet map[string]
 	return critical, nonCritical
 }
 
+// Regular expressions for the width conditions of media queries.
+var (
+	// Match (min-width: 1200px)
+	mediaMinWidthRegex = regexp.MustCompile(`\(\s*min-width\s*:\s*([\d.]+)(px|em|rem)\s*\)`)
+
+	// Match (width >= 1200px) and (width > 1200px)
+	mediaWidthGreaterRegex = regexp.MustCompile(`\(\s*width\s*>=?\s*([\d.]+)(px|em|rem)\s*\)`)
+
+	// Match (1200px <= width) and (1200px < width)
+	mediaWidthLessRegex = regexp.MustCompile(`\(\s*([\d.]+)(px|em|rem)\s*<=?\s*width\b`)
+)
+
+// includesMobileViewport reports whether an at-rule header applies to a
+// mobile screen. Only @media rules can exclude it: a media query list does
+// so when every query targets a non-screen media type (print, speech) or
+// requires a min-width at or above MobileBreakpoint. Queries using "not"
+// are assumed to match.
+func (e *Extractor) includesMobileViewport(header string) bool {
+	header = strings.ToLower(strings.TrimSpace(header))
+	if !strings.HasPrefix(header, "@media") {
+		return true
+	}
+
+	for _, query := range strings.Split(strings.TrimPrefix(header, "@media"), ",") {
+		query = strings.TrimSpace(query)
+		if query == "" || strings.HasPrefix(query, "not ") {
+			return true
+		}
+		query = strings.TrimPrefix(query, "only ")
+
+		mediaType := ""
+		if !strings.HasPrefix(query, "(") {
+			mediaType = strings.Fields(query)[0]
+		}
+		if mediaType == "print" || mediaType == "speech" {
+			continue
+		}
+
+		if e.MobileBreakpoint <= 0 || e.mediaMinWidth(query) < float64(e.MobileBreakpoint) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// mediaMinWidth returns the largest minimum width, in CSS pixels, that a
+// media query requires, or 0 if it has none. em and rem are taken as 16px.
+func (e *Extractor) mediaMinWidth(query string) float64 {
+	minWidth := 0.0
+	for _, re := range []*regexp.Regexp{mediaMinWidthRegex, mediaWidthGreaterRegex, mediaWidthLessRegex} {
+		for _, match := range re.FindAllStringSubmatch(query, -1) {
+			value, err := strconv.ParseFloat(match[1], 64)
+			if err != nil {
+				continue
+			}
+			if match[2] != "px" {
+				value *= 16
+			}
+			minWidth = max(minWidth, value)
+		}
+	}
+	return minWidth
+}
+
 // isCriticalRule determines if a CSS rule is critical.
 func (e *Extractor) isCriticalRule(rule string, criticalSet, excludeSet map[string]bool) bool {
 	// Extract selector(s) from the rule
@@ -463,25 +592,9 @@ func (e *Extractor) splitSelector(selector string) []string {
 	return result
 }
 
-// minify performs basic CSS minification.
+// minify minifies CSS with Minify.
 func (e *Extractor) minify(css string) string {
-	// Remove comments
-	css = e.removeComments(css)
-
-	// Remove excessive whitespace
-	css = regexp.MustCompile(`\s+`).ReplaceAllString(css, " ")
-
-	// Remove whitespace around certain characters
-	css = regexp.MustCompile(`\s*{\s*`).ReplaceAllString(css, "{")
-	css = regexp.MustCompile(`\s*}\s*`).ReplaceAllString(css, "}")
-	css = regexp.MustCompile(`\s*;\s*`).ReplaceAllString(css, ";")
-	css = regexp.MustCompile(`\s*:\s*`).ReplaceAllString(css, ":")
-	css = regexp.MustCompile(`\s*,\s*`).ReplaceAllString(css, ",")
-
-	// Remove trailing semicolons before closing braces
-	css = strings.ReplaceAll(css, ";}", "}")
-
-	return strings.TrimSpace(css)
+	return Minify(css)
 }
 
 // removeComments removes CSS comments.
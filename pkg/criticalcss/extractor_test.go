@@ -115,6 +115,122 @@ body {
 	}
 }
 
+func TestExtractor_Extract_DesktopMediaQueries(t *testing.T) {
+	ext := NewExtractor().WithMinify(false)
+
+	css := `
+@font-face {
+	font-family: "Inter";
+	src: url("/fonts/inter.woff2") format("woff2");
+}
+
+body {
+	margin: 0;
+}
+
+@media (min-width: 1200px) {
+	body {
+		font-size: 18px;
+	}
+}
+
+@media screen and (min-width: 480px) {
+	.container {
+		padding: 1rem;
+	}
+}
+
+@media print {
+	nav {
+		display: none;
+	}
+}
+
+@media (prefers-color-scheme: dark) {
+	body {
+		background: black;
+	}
+}
+`
+
+	result, err := ext.Extract(css)
+	if err != nil {
+		t.Fatalf("Extract failed: %v", err)
+	}
+
+	for _, want := range []string{"@font-face", "margin: 0", "padding: 1rem", "background: black"} {
+		if !strings.Contains(result.Critical, want) {
+			t.Errorf("Expected %q in critical CSS, got:\n%s", want, result.Critical)
+		}
+	}
+	for _, want := range []string{"font-size: 18px", "@media print"} {
+		if strings.Contains(result.Critical, want) {
+			t.Errorf("Expected %q NOT in critical CSS", want)
+		}
+		if !strings.Contains(result.NonCritical, want) {
+			t.Errorf("Expected %q in non-critical CSS, got:\n%s", want, result.NonCritical)
+		}
+	}
+}
+
+func TestExtractor_Extract_NestedAtRules(t *testing.T) {
+	ext := NewExtractor()
+
+	css := `
+@supports (display: grid) {
+	.container { display: grid; }
+	.widget { display: grid; }
+	@media (min-width: 64em) {
+		.container { grid-template-columns: 1fr 1fr; }
+	}
+}
+`
+
+	result, err := ext.Extract(css)
+	if err != nil {
+		t.Fatalf("Extract failed: %v", err)
+	}
+
+	wantCritical := "@supports (display:grid){.container{display:grid}}"
+	if result.Critical != wantCritical {
+		t.Errorf("Critical = %q, want %q", result.Critical, wantCritical)
+	}
+	wantNonCritical := "@supports (display:grid){.widget{display:grid}@media (min-width:64em){.container{grid-template-columns:1fr 1fr}}}"
+	if result.NonCritical != wantNonCritical {
+		t.Errorf("NonCritical = %q, want %q", result.NonCritical, wantNonCritical)
+	}
+}
+
+func TestExtractor_includesMobileViewport(t *testing.T) {
+	tests := []struct {
+		header     string
+		breakpoint int
+		expected   bool
+	}{
+		{"@media (max-width: 768px)", 768, true},
+		{"@media (min-width: 768px)", 768, false},
+		{"@media (min-width: 767px)", 768, true},
+		{"@media screen and (min-width: 75em)", 768, false},
+		{"@media (width >= 1024px)", 768, false},
+		{"@media (1024px <= width)", 768, false},
+		{"@media (min-width: 1200px), (orientation: portrait)", 768, true},
+		{"@media only screen and (min-width: 1200px)", 1300, true},
+		{"@media (min-width: 1200px)", 0, true},
+		{"@media print", 768, false},
+		{"@media not print", 768, true},
+		{"@supports (display: grid)", 768, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.header, func(t *testing.T) {
+			ext := NewExtractor().WithMobileBreakpoint(tt.breakpoint)
+			if got := ext.includesMobileViewport(tt.header); got != tt.expected {
+				t.Errorf("includesMobileViewport(%q) = %v, want %v", tt.header, got, tt.expected)
+			}
+		})
+	}
+}
+
 func TestExtractor_Extract_WithExtraSelectors(t *testing.T) {
 	ext := NewExtractor().WithSelectors([]string{".my-custom-class"})
 
@@ -0,0 +1,110 @@
+package criticalcss
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"sort"
+	"strings"
+)
+
+// HashCSS returns a SHA256 hash of CSS that is the same for equivalent
+// stylesheets. Before hashing, the CSS is minified and, within each rule,
+// selector lists and declarations are sorted. Declarations are only sorted
+// when no property repeats or overrides another (e.g. "margin" and
+// "margin-top"), since their order matters then. Rule order is kept, as it
+// affects the cascade. Returns "" for empty CSS.
+func (e *Extractor) HashCSS(css string) string {
+	canonical := e.canonicalCSS(e.minify(css))
+	if canonical == "" {
+		return ""
+	}
+	h := sha256.Sum256([]byte(canonical))
+	return hex.EncodeToString(h[:])
+}
+
+// canonicalCSS rewrites minified CSS into the canonical form hashed by
+// HashCSS.
+func (e *Extractor) canonicalCSS(css string) string {
+	var b strings.Builder
+	for _, rule := range e.parseRules(css) {
+		braceIdx := strings.Index(rule, "{")
+		lastBrace := strings.LastIndex(rule, "}")
+		if braceIdx == -1 || lastBrace < braceIdx {
+			b.WriteString(rule)
+			continue
+		}
+
+		prelude := rule[:braceIdx]
+		body := rule[braceIdx+1 : lastBrace]
+
+		switch {
+		case e.isAtRule(rule) && strings.Contains(body, "{"):
+			// Group rules (@media, @supports, @keyframes) hold nested rules
+			b.WriteString(prelude + "{" + e.canonicalCSS(body) + "}")
+		case e.isAtRule(rule):
+			b.WriteString(prelude + "{" + canonicalDeclarations(body) + "}")
+		default:
+			selectors := splitTopLevel(prelude, ',')
+			sort.Strings(selectors)
+			b.WriteString(strings.Join(selectors, ",") + "{" + canonicalDeclarations(body) + "}")
+		}
+	}
+	return b.String()
+}
+
+// canonicalDeclarations sorts a minified declaration block when the order
+// of its declarations cannot change the result.
+func canonicalDeclarations(body string) string {
+	var decls []string
+	for _, decl := range splitTopLevel(body, ';') {
+		if decl != "" {
+			decls = append(decls, decl)
+		}
+	}
+
+	properties := make([]string, len(decls))
+	for i, decl := range decls {
+		name, _, _ := strings.Cut(decl, ":")
+		properties[i] = strings.ToLower(name)
+	}
+	for i, a := range properties {
+		for _, b := range properties[i+1:] {
+			if a == b || strings.HasPrefix(a, b+"-") || strings.HasPrefix(b, a+"-") {
+				// Repeated or overlapping properties: order is significant
+				return strings.Join(decls, ";")
+			}
+		}
+	}
+
+	sort.Strings(decls)
+	return strings.Join(decls, ";")
+}
+
+// splitTopLevel splits s on sep outside parentheses, brackets, and quotes.
+func splitTopLevel(s string, sep byte) []string {
+	var parts []string
+	depth := 0
+	var quote byte
+	start := 0
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case quote != 0:
+			if c == quote && (i == 0 || s[i-1] != '\\') {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '(' || c == '[':
+			depth++
+		case c == ')' || c == ']':
+			if depth > 0 {
+				depth--
+			}
+		case c == sep && depth == 0:
+			parts = append(parts, s[start:i])
+			start = i + 1
+		}
+	}
+	return append(parts, s[start:])
+}
@@ -0,0 +1,135 @@
+package criticalcss
+
+import "testing"
+
+func TestExtractor_HashCSS_Equivalent(t *testing.T) {
+	ext := NewExtractor()
+
+	tests := []struct {
+		name string
+		a, b string
+	}{
+		{
+			name: "whitespace and comments",
+			a:    "body { margin: 0; }",
+			b:    "/* reset */\nbody{\n  margin:0\n}\n",
+		},
+		{
+			name: "selector list order",
+			a:    "h1, h2, .title { font-weight: bold; }",
+			b:    ".title, h2, h1 { font-weight: bold; }",
+		},
+		{
+			name: "independent declaration order",
+			a:    "body { margin: 0; color: black; line-height: 1.5; }",
+			b:    "body { line-height: 1.5; margin: 0; color: black; }",
+		},
+		{
+			name: "inside media queries",
+			a:    "@media (max-width: 600px) { a, p { color: red; padding: 0; } }",
+			b:    "@media (max-width: 600px) {\n  p, a { padding: 0; color: red; }\n}",
+		},
+		{
+			name: "functional selector lists",
+			a:    ":is(h1, h2), p { margin: 0; }",
+			b:    "p, :is(h1, h2) { margin: 0; }",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hashA, hashB := ext.HashCSS(tt.a), ext.HashCSS(tt.b)
+			if hashA == "" || hashA != hashB {
+				t.Errorf("HashCSS differs for equivalent CSS:\n%q -> %s\n%q -> %s", tt.a, hashA, tt.b, hashB)
+			}
+		})
+	}
+}
+
+func TestExtractor_HashCSS_Different(t *testing.T) {
+	ext := NewExtractor()
+
+	tests := []struct {
+		name string
+		a, b string
+	}{
+		{
+			name: "different values",
+			a:    "body { margin: 0; }",
+			b:    "body { margin: 1px; }",
+		},
+		{
+			name: "rule order affects the cascade",
+			a:    "a { color: red; } .link { color: blue; }",
+			b:    ".link { color: blue; } a { color: red; }",
+		},
+		{
+			name: "overriding declarations keep their order",
+			a:    "p { margin: 0; margin-top: 1rem; }",
+			b:    "p { margin-top: 1rem; margin: 0; }",
+		},
+		{
+			name: "repeated property fallbacks keep their order",
+			a:    "p { width: 100%; width: fit-content; }",
+			b:    "p { width: fit-content; width: 100%; }",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if ext.HashCSS(tt.a) == ext.HashCSS(tt.b) {
+				t.Errorf("HashCSS should differ:\n%q\n%q", tt.a, tt.b)
+			}
+		})
+	}
+}
+
+func TestExtractor_HashCSS_Empty(t *testing.T) {
+	if got := NewExtractor().HashCSS("  /* nothing */ "); got != "" {
+		t.Errorf("HashCSS(empty) = %q, want empty", got)
+	}
+}
+
+func TestExtractor_Extract_Hash(t *testing.T) {
+	ext := NewExtractor()
+
+	// Critical rules reordered within their declarations and selector lists
+	a, err := ext.Extract("body, html { margin: 0; color: black; } .widget { display: none; }")
+	if err != nil {
+		t.Fatalf("Extract failed: %v", err)
+	}
+	b, err := ext.Extract("html, body { color: black; margin: 0; }\n.widget { display: block; }")
+	if err != nil {
+		t.Fatalf("Extract failed: %v", err)
+	}
+	if a.Hash == "" || a.Hash != b.Hash {
+		t.Errorf("Hash = %q and %q, want equal for equivalent critical CSS", a.Hash, b.Hash)
+	}
+	if a.Hash != ext.HashCSS(a.Critical) {
+		t.Error("Result.Hash should be HashCSS of the critical CSS")
+	}
+}
+
+func TestExtractMultiple_StableOrder(t *testing.T) {
+	ext := NewExtractor()
+	files := map[string]string{
+		"a.css": "body { margin: 0; }",
+		"b.css": "p { color: red; }",
+		"c.css": "h1 { font-size: 2rem; }",
+		"d.css": "a { color: blue; }",
+	}
+
+	first, err := ext.ExtractMultiple(files)
+	if err != nil {
+		t.Fatalf("ExtractMultiple failed: %v", err)
+	}
+	for i := 0; i < 20; i++ {
+		result, err := ext.ExtractMultiple(files)
+		if err != nil {
+			t.Fatalf("ExtractMultiple failed: %v", err)
+		}
+		if result.Critical != first.Critical || result.Hash != first.Hash {
+			t.Fatalf("ExtractMultiple output changed between runs:\n%q\n%q", first.Critical, result.Critical)
+		}
+	}
+}
@@ -0,0 +1,313 @@
+package criticalcss
+
+import "strings"
+
+// zeroLengthUnits are the units whose zero value may drop the unit. Times,
+// angles, and percentages are excluded because a bare 0 is invalid or means
+// something else for some properties.
+var zeroLengthUnits = map[string]bool{
+	"px": true, "em": true, "rem": true, "ex": true, "ch": true,
+	"vw": true, "vh": true, "vmin": true, "vmax": true,
+	"cm": true, "mm": true, "in": true, "pt": true, "pc": true, "q": true,
+}
+
+// groupAtRules are at-rules whose blocks hold rules rather than declarations.
+var groupAtRules = map[string]bool{
+	"media": true, "supports": true, "document": true, "-moz-document": true,
+	"layer": true, "container": true, "scope": true, "starting-style": true,
+}
+
+// Minify returns css with comments and redundant whitespace removed, zero
+// lengths written as 0, and the last semicolon of each block dropped.
+//
+// The input is scanned token by token rather than with regular expressions,
+// so strings, url() values, and escaped characters are copied unchanged, and
+// whitespace is only removed where it cannot change meaning: around ':' in
+// declarations but not in selectors ("a :hover"), and around '+' in
+// selectors but not in values ("calc(1px + 2px)"). Zero lengths keep their
+// unit inside functions, where calc() requires it, and in custom property
+// values, which may end up in calc().
+func Minify(css string) string {
+	m := minifier{in: css, out: make([]byte, 0, len(css))}
+	m.run()
+	return strings.TrimSpace(string(m.out))
+}
+
+// minifier holds the scanner state for Minify.
+type minifier struct {
+	in  string
+	out []byte
+
+	// blocks records, for each open block, whether it holds declarations
+	blocks []bool
+
+	// preludeStart is the output offset where the current selector, at-rule
+	// prelude, or declaration starts
+	preludeStart int
+
+	// parens is the parenthesis depth within the current prelude or
+	// declaration
+	parens int
+
+	// inValue is set after the ':' of a declaration, and customProperty
+	// when that declaration's name starts with "--"
+	inValue        bool
+	customProperty bool
+
+	// space is set when whitespace or a comment has been skipped
+	space bool
+}
+
+func (m *minifier) inDeclarations() bool {
+	return len(m.blocks) > 0 && m.blocks[len(m.blocks)-1]
+}
+
+// inAtPrelude reports whether the current prelude is an at-rule's.
+func (m *minifier) inAtPrelude() bool {
+	prelude := strings.TrimSpace(string(m.out[m.preludeStart:]))
+	return strings.HasPrefix(prelude, "@")
+}
+
+// noSpaceAround reports whether whitespace next to c can be dropped in the
+// current context.
+func (m *minifier) noSpaceAround(c byte) bool {
+	switch c {
+	case '{', '}', ';', ',':
+		return true
+	case ':':
+		return m.inDeclarations() || (m.inAtPrelude() && m.parens > 0)
+	case '>', '+', '~':
+		return !m.inDeclarations() && !m.inAtPrelude()
+	}
+	return false
+}
+
+// flushSpace writes a pending space before next if one is needed.
+func (m *minifier) flushSpace(next byte) {
+	if !m.space {
+		return
+	}
+	m.space = false
+	if len(m.out) == 0 || next == ')' || m.noSpaceAround(next) {
+		return
+	}
+	last := m.out[len(m.out)-1]
+	if last == '(' || m.noSpaceAround(last) {
+		return
+	}
+	m.out = append(m.out, ' ')
+}
+
+func (m *minifier) run() {
+	for i := 0; i < len(m.in); {
+		c := m.in[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\f':
+			m.space = true
+			i++
+
+		case c == '/' && i+1 < len(m.in) && m.in[i+1] == '*':
+			end := strings.Index(m.in[i+2:], "*/")
+			if end == -1 {
+				return
+			}
+			m.space = true
+			i += 2 + end + 2
+
+		case c == '"' || c == '\'':
+			m.flushSpace(c)
+			i = m.copyString(i)
+
+		case c == '\\':
+			m.flushSpace(c)
+			i = m.copyEscape(i)
+
+		case isURLStart(m.in, i) && !m.prevIsNameChar():
+			m.flushSpace(c)
+			i = m.copyURL(i)
+
+		case c == '0' && m.zeroLengthAt(i):
+			m.flushSpace(c)
+			m.out = append(m.out, '0')
+			i++
+			for i < len(m.in) && isLetter(m.in[i]) {
+				i++
+			}
+
+		default:
+			m.flushSpace(c)
+			m.writePunct(c)
+			i++
+		}
+	}
+}
+
+// writePunct writes c, updating block and declaration state.
+func (m *minifier) writePunct(c byte) {
+	switch c {
+	case '{':
+		m.blocks = append(m.blocks, m.opensDeclarations())
+		m.out = append(m.out, c)
+		m.startPrelude()
+	case '}':
+		if n := len(m.out); n > 0 && m.out[n-1] == ';' {
+			m.out = m.out[:n-1]
+		}
+		if len(m.blocks) > 0 {
+			m.blocks = m.blocks[:len(m.blocks)-1]
+		}
+		m.out = append(m.out, c)
+		m.startPrelude()
+	case ';':
+		m.out = append(m.out, c)
+		m.startPrelude()
+	case ':':
+		if m.inDeclarations() && m.parens == 0 && !m.inValue {
+			name := strings.TrimSpace(string(m.out[m.preludeStart:]))
+			m.inValue = true
+			m.customProperty = strings.HasPrefix(name, "--")
+		}
+		m.out = append(m.out, c)
+	case '(':
+		m.parens++
+		m.out = append(m.out, c)
+	case ')':
+		if m.parens > 0 {
+			m.parens--
+		}
+		m.out = append(m.out, c)
+	default:
+		m.out = append(m.out, c)
+	}
+}
+
+// startPrelude resets per-prelude state after a block boundary or ';'.
+func (m *minifier) startPrelude() {
+	m.preludeStart = len(m.out)
+	m.parens = 0
+	m.inValue = false
+	m.customProperty = false
+}
+
+// opensDeclarations reports whether the block opened by the current prelude
+// holds declarations. Group at-rules and @keyframes hold rules.
+func (m *minifier) opensDeclarations() bool {
+	prelude := strings.TrimSpace(string(m.out[m.preludeStart:]))
+	if !strings.HasPrefix(prelude, "@") {
+		return true
+	}
+	name := strings.ToLower(prelude[1:])
+	if end := strings.IndexAny(name, " ({"); end != -1 {
+		name = name[:end]
+	}
+	return !groupAtRules[name] && !strings.HasSuffix(name, "keyframes")
+}
+
+// zeroLengthAt reports whether a standalone zero length such as "0px"
+// starts at i and may drop its unit.
+func (m *minifier) zeroLengthAt(i int) bool {
+	if !m.inValue || m.customProperty || m.parens > 0 || m.prevIsNameChar() {
+		return false
+	}
+	if n := len(m.out); n > 0 && !m.space && (m.out[n-1] == '.' || m.out[n-1] == '-' || m.out[n-1] == '+') {
+		return false
+	}
+	end := i + 1
+	for end < len(m.in) && isLetter(m.in[end]) {
+		end++
+	}
+	if !zeroLengthUnits[strings.ToLower(m.in[i+1:end])] {
+		return false
+	}
+	return end == len(m.in) || !isNameChar(m.in[end])
+}
+
+// prevIsNameChar reports whether the last output byte continues a name or
+// number, with no whitespace pending in between.
+func (m *minifier) prevIsNameChar() bool {
+	n := len(m.out)
+	return !m.space && n > 0 && (isNameChar(m.out[n-1]) || m.out[n-1] == '.')
+}
+
+// copyString copies the quoted string starting at i and returns the offset
+// after it. An unterminated string ends at the line break, as in CSS.
+func (m *minifier) copyString(i int) int {
+	quote := m.in[i]
+	m.out = append(m.out, quote)
+	i++
+	for i < len(m.in) {
+		c := m.in[i]
+		switch c {
+		case '\\':
+			i = m.copyEscape(i)
+			continue
+		case quote:
+			m.out = append(m.out, c)
+			return i + 1
+		case '\n':
+			return i
+		}
+		m.out = append(m.out, c)
+		i++
+	}
+	return i
+}
+
+// copyEscape copies a backslash and the character it escapes.
+func (m *minifier) copyEscape(i int) int {
+	m.out = append(m.out, '\\')
+	if i+1 < len(m.in) {
+		m.out = append(m.out, m.in[i+1])
+		return i + 2
+	}
+	return i + 1
+}
+
+// copyURL copies a url( token starting at i. Quoted urls are left to the
+// string handling; unquoted ones are copied up to the closing parenthesis
+// with only surrounding whitespace removed.
+func (m *minifier) copyURL(i int) int {
+	m.out = append(m.out, m.in[i:i+4]...)
+	i += 4
+	j := i
+	for j < len(m.in) && isSpace(m.in[j]) {
+		j++
+	}
+	if j < len(m.in) && (m.in[j] == '"' || m.in[j] == '\'') {
+		m.parens++
+		return j
+	}
+
+	for j < len(m.in) && m.in[j] != ')' {
+		if m.in[j] == '\\' {
+			j = m.copyEscape(j)
+			continue
+		}
+		m.out = append(m.out, m.in[j])
+		j++
+	}
+	for n := len(m.out); n > 0 && isSpace(m.out[n-1]); n-- {
+		m.out = m.out[:n-1]
+	}
+	if j < len(m.in) {
+		m.out = append(m.out, ')')
+		j++
+	}
+	return j
+}
+
+func isURLStart(s string, i int) bool {
+	return i+4 <= len(s) && strings.EqualFold(s[i:i+4], "url(")
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\f'
+}
+
+func isLetter(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isNameChar(c byte) bool {
+	return isLetter(c) || (c >= '0' && c <= '9') || c == '-' || c == '_' || c == '%' || c >= 0x80
+}
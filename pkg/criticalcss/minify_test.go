@@ -0,0 +1,100 @@
+package criticalcss
+
+import "testing"
+
+func TestMinify(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{
+			name:  "whitespace and last semicolon",
+			input: "body {\n\tmargin: 0;\n\tpadding: 1em 2em;\n}\n",
+			want:  "body{margin:0;padding:1em 2em}",
+		},
+		{
+			name:  "comments",
+			input: "/* reset */\nbody { /* inline */ color: red; } /* end */",
+			want:  "body{color:red}",
+		},
+		{
+			name:  "comment separating tokens",
+			input: "a{margin:1px/**/2px}",
+			want:  "a{margin:1px 2px}",
+		},
+		{
+			name:  "content string with braces",
+			input: `.a::before { content: "} { ; : ,"; }`,
+			want:  `.a::before{content:"} { ; : ,"}`,
+		},
+		{
+			name:  "content string with comment",
+			input: `.a::after { content: '/* not a comment */'; }`,
+			want:  `.a::after{content:'/* not a comment */'}`,
+		},
+		{
+			name:  "escaped quotes in string",
+			input: `.a::before { content: "say \"hi\"  ;  }"; }`,
+			want:  `.a::before{content:"say \"hi\"  ;  }"}`,
+		},
+		{
+			name:  "unquoted data uri",
+			input: `.icon { background: url( data:image/svg+xml;utf8,<svg><path d='M0 0h10'/></svg> ) no-repeat; }`,
+			want:  `.icon{background:url(data:image/svg+xml;utf8,<svg><path d='M0 0h10'/></svg>) no-repeat}`,
+		},
+		{
+			name:  "quoted data uri",
+			input: `.icon { background-image: url( "data:image/svg+xml,<svg a='1'>/* x */ { }</svg>" ); }`,
+			want:  `.icon{background-image:url("data:image/svg+xml,<svg a='1'>/* x */ { }</svg>")}`,
+		},
+		{
+			name:  "media block",
+			input: "@media screen and (max-width: 600px) {\n  .nav { display: none; }\n  .a > .b { margin: 0px; }\n}\n",
+			want:  "@media screen and (max-width:600px){.nav{display:none}.a>.b{margin:0}}",
+		},
+		{
+			name:  "zero lengths",
+			input: "a { margin: 0px 10px 0em 0.5px; padding: 0PX; }",
+			want:  "a{margin:0 10px 0 0.5px;padding:0}",
+		},
+		{
+			name:  "zero without length unit",
+			input: "a { transition: opacity 0s; flex: 1 1 0%; transform: rotate(0deg); }",
+			want:  "a{transition:opacity 0s;flex:1 1 0%;transform:rotate(0deg)}",
+		},
+		{
+			name:  "zero length in calc and custom property",
+			input: "a { --gap: 0px; width: calc(0px + 100%); }",
+			want:  "a{--gap:0px;width:calc(0px + 100%)}",
+		},
+		{
+			name:  "descendant pseudo-class selector",
+			input: "nav :hover , a:focus { color: red }",
+			want:  "nav :hover,a:focus{color:red}",
+		},
+		{
+			name:  "escaped selector",
+			input: `.md\:flex { display: flex; }`,
+			want:  `.md\:flex{display:flex}`,
+		},
+		{
+			name:  "keyframes",
+			input: "@keyframes spin { from { transform: rotate(0deg); } to { transform: rotate(360deg); } }",
+			want:  "@keyframes spin{from{transform:rotate(0deg)}to{transform:rotate(360deg)}}",
+		},
+		{
+			name:  "important",
+			input: "a { color: red !important; }",
+			want:  "a{color:red !important}",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Minify(tt.input); got != tt.want {
+				t.Errorf("Minify(%q)\n got: %s\nwant: %s", tt.input, got, tt.want)
+			}
+		})
+	}
+}
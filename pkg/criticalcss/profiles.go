@@ -0,0 +1,197 @@
+package criticalcss
+
+import (
+	"sort"
+	"sync"
+)
+
+// baseProfileSelectors are shared by every built-in layout profile: page
+// chrome and the elements that start most content.
+var baseProfileSelectors = []string{
+	// Reset and base
+	"*",
+	"html",
+	"body",
+
+	// Structure
+	"main",
+	"header",
+	"footer",
+	"nav",
+	"article",
+	"section",
+	"div",
+
+	// Typography
+	"h1",
+	"h2",
+	"h3",
+	"p",
+	"a",
+	"span",
+	"strong",
+	"em",
+
+	// Lists and media
+	"ul",
+	"ol",
+	"li",
+	"img",
+	"figure",
+
+	// Site header (from layouts/*.html)
+	".site-header",
+	".site-title",
+	".site-logo",
+	".site-nav",
+	".header-actions",
+	".theme-toggle",
+	".theme-toggle-icon",
+	".container",
+	".layout-container",
+	".layout-content",
+	".post-content",
+
+	// Common utilities
+	".sr-only",
+}
+
+// builtinProfiles are the critical selectors for the layouts in
+// models.LayoutConfig, on top of baseProfileSelectors. The bare layout
+// has no site chrome, so it lists its selectors in full.
+var builtinProfiles = map[string][]string{
+	"docs": withBaseSelectors(
+		"aside",
+		"pre",
+		"code",
+		".layout-sidebar",
+		".sidebar-content",
+		".sidebar-overlay",
+		".mobile-menu-toggle",
+		".hamburger-line",
+		".layout-toc",
+		".toc",
+		".toc-title",
+		".toc-content",
+		".breadcrumbs",
+		".post",
+		".post-header",
+		".post-description",
+		".heading-anchor",
+	),
+	"blog": withBaseSelectors(
+		"blockquote",
+		".post",
+		".post-header",
+		".post-meta",
+		".post-cover",
+		".tags",
+		".tag",
+		".layout-toc",
+		".toc",
+		".toc-title",
+		".toc-content",
+		".heading-anchor",
+	),
+	"landing": withBaseSelectors(
+		"button",
+		".hero",
+		".hero-content",
+		".hero-title",
+		".hero-subtitle",
+		".hero-actions",
+		".hero-image",
+		".btn",
+		".btn--primary",
+		".btn--secondary",
+	),
+	"bare": {
+		"*",
+		"html",
+		"body",
+		"main",
+		"article",
+		"h1",
+		"h2",
+		"h3",
+		"p",
+		"a",
+		"img",
+		".layout-content",
+		".post-content",
+	},
+}
+
+// withBaseSelectors returns baseProfileSelectors followed by selectors.
+func withBaseSelectors(selectors ...string) []string {
+	result := make([]string, 0, len(baseProfileSelectors)+len(selectors))
+	result = append(result, baseProfileSelectors...)
+	return append(result, selectors...)
+}
+
+// profileRegistry holds critical selector profiles keyed by layout name.
+var profileRegistry = struct {
+	sync.RWMutex
+	profiles map[string][]string
+}{
+	profiles: func() map[string][]string {
+		profiles := make(map[string][]string, len(builtinProfiles))
+		for name, selectors := range builtinProfiles {
+			profiles[name] = selectors
+		}
+		return profiles
+	}(),
+}
+
+// RegisterProfile registers the critical selectors for a layout, replacing
+// any existing profile with that name.
+func RegisterProfile(layout string, selectors []string) {
+	profileRegistry.Lock()
+	defer profileRegistry.Unlock()
+	profileRegistry.profiles[layout] = append([]string(nil), selectors...)
+}
+
+// Profile returns the critical selectors registered for a layout.
+// Returns false if the layout has no profile.
+func Profile(layout string) ([]string, bool) {
+	profileRegistry.RLock()
+	defer profileRegistry.RUnlock()
+
+	selectors, ok := profileRegistry.profiles[layout]
+	if !ok {
+		return nil, false
+	}
+	return append([]string(nil), selectors...), true
+}
+
+// ProfileNames returns the names of all registered profiles, sorted.
+func ProfileNames() []string {
+	profileRegistry.RLock()
+	defer profileRegistry.RUnlock()
+
+	names := make([]string, 0, len(profileRegistry.profiles))
+	for name := range profileRegistry.profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// NewExtractorWithSelectors creates an Extractor that treats only the given
+// selectors as critical, instead of the default list.
+func NewExtractorWithSelectors(selectors []string) *Extractor {
+	e := NewExtractor()
+	e.CriticalSelectors = append([]string(nil), selectors...)
+	return e
+}
+
+// NewExtractorForLayout creates an Extractor using the profile registered
+// for a layout. Returns false, and an Extractor with the default selectors,
+// if the layout has no profile.
+func NewExtractorForLayout(layout string) (*Extractor, bool) {
+	selectors, ok := Profile(layout)
+	if !ok {
+		return NewExtractor(), false
+	}
+	return NewExtractorWithSelectors(selectors), true
+}
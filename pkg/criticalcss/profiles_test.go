@@ -0,0 +1,109 @@
+package criticalcss
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const layoutTestCSS = `
+body { margin: 0; }
+.hero { min-height: 80vh; }
+.layout-sidebar { width: 280px; }
+.post-meta { color: gray; }
+`
+
+func TestBuiltinProfiles(t *testing.T) {
+	for _, layout := range []string{"docs", "blog", "landing", "bare"} {
+		if _, ok := Profile(layout); !ok {
+			t.Errorf("expected a built-in profile for %q", layout)
+		}
+	}
+}
+
+func TestNewExtractorForLayout(t *testing.T) {
+	tests := []struct {
+		layout      string
+		critical    []string
+		notCritical []string
+	}{
+		{"docs", []string{"margin:0", ".layout-sidebar"}, []string{".hero", ".post-meta"}},
+		{"landing", []string{"margin:0", ".hero"}, []string{".layout-sidebar", ".post-meta"}},
+		{"blog", []string{"margin:0", ".post-meta"}, []string{".hero", ".layout-sidebar"}},
+		{"bare", []string{"margin:0"}, []string{".hero", ".layout-sidebar", ".post-meta"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.layout, func(t *testing.T) {
+			ext, ok := NewExtractorForLayout(tt.layout)
+			if !ok {
+				t.Fatalf("no profile for %q", tt.layout)
+			}
+			result, err := ext.Extract(layoutTestCSS)
+			if err != nil {
+				t.Fatalf("Extract failed: %v", err)
+			}
+
+			for _, want := range tt.critical {
+				if !strings.Contains(result.Critical, want) {
+					t.Errorf("Expected %q in critical CSS, got %q", want, result.Critical)
+				}
+			}
+			for _, unwanted := range tt.notCritical {
+				if strings.Contains(result.Critical, unwanted) {
+					t.Errorf("Expected %q NOT in critical CSS, got %q", unwanted, result.Critical)
+				}
+			}
+		})
+	}
+}
+
+func TestNewExtractorForLayout_Unknown(t *testing.T) {
+	ext, ok := NewExtractorForLayout("gallery")
+	if ok {
+		t.Error("expected no profile for an unregistered layout")
+	}
+	if !reflect.DeepEqual(ext.CriticalSelectors, defaultCriticalSelectors) {
+		t.Error("unregistered layouts should use the default selectors")
+	}
+}
+
+func TestRegisterProfile(t *testing.T) {
+	selectors := []string{"body", ".gallery-grid"}
+	RegisterProfile("gallery-test", selectors)
+	selectors[1] = ".changed"
+
+	got, ok := Profile("gallery-test")
+	if !ok {
+		t.Fatal("expected registered profile")
+	}
+	if want := []string{"body", ".gallery-grid"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Profile() = %v, want %v", got, want)
+	}
+
+	got[0] = "html"
+	if again, _ := Profile("gallery-test"); again[0] != "body" {
+		t.Error("Profile should return a copy")
+	}
+
+	found := false
+	for _, name := range ProfileNames() {
+		if name == "gallery-test" {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("ProfileNames should include registered profiles")
+	}
+}
+
+func TestNewExtractorWithSelectors(t *testing.T) {
+	ext := NewExtractorWithSelectors([]string{".only"})
+	result, err := ext.Extract(`body { margin: 0; } .only { color: red; }`)
+	if err != nil {
+		t.Fatalf("Extract failed: %v", err)
+	}
+	if result.Critical != ".only{color:red}" {
+		t.Errorf("Critical = %q, want only the given selector", result.Critical)
+	}
+}
@@ -0,0 +1,296 @@
+package criticalcss
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// NamedCSS is a stylesheet and the name it is served under.
+type NamedCSS struct {
+	// Name identifies the sheet, usually its path relative to ImportRoot
+	// with forward slashes (e.g., "css/main.css"). Relative @import URLs in
+	// the sheet resolve against it.
+	Name string
+
+	// CSS is the stylesheet source.
+	CSS string
+}
+
+// ExtractMany separates several stylesheets into critical and non-critical
+// parts. Sheets are matched in the given order, as a browser would apply
+// them, and their critical rules are concatenated in that order. The
+// non-critical rules are returned per sheet name, so each file can still be
+// loaded on its own.
+//
+// Local @import rules are inlined before extraction, taking the imported
+// sheet from sheets when it is listed there and otherwise from ImportRoot.
+// An import with a media query is wrapped in a matching @media rule. Imports
+// of remote URLs, imports with layer() or supports() conditions, and local
+// imports that cannot be found without an ImportRoot are kept as @import
+// rules at the start of the critical CSS. A local import that is missing
+// from ImportRoot, or that imports itself through other sheets, is an error.
+// A listed sheet that another listed sheet imports is extracted only where
+// it is imported, and its entry in remaining is empty.
+func (e *Extractor) ExtractMany(sheets []NamedCSS) (critical string, remaining map[string]string, err error) {
+	criticalSet := e.buildSelectorSet(e.CriticalSelectors)
+	excludeSet := e.buildSelectorSet(e.ExcludeSelectors)
+
+	byName := make(map[string]string, len(sheets))
+	for _, sheet := range sheets {
+		byName[cleanSheetName(sheet.Name)] = sheet.CSS
+	}
+
+	inlined := make([]string, len(sheets))
+	imported := make(map[string]bool)
+	for i, sheet := range sheets {
+		name := cleanSheetName(sheet.Name)
+		css, err := e.inlineImports(name, sheet.CSS, byName, []string{name}, imported)
+		if err != nil {
+			return "", nil, err
+		}
+		inlined[i] = css
+	}
+
+	var imports, criticalRules []string
+	remaining = make(map[string]string, len(sheets))
+	for i, sheet := range sheets {
+		if imported[cleanSheetName(sheet.Name)] {
+			// Already extracted where it is imported
+			remaining[sheet.Name] = ""
+			continue
+		}
+
+		sheetCritical, sheetRemaining := e.splitRules(e.parseRules(inlined[i]), criticalSet, excludeSet)
+		for _, rule := range sheetCritical {
+			if isImportRule(rule) {
+				imports = append(imports, rule)
+			} else {
+				criticalRules = append(criticalRules, rule)
+			}
+		}
+
+		nonCritical := strings.Join(sheetRemaining, "\n")
+		if e.MinifyOutput {
+			nonCritical = e.minify(nonCritical)
+		}
+		remaining[sheet.Name] = nonCritical
+	}
+
+	// @import is only valid before other rules
+	critical = strings.Join(append(imports, criticalRules...), "\n")
+	if e.MinifyOutput {
+		critical = e.minify(critical)
+	}
+	return critical, remaining, nil
+}
+
+// inlineImports replaces the local @import rules in css, a sheet named name,
+// with the imported sheets. stack holds the sheets being inlined, to detect
+// import cycles, and imported collects the names of inlined sheets that are
+// in byName.
+func (e *Extractor) inlineImports(name, css string, byName map[string]string, stack []string, imported map[string]bool) (string, error) {
+	if !strings.Contains(strings.ToLower(css), "@import") {
+		return css, nil
+	}
+
+	var out strings.Builder
+	depth := 0
+	for i := 0; i < len(css); i++ {
+		c := css[i]
+		switch {
+		case c == '/' && i+1 < len(css) && css[i+1] == '*':
+			end := strings.Index(css[i+2:], "*/")
+			if end < 0 {
+				out.WriteString(css[i:])
+				return out.String(), nil
+			}
+			out.WriteString(css[i : i+2+end+2])
+			i += end + 3
+			continue
+		case c == '"' || c == '\'':
+			end := skipCSSString(css, i)
+			out.WriteString(css[i:end])
+			i = end - 1
+			continue
+		case c == '{':
+			depth++
+		case c == '}':
+			if depth > 0 {
+				depth--
+			}
+		case c == '@' && depth == 0 && hasImportKeyword(css[i:]):
+			end := importEnd(css, i)
+			rule := css[i:end]
+			inlined, ok, err := e.resolveImport(name, rule, byName, stack, imported)
+			if err != nil {
+				return "", err
+			}
+			if ok {
+				out.WriteString(inlined)
+			} else {
+				out.WriteString(rule)
+			}
+			i = end - 1
+			continue
+		}
+		out.WriteByte(c)
+	}
+	return out.String(), nil
+}
+
+// resolveImport returns the CSS that replaces an @import rule from the
+// sheet name, or false if the rule should be kept as is.
+func (e *Extractor) resolveImport(name, rule string, byName map[string]string, stack []string, imported map[string]bool) (string, bool, error) {
+	target, conditions := parseImportRule(rule)
+	if target == "" || !isLocalImport(target) {
+		return "", false, nil
+	}
+	lowerConditions := strings.ToLower(conditions)
+	if strings.Contains(lowerConditions, "layer") || strings.Contains(lowerConditions, "supports(") {
+		return "", false, nil
+	}
+
+	key, ok := resolveImportPath(name, target)
+	if !ok {
+		return "", false, nil
+	}
+	for _, parent := range stack {
+		if parent == key {
+			return "", false, fmt.Errorf("%s: @import %q: import cycle through %s", name, target, strings.Join(append(stack, key), " -> "))
+		}
+	}
+
+	css, found := byName[key]
+	if found {
+		imported[key] = true
+	} else {
+		if e.ImportRoot == "" {
+			return "", false, nil
+		}
+		data, err := os.ReadFile(filepath.Join(e.ImportRoot, filepath.FromSlash(key)))
+		if err != nil {
+			return "", false, fmt.Errorf("%s: @import %q: %w", name, target, err)
+		}
+		css = string(data)
+	}
+
+	inlined, err := e.inlineImports(key, css, byName, append(stack, key), imported)
+	if err != nil {
+		return "", false, err
+	}
+	if conditions != "" {
+		inlined = "@media " + conditions + " {\n" + inlined + "\n}"
+	}
+	return "\n" + inlined + "\n", true, nil
+}
+
+// hasImportKeyword reports whether s starts with an @import keyword.
+func hasImportKeyword(s string) bool {
+	const keyword = "@import"
+	if len(s) <= len(keyword) || !strings.EqualFold(s[:len(keyword)], keyword) {
+		return false
+	}
+	next := s[len(keyword)]
+	return next == ' ' || next == '\t' || next == '\n' || next == '\r' || next == '"' || next == '\'' || next == 'u' || next == 'U'
+}
+
+// importEnd returns the index just past the ';' ending the @import rule
+// starting at start, or len(css) if it is not terminated.
+func importEnd(css string, start int) int {
+	parens := 0
+	for i := start; i < len(css); i++ {
+		switch css[i] {
+		case '"', '\'':
+			i = skipCSSString(css, i) - 1
+		case '(':
+			parens++
+		case ')':
+			if parens > 0 {
+				parens--
+			}
+		case ';':
+			if parens == 0 {
+				return i + 1
+			}
+		}
+	}
+	return len(css)
+}
+
+// skipCSSString returns the index just past the string literal starting
+// at start.
+func skipCSSString(css string, start int) int {
+	quote := css[start]
+	for i := start + 1; i < len(css); i++ {
+		switch css[i] {
+		case '\\':
+			i++
+		case quote:
+			return i + 1
+		}
+	}
+	return len(css)
+}
+
+// parseImportRule returns the URL of an @import rule and the conditions
+// (media query, layer, or supports) that follow it.
+func parseImportRule(rule string) (target, conditions string) {
+	rest := strings.TrimSpace(rule[len("@import"):])
+	rest = strings.TrimSpace(strings.TrimSuffix(rest, ";"))
+
+	if len(rest) >= 4 && strings.EqualFold(rest[:4], "url(") {
+		end := strings.IndexByte(rest, ')')
+		if end < 0 {
+			return "", ""
+		}
+		target = strings.Trim(strings.TrimSpace(rest[4:end]), `"'`)
+		return target, strings.TrimSpace(rest[end+1:])
+	}
+	if rest != "" && (rest[0] == '"' || rest[0] == '\'') {
+		end := skipCSSString(rest, 0)
+		target = rest[1 : end-1]
+		return target, strings.TrimSpace(rest[end:])
+	}
+	return "", ""
+}
+
+// isLocalImport reports whether an @import URL refers to a file of the site
+// rather than another origin or inline data.
+func isLocalImport(target string) bool {
+	lower := strings.ToLower(target)
+	return !strings.Contains(lower, "://") &&
+		!strings.HasPrefix(lower, "//") &&
+		!strings.HasPrefix(lower, "data:")
+}
+
+// resolveImportPath returns the sheet name an @import URL in the sheet from
+// refers to. Root-relative URLs resolve against the import root. It returns
+// false for URLs that point outside the root.
+func resolveImportPath(from, target string) (string, bool) {
+	if i := strings.IndexAny(target, "?#"); i >= 0 {
+		target = target[:i]
+	}
+	var key string
+	if strings.HasPrefix(target, "/") {
+		key = path.Clean(strings.TrimPrefix(target, "/"))
+	} else {
+		key = path.Join(path.Dir(from), target)
+	}
+	if key == "." || key == ".." || strings.HasPrefix(key, "../") {
+		return "", false
+	}
+	return key, true
+}
+
+// cleanSheetName normalizes a sheet name for import lookups.
+func cleanSheetName(name string) string {
+	return strings.TrimPrefix(path.Clean("/"+filepath.ToSlash(name)), "/")
+}
+
+// isImportRule reports whether rule is an @import rule.
+func isImportRule(rule string) bool {
+	return hasImportKeyword(strings.TrimSpace(rule))
+}
@@ -0,0 +1,138 @@
+package criticalcss
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestExtractMany_CriticalSelectorInSecondSheet(t *testing.T) {
+	ext := NewExtractorWithSelectors([]string{"body", ".hero"})
+
+	sheets := []NamedCSS{
+		{Name: "css/base.css", CSS: "body { margin: 0; }\n.footer { color: gray; }"},
+		{Name: "css/theme.css", CSS: ".hero { color: red; }\n.sidebar { width: 20rem; }\nbody { color: #111; }"},
+	}
+
+	critical, remaining, err := ext.ExtractMany(sheets)
+	if err != nil {
+		t.Fatalf("ExtractMany() error = %v", err)
+	}
+
+	if want := "body{margin:0}.hero{color:red}body{color:#111}"; critical != want {
+		t.Errorf("critical = %q, want %q", critical, want)
+	}
+	if got := remaining["css/base.css"]; got != ".footer{color:gray}" {
+		t.Errorf("remaining[base] = %q", got)
+	}
+	if got := remaining["css/theme.css"]; got != ".sidebar{width:20rem}" {
+		t.Errorf("remaining[theme] = %q", got)
+	}
+	if len(remaining) != 2 {
+		t.Errorf("remaining has %d sheets, want 2", len(remaining))
+	}
+}
+
+func TestExtractMany_InlinesImports(t *testing.T) {
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, "css", "partials"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	for name, css := range map[string]string{
+		"css/partials/header.css": "header { height: 4rem; }\n.menu { display: none; }",
+		"css/screen.css":          "body { color: black; }",
+	} {
+		if err := os.WriteFile(filepath.Join(root, filepath.FromSlash(name)), []byte(css), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	ext := NewExtractorWithSelectors([]string{"body", "header", ".hero"}).WithImportRoot(root)
+	sheets := []NamedCSS{
+		{Name: "css/main.css", CSS: `@charset "utf-8";
+@import url("https://fonts.example.com/inter.css");
+@import "partials/header.css";
+@import url(/css/screen.css) screen;
+@import "hero.css";
+/* @import "missing.css"; */
+.card { padding: 1rem; }`},
+		{Name: "css/hero.css", CSS: ".hero { min-height: 50vh; }"},
+	}
+
+	critical, remaining, err := ext.ExtractMany(sheets)
+	if err != nil {
+		t.Fatalf("ExtractMany() error = %v", err)
+	}
+
+	for _, want := range []string{
+		`@import url("https://fonts.example.com/inter.css");`,
+		"header{height:4rem}",
+		"@media screen{body{color:black}}",
+		".hero{min-height:50vh}",
+	} {
+		if !strings.Contains(critical, want) {
+			t.Errorf("critical = %q, want it to contain %q", critical, want)
+		}
+	}
+	if !strings.HasPrefix(critical, "@import") {
+		t.Errorf("critical = %q, want the remote @import first", critical)
+	}
+	if strings.Contains(critical, "partials/header.css") || strings.Contains(critical, "screen.css") {
+		t.Errorf("critical = %q, local imports should be inlined", critical)
+	}
+	if got, want := remaining["css/main.css"], ".menu{display:none}.card{padding:1rem}"; got != want {
+		t.Errorf("remaining[main] = %q, want %q", got, want)
+	}
+	// hero.css is extracted where main.css imports it, not again on its own
+	if strings.Count(critical, ".hero") != 1 {
+		t.Errorf("critical = %q, want .hero once", critical)
+	}
+	if got, ok := remaining["css/hero.css"]; !ok || got != "" {
+		t.Errorf("remaining[hero] = %q, %v; want empty entry", got, ok)
+	}
+}
+
+func TestExtractMany_ImportErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		sheets  []NamedCSS
+		wantErr string
+	}{
+		{
+			name: "cycle",
+			sheets: []NamedCSS{
+				{Name: "a.css", CSS: `@import "b.css"; body { margin: 0; }`},
+				{Name: "b.css", CSS: `@import "a.css";`},
+			},
+			wantErr: "import cycle through a.css -> b.css -> a.css",
+		},
+		{
+			name:    "missing file",
+			sheets:  []NamedCSS{{Name: "css/main.css", CSS: `@import "missing.css";`}},
+			wantErr: `css/main.css: @import "missing.css"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ext := NewExtractor().WithImportRoot(t.TempDir())
+			_, _, err := ext.ExtractMany(tt.sheets)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ExtractMany() error = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestExtractMany_UnresolvedImportWithoutRoot(t *testing.T) {
+	ext := NewExtractorWithSelectors([]string{"body"})
+
+	critical, _, err := ext.ExtractMany([]NamedCSS{{Name: "main.css", CSS: `@import "vendor.css"; body { margin: 0; }`}})
+	if err != nil {
+		t.Fatalf("ExtractMany() error = %v", err)
+	}
+	if want := `@import "vendor.css";body{margin:0}`; critical != want {
+		t.Errorf("critical = %q, want %q", critical, want)
+	}
+}
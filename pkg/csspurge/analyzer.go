@@ -17,15 +17,27 @@ type UsedSelectors struct {
 	Elements map[string]bool
 	// Attributes maps attribute names to true (for [attr] selectors)
 	Attributes map[string]bool
+	// AttributeValues maps attribute names to the values they were seen
+	// with (for [attr="value"] selectors)
+	AttributeValues map[string]map[string]bool
+	// ElementAttributes maps element names to the attribute names seen on
+	// them (for tag[attr] selectors)
+	ElementAttributes map[string]map[string]bool
+	// StyleSelectors maps selectors declared in <style> blocks to true.
+	// Selectors are whitespace-normalized with nesting resolved.
+	StyleSelectors map[string]bool
 }
 
 // NewUsedSelectors creates an empty UsedSelectors struct.
 func NewUsedSelectors() *UsedSelectors {
 	return &UsedSelectors{
-		Classes:    make(map[string]bool),
-		IDs:        make(map[string]bool),
-		Elements:   make(map[string]bool),
-		Attributes: make(map[string]bool),
+		Classes:           make(map[string]bool),
+		IDs:               make(map[string]bool),
+		Elements:          make(map[string]bool),
+		Attributes:        make(map[string]bool),
+		AttributeValues:   make(map[string]map[string]bool),
+		ElementAttributes: make(map[string]map[string]bool),
+		StyleSelectors:    make(map[string]bool),
 	}
 }
 
@@ -46,6 +58,37 @@ func (u *UsedSelectors) Merge(other *UsedSelectors) {
 	for k := range other.Attributes {
 		u.Attributes[k] = true
 	}
+	mergeSets(u.AttributeValues, other.AttributeValues)
+	mergeSets(u.ElementAttributes, other.ElementAttributes)
+	for k := range other.StyleSelectors {
+		u.StyleSelectors[k] = true
+	}
+}
+
+// mergeSets adds every entry of src to dst.
+func mergeSets(dst, src map[string]map[string]bool) {
+	for key, values := range src {
+		set := dst[key]
+		if set == nil {
+			set = make(map[string]bool, len(values))
+			dst[key] = set
+		}
+		for v := range values {
+			set[v] = true
+		}
+	}
+}
+
+// addToSet adds value to the set stored under key, creating it if needed.
+func addToSet(sets map[string]map[string]bool, key, value string) {
+	set := sets[key]
+	if set == nil {
+		set = make(map[string]bool)
+		sets[key] = set
+	}
+	if value != "" {
+		set[value] = true
+	}
 }
 
 // ScanHTML parses an HTML file and extracts used selectors.
@@ -62,33 +105,7 @@ func ScanHTML(htmlPath string, used *UsedSelectors) error {
 		return err
 	}
 
-	// Walk all elements in the document
-	doc.Find("*").Each(func(_ int, s *goquery.Selection) {
-		// Extract element name
-		if node := s.Get(0); node != nil {
-			used.Elements[strings.ToLower(node.Data)] = true
-
-			// Extract all attributes
-			for _, attr := range node.Attr {
-				attrName := strings.ToLower(attr.Key)
-				used.Attributes[attrName] = true
-
-				// Extract classes from class attribute
-				if attrName == "class" {
-					classes := strings.Fields(attr.Val)
-					for _, class := range classes {
-						used.Classes[class] = true
-					}
-				}
-
-				// Extract ID
-				if attrName == "id" && attr.Val != "" {
-					used.IDs[attr.Val] = true
-				}
-			}
-		}
-	})
-
+	scanDocument(doc, used)
 	return nil
 }
 
@@ -100,27 +117,70 @@ func ScanHTMLContent(html string, used *UsedSelectors) error {
 		return err
 	}
 
+	scanDocument(doc, used)
+	return nil
+}
+
+// scanDocument records the elements, classes, IDs, and attributes of every
+// element in doc, and the selectors declared in its <style> blocks.
+func scanDocument(doc *goquery.Document, used *UsedSelectors) {
+	// Walk all elements in the document
 	doc.Find("*").Each(func(_ int, s *goquery.Selection) {
-		if node := s.Get(0); node != nil {
-			used.Elements[strings.ToLower(node.Data)] = true
-
-			for _, attr := range node.Attr {
-				attrName := strings.ToLower(attr.Key)
-				used.Attributes[attrName] = true
-
-				if attrName == "class" {
-					classes := strings.Fields(attr.Val)
-					for _, class := range classes {
-						used.Classes[class] = true
-					}
-				}
+		node := s.Get(0)
+		if node == nil {
+			return
+		}
 
-				if attrName == "id" && attr.Val != "" {
-					used.IDs[attr.Val] = true
+		// Extract element name
+		element := strings.ToLower(node.Data)
+		used.Elements[element] = true
+		addToSet(used.ElementAttributes, element, "")
+
+		// Extract all attributes, including inline style attributes
+		for _, attr := range node.Attr {
+			attrName := strings.ToLower(attr.Key)
+			used.Attributes[attrName] = true
+			addToSet(used.AttributeValues, attrName, attr.Val)
+			addToSet(used.ElementAttributes, element, attrName)
+
+			// Extract classes from class attribute
+			if attrName == "class" {
+				classes := strings.Fields(attr.Val)
+				for _, class := range classes {
+					used.Classes[class] = true
 				}
 			}
+
+			// Extract ID
+			if attrName == "id" && attr.Val != "" {
+				used.IDs[attr.Val] = true
+			}
+		}
+
+		if element == "style" {
+			recordStyleSelectors(ParseCSS(s.Text()), nil, used)
 		}
 	})
+}
 
-	return nil
+// recordStyleSelectors records the selectors of style rules declared in a
+// <style> block, resolving nested rules against their parents.
+func recordStyleSelectors(rules []CSSRule, parents []string, used *UsedSelectors) {
+	for _, rule := range rules {
+		if rule.IsAtRule {
+			recordStyleSelectors(rule.NestedRules, parents, used)
+			continue
+		}
+		selectors := resolveNestedSelectors(rule.Selector, parents)
+		for _, sel := range selectors {
+			used.StyleSelectors[normalizeSelector(sel)] = true
+		}
+		recordStyleSelectors(rule.NestedRules, selectors, used)
+	}
+}
+
+// normalizeSelector collapses whitespace in a selector so equivalent
+// selectors from different sources compare equal.
+func normalizeSelector(selector string) string {
+	return strings.Join(strings.Fields(selector), " ")
 }
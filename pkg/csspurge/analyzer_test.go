@@ -106,10 +106,14 @@ func TestUsedSelectorsMerge(t *testing.T) {
 	u1 := NewUsedSelectors()
 	u1.Classes["a"] = true
 	u1.IDs["id1"] = true
+	u1.AttributeValues["data-state"] = map[string]bool{"open": true}
 
 	u2 := NewUsedSelectors()
 	u2.Classes["b"] = true
 	u2.IDs["id2"] = true
+	u2.AttributeValues["data-state"] = map[string]bool{"closed": true}
+	u2.ElementAttributes["input"] = map[string]bool{"type": true}
+	u2.StyleSelectors[".tabs .tab"] = true
 
 	u1.Merge(u2)
 
@@ -119,4 +123,69 @@ func TestUsedSelectorsMerge(t *testing.T) {
 	if !u1.IDs["id1"] || !u1.IDs["id2"] {
 		t.Error("IDs not merged correctly")
 	}
+	if !u1.AttributeValues["data-state"]["open"] || !u1.AttributeValues["data-state"]["closed"] {
+		t.Error("attribute values not merged correctly")
+	}
+	if !u1.ElementAttributes["input"]["type"] {
+		t.Error("element attributes not merged correctly")
+	}
+	if !u1.StyleSelectors[".tabs .tab"] {
+		t.Error("style selectors not merged correctly")
+	}
+}
+
+func TestScanHTMLContent_AttributeValues(t *testing.T) {
+	html := `<div data-state="open" data-size="lg"><input type="checkbox" checked><span style="color: red">x</span></div>`
+
+	used := NewUsedSelectors()
+	if err := ScanHTMLContent(html, used); err != nil {
+		t.Fatalf("ScanHTMLContent() error = %v", err)
+	}
+
+	for attr, value := range map[string]string{
+		"data-state": "open",
+		"data-size":  "lg",
+		"type":       "checkbox",
+		"style":      "color: red",
+	} {
+		if !used.AttributeValues[attr][value] {
+			t.Errorf("expected %s=%q to be recorded, got %v", attr, value, used.AttributeValues[attr])
+		}
+	}
+
+	if !used.ElementAttributes["input"]["checked"] || !used.ElementAttributes["input"]["type"] {
+		t.Errorf("expected input attributes, got %v", used.ElementAttributes["input"])
+	}
+	if used.ElementAttributes["div"]["type"] {
+		t.Error("div should not record input's type attribute")
+	}
+	if _, ok := used.ElementAttributes["span"]; !ok {
+		t.Error("every element should have an ElementAttributes entry")
+	}
+}
+
+func TestScanHTMLContent_StyleBlocks(t *testing.T) {
+	html := `<html><head><style>
+.tabs .tab { color: red; }
+@media (min-width: 600px) {
+  .tabs   >  .panel { padding: 0; }
+}
+.card {
+  &.is-open { display: block; }
+}
+</style></head><body></body></html>`
+
+	used := NewUsedSelectors()
+	if err := ScanHTMLContent(html, used); err != nil {
+		t.Fatalf("ScanHTMLContent() error = %v", err)
+	}
+
+	for _, sel := range []string{".tabs .tab", ".tabs > .panel", ".card", ".card.is-open"} {
+		if !used.StyleSelectors[sel] {
+			t.Errorf("expected style selector %q, got %v", sel, used.StyleSelectors)
+		}
+	}
+	if used.Classes["tab"] {
+		t.Error("classes in <style> blocks should not count as used in the HTML")
+	}
 }
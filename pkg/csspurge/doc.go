@@ -23,7 +23,7 @@
 //	opts := csspurge.PurgeOptions{
 //	    Preserve: []string{"js-*", "htmx-*"},
 //	}
-//	purged, stats := csspurge.PurgeCSS(cssContent, used, opts)
+//	purged, stats, report := csspurge.PurgeCSS(cssContent, used, opts)
 //
 // # Preserved Patterns
 //
@@ -37,6 +37,15 @@
 //   - active, hidden, loading - Common state classes
 //   - dark, light - Theme mode classes
 //
+// # Root Rules and Custom Properties
+//
+// Rules on the document root (:root, html, and compounds such as html.dark)
+// are always kept, since they hold base and theme styles. Themes often
+// declare custom properties that are read through var() by rules for
+// elements added at runtime, so rules declaring custom properties on data-*
+// attribute selectors like [data-accent="red"] are kept too. Set
+// PreserveCustomProperties to keep every rule that declares one.
+//
 // # CSS Parsing
 //
 // The package uses a regex-based CSS parser that handles:
@@ -46,7 +55,39 @@
 //   - Keyframes: @keyframes name { ... }
 //   - Font-faces: @font-face { ... }
 //   - Imports: @import ...
+//   - Native nesting: .card { &:hover { ... } .title { ... } }
 //
 // At-rules like @media queries are preserved if any of their nested rules
 // are used. @keyframes and @font-face are always preserved.
+//
+// Nested style rules are resolved against their parent: "&" stands for the
+// parent selector and selectors without "&" target descendants of it. A
+// parent rule is kept if it or any nested rule is used. Selector lists inside
+// :is() and :where() are treated as alternatives, so a rule is kept if any of
+// them is used, and the arguments of :not() are never required to be used.
+//
+// # Attribute Selectors and Style Blocks
+//
+// ScanHTML records every attribute name and value in the HTML, including
+// inline style attributes, and which attributes appear on which elements.
+// An attribute selector is used only if its value operator (=, ~=, |=, ^=,
+// $=, *=, with the optional "i" flag) matches a recorded value, and a
+// selector like input[type="checkbox"] also requires that attribute on that
+// element. Attributes matching PreserveAttributes are kept for any value.
+//
+// Selectors declared in a page's <style> blocks are recorded too, and a
+// stylesheet rule with the same selector is always kept so the two keep
+// cascading together.
+//
+// # Purge Reports
+//
+// PurgeCSS also returns a PurgeReport listing each removed selector with the
+// source byte offset of its rule and the reason it was removed, naming the
+// class, ID, attribute, or element that was missing from the HTML. The
+// report counts how often each preserve pattern matched, so patterns that
+// never match can be pruned:
+//
+//	for _, pattern := range report.UnusedPreservePatterns() {
+//	    log.Printf("preserve pattern %q matched nothing", pattern)
+//	}
 package csspurge
@@ -16,6 +16,12 @@ type PurgeOptions struct {
 	// Example: ["data-theme", "data-palette"]
 	PreserveAttributes []string
 
+	// PreserveCustomProperties keeps every rule that declares a custom
+	// property (e.g., --accent), even if its selector looks unused. Custom
+	// properties on :root, html, and data-* attribute selectors are always
+	// kept.
+	PreserveCustomProperties bool
+
 	// Verbose enables detailed logging.
 	Verbose bool
 }
@@ -43,8 +49,9 @@ func (s *PurgeStats) SavingsPercent() float64 {
 }
 
 // PurgeCSS removes unused CSS rules based on used selectors.
-// It returns the purged CSS content and statistics.
-func PurgeCSS(css string, used *UsedSelectors, opts PurgeOptions) (string, PurgeStats) {
+// It returns the purged CSS content, statistics, and a report of the
+// removed selectors and the preserve patterns that matched.
+func PurgeCSS(css string, used *UsedSelectors, opts PurgeOptions) (string, PurgeStats, PurgeReport) {
 	stats := PurgeStats{
 		OriginalSize: len(css),
 	}
@@ -52,9 +59,13 @@ func PurgeCSS(css string, used *UsedSelectors, opts PurgeOptions) (string, Purge
 	rules := ParseCSS(css)
 	stats.TotalRules = countRules(rules)
 
+	report := PurgeReport{
+		Preserve: countPreserveMatches(rules, opts),
+	}
+
 	var result strings.Builder
 	for _, rule := range rules {
-		kept := processRule(rule, used, opts, &result)
+		kept := processRule(rule, used, opts, &result, &report)
 		if kept {
 			stats.KeptRules++
 		}
@@ -64,7 +75,7 @@ func PurgeCSS(css string, used *UsedSelectors, opts PurgeOptions) (string, Purge
 	stats.PurgedSize = len(purged)
 	stats.RemovedRules = stats.TotalRules - stats.KeptRules
 
-	return purged, stats
+	return purged, stats, report
 }
 
 // countRules counts the total number of rules including nested ones.
@@ -79,7 +90,7 @@ func countRules(rules []CSSRule) int {
 
 // processRule processes a single CSS rule and writes it if used.
 // Returns true if the rule was kept.
-func processRule(rule CSSRule, used *UsedSelectors, opts PurgeOptions, out *strings.Builder) bool {
+func processRule(rule CSSRule, used *UsedSelectors, opts PurgeOptions, out *strings.Builder, report *PurgeReport) bool {
 	// Always keep certain @-rules
 	if rule.IsAtRule {
 		switch rule.AtRuleType {
@@ -94,7 +105,7 @@ func processRule(rule CSSRule, used *UsedSelectors, opts PurgeOptions, out *stri
 			var nestedOut strings.Builder
 			keptNested := 0
 			for _, nested := range rule.NestedRules {
-				if processRule(nested, used, opts, &nestedOut) {
+				if processRule(nested, used, opts, &nestedOut, report) {
 					keptNested++
 				}
 			}
@@ -123,21 +134,212 @@ func processRule(rule CSSRule, used *UsedSelectors, opts PurgeOptions, out *stri
 		}
 	}
 
-	// Check if regular rule is used
-	if isSelectorUsed(rule.Selector, used, opts) {
-		out.WriteString(rule.Content)
-		out.WriteString("\n")
+	return processStyleRule(rule, nil, used, opts, out, report)
+}
+
+// processStyleRule writes a style rule if it or any rule nested inside it is
+// used. parents holds the resolved selectors of the enclosing style rule when
+// the rule is nested. Unused selectors are added to report. Returns true if
+// the rule was kept.
+func processStyleRule(rule CSSRule, parents []string, used *UsedSelectors, opts PurgeOptions, out *strings.Builder, report *PurgeReport) bool {
+	selectors := resolveNestedSelectors(rule.Selector, parents)
+	selfUsed := isRuleAlwaysKept(rule, selectors, opts) || anySelectorUsed(selectors, used, opts)
+	if !selfUsed {
+		report.recordRemoved(selectors, rule.Offset, used, opts)
+	}
+
+	if len(rule.NestedRules) == 0 {
+		if selfUsed {
+			out.WriteString(rule.Content)
+			out.WriteString("\n")
+		}
+		return selfUsed
+	}
+
+	var nestedOut strings.Builder
+	keptNested := 0
+	for _, nested := range rule.NestedRules {
+		if nested.IsAtRule {
+			// At-rules nested in a style rule (e.g., @media) apply to the
+			// parent's elements, so they live and die with the parent.
+			if selfUsed {
+				nestedOut.WriteString(nested.Content)
+				nestedOut.WriteString("\n")
+				keptNested++
+			}
+			continue
+		}
+		if processStyleRule(nested, selectors, used, opts, &nestedOut, report) {
+			keptNested++
+		}
+	}
+
+	if !selfUsed && keptNested == 0 {
+		return false
+	}
+
+	// Reconstruct the rule, dropping its own declarations if only nested
+	// rules are used
+	out.WriteString(rule.Selector)
+	out.WriteString(" {\n")
+	if selfUsed {
+		for _, decl := range rule.Declarations {
+			out.WriteString(decl)
+			out.WriteString("\n")
+		}
+	}
+	out.WriteString(nestedOut.String())
+	out.WriteString("}\n")
+	return true
+}
+
+// isRuleAlwaysKept reports whether a style rule is kept whatever the HTML
+// contains. Rules on the document root (:root, html) hold base and theme
+// styles. Custom properties may be read through var() by rules that only
+// match elements added at runtime, so rules declaring them on data-*
+// attribute selectors (or on any selector, with PreserveCustomProperties)
+// are kept too.
+func isRuleAlwaysKept(rule CSSRule, selectors []string, opts PurgeOptions) bool {
+	for _, sel := range selectors {
+		if isRootSelector(sel) {
+			return true
+		}
+	}
+
+	if !declaresCustomProperty(rule) {
+		return false
+	}
+	if opts.PreserveCustomProperties {
 		return true
 	}
+	for _, sel := range selectors {
+		if isDataAttributeSelector(sel) {
+			return true
+		}
+	}
+	return false
+}
+
+// declaresCustomProperty reports whether a style rule's own declarations
+// define a custom property.
+func declaresCustomProperty(rule CSSRule) bool {
+	declarations := rule.Declarations
+	if len(rule.NestedRules) == 0 {
+		declarations, _ = parseRuleBody(extractInnerContent(rule.Content))
+	}
+	for _, decl := range declarations {
+		if strings.HasPrefix(strings.TrimSpace(decl), "--") {
+			return true
+		}
+	}
+	return false
+}
 
+// isRootSelector reports whether selector is a compound selector on the
+// document root, such as ":root", "html", "html.dark", or
+// ":root[data-theme="dark"]".
+func isRootSelector(selector string) bool {
+	if !isCompoundSelector(selector) {
+		return false
+	}
+	lower := strings.ToLower(selector)
+	for _, prefix := range []string{":root", "html"} {
+		if rest, ok := strings.CutPrefix(lower, prefix); ok {
+			return rest == "" || !isIdentChar(rest[0])
+		}
+	}
 	return false
 }
 
+// isDataAttributeSelector reports whether selector is a compound selector
+// made of data-* attribute selectors, optionally on the document root, such
+// as "[data-accent="red"]" or "html[data-theme]".
+func isDataAttributeSelector(selector string) bool {
+	if !isCompoundSelector(selector) {
+		return false
+	}
+	if len(ExtractClassesFromSelector(selector)) > 0 || len(ExtractIDsFromSelector(selector)) > 0 {
+		return false
+	}
+	for _, elem := range ExtractElementsFromSelector(selector) {
+		if !strings.EqualFold(elem, "html") {
+			return false
+		}
+	}
+
+	attrs := ExtractAttributeSelectors(selector)
+	for _, attr := range attrs {
+		if !strings.HasPrefix(attr.Name, "data-") {
+			return false
+		}
+	}
+	return len(attrs) > 0
+}
+
+// isCompoundSelector reports whether selector has no combinators outside
+// attribute selectors and parentheses, so it targets a single element.
+func isCompoundSelector(selector string) bool {
+	depth := 0
+	var quote byte
+	for i := 0; i < len(selector); i++ {
+		ch := selector[i]
+		if quote != 0 {
+			if ch == '\\' {
+				i++
+			} else if ch == quote {
+				quote = 0
+			}
+			continue
+		}
+		switch ch {
+		case '"', '\'':
+			quote = ch
+		case '[', '(':
+			depth++
+		case ']', ')':
+			if depth > 0 {
+				depth--
+			}
+		case ' ', '\t', '\n', '\r', '>', '+', '~':
+			if depth == 0 {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// resolveNestedSelectors expands a (possibly nested) selector list into the
+// complex selectors it targets. For nested rules, "&" is replaced by each
+// parent selector, and selectors without "&" are treated as descendants of
+// the parent (implicit nesting, e.g. ".title" or "> .title").
+func resolveNestedSelectors(selector string, parents []string) []string {
+	selectors := ExtractSelectorsFromRule(selector)
+	if len(parents) == 0 {
+		return selectors
+	}
+
+	resolved := make([]string, 0, len(selectors)*len(parents))
+	for _, sel := range selectors {
+		for _, parent := range parents {
+			if strings.Contains(sel, "&") {
+				resolved = append(resolved, strings.ReplaceAll(sel, "&", parent))
+			} else {
+				resolved = append(resolved, parent+" "+sel)
+			}
+		}
+	}
+	return resolved
+}
+
 // isSelectorUsed checks if a CSS selector matches any used elements.
 // For comma-separated selectors, returns true if ANY selector matches.
 func isSelectorUsed(selector string, used *UsedSelectors, opts PurgeOptions) bool {
-	selectors := ExtractSelectorsFromRule(selector)
+	return anySelectorUsed(ExtractSelectorsFromRule(selector), used, opts)
+}
 
+// anySelectorUsed returns true if any selector in the list is used.
+func anySelectorUsed(selectors []string, used *UsedSelectors, opts PurgeOptions) bool {
 	for _, sel := range selectors {
 		if isSingleSelectorUsed(sel, used, opts) {
 			return true
@@ -147,13 +349,133 @@ func isSelectorUsed(selector string, used *UsedSelectors, opts PurgeOptions) boo
 	return false
 }
 
+// maxSelectorExpansions caps how many alternatives a selector may expand to
+// through :is()/:where(); larger selectors are conservatively kept.
+const maxSelectorExpansions = 256
+
+// forgivingPseudoClasses take a selector list and match if any selector in
+// the list matches, so each list entry is an alternative.
+var forgivingPseudoClasses = []string{":is(", ":where(", ":matches(", ":-webkit-any(", ":-moz-any("}
+
+// expandSelector rewrites a complex selector into the alternatives it can
+// match: each ":is(a, b)" or ":where(a, b)" is replaced by each of its
+// arguments, and ":not(...)" arguments are dropped since a negation never
+// requires the negated classes to be present. Returns nil if the selector
+// expands to more than maxSelectorExpansions alternatives.
+func expandSelector(selector string) []string {
+	results := []string{selector}
+	for {
+		changed := false
+		next := make([]string, 0, len(results))
+		for _, sel := range results {
+			start, open, name := findFunctionalPseudo(sel)
+			if start == -1 {
+				next = append(next, sel)
+				continue
+			}
+			closeIdx := findMatchingParen(sel, open)
+			if closeIdx == -1 {
+				next = append(next, sel)
+				continue
+			}
+
+			changed = true
+			prefix, suffix := sel[:start], sel[closeIdx+1:]
+			if name == ":not(" {
+				// Keep a bare :not so the selector still counts as pseudo-only
+				next = append(next, prefix+":not"+suffix)
+				continue
+			}
+			for _, alt := range ExtractSelectorsFromRule(sel[open+1 : closeIdx]) {
+				// Spaces keep the alternative's components separate from the
+				// surrounding compound (".a:is(div)" must not read as ".adiv")
+				next = append(next, prefix+" "+alt+" "+suffix)
+			}
+		}
+		if len(next) > maxSelectorExpansions {
+			return nil
+		}
+		results = next
+		if !changed {
+			return results
+		}
+	}
+}
+
+// findFunctionalPseudo finds the first :is(), :where(), or :not() style
+// pseudo-class in selector. It returns the index of the leading colon, the
+// index of the opening parenthesis, and the matched prefix, or -1 if none.
+func findFunctionalPseudo(selector string) (start, open int, name string) {
+	start = -1
+	for _, candidate := range append([]string{":not("}, forgivingPseudoClasses...) {
+		idx := strings.Index(selector, candidate)
+		if idx != -1 && (start == -1 || idx < start) {
+			start, name = idx, candidate
+		}
+	}
+	if start == -1 {
+		return -1, -1, ""
+	}
+	return start, start + len(name) - 1, name
+}
+
+// findMatchingParen finds the closing parenthesis matching the one at open.
+func findMatchingParen(s string, open int) int {
+	depth := 0
+	for i := open; i < len(s); i++ {
+		switch s[i] {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
 // isSingleSelectorUsed checks if a single CSS selector is used.
+// Selectors using :is(), :where(), or :not() are expanded first, and the
+// selector is used if any of its alternatives is used.
 func isSingleSelectorUsed(selector string, used *UsedSelectors, opts PurgeOptions) bool {
 	// Check if selector matches a preserve pattern
 	if matchesPreservePattern(selector, opts.Preserve) {
 		return true
 	}
 
+	// Selectors also declared in a page's <style> block are kept so the
+	// stylesheet and inline styles keep cascading together
+	if used.StyleSelectors[normalizeSelector(selector)] {
+		return true
+	}
+
+	if !strings.Contains(selector, "(") {
+		return isCompoundSelectorUsed(selector, used, opts)
+	}
+
+	alternatives := expandSelector(selector)
+	if alternatives == nil {
+		// Too complex to analyze; keep it to be safe
+		return true
+	}
+	for _, alt := range alternatives {
+		if isCompoundSelectorUsed(alt, used, opts) {
+			return true
+		}
+	}
+	return false
+}
+
+// isCompoundSelectorUsed checks if a selector without :is(), :where(), or
+// :not() arguments is used.
+func isCompoundSelectorUsed(selector string, used *UsedSelectors, opts PurgeOptions) bool {
+	// Check if selector matches a preserve pattern
+	if matchesPreservePattern(selector, opts.Preserve) {
+		return true
+	}
+
 	// Universal selector is always used
 	if strings.Contains(selector, "*") {
 		return true
@@ -163,7 +485,7 @@ func isSingleSelectorUsed(selector string, used *UsedSelectors, opts PurgeOption
 	classes := ExtractClassesFromSelector(selector)
 	ids := ExtractIDsFromSelector(selector)
 	elements := ExtractElementsFromSelector(selector)
-	attrs := ExtractAttributesFromSelector(selector)
+	attrs := ExtractAttributeSelectors(selector)
 	hasPseudo := strings.Contains(selector, ":")
 
 	// Keep pseudo-only selectors (e.g., :root, ::selection) to avoid
@@ -233,20 +555,48 @@ func allIDsUsed(ids []string, used *UsedSelectors, preserve []string) bool {
 	return true
 }
 
-// allAttributesUsed checks if all attributes (not matching preserve patterns) are used.
-func allAttributesUsed(attrs []string, used *UsedSelectors, preserveAttrs []string) bool {
+// allAttributesUsed checks if all attribute selectors (whose names do not
+// match preserve patterns) are used.
+func allAttributesUsed(attrs []AttributeSelector, used *UsedSelectors, preserveAttrs []string) bool {
 	for _, attr := range attrs {
 		// Check if attribute matches a preserve pattern
-		if matchesPreservePatterns(attr, preserveAttrs) {
+		if matchesPreservePatterns(attr.Name, preserveAttrs) {
 			continue
 		}
-		if !used.Attributes[attr] {
+		if !isAttributeSelectorUsed(attr, used) {
 			return false
 		}
 	}
 	return true
 }
 
+// isAttributeSelectorUsed checks if an attribute selector matches the HTML.
+// The attribute must be present, on the selector's element if it names one,
+// with a value satisfying the selector's operator. Element and value checks
+// are skipped when used has no such details (e.g., built by hand).
+func isAttributeSelectorUsed(attr AttributeSelector, used *UsedSelectors) bool {
+	if !used.Attributes[attr.Name] {
+		return false
+	}
+
+	if attr.Element != "" && len(used.ElementAttributes) > 0 {
+		if !used.ElementAttributes[attr.Element][attr.Name] {
+			return false
+		}
+	}
+
+	values, ok := used.AttributeValues[attr.Name]
+	if attr.Operator == "" || !ok {
+		return true
+	}
+	for value := range values {
+		if attr.Matches(value) {
+			return true
+		}
+	}
+	return false
+}
+
 // matchesPreservePattern checks if a selector matches any preserve pattern.
 func matchesPreservePattern(selector string, patterns []string) bool {
 	// Extract classes and IDs from selector to check against patterns
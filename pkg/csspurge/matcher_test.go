@@ -152,7 +152,7 @@ func TestPurgeCSS(t *testing.T) {
 				Preserve: tt.preserve,
 			}
 
-			output, stats := PurgeCSS(tt.css, used, opts)
+			output, stats, _ := PurgeCSS(tt.css, used, opts)
 
 			if stats.KeptRules != tt.wantKeptRules {
 				t.Errorf("kept %d rules, want %d", stats.KeptRules, tt.wantKeptRules)
@@ -175,7 +175,7 @@ func TestPurgeStats(t *testing.T) {
 	used.Classes["used"] = true
 
 	css := `.used { color: red; } .unused1 { color: blue; } .unused2 { color: green; }`
-	_, stats := PurgeCSS(css, used, PurgeOptions{})
+	_, stats, _ := PurgeCSS(css, used, PurgeOptions{})
 
 	if stats.TotalRules != 3 {
 		t.Errorf("TotalRules = %d, want 3", stats.TotalRules)
@@ -271,6 +271,17 @@ func TestIsSelectorUsed(t *testing.T) {
 		{":where(*)", true},
 		{".foo, .unused", true},       // One of multiple matches
 		{".unused1, .unused2", false}, // None match
+		{":is(.foo, .unused)", true},
+		{":is(.unused1, .unused2)", false},
+		{"div:where(.unused, .foo) span", true},
+		{"div:is(.unused)", false},
+		{".foo:is(div, span)", true},
+		{".unused:is(div)", false},
+		{":is(.unused, :is(.other, #bar))", true},
+		{".foo:not(.unused)", true}, // Negated classes are not required
+		{".unused:not(.foo)", false},
+		{":not(.unused)", true},
+		{"div:nth-child(2n+1)", true},
 	}
 
 	for _, tt := range tests {
@@ -283,6 +294,72 @@ func TestIsSelectorUsed(t *testing.T) {
 	}
 }
 
+func TestPurgeCSS_Nesting(t *testing.T) {
+	used := NewUsedSelectors()
+	used.Classes["card"] = true
+	used.Classes["title"] = true
+	used.Classes["used"] = true
+	used.Elements["div"] = true
+
+	css := `.card {
+  color: red;
+  &:hover { color: blue; }
+  &.unused-state { color: green; }
+  .title { font-weight: bold; }
+  .missing { display: none; }
+  :is(.used, .nope) & { margin: 0; }
+  @media (min-width: 600px) { padding: 2rem; }
+}
+.gone {
+  color: red;
+  &:hover { color: blue; }
+  .title { font-weight: bold; }
+}
+.parent-unused {
+  color: red;
+  .js-widget & { display: block; }
+}
+:is(.used, .nope) > div { margin: 1px; }
+:where(.nope, .other) { margin: 2px; }
+.used:not(.is-disabled) { opacity: 1; }`
+
+	output, stats, _ := PurgeCSS(css, used, PurgeOptions{Preserve: []string{"js-*"}})
+
+	for _, want := range []string{
+		".card {",
+		"color: red;",
+		"&:hover { color: blue; }",
+		".title { font-weight: bold; }",
+		":is(.used, .nope) & { margin: 0; }",
+		"@media (min-width: 600px) { padding: 2rem; }",
+		":is(.used, .nope) > div { margin: 1px; }",
+		".used:not(.is-disabled) { opacity: 1; }",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("output should contain %q\noutput:\n%s", want, output)
+		}
+	}
+	for _, unwanted := range []string{
+		"&.unused-state",
+		".missing",
+		".gone",
+		":where(.nope, .other)",
+	} {
+		if strings.Contains(output, unwanted) {
+			t.Errorf("output should not contain %q\noutput:\n%s", unwanted, output)
+		}
+	}
+
+	// .parent-unused is kept only as a wrapper for the preserved nested rule
+	if !strings.Contains(output, ".parent-unused {\n.js-widget & { display: block; }\n}") {
+		t.Errorf("expected wrapper without declarations for .parent-unused\noutput:\n%s", output)
+	}
+
+	if stats.KeptRules != 4 {
+		t.Errorf("KeptRules = %d, want 4", stats.KeptRules)
+	}
+}
+
 func TestDefaultPreserveAttributes(t *testing.T) {
 	attrs := DefaultPreserveAttributes()
 	if len(attrs) == 0 {
@@ -409,7 +486,7 @@ func TestThemeSelectorsPreserved(t *testing.T) {
 				PreserveAttributes: tt.preserveAttrs,
 			}
 
-			output, stats := PurgeCSS(tt.css, used, opts)
+			output, stats, _ := PurgeCSS(tt.css, used, opts)
 
 			if tt.wantKept && stats.KeptRules == 0 {
 				t.Errorf("expected rules to be kept, but none were kept")
@@ -429,3 +506,151 @@ func TestThemeSelectorsPreserved(t *testing.T) {
 		})
 	}
 }
+
+func TestPurgeCSS_AttributeSelectors(t *testing.T) {
+	html := `<html><head><style>.tabs .tab { color: red; }</style></head>
+<body>
+<div class="menu" data-state="open" data-size="lg icon">
+<input type="checkbox" checked>
+<a href="https://example.com/docs" lang="en-US">Docs</a>
+</div>
+<div class="tabs"></div>
+</body></html>`
+
+	used := NewUsedSelectors()
+	if err := ScanHTMLContent(html, used); err != nil {
+		t.Fatalf("ScanHTMLContent() error = %v", err)
+	}
+
+	tests := []struct {
+		selector string
+		want     bool
+	}{
+		{`[data-state]`, true},
+		{`[data-state="open"]`, true},
+		{`[data-state="closed"]`, false},
+		{`[data-state="OPEN" i]`, true},
+		{`[data-state='open'] .item`, false},
+		{`.menu[data-state=open]`, true},
+		{`[data-size~="icon"]`, true},
+		{`[data-size~="ic"]`, false},
+		{`[data-missing]`, false},
+		{`[lang|="en"]`, true},
+		{`a[href^="https://"]`, true},
+		{`a[href$=".pdf"]`, false},
+		{`a[href*="example"]`, true},
+		{`input[type="checkbox"]`, true},
+		{`input[type="radio"]`, false},
+		{`input[checked]`, true},
+		{`div[checked]`, false},
+		{`button[type="checkbox"]`, false},
+		{`div > input[type="checkbox" i]`, true},
+		{`.tabs .tab`, true},
+		{`.tabs  .tab`, true},
+		{`.tabs .panel`, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.selector, func(t *testing.T) {
+			if got := isSelectorUsed(tt.selector, used, PurgeOptions{}); got != tt.want {
+				t.Errorf("isSelectorUsed(%q) = %v, want %v", tt.selector, got, tt.want)
+			}
+		})
+	}
+
+	// Preserved attributes are kept regardless of their value
+	opts := PurgeOptions{PreserveAttributes: []string{"data-state"}}
+	if !isSelectorUsed(`[data-state="closed"]`, used, opts) {
+		t.Error("preserved attribute selector should be kept for any value")
+	}
+}
+
+func TestPurgeCSS_CustomProperties(t *testing.T) {
+	css := `:root { --accent: #e63946; }
+html.theme-ocean { --accent: #0077b6; }
+:root.custom-scheme { --bg: #111; }
+[data-accent="red"] { --accent: red; }
+html[data-density="compact"] { --gap: 0.25rem; }
+.js-toggle-on { color: var(--accent); }
+.card-variant { --card-bg: #eee; }
+[data-unused] .card { --card-bg: #ddd; }
+.unused { color: red; }`
+
+	// Only <body> is in the HTML: every rule above targets runtime state
+	used := NewUsedSelectors()
+	used.Elements["body"] = true
+
+	tests := []struct {
+		name            string
+		opts            PurgeOptions
+		wantContains    []string
+		wantNotContains []string
+	}{
+		{
+			name: "root and data attribute scopes are kept",
+			opts: PurgeOptions{Preserve: []string{"js-*"}},
+			wantContains: []string{
+				":root { --accent: #e63946; }",
+				"html.theme-ocean",
+				":root.custom-scheme",
+				`[data-accent="red"]`,
+				`html[data-density="compact"]`,
+				".js-toggle-on",
+			},
+			wantNotContains: []string{".card-variant", "[data-unused]", ".unused"},
+		},
+		{
+			name:         "preserve custom properties keeps any declaring rule",
+			opts:         PurgeOptions{PreserveCustomProperties: true},
+			wantContains: []string{":root {", ".card-variant", "[data-unused] .card"},
+			wantNotContains: []string{
+				".js-toggle-on",
+				".unused",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			output, _, report := PurgeCSS(css, used, tt.opts)
+
+			for _, want := range tt.wantContains {
+				if !strings.Contains(output, want) {
+					t.Errorf("output should contain %q, got: %s", want, output)
+				}
+			}
+			for _, notWant := range tt.wantNotContains {
+				if strings.Contains(output, notWant) {
+					t.Errorf("output should NOT contain %q, got: %s", notWant, output)
+				}
+			}
+			for _, removed := range report.Removed {
+				if strings.HasPrefix(removed.Selector, ":root") || strings.HasPrefix(removed.Selector, "html") {
+					t.Errorf("report lists kept root selector %q as removed", removed.Selector)
+				}
+			}
+		})
+	}
+}
+
+func TestIsRootSelector(t *testing.T) {
+	tests := []struct {
+		selector string
+		want     bool
+	}{
+		{":root", true},
+		{"html", true},
+		{"HTML.dark", true},
+		{`:root[data-theme="dark"]`, true},
+		{`html:has(input[name="a b"]:checked)`, true},
+		{"html body", false},
+		{":root > .x", false},
+		{"htmlx", false},
+		{".html", false},
+	}
+	for _, tt := range tests {
+		if got := isRootSelector(tt.selector); got != tt.want {
+			t.Errorf("isRootSelector(%q) = %v, want %v", tt.selector, got, tt.want)
+		}
+	}
+}
@@ -2,6 +2,8 @@ package csspurge
 
 import (
 	"regexp"
+	"sort"
+	"strconv"
 	"strings"
 )
 
@@ -20,15 +22,18 @@ type CSSRule struct {
 	IsAtRule bool
 	// AtRuleType is the type of @-rule (e.g., "media", "keyframes", "font-face")
 	AtRuleType string
-	// NestedRules contains rules nested inside @-rules like @media
+	// NestedRules contains rules nested inside @-rules like @media, and
+	// rules nested inside style rules using CSS nesting
 	NestedRules []CSSRule
+	// Declarations holds a style rule's own declarations when it has
+	// nested rules (e.g., "color: red;")
+	Declarations []string
+	// Offset is the byte offset of the rule in the original CSS source
+	Offset int
 }
 
 // Regular expressions for CSS parsing.
 var (
-	// Match selectors within a selector list (comma-separated)
-	selectorSplitRegex = regexp.MustCompile(`\s*,\s*`)
-
 	// Extract class names from selector: .class-name
 	classRegex = regexp.MustCompile(`\.(-?[_a-zA-Z][_a-zA-Z0-9-]*(?:\\:[_a-zA-Z0-9-]+)*)`)
 
@@ -50,18 +55,36 @@ var (
 func ParseCSS(content string) []CSSRule {
 	var rules []CSSRule
 
-	// Remove CSS comments
-	content = removeComments(content)
+	// Remove CSS comments, remembering where they were so rule offsets
+	// can be reported against the original source
+	content, gaps := removeCommentsWithOffsets(content)
 
 	// Process the CSS content
 	rules = parseRules(content, rules)
+	mapRuleOffsets(rules, gaps)
 
 	return rules
 }
 
+// commentGap records that removed bytes of comments precede position pos
+// of the comment-free content.
+type commentGap struct {
+	pos     int
+	removed int // cumulative bytes removed up to and including this gap
+}
+
 // removeComments strips CSS comments from content.
 func removeComments(css string) string {
+	stripped, _ := removeCommentsWithOffsets(css)
+	return stripped
+}
+
+// removeCommentsWithOffsets strips CSS comments from content and returns the
+// gaps left by them, ordered by position.
+func removeCommentsWithOffsets(css string) (string, []commentGap) {
 	var result strings.Builder
+	var gaps []commentGap
+	removed := 0
 	i := 0
 	for i < len(css) {
 		// Check for comment start
@@ -72,13 +95,39 @@ func removeComments(css string) string {
 				// Unclosed comment - skip rest of content
 				break
 			}
+			removed += end + 4
+			gaps = append(gaps, commentGap{pos: result.Len(), removed: removed})
 			i += end + 4 // Skip past */
 			continue
 		}
 		result.WriteByte(css[i])
 		i++
 	}
-	return result.String()
+	return result.String(), gaps
+}
+
+// mapRuleOffsets converts rule offsets in comment-free content back to
+// offsets in the original source.
+func mapRuleOffsets(rules []CSSRule, gaps []commentGap) {
+	if len(gaps) == 0 {
+		return
+	}
+	for i := range rules {
+		// Find the last gap at or before the rule's position
+		n := sort.Search(len(gaps), func(j int) bool { return gaps[j].pos > rules[i].Offset })
+		if n > 0 {
+			rules[i].Offset += gaps[n-1].removed
+		}
+		mapRuleOffsets(rules[i].NestedRules, gaps)
+	}
+}
+
+// shiftRuleOffsets adds delta to the offsets of rules and their nested rules.
+func shiftRuleOffsets(rules []CSSRule, delta int) {
+	for i := range rules {
+		rules[i].Offset += delta
+		shiftRuleOffsets(rules[i].NestedRules, delta)
+	}
 }
 
 // parseRules parses CSS rules from content, handling @-rules recursively.
@@ -142,6 +191,7 @@ func parseAtRule(content string, startPos int) (rule *CSSRule, newPos int) {
 		rule = &CSSRule{
 			IsAtRule: true,
 			Content:  strings.TrimSpace(content[pos:endPos]),
+			Offset:   startPos,
 		}
 		// Determine rule type
 		rule.AtRuleType = getAtRuleType(rule.Content)
@@ -165,6 +215,7 @@ func parseAtRule(content string, startPos int) (rule *CSSRule, newPos int) {
 	rule = &CSSRule{
 		IsAtRule: true,
 		Content:  fullContent,
+		Offset:   startPos,
 	}
 
 	// Extract the rule type and nested content
@@ -176,6 +227,10 @@ func parseAtRule(content string, startPos int) (rule *CSSRule, newPos int) {
 		innerContent := extractInnerContent(fullContent)
 		if innerContent != "" {
 			rule.NestedRules = parseRules(innerContent, nil)
+			// Nested offsets are relative to the trimmed inner content
+			inner := content[bracePos+1:]
+			leading := len(inner) - len(strings.TrimLeft(inner, " \n\r\t"))
+			shiftRuleOffsets(rule.NestedRules, bracePos+1+leading)
 		}
 	}
 
@@ -206,10 +261,89 @@ func parseRegularRule(content string, startPos int) (rule *CSSRule, newPos int)
 
 	fullContent := strings.TrimSpace(content[startPos:endPos])
 
-	return &CSSRule{
+	rule = &CSSRule{
 		Selector: selector,
 		Content:  fullContent,
-	}, endPos
+		Offset:   startPos,
+	}
+
+	// Split nested rule blocks from the rule's own declarations
+	body := content[bracePos+1 : endPos-1]
+	if strings.Contains(body, "{") {
+		rule.Declarations, rule.NestedRules = parseRuleBody(body)
+		shiftRuleOffsets(rule.NestedRules, bracePos+1)
+	}
+
+	return rule, endPos
+}
+
+// parseRuleBody splits the body of a style rule into its declarations and
+// the rules nested inside it (CSS nesting). Semicolons and braces inside
+// parentheses or strings do not end a declaration.
+func parseRuleBody(body string) (declarations []string, nested []CSSRule) {
+	addDeclaration := func(decl string) {
+		if decl = strings.TrimSpace(decl); decl != "" && decl != ";" {
+			declarations = append(declarations, decl)
+		}
+	}
+
+	stmtStart := 0
+	parenDepth := 0
+	var quote byte
+	for i := 0; i < len(body); i++ {
+		ch := body[i]
+		if quote != 0 {
+			if ch == '\\' {
+				i++
+			} else if ch == quote {
+				quote = 0
+			}
+			continue
+		}
+
+		switch ch {
+		case '"', '\'':
+			quote = ch
+		case '(':
+			parenDepth++
+		case ')':
+			if parenDepth > 0 {
+				parenDepth--
+			}
+		case ';':
+			if parenDepth == 0 {
+				addDeclaration(body[stmtStart : i+1])
+				stmtStart = i + 1
+			}
+		case '{':
+			if parenDepth != 0 {
+				continue
+			}
+			// Skip leading whitespace so the nested rule starts at its prelude
+			start := stmtStart
+			for start < i && isWhitespace(body[start]) {
+				start++
+			}
+			var rule *CSSRule
+			var end int
+			if body[start] == '@' {
+				rule, end = parseAtRule(body, start)
+			} else {
+				rule, end = parseRegularRule(body, start)
+			}
+			if rule == nil || end <= i {
+				// Unbalanced braces; treat the rest as a declaration
+				addDeclaration(body[stmtStart:])
+				return declarations, nested
+			}
+			nested = append(nested, *rule)
+			i = end - 1
+			stmtStart = end
+		}
+	}
+	addDeclaration(body[stmtStart:])
+
+	return declarations, nested
 }
 
 // getAtRuleType extracts the type of @-rule.
@@ -222,10 +356,22 @@ func getAtRuleType(content string) string {
 }
 
 // findMatchingBrace finds the matching closing brace for the opening brace at position start.
+// Braces inside quoted strings are ignored.
 func findMatchingBrace(content string, start int) int {
 	count := 0
+	var quote byte
 	for i := start; i < len(content); i++ {
+		if quote != 0 {
+			if content[i] == '\\' {
+				i++
+			} else if content[i] == quote {
+				quote = 0
+			}
+			continue
+		}
 		switch content[i] {
+		case '"', '\'':
+			quote = content[i]
 		case '{':
 			count++
 		case '}':
@@ -254,15 +400,49 @@ func isWhitespace(ch byte) bool {
 }
 
 // ExtractSelectorsFromRule extracts individual selectors from a selector list.
+// Commas inside parentheses (e.g., ":is(.a, .b)"), attribute selectors, and
+// strings do not split the list.
 func ExtractSelectorsFromRule(selector string) []string {
-	selectors := selectorSplitRegex.Split(selector, -1)
-	result := make([]string, 0, len(selectors))
-	for _, sel := range selectors {
-		trimmed := strings.TrimSpace(sel)
-		if trimmed != "" {
+	var result []string
+	add := func(sel string) {
+		if trimmed := strings.TrimSpace(sel); trimmed != "" {
 			result = append(result, trimmed)
 		}
 	}
+
+	start := 0
+	depth := 0
+	var quote byte
+	for i := 0; i < len(selector); i++ {
+		ch := selector[i]
+		if quote != 0 {
+			if ch == '\\' {
+				i++
+			} else if ch == quote {
+				quote = 0
+			}
+			continue
+		}
+		switch ch {
+		case '\\':
+			i++ // escaped character, e.g. ".sm\,p-2"
+		case '"', '\'':
+			quote = ch
+		case '(', '[':
+			depth++
+		case ')', ']':
+			if depth > 0 {
+				depth--
+			}
+		case ',':
+			if depth == 0 {
+				add(selector[start:i])
+				start = i + 1
+			}
+		}
+	}
+	add(selector[start:])
+
 	return result
 }
 
@@ -291,9 +471,10 @@ func ExtractIDsFromSelector(selector string) []string {
 }
 
 // ExtractElementsFromSelector extracts unique element names from a selector.
-// Element names are normalized to lowercase and deduplicated.
+// Element names are normalized to lowercase and deduplicated. Words inside
+// attribute selectors (e.g., [type="a b"]) are not elements.
 func ExtractElementsFromSelector(selector string) []string {
-	matches := elementRegex.FindAllStringSubmatch(selector, -1)
+	matches := elementRegex.FindAllStringSubmatch(stripAttributeSelectors(selector), -1)
 	elements := make([]string, 0, len(matches))
 	seen := make(map[string]bool)
 	for _, match := range matches {
@@ -320,3 +501,212 @@ func ExtractAttributesFromSelector(selector string) []string {
 	}
 	return attrs
 }
+
+// AttributeSelector is an attribute selector like [data-state="open" i].
+type AttributeSelector struct {
+	// Element is the element name of the compound selector the attribute
+	// selector belongs to (e.g., "input" for input[type]), or "" if none
+	Element string
+	// Name is the lowercased attribute name
+	Name string
+	// Operator is "" for presence checks, or one of =, ~=, |=, ^=, $=, *=
+	Operator string
+	// Value is the unquoted value to compare against
+	Value string
+	// CaseInsensitive is true when the selector has the "i" flag
+	CaseInsensitive bool
+}
+
+// Matches reports whether an attribute value satisfies the selector's
+// operator and value. Presence-only selectors match any value.
+func (a AttributeSelector) Matches(value string) bool {
+	want := a.Value
+	if a.CaseInsensitive {
+		value, want = strings.ToLower(value), strings.ToLower(want)
+	}
+	switch a.Operator {
+	case "":
+		return true
+	case "=":
+		return value == want
+	case "~=":
+		for _, word := range strings.Fields(value) {
+			if word == want {
+				return true
+			}
+		}
+		return false
+	case "|=":
+		return value == want || strings.HasPrefix(value, want+"-")
+	case "^=":
+		return want != "" && strings.HasPrefix(value, want)
+	case "$=":
+		return want != "" && strings.HasSuffix(value, want)
+	case "*=":
+		return want != "" && strings.Contains(value, want)
+	default:
+		return true
+	}
+}
+
+// String formats the attribute selector as it would appear in CSS.
+func (a AttributeSelector) String() string {
+	if a.Operator == "" {
+		return "[" + a.Name + "]"
+	}
+	s := "[" + a.Name + a.Operator + strconv.Quote(a.Value)
+	if a.CaseInsensitive {
+		s += " i"
+	}
+	return s + "]"
+}
+
+// attributeOperators lists the attribute selector operators, two-character
+// operators first so "~=" is not read as "=".
+var attributeOperators = []string{"~=", "|=", "^=", "$=", "*=", "="}
+
+// ExtractAttributeSelectors extracts the attribute selectors from a selector
+// along with the element name of the compound selector each belongs to.
+func ExtractAttributeSelectors(selector string) []AttributeSelector {
+	var result []AttributeSelector
+	compoundStart := true
+	element := ""
+	for i := 0; i < len(selector); i++ {
+		ch := selector[i]
+		switch {
+		case ch == '[':
+			end := findAttributeEnd(selector, i)
+			if end == -1 {
+				return result
+			}
+			if attr, ok := parseAttributeSelector(selector[i+1 : end]); ok {
+				attr.Element = element
+				result = append(result, attr)
+			}
+			i = end
+			compoundStart = false
+		case ch == '\\':
+			i++ // escaped character
+			compoundStart = false
+		case isWhitespace(ch) || ch == '>' || ch == '+' || ch == '~' || ch == '(' || ch == ')' || ch == ',':
+			// A combinator or argument list starts a new compound selector
+			compoundStart, element = true, ""
+		case compoundStart && isIdentStart(ch):
+			end := i
+			for end < len(selector) && isIdentChar(selector[end]) {
+				end++
+			}
+			element = strings.ToLower(selector[i:end])
+			i = end - 1
+			compoundStart = false
+		default:
+			compoundStart = false
+		}
+	}
+	return result
+}
+
+// parseAttributeSelector parses the inside of an attribute selector, e.g.
+// `data-state="open" i`.
+func parseAttributeSelector(inner string) (AttributeSelector, bool) {
+	inner = strings.TrimSpace(inner)
+	end := 0
+	for end < len(inner) && isIdentChar(inner[end]) {
+		end++
+	}
+	if end == 0 || !isIdentStart(inner[0]) {
+		return AttributeSelector{}, false
+	}
+	attr := AttributeSelector{Name: strings.ToLower(inner[:end])}
+
+	rest := strings.TrimSpace(inner[end:])
+	if rest == "" {
+		return attr, true
+	}
+	for _, op := range attributeOperators {
+		if strings.HasPrefix(rest, op) {
+			attr.Operator = op
+			rest = strings.TrimSpace(rest[len(op):])
+			break
+		}
+	}
+	if attr.Operator == "" {
+		return AttributeSelector{}, false
+	}
+
+	// The value is a quoted string or an identifier, optionally followed by
+	// a case-sensitivity flag
+	var flags string
+	if rest != "" && (rest[0] == '"' || rest[0] == '\'') {
+		closeIdx := strings.IndexByte(rest[1:], rest[0])
+		if closeIdx == -1 {
+			return AttributeSelector{}, false
+		}
+		attr.Value = rest[1 : closeIdx+1]
+		flags = rest[closeIdx+2:]
+	} else {
+		fields := strings.Fields(rest)
+		if len(fields) == 0 {
+			return AttributeSelector{}, false
+		}
+		attr.Value = fields[0]
+		flags = strings.TrimPrefix(rest, fields[0])
+	}
+	attr.CaseInsensitive = strings.EqualFold(strings.TrimSpace(flags), "i")
+
+	return attr, true
+}
+
+// findAttributeEnd finds the closing bracket of the attribute selector
+// opened at start, skipping quoted values.
+func findAttributeEnd(selector string, start int) int {
+	var quote byte
+	for i := start + 1; i < len(selector); i++ {
+		ch := selector[i]
+		if quote != 0 {
+			if ch == '\\' {
+				i++
+			} else if ch == quote {
+				quote = 0
+			}
+			continue
+		}
+		switch ch {
+		case '"', '\'':
+			quote = ch
+		case ']':
+			return i
+		}
+	}
+	return -1
+}
+
+// stripAttributeSelectors removes attribute selectors from a selector,
+// leaving "[]" in their place.
+func stripAttributeSelectors(selector string) string {
+	if !strings.Contains(selector, "[") {
+		return selector
+	}
+	var b strings.Builder
+	for i := 0; i < len(selector); i++ {
+		if selector[i] == '[' {
+			if end := findAttributeEnd(selector, i); end != -1 {
+				b.WriteString("[]")
+				i = end
+				continue
+			}
+		}
+		b.WriteByte(selector[i])
+	}
+	return b.String()
+}
+
+// isIdentStart returns true for characters that can start a CSS identifier.
+func isIdentStart(ch byte) bool {
+	return (ch >= 'a' && ch <= 'z') || (ch >= 'A' && ch <= 'Z') || ch == '_' || ch >= 0x80
+}
+
+// isIdentChar returns true for characters that can appear in a CSS identifier.
+func isIdentChar(ch byte) bool {
+	return isIdentStart(ch) || (ch >= '0' && ch <= '9') || ch == '-'
+}
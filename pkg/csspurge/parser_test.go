@@ -108,6 +108,53 @@ func TestParseCSS(t *testing.T) {
 	}
 }
 
+func TestParseCSS_Nesting(t *testing.T) {
+	css := `.card {
+  color: red;
+  background: url("data:image/svg+xml;utf8,<svg>{}</svg>");
+  &:hover { color: blue; }
+  .title, > .subtitle { font-weight: bold; }
+  @media (min-width: 600px) { padding: 2rem; }
+  margin: 0
+}`
+
+	rules := ParseCSS(css)
+	if len(rules) != 1 {
+		t.Fatalf("ParseCSS() returned %d rules, want 1", len(rules))
+	}
+	card := rules[0]
+	if card.Selector != ".card" {
+		t.Errorf("selector = %q, want %q", card.Selector, ".card")
+	}
+
+	wantDecls := []string{
+		"color: red;",
+		`background: url("data:image/svg+xml;utf8,<svg>{}</svg>");`,
+		"margin: 0",
+	}
+	if len(card.Declarations) != len(wantDecls) {
+		t.Fatalf("declarations = %q, want %q", card.Declarations, wantDecls)
+	}
+	for i, want := range wantDecls {
+		if card.Declarations[i] != want {
+			t.Errorf("declaration[%d] = %q, want %q", i, card.Declarations[i], want)
+		}
+	}
+
+	if len(card.NestedRules) != 3 {
+		t.Fatalf("got %d nested rules, want 3", len(card.NestedRules))
+	}
+	if card.NestedRules[0].Selector != "&:hover" {
+		t.Errorf("nested[0] selector = %q, want %q", card.NestedRules[0].Selector, "&:hover")
+	}
+	if card.NestedRules[1].Selector != ".title, > .subtitle" {
+		t.Errorf("nested[1] selector = %q, want %q", card.NestedRules[1].Selector, ".title, > .subtitle")
+	}
+	if !card.NestedRules[2].IsAtRule || card.NestedRules[2].AtRuleType != "media" {
+		t.Errorf("nested[2] = %+v, want @media rule", card.NestedRules[2])
+	}
+}
+
 func TestExtractSelectorsFromRule(t *testing.T) {
 	tests := []struct {
 		selector string
@@ -118,6 +165,9 @@ func TestExtractSelectorsFromRule(t *testing.T) {
 		{".foo, .bar", []string{".foo", ".bar"}},
 		{".foo,.bar", []string{".foo", ".bar"}},
 		{" h1 ,  h2 ", []string{"h1", "h2"}},
+		{":is(.a, .b) p, .c", []string{":is(.a, .b) p", ".c"}},
+		{`a[title="x,y"], b`, []string{`a[title="x,y"]`, "b"}},
+		{".card:not(.a, .b)", []string{".card:not(.a, .b)"}},
 	}
 
 	for _, tt := range tests {
@@ -246,3 +296,75 @@ func TestRemoveComments(t *testing.T) {
 		})
 	}
 }
+
+func TestParseCSS_Offsets(t *testing.T) {
+	css := `/* a comment */ .a { color: red; }
+@media (min-width: 1px) {
+  /* inner */
+  .b { color: blue; }
+}
+.c {
+  color: red;
+  /* x */ &:hover { color: green; }
+}`
+
+	rules := ParseCSS(css)
+	if len(rules) != 3 {
+		t.Fatalf("got %d rules, want 3", len(rules))
+	}
+
+	tests := []struct {
+		name string
+		rule CSSRule
+		want string
+	}{
+		{"top-level rule", rules[0], ".a {"},
+		{"at-rule", rules[1], "@media"},
+		{"rule in at-rule", rules[1].NestedRules[0], ".b {"},
+		{"nested style rule", rules[2].NestedRules[0], "&:hover"},
+	}
+	for _, tt := range tests {
+		if want := strings.Index(css, tt.want); tt.rule.Offset != want {
+			t.Errorf("%s: Offset = %d, want %d", tt.name, tt.rule.Offset, want)
+		}
+	}
+}
+
+func TestExtractAttributeSelectors(t *testing.T) {
+	tests := []struct {
+		selector string
+		want     []AttributeSelector
+	}{
+		{".a", nil},
+		{"[data-open]", []AttributeSelector{{Name: "data-open"}}},
+		{`input[type="checkbox"]`, []AttributeSelector{{Element: "input", Name: "type", Operator: "=", Value: "checkbox"}}},
+		{`[Data-State='a b' i]`, []AttributeSelector{{Name: "data-state", Operator: "=", Value: "a b", CaseInsensitive: true}}},
+		{`a.link[href^=https][rel~="nofollow"]`, []AttributeSelector{
+			{Element: "a", Name: "href", Operator: "^=", Value: "https"},
+			{Element: "a", Name: "rel", Operator: "~=", Value: "nofollow"},
+		}},
+		{`div > [lang|=en] span[data-x="]"]`, []AttributeSelector{
+			{Name: "lang", Operator: "|=", Value: "en"},
+			{Element: "span", Name: "data-x", Operator: "=", Value: "]"},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.selector, func(t *testing.T) {
+			got := ExtractAttributeSelectors(tt.selector)
+			if len(got) != len(tt.want) {
+				t.Fatalf("ExtractAttributeSelectors(%q) = %+v, want %+v", tt.selector, got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("attribute %d = %+v, want %+v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+
+	// Words in attribute values are not elements
+	if got := ExtractElementsFromSelector(`input[type="a b"]`); len(got) != 1 || got[0] != "input" {
+		t.Errorf("ExtractElementsFromSelector() = %v, want [input]", got)
+	}
+}
@@ -0,0 +1,173 @@
+package csspurge
+
+import (
+	"strings"
+)
+
+// Reasons a selector was removed.
+const (
+	// ReasonNoMatchingElement means an element-only selector targets
+	// elements that do not appear in the HTML.
+	ReasonNoMatchingElement = "no matching element"
+	// ReasonNotInPreserveList means a class, ID, or attribute in the selector
+	// does not appear in the HTML and no preserve pattern matches it.
+	ReasonNotInPreserveList = "not in preserve list"
+)
+
+// PurgeReport describes what PurgeCSS removed and why.
+type PurgeReport struct {
+	// Removed lists the selectors whose declarations were removed.
+	Removed []RemovedSelector `json:"removed"`
+	// Preserve lists every preserve pattern with the number of selectors it
+	// matched, in configuration order.
+	Preserve []PreserveMatch `json:"preserve"`
+}
+
+// RemovedSelector describes a selector removed by PurgeCSS.
+type RemovedSelector struct {
+	// Selector is the removed selector; nested selectors are resolved
+	// against their parent (e.g., ".card.active" for "&.active").
+	Selector string `json:"selector"`
+	// Offset is the byte offset of the selector's rule in the source CSS.
+	Offset int `json:"offset"`
+	// Reason is ReasonNoMatchingElement or ReasonNotInPreserveList.
+	Reason string `json:"reason"`
+	// Missing is the selector component that was not found in the HTML
+	// (e.g., ".modal", "#sidebar", "[data-open]", "table").
+	Missing string `json:"missing,omitempty"`
+}
+
+// PreserveMatch records how many selectors a preserve pattern matched.
+type PreserveMatch struct {
+	// Pattern is the glob pattern from Preserve or PreserveAttributes.
+	Pattern string `json:"pattern"`
+	// Attribute is true for patterns from PreserveAttributes.
+	Attribute bool `json:"attribute,omitempty"`
+	// Matches is the number of selectors in the CSS the pattern matched.
+	Matches int `json:"matches"`
+}
+
+// UnusedPreservePatterns returns the preserve patterns that matched nothing
+// and can be pruned from the configuration.
+func (r *PurgeReport) UnusedPreservePatterns() []string {
+	var unused []string
+	for _, p := range r.Preserve {
+		if p.Matches == 0 {
+			unused = append(unused, p.Pattern)
+		}
+	}
+	return unused
+}
+
+// Merge adds the removals and preserve pattern matches of other to r.
+// Patterns are matched up by pattern and kind.
+func (r *PurgeReport) Merge(other PurgeReport) {
+	r.Removed = append(r.Removed, other.Removed...)
+	for _, p := range other.Preserve {
+		found := false
+		for i := range r.Preserve {
+			if r.Preserve[i].Pattern == p.Pattern && r.Preserve[i].Attribute == p.Attribute {
+				r.Preserve[i].Matches += p.Matches
+				found = true
+				break
+			}
+		}
+		if !found {
+			r.Preserve = append(r.Preserve, p)
+		}
+	}
+}
+
+// recordRemoved adds the selectors of a removed rule to the report.
+func (r *PurgeReport) recordRemoved(selectors []string, offset int, used *UsedSelectors, opts PurgeOptions) {
+	for _, sel := range selectors {
+		reason, missing := removalReason(sel, used, opts)
+		r.Removed = append(r.Removed, RemovedSelector{
+			Selector: sel,
+			Offset:   offset,
+			Reason:   reason,
+			Missing:  missing,
+		})
+	}
+}
+
+// removalReason explains why an unused selector was removed, naming the
+// first component of the selector that was not found in the HTML.
+func removalReason(selector string, used *UsedSelectors, opts PurgeOptions) (reason, missing string) {
+	alternatives := []string{selector}
+	if strings.Contains(selector, "(") {
+		if expanded := expandSelector(selector); expanded != nil {
+			alternatives = expanded
+		}
+	}
+
+	// Report against the first alternative; every alternative is unused
+	sel := alternatives[0]
+	for _, class := range ExtractClassesFromSelector(sel) {
+		if !used.Classes[class] && !matchesPreservePatterns(class, opts.Preserve) {
+			return ReasonNotInPreserveList, "." + class
+		}
+	}
+	for _, id := range ExtractIDsFromSelector(sel) {
+		if !used.IDs[id] && !matchesPreservePatterns(id, opts.Preserve) {
+			return ReasonNotInPreserveList, "#" + id
+		}
+	}
+	for _, attr := range ExtractAttributeSelectors(sel) {
+		if !isAttributeSelectorUsed(attr, used) && !matchesPreservePatterns(attr.Name, opts.PreserveAttributes) {
+			return ReasonNotInPreserveList, attr.String()
+		}
+	}
+
+	return ReasonNoMatchingElement, strings.Join(ExtractElementsFromSelector(sel), ", ")
+}
+
+// countPreserveMatches counts, for each preserve pattern, the selectors in
+// rules that it matches.
+func countPreserveMatches(rules []CSSRule, opts PurgeOptions) []PreserveMatch {
+	matches := make([]PreserveMatch, 0, len(opts.Preserve)+len(opts.PreserveAttributes))
+	for _, pattern := range opts.Preserve {
+		matches = append(matches, PreserveMatch{Pattern: pattern})
+	}
+	for _, pattern := range opts.PreserveAttributes {
+		matches = append(matches, PreserveMatch{Pattern: pattern, Attribute: true})
+	}
+	if len(matches) == 0 {
+		return matches
+	}
+
+	var walk func(rules []CSSRule)
+	walk = func(rules []CSSRule) {
+		for _, rule := range rules {
+			walk(rule.NestedRules)
+			if rule.IsAtRule {
+				continue
+			}
+			for _, sel := range ExtractSelectorsFromRule(rule.Selector) {
+				attrs := ExtractAttributesFromSelector(sel)
+				for i := range matches {
+					if matches[i].Attribute {
+						if anyMatchesPattern(attrs, matches[i].Pattern) {
+							matches[i].Matches++
+						}
+					} else if matchesPreservePattern(sel, []string{matches[i].Pattern}) {
+						matches[i].Matches++
+					}
+				}
+			}
+		}
+	}
+	walk(rules)
+
+	return matches
+}
+
+// anyMatchesPattern returns true if any value matches the glob pattern.
+func anyMatchesPattern(values []string, pattern string) bool {
+	for _, v := range values {
+		if matchesPreservePatterns(v, []string{pattern}) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,82 @@
+package csspurge
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestPurgeCSS_Report(t *testing.T) {
+	used := NewUsedSelectors()
+	used.Classes["card"] = true
+	used.Elements["div"] = true
+
+	css := `/* header */
+.card { color: red; }
+.modal { display: none; }
+table { width: 100%; }
+#sidebar, .js-menu { float: left; }
+[data-open] { outline: 0; }
+.card {
+  &.unused-state { color: green; }
+}
+@media (min-width: 600px) {
+  .gone:is(.a, .b) { padding: 0; }
+}`
+
+	_, stats, report := PurgeCSS(css, used, PurgeOptions{
+		Preserve:           []string{"js-*", "htmx-*"},
+		PreserveAttributes: []string{"data-theme"},
+	})
+
+	want := []RemovedSelector{
+		{Selector: ".modal", Offset: strings.Index(css, ".modal"), Reason: ReasonNotInPreserveList, Missing: ".modal"},
+		{Selector: "table", Offset: strings.Index(css, "table"), Reason: ReasonNoMatchingElement, Missing: "table"},
+		{Selector: "[data-open]", Offset: strings.Index(css, "[data-open]"), Reason: ReasonNotInPreserveList, Missing: "[data-open]"},
+		{Selector: ".card.unused-state", Offset: strings.Index(css, "&.unused-state"), Reason: ReasonNotInPreserveList, Missing: ".unused-state"},
+		{Selector: ".gone:is(.a, .b)", Offset: strings.Index(css, ".gone"), Reason: ReasonNotInPreserveList, Missing: ".gone"},
+	}
+	if !reflect.DeepEqual(report.Removed, want) {
+		t.Errorf("Removed =\n%+v\nwant\n%+v", report.Removed, want)
+	}
+	if stats.RemovedRules == 0 {
+		t.Error("stats should still count removed rules")
+	}
+
+	wantPreserve := []PreserveMatch{
+		{Pattern: "js-*", Matches: 1},
+		{Pattern: "htmx-*", Matches: 0},
+		{Pattern: "data-theme", Attribute: true, Matches: 0},
+	}
+	if !reflect.DeepEqual(report.Preserve, wantPreserve) {
+		t.Errorf("Preserve = %+v, want %+v", report.Preserve, wantPreserve)
+	}
+	if got := report.UnusedPreservePatterns(); !reflect.DeepEqual(got, []string{"htmx-*", "data-theme"}) {
+		t.Errorf("UnusedPreservePatterns() = %v", got)
+	}
+}
+
+func TestPurgeReport_Merge(t *testing.T) {
+	report := PurgeReport{
+		Removed:  []RemovedSelector{{Selector: ".a"}},
+		Preserve: []PreserveMatch{{Pattern: "js-*", Matches: 1}},
+	}
+	report.Merge(PurgeReport{
+		Removed: []RemovedSelector{{Selector: ".b"}},
+		Preserve: []PreserveMatch{
+			{Pattern: "js-*", Matches: 2},
+			{Pattern: "js-*", Attribute: true, Matches: 1},
+		},
+	})
+
+	if len(report.Removed) != 2 {
+		t.Errorf("Removed = %+v, want 2 entries", report.Removed)
+	}
+	wantPreserve := []PreserveMatch{
+		{Pattern: "js-*", Matches: 3},
+		{Pattern: "js-*", Attribute: true, Matches: 1},
+	}
+	if !reflect.DeepEqual(report.Preserve, wantPreserve) {
+		t.Errorf("Preserve = %+v, want %+v", report.Preserve, wantPreserve)
+	}
+}
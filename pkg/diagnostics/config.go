@@ -0,0 +1,195 @@
+package diagnostics
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+)
+
+// LintConfigFile is the standalone diagnostics config file, read from the
+// project root. Its rules override those in [markata-go.diagnostics].
+const LintConfigFile = ".markata-lint.toml"
+
+// ruleIDs lists the codes of every diagnostic rule.
+var ruleIDs = []string{
+	"admonition-fenced-code",
+	"broken-local-link",
+	"broken-wikilink",
+	"duplicate-key",
+	"h1-in-content",
+	"heading-skip",
+	"invalid-date",
+	"missing-alt-text",
+	"missing-image-dimensions",
+	"protocol-less-url",
+	"unclosed-admonition",
+	"unknown-mention",
+}
+
+// offByDefault lists rules that only run when a Config enables them.
+var offByDefault = map[string]bool{
+	"missing-image-dimensions": true,
+}
+
+// RuleIDs returns the sorted codes of every diagnostic rule.
+func RuleIDs() []string {
+	return append([]string(nil), ruleIDs...)
+}
+
+// IsRuleID reports whether id is the code of a diagnostic rule.
+func IsRuleID(id string) bool {
+	i := sort.SearchStrings(ruleIDs, id)
+	return i < len(ruleIDs) && ruleIDs[i] == id
+}
+
+// ParseSeverity parses "error", "warning" (or "warn"), or "info".
+func ParseSeverity(s string) (Severity, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "error":
+		return SeverityError, nil
+	case "warning", "warn":
+		return SeverityWarning, nil
+	case "info":
+		return SeverityInfo, nil
+	}
+	return SeverityWarning, fmt.Errorf("unknown severity %q (want error, warning, or info)", s)
+}
+
+// RuleConfig overrides a single rule.
+type RuleConfig struct {
+	// Enabled turns the rule off when false (default: true).
+	Enabled *bool `json:"enabled,omitempty" yaml:"enabled,omitempty" toml:"enabled,omitempty"`
+
+	// Severity replaces the rule's severity: "error", "warning", or "info".
+	Severity string `json:"severity,omitempty" yaml:"severity,omitempty" toml:"severity,omitempty"`
+}
+
+// Config enables, disables, and re-grades rules by ID:
+//
+//	[markata-go.diagnostics.rules]
+//	h1-in-content = { enabled = false }
+//	missing-alt-text = { severity = "info" }
+//
+// A nil or empty Config leaves every rule at its default.
+type Config struct {
+	Rules map[string]RuleConfig `json:"rules,omitempty" yaml:"rules,omitempty" toml:"rules,omitempty"`
+}
+
+// ConfigFromMap decodes a Config from a generic map, such as the
+// diagnostics section of the site config's Extra map.
+func ConfigFromMap(raw map[string]any) (*Config, error) {
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return nil, fmt.Errorf("encoding diagnostics config: %w", err)
+	}
+	var cfg Config
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("invalid diagnostics config: %w", err)
+	}
+	return &cfg, nil
+}
+
+// LoadConfigFile reads a Config from a TOML file such as LintConfigFile.
+// A missing file returns a nil Config and no error.
+func LoadConfigFile(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var cfg Config
+	if err := toml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	return &cfg, nil
+}
+
+// Merge returns a Config with override's rules layered over c's. Fields
+// override leaves unset keep c's value. Either Config may be nil.
+func (c *Config) Merge(override *Config) *Config {
+	merged := &Config{Rules: make(map[string]RuleConfig)}
+	for _, src := range []*Config{c, override} {
+		if src == nil {
+			continue
+		}
+		for id, rule := range src.Rules {
+			current := merged.Rules[id]
+			if rule.Enabled != nil {
+				current.Enabled = rule.Enabled
+			}
+			if rule.Severity != "" {
+				current.Severity = rule.Severity
+			}
+			merged.Rules[id] = current
+		}
+	}
+	return merged
+}
+
+// Validate returns a warning for each unknown rule ID and invalid
+// severity. Unknown rules are otherwise ignored, and an invalid severity
+// leaves the rule's default in place.
+func (c *Config) Validate() []string {
+	if c == nil {
+		return nil
+	}
+	ids := make([]string, 0, len(c.Rules))
+	for id := range c.Rules {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	var warnings []string
+	for _, id := range ids {
+		if !IsRuleID(id) {
+			warnings = append(warnings, fmt.Sprintf("diagnostics: unknown rule %q", id))
+			continue
+		}
+		if sev := c.Rules[id].Severity; sev != "" {
+			if _, err := ParseSeverity(sev); err != nil {
+				warnings = append(warnings, fmt.Sprintf("diagnostics: rule %q: %v", id, err))
+			}
+		}
+	}
+	return warnings
+}
+
+// RuleEnabled reports whether the rule with the given ID is enabled. Rules
+// are on unless disabled, except for the few that are off by default, such
+// as missing-image-dimensions, which need enabled = true.
+func (c *Config) RuleEnabled(id string) bool {
+	if c != nil {
+		if rule, ok := c.Rules[id]; ok && rule.Enabled != nil {
+			return *rule.Enabled
+		}
+	}
+	return !offByDefault[id]
+}
+
+// apply drops the issues of disabled rules and applies severity overrides.
+func (c *Config) apply(issues []Issue) []Issue {
+	if c == nil || len(c.Rules) == 0 {
+		return issues
+	}
+	kept := issues[:0]
+	for _, issue := range issues {
+		if !c.RuleEnabled(issue.Code) {
+			continue
+		}
+		if sev := c.Rules[issue.Code].Severity; sev != "" {
+			if severity, err := ParseSeverity(sev); err == nil {
+				issue.Severity = severity
+			}
+		}
+		kept = append(kept, issue)
+	}
+	return kept
+}
@@ -0,0 +1,145 @@
+package diagnostics
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func boolPtr(b bool) *bool { return &b }
+
+func TestCheckWithOptions_Config(t *testing.T) {
+	content := "# Title\n\n![](img.png)\n"
+
+	issues := CheckWithOptions("test.md", content, nil, Options{})
+	if !hasCode(issues, "h1-in-content") || !hasCode(issues, "missing-alt-text") {
+		t.Fatalf("default issues = %v, want h1-in-content and missing-alt-text", issues)
+	}
+
+	cfg := &Config{Rules: map[string]RuleConfig{
+		"h1-in-content":    {Enabled: boolPtr(false)},
+		"missing-alt-text": {Severity: "info"},
+	}}
+	issues = CheckWithOptions("test.md", content, nil, Options{Config: cfg})
+
+	if hasCode(issues, "h1-in-content") {
+		t.Error("disabled h1-in-content rule still reported")
+	}
+	found := false
+	for _, issue := range issues {
+		if issue.Code == "missing-alt-text" {
+			found = true
+			if issue.Severity != SeverityInfo {
+				t.Errorf("missing-alt-text severity = %v, want info", issue.Severity)
+			}
+		}
+	}
+	if !found {
+		t.Error("missing-alt-text not reported")
+	}
+}
+
+func hasCode(issues []Issue, code string) bool {
+	for _, issue := range issues {
+		if issue.Code == code {
+			return true
+		}
+	}
+	return false
+}
+
+func TestConfig_Validate(t *testing.T) {
+	cfg := &Config{Rules: map[string]RuleConfig{
+		"h1-in-content": {Enabled: boolPtr(false)},
+		"no-such-rule":  {Enabled: boolPtr(false)},
+		"heading-skip":  {Severity: "loud"},
+	}}
+	warnings := cfg.Validate()
+	if len(warnings) != 2 {
+		t.Fatalf("Validate() = %v, want 2 warnings", warnings)
+	}
+	if !strings.Contains(warnings[0], `"heading-skip"`) || !strings.Contains(warnings[1], `unknown rule "no-such-rule"`) {
+		t.Errorf("Validate() = %v", warnings)
+	}
+
+	var nilCfg *Config
+	if warnings := nilCfg.Validate(); warnings != nil {
+		t.Errorf("nil Validate() = %v, want nil", warnings)
+	}
+}
+
+func TestConfig_Merge(t *testing.T) {
+	site := &Config{Rules: map[string]RuleConfig{
+		"h1-in-content":    {Enabled: boolPtr(false)},
+		"missing-alt-text": {Severity: "info"},
+	}}
+	file := &Config{Rules: map[string]RuleConfig{
+		"missing-alt-text": {Enabled: boolPtr(false)},
+	}}
+
+	merged := site.Merge(file)
+	if merged.RuleEnabled("h1-in-content") || merged.RuleEnabled("missing-alt-text") {
+		t.Errorf("merged rules = %+v, want both disabled", merged.Rules)
+	}
+	if got := merged.Rules["missing-alt-text"].Severity; got != "info" {
+		t.Errorf("missing-alt-text severity = %q, want kept from site config", got)
+	}
+
+	var nilCfg *Config
+	if !nilCfg.Merge(nil).RuleEnabled("heading-skip") {
+		t.Error("empty merge disabled a rule")
+	}
+}
+
+func TestConfigFromMap(t *testing.T) {
+	cfg, err := ConfigFromMap(map[string]any{
+		"rules": map[string]any{
+			"h1-in-content": map[string]any{"enabled": false},
+		},
+	})
+	if err != nil {
+		t.Fatalf("ConfigFromMap() error = %v", err)
+	}
+	if cfg.RuleEnabled("h1-in-content") {
+		t.Error("h1-in-content enabled, want disabled")
+	}
+
+	if _, err := ConfigFromMap(map[string]any{"rules": "nope"}); err == nil {
+		t.Error("ConfigFromMap() with invalid rules expected error")
+	}
+}
+
+func TestLoadConfigFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, LintConfigFile)
+
+	cfg, err := LoadConfigFile(path)
+	if err != nil || cfg != nil {
+		t.Fatalf("LoadConfigFile(missing) = %v, %v, want nil, nil", cfg, err)
+	}
+
+	content := "[rules]\nheading-skip = { severity = \"error\" }\n"
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err = LoadConfigFile(path)
+	if err != nil {
+		t.Fatalf("LoadConfigFile() error = %v", err)
+	}
+	if got := cfg.Rules["heading-skip"].Severity; got != "error" {
+		t.Errorf("heading-skip severity = %q, want error", got)
+	}
+}
+
+func TestRuleIDs_Sorted(t *testing.T) {
+	ids := RuleIDs()
+	for i := 1; i < len(ids); i++ {
+		if ids[i-1] >= ids[i] {
+			t.Fatalf("RuleIDs() not sorted: %v", ids)
+		}
+	}
+	if !IsRuleID("h1-in-content") || IsRuleID("h1") {
+		t.Error("IsRuleID() mismatch")
+	}
+}
@@ -3,6 +3,13 @@ package diagnostics
 import (
 	"bufio"
 	"fmt"
+	"image"
+	_ "image/gif"  // register GIF for image.DecodeConfig
+	_ "image/jpeg" // register JPEG for image.DecodeConfig
+	_ "image/png"  // register PNG for image.DecodeConfig
+	"net/url"
+	"os"
+	"path/filepath"
 	"regexp"
 	"strings"
 	"time"
@@ -58,9 +65,44 @@ type Resolver interface {
 	ResolveHandle(handle string) bool
 }
 
+// BaseDirResolver is an optional interface a Resolver can implement to enable
+// the broken-local-link check.
+type BaseDirResolver interface {
+	// BaseDir returns the directory that relative links in filePath resolve
+	// against, or "" to skip the check for that file.
+	BaseDir(filePath string) string
+}
+
+// RuleLevel controls whether an optional rule reports issues.
+type RuleLevel int
+
+const (
+	// RuleWarn reports the rule's issues as warnings (the default).
+	RuleWarn RuleLevel = iota
+	// RuleIgnore disables the rule.
+	RuleIgnore
+)
+
+// Options configures optional diagnostic rules. The zero value enables
+// every rule at its default level.
+type Options struct {
+	// HeadingSkip controls the heading-skip rule.
+	HeadingSkip RuleLevel
+
+	// Config enables, disables, and re-grades rules by ID. Nil keeps every
+	// rule at its default.
+	Config *Config
+}
+
 // Check runs all diagnostic checks on the content and returns any issues found.
 // The resolver is optional; if nil, wikilink and mention checks are skipped.
+// Local link checks run only when the resolver implements BaseDirResolver.
 func Check(filePath, content string, resolver Resolver) []Issue {
+	return CheckWithOptions(filePath, content, resolver, Options{})
+}
+
+// CheckWithOptions is like Check but applies the given rule options.
+func CheckWithOptions(filePath, content string, resolver Resolver, opts Options) []Issue {
 	var issues []Issue
 
 	// Extract frontmatter for YAML-specific checks
@@ -75,15 +117,33 @@ func Check(filePath, content string, resolver Resolver) []Issue {
 	issues = append(issues, checkImageLinks(filePath, body, hasFrontmatter, frontmatter)...)
 	issues = append(issues, checkProtocollessURLs(filePath, content)...)
 	issues = append(issues, checkH1Headings(filePath, body, hasFrontmatter, frontmatter)...)
+	if opts.HeadingSkip != RuleIgnore {
+		issues = append(issues, checkHeadingSkips(filePath, body, hasFrontmatter, frontmatter)...)
+	}
 	issues = append(issues, checkAdmonitionFencedCode(filePath, body, hasFrontmatter, frontmatter)...)
+	issues = append(issues, checkUnclosedAdmonitions(filePath, body, hasFrontmatter, frontmatter)...)
 
 	// Reference checks (require resolver)
 	if resolver != nil {
 		issues = append(issues, checkWikilinks(filePath, body, hasFrontmatter, frontmatter, resolver)...)
 		issues = append(issues, checkMentions(filePath, body, hasFrontmatter, frontmatter, resolver)...)
+		if dirResolver, ok := resolver.(BaseDirResolver); ok {
+			if baseDir := dirResolver.BaseDir(filePath); baseDir != "" {
+				issues = append(issues, checkLocalLinks(filePath, body, hasFrontmatter, frontmatter, baseDir)...)
+			}
+		}
 	}
 
-	return issues
+	// Off by default; local images are measured when a base directory is known
+	if opts.Config.RuleEnabled("missing-image-dimensions") {
+		baseDir := ""
+		if dirResolver, ok := resolver.(BaseDirResolver); ok {
+			baseDir = dirResolver.BaseDir(filePath)
+		}
+		issues = append(issues, checkImageDimensions(filePath, body, hasFrontmatter, frontmatter, baseDir)...)
+	}
+
+	return opts.Config.apply(issues)
 }
 
 // extractFrontmatter extracts frontmatter from content.
@@ -100,12 +160,23 @@ func extractFrontmatter(content string) (frontmatter, body string, hasFrontmatte
 	return parts[0], parts[1], true
 }
 
+// bodyLineOffset returns the 0-based file line of the first body line
+// returned by extractFrontmatter. The body starts on the closing --- line.
+func bodyLineOffset(hasFrontmatter bool, frontmatter string) int {
+	if !hasFrontmatter {
+		return 0
+	}
+	return strings.Count(frontmatter, "\n")
+}
+
 // checkDuplicateKeys finds duplicate YAML keys in frontmatter.
 func checkDuplicateKeys(filePath, frontmatter string) []Issue {
 	var issues []Issue
 	seen := make(map[string]int) // key -> first line number
 	scanner := bufio.NewScanner(strings.NewReader(frontmatter))
-	lineNum := 1 // Start at 1 (after the opening ---)
+	// The first scanned line is the remainder of the opening --- line,
+	// so lineNum is the 1-based line number in the file.
+	lineNum := 0
 
 	// Regex to match top-level YAML keys (not indented)
 	keyRegex := regexp.MustCompile(`^([a-zA-Z_][a-zA-Z0-9_]*)\s*:`)
@@ -143,7 +214,7 @@ func checkDuplicateKeys(filePath, frontmatter string) []Issue {
 func checkDateFormats(filePath, frontmatter string) []Issue {
 	var issues []Issue
 	scanner := bufio.NewScanner(strings.NewReader(frontmatter))
-	lineNum := 1
+	lineNum := 0 // 1-based line number in the file, see checkDuplicateKeys
 
 	// Regex to match date-like fields
 	dateKeyRegex := regexp.MustCompile(`^(date|published_date|created|modified|updated)\s*:\s*(.+)$`)
@@ -207,10 +278,7 @@ func checkImageLinks(filePath, body string, hasFrontmatter bool, frontmatter str
 	noAltRegex := regexp.MustCompile(`!\[\]\(([^)]+)\)`)
 
 	// Calculate line offset for body
-	lineOffset := 0
-	if hasFrontmatter {
-		lineOffset = strings.Count(frontmatter, "\n") + 2 // +2 for both --- lines
-	}
+	lineOffset := bodyLineOffset(hasFrontmatter, frontmatter)
 
 	scanner := bufio.NewScanner(strings.NewReader(body))
 	lineNum := 0
@@ -325,27 +393,148 @@ func checkH1Headings(filePath, body string, hasFrontmatter bool, frontmatter str
 	return issues
 }
 
+// Heading patterns for checkHeadingSkips.
+var (
+	atxHeadingRegex      = regexp.MustCompile(`^ {0,3}(#{1,6})(?:[ \t]|$)`)
+	setextH1Regex        = regexp.MustCompile(`^ {0,3}=+[ \t]*$`)
+	setextH2Regex        = regexp.MustCompile(`^ {0,3}-+[ \t]*$`)
+	setextParagraphRegex = regexp.MustCompile(`^ {0,3}[^\s#>\-*+|]`)
+)
+
+// checkHeadingSkips finds headings whose level increases by more than one
+// from the previous heading, e.g. an H2 followed directly by an H4. Content
+// starts below the H1 that templates render from the title, so a leading H3
+// is also a skip. Decreasing levels are always fine.
+func checkHeadingSkips(filePath, body string, hasFrontmatter bool, frontmatter string) []Issue {
+	var issues []Issue
+
+	lineOffset := bodyLineOffset(hasFrontmatter, frontmatter)
+
+	lines := strings.Split(body, "\n")
+	inCodeBlock := false
+	codeBlockPattern := regexp.MustCompile("^```|^~~~")
+	prevLevel := 1
+
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if codeBlockPattern.MatchString(trimmed) {
+			inCodeBlock = !inCodeBlock
+			continue
+		}
+		if inCodeBlock {
+			continue
+		}
+
+		level, headingLine := 0, i
+		if match := atxHeadingRegex.FindStringSubmatch(line); match != nil {
+			level = len(match[1])
+		} else if i > 0 && setextParagraphRegex.MatchString(lines[i-1]) &&
+			!atxHeadingRegex.MatchString(lines[i-1]) {
+			// Setext underline: the heading text is on the previous line
+			switch {
+			case setextH1Regex.MatchString(line):
+				level, headingLine = 1, i-1
+			case setextH2Regex.MatchString(line):
+				level, headingLine = 2, i-1
+			}
+		}
+		if level == 0 {
+			continue
+		}
+
+		if level > prevLevel+1 {
+			issues = append(issues, Issue{
+				File: filePath,
+				Range: Range{
+					StartLine: headingLine + lineOffset,
+					StartCol:  0,
+					EndLine:   headingLine + lineOffset,
+					EndCol:    len(lines[headingLine]),
+				},
+				Code:     "heading-skip",
+				Severity: SeverityWarning,
+				Message:  fmt.Sprintf("heading level skipped: H%d follows H%d (use H%d or lower)", level, prevLevel, prevLevel+1),
+				Fixable:  false,
+			})
+		}
+		prevLevel = level
+	}
+
+	return issues
+}
+
+var (
+	// admonitionHeaderRegex matches !!!, ??? and ???+ admonition openers.
+	admonitionHeaderRegex = regexp.MustCompile(`^(\s*)(?:!!!|\?\?\?\+?)\s+[\w-]+`)
+	fencedCodeRegex       = regexp.MustCompile("^\\s*(?:```|~~~)")
+
+	// containerFenceRegex matches ::: container fences, capturing the colons
+	// and any text after them. A fence without text closes a container.
+	containerFenceRegex = regexp.MustCompile(`^\s*(:{3,})\s*(.*?)\s*$`)
+)
+
 // checkAdmonitionFencedCode detects fenced code blocks inside admonitions
-// that don't have a blank line before them.
+// that don't have a blank line before them. It also detects the inverse: a
+// fence after a blank line that is indented less than the admonition body,
+// which ends the admonition early and renders the code outside it.
 func checkAdmonitionFencedCode(filePath, body string, hasFrontmatter bool, frontmatter string) []Issue {
 	var issues []Issue
 
-	lineOffset := 0
-	if hasFrontmatter {
-		lineOffset = strings.Count(frontmatter, "\n") + 1
-		body = strings.TrimPrefix(body, "\n")
+	lineOffset := bodyLineOffset(hasFrontmatter, frontmatter)
+
+	type openAdmonition struct {
+		indent  int
+		hasBody bool
 	}
 
 	lines := strings.Split(body, "\n")
+	var open []openAdmonition
+	inCodeBlock := false
+	afterBlank := false
 
-	admonitionRegex := regexp.MustCompile(`^(\s*)!!!\s+\w+`)
-	fencedCodeRegex := regexp.MustCompile(`^\s*` + "```")
+	for i, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			afterBlank = true
+			continue
+		}
+		isFence := fencedCodeRegex.MatchString(line)
+		if inCodeBlock {
+			inCodeBlock = !isFence
+			afterBlank = false
+			continue
+		}
 
-	for i := 0; i < len(lines); i++ {
-		line := lines[i]
-		lineNum := i + 1 + lineOffset
+		indent := indentWidth(line)
+		if len(open) > 0 {
+			// Admonition content is indented 4 spaces past the opener. A
+			// shallower fence after a blank line was likely meant to be
+			// inside, either because it is indented at all or because the
+			// admonition would otherwise be empty.
+			top := open[len(open)-1]
+			if isFence && afterBlank && indent < top.indent+4 && (indent > top.indent || !top.hasBody) {
+				issues = append(issues, Issue{
+					File: filePath,
+					Range: Range{
+						StartLine: i + lineOffset,
+						StartCol:  0,
+						EndLine:   i + lineOffset,
+						EndCol:    len(line),
+					},
+					Code:     "admonition-fenced-code",
+					Severity: SeverityWarning,
+					Message:  fmt.Sprintf("fenced code block after blank line is indented %d spaces, which ends the admonition early - indent it %d spaces to keep it inside", indent, top.indent+4),
+					Fixable:  false,
+				})
+			}
+		}
+		for len(open) > 0 && indent < open[len(open)-1].indent+4 {
+			open = open[:len(open)-1]
+		}
+		if len(open) > 0 {
+			open[len(open)-1].hasBody = true
+		}
 
-		if match := admonitionRegex.FindStringSubmatch(line); match != nil {
+		if match := admonitionHeaderRegex.FindStringSubmatch(line); match != nil {
 			admonitionIndent := len(match[1])
 
 			if i+1 < len(lines) {
@@ -357,9 +546,9 @@ func checkAdmonitionFencedCode(filePath, body string, hasFrontmatter bool, front
 					issues = append(issues, Issue{
 						File: filePath,
 						Range: Range{
-							StartLine: lineNum - 1,
+							StartLine: i + lineOffset,
 							StartCol:  0,
-							EndLine:   lineNum - 1,
+							EndLine:   i + lineOffset,
 							EndCol:    len(line),
 						},
 						Code:     "admonition-fenced-code",
@@ -369,7 +558,106 @@ func checkAdmonitionFencedCode(filePath, body string, hasFrontmatter bool, front
 					})
 				}
 			}
+			open = append(open, openAdmonition{indent: indent})
 		}
+
+		inCodeBlock = isFence
+		afterBlank = false
+	}
+
+	return issues
+}
+
+// indentWidth returns the width of a line's leading whitespace, counting
+// tabs as 4 columns like the markdown parser does.
+func indentWidth(line string) int {
+	width := 0
+	for _, r := range line {
+		switch r {
+		case ' ':
+			width++
+		case '\t':
+			width += 4 - width%4
+		default:
+			return width
+		}
+	}
+	return width
+}
+
+// checkUnclosedAdmonitions finds ::: containers that are never closed, which
+// makes the rest of the post render inside them. Closing fences are matched
+// the way the container parser does: open containers are checked from the
+// outermost in, and the first one at least as deep as the fence is closed
+// along with everything nested inside it. Nested containers therefore need
+// more colons than their parent; one closed by its parent's fence, or a
+// container closed by a shorter fence, is reported too.
+func checkUnclosedAdmonitions(filePath, body string, hasFrontmatter bool, frontmatter string) []Issue {
+	var issues []Issue
+
+	lineOffset := bodyLineOffset(hasFrontmatter, frontmatter)
+
+	type openContainer struct {
+		line  int
+		fence string
+	}
+
+	lines := strings.Split(body, "\n")
+	report := func(c openContainer, message string) {
+		issues = append(issues, Issue{
+			File: filePath,
+			Range: Range{
+				StartLine: c.line + lineOffset,
+				StartCol:  0,
+				EndLine:   c.line + lineOffset,
+				EndCol:    len(lines[c.line]),
+			},
+			Code:     "unclosed-admonition",
+			Severity: SeverityWarning,
+			Message:  message,
+			Fixable:  false,
+		})
+	}
+
+	var open []openContainer
+	inCodeBlock := false
+	for i, line := range lines {
+		if fencedCodeRegex.MatchString(line) {
+			inCodeBlock = !inCodeBlock
+			continue
+		}
+		if inCodeBlock {
+			continue
+		}
+
+		match := containerFenceRegex.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+		fence := match[1]
+		if match[2] != "" {
+			open = append(open, openContainer{line: i, fence: fence})
+			continue
+		}
+
+		closeLine := i + lineOffset + 1
+		for k, c := range open {
+			if len(c.fence) < len(fence) {
+				continue
+			}
+			if len(c.fence) > len(fence) {
+				report(c, fmt.Sprintf("%s container is ended early by the shorter %s fence on line %d - close it with %s", c.fence, fence, closeLine, c.fence))
+			}
+			for _, inner := range open[k+1:] {
+				report(inner, fmt.Sprintf("nested %s container is ended by the %s fence on line %d that closes its parent - nested containers need more colons than their parent", inner.fence, fence, closeLine))
+			}
+			open = open[:k]
+			break
+		}
+	}
+
+	for _, c := range open {
+		report(c, fmt.Sprintf("%s container is never closed, so the rest of the post renders inside it - add a closing %s line", c.fence, c.fence))
 	}
 
 	return issues
@@ -382,10 +670,7 @@ var wikilinkRegex = regexp.MustCompile(`\[\[([^\]|]+)(?:\|([^\]]+))?\]\]`)
 func checkWikilinks(filePath, body string, hasFrontmatter bool, frontmatter string, resolver Resolver) []Issue {
 	var issues []Issue
 
-	lineOffset := 0
-	if hasFrontmatter {
-		lineOffset = strings.Count(frontmatter, "\n") + 2 // +2 for both --- lines
-	}
+	lineOffset := bodyLineOffset(hasFrontmatter, frontmatter)
 
 	lines := strings.Split(body, "\n")
 	inCodeBlock := false
@@ -439,10 +724,7 @@ var mentionRegex = regexp.MustCompile(`@([a-zA-Z][a-zA-Z0-9_.-]*)`)
 func checkMentions(filePath, body string, hasFrontmatter bool, frontmatter string, resolver Resolver) []Issue {
 	var issues []Issue
 
-	lineOffset := 0
-	if hasFrontmatter {
-		lineOffset = strings.Count(frontmatter, "\n") + 2
-	}
+	lineOffset := bodyLineOffset(hasFrontmatter, frontmatter)
 
 	lines := strings.Split(body, "\n")
 	inCodeBlock := false
@@ -499,3 +781,227 @@ func checkMentions(filePath, body string, hasFrontmatter bool, frontmatter strin
 
 	return issues
 }
+
+// inlineLinkRegex matches inline markdown links and images, capturing the
+// leading "!" for images and the destination, which may be wrapped in <>
+// and followed by an optional "title".
+var inlineLinkRegex = regexp.MustCompile(`(!?)\[[^\]]*\]\(\s*(<[^>]*>|[^)\s]+)(?:\s+(?:"[^"]*"|'[^']*'))?\s*\)`)
+
+// inlineCodeRegex matches inline code spans, which are ignored by link checks.
+var inlineCodeRegex = regexp.MustCompile("`[^`]*`")
+
+// schemeRegex matches a URL scheme such as "https:" or "mailto:".
+var schemeRegex = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9+.-]*:`)
+
+// checkLocalLinks finds inline links and images whose relative targets do
+// not exist on disk, resolving them against baseDir.
+func checkLocalLinks(filePath, body string, hasFrontmatter bool, frontmatter, baseDir string) []Issue {
+	var issues []Issue
+
+	lineOffset := bodyLineOffset(hasFrontmatter, frontmatter)
+
+	lines := strings.Split(body, "\n")
+	inCodeBlock := false
+	codeBlockPattern := regexp.MustCompile("^```|^~~~")
+
+	for lineNum, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if codeBlockPattern.MatchString(trimmed) {
+			inCodeBlock = !inCodeBlock
+			continue
+		}
+		if inCodeBlock {
+			continue
+		}
+
+		// Blank out inline code so offsets still match the original line
+		masked := inlineCodeRegex.ReplaceAllStringFunc(line, func(code string) string {
+			return strings.Repeat(" ", len(code))
+		})
+
+		matches := inlineLinkRegex.FindAllStringSubmatchIndex(masked, -1)
+		for _, match := range matches {
+			if len(match) < 6 {
+				continue
+			}
+
+			target := localLinkTarget(line[match[4]:match[5]])
+			if target == "" {
+				continue
+			}
+			if _, err := os.Stat(filepath.Join(baseDir, filepath.FromSlash(target))); err == nil {
+				continue
+			}
+
+			kind := "link"
+			if line[match[2]:match[3]] == "!" {
+				kind = "image"
+			}
+			issues = append(issues, Issue{
+				File: filePath,
+				Range: Range{
+					StartLine: lineNum + lineOffset,
+					StartCol:  match[0],
+					EndLine:   lineNum + lineOffset,
+					EndCol:    match[1],
+				},
+				Code:     "broken-local-link",
+				Severity: SeverityWarning,
+				Message:  fmt.Sprintf("broken local %s: %q not found", kind, target),
+				Fixable:  false,
+			})
+		}
+	}
+
+	return issues
+}
+
+// localLinkTarget returns the decoded relative file path of a link
+// destination, or "" for destinations that are not local files: URLs with
+// a scheme (http:, mailto:, ...), protocol-less and site-absolute paths,
+// and same-page anchors.
+func localLinkTarget(dest string) string {
+	dest = strings.TrimSuffix(strings.TrimPrefix(dest, "<"), ">")
+	if dest == "" || strings.HasPrefix(dest, "#") || strings.HasPrefix(dest, "/") ||
+		schemeRegex.MatchString(dest) {
+		return ""
+	}
+
+	// Drop the fragment and query, which are not part of the file path
+	if i := strings.IndexAny(dest, "#?"); i >= 0 {
+		dest = dest[:i]
+	}
+	if decoded, err := url.PathUnescape(dest); err == nil {
+		dest = decoded
+	}
+	return dest
+}
+
+// markdownImageRegex matches inline markdown images with an optional
+// trailing {...} attribute block, capturing the alt text, the destination,
+// and the attributes.
+var markdownImageRegex = regexp.MustCompile(`!\[([^\]]*)\]\(\s*(<[^>]*>|[^)\s]+)(?:\s+(?:"[^"]*"|'[^']*'))?\s*\)(\{[^}]*\})?`)
+
+var (
+	// htmlImgRegex matches a raw <img> tag.
+	htmlImgRegex = regexp.MustCompile(`(?i)<img\b[^>]*>`)
+
+	// imgSrcRegex, imgWidthRegex, and imgHeightRegex read attributes of an
+	// <img> tag or a markdown {...} attribute block.
+	imgSrcRegex    = regexp.MustCompile(`(?i)\bsrc\s*=\s*(?:"([^"]*)"|'([^']*)'|([^\s>]+))`)
+	imgWidthRegex  = regexp.MustCompile(`(?i)(?:^|[\s{])width\s*=`)
+	imgHeightRegex = regexp.MustCompile(`(?i)(?:^|[\s{])height\s*=`)
+
+	// emptyAltRegex matches alt="" marking an image as decorative.
+	emptyAltRegex = regexp.MustCompile(`(?i)\balt\s*=\s*(?:""|'')`)
+)
+
+// checkImageDimensions finds markdown images and <img> tags without both a
+// width and a height, which shift the layout when they load. Decorative
+// images (empty alt text) are skipped. When baseDir is set, local images
+// are measured so the message can suggest the attributes to add.
+func checkImageDimensions(filePath, body string, hasFrontmatter bool, frontmatter, baseDir string) []Issue {
+	var issues []Issue
+
+	lineOffset := bodyLineOffset(hasFrontmatter, frontmatter)
+
+	lines := strings.Split(body, "\n")
+	inCodeBlock := false
+	codeBlockPattern := regexp.MustCompile("^```|^~~~")
+
+	report := func(lineNum, start, end int, src string, markdown bool) {
+		message := fmt.Sprintf("image %q has no width and height, which can shift the layout as it loads", src)
+		if width, height, ok := localImageSize(baseDir, src); ok {
+			if markdown {
+				message += fmt.Sprintf("; add {width=%d height=%d}", width, height)
+			} else {
+				message += fmt.Sprintf(`; add width="%d" height="%d"`, width, height)
+			}
+		}
+		issues = append(issues, Issue{
+			File: filePath,
+			Range: Range{
+				StartLine: lineNum + lineOffset,
+				StartCol:  start,
+				EndLine:   lineNum + lineOffset,
+				EndCol:    end,
+			},
+			Code:     "missing-image-dimensions",
+			Severity: SeverityWarning,
+			Message:  message,
+			Fixable:  false,
+		})
+	}
+
+	for lineNum, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if codeBlockPattern.MatchString(trimmed) {
+			inCodeBlock = !inCodeBlock
+			continue
+		}
+		if inCodeBlock {
+			continue
+		}
+
+		// Blank out inline code so offsets still match the original line
+		masked := inlineCodeRegex.ReplaceAllStringFunc(line, func(code string) string {
+			return strings.Repeat(" ", len(code))
+		})
+
+		for _, match := range markdownImageRegex.FindAllStringSubmatchIndex(masked, -1) {
+			alt := line[match[2]:match[3]]
+			if strings.TrimSpace(alt) == "" {
+				continue
+			}
+			attrs := ""
+			if match[6] >= 0 {
+				attrs = line[match[6]:match[7]]
+			}
+			if imgWidthRegex.MatchString(attrs) && imgHeightRegex.MatchString(attrs) {
+				continue
+			}
+			src := strings.TrimSuffix(strings.TrimPrefix(line[match[4]:match[5]], "<"), ">")
+			report(lineNum, match[0], match[1], src, true)
+		}
+
+		for _, match := range htmlImgRegex.FindAllStringIndex(masked, -1) {
+			tag := line[match[0]:match[1]]
+			if emptyAltRegex.MatchString(tag) {
+				continue
+			}
+			if imgWidthRegex.MatchString(tag) && imgHeightRegex.MatchString(tag) {
+				continue
+			}
+			src := ""
+			if m := imgSrcRegex.FindStringSubmatch(tag); m != nil {
+				src = m[1] + m[2] + m[3]
+			}
+			report(lineNum, match[0], match[1], src, false)
+		}
+	}
+
+	return issues
+}
+
+// localImageSize returns the pixel size of the local image src relative to
+// baseDir. It returns false for remote images, formats it cannot decode
+// (such as SVG), and when baseDir is empty.
+func localImageSize(baseDir, src string) (width, height int, ok bool) {
+	if baseDir == "" {
+		return 0, 0, false
+	}
+	target := localLinkTarget(src)
+	if target == "" {
+		return 0, 0, false
+	}
+	f, err := os.Open(filepath.Join(baseDir, filepath.FromSlash(target)))
+	if err != nil {
+		return 0, 0, false
+	}
+	defer f.Close()
+	cfg, _, err := image.DecodeConfig(f)
+	if err != nil {
+		return 0, 0, false
+	}
+	return cfg.Width, cfg.Height, true
+}
@@ -1,6 +1,13 @@
 package diagnostics
 
 import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/png"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -444,6 +451,59 @@ title: Test
     ` + "```",
 			wantLen: 1,
 		},
+		{
+			name: "blank line then under-indented fence ends admonition",
+			content: `!!! note
+    Some text.
+
+  ` + "```python" + `
+  print("hello")
+  ` + "```",
+			wantLen: 1,
+		},
+		{
+			name: "blank line then unindented fence in empty admonition",
+			content: `??? tip "Example"
+
+` + "```python" + `
+print("hello")
+` + "```",
+			wantLen: 1,
+		},
+		{
+			name: "unindented fence after admonition body OK",
+			content: `!!! note
+    Some text.
+
+` + "```python" + `
+print("hello")
+` + "```",
+			wantLen: 0,
+		},
+		{
+			name: "nested admonition with indented fence OK",
+			content: `!!! note
+    Outer.
+
+    !!! warning
+        Inner.
+
+        ` + "```python" + `
+        print("hello")
+        ` + "```" + `
+
+    Back in outer.`,
+			wantLen: 0,
+		},
+		{
+			name: "admonition inside code block ignored",
+			content: "```markdown" + `
+!!! note
+
+  ` + "~~~" + `
+` + "```",
+			wantLen: 0,
+		},
 	}
 
 	for _, tt := range tests {
@@ -464,6 +524,134 @@ title: Test
 	}
 }
 
+func TestCheck_UnclosedAdmonition(t *testing.T) {
+	tests := []struct {
+		name      string
+		content   string
+		wantLines []int
+	}{
+		{
+			name:      "closed container OK",
+			content:   "::: note\nText\n:::\nAfter",
+			wantLines: nil,
+		},
+		{
+			name:      "unclosed container",
+			content:   "---\ntitle: Test\n---\nIntro\n\n::: note\nText\n\nRest of the post",
+			wantLines: []int{5},
+		},
+		{
+			name:      "correctly nested containers OK",
+			content:   ":::: wa-tabs\n::::: wa-tab {label=\"One\"}\nOne\n:::::\n::::: wa-tab {label=\"Two\"}\nTwo\n:::::\n::::",
+			wantLines: nil,
+		},
+		{
+			name:      "nested container closed by parent fence",
+			content:   "::: outer\n::: inner\nText\n:::\n:::",
+			wantLines: []int{1},
+		},
+		{
+			name:      "container closed by shorter fence",
+			content:   ":::: note\nText\n:::",
+			wantLines: []int{0},
+		},
+		{
+			name:      "inner container left open",
+			content:   "::: outer\n:::: inner\nText\n:::",
+			wantLines: []int{1},
+		},
+		{
+			name:      "fences inside code block ignored",
+			content:   "```markdown\n::: note\n```\n\n~~~\n:::\n~~~",
+			wantLines: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issues := Check("test.md", tt.content, nil)
+
+			var gotLines []int
+			for _, issue := range issues {
+				if issue.Code == "unclosed-admonition" {
+					gotLines = append(gotLines, issue.Range.StartLine)
+				}
+			}
+
+			if fmt.Sprint(gotLines) != fmt.Sprint(tt.wantLines) {
+				t.Errorf("unclosed-admonition lines = %v, want %v", gotLines, tt.wantLines)
+			}
+		})
+	}
+}
+
+func TestCheck_IssueLines(t *testing.T) {
+	content := `---
+title: Test
+date: 2024/01/15
+title: Again
+---
+
+![](cat.png)
+[[missing]] and @nobody
+# Heading`
+
+	want := map[string]int{
+		"invalid-date":     2,
+		"duplicate-key":    3,
+		"missing-alt-text": 6,
+		"broken-wikilink":  7,
+		"unknown-mention":  7,
+		"h1-in-content":    8,
+	}
+
+	issues := Check("test.md", content, &mockResolver{})
+	got := make(map[string]int, len(issues))
+	for _, issue := range issues {
+		got[issue.Code] = issue.Range.StartLine
+	}
+
+	for code, line := range want {
+		gotLine, ok := got[code]
+		if !ok {
+			t.Errorf("missing %s issue", code)
+			continue
+		}
+		if gotLine != line {
+			t.Errorf("%s StartLine = %d, want %d", code, gotLine, line)
+		}
+	}
+}
+
+func TestBodyLineOffset(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    int
+	}{
+		{"no frontmatter", "# Title\nbody", 0},
+		{"empty frontmatter", "---\n---\nbody", 1},
+		{"one key", "---\ntitle: Test\n---\nbody", 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			frontmatter, body, hasFrontmatter := extractFrontmatter(tt.content)
+			got := bodyLineOffset(hasFrontmatter, frontmatter)
+			if got != tt.want {
+				t.Errorf("bodyLineOffset() = %d, want %d", got, tt.want)
+			}
+			// The first body line is the remainder of the closing --- line,
+			// so the line after it must be "body".
+			lines := strings.Split(tt.content, "\n")
+			bodyLines := strings.Split(body, "\n")
+			if tt.want > 0 && lines[got+1] != bodyLines[1] {
+				t.Errorf("line %d = %q, want %q", got+1, lines[got+1], bodyLines[1])
+			}
+		})
+	}
+}
+
 func TestSeverity_String(t *testing.T) {
 	tests := []struct {
 		severity Severity
@@ -483,3 +671,242 @@ func TestSeverity_String(t *testing.T) {
 		})
 	}
 }
+
+// dirResolver is a mockResolver that also supplies a base directory.
+type dirResolver struct {
+	mockResolver
+	dir string
+}
+
+func (d *dirResolver) BaseDir(string) string {
+	return d.dir
+}
+
+func TestCheck_BrokenLocalLinks(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "img"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"other-post.md", "img/my photo.png"} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte("x"), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	resolver := &dirResolver{dir: dir}
+
+	tests := []struct {
+		name     string
+		content  string
+		wantLen  int
+		resolver Resolver
+	}{
+		{"valid relative doc link", "See [other](./other-post.md).", 0, resolver},
+		{"valid link with title and fragment", `See [other](other-post.md#intro "Other post").`, 0, resolver},
+		{"url-encoded spaces", "![photo](img/my%20photo.png)", 0, resolver},
+		{"angle bracket destination", "![photo](<img/my photo.png>)", 0, resolver},
+		{"missing image", "![diagram](./img/foo.png)", 1, resolver},
+		{"missing doc link", "[see](../missing-post.md)", 1, resolver},
+		{"external links skipped", "[a](https://go.dev) [b](mailto:me@example.com) [c](#top) [d](/about/)", 0, resolver},
+		{"wikilinks skipped", "[[other-post]]", 0, resolver},
+		{"inline code skipped", "Use `![x](nope.png)` syntax", 0, resolver},
+		{"fenced code skipped", "```\n[x](nope.md)\n```", 0, resolver},
+		{"resolver without base dir skips check", "![diagram](./img/foo.png)", 0, &mockResolver{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issues := Check("post.md", tt.content, tt.resolver)
+
+			var linkIssues []Issue
+			for _, issue := range issues {
+				if issue.Code == "broken-local-link" {
+					linkIssues = append(linkIssues, issue)
+				}
+			}
+
+			if len(linkIssues) != tt.wantLen {
+				t.Errorf("got %d broken-local-link issues, want %d: %v", len(linkIssues), tt.wantLen, linkIssues)
+			}
+		})
+	}
+}
+
+func TestCheck_BrokenLocalLinkRange(t *testing.T) {
+	content := "---\ntitle: Test\n---\n\nText ![diagram](./img/foo.png \"Diagram\")"
+	issues := Check("post.md", content, &dirResolver{dir: t.TempDir()})
+
+	var found bool
+	for _, issue := range issues {
+		if issue.Code != "broken-local-link" {
+			continue
+		}
+		found = true
+		if issue.Range.StartLine != 4 || issue.Range.StartCol != 5 {
+			t.Errorf("range start = %d:%d, want 4:5", issue.Range.StartLine, issue.Range.StartCol)
+		}
+		if !strings.Contains(issue.Message, `"./img/foo.png"`) || !strings.Contains(issue.Message, "image") {
+			t.Errorf("message = %q", issue.Message)
+		}
+	}
+	if !found {
+		t.Fatal("expected a broken-local-link issue")
+	}
+}
+
+func TestCheck_HeadingSkip(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		wantLine []int
+	}{
+		{
+			name:     "valid H1 H2 H3 sequence",
+			content:  "# One\n\n## Two\n\n### Three\n",
+			wantLine: nil,
+		},
+		{
+			name:     "H2 to H4 skip",
+			content:  "## Two\n\ntext\n\n#### Four",
+			wantLine: []int{4},
+		},
+		{
+			name:     "leading H3 skips the title H1",
+			content:  "### Three",
+			wantLine: []int{0},
+		},
+		{
+			name:     "decreasing levels are fine",
+			content:  "## Two\n\n### Three\n\n## Two again",
+			wantLine: nil,
+		},
+		{
+			name:     "setext headings",
+			content:  "Title\n=====\n\nSection\n-------\n\n#### Deep",
+			wantLine: []int{6},
+		},
+		{
+			name:     "headings in code blocks ignored",
+			content:  "## Two\n\n```\n#### inside a code block\n```\n\n### Three",
+			wantLine: nil,
+		},
+		{
+			name:     "hashes without space are not headings",
+			content:  "## Two\n\n####not-a-heading",
+			wantLine: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issues := Check("test.md", tt.content, nil)
+
+			var lines []int
+			for _, issue := range issues {
+				if issue.Code == "heading-skip" {
+					lines = append(lines, issue.Range.StartLine)
+				}
+			}
+
+			if len(lines) != len(tt.wantLine) {
+				t.Fatalf("got heading-skip issues on lines %v, want %v", lines, tt.wantLine)
+			}
+			for i := range lines {
+				if lines[i] != tt.wantLine[i] {
+					t.Errorf("issue %d on line %d, want %d", i, lines[i], tt.wantLine[i])
+				}
+			}
+		})
+	}
+}
+
+func TestCheck_HeadingSkipWithH1(t *testing.T) {
+	content := "---\ntitle: Test\n---\n# Title\n\n### Skipped"
+	issues := Check("test.md", content, nil)
+
+	codes := make(map[string][]int)
+	for _, issue := range issues {
+		codes[issue.Code] = append(codes[issue.Code], issue.Range.StartLine)
+	}
+
+	if got := codes["h1-in-content"]; len(got) != 1 || got[0] != 3 {
+		t.Errorf("h1-in-content lines = %v, want [3]", got)
+	}
+	if got := codes["heading-skip"]; len(got) != 1 || got[0] != 5 {
+		t.Errorf("heading-skip lines = %v, want [5] only", got)
+	}
+}
+
+func TestCheckWithOptions_IgnoreHeadingSkip(t *testing.T) {
+	issues := CheckWithOptions("test.md", "## Two\n\n#### Four", nil, Options{HeadingSkip: RuleIgnore})
+	for _, issue := range issues {
+		if issue.Code == "heading-skip" {
+			t.Errorf("heading-skip reported when ignored: %v", issue)
+		}
+	}
+}
+
+func TestCheck_MissingImageDimensions(t *testing.T) {
+	dir := t.TempDir()
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, 640, 480))); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "hero.png"), buf.Bytes(), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	resolver := &dirResolver{dir: dir}
+	enabled := Options{Config: &Config{Rules: map[string]RuleConfig{
+		"missing-image-dimensions": {Enabled: boolPtr(true)},
+	}}}
+
+	tests := []struct {
+		name        string
+		content     string
+		wantLen     int
+		wantMessage string
+	}{
+		{"dimensionless local image suggests size", "![Hero](hero.png)", 1, "add {width=640 height=480}"},
+		{"markdown with explicit size", "![Hero](hero.png){width=640 height=480}", 0, ""},
+		{"markdown with width only", "![Hero](hero.png){.wide width=640}", 1, ""},
+		{"raw img suggests attributes", `<img src="hero.png" alt="Hero">`, 1, `add width="640" height="480"`},
+		{"raw img with explicit size", `<img src="hero.png" alt="Hero" width="640" height="480">`, 0, ""},
+		{"decorative raw img skipped", `<img src="hero.png" alt="">`, 0, ""},
+		{"decorative markdown image skipped", "![](hero.png)", 0, ""},
+		{"remote image has no suggestion", "![Logo](https://example.com/logo.png)", 1, "layout"},
+		{"code skipped", "`![Hero](hero.png)`\n```\n<img src=\"hero.png\" alt=\"x\">\n```", 0, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []Issue
+			for _, issue := range CheckWithOptions("post.md", tt.content, resolver, enabled) {
+				if issue.Code == "missing-image-dimensions" {
+					got = append(got, issue)
+				}
+			}
+			if len(got) != tt.wantLen {
+				t.Fatalf("got %d missing-image-dimensions issues, want %d: %v", len(got), tt.wantLen, got)
+			}
+			if tt.wantMessage != "" && !strings.Contains(got[0].Message, tt.wantMessage) {
+				t.Errorf("message = %q, want it to contain %q", got[0].Message, tt.wantMessage)
+			}
+			if len(got) > 0 && got[0].Severity != SeverityWarning {
+				t.Errorf("severity = %v, want warning", got[0].Severity)
+			}
+		})
+	}
+}
+
+func TestCheck_MissingImageDimensionsOffByDefault(t *testing.T) {
+	issues := Check("post.md", "![Hero](hero.png)", &dirResolver{dir: t.TempDir()})
+	if hasCode(issues, "missing-image-dimensions") {
+		t.Errorf("rule should be off by default, got %v", issues)
+	}
+
+	// A severity override alone does not turn it on
+	cfg := &Config{Rules: map[string]RuleConfig{"missing-image-dimensions": {Severity: "error"}}}
+	issues = CheckWithOptions("post.md", "![Hero](hero.png)", nil, Options{Config: cfg})
+	if hasCode(issues, "missing-image-dimensions") {
+		t.Errorf("rule should stay off without enabled = true, got %v", issues)
+	}
+}
@@ -8,12 +8,18 @@
 // The package detects the following issues:
 //   - broken-wikilink: Wikilinks pointing to non-existent posts
 //   - unknown-mention: Mentions (@handle) not found in blogroll
+//   - broken-local-link: Relative links and images to files missing on disk
 //   - h1-in-content: H1 headings in content (templates add H1 from title)
+//   - heading-skip: Heading levels that jump by more than one (H2 -> H4)
 //   - duplicate-key: Duplicate YAML keys in frontmatter
 //   - invalid-date: Invalid date formats (non-ISO 8601)
 //   - missing-alt-text: Images without alt text
+//   - missing-image-dimensions: Images without width and height (off by
+//     default; enable it through Config)
 //   - protocol-less-url: URLs without protocol (//example.com)
-//   - admonition-fenced-code: Fenced code blocks in admonitions without blank line
+//   - admonition-fenced-code: Fenced code blocks in admonitions without blank
+//     line, or after a blank line but indented too little to stay inside
+//   - unclosed-admonition: ::: containers with no matching closing fence
 //
 // # Usage
 //
@@ -24,4 +30,23 @@
 // For wikilink and mention checking, provide a Resolver:
 //
 //	issues := diagnostics.Check(filePath, content, resolver)
+//
+// Optional rules can be disabled with CheckWithOptions:
+//
+//	issues := diagnostics.CheckWithOptions(filePath, content, resolver,
+//	    diagnostics.Options{HeadingSkip: diagnostics.RuleIgnore})
+//
+// Options.Config enables, disables, or re-grades rules by ID. The lint
+// command and LSP server load it from [markata-go.diagnostics] and
+// LintConfigFile:
+//
+//	cfg := &diagnostics.Config{Rules: map[string]diagnostics.RuleConfig{
+//	    "missing-alt-text": {Severity: "info"},
+//	}}
+//	issues := diagnostics.CheckWithOptions(filePath, content, resolver,
+//	    diagnostics.Options{Config: cfg})
+//
+// To check relative links such as [see](../other-post.md) or
+// ![diagram](./img/foo.png), the resolver must also implement
+// BaseDirResolver to supply the directory links resolve against.
 package diagnostics
@@ -0,0 +1,53 @@
+package filter
+
+import "github.com/WaylonWalker/markata-go/pkg/models"
+
+// Fields returns the names of the post fields the expression reads, in the
+// order they first appear. Nested access such as "author.name" reports only
+// the root field. A bare identifier on the value side of 'in' is skipped
+// because it falls back to its own name as a string ("tags contains go").
+func (f *Filter) Fields() []string {
+	var fields []string
+	seen := make(map[string]bool)
+	add := func(name string) {
+		if !seen[name] {
+			seen[name] = true
+			fields = append(fields, name)
+		}
+	}
+
+	var walk func(expr Expr)
+	walk = func(expr Expr) {
+		switch e := expr.(type) {
+		case *Identifier:
+			add(e.Name)
+		case *BinaryExpr:
+			walk(e.Left)
+			walk(e.Right)
+		case *UnaryExpr:
+			walk(e.Expr)
+		case *InExpr:
+			if _, ok := e.Value.(*Identifier); !ok {
+				walk(e.Value)
+			}
+			walk(e.Collection)
+		case *CallExpr:
+			walk(e.Object)
+			for _, arg := range e.Args {
+				walk(arg)
+			}
+		case *FieldAccess:
+			walk(e.Object)
+		}
+	}
+	walk(f.ast)
+
+	return fields
+}
+
+// IsKnownField reports whether name is a built-in post field. Other names
+// are looked up in the post's Extra map.
+func IsKnownField(name string) bool {
+	_, ok := getKnownField(&models.Post{}, name)
+	return ok
+}
@@ -1,6 +1,7 @@
 package filter
 
 import (
+	"strings"
 	"testing"
 	"time"
 
@@ -788,3 +789,126 @@ func TestFilter_NilHandling(t *testing.T) {
 		t.Errorf("expected at least 1 match, got %d", len(result))
 	}
 }
+
+func TestFilter_Precedence(t *testing.T) {
+	posts := []*models.Post{
+		{Slug: "go", Published: true, Tags: []string{"golang"}, Extra: map[string]interface{}{"words": 100}},
+		{Slug: "rust", Published: true, Tags: []string{"rust"}, Extra: map[string]interface{}{"words": 900}},
+		{Slug: "draft-go", Published: false, Draft: true, Tags: []string{"golang"}, Extra: map[string]interface{}{"words": 300}},
+		{Slug: "python", Published: true, Tags: []string{"python"}, Extra: map[string]interface{}{"words": 500}},
+	}
+
+	tests := []struct {
+		filter string
+		want   string
+	}{
+		// Simple expressions keep working
+		{"published == True", "go,rust,python"},
+		{"'golang' in tags", "go,draft-go"},
+		{"tags contains rust", "rust"},
+		{"words > 400", "rust,python"},
+
+		// and binds tighter than or
+		{"published == False or tags contains rust and words > 400", "rust,draft-go"},
+		{"tags contains rust and words > 400 or published == False", "rust,draft-go"},
+		{"(published == False or tags contains rust) and words > 400", "rust"},
+		{"published==true and (tags contains golang or tags contains rust)", "go,rust"},
+		{"published == True and tags contains golang or tags contains rust", "go,rust"},
+		{"published == True and (tags contains golang or tags contains python)", "go,python"},
+
+		// not binds tighter than and/or
+		{"not draft and words < 400", "go"},
+		{"not (draft or words < 400)", "rust,python"},
+		{"not not draft", "draft-go"},
+		{"not draft == True", "go,rust,python"},
+
+		// Nested parentheses
+		{"((published == True))", "go,rust,python"},
+		{"(words > 200 and (tags contains golang or (tags contains python and words < 600)))", "draft-go,python"},
+		{"slug == 'go' or (slug == 'rust' or slug == 'python') and words > 600", "go,rust"},
+
+		// Comparisons and in/contains on both sides of operators
+		{"words >= 300 and words <= 500", "draft-go,python"},
+		{"slug != 'go' and 'o' in slug", "draft-go,python"},
+		{"slug.startswith('draft') or tags contains python", "draft-go,python"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.filter, func(t *testing.T) {
+			f, err := Parse(tt.filter)
+			if err != nil {
+				t.Fatalf("parse error: %v", err)
+			}
+			var got []string
+			for _, post := range f.MatchAll(posts) {
+				got = append(got, post.Slug)
+			}
+			if strings.Join(got, ",") != tt.want {
+				t.Errorf("matched %v, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFilter_SyntaxErrorPosition(t *testing.T) {
+	tests := []struct {
+		filter string
+		want   string
+	}{
+		{"published == True)", "unexpected RPAREN()) at position 17"},
+		{"published == True tags contains go", "unexpected IDENTIFIER(tags) at position 18"},
+		{"(published == True", "expected ')' after expression, got EOF at position 18"},
+		{"published == True and", "unexpected EOF at position 21"},
+		{"words > 1 < 2", "comparisons cannot be chained; combine them with 'and' at position 10"},
+		{"title.", "expected identifier after '.', got EOF at position 6"},
+		{"title.startswith('a'", "expected ')' after method arguments, got EOF at position 20"},
+		{"published = True", "unexpected character '=' at position 10"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.filter, func(t *testing.T) {
+			_, err := Parse(tt.filter)
+			if err == nil {
+				t.Fatalf("expected error for %q", tt.filter)
+			}
+			if !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("error = %v, want it to contain %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestFilter_Fields(t *testing.T) {
+	tests := []struct {
+		filter string
+		want   string
+	}{
+		{"", ""},
+		{"published == True and date <= today", "published,date"},
+		{"tags contains golang or 'rust' in tags", "tags"},
+		{"not draft and (series == 'go' or title.startswith(prefix))", "draft,series,title,prefix"},
+		{"author.name == 'me' and words > 100", "author,words"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.filter, func(t *testing.T) {
+			f := MustParse(tt.filter)
+			if got := strings.Join(f.Fields(), ","); got != tt.want {
+				t.Errorf("Fields() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsKnownField(t *testing.T) {
+	for _, name := range []string{"published", "Published", "tags", "date", "templateKey", "author"} {
+		if !IsKnownField(name) {
+			t.Errorf("IsKnownField(%q) = false, want true", name)
+		}
+	}
+	for _, name := range []string{"series", "words", "tag"} {
+		if IsKnownField(name) {
+			t.Errorf("IsKnownField(%q) = true, want false", name)
+		}
+	}
+}
@@ -146,7 +146,21 @@ func (p *Parser) Parse() (Expr, error) {
 		// Empty expression - always matches
 		return &Literal{Value: true}, nil
 	}
-	return p.parseOr()
+	expr, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	// Anything left over means the expression did not parse as a whole,
+	// e.g. "a == 1 b == 2" or an unmatched ')'
+	if p.current.Type != TokenEOF {
+		return nil, p.errorf("unexpected %s", p.current)
+	}
+	return expr, nil
+}
+
+// errorf returns a parse error annotated with the current token's position.
+func (p *Parser) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf(format+" at position %d", append(args, p.current.Pos)...)
 }
 
 // parseOr parses 'or' expressions (lowest precedence)
@@ -250,6 +264,9 @@ func (p *Parser) parseComparison() (Expr, error) {
 		if err != nil {
 			return nil, err
 		}
+		if p.current.Type == TokenCompareOp {
+			return nil, p.errorf("unexpected %s, comparisons cannot be chained; combine them with 'and'", p.current)
+		}
 		return &BinaryExpr{Left: left, Op: op, Right: right}, nil
 	}
 
@@ -270,7 +287,7 @@ func (p *Parser) parseAccess() (Expr, error) {
 
 		// Accept identifier or 'contains' keyword as method name (for backward compatibility)
 		if p.current.Type != TokenIdentifier && p.current.Type != TokenContains {
-			return nil, fmt.Errorf("expected identifier after '.', got %s", p.current)
+			return nil, p.errorf("expected identifier after '.', got %s", p.current)
 		}
 
 		name := p.current.Value
@@ -303,7 +320,7 @@ func (p *Parser) parseAccess() (Expr, error) {
 			}
 
 			if p.current.Type != TokenRParen {
-				return nil, fmt.Errorf("expected ')' after method arguments, got %s", p.current)
+				return nil, p.errorf("expected ')' after method arguments, got %s", p.current)
 			}
 			if err := p.advance(); err != nil {
 				return nil, err
@@ -376,7 +393,7 @@ func (p *Parser) parsePrimary() (Expr, error) {
 			return nil, err
 		}
 		if p.current.Type != TokenRParen {
-			return nil, fmt.Errorf("expected ')' after expression, got %s", p.current)
+			return nil, p.errorf("expected ')' after expression, got %s", p.current)
 		}
 		if err := p.advance(); err != nil {
 			return nil, err
@@ -384,7 +401,7 @@ func (p *Parser) parsePrimary() (Expr, error) {
 		return expr, nil
 
 	default:
-		return nil, fmt.Errorf("unexpected token: %s", p.current)
+		return nil, p.errorf("unexpected %s", p.current)
 	}
 }
 
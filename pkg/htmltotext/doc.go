@@ -25,10 +25,38 @@
 //	Input:  Visit <a href="https://go.dev">https://go.dev</a>
 //	Output: Visit https://go.dev
 //
-// Duplicate URLs reuse the same reference number.
+// Duplicate URLs reuse the same reference number. Links within the page
+// (href="#..."), such as footnote markers and back-links, become their text
+// with no reference. A link with no text, such as an icon link, uses its
+// aria-label or title attribute as the text.
 //
 // # Usage
 //
 //	text := htmltotext.Convert("<p>Hello &amp; <a href=\"https://go.dev\">Go</a></p>")
 //	// Returns: "Hello & Go [1]\n\nReferences:\n[1]: https://go.dev"
+//
+// # Line Wrapping
+//
+// Convert leaves lines unwrapped. ConvertWith accepts Options; a WrapWidth
+// word-wraps body text for plaintext outputs such as .txt posts and email
+// newsletters. Width is counted in runes, long words and URLs are never
+// split, a "[n]" marker stays with the word it annotates, and the
+// References section is not wrapped:
+//
+//	text := htmltotext.ConvertWith(html, htmltotext.Options{WrapWidth: 72})
+//
+// # Markdown Output
+//
+// ConvertToMarkdown, or Options.Markdown, shares the same HTML handling but
+// writes Markdown for "copy as markdown" and email bodies: **bold**,
+// _italic_, "## " headings, "- " and "1. " list markers, "> " blockquotes,
+// and inline links instead of footnotes:
+//
+//	Input:  <h2>Install</h2><p>See <a href="https://go.dev">Go</a>.</p>
+//	Output: ## Install
+//
+//	        See [Go](https://go.dev).
+//
+// Code blocks are fenced and keep the language from a class="language-x"
+// on the <pre> or <code> tag. Lines are never wrapped in Markdown mode.
 package htmltotext
@@ -5,6 +5,7 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"unicode/utf8"
 )
 
 // Pre-compiled regex patterns for HTML parsing.
@@ -15,6 +16,11 @@ var (
 	// Matches opening anchor tags and captures the href attribute.
 	anchorOpenRe = regexp.MustCompile(`(?i)<a\s[^>]*href\s*=\s*["']([^"']*)["'][^>]*>`)
 
+	// Match the aria-label and title attributes of a tag, used as the
+	// text of links that have none.
+	ariaLabelAttrRe = regexp.MustCompile(`(?i)\saria-label\s*=\s*(?:"([^"]*)"|'([^']*)')`)
+	titleAttrRe     = regexp.MustCompile(`(?i)\stitle\s*=\s*(?:"([^"]*)"|'([^']*)')`)
+
 	// Matches closing anchor tags.
 	anchorCloseRe = regexp.MustCompile(`(?i)</a\s*>`)
 
@@ -38,27 +44,71 @@ var (
 
 	// Collapses multiple spaces (not newlines) to a single space.
 	multiSpaceRe = regexp.MustCompile(`[^\S\n]+`)
+
+	// Matches a footnote reference marker such as "[1]" or "[12]," at the
+	// start of a word.
+	refMarkerRe = regexp.MustCompile(`^\[\d+\]`)
 )
 
+// Options controls optional formatting of the converted text.
+type Options struct {
+	// WrapWidth word-wraps body text to this many columns (runes).
+	// Words longer than the width, such as URLs, are never split, and the
+	// References section is left unwrapped. Zero disables wrapping.
+	// It is ignored in Markdown mode, where wrapping would break headings.
+	WrapWidth int
+
+	// Markdown emits Markdown instead of plain text, see ConvertToMarkdown.
+	Markdown bool
+}
+
 // Convert transforms HTML content into plain text with footnote-style link
 // references. It decodes HTML entities, strips tags while preserving block
 // structure, and appends a references section for any hyperlinks found.
 //
 // Links where the visible text matches the URL are rendered inline without
 // a footnote reference. Duplicate URLs share the same reference number.
+// Lines are not wrapped; use ConvertWith to set a wrap width.
 func Convert(htmlContent string) string {
+	return ConvertWith(htmlContent, Options{})
+}
+
+// ConvertWith is like Convert but applies the given formatting options.
+func ConvertWith(htmlContent string, opts Options) string {
 	if htmlContent == "" {
 		return ""
 	}
+	if opts.Markdown {
+		return convertMarkdown(htmlContent)
+	}
 
 	// Phase 1: Extract links and replace anchor tags with placeholders
 	var links []linkRef
 	urlToRef := make(map[string]int) // url -> 1-based reference number
 	nextRef := 1
 
-	// Process anchor tags: extract href and link text, replace with placeholders.
-	// We process the HTML string by finding anchor open/close pairs.
-	result := processAnchors(htmlContent, &links, urlToRef, &nextRef)
+	// Process anchor tags: replace each with its text and a footnote
+	// reference, or just the text when it matches the URL.
+	result := processAnchors(htmlContent, func(linkText, href string) string {
+		// Links within the page, such as footnote markers, only make
+		// sense in the HTML
+		if strings.HasPrefix(href, "#") {
+			return linkText
+		}
+		if linkText == href || linkText == strings.TrimSuffix(href, "/") ||
+			strings.TrimSuffix(linkText, "/") == strings.TrimSuffix(href, "/") {
+			return linkText
+		}
+		// Assign or reuse reference number
+		refNum, exists := urlToRef[href]
+		if !exists {
+			refNum = nextRef
+			urlToRef[href] = refNum
+			nextRef++
+		}
+		links = append(links, linkRef{url: href, text: linkText})
+		return linkText + " [" + strconv.Itoa(refNum) + "]"
+	})
 
 	// Phase 2: Convert block-level tags to newlines for structure
 	result = hrTagRe.ReplaceAllString(result, "\n\n---\n\n")
@@ -74,17 +124,10 @@ func Convert(htmlContent string) string {
 	result = html.UnescapeString(result)
 
 	// Phase 5: Clean up whitespace
-	// Collapse multiple spaces (not newlines) to single space
-	result = multiSpaceRe.ReplaceAllString(result, " ")
-	// Clean up spaces around newlines
-	lines := strings.Split(result, "\n")
-	for i, line := range lines {
-		lines[i] = strings.TrimRight(line, " \t")
+	result = cleanWhitespace(result)
+	if opts.WrapWidth > 0 {
+		result = wrapText(result, opts.WrapWidth)
 	}
-	result = strings.Join(lines, "\n")
-	// Collapse 3+ newlines to 2
-	result = multiNewlineRe.ReplaceAllString(result, "\n\n")
-	result = strings.TrimSpace(result)
 
 	// Phase 6: Append references section if there are any links
 	if len(links) > 0 {
@@ -110,18 +153,31 @@ func Convert(htmlContent string) string {
 	return result
 }
 
+// cleanWhitespace collapses runs of spaces, trims trailing spaces from each
+// line, and collapses three or more newlines to a blank line.
+func cleanWhitespace(text string) string {
+	// Collapse multiple spaces (not newlines) to single space
+	text = multiSpaceRe.ReplaceAllString(text, " ")
+	// Clean up spaces around newlines
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " \t")
+	}
+	text = strings.Join(lines, "\n")
+	// Collapse 3+ newlines to 2
+	text = multiNewlineRe.ReplaceAllString(text, "\n\n")
+	return strings.TrimSpace(text)
+}
+
 // processAnchors finds all <a href="...">text</a> pairs in the HTML and
-// replaces them with either "text [N]" (footnote reference) or just "text"
-// (when text matches URL). It populates the links slice and urlToRef map.
-func processAnchors(
-	htmlContent string,
-	links *[]linkRef,
-	urlToRef map[string]int,
-	nextRef *int,
-) string {
+// replaces each with format(text, href), called in document order. The text
+// has nested tags stripped and entities decoded; a link without text, such
+// as an icon link, gets its aria-label or title attribute instead.
+func processAnchors(htmlContent string, format func(linkText, href string) string) string {
 	type anchorSpan struct {
 		start    int // start of <a ...>
 		end      int // end of </a> (after >)
+		openTag  string
 		href     string
 		textHTML string // HTML between <a> and </a>
 	}
@@ -163,6 +219,7 @@ func processAnchors(
 		spans = append(spans, anchorSpan{
 			start:    openIdx[0],
 			end:      fullEnd,
+			openTag:  openTag,
 			href:     href,
 			textHTML: htmlContent[textStart:textEnd],
 		})
@@ -173,7 +230,7 @@ func processAnchors(
 		return htmlContent
 	}
 
-	// First pass (left-to-right): assign reference numbers and compute replacements
+	// First pass (left-to-right): format links in document order
 	type spanReplacement struct {
 		start       int
 		end         int
@@ -186,28 +243,14 @@ func processAnchors(
 		linkText := htmlTagRe.ReplaceAllString(span.textHTML, "")
 		linkText = html.UnescapeString(linkText)
 		linkText = strings.TrimSpace(linkText)
-
-		var replacement string
-		// If link text matches the URL, just show the URL inline (no footnote)
-		if linkText == span.href || linkText == strings.TrimSuffix(span.href, "/") ||
-			strings.TrimSuffix(linkText, "/") == strings.TrimSuffix(span.href, "/") {
-			replacement = linkText
-		} else {
-			// Assign or reuse reference number
-			refNum, exists := urlToRef[span.href]
-			if !exists {
-				refNum = *nextRef
-				urlToRef[span.href] = refNum
-				*nextRef++
-			}
-			*links = append(*links, linkRef{url: span.href, text: linkText})
-			replacement = linkText + " [" + strconv.Itoa(refNum) + "]"
+		if linkText == "" {
+			linkText = anchorLabel(span.openTag)
 		}
 
 		replacements[i] = spanReplacement{
 			start:       span.start,
 			end:         span.end,
-			replacement: replacement,
+			replacement: format(linkText, span.href),
 		}
 	}
 
@@ -221,6 +264,86 @@ func processAnchors(
 	return result
 }
 
+// anchorLabel returns the aria-label of an <a> tag, or its title when it
+// has no aria-label, with entities decoded.
+func anchorLabel(openTag string) string {
+	for _, re := range []*regexp.Regexp{ariaLabelAttrRe, titleAttrRe} {
+		m := re.FindStringSubmatch(openTag)
+		if m == nil {
+			continue
+		}
+		if label := strings.TrimSpace(html.UnescapeString(m[1] + m[2])); label != "" {
+			return label
+		}
+	}
+	return ""
+}
+
+// wrapText word-wraps each line of text to width runes. Continuation lines
+// keep the line's indentation, and list items ("- ") are indented to align
+// with the item text. Footnote markers stay attached to the preceding word.
+func wrapText(text string, width int) string {
+	lines := strings.Split(text, "\n")
+	wrapped := make([]string, 0, len(lines))
+	for _, line := range lines {
+		wrapped = append(wrapped, wrapLine(line, width)...)
+	}
+	return strings.Join(wrapped, "\n")
+}
+
+// wrapLine wraps a single line, splitting only on ASCII spaces so that
+// non-breaking spaces are preserved.
+func wrapLine(line string, width int) []string {
+	if utf8.RuneCountInString(line) <= width {
+		return []string{line}
+	}
+
+	body := strings.TrimLeft(line, " ")
+	indent := line[:len(line)-len(body)]
+	hanging := indent
+	if strings.HasPrefix(body, "- ") {
+		hanging += "  "
+	}
+
+	words := wrapWords(body)
+	if len(words) == 0 {
+		return []string{line}
+	}
+
+	var out []string
+	current := indent + words[0]
+	currentWidth := utf8.RuneCountInString(current)
+	for _, word := range words[1:] {
+		wordWidth := utf8.RuneCountInString(word)
+		if currentWidth+1+wordWidth > width {
+			out = append(out, current)
+			current = hanging + word
+			currentWidth = utf8.RuneCountInString(hanging) + wordWidth
+			continue
+		}
+		current += " " + word
+		currentWidth += 1 + wordWidth
+	}
+	return append(out, current)
+}
+
+// wrapWords splits text into wrappable units, joining each footnote marker
+// to the word before it so a line never starts with "[n]".
+func wrapWords(text string) []string {
+	var words []string
+	for _, word := range strings.Split(text, " ") {
+		if word == "" {
+			continue
+		}
+		if len(words) > 0 && refMarkerRe.MatchString(word) {
+			words[len(words)-1] += " " + word
+			continue
+		}
+		words = append(words, word)
+	}
+	return words
+}
+
 // linkRef holds a link's URL and visible text for footnote generation.
 type linkRef struct {
 	url  string
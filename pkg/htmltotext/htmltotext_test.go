@@ -183,6 +183,49 @@ func TestConvert_EntitiesInLinkText(t *testing.T) {
 	}
 }
 
+func TestConvert_InternalAnchors(t *testing.T) {
+	input := `<p>Go is fast<sup id="fnref1"><a href="#fn1">1</a></sup>. See <a href="https://go.dev">Go</a>.</p>` +
+		`<ol><li id="fn1">Mostly. <a href="#fnref1">↩</a></li></ol>`
+	want := "Go is fast1. See Go [1].\n\n- Mostly. ↩\n\nReferences:\n[1]: https://go.dev"
+	got := Convert(input)
+	if got != want {
+		t.Errorf("Convert(%q) =\n%s\nwant:\n%s", input, got, want)
+	}
+}
+
+func TestConvert_LinkLabelFallback(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{
+			"aria-label",
+			`<a href="https://github.com/x" aria-label="GitHub &amp; more"><svg><path d="M0"/></svg></a>`,
+			"GitHub & more [1]\n\nReferences:\n[1]: https://github.com/x",
+		},
+		{
+			"title",
+			`<a href="https://example.com/feed.xml" title='RSS feed'><i class="icon"></i></a>`,
+			"RSS feed [1]\n\nReferences:\n[1]: https://example.com/feed.xml",
+		},
+		{
+			"text wins over label",
+			`<a href="https://go.dev" aria-label="Go website">Go</a>`,
+			"Go [1]\n\nReferences:\n[1]: https://go.dev",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Convert(tt.input)
+			if got != tt.want {
+				t.Errorf("Convert(%q) =\n%s\nwant:\n%s", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
 func TestConvert_ComplexDocument(t *testing.T) {
 	input := `<h1>My Post</h1>
 <p>This is about <a href="https://go.dev">Go</a> and &amp; more.</p>
@@ -317,3 +360,68 @@ func TestConvert_NoRawHTMLTags(t *testing.T) {
 		}
 	}
 }
+
+func TestConvertWith_WrapWidth(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		width int
+		want  string
+	}{
+		{
+			"zero width leaves lines unwrapped",
+			"<p>The quick brown fox jumps over the lazy dog.</p>",
+			0,
+			"The quick brown fox jumps over the lazy dog.",
+		},
+		{
+			"wraps paragraph text",
+			"<p>The quick brown fox jumps over the lazy dog.</p><p>Short.</p>",
+			16,
+			"The quick brown\nfox jumps over\nthe lazy dog.\n\nShort.",
+		},
+		{
+			"multi-byte runes measured by rune count",
+			"<p>héllo wörld ünïcode</p>",
+			11,
+			"héllo wörld\nünïcode",
+		},
+		{
+			"long words are not split",
+			"<p>see https://example.com/a/very/long/path now</p>",
+			10,
+			"see\nhttps://example.com/a/very/long/path\nnow",
+		},
+		{
+			"list items use a hanging indent",
+			"<ul><li>alpha beta gamma delta</li></ul>",
+			12,
+			"- alpha beta\n  gamma\n  delta",
+		},
+		{
+			"non-breaking spaces are kept",
+			"<p>one two&nbsp;three</p>",
+			8,
+			"one\ntwo three",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ConvertWith(tt.input, Options{WrapWidth: tt.width})
+			if got != tt.want {
+				t.Errorf("ConvertWith(%q, %d) =\n%q\nwant:\n%q", tt.input, tt.width, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConvertWith_WrapKeepsReferences(t *testing.T) {
+	input := `<p>Read the <a href="https://go.dev/doc/effective_go">guide</a> before writing code.</p>`
+	got := ConvertWith(input, Options{WrapWidth: 14})
+	want := "Read the\nguide [1]\nbefore writing\ncode.\n\n" +
+		"References:\n[1]: https://go.dev/doc/effective_go"
+	if got != want {
+		t.Errorf("ConvertWith() =\n%q\nwant:\n%q", got, want)
+	}
+}
@@ -0,0 +1,260 @@
+package htmltotext
+
+import (
+	"html"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Placeholder runes used while converting to Markdown. They survive tag
+// stripping and whitespace cleanup and are resolved at the end.
+const (
+	// mdIndent marks one column of list indentation.
+	mdIndent = "\x01"
+	// mdQuoteOpen and mdQuoteClose delimit blockquote content.
+	mdQuoteOpen  = "\x02"
+	mdQuoteClose = "\x03"
+	// mdBlock surrounds the index of an extracted code block.
+	mdBlock = "\x00"
+)
+
+// Pre-compiled regex patterns for Markdown conversion.
+var (
+	// Matches <pre> blocks, capturing the tag's attributes and content.
+	preBlockRe = regexp.MustCompile(`(?is)<pre\b([^>]*)>(.*?)</pre\s*>`)
+
+	// Matches a <code> tag opening a <pre> block, capturing its attributes.
+	preCodeOpenRe = regexp.MustCompile(`(?i)^\s*<code\b([^>]*)>`)
+
+	// Matches a language-x class, capturing the language.
+	languageClassRe = regexp.MustCompile(`\blanguage-([\w+#.-]+)`)
+
+	// Matches inline <code> elements, capturing the content.
+	inlineCodeRe = regexp.MustCompile(`(?is)<code\b[^>]*>(.*?)</code\s*>`)
+
+	// Matches <img> tags.
+	imgTagRe = regexp.MustCompile(`(?i)<img\b[^>]*>`)
+
+	// Matches a quoted src or alt attribute, capturing its name and value.
+	imgAttrRe = regexp.MustCompile(`(?i)\b(src|alt)\s*=\s*(?:"([^"]*)"|'([^']*)')`)
+
+	// Match opening and closing emphasis tags.
+	strongTagRe = regexp.MustCompile(`(?i)</?(?:strong|b)(?:\s[^>]*)?>`)
+	emTagRe     = regexp.MustCompile(`(?i)</?(?:em|i)(?:\s[^>]*)?>`)
+	delTagRe    = regexp.MustCompile(`(?i)</?(?:del|s|strike)(?:\s[^>]*)?>`)
+
+	// Matches heading opening tags, capturing the level.
+	headingOpenRe = regexp.MustCompile(`(?i)<h([1-6])\b[^>]*>`)
+
+	// Matches list tags, capturing the closing slash and tag name.
+	listTagRe = regexp.MustCompile(`(?i)<(/?)(ul|ol|li)\b[^>]*>`)
+
+	// Matches whitespace before a list tag, which would otherwise separate
+	// items with blank lines.
+	listGapRe = regexp.MustCompile(`(?i)\s+(</?(?:ul|ol|li)\b)`)
+
+	// Match blockquote tags.
+	blockquoteOpenRe  = regexp.MustCompile(`(?i)<blockquote\b[^>]*>`)
+	blockquoteCloseRe = regexp.MustCompile(`(?i)</blockquote\s*>`)
+
+	// Matches an innermost blockquote, capturing its content.
+	quoteSpanRe = regexp.MustCompile(`(?s)` + mdQuoteOpen + `([^` + mdQuoteOpen + mdQuoteClose + `]*)` + mdQuoteClose)
+
+	// Matches a code block placeholder, capturing its index.
+	blockPlaceholderRe = regexp.MustCompile(mdBlock + `(\d+)` + mdBlock)
+)
+
+// ConvertToMarkdown transforms HTML content into Markdown. It walks the HTML
+// like Convert but writes **bold**, _italic_, "## " headings, inline
+// [text](url) links instead of footnotes, ![alt](src) images, list markers,
+// "> " blockquotes, and fenced code blocks that keep the language from a
+// class="language-x" on the <pre> or <code> tag.
+func ConvertToMarkdown(htmlContent string) string {
+	return ConvertWith(htmlContent, Options{Markdown: true})
+}
+
+// convertMarkdown implements ConvertWith's Markdown mode.
+func convertMarkdown(htmlContent string) string {
+	// Phase 1: Pull code blocks out so their whitespace is kept verbatim
+	var blocks []string
+	result := preBlockRe.ReplaceAllStringFunc(htmlContent, func(match string) string {
+		parts := preBlockRe.FindStringSubmatch(match)
+		blocks = append(blocks, fencedCodeBlock(parts[1], parts[2]))
+		return "\n\n" + mdBlock + strconv.Itoa(len(blocks)-1) + mdBlock + "\n\n"
+	})
+
+	// Phase 2: Inline elements, before links so link text keeps them
+	result = inlineCodeRe.ReplaceAllStringFunc(result, func(match string) string {
+		code := inlineCodeRe.FindStringSubmatch(match)[1]
+		code = html.UnescapeString(htmlTagRe.ReplaceAllString(code, ""))
+		// Re-escape so the tag stripping below leaves the code alone.
+		return inlineCode(html.EscapeString(code))
+	})
+	result = imgTagRe.ReplaceAllStringFunc(result, markdownImage)
+	result = strongTagRe.ReplaceAllString(result, "**")
+	result = emTagRe.ReplaceAllString(result, "_")
+	result = delTagRe.ReplaceAllString(result, "~~")
+	result = processAnchors(result, func(linkText, href string) string {
+		// Entities are decoded with the rest of the text in phase 4.
+		if linkText == html.UnescapeString(href) || linkText == "" {
+			return "&lt;" + href + "&gt;"
+		}
+		return "[" + html.EscapeString(linkText) + "](" + href + ")"
+	})
+
+	// Phase 3: Block structure
+	result = headingOpenRe.ReplaceAllStringFunc(result, func(match string) string {
+		level := int(headingOpenRe.FindStringSubmatch(match)[1][0] - '0')
+		return "\n\n" + strings.Repeat("#", level) + " "
+	})
+	result = headingCloseRe.ReplaceAllString(result, "\n\n")
+	result = markdownLists(listGapRe.ReplaceAllString(result, "$1"))
+	result = blockquoteOpenRe.ReplaceAllString(result, "\n\n"+mdQuoteOpen)
+	result = blockquoteCloseRe.ReplaceAllString(result, mdQuoteClose+"\n\n")
+	result = hrTagRe.ReplaceAllString(result, "\n\n---\n\n")
+	result = brTagRe.ReplaceAllString(result, "\n")
+	result = blockCloseRe.ReplaceAllString(result, "\n\n")
+
+	// Phase 4: Strip remaining tags and decode entities
+	result = htmlTagRe.ReplaceAllString(result, "")
+	result = html.UnescapeString(result)
+
+	// Phase 5: Clean up whitespace, then resolve placeholders
+	result = cleanWhitespace(result)
+	lines := strings.Split(result, "\n")
+	for i, line := range lines {
+		lines[i] = strings.ReplaceAll(strings.TrimLeft(line, " \t"), mdIndent, " ")
+	}
+	result = strings.Join(lines, "\n")
+	result = blockPlaceholderRe.ReplaceAllStringFunc(result, func(match string) string {
+		i, err := strconv.Atoi(blockPlaceholderRe.FindStringSubmatch(match)[1])
+		if err != nil || i >= len(blocks) {
+			return ""
+		}
+		return blocks[i]
+	})
+	for quoteSpanRe.MatchString(result) {
+		result = quoteSpanRe.ReplaceAllStringFunc(result, func(match string) string {
+			return quoteLines(quoteSpanRe.FindStringSubmatch(match)[1])
+		})
+	}
+
+	result = multiNewlineRe.ReplaceAllString(result, "\n\n")
+	return strings.TrimSpace(result)
+}
+
+// fencedCodeBlock renders a <pre> element's attributes and inner HTML as a
+// fenced code block, taking the language from a language-x class.
+func fencedCodeBlock(preAttrs, inner string) string {
+	language := ""
+	if m := languageClassRe.FindStringSubmatch(preAttrs); m != nil {
+		language = m[1]
+	}
+	if m := preCodeOpenRe.FindStringSubmatch(inner); m != nil && language == "" {
+		if lang := languageClassRe.FindStringSubmatch(m[1]); lang != nil {
+			language = lang[1]
+		}
+	}
+
+	code := html.UnescapeString(htmlTagRe.ReplaceAllString(inner, ""))
+	code = strings.TrimRight(strings.TrimLeft(code, "\n"), "\n ")
+
+	fence := "```"
+	for strings.Contains(code, fence) {
+		fence += "`"
+	}
+	return fence + language + "\n" + code + "\n" + fence
+}
+
+// inlineCode wraps code in enough backticks that backticks inside it do not
+// end the span.
+func inlineCode(code string) string {
+	fence := "`"
+	for strings.Contains(code, fence) {
+		fence += "`"
+	}
+	if strings.HasPrefix(code, "`") || strings.HasSuffix(code, "`") {
+		return fence + " " + code + " " + fence
+	}
+	return fence + code + fence
+}
+
+// markdownImage renders an <img> tag as ![alt](src), or nothing without a src.
+func markdownImage(tag string) string {
+	var src, alt string
+	for _, attr := range imgAttrRe.FindAllStringSubmatch(tag, -1) {
+		value := attr[2] + attr[3]
+		if strings.EqualFold(attr[1], "src") {
+			src = value
+		} else {
+			alt = value
+		}
+	}
+	if src == "" {
+		return ""
+	}
+	return "![" + alt + "](" + src + ")"
+}
+
+// markdownLists replaces list tags with "- " and "1. " item markers. Nested
+// items are indented under their parent item's text.
+func markdownLists(text string) string {
+	type list struct {
+		ordered bool
+		count   int
+	}
+	var stack []list
+
+	return listTagRe.ReplaceAllStringFunc(text, func(tag string) string {
+		m := listTagRe.FindStringSubmatch(tag)
+		closing, name := m[1] == "/", strings.ToLower(m[2])
+
+		switch {
+		case name == "li" && closing:
+			return ""
+		case name == "li":
+			marker := "- "
+			if len(stack) > 0 && stack[len(stack)-1].ordered {
+				stack[len(stack)-1].count++
+				marker = strconv.Itoa(stack[len(stack)-1].count) + ". "
+			}
+			indent := 0
+			for _, parent := range stack[:max(len(stack)-1, 0)] {
+				if parent.ordered {
+					indent += len(strconv.Itoa(parent.count)) + 2
+				} else {
+					indent += 2
+				}
+			}
+			return "\n" + strings.Repeat(mdIndent, indent) + marker
+		case closing:
+			if len(stack) > 0 {
+				stack = stack[:len(stack)-1]
+			}
+			if len(stack) == 0 {
+				return "\n\n"
+			}
+			return ""
+		default:
+			stack = append(stack, list{ordered: name == "ol"})
+			if len(stack) == 1 {
+				return "\n\n"
+			}
+			return ""
+		}
+	})
+}
+
+// quoteLines prefixes each line of text with "> ".
+func quoteLines(text string) string {
+	lines := strings.Split(strings.Trim(text, "\n"), "\n")
+	for i, line := range lines {
+		if line == "" {
+			lines[i] = ">"
+		} else {
+			lines[i] = "> " + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,110 @@
+package htmltotext
+
+import "testing"
+
+func TestConvertToMarkdown(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"empty", "", ""},
+		{
+			name:  "headings",
+			input: `<h1 id="intro">Intro</h1><p>Text</p><h3>Details</h3>`,
+			want:  "# Intro\n\nText\n\n### Details",
+		},
+		{
+			name:  "emphasis",
+			input: `<p><strong>bold</strong>, <b>b</b>, <em>italic</em>, <i>i</i>, <del>gone</del></p>`,
+			want:  "**bold**, **b**, _italic_, _i_, ~~gone~~",
+		},
+		{
+			name:  "inline link",
+			input: `<p>Read <a href="https://go.dev/doc">the <em>docs</em></a> now.</p>`,
+			want:  "Read [the _docs_](https://go.dev/doc) now.",
+		},
+		{
+			name:  "bare link",
+			input: `<p>Visit <a href="https://go.dev">https://go.dev</a></p>`,
+			want:  "Visit <https://go.dev>",
+		},
+		{
+			name:  "icon link label",
+			input: `<a href="https://github.com/x" aria-label="GitHub"><svg></svg></a>`,
+			want:  "[GitHub](https://github.com/x)",
+		},
+		{
+			name:  "link entities",
+			input: `<a href="/search?q=go&amp;page=2">Tom &amp; Jerry</a>`,
+			want:  "[Tom & Jerry](/search?q=go&page=2)",
+		},
+		{
+			name:  "image",
+			input: `<p><img src="/img/cat.png" alt="A cat"></p>`,
+			want:  "![A cat](/img/cat.png)",
+		},
+		{
+			name:  "inline code",
+			input: "<p>Use <code>a &lt; b</code> or <code>x`y</code>.</p>",
+			want:  "Use `a < b` or ``x`y``.",
+		},
+		{
+			name: "code block with language",
+			input: `<pre class="chroma"><code class="language-go" data-lang="go"><span class="kd">func</span> main() {
+    fmt.Println(&#34;&lt;hi&gt;&#34;)
+}
+</code></pre>`,
+			want: "```go\nfunc main() {\n    fmt.Println(\"<hi>\")\n}\n```",
+		},
+		{
+			name:  "code block language on pre",
+			input: `<pre class="language-sh"><code>echo  hi</code></pre>`,
+			want:  "```sh\necho  hi\n```",
+		},
+		{
+			name:  "code block without language",
+			input: "<pre><code>```\nnested\n```</code></pre>",
+			want:  "````\n```\nnested\n```\n````",
+		},
+		{
+			name: "unordered list",
+			input: `<ul>
+  <li>one</li>
+  <li>two</li>
+</ul>`,
+			want: "- one\n- two",
+		},
+		{
+			name:  "nested ordered list",
+			input: `<ol><li>first<ul><li>sub</li></ul></li><li>second</li></ol><p>After</p>`,
+			want:  "1. first\n   - sub\n2. second\n\nAfter",
+		},
+		{
+			name:  "blockquote",
+			input: `<blockquote><p>quoted</p><blockquote><p>inner</p></blockquote></blockquote>`,
+			want:  "> quoted\n>\n> > inner",
+		},
+		{
+			name:  "horizontal rule",
+			input: `<p>Above</p><hr><p>Below</p>`,
+			want:  "Above\n\n---\n\nBelow",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ConvertToMarkdown(tt.input); got != tt.want {
+				t.Errorf("ConvertToMarkdown(%q)\n got: %q\nwant: %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConvertWith_MarkdownIgnoresWrap(t *testing.T) {
+	input := `<h2>A heading long enough to wrap at twenty columns</h2>`
+	want := "## A heading long enough to wrap at twenty columns"
+	if got := ConvertWith(input, Options{Markdown: true, WrapWidth: 20}); got != want {
+		t.Errorf("ConvertWith() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,66 @@
+package lifecycle
+
+import "path/filepath"
+
+// DefaultBuildCacheDir is the build cache directory name, relative to the
+// content directory.
+const DefaultBuildCacheDir = ".markata"
+
+// SetBuildCacheEnabled turns the persistent build cache on or off for this
+// Manager, overriding the build_cache.enabled config setting. With the cache
+// off every post is rendered from scratch and the cache is neither read nor
+// saved.
+func (m *Manager) SetBuildCacheEnabled(enabled bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.buildCacheEnabled = &enabled
+}
+
+// BuildCacheEnabled reports whether the persistent build cache is used. It
+// returns the value set with SetBuildCacheEnabled, else build_cache.enabled
+// from the config, else true.
+func (m *Manager) BuildCacheEnabled() bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if m.buildCacheEnabled != nil {
+		return *m.buildCacheEnabled
+	}
+	if m.config == nil || m.config.Extra == nil {
+		return true
+	}
+	if cacheConfig, ok := m.config.Extra["build_cache"].(map[string]interface{}); ok {
+		if enabled, ok := cacheConfig["enabled"].(bool); ok {
+			return enabled
+		}
+	}
+	return true
+}
+
+// SetBuildCacheDir sets the directory the build cache is stored in,
+// overriding the cache_dir config setting. An empty dir restores the default.
+func (m *Manager) SetBuildCacheDir(dir string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.buildCacheDir = dir
+}
+
+// BuildCacheDir returns the build cache directory: the one set with
+// SetBuildCacheDir, else cache_dir from the config, else DefaultBuildCacheDir
+// inside the content directory, so incremental build state survives when
+// output is redirected to a temporary path.
+func (m *Manager) BuildCacheDir() string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if m.buildCacheDir != "" {
+		return m.buildCacheDir
+	}
+	if m.config == nil {
+		return DefaultBuildCacheDir
+	}
+	if dir, ok := m.config.Extra["cache_dir"].(string); ok && dir != "" {
+		return dir
+	}
+	return filepath.Join(m.config.ContentDir, DefaultBuildCacheDir)
+}
@@ -1,6 +1,6 @@
 // Package lifecycle provides the lifecycle management system for markata-go.
 //
-// The lifecycle system orchestrates the build process through 9 stages:
+// The lifecycle system orchestrates the build process through 10 stages:
 //
 //   - configure: Load configuration and initialize plugins
 //   - validate: Validate configuration before processing
@@ -10,6 +10,7 @@
 //   - render: Convert markdown to HTML
 //   - collect: Build feeds, navigation, and aggregated content
 //   - write: Write output files
+//   - verify: Check the written output
 //   - cleanup: Release resources
 //
 // # Plugin System
@@ -41,6 +42,70 @@
 //	    return PriorityDefault
 //	}
 //
+// # Plugin Dependencies
+//
+// Plugins that must run after specific other plugins can name them with
+// DependsOn instead of guessing priority numbers:
+//
+//	func (p *MyPlugin) DependsOn(stage Stage) []string {
+//	    if stage == StageCollect {
+//	        return []string{"tag_aggregator"}
+//	    }
+//	    return nil
+//	}
+//
+// Within each stage the Manager runs plugins in dependency order, using
+// priority and then registration order to break ties. A dependency cycle
+// fails the stage with a DependencyCycleError naming the plugins involved.
+//
+// # Running a Subset of Plugins
+//
+// RunOnly runs every stage but only the hooks of the named plugins, which
+// helps when debugging one plugin without editing DisabledHooks in the config:
+//
+//	err := m.RunOnly([]string{"render_markdown", "templates"})
+//
+// SetOnlyPlugins applies the same restriction to later RunTo calls. A kept
+// plugin whose DependsOn names a skipped plugin gets a warning.
+//
+// # Build Cache
+//
+// The build_cache plugin keeps rendered HTML and other per-post results in
+// BuildCacheDir so unchanged posts are not processed again. The Manager
+// options override the config for one run:
+//
+//	m.SetBuildCacheDir("/tmp/site-cache")
+//	m.SetBuildCacheEnabled(false) // render everything from scratch
+//
+// # Observing a Build
+//
+// SetObserver registers an Observer that is told when stages and plugin
+// hooks start and finish, with the same timings Profile reports. The
+// services package uses it to stream build events to a TUI or dashboard.
+//
+// # Logging
+//
+// Plugins log through m.Logger() rather than the standard logger. Messages
+// have a level and key/value fields, and the "stage" and "plugin" fields
+// are added for the hook that is running:
+//
+//	m.Logger().Warn("failed to download asset", "asset", name, "error", err)
+//
+// SetLogger swaps the default text logger for NewJSONLogger (one JSON
+// object per line, for CI), a text logger at another level, or NopLogger
+// in tests.
+//
+// # Build Manifest
+//
+// When enabled with [markata-go.build_manifest] enabled = true, the
+// build_manifest plugin records every output file at the end of cleanup
+// with its size, SHA-256 hash, and source post or feed in manifest.json.
+// The same entries are available after a run:
+//
+//	for _, e := range m.Manifest() {
+//	    fmt.Println(e.Path, e.Hash, e.Post)
+//	}
+//
 // # Usage
 //
 // Basic usage:
@@ -66,6 +131,54 @@
 //	    log.Fatal(err)
 //	}
 //
+// Rebuilding after source files change (e.g. in watch mode):
+//
+//	// Reprocess only the changed posts and the posts that link to them
+//	if err := m.RunIncremental([]string{"posts/hello.md"}); err != nil {
+//	    log.Fatal(err)
+//	}
+//
+// Rebuilding whenever sources change, until ctx is canceled:
+//
+//	w := lifecycle.NewWatcher(m, lifecycle.WatchOptions{
+//	    OnRebuild: func(change lifecycle.WatchChange, err error) {
+//	        if err != nil {
+//	            log.Printf("rebuild failed: %v", err)
+//	        }
+//	    },
+//	})
+//	if err := w.Run(ctx); err != nil {
+//	    log.Fatal(err)
+//	}
+//
+// Previewing which output files a build would touch:
+//
+//	plan, err := m.DryRun()
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	for _, w := range plan.Writes() {
+//	    fmt.Println(w.Action, w.Path)
+//	}
+//
+// Canceling a build and stopping at the first failing post:
+//
+//	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+//	defer cancel()
+//	m.SetFailFast(true) // default collects every post error into *PostErrors
+//	if err := m.RunContext(ctx); err != nil {
+//	    log.Fatal(err)
+//	}
+//
+// Inspecting where build time went:
+//
+//	for _, st := range m.Profile() {
+//	    fmt.Printf("%s wall=%s cpu=%s\n", st.Stage, st.Wall, st.CPU)
+//	    for _, pt := range st.Plugins {
+//	        fmt.Printf("  %s wall=%s cpu=%s\n", pt.Plugin, pt.Wall, pt.CPU)
+//	    }
+//	}
+//
 // Filtering and mapping posts:
 //
 //	// Get published posts
@@ -73,4 +186,10 @@
 //
 //	// Get all titles of posts with tag "golang", sorted by date
 //	titles, _ := m.Map("title", "tags contains golang", "date", true)
+//
+//	// Sort by several keys: series, then newest first within a series
+//	titles, _ = m.MapSorted("title", "", []lifecycle.SortSpec{
+//	    {Field: "series"},
+//	    {Field: "date", Descending: true},
+//	})
 package lifecycle
@@ -0,0 +1,159 @@
+package lifecycle
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"sort"
+	"sync"
+)
+
+// WriteAction describes what a build would do to an output file.
+type WriteAction string
+
+const (
+	// WriteActionCreate means the file does not exist yet.
+	WriteActionCreate WriteAction = "create"
+
+	// WriteActionOverwrite means the file exists with different content.
+	WriteActionOverwrite WriteAction = "overwrite"
+
+	// WriteActionUnchanged means the file exists with identical content.
+	WriteActionUnchanged WriteAction = "unchanged"
+
+	// WriteActionDelete means the file would be removed.
+	WriteActionDelete WriteAction = "delete"
+
+	// WriteActionUnknown marks a write plugin that cannot report its writes.
+	WriteActionUnknown WriteAction = "unknown"
+)
+
+// PlannedWrite is a single entry in a WritePlan.
+type PlannedWrite struct {
+	// Path is the output file path. It is empty for WriteActionUnknown.
+	Path string
+
+	// Action is what the build would do to Path.
+	Action WriteAction
+
+	// Plugin is the name of the write plugin that reported the entry.
+	Plugin string
+}
+
+// WritePlan lists the output files a build would touch. It is produced by
+// Manager.DryRun and filled in by plugins implementing WritePlanner.
+// It is safe for concurrent use.
+type WritePlan struct {
+	mu     sync.Mutex
+	writes []PlannedWrite
+	plugin string
+}
+
+// Add records that path would be written with content, classifying the
+// write by comparing content with what is currently on disk.
+func (p *WritePlan) Add(path string, content []byte) {
+	action := WriteActionCreate
+	existing, err := os.ReadFile(path)
+	switch {
+	case err == nil && bytes.Equal(existing, content):
+		action = WriteActionUnchanged
+	case err == nil:
+		action = WriteActionOverwrite
+	case !errors.Is(err, fs.ErrNotExist):
+		// Unreadable files would still be replaced by the build.
+		action = WriteActionOverwrite
+	}
+	p.add(path, action)
+}
+
+// Remove records that path would be deleted. Paths that do not exist are ignored.
+func (p *WritePlan) Remove(path string) {
+	if _, err := os.Stat(path); err != nil {
+		return
+	}
+	p.add(path, WriteActionDelete)
+}
+
+func (p *WritePlan) add(path string, action WriteAction) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.writes = append(p.writes, PlannedWrite{Path: path, Action: action, Plugin: p.plugin})
+}
+
+// Writes returns the planned writes sorted by path. Entries for plugins that
+// could not plan their writes have an empty path and sort first.
+func (p *WritePlan) Writes() []PlannedWrite {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	result := make([]PlannedWrite, len(p.writes))
+	copy(result, p.writes)
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Path < result[j].Path
+	})
+	return result
+}
+
+// Count returns the number of planned writes with the given action.
+func (p *WritePlan) Count(action WriteAction) int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	n := 0
+	for _, w := range p.writes {
+		if w.Action == action {
+			n++
+		}
+	}
+	return n
+}
+
+// WritePlanner is implemented by write-stage plugins that can describe the
+// files they would write without touching disk. Write plugins that do not
+// implement it appear in a WritePlan with WriteActionUnknown.
+type WritePlanner interface {
+	WritePlugin
+	// PlanWrites reports every file Write would create, overwrite, or delete
+	// by calling plan.Add and plan.Remove. It must not modify the output directory.
+	PlanWrites(m *Manager, plan *WritePlan) error
+}
+
+// DryRun runs all stages up through collect, then asks each write plugin to
+// plan its writes instead of performing them. The write and cleanup stages
+// are not run, so the returned plan reflects the current output directory.
+func (m *Manager) DryRun() (*WritePlan, error) {
+	if err := m.RunTo(StageCollect); err != nil {
+		return nil, err
+	}
+
+	m.mu.Lock()
+	m.currentStage = StageWrite
+	m.mu.Unlock()
+
+	sorted, err := sortPlugins(m.Plugins(), StageWrite)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := &WritePlan{}
+	for _, p := range sorted {
+		if _, ok := p.(WritePlugin); !ok {
+			continue
+		}
+
+		plan.mu.Lock()
+		plan.plugin = p.Name()
+		plan.mu.Unlock()
+
+		planner, ok := p.(WritePlanner)
+		if !ok {
+			plan.add("", WriteActionUnknown)
+			continue
+		}
+		if err := planner.PlanWrites(m, plan); err != nil {
+			return nil, fmt.Errorf("planning writes for %s: %w", p.Name(), err)
+		}
+	}
+
+	return plan, nil
+}
@@ -0,0 +1,104 @@
+package lifecycle
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// plannedWriter writes fixed files and can plan them.
+type plannedWriter struct {
+	files   map[string]string
+	removes []string
+	wrote   bool
+}
+
+func (p *plannedWriter) Name() string { return "planned" }
+
+func (p *plannedWriter) Write(_ *Manager) error {
+	p.wrote = true
+	return nil
+}
+
+func (p *plannedWriter) PlanWrites(_ *Manager, plan *WritePlan) error {
+	for path, content := range p.files {
+		plan.Add(path, []byte(content))
+	}
+	for _, path := range p.removes {
+		plan.Remove(path)
+	}
+	return nil
+}
+
+// opaqueWriter writes files but cannot plan them.
+type opaqueWriter struct{}
+
+func (p *opaqueWriter) Name() string { return "opaque" }
+
+func (p *opaqueWriter) Write(_ *Manager) error { return nil }
+
+func TestManagerDryRun(t *testing.T) {
+	dir := t.TempDir()
+	same := filepath.Join(dir, "same.html")
+	changed := filepath.Join(dir, "changed.html")
+	stale := filepath.Join(dir, "stale.html")
+	for path, content := range map[string]string{same: "same", changed: "old", stale: "stale"} {
+		//nolint:gosec // Test file permissions are fine at 0644
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatalf("failed to write %s: %v", path, err)
+		}
+	}
+	created := filepath.Join(dir, "new.html")
+
+	writer := &plannedWriter{
+		files:   map[string]string{same: "same", changed: "new", created: "fresh"},
+		removes: []string{stale, filepath.Join(dir, "missing.html")},
+	}
+	m := NewManager()
+	m.RegisterPlugins(writer, &opaqueWriter{})
+
+	plan, err := m.DryRun()
+	if err != nil {
+		t.Fatalf("DryRun() error = %v", err)
+	}
+
+	if writer.wrote {
+		t.Error("DryRun() ran the write stage")
+	}
+	if m.HasRun(StageWrite) {
+		t.Error("DryRun() marked the write stage as run")
+	}
+	if !m.HasRun(StageCollect) {
+		t.Error("DryRun() did not run the collect stage")
+	}
+	if _, err := os.Stat(created); !os.IsNotExist(err) {
+		t.Errorf("DryRun() created %s", created)
+	}
+
+	want := map[string]WriteAction{
+		"":      WriteActionUnknown,
+		same:    WriteActionUnchanged,
+		changed: WriteActionOverwrite,
+		created: WriteActionCreate,
+		stale:   WriteActionDelete,
+	}
+	writes := plan.Writes()
+	if len(writes) != len(want) {
+		t.Fatalf("Writes() = %+v, want %d entries", writes, len(want))
+	}
+	for _, w := range writes {
+		if w.Action != want[w.Path] {
+			t.Errorf("%q action = %s, want %s", w.Path, w.Action, want[w.Path])
+		}
+		wantPlugin := "planned"
+		if w.Path == "" {
+			wantPlugin = "opaque"
+		}
+		if w.Plugin != wantPlugin {
+			t.Errorf("%q plugin = %s, want %s", w.Path, w.Plugin, wantPlugin)
+		}
+	}
+	if got := plan.Count(WriteActionCreate); got != 1 {
+		t.Errorf("Count(create) = %d, want 1", got)
+	}
+}
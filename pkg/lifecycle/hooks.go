@@ -4,10 +4,10 @@ import (
 	"errors"
 	"fmt"
 	"sort"
+	"strings"
 	"time"
 
 	"github.com/WaylonWalker/markata-go/pkg/buildstats"
-	"github.com/WaylonWalker/markata-go/pkg/logging"
 	"github.com/WaylonWalker/markata-go/pkg/models"
 )
 
@@ -103,6 +103,133 @@ func sortPluginsByPriority(plugins []Plugin, stage Stage) []Plugin {
 	return sorted
 }
 
+// DependencyCycleError is returned when plugins declare dependencies on each
+// other that cannot be satisfied within a stage.
+type DependencyCycleError struct {
+	Stage Stage
+	// Cycle lists the plugins involved; each plugin depends on the next, and
+	// the last repeats the first.
+	Cycle []string
+}
+
+func (e *DependencyCycleError) Error() string {
+	return fmt.Sprintf("plugin dependency cycle in %s stage: %s", e.Stage, strings.Join(e.Cycle, " -> "))
+}
+
+// sortPlugins returns plugins in execution order for the given stage.
+// Plugins run after every plugin named by their DependsOn; among plugins whose
+// dependencies are satisfied, lower priority runs first, then registration order.
+// Without any DependencyPlugin this is identical to sortPluginsByPriority.
+func sortPlugins(plugins []Plugin, stage Stage) ([]Plugin, error) {
+	hasDeps := false
+	for _, p := range plugins {
+		if _, ok := p.(DependencyPlugin); ok {
+			hasDeps = true
+			break
+		}
+	}
+	if !hasDeps {
+		return sortPluginsByPriority(plugins, stage), nil
+	}
+
+	n := len(plugins)
+	byName := make(map[string]int, n)
+	priorities := make([]int, n)
+	for i, p := range plugins {
+		if _, exists := byName[p.Name()]; !exists {
+			byName[p.Name()] = i
+		}
+		priorities[i] = PriorityDefault
+		if pp, ok := p.(PriorityPlugin); ok {
+			priorities[i] = pp.Priority(stage)
+		}
+	}
+
+	// deps[i] holds the plugins i must follow; dependents is the reverse.
+	deps := make([][]int, n)
+	dependents := make([][]int, n)
+	pending := make([]int, n)
+	for i, p := range plugins {
+		dp, ok := p.(DependencyPlugin)
+		if !ok {
+			continue
+		}
+		seen := make(map[int]bool)
+		for _, name := range dp.DependsOn(stage) {
+			j, exists := byName[name]
+			if !exists || seen[j] {
+				continue
+			}
+			seen[j] = true
+			deps[i] = append(deps[i], j)
+			dependents[j] = append(dependents[j], i)
+			pending[i]++
+		}
+	}
+
+	sorted := make([]Plugin, 0, n)
+	done := make([]bool, n)
+	for len(sorted) < n {
+		next := -1
+		for i := 0; i < n; i++ {
+			if done[i] || pending[i] > 0 {
+				continue
+			}
+			if next == -1 || priorities[i] < priorities[next] {
+				next = i
+			}
+		}
+		if next == -1 {
+			return nil, &DependencyCycleError{Stage: stage, Cycle: findDependencyCycle(plugins, deps, done)}
+		}
+
+		done[next] = true
+		sorted = append(sorted, plugins[next])
+		for _, d := range dependents[next] {
+			pending[d]--
+		}
+	}
+
+	return sorted, nil
+}
+
+// findDependencyCycle walks unsatisfied dependencies from the first plugin
+// that could not be scheduled until a plugin repeats, and returns that loop.
+func findDependencyCycle(plugins []Plugin, deps [][]int, done []bool) []string {
+	start := -1
+	for i := range plugins {
+		if !done[i] {
+			start = i
+			break
+		}
+	}
+	if start == -1 {
+		return nil
+	}
+
+	position := make(map[int]int)
+	path := make([]int, 0)
+	for current := start; ; {
+		if at, seen := position[current]; seen {
+			names := make([]string, 0, len(path)-at+1)
+			for _, idx := range path[at:] {
+				names = append(names, plugins[idx].Name())
+			}
+			return append(names, plugins[current].Name())
+		}
+		position[current] = len(path)
+		path = append(path, current)
+
+		// Every unscheduled plugin has at least one unscheduled dependency.
+		for _, dep := range deps[current] {
+			if !done[dep] {
+				current = dep
+				break
+			}
+		}
+	}
+}
+
 // isCriticalStage returns true if errors in the given stage should halt execution.
 func isCriticalStage(stage Stage) bool {
 	switch stage {
@@ -112,6 +239,9 @@ func isCriticalStage(stage Stage) bool {
 	case StageTransform, StageRender, StageCollect, StageWrite:
 		// Later stages can potentially continue on partial failures
 		return false
+	case StageVerify:
+		// Every verifier runs; runVerifyHooks fails the build afterward
+		return false
 	case StageCleanup:
 		// Cleanup errors are warnings only
 		return false
@@ -137,10 +267,45 @@ func isCriticalError(err error) bool {
 	return false
 }
 
+// skippedDependencies returns a warning for each dependency of a plugin kept
+// by Manager.SetOnlyPlugins on a plugin it skips in this stage, since the
+// kept plugin may then miss data it expects. implements reports whether a
+// plugin has a hook for the stage.
+func skippedDependencies(m *Manager, stage Stage, plugins []Plugin, implements func(Plugin) bool) []*HookError {
+	if m.OnlyPlugins() == nil {
+		return nil
+	}
+
+	skipped := make(map[string]bool)
+	for _, p := range plugins {
+		if implements(p) && !m.runsPlugin(p.Name()) {
+			skipped[p.Name()] = true
+		}
+	}
+
+	var warnings []*HookError
+	for _, p := range plugins {
+		dp, ok := p.(DependencyPlugin)
+		if !ok || !implements(p) || !m.runsPlugin(p.Name()) {
+			continue
+		}
+		for _, dep := range dp.DependsOn(stage) {
+			if skipped[dep] {
+				warnings = append(warnings, &HookError{
+					Stage:  stage,
+					Plugin: p.Name(),
+					Err:    fmt.Errorf("depends on plugin %q, which is not in the plugins to run", dep),
+				})
+			}
+		}
+	}
+	return warnings
+}
+
 // executeHooks runs all plugins that implement the given stage interface.
 // Returns collected errors. If any critical error occurs, execution stops.
 func executeHooks[T Plugin](
-	_ *Manager,
+	m *Manager,
 	stage Stage,
 	plugins []Plugin,
 	check func(Plugin) (T, bool),
@@ -149,17 +314,54 @@ func executeHooks[T Plugin](
 	hookErrors := &HookErrors{}
 	critical := isCriticalStage(stage)
 
-	// Sort plugins by priority
-	sorted := sortPluginsByPriority(plugins, stage)
+	// Sort plugins by declared dependencies, then priority
+	sorted, err := sortPlugins(plugins, stage)
+	if err != nil {
+		var cycle *DependencyCycleError
+		plugin := ""
+		if errors.As(err, &cycle) && len(cycle.Cycle) > 0 {
+			plugin = cycle.Cycle[0]
+		}
+		hookErrors.Add(stage, plugin, err, true)
+		return hookErrors
+	}
+
+	skipped := skippedDependencies(m, stage, sorted, func(p Plugin) bool {
+		_, ok := check(p)
+		return ok
+	})
+	hookErrors.Errors = append(hookErrors.Errors, skipped...)
 
+	ctx := m.Context()
+	observer := m.currentObserver()
 	for _, p := range sorted {
 		typed, ok := check(p)
-		if !ok {
+		if !ok || !m.runsPlugin(p.Name()) {
 			continue
 		}
+		if ctx.Err() != nil {
+			// Canceled; runStage returns the context's error
+			return hookErrors
+		}
 
+		if observer != nil {
+			observer.PluginStarted(stage, p.Name())
+		}
+		m.hookCounters.reset()
+		m.setCurrentPlugin(p.Name())
 		start := time.Now()
-		if err := execute(typed); err != nil {
+		err := execute(typed)
+		elapsed := time.Since(start)
+		m.setCurrentPlugin("")
+		timing := m.recordPluginTiming(p.Name(), elapsed)
+		if observer != nil {
+			observer.PluginFinished(stage, timing, err)
+		}
+		if err != nil && ctx.Err() != nil {
+			// The plugin most likely failed because of the cancellation
+			return hookErrors
+		}
+		if err != nil {
 			// Check if the error itself is marked as critical
 			errIsCritical := critical || isCriticalError(err)
 			hookErrors.Add(stage, p.Name(), err, errIsCritical)
@@ -168,10 +370,9 @@ func executeHooks[T Plugin](
 				return hookErrors
 			}
 		}
-		elapsed := time.Since(start)
 		buildstats.RecordPlugin(string(stage), p.Name(), elapsed)
 		if elapsed > 50*time.Millisecond {
-			logging.Component(p.Name()).Phase(string(stage)).Printf("took %v", elapsed)
+			m.Logger().With(LogKeyPlugin, p.Name()).Info(fmt.Sprintf("took %v", elapsed))
 		}
 	}
 
@@ -230,6 +431,25 @@ func runLoadHooks(m *Manager) *HookErrors {
 	)
 }
 
+// RunLoadHooksSubset runs load hooks only for the provided files and
+// returns the posts they load. The manager's files and posts are restored
+// afterward.
+func RunLoadHooksSubset(m *Manager, files []string) ([]*models.Post, error) {
+	if m == nil {
+		return nil, nil
+	}
+	originalFiles := m.Files()
+	originalPosts := m.Posts()
+	m.SetFiles(files)
+	m.SetPosts(nil)
+	defer func() {
+		m.SetFiles(originalFiles)
+		m.SetPosts(originalPosts)
+	}()
+	err := runLoadHooks(m).ErrorOrNil()
+	return m.Posts(), err
+}
+
 // runTransformHooks executes all TransformPlugin hooks.
 func runTransformHooks(m *Manager) *HookErrors {
 	return executeHooks(m, StageTransform, m.plugins,
@@ -306,6 +526,25 @@ func runWriteHooks(m *Manager) *HookErrors {
 	)
 }
 
+// runVerifyHooks executes all VerifyPlugin hooks. Verifiers run to completion
+// so their problems are reported together, then every error is promoted to
+// critical so broken output fails the build.
+func runVerifyHooks(m *Manager) *HookErrors {
+	hookErrors := executeHooks(m, StageVerify, m.plugins,
+		func(p Plugin) (VerifyPlugin, bool) {
+			vp, ok := p.(VerifyPlugin)
+			return vp, ok
+		},
+		func(vp VerifyPlugin) error {
+			return vp.Verify(m)
+		},
+	)
+	for _, err := range hookErrors.Errors {
+		err.Critical = true
+	}
+	return hookErrors
+}
+
 // runCleanupHooks executes all CleanupPlugin hooks.
 func runCleanupHooks(m *Manager) *HookErrors {
 	return executeHooks(m, StageCleanup, m.plugins,
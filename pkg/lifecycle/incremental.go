@@ -0,0 +1,114 @@
+package lifecycle
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// RunIncremental rebuilds the site after the given source files changed.
+//
+// Changed paths may be absolute or relative to the working directory. Only
+// those posts, plus the posts that depend on them through the dependency graph
+// published by the build cache during the configure stage, are reloaded and
+// re-rendered; untouched posts are restored from the content-hash cache.
+// Changed paths that no longer exist are treated as removed.
+//
+// Unless feeds_incremental is enabled, feeds are re-collected from the full
+// post list so that a changed post joins or leaves every feed whose filter it
+// matches; publishing then skips feeds whose items are unchanged.
+//
+// RunIncremental falls back to a full build when no dependency graph is
+// available after configure, when a changed path lies outside the content
+// directory, or when changed is empty. A manager that has already run is
+// Reset first.
+func (m *Manager) RunIncremental(changed []string) error {
+	return m.RunIncrementalContext(context.Background(), changed)
+}
+
+// RunIncrementalContext is RunIncremental with cancellation, see RunContext.
+func (m *Manager) RunIncrementalContext(ctx context.Context, changed []string) error {
+	if m.HasRun(StageConfigure) {
+		m.Reset()
+	}
+
+	contentDir := m.Config().ContentDir
+	if contentDir == "" {
+		contentDir = "."
+	}
+
+	normalized, outside := normalizeChangedPaths(changed, contentDir)
+	if len(normalized) == 0 || outside {
+		m.markFullRebuild()
+		return m.RunContext(ctx)
+	}
+
+	removed := make([]string, 0)
+	for _, rel := range normalized {
+		if _, err := os.Stat(filepath.Join(contentDir, rel)); os.IsNotExist(err) {
+			removed = append(removed, rel)
+		}
+	}
+
+	SetServeFullRebuild(m, false)
+	SetServeChangedPaths(m, normalized)
+	SetServeRemovedPaths(m, removed)
+	// New files must be discovered, so never trust a cached file list.
+	SetServeGlobDirty(m, true)
+
+	if err := m.RunToContext(ctx, StageConfigure); err != nil {
+		return err
+	}
+
+	if len(GetServeAffectedPaths(m)) == 0 {
+		// Nothing resolved the dependency graph, so dependents are unknown.
+		m.markFullRebuild()
+	}
+
+	return m.RunContext(ctx)
+}
+
+// markFullRebuild clears incremental state so every plugin does a full build.
+func (m *Manager) markFullRebuild() {
+	SetServeFullRebuild(m, true)
+	SetServeChangedPaths(m, nil)
+	SetServeRemovedPaths(m, nil)
+	SetServeAffectedPaths(m, nil)
+	SetServeGlobDirty(m, true)
+}
+
+// normalizeChangedPaths converts paths to cleaned paths relative to
+// contentDir and reports whether any path lies outside of it.
+func normalizeChangedPaths(paths []string, contentDir string) (normalized []string, outside bool) {
+	absContentDir, err := filepath.Abs(contentDir)
+	if err != nil {
+		absContentDir = contentDir
+	}
+
+	seen := make(map[string]struct{}, len(paths))
+	for _, p := range paths {
+		if p == "" {
+			continue
+		}
+		absPath, err := filepath.Abs(p)
+		if err != nil {
+			absPath = p
+		}
+		rel, err := filepath.Rel(absContentDir, absPath)
+		if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			outside = true
+			continue
+		}
+		if rel == "." {
+			continue
+		}
+		if _, ok := seen[rel]; ok {
+			continue
+		}
+		seen[rel] = struct{}{}
+		normalized = append(normalized, rel)
+	}
+
+	return normalized, outside
+}
@@ -0,0 +1,184 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// graphPlugin mimics the build cache: during configure it marks the changed
+// paths and their dependents as affected.
+type graphPlugin struct {
+	dependents map[string][]string
+}
+
+func (p *graphPlugin) Name() string { return "graph" }
+
+func (p *graphPlugin) Configure(m *Manager) error {
+	if IsServeFullRebuild(m) {
+		return nil
+	}
+	affected := make(map[string]bool)
+	for _, path := range GetServeChangedPaths(m) {
+		affected[path] = true
+		for _, dep := range p.dependents[path] {
+			affected[dep] = true
+		}
+	}
+	SetServeAffectedPaths(m, affected)
+	return nil
+}
+
+// rebuildRecorder captures the incremental state seen by the render stage.
+type rebuildRecorder struct {
+	full     bool
+	affected []string
+	removed  []string
+}
+
+func (p *rebuildRecorder) Name() string { return "recorder" }
+
+func (p *rebuildRecorder) Render(m *Manager) error {
+	p.full = IsServeFullRebuild(m)
+	p.affected = p.affected[:0]
+	for path := range GetServeAffectedPaths(m) {
+		p.affected = append(p.affected, path)
+	}
+	sort.Strings(p.affected)
+	p.removed = GetServeRemovedPaths(m)
+	return nil
+}
+
+func newIncrementalManager(t *testing.T) (m *Manager, dir string) {
+	t.Helper()
+	dir = t.TempDir()
+	for _, name := range []string{"a.md", "b.md", "c.md"} {
+		//nolint:gosec // Test file permissions are fine at 0644
+		if err := os.WriteFile(filepath.Join(dir, name), []byte("# "+name), 0o644); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+	m = NewManager()
+	m.Config().ContentDir = dir
+	return m, dir
+}
+
+func TestRunIncremental_RebuildsChangedAndDependents(t *testing.T) {
+	m, dir := newIncrementalManager(t)
+	recorder := &rebuildRecorder{}
+	m.RegisterPlugins(&graphPlugin{dependents: map[string][]string{"a.md": {"b.md"}}}, recorder)
+
+	if err := m.RunIncremental([]string{filepath.Join(dir, "a.md")}); err != nil {
+		t.Fatalf("RunIncremental() error = %v", err)
+	}
+
+	if recorder.full {
+		t.Fatal("expected incremental rebuild, got full rebuild")
+	}
+	if got := strings.Join(recorder.affected, ","); got != "a.md,b.md" {
+		t.Errorf("affected = %q, want %q", got, "a.md,b.md")
+	}
+	if !m.HasRun(StageCleanup) {
+		t.Error("expected all stages to run")
+	}
+}
+
+func TestRunIncremental_TracksRemovedPaths(t *testing.T) {
+	m, dir := newIncrementalManager(t)
+	recorder := &rebuildRecorder{}
+	m.RegisterPlugins(&graphPlugin{}, recorder)
+
+	if err := os.Remove(filepath.Join(dir, "c.md")); err != nil {
+		t.Fatalf("failed to remove c.md: %v", err)
+	}
+	if err := m.RunIncremental([]string{filepath.Join(dir, "c.md")}); err != nil {
+		t.Fatalf("RunIncremental() error = %v", err)
+	}
+
+	if recorder.full {
+		t.Fatal("expected incremental rebuild, got full rebuild")
+	}
+	if len(recorder.removed) != 1 || recorder.removed[0] != "c.md" {
+		t.Errorf("removed = %v, want [c.md]", recorder.removed)
+	}
+}
+
+func TestRunIncremental_FallsBackToFullBuild(t *testing.T) {
+	tests := []struct {
+		name    string
+		graph   bool
+		changed func(dir string) []string
+	}{
+		{
+			name:    "no dependency graph",
+			changed: func(dir string) []string { return []string{filepath.Join(dir, "a.md")} },
+		},
+		{
+			name:    "path outside content dir",
+			graph:   true,
+			changed: func(dir string) []string { return []string{filepath.Join(filepath.Dir(dir), "other.md")} },
+		},
+		{
+			name:    "no changes",
+			graph:   true,
+			changed: func(string) []string { return nil },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, dir := newIncrementalManager(t)
+			recorder := &rebuildRecorder{}
+			if tt.graph {
+				m.RegisterPlugin(&graphPlugin{})
+			}
+			m.RegisterPlugin(recorder)
+
+			if err := m.RunIncremental(tt.changed(dir)); err != nil {
+				t.Fatalf("RunIncremental() error = %v", err)
+			}
+			if !recorder.full {
+				t.Error("expected full rebuild")
+			}
+			if len(recorder.affected) != 0 {
+				t.Errorf("affected = %v, want none on full rebuild", recorder.affected)
+			}
+		})
+	}
+}
+
+func TestRunIncremental_ResetsPreviousRun(t *testing.T) {
+	m, dir := newIncrementalManager(t)
+	recorder := &rebuildRecorder{}
+	m.RegisterPlugins(&graphPlugin{}, recorder)
+
+	if err := m.Run(); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if err := m.RunIncremental([]string{filepath.Join(dir, "b.md")}); err != nil {
+		t.Fatalf("RunIncremental() error = %v", err)
+	}
+	if recorder.full || strings.Join(recorder.affected, ",") != "b.md" {
+		t.Errorf("expected incremental rebuild of b.md, got full=%v affected=%v", recorder.full, recorder.affected)
+	}
+}
+
+func TestRunIncrementalContext_Canceled(t *testing.T) {
+	m, dir := newIncrementalManager(t)
+	recorder := &rebuildRecorder{}
+	m.RegisterPlugins(&graphPlugin{}, recorder)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err := m.RunIncrementalContext(ctx, []string{filepath.Join(dir, "a.md")})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("RunIncrementalContext() error = %v, want context.Canceled", err)
+	}
+	if m.HasRun(StageRender) {
+		t.Error("render stage ran after the context was canceled")
+	}
+}
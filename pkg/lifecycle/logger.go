@@ -0,0 +1,137 @@
+package lifecycle
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+
+	"github.com/WaylonWalker/markata-go/pkg/logging"
+)
+
+// Logger is the structured logger the Manager and plugins write build
+// messages to. Args are alternating keys and values, or slog.Attr values,
+// as with log/slog:
+//
+//	m.Logger().Warn("failed to download asset", "asset", name, "error", err)
+type Logger interface {
+	Debug(msg string, args ...any)
+	Info(msg string, args ...any)
+	Warn(msg string, args ...any)
+	Error(msg string, args ...any)
+
+	// With returns a Logger that adds args to every message.
+	With(args ...any) Logger
+}
+
+// Keys of the fields the Manager adds to messages logged during a stage.
+const (
+	LogKeyStage  = logging.StageKey
+	LogKeyPlugin = logging.PluginKey
+)
+
+// slogLogger adapts a slog.Logger to Logger.
+type slogLogger struct {
+	l *slog.Logger
+}
+
+// NewLogger returns a Logger that writes to h.
+func NewLogger(h slog.Handler) Logger {
+	return slogLogger{l: slog.New(h)}
+}
+
+// NewTextLogger returns a Logger that writes through the standard logger,
+// so messages get the plain or rich formatting configured with the logging
+// package. The plugin and stage fields become the line's [component] tag.
+func NewTextLogger(level slog.Leveler) Logger {
+	return NewLogger(logging.NewHandler(level))
+}
+
+// NewJSONLogger returns a Logger that writes one JSON object per message
+// to w, for CI and log collectors.
+func NewJSONLogger(w io.Writer, level slog.Leveler) Logger {
+	return NewLogger(slog.NewJSONHandler(w, &slog.HandlerOptions{Level: level}))
+}
+
+// NopLogger returns a Logger that discards every message, for tests.
+func NopLogger() Logger {
+	return NewLogger(nopHandler{})
+}
+
+func (l slogLogger) Debug(msg string, args ...any) { l.l.Debug(msg, args...) }
+func (l slogLogger) Info(msg string, args ...any)  { l.l.Info(msg, args...) }
+func (l slogLogger) Warn(msg string, args ...any)  { l.l.Warn(msg, args...) }
+func (l slogLogger) Error(msg string, args ...any) { l.l.Error(msg, args...) }
+
+func (l slogLogger) With(args ...any) Logger {
+	return slogLogger{l: l.l.With(args...)}
+}
+
+// nopHandler is a slog.Handler that is never enabled.
+type nopHandler struct{}
+
+func (nopHandler) Enabled(context.Context, slog.Level) bool  { return false }
+func (nopHandler) Handle(context.Context, slog.Record) error { return nil }
+func (h nopHandler) WithAttrs([]slog.Attr) slog.Handler      { return h }
+func (h nopHandler) WithGroup(string) slog.Handler           { return h }
+
+// ParseLogLevel parses "debug", "info", "warn" (or "warning"), or "error".
+// An empty string is info.
+func ParseLogLevel(s string) (slog.Level, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "debug":
+		return slog.LevelDebug, nil
+	case "", "info":
+		return slog.LevelInfo, nil
+	case "warn", "warning":
+		return slog.LevelWarn, nil
+	case "error":
+		return slog.LevelError, nil
+	default:
+		return 0, fmt.Errorf("invalid log level %q (expected debug, info, warn, or error)", s)
+	}
+}
+
+// SetLogger sets the logger build messages are written to. Pass nil to go
+// back to the default, a text logger at info level.
+func (m *Manager) SetLogger(l Logger) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.logger = l
+}
+
+// Logger returns the build logger with the running stage and plugin
+// attached as the "stage" and "plugin" fields. Plugins should call it
+// inside each hook rather than keep the result, so the fields match the
+// hook that logs.
+func (m *Manager) Logger() Logger {
+	m.mu.RLock()
+	l, stage, plugin := m.logger, m.currentStage, m.currentPlugin
+	m.mu.RUnlock()
+
+	if l == nil {
+		l = defaultLogger
+	}
+	var args []any
+	if stage != "" {
+		args = append(args, LogKeyStage, string(stage))
+	}
+	if plugin != "" {
+		args = append(args, LogKeyPlugin, plugin)
+	}
+	if len(args) == 0 {
+		return l
+	}
+	return l.With(args...)
+}
+
+// defaultLogger is used by Managers without a logger set.
+var defaultLogger = NewTextLogger(slog.LevelInfo)
+
+// setCurrentPlugin records the plugin whose hook is running, for Logger.
+func (m *Manager) setCurrentPlugin(name string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.currentPlugin = name
+}
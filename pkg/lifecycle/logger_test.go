@@ -0,0 +1,119 @@
+package lifecycle
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+// decodeJSONLogs parses one JSON object per line.
+func decodeJSONLogs(t *testing.T, buf *bytes.Buffer) []map[string]any {
+	t.Helper()
+	var records []map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		var record map[string]any
+		if err := json.Unmarshal([]byte(line), &record); err != nil {
+			t.Fatalf("log line %q is not JSON: %v", line, err)
+		}
+		records = append(records, record)
+	}
+	return records
+}
+
+func TestManagerLogger_AttachesStageAndPlugin(t *testing.T) {
+	var buf bytes.Buffer
+	m := NewManager()
+	m.SetLogger(NewJSONLogger(&buf, slog.LevelDebug))
+
+	p := NewTestPlugin("greeter")
+	p.transformFn = func(m *Manager) error {
+		m.Logger().Info("transformed posts", "count", 3)
+		return nil
+	}
+	p.writeFn = func(m *Manager) error {
+		m.Logger().With("file", "index.html").Warn("slow write")
+		return nil
+	}
+	m.RegisterPlugin(p)
+
+	if err := m.Run(); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	m.Logger().Info("after build")
+
+	records := decodeJSONLogs(t, &buf)
+	if len(records) != 3 {
+		t.Fatalf("got %d records, want 3: %v", len(records), records)
+	}
+
+	want := []map[string]any{
+		{"msg": "transformed posts", "level": "INFO", "stage": "transform", "plugin": "greeter", "count": float64(3)},
+		{"msg": "slow write", "level": "WARN", "stage": "write", "plugin": "greeter", "file": "index.html"},
+	}
+	for i, fields := range want {
+		for key, value := range fields {
+			if records[i][key] != value {
+				t.Errorf("record %d %s = %v, want %v", i, key, records[i][key], value)
+			}
+		}
+	}
+
+	// Outside a hook there is no plugin
+	if _, ok := records[2]["plugin"]; ok {
+		t.Errorf("record after build has plugin %v", records[2]["plugin"])
+	}
+}
+
+func TestJSONLogger_Level(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewJSONLogger(&buf, slog.LevelWarn)
+	l.Debug("hidden")
+	l.Info("hidden")
+	l.Warn("shown")
+	l.Error("shown too")
+
+	records := decodeJSONLogs(t, &buf)
+	if len(records) != 2 || records[0]["msg"] != "shown" || records[1]["msg"] != "shown too" {
+		t.Errorf("records = %v, want only warn and error", records)
+	}
+}
+
+func TestNopLogger(t *testing.T) {
+	m := NewManager()
+	m.SetLogger(NopLogger())
+	p := NewTestPlugin("quiet")
+	p.loadFn = func(m *Manager) error {
+		m.Logger().With("key", "value").Error("discarded")
+		return nil
+	}
+	m.RegisterPlugin(p)
+	if err := m.Run(); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+}
+
+func TestParseLogLevel(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    slog.Level
+		wantErr bool
+	}{
+		{"", slog.LevelInfo, false},
+		{"debug", slog.LevelDebug, false},
+		{"WARN", slog.LevelWarn, false},
+		{"warning", slog.LevelWarn, false},
+		{"error", slog.LevelError, false},
+		{"loud", 0, true},
+	}
+	for _, tt := range tests {
+		got, err := ParseLogLevel(tt.in)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ParseLogLevel(%q) = %v, %v; want %v, error %v", tt.in, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
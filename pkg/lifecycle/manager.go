@@ -1,12 +1,14 @@
 package lifecycle
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"reflect"
-	"runtime"
 	"sort"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/WaylonWalker/markata-go/pkg/filter"
 	"github.com/WaylonWalker/markata-go/pkg/models"
@@ -234,6 +236,12 @@ type Manager struct {
 	// currentStage tracks the currently executing stage.
 	currentStage Stage
 
+	// currentPlugin is the plugin whose hook is running, see Logger.
+	currentPlugin string
+
+	// logger receives build messages, see SetLogger.
+	logger Logger
+
 	// stagesRun tracks which stages have completed.
 	stagesRun map[Stage]bool
 
@@ -249,22 +257,47 @@ type Manager struct {
 	// concurrency controls the number of concurrent goroutines for parallel processing.
 	concurrency int
 
+	// failFast stops concurrent post processing at the first error instead
+	// of aggregating every failure, see SetFailFast.
+	failFast bool
+
+	// ctx is the context of the running build, see RunContext.
+	ctx context.Context
+
 	// assetHashes maps original asset paths to their content hashes for cache busting.
 	// Key: original path (e.g., "css/main.css"), Value: hash (first 8 chars of SHA-256).
 	assetHashes map[string]string
+
+	// manifest lists the output files of the last build, see Manifest.
+	manifest []ManifestEntry
+
+	// observer receives build progress notifications, see SetObserver.
+	observer Observer
+
+	// profile holds timings for completed stages, see Profile.
+	profile []StageTiming
+
+	// activeTiming collects plugin timings for the stage being run.
+	activeTiming *StageTiming
+
+	// hookCounters tracks concurrent post processing within the running hook.
+	hookCounters hookCounters
+
+	// onlyPlugins limits hook execution to the named plugins, see
+	// SetOnlyPlugins. Nil runs every plugin.
+	onlyPlugins map[string]bool
+
+	// buildCacheEnabled overrides the build_cache.enabled config setting
+	// when non-nil, see SetBuildCacheEnabled.
+	buildCacheEnabled *bool
+
+	// buildCacheDir overrides the build cache location, see SetBuildCacheDir.
+	buildCacheDir string
 }
 
 // NewManager creates a new lifecycle Manager with default settings.
 // Concurrency is auto-detected from CPU cores, capped at 16.
 func NewManager() *Manager {
-	concurrency := runtime.NumCPU()
-	if concurrency > 16 {
-		concurrency = 16 // Cap to avoid excessive goroutine overhead
-	}
-	if concurrency < 1 {
-		concurrency = 1
-	}
-
 	return &Manager{
 		plugins:     make([]Plugin, 0),
 		config:      NewConfig(),
@@ -274,7 +307,7 @@ func NewManager() *Manager {
 		stagesRun:   make(map[Stage]bool),
 		cache:       newMemoryCache(),
 		warnings:    make([]*HookError, 0),
-		concurrency: concurrency,
+		concurrency: defaultConcurrency(),
 		assetHashes: make(map[string]string),
 	}
 }
@@ -303,6 +336,75 @@ func (m *Manager) Plugins() []Plugin {
 	return result
 }
 
+// SetOnlyPlugins limits the following stages to the named plugins; every
+// other registered plugin is skipped. Unlike Config.DisabledHooks this is not
+// part of the site config, so it is meant for one-off runs such as debugging
+// a single plugin. Names must match registered plugins. An empty list runs
+// every plugin again.
+func (m *Manager) SetOnlyPlugins(names []string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if len(names) == 0 {
+		m.onlyPlugins = nil
+		return nil
+	}
+
+	registered := make(map[string]bool, len(m.plugins))
+	for _, p := range m.plugins {
+		registered[p.Name()] = true
+	}
+
+	only := make(map[string]bool, len(names))
+	var unknown []string
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		if !registered[name] {
+			unknown = append(unknown, name)
+			continue
+		}
+		only[name] = true
+	}
+	if len(unknown) > 0 {
+		known := make([]string, 0, len(registered))
+		for name := range registered {
+			known = append(known, name)
+		}
+		sort.Strings(known)
+		return fmt.Errorf("unknown plugin(s) %s (registered plugins: %s)",
+			strings.Join(unknown, ", "), strings.Join(known, ", "))
+	}
+
+	m.onlyPlugins = only
+	return nil
+}
+
+// OnlyPlugins returns the sorted names set by SetOnlyPlugins, or nil when
+// every plugin runs.
+func (m *Manager) OnlyPlugins() []string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if m.onlyPlugins == nil {
+		return nil
+	}
+	names := make([]string, 0, len(m.onlyPlugins))
+	for name := range m.onlyPlugins {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// runsPlugin reports whether the named plugin runs under SetOnlyPlugins.
+func (m *Manager) runsPlugin(name string) bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.onlyPlugins == nil || m.onlyPlugins[name]
+}
+
 // Config returns the current configuration.
 func (m *Manager) Config() *Config {
 	m.mu.RLock()
@@ -317,6 +419,34 @@ func (m *Manager) SetConfig(config *Config) {
 	m.config = config
 }
 
+// UnmarshalPluginConfig decodes the Extra[key] subtree of the configuration
+// into dst, which must be a pointer to the plugin's typed config. Fields are
+// matched by their json tags. Fill dst with the plugin's defaults first: only
+// keys present in the config overwrite them, including inside nested structs.
+// A missing or null key leaves dst untouched.
+//
+// Extra holds decoded Go values, so this works the same whether the config
+// was written in TOML, YAML, or JSON.
+func (m *Manager) UnmarshalPluginConfig(key string, dst any) error {
+	config := m.Config()
+	if config == nil || config.Extra == nil {
+		return nil
+	}
+	raw, ok := config.Extra[key]
+	if !ok || raw == nil {
+		return nil
+	}
+
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return fmt.Errorf("config %s: %w", key, err)
+	}
+	if err := json.Unmarshal(data, dst); err != nil {
+		return fmt.Errorf("config %s: %w", key, err)
+	}
+	return nil
+}
+
 // Posts returns a copy of the posts slice.
 func (m *Manager) Posts() []*models.Post {
 	m.mu.RLock()
@@ -495,11 +625,12 @@ func (m *Manager) Warnings() []*HookError {
 }
 
 // SetConcurrency sets the concurrency level for parallel processing.
+// Zero or less restores the default, the number of CPU cores capped at 16.
 func (m *Manager) SetConcurrency(n int) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 	if n < 1 {
-		n = 1
+		n = defaultConcurrency()
 	}
 	m.concurrency = n
 }
@@ -511,6 +642,36 @@ func (m *Manager) Concurrency() int {
 	return m.concurrency
 }
 
+// SetFailFast selects how concurrent post processing handles errors. When
+// true, the first failing post stops the remaining ones and its error is
+// returned. When false (the default), every post is processed and all
+// failures are returned together as a *PostErrors.
+func (m *Manager) SetFailFast(failFast bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.failFast = failFast
+}
+
+// FailFast reports whether concurrent post processing stops at the first
+// error.
+func (m *Manager) FailFast() bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.failFast
+}
+
+// Context returns the context of the running build, or
+// context.Background() outside RunContext. Long-running plugins can watch
+// it to stop early when the build is canceled.
+func (m *Manager) Context() context.Context {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if m.ctx == nil {
+		return context.Background()
+	}
+	return m.ctx
+}
+
 // SetAssetHash stores a content hash for an asset path (for cache busting).
 // This is called by the static_assets plugin during the Write stage.
 func (m *Manager) SetAssetHash(path, hash string) {
@@ -543,12 +704,53 @@ func (m *Manager) Run() error {
 	return m.RunTo(StageCleanup)
 }
 
+// RunContext executes all lifecycle stages in order, stopping when ctx is
+// canceled. Cancellation is checked between stages and plugins, and
+// concurrent post processing stops handing out posts, so the build returns
+// ctx.Err() soon after. The interrupted stage is not marked complete.
+func (m *Manager) RunContext(ctx context.Context) error {
+	return m.RunToContext(ctx, StageCleanup)
+}
+
+// RunOnly executes all lifecycle stages like Run, but only runs the hooks of
+// the named plugins in each stage. The restriction lasts for this call only.
+// A kept plugin that depends on a skipped one gets a warning, see Warnings.
+func (m *Manager) RunOnly(plugins []string) error {
+	if len(plugins) == 0 {
+		return fmt.Errorf("no plugins named")
+	}
+	if err := m.SetOnlyPlugins(plugins); err != nil {
+		return err
+	}
+	defer m.SetOnlyPlugins(nil) //nolint:errcheck // clearing never fails
+
+	return m.Run()
+}
+
 // RunTo executes lifecycle stages up to and including the specified stage.
 // Already completed stages are skipped.
 func (m *Manager) RunTo(stage Stage) error {
+	return m.RunToContext(context.Background(), stage)
+}
+
+// RunToContext is RunTo with cancellation, see RunContext.
+func (m *Manager) RunToContext(ctx context.Context, stage Stage) error {
 	if !IsValidStage(stage) {
 		return fmt.Errorf("invalid stage: %s", stage)
 	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	prevCtx := m.ctx
+	m.ctx = ctx
+	m.mu.Unlock()
+	defer func() {
+		m.mu.Lock()
+		m.ctx = prevCtx
+		m.mu.Unlock()
+	}()
 
 	// Clear template caches at the start of each build cycle
 	// This ensures fresh data while still benefiting from caching within a build
@@ -560,6 +762,9 @@ func (m *Manager) RunTo(stage Stage) error {
 		if m.HasRun(s) {
 			continue
 		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
 
 		if err := m.runStage(s); err != nil {
 			return err
@@ -575,6 +780,21 @@ func (m *Manager) runStage(stage Stage) error {
 	m.currentStage = stage
 	m.mu.Unlock()
 
+	observer := m.currentObserver()
+	if observer != nil {
+		observer.StageStarted(stage)
+	}
+
+	var stageWarnings []*HookError
+	m.beginStageTiming(stage)
+	start := time.Now()
+	defer func() {
+		timing := m.endStageTiming(time.Since(start))
+		if observer != nil {
+			observer.StageFinished(timing, stageWarnings)
+		}
+	}()
+
 	var hookErrors *HookErrors
 
 	switch stage {
@@ -594,6 +814,8 @@ func (m *Manager) runStage(stage Stage) error {
 		hookErrors = runCollectHooks(m)
 	case StageWrite:
 		hookErrors = runWriteHooks(m)
+	case StageVerify:
+		hookErrors = runVerifyHooks(m)
 	case StageCleanup:
 		hookErrors = runCleanupHooks(m)
 	default:
@@ -605,10 +827,16 @@ func (m *Manager) runStage(stage Stage) error {
 	for _, err := range hookErrors.Errors {
 		if !err.Critical {
 			m.warnings = append(m.warnings, err)
+			stageWarnings = append(stageWarnings, err)
 		}
 	}
 	m.mu.Unlock()
 
+	// A canceled build stops without marking the stage complete
+	if err := m.Context().Err(); err != nil {
+		return err
+	}
+
 	// Return error if any critical errors occurred
 	if hookErrors.HasCritical() {
 		return hookErrors
@@ -633,6 +861,9 @@ func (m *Manager) Reset() {
 	m.stagesRun = make(map[Stage]bool)
 	m.warnings = make([]*HookError, 0)
 	m.currentStage = ""
+	m.manifest = nil
+	m.profile = nil
+	m.activeTiming = nil
 	m.cache.Clear()
 }
 
@@ -642,8 +873,11 @@ func (m *Manager) Reset() {
 //   - "draft != True" - field not equals value
 //   - "'go' in tags" - value in slice (or "tags contains go" for legacy syntax)
 //   - "date <= today" - date comparisons with special values
-//   - Multiple conditions can be combined with "and" or "or"
+//   - Multiple conditions can be combined with "and" or "or"; "and" binds
+//     tighter than "or", and parentheses group conditions explicitly
 //   - Supports "not" for negation
+//
+// Syntax errors include the position of the offending token.
 func (m *Manager) Filter(expr string) ([]*models.Post, error) {
 	if expr == "" {
 		return m.Posts(), nil
@@ -666,30 +900,48 @@ func (m *Manager) Filter(expr string) ([]*models.Post, error) {
 	return results, nil
 }
 
+// SortSpec describes one key of a multi-key sort for MapSorted.
+type SortSpec struct {
+	// Field is the post field to sort by (same lookup rules as Map).
+	Field string
+
+	// Descending reverses the order for this key.
+	Descending bool
+}
+
 // Map extracts field values from posts, with optional filtering and sorting.
 // Parameters:
 //   - field: the field to extract (supports dot notation for nested fields)
 //   - filterExpr: optional filter expression (same syntax as Filter)
 //   - sortField: field to sort by (empty for no sorting)
 //   - reverse: if true, sort in descending order
+//
+// Map is shorthand for MapSorted with a single SortSpec.
 func (m *Manager) Map(field, filterExpr, sortField string, reverse bool) ([]interface{}, error) {
+	var sorts []SortSpec
+	if sortField != "" {
+		sorts = []SortSpec{{Field: sortField, Descending: reverse}}
+	}
+	return m.MapSorted(field, filterExpr, sorts)
+}
+
+// MapSorted extracts field values from posts matching filterExpr, sorted by
+// each SortSpec in turn: later specs only break ties left by earlier ones.
+// The sort is stable, so posts with equal keys keep their discovery order,
+// and posts missing a sort field come last for that key regardless of direction.
+//
+//	// Sort by series, then newest first within each series
+//	titles, err := m.MapSorted("title", "", []SortSpec{
+//	    {Field: "series"},
+//	    {Field: "date", Descending: true},
+//	})
+func (m *Manager) MapSorted(field, filterExpr string, sorts []SortSpec) ([]interface{}, error) {
 	posts, err := m.Filter(filterExpr)
 	if err != nil {
 		return nil, err
 	}
 
-	// Sort if requested
-	if sortField != "" {
-		sort.SliceStable(posts, func(i, j int) bool {
-			vi := getPostField(posts[i], sortField)
-			vj := getPostField(posts[j], sortField)
-			cmp := compareValues(vi, vj)
-			if reverse {
-				return cmp > 0
-			}
-			return cmp < 0
-		})
-	}
+	sortPostsBySpecs(posts, sorts)
 
 	// Extract field values
 	result := make([]interface{}, len(posts))
@@ -700,6 +952,54 @@ func (m *Manager) Map(field, filterExpr, sortField string, reverse bool) ([]inte
 	return result, nil
 }
 
+// sortPostsBySpecs stable-sorts posts in place by the given sort keys.
+// Sort values are looked up once per post rather than on every comparison.
+func sortPostsBySpecs(posts []*models.Post, sorts []SortSpec) {
+	if len(sorts) == 0 || len(posts) < 2 {
+		return
+	}
+
+	keys := make([][]interface{}, len(posts))
+	order := make([]int, len(posts))
+	for i, post := range posts {
+		order[i] = i
+		keys[i] = make([]interface{}, len(sorts))
+		for k, spec := range sorts {
+			keys[i][k] = getPostField(post, spec.Field)
+		}
+	}
+
+	sort.SliceStable(order, func(a, b int) bool {
+		ka, kb := keys[order[a]], keys[order[b]]
+		for k, spec := range sorts {
+			va, vb := ka[k], kb[k]
+			switch {
+			case va == nil && vb == nil:
+				continue
+			case va == nil:
+				return false // missing values sort last
+			case vb == nil:
+				return true
+			}
+			cmp := compareValues(va, vb)
+			if cmp == 0 {
+				continue
+			}
+			if spec.Descending {
+				return cmp > 0
+			}
+			return cmp < 0
+		}
+		return false
+	})
+
+	sorted := make([]*models.Post, len(posts))
+	for i, idx := range order {
+		sorted[i] = posts[idx]
+	}
+	copy(posts, sorted)
+}
+
 // getPostField retrieves a field value from a post using reflection.
 func getPostField(post *models.Post, field string) interface{} {
 	// Check Extra fields first
@@ -760,59 +1060,14 @@ func compareValues(a, b interface{}) int {
 // only a fixed number of goroutines are spawned. This eliminates scheduler overhead
 // and memory churn for large builds.
 //
-// Error handling: If any post fails to process, the function continues processing
-// remaining posts and returns an aggregated error containing the count of failures
-// and the first error encountered.
+// Canceling the build's context (see RunContext) stops handing out posts and
+// returns the context's error once the posts in flight finish.
+//
+// Error handling: By default every post is processed and a *PostErrors holding
+// each failure is returned. With SetFailFast(true), remaining posts are skipped
+// after the first failure and that error is returned.
 func (m *Manager) ProcessPostsConcurrently(fn func(*models.Post) error) error {
-	posts := m.Posts()
-	if len(posts) == 0 {
-		return nil
-	}
-
-	numWorkers := m.Concurrency()
-	if numWorkers > len(posts) {
-		numWorkers = len(posts)
-	}
-
-	jobs := make(chan *models.Post, len(posts))
-	errCh := make(chan error, len(posts))
-
-	var wg sync.WaitGroup
-
-	// Start fixed number of workers
-	for i := 0; i < numWorkers; i++ {
-		wg.Add(1)
-		go func() {
-			defer wg.Done()
-			for post := range jobs {
-				if err := fn(post); err != nil {
-					errCh <- fmt.Errorf("processing %s: %w", post.Path, err)
-				}
-			}
-		}()
-	}
-
-	// Send all posts to the jobs channel
-	for _, post := range posts {
-		jobs <- post
-	}
-	close(jobs)
-
-	// Wait for all workers to complete
-	wg.Wait()
-	close(errCh)
-
-	// Collect errors
-	errs := make([]error, 0)
-	for err := range errCh {
-		errs = append(errs, err)
-	}
-
-	if len(errs) > 0 {
-		return fmt.Errorf("%d posts failed to process; first error: %w", len(errs), errs[0])
-	}
-
-	return nil
+	return m.ProcessPostsSliceConcurrently(m.Posts(), fn)
 }
 
 // ProcessPostsSliceConcurrently processes the provided posts slice concurrently.
@@ -824,54 +1079,20 @@ func (m *Manager) ProcessPostsConcurrently(fn func(*models.Post) error) error {
 //	changedPosts := m.FilterPosts(func(p *models.Post) bool { return needsRebuild(p) })
 //	return m.ProcessPostsSliceConcurrently(changedPosts, processFunc)
 func (m *Manager) ProcessPostsSliceConcurrently(posts []*models.Post, fn func(*models.Post) error) error {
-	if len(posts) == 0 {
-		return nil
-	}
-
-	numWorkers := m.Concurrency()
-	if numWorkers > len(posts) {
-		numWorkers = len(posts)
-	}
-
-	jobs := make(chan *models.Post, len(posts))
-	errCh := make(chan error, len(posts))
-
-	var wg sync.WaitGroup
-
-	// Start fixed number of workers
-	for i := 0; i < numWorkers; i++ {
-		wg.Add(1)
-		go func() {
-			defer wg.Done()
-			for post := range jobs {
-				if err := fn(post); err != nil {
-					errCh <- fmt.Errorf("processing %s: %w", post.Path, err)
-				}
-			}
-		}()
-	}
-
-	// Send posts to the jobs channel
-	for _, post := range posts {
-		jobs <- post
-	}
-	close(jobs)
-
-	// Wait for all workers to complete
-	wg.Wait()
-	close(errCh)
-
-	// Collect errors
-	errs := make([]error, 0)
-	for err := range errCh {
-		errs = append(errs, err)
-	}
-
-	if len(errs) > 0 {
-		return fmt.Errorf("%d posts failed to process; first error: %w", len(errs), errs[0])
-	}
+	return processConcurrently(m, posts,
+		func(post *models.Post) string { return post.Path },
+		func(_ int, post *models.Post) error { return fn(post) },
+	)
+}
 
-	return nil
+// ProcessFilesConcurrently calls fn for each file on the same worker pool,
+// with the same cancellation and error handling, as ProcessPostsConcurrently.
+// fn also receives the file's index in files, so results can be kept in order.
+func (m *Manager) ProcessFilesConcurrently(files []string, fn func(i int, file string) error) error {
+	return processConcurrently(m, files,
+		func(file string) string { return file },
+		fn,
+	)
 }
 
 // FilterPosts returns a new slice containing only posts that match the predicate.
@@ -1,10 +1,16 @@
 package lifecycle
 
 import (
+	"context"
 	"errors"
+	"fmt"
+	"path/filepath"
+	"reflect"
 	"runtime"
+	"strings"
 	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/WaylonWalker/markata-go/pkg/models"
 )
@@ -126,6 +132,14 @@ func (p *TestPlugin) Write(m *Manager) error {
 	return nil
 }
 
+func (p *TestPlugin) Verify(_ *Manager) error {
+	p.stagesRun = append(p.stagesRun, StageVerify)
+	if p.shouldError == StageVerify {
+		return errors.New(p.errorMsg)
+	}
+	return nil
+}
+
 func (p *TestPlugin) Cleanup(m *Manager) error {
 	p.stagesRun = append(p.stagesRun, StageCleanup)
 	if p.shouldError == StageCleanup {
@@ -147,6 +161,7 @@ func TestStageOrder(t *testing.T) {
 		StageRender,
 		StageCollect,
 		StageWrite,
+		StageVerify,
 		StageCleanup,
 	}
 
@@ -174,7 +189,8 @@ func TestStageIndex(t *testing.T) {
 		{StageRender, 5},
 		{StageCollect, 6},
 		{StageWrite, 7},
-		{StageCleanup, 8},
+		{StageVerify, 8},
+		{StageCleanup, 9},
 		{Stage("invalid"), -1},
 	}
 
@@ -376,6 +392,34 @@ func TestManagerErrorStopsCriticalStage(t *testing.T) {
 	}
 }
 
+func TestManagerVerifyErrorsAggregate(t *testing.T) {
+	m := NewManager()
+	first := NewTestPlugin("first")
+	first.shouldError = StageVerify
+	first.errorMsg = "empty index.html"
+	second := NewTestPlugin("second")
+	second.shouldError = StageVerify
+	second.errorMsg = "broken link"
+	m.RegisterPlugins(first, second)
+
+	err := m.Run()
+	var hookErrors *HookErrors
+	if !errors.As(err, &hookErrors) {
+		t.Fatalf("Run() error = %v, want *HookErrors", err)
+	}
+	if len(hookErrors.Errors) != 2 {
+		t.Fatalf("got %d errors, want one per failing verifier: %v", len(hookErrors.Errors), err)
+	}
+	for _, hookErr := range hookErrors.Errors {
+		if !hookErr.Critical || hookErr.Stage != StageVerify {
+			t.Errorf("error %v should be a critical verify error", hookErr)
+		}
+	}
+	if m.HasRun(StageVerify) || m.HasRun(StageCleanup) {
+		t.Error("a failed verify stage should stop the build before cleanup")
+	}
+}
+
 func TestManagerPriorityOrdering(t *testing.T) {
 	m := NewManager()
 
@@ -423,6 +467,182 @@ func TestManagerPriorityOrdering(t *testing.T) {
 	}
 }
 
+// depPlugin is a TestPlugin that declares dependencies for the configure stage.
+type depPlugin struct {
+	*TestPlugin
+	deps []string
+}
+
+func (p *depPlugin) DependsOn(stage Stage) []string {
+	if stage != StageConfigure {
+		return nil
+	}
+	return p.deps
+}
+
+func newOrderedPlugin(name string, priority int, order *[]string, deps ...string) *depPlugin {
+	p := NewTestPlugin(name)
+	p.priority = priority
+	p.configureFn = func(_ *Manager) error {
+		*order = append(*order, name)
+		return nil
+	}
+	return &depPlugin{TestPlugin: p, deps: deps}
+}
+
+func TestManagerDependencyOrdering(t *testing.T) {
+	m := NewManager()
+	order := make([]string, 0)
+
+	// "feeds" has the lowest priority but must wait for "tag_aggregator",
+	// which in turn waits for "tags". "other" is unconstrained.
+	m.RegisterPlugins(
+		newOrderedPlugin("feeds", PriorityFirst, &order, "tag_aggregator", "missing"),
+		newOrderedPlugin("tag_aggregator", PriorityDefault, &order, "tags"),
+		newOrderedPlugin("other", PriorityEarly, &order),
+		newOrderedPlugin("tags", PriorityLast, &order),
+	)
+
+	if err := m.RunTo(StageConfigure); err != nil {
+		t.Fatalf("RunTo(StageConfigure) failed: %v", err)
+	}
+
+	expected := []string{"other", "tags", "tag_aggregator", "feeds"}
+	if strings.Join(order, ",") != strings.Join(expected, ",") {
+		t.Errorf("Expected order %v, got %v", expected, order)
+	}
+}
+
+func TestManagerDependencyTiesUsePriority(t *testing.T) {
+	m := NewManager()
+	order := make([]string, 0)
+
+	m.RegisterPlugins(
+		newOrderedPlugin("late", PriorityLate, &order, "base"),
+		newOrderedPlugin("early", PriorityEarly, &order, "base"),
+		newOrderedPlugin("base", PriorityDefault, &order),
+	)
+
+	if err := m.RunTo(StageConfigure); err != nil {
+		t.Fatalf("RunTo(StageConfigure) failed: %v", err)
+	}
+
+	expected := []string{"base", "early", "late"}
+	if strings.Join(order, ",") != strings.Join(expected, ",") {
+		t.Errorf("Expected order %v, got %v", expected, order)
+	}
+}
+
+func TestManagerDependencyCycle(t *testing.T) {
+	m := NewManager()
+	order := make([]string, 0)
+
+	m.RegisterPlugins(
+		newOrderedPlugin("standalone", PriorityDefault, &order),
+		newOrderedPlugin("a", PriorityDefault, &order, "b"),
+		newOrderedPlugin("b", PriorityDefault, &order, "c"),
+		newOrderedPlugin("c", PriorityDefault, &order, "a"),
+	)
+
+	err := m.RunTo(StageConfigure)
+	if err == nil {
+		t.Fatal("Expected dependency cycle error")
+	}
+
+	var hookErrs *HookErrors
+	if !errors.As(err, &hookErrs) || len(hookErrs.Errors) != 1 {
+		t.Fatalf("Expected a single hook error, got %T: %v", err, err)
+	}
+	var cycle *DependencyCycleError
+	if !errors.As(hookErrs.Errors[0], &cycle) {
+		t.Fatalf("Expected DependencyCycleError, got %T: %v", err, err)
+	}
+	if got := strings.Join(cycle.Cycle, " -> "); got != "a -> b -> c -> a" {
+		t.Errorf("Expected cycle a -> b -> c -> a, got %s", got)
+	}
+	if len(order) != 0 {
+		t.Errorf("Expected no plugins to run, got %v", order)
+	}
+}
+
+func TestManagerRunOnly(t *testing.T) {
+	m := NewManager()
+	kept := NewTestPlugin("kept")
+	other := NewTestPlugin("other")
+	alsoKept := NewTestPlugin("also_kept")
+	m.RegisterPlugins(kept, other, alsoKept)
+
+	if err := m.RunOnly([]string{"kept", "also_kept"}); err != nil {
+		t.Fatalf("RunOnly() failed: %v", err)
+	}
+
+	if len(kept.stagesRun) != len(StageOrder) {
+		t.Errorf("kept plugin ran %v, want every stage", kept.stagesRun)
+	}
+	if len(alsoKept.stagesRun) != len(StageOrder) {
+		t.Errorf("also_kept plugin ran %v, want every stage", alsoKept.stagesRun)
+	}
+	if len(other.stagesRun) != 0 {
+		t.Errorf("other plugin ran %v, want no stages", other.stagesRun)
+	}
+	if m.OnlyPlugins() != nil {
+		t.Errorf("OnlyPlugins() = %v after RunOnly, want nil", m.OnlyPlugins())
+	}
+
+	// The restriction only lasts for the RunOnly call.
+	m.Reset()
+	if err := m.Run(); err != nil {
+		t.Fatalf("Run() failed: %v", err)
+	}
+	if len(other.stagesRun) != len(StageOrder) {
+		t.Errorf("other plugin ran %v after RunOnly, want every stage", other.stagesRun)
+	}
+}
+
+func TestManagerRunOnly_UnknownPlugin(t *testing.T) {
+	m := NewManager()
+	p := NewTestPlugin("known")
+	m.RegisterPlugin(p)
+
+	err := m.RunOnly([]string{"known", "missing"})
+	if err == nil {
+		t.Fatal("Expected an error for an unregistered plugin")
+	}
+	if !strings.Contains(err.Error(), `missing`) || !strings.Contains(err.Error(), "registered plugins: known") {
+		t.Errorf("error %q should name the unknown and registered plugins", err)
+	}
+	if len(p.stagesRun) != 0 {
+		t.Errorf("no stage should run, got %v", p.stagesRun)
+	}
+}
+
+func TestManagerRunOnly_WarnsOnSkippedDependency(t *testing.T) {
+	m := NewManager()
+	order := make([]string, 0)
+	m.RegisterPlugins(
+		newOrderedPlugin("tags", PriorityDefault, &order),
+		newOrderedPlugin("feeds", PriorityDefault, &order, "tags"),
+	)
+
+	if err := m.SetOnlyPlugins([]string{"feeds"}); err != nil {
+		t.Fatalf("SetOnlyPlugins() failed: %v", err)
+	}
+	if err := m.RunTo(StageConfigure); err != nil {
+		t.Fatalf("RunTo(StageConfigure) failed: %v", err)
+	}
+
+	if strings.Join(order, ",") != "feeds" {
+		t.Errorf("Expected only feeds to run, got %v", order)
+	}
+	warnings := m.Warnings()
+	if len(warnings) != 1 {
+		t.Fatalf("Expected 1 warning, got %v", warnings)
+	}
+	if warnings[0].Plugin != "feeds" || warnings[0].Stage != StageConfigure || !strings.Contains(warnings[0].Error(), `"tags"`) {
+		t.Errorf("unexpected warning: %v", warnings[0])
+	}
+}
+
 func TestManagerFilter(t *testing.T) {
 	m := NewManager()
 
@@ -447,6 +667,9 @@ func TestManagerFilter(t *testing.T) {
 		{"tags contains python", 0},
 		{"published==true and tags contains go", 1},
 		{"published==true or tags contains go", 3},
+		{"published==true and (tags contains go or tags contains rust)", 2},
+		{"published==false or tags contains rust and tags contains go", 1},
+		{"(published==false or tags contains rust) and not tags contains go", 1},
 		{"", 3}, // Empty filter returns all
 	}
 
@@ -462,6 +685,15 @@ func TestManagerFilter(t *testing.T) {
 	}
 }
 
+func TestManagerFilter_SyntaxError(t *testing.T) {
+	m := NewManager()
+
+	_, err := m.Filter("published==true and (tags contains go")
+	if err == nil || !strings.Contains(err.Error(), "at position 37") {
+		t.Errorf("Filter() error = %v, want position of the missing ')'", err)
+	}
+}
+
 func TestManagerMap(t *testing.T) {
 	m := NewManager()
 
@@ -486,6 +718,153 @@ func TestManagerMap(t *testing.T) {
 	}
 }
 
+func TestManagerMapSorted(t *testing.T) {
+	m := NewManager()
+
+	day := func(d int) *time.Time {
+		t := time.Date(2024, 1, d, 0, 0, 0, 0, time.UTC)
+		return &t
+	}
+	post := func(slug, series string, date *time.Time) *models.Post {
+		p := &models.Post{Path: slug + ".md", Slug: slug, Date: date, Extra: map[string]interface{}{}}
+		if series != "" {
+			p.Extra["series"] = series
+		}
+		return p
+	}
+
+	m.SetPosts([]*models.Post{
+		post("b-old", "beta", day(1)),
+		post("none-1", "", day(5)),
+		post("a-new", "alpha", day(9)),
+		post("b-new", "beta", day(7)),
+		post("a-old", "alpha", day(2)),
+		post("a-undated", "alpha", nil),
+		post("none-2", "", day(3)),
+	})
+
+	slugs, err := m.MapSorted("slug", "", []SortSpec{
+		{Field: "series"},
+		{Field: "date", Descending: true},
+	})
+	if err != nil {
+		t.Fatalf("MapSorted() returned error: %v", err)
+	}
+
+	want := []string{"a-new", "a-old", "a-undated", "b-new", "b-old", "none-1", "none-2"}
+	if len(slugs) != len(want) {
+		t.Fatalf("Expected %d results, got %d", len(want), len(slugs))
+	}
+	for i, slug := range want {
+		if slugs[i] != slug {
+			t.Errorf("Position %d: expected %s, got %v (all: %v)", i, slug, slugs[i], slugs)
+		}
+	}
+
+	// Missing values stay last when the direction is flipped, and equal keys
+	// keep discovery order.
+	slugs, err = m.MapSorted("slug", "", []SortSpec{{Field: "series", Descending: true}})
+	if err != nil {
+		t.Fatalf("MapSorted() returned error: %v", err)
+	}
+	want = []string{"b-old", "b-new", "a-new", "a-old", "a-undated", "none-1", "none-2"}
+	for i, slug := range want {
+		if slugs[i] != slug {
+			t.Errorf("Descending position %d: expected %s, got %v (all: %v)", i, slug, slugs[i], slugs)
+		}
+	}
+}
+
+func TestManagerMapSingleKeyUsesSortedPath(t *testing.T) {
+	m := NewManager()
+
+	title1 := "Alpha"
+	title2 := "Beta"
+	m.SetPosts([]*models.Post{
+		{Path: "b.md", Title: &title2},
+		{Path: "none.md"},
+		{Path: "a.md", Title: &title1},
+	})
+
+	paths, err := m.Map("path", "", "title", true)
+	if err != nil {
+		t.Fatalf("Map() returned error: %v", err)
+	}
+	want := []interface{}{"b.md", "a.md", "none.md"}
+	for i := range want {
+		if paths[i] != want[i] {
+			t.Errorf("Position %d: expected %v, got %v", i, want[i], paths[i])
+		}
+	}
+}
+
+type testRendererConfig struct {
+	Path    string   `json:"path"`
+	Args    []string `json:"args"`
+	Timeout int      `json:"timeout"`
+}
+
+type testPluginConfig struct {
+	Enabled  bool               `json:"enabled"`
+	Theme    string             `json:"theme"`
+	Renderer testRendererConfig `json:"renderer"`
+	Limits   map[string]float64 `json:"limits"`
+}
+
+func TestManagerUnmarshalPluginConfig(t *testing.T) {
+	m := NewManager()
+	// Shaped like a TOML config after loading: numbers arrive as float64
+	m.Config().Extra["diagram"] = map[string]interface{}{
+		"theme": "dark",
+		"renderer": map[string]interface{}{
+			"path": "/usr/bin/render",
+		},
+		"limits": map[string]interface{}{"width": float64(800)},
+	}
+
+	cfg := testPluginConfig{
+		Enabled:  true,
+		Theme:    "default",
+		Renderer: testRendererConfig{Path: "render", Args: []string{"--quiet"}, Timeout: 30},
+		Limits:   map[string]float64{"height": 600},
+	}
+	if err := m.UnmarshalPluginConfig("diagram", &cfg); err != nil {
+		t.Fatalf("UnmarshalPluginConfig returned error: %v", err)
+	}
+
+	want := testPluginConfig{
+		Enabled:  true,
+		Theme:    "dark",
+		Renderer: testRendererConfig{Path: "/usr/bin/render", Args: []string{"--quiet"}, Timeout: 30},
+		Limits:   map[string]float64{"height": 600, "width": 800},
+	}
+	if !reflect.DeepEqual(cfg, want) {
+		t.Errorf("config = %+v, want %+v", cfg, want)
+	}
+}
+
+func TestManagerUnmarshalPluginConfigMissingKey(t *testing.T) {
+	m := NewManager()
+	cfg := testPluginConfig{Theme: "default"}
+	if err := m.UnmarshalPluginConfig("diagram", &cfg); err != nil {
+		t.Fatalf("UnmarshalPluginConfig returned error: %v", err)
+	}
+	if cfg.Theme != "default" {
+		t.Errorf("Theme = %q, want defaults kept for a missing section", cfg.Theme)
+	}
+}
+
+func TestManagerUnmarshalPluginConfigTypeMismatch(t *testing.T) {
+	m := NewManager()
+	m.Config().Extra["diagram"] = map[string]interface{}{"enabled": "yes"}
+
+	var cfg testPluginConfig
+	err := m.UnmarshalPluginConfig("diagram", &cfg)
+	if err == nil || !strings.Contains(err.Error(), "config diagram") {
+		t.Errorf("UnmarshalPluginConfig error = %v, want an error naming the section", err)
+	}
+}
+
 func TestManagerCache(t *testing.T) {
 	m := NewManager()
 
@@ -615,6 +994,162 @@ func TestManagerConcurrentProcessingErrorHandling(t *testing.T) {
 	}
 }
 
+func TestManagerConcurrentProcessingAggregatesErrors(t *testing.T) {
+	m := NewManager()
+	m.SetConcurrency(3)
+
+	posts := make([]*models.Post, 10)
+	for i := range posts {
+		posts[i] = &models.Post{Path: fmt.Sprintf("post-%d.md", i)}
+	}
+	m.SetPosts(posts)
+
+	errBoom := errors.New("boom")
+	var callCount int64
+	err := m.ProcessPostsConcurrently(func(post *models.Post) error {
+		atomic.AddInt64(&callCount, 1)
+		if post.Path == "post-2.md" || post.Path == "post-7.md" {
+			return errBoom
+		}
+		return nil
+	})
+
+	var postErrs *PostErrors
+	if !errors.As(err, &postErrs) {
+		t.Fatalf("err = %v, want *PostErrors", err)
+	}
+	if len(postErrs.Errors) != 2 {
+		t.Errorf("len(Errors) = %d, want 2: %v", len(postErrs.Errors), postErrs.Errors)
+	}
+	if !errors.Is(err, errBoom) {
+		t.Errorf("errors.Is(err, errBoom) = false for %v", err)
+	}
+	if !strings.Contains(err.Error(), "2 posts failed to process") {
+		t.Errorf("err = %q, want the failure count", err)
+	}
+	if got := atomic.LoadInt64(&callCount); got != 10 {
+		t.Errorf("processed %d posts, want all 10", got)
+	}
+}
+
+func TestManagerConcurrentProcessingFailFast(t *testing.T) {
+	m := NewManager()
+	m.SetConcurrency(1)
+	m.SetFailFast(true)
+
+	posts := make([]*models.Post, 10)
+	for i := range posts {
+		posts[i] = &models.Post{Path: fmt.Sprintf("post-%d.md", i)}
+	}
+	m.SetPosts(posts)
+
+	errBoom := errors.New("boom")
+	var callCount int64
+	err := m.ProcessPostsConcurrently(func(post *models.Post) error {
+		atomic.AddInt64(&callCount, 1)
+		if post.Path == "post-1.md" {
+			return errBoom
+		}
+		return nil
+	})
+
+	if !errors.Is(err, errBoom) {
+		t.Fatalf("err = %v, want errBoom", err)
+	}
+	var postErrs *PostErrors
+	if errors.As(err, &postErrs) {
+		t.Errorf("err = %v, want the first error rather than *PostErrors", err)
+	}
+	if !strings.Contains(err.Error(), "post-1.md") {
+		t.Errorf("err = %q, want it to name the failing post", err)
+	}
+	if got := atomic.LoadInt64(&callCount); got != 2 {
+		t.Errorf("processed %d posts, want 2 (stopping after the failure)", got)
+	}
+}
+
+func TestManagerRunContextCancelStopsWork(t *testing.T) {
+	m := NewManager()
+	m.SetConcurrency(2)
+
+	posts := make([]*models.Post, 1000)
+	for i := range posts {
+		posts[i] = &models.Post{Path: fmt.Sprintf("post-%d.md", i)}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var processed int64
+	plugin := NewTestPlugin("worker")
+	plugin.loadFn = func(m *Manager) error {
+		m.SetPosts(posts)
+		return nil
+	}
+	plugin.transformFn = func(m *Manager) error {
+		return m.ProcessPostsConcurrently(func(_ *models.Post) error {
+			if atomic.AddInt64(&processed, 1) == 5 {
+				cancel()
+			}
+			return nil
+		})
+	}
+	later := NewTestPlugin("later")
+	m.RegisterPlugins(plugin, later)
+
+	err := m.RunContext(ctx)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("RunContext() = %v, want context.Canceled", err)
+	}
+	if got := atomic.LoadInt64(&processed); got >= int64(len(posts)) || got > 10 {
+		t.Errorf("processed %d posts after cancel, want work to stop promptly", got)
+	}
+	if m.HasRun(StageTransform) {
+		t.Error("transform stage marked complete after cancellation")
+	}
+	for _, stage := range later.stagesRun {
+		if StageIndex(stage) >= StageIndex(StageRender) {
+			t.Errorf("plugin ran %s after cancellation", stage)
+		}
+	}
+	if len(m.Warnings()) != 0 {
+		t.Errorf("cancellation recorded as warnings: %v", m.Warnings())
+	}
+
+	// The context only applies to that run
+	if m.Context().Err() != nil {
+		t.Error("Context() still canceled after RunContext returned")
+	}
+	if err := m.RunContext(context.Background()); err != nil {
+		t.Errorf("RunContext() after cancel = %v, want the build to resume", err)
+	}
+}
+
+func TestManagerRunContextAlreadyCanceled(t *testing.T) {
+	m := NewManager()
+	plugin := NewTestPlugin("p")
+	m.RegisterPlugin(plugin)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if err := m.RunContext(ctx); !errors.Is(err, context.Canceled) {
+		t.Fatalf("RunContext() = %v, want context.Canceled", err)
+	}
+	if len(plugin.stagesRun) != 0 {
+		t.Errorf("stages ran with a canceled context: %v", plugin.stagesRun)
+	}
+}
+
+func TestManagerSetConcurrencyZeroUsesDefault(t *testing.T) {
+	m := NewManager()
+	m.SetConcurrency(1)
+	m.SetConcurrency(0)
+	if got, want := m.Concurrency(), defaultConcurrency(); got != want {
+		t.Errorf("Concurrency() = %d, want default %d", got, want)
+	}
+}
+
 // TestProcessPostsConcurrentlyGoroutineBound verifies that ProcessPostsConcurrently
 // uses a bounded worker pool and does not spawn a goroutine per post.
 // This is critical for large builds (5k+ posts) to avoid scheduler overhead.
@@ -688,6 +1223,81 @@ func TestProcessPostsConcurrentlyGoroutineBound(t *testing.T) {
 		baselineGoroutines, peakGoroutines, goroutineIncrease, maxExpected)
 }
 
+func TestManagerProfile(t *testing.T) {
+	m := NewManager()
+	m.SetConcurrency(4)
+
+	posts := make([]*models.Post, 8)
+	for i := range posts {
+		posts[i] = &models.Post{Path: "test.md"}
+	}
+
+	loader := NewTestPlugin("loader")
+	loader.loadFn = func(m *Manager) error {
+		m.SetPosts(posts)
+		return nil
+	}
+	renderer := NewTestPlugin("renderer")
+	renderer.renderFn = func(m *Manager) error {
+		return m.ProcessPostsConcurrently(func(_ *models.Post) error {
+			time.Sleep(5 * time.Millisecond)
+			return nil
+		})
+	}
+	m.RegisterPlugins(loader, renderer)
+
+	if err := m.RunTo(StageRender); err != nil {
+		t.Fatalf("RunTo(StageRender) returned error: %v", err)
+	}
+
+	profile := m.Profile()
+	if len(profile) != len(StagesUpTo(StageRender)) {
+		t.Fatalf("Expected %d stage timings, got %d", len(StagesUpTo(StageRender)), len(profile))
+	}
+
+	render := profile[len(profile)-1]
+	if render.Stage != StageRender {
+		t.Fatalf("Expected last stage %s, got %s", StageRender, render.Stage)
+	}
+	if render.Posts != len(posts) {
+		t.Errorf("Expected stage posts %d, got %d", len(posts), render.Posts)
+	}
+	if len(render.Plugins) != 2 || render.Plugins[1].Plugin != "renderer" {
+		t.Fatalf("Expected loader and renderer timings, got %+v", render.Plugins)
+	}
+
+	timing := render.Plugins[1]
+	if timing.Posts != len(posts) {
+		t.Errorf("Expected renderer posts %d, got %d", len(posts), timing.Posts)
+	}
+	if timing.CPU < 8*5*time.Millisecond {
+		t.Errorf("Expected renderer CPU >= 40ms (sum of workers), got %v", timing.CPU)
+	}
+	if timing.CPU <= timing.Wall {
+		t.Errorf("Expected concurrent CPU %v to exceed wall %v", timing.CPU, timing.Wall)
+	}
+	if render.Wall < timing.Wall {
+		t.Errorf("Expected stage wall %v >= plugin wall %v", render.Wall, timing.Wall)
+	}
+
+	m.Reset()
+	if got := m.Profile(); len(got) != 0 {
+		t.Errorf("Expected empty profile after Reset, got %d stages", len(got))
+	}
+}
+
+func TestManagerProfileIgnoresSubsetHooks(t *testing.T) {
+	m := NewManager()
+	m.RegisterPlugin(NewTestPlugin("test"))
+
+	if err := RunRenderHooksSubset(m, nil); err != nil {
+		t.Fatalf("RunRenderHooksSubset returned error: %v", err)
+	}
+	if got := m.Profile(); len(got) != 0 {
+		t.Errorf("Expected no stage timings outside of Run, got %+v", got)
+	}
+}
+
 func TestInvalidStage(t *testing.T) {
 	m := NewManager()
 
@@ -696,3 +1306,38 @@ func TestInvalidStage(t *testing.T) {
 		t.Error("Expected error for invalid stage, got nil")
 	}
 }
+
+func TestManagerBuildCacheOptions(t *testing.T) {
+	m := NewManager()
+	m.Config().ContentDir = "site"
+
+	if !m.BuildCacheEnabled() {
+		t.Error("build cache should be enabled by default")
+	}
+	if got, want := m.BuildCacheDir(), filepath.Join("site", DefaultBuildCacheDir); got != want {
+		t.Errorf("BuildCacheDir() = %q, want %q", got, want)
+	}
+
+	m.Config().Extra["cache_dir"] = "from-config"
+	m.Config().Extra["build_cache"] = map[string]interface{}{"enabled": false}
+	if m.BuildCacheEnabled() {
+		t.Error("build_cache.enabled = false should disable the build cache")
+	}
+	if got := m.BuildCacheDir(); got != "from-config" {
+		t.Errorf("BuildCacheDir() = %q, want cache_dir from config", got)
+	}
+
+	m.SetBuildCacheEnabled(true)
+	m.SetBuildCacheDir("override")
+	if !m.BuildCacheEnabled() {
+		t.Error("SetBuildCacheEnabled(true) should override the config")
+	}
+	if got := m.BuildCacheDir(); got != "override" {
+		t.Errorf("BuildCacheDir() = %q, want %q", got, "override")
+	}
+
+	m.SetBuildCacheDir("")
+	if got := m.BuildCacheDir(); got != "from-config" {
+		t.Errorf("BuildCacheDir() after reset = %q, want %q", got, "from-config")
+	}
+}
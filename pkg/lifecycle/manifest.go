@@ -0,0 +1,192 @@
+package lifecycle
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// ManifestFileName is the name of the build manifest written to the output
+// directory. It is never listed in the manifest itself.
+const ManifestFileName = "manifest.json"
+
+// ManifestEntry describes one file in the build output.
+type ManifestEntry struct {
+	// Path is the file path relative to the output directory, using forward
+	// slashes (e.g., "blog/hello/index.html").
+	Path string `json:"path"`
+
+	// Size is the file size in bytes.
+	Size int64 `json:"size"`
+
+	// Hash is the hex-encoded SHA-256 of the file contents.
+	Hash string `json:"hash"`
+
+	// Post is the slug of the post the file was generated from, if any.
+	// The home page post has an empty slug and is reported as "/".
+	Post string `json:"post,omitempty"`
+
+	// Feed is the slug of the feed the file was generated from, if any.
+	// The home feed has an empty slug and is reported as "/".
+	Feed string `json:"feed,omitempty"`
+}
+
+// manifestRoot is how an empty (home page) slug is reported in the manifest.
+const manifestRoot = "/"
+
+// rootFeedFiles are the files a feed with an empty slug writes to the root of
+// the output directory, besides its page/ subtree.
+var rootFeedFiles = map[string]bool{
+	"index.html": true,
+	"rss.xml":    true,
+	"atom.xml":   true,
+	"index.json": true,
+}
+
+// BuildManifest walks outputDir and returns an entry for every regular file,
+// sorted by path. Files are attributed to the post or feed whose output
+// directory most closely contains them; a post wins over a feed with the same
+// slug. Files outside any post or feed directory, such as static assets, have
+// neither set.
+//
+// If outputDir already holds a manifest from a previous build, files that
+// have not been modified since it was written and still have the recorded
+// size reuse the recorded hash instead of being read again.
+func BuildManifest(outputDir string, postSlugs, feedSlugs []string) ([]ManifestEntry, error) {
+	posts := make(map[string]bool, len(postSlugs))
+	for _, slug := range postSlugs {
+		posts[slug] = true
+	}
+	feeds := make(map[string]bool, len(feedSlugs))
+	for _, slug := range feedSlugs {
+		feeds[slug] = true
+	}
+	previous, writtenAt := readManifest(filepath.Join(outputDir, ManifestFileName))
+
+	var entries []ManifestEntry
+	err := filepath.WalkDir(outputDir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		rel, err := filepath.Rel(outputDir, p)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if rel == ManifestFileName {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		entry := ManifestEntry{Path: rel, Size: info.Size()}
+		if prev, ok := previous[rel]; ok && prev.Size == info.Size() && info.ModTime().Before(writtenAt) {
+			entry.Hash = prev.Hash
+		} else if entry.Size, entry.Hash, err = hashFile(p); err != nil {
+			return err
+		}
+		entry.Post, entry.Feed = manifestSource(rel, posts, feeds)
+		entries = append(entries, entry)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// manifestSource returns the post or feed slug that owns rel by checking each
+// parent directory from the deepest up.
+func manifestSource(rel string, posts, feeds map[string]bool) (post, feed string) {
+	for dir := path.Dir(rel); dir != "."; dir = path.Dir(dir) {
+		if posts[dir] {
+			return dir, ""
+		}
+		if feeds[dir] {
+			return "", dir
+		}
+	}
+
+	// Empty slugs write to the output root, which also holds every
+	// unrelated file, so only claim the files they are known to write.
+	if rel == "index.html" && posts[""] {
+		return manifestRoot, ""
+	}
+	if feeds[""] && (rootFeedFiles[rel] || strings.HasPrefix(rel, "page/")) {
+		return "", manifestRoot
+	}
+	return "", ""
+}
+
+// readManifest loads a previously written manifest keyed by path, along with
+// the time it was written. A missing or unreadable manifest yields no entries.
+func readManifest(p string) (map[string]ManifestEntry, time.Time) {
+	info, err := os.Stat(p)
+	if err != nil {
+		return nil, time.Time{}
+	}
+	data, err := os.ReadFile(p)
+	if err != nil {
+		return nil, time.Time{}
+	}
+	var entries []ManifestEntry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, time.Time{}
+	}
+
+	byPath := make(map[string]ManifestEntry, len(entries))
+	for _, e := range entries {
+		if e.Hash != "" {
+			byPath[e.Path] = e
+		}
+	}
+	return byPath, info.ModTime()
+}
+
+// hashFile returns the size and hex-encoded SHA-256 of the file at p.
+func hashFile(p string) (int64, string, error) {
+	f, err := os.Open(p)
+	if err != nil {
+		return 0, "", err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	n, err := io.Copy(h, f)
+	if err != nil {
+		return 0, "", err
+	}
+	return n, hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// SetManifest stores the output manifest of the current build.
+func (m *Manager) SetManifest(entries []ManifestEntry) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.manifest = entries
+}
+
+// Manifest returns a copy of the output manifest recorded by the build, or
+// nil if none was recorded (e.g., the build_manifest plugin is disabled).
+func (m *Manager) Manifest() []ManifestEntry {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if m.manifest == nil {
+		return nil
+	}
+	result := make([]ManifestEntry, len(m.manifest))
+	copy(result, m.manifest)
+	return result
+}
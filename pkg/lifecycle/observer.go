@@ -0,0 +1,36 @@
+package lifecycle
+
+// Observer receives build progress notifications from a Manager, built on
+// the same measurements as Profile. Methods are called synchronously from
+// the goroutine running the stage, so implementations must return quickly
+// and hand slow work off elsewhere.
+type Observer interface {
+	// StageStarted is called before the hooks of a stage run.
+	StageStarted(stage Stage)
+
+	// PluginStarted is called before a plugin's hook for stage runs.
+	PluginStarted(stage Stage, plugin string)
+
+	// PluginFinished is called after a plugin's hook for stage returns,
+	// with its timing and the error it returned, if any.
+	PluginFinished(stage Stage, timing PluginTiming, err error)
+
+	// StageFinished is called after a stage ends, with its timing and the
+	// non-critical errors it collected.
+	StageFinished(timing StageTiming, warnings []*HookError)
+}
+
+// SetObserver registers o to receive build progress notifications,
+// replacing any previous observer. Pass nil to stop notifications.
+func (m *Manager) SetObserver(o Observer) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.observer = o
+}
+
+// currentObserver returns the registered observer, or nil.
+func (m *Manager) currentObserver() Observer {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.observer
+}
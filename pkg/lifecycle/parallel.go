@@ -0,0 +1,116 @@
+package lifecycle
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"sync"
+	"time"
+)
+
+// maxDefaultConcurrency caps the auto-detected concurrency to avoid
+// excessive goroutine overhead on large machines.
+const maxDefaultConcurrency = 16
+
+// defaultConcurrency returns the number of CPU cores, capped at
+// maxDefaultConcurrency.
+func defaultConcurrency() int {
+	return max(1, min(runtime.NumCPU(), maxDefaultConcurrency))
+}
+
+// PostErrors collects the errors of every item that failed in one
+// concurrent processing call when errors are aggregated (see SetFailFast).
+type PostErrors struct {
+	Errors []error
+}
+
+func (e *PostErrors) Error() string {
+	return fmt.Sprintf("%d posts failed to process; first error: %v", len(e.Errors), e.Errors[0])
+}
+
+// Unwrap returns every collected error, for errors.Is and errors.As.
+func (e *PostErrors) Unwrap() []error {
+	return e.Errors
+}
+
+// processConcurrently calls fn for each item on a pool of Concurrency()
+// workers. Items are handed out one at a time, so once the build's context
+// is canceled, or an item fails in fail-fast mode, no further items start;
+// items already running finish. A canceled build returns the context's
+// error. Otherwise a fail-fast run returns the first error and an
+// aggregating run returns a *PostErrors with every failure.
+func processConcurrently[T any](m *Manager, items []T, name func(T) string, fn func(int, T) error) error {
+	if len(items) == 0 {
+		return nil
+	}
+
+	start := time.Now()
+	defer func() { m.hookCounters.wall.Add(int64(time.Since(start))) }()
+	m.hookCounters.posts.Add(int64(len(items)))
+
+	parent := m.Context()
+	ctx, cancel := context.WithCancel(parent)
+	defer cancel()
+	failFast := m.FailFast()
+
+	type job struct {
+		index int
+		item  T
+	}
+	jobs := make(chan job)
+
+	var (
+		errMu sync.Mutex
+		errs  []error
+		wg    sync.WaitGroup
+	)
+
+	numWorkers := min(m.Concurrency(), len(items))
+	for range numWorkers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range jobs {
+				itemStart := time.Now()
+				err := fn(j.index, j.item)
+				m.hookCounters.busy.Add(int64(time.Since(itemStart)))
+				if err == nil {
+					continue
+				}
+
+				errMu.Lock()
+				errs = append(errs, fmt.Errorf("processing %s: %w", name(j.item), err))
+				errMu.Unlock()
+				if failFast {
+					cancel()
+				}
+			}
+		}()
+	}
+
+send:
+	for i, item := range items {
+		if ctx.Err() != nil {
+			break
+		}
+		select {
+		case jobs <- job{index: i, item: item}:
+		case <-ctx.Done():
+			break send
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	if err := parent.Err(); err != nil {
+		return err
+	}
+	switch {
+	case len(errs) == 0:
+		return nil
+	case failFast:
+		return errs[0]
+	default:
+		return &PostErrors{Errors: errs}
+	}
+}
@@ -62,6 +62,14 @@ type WritePlugin interface {
 	Write(m *Manager) error
 }
 
+// VerifyPlugin is implemented by plugins that participate in the verify stage.
+// This stage runs after the write stage to check the output for breakage.
+// Every verify plugin runs; their errors are then reported together and fail the build.
+type VerifyPlugin interface {
+	Plugin
+	Verify(m *Manager) error
+}
+
 // CleanupPlugin is implemented by plugins that participate in the cleanup stage.
 // This stage is used to release resources and perform cleanup tasks.
 type CleanupPlugin interface {
@@ -80,6 +88,17 @@ type PriorityPlugin interface {
 	Priority(stage Stage) int
 }
 
+// DependencyPlugin can be implemented by plugins that must run after other
+// plugins within a stage. It is more robust than guessing priority numbers:
+// the Manager orders plugins so that every named dependency runs first, and
+// uses priorities (then registration order) only to break ties.
+type DependencyPlugin interface {
+	Plugin
+	// DependsOn returns the names of plugins that must run before this one
+	// in the given stage. Names of plugins that are not registered are ignored.
+	DependsOn(stage Stage) []string
+}
+
 // Priority constants for common ordering scenarios.
 const (
 	// PriorityFirst ensures a plugin runs before most others.
@@ -0,0 +1,121 @@
+package lifecycle
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// StageTiming records how long a lifecycle stage took and how that time was
+// split between the plugins that ran in it.
+type StageTiming struct {
+	// Stage is the lifecycle stage that ran.
+	Stage Stage
+
+	// Wall is the elapsed wall-clock time of the stage.
+	Wall time.Duration
+
+	// CPU is the summed busy time of all plugins in the stage. It exceeds
+	// Wall when plugins process posts concurrently.
+	CPU time.Duration
+
+	// Posts is the number of posts held by the manager when the stage finished.
+	Posts int
+
+	// Plugins lists per-plugin timings in execution order.
+	Plugins []PluginTiming
+}
+
+// PluginTiming records how long a single plugin hook took within a stage.
+type PluginTiming struct {
+	// Plugin is the plugin name.
+	Plugin string
+
+	// Wall is the elapsed wall-clock time of the hook.
+	Wall time.Duration
+
+	// CPU is the busy time of the hook. Work done through
+	// ProcessPostsConcurrently or ProcessPostsSliceConcurrently counts the
+	// time spent by every worker; all other work counts at wall-clock time.
+	CPU time.Duration
+
+	// Posts is the number of posts the hook processed through the concurrent
+	// helpers. It is zero for hooks that do not use them.
+	Posts int
+}
+
+// hookCounters accumulates concurrent-helper activity for the running hook.
+// Workers update it with atomic adds so the per-post path does not allocate.
+type hookCounters struct {
+	busy  atomic.Int64 // summed worker time in nanoseconds
+	wall  atomic.Int64 // wall time spent inside the concurrent helpers
+	posts atomic.Int64 // posts handed to the concurrent helpers
+}
+
+func (c *hookCounters) reset() {
+	c.busy.Store(0)
+	c.wall.Store(0)
+	c.posts.Store(0)
+}
+
+// Profile returns per-stage and per-plugin timings for the stages run since
+// the manager was created or last Reset, in execution order.
+func (m *Manager) Profile() []StageTiming {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	result := make([]StageTiming, len(m.profile))
+	for i, st := range m.profile {
+		st.Plugins = append([]PluginTiming(nil), st.Plugins...)
+		result[i] = st
+	}
+	return result
+}
+
+// beginStageTiming starts collecting plugin timings for stage.
+func (m *Manager) beginStageTiming(stage Stage) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.activeTiming = &StageTiming{Stage: stage}
+}
+
+// endStageTiming stores and returns the timing of the stage started by
+// beginStageTiming.
+func (m *Manager) endStageTiming(wall time.Duration) StageTiming {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.activeTiming == nil {
+		return StageTiming{}
+	}
+	m.activeTiming.Wall = wall
+	m.activeTiming.Posts = len(m.posts)
+	timing := *m.activeTiming
+	m.profile = append(m.profile, timing)
+	m.activeTiming = nil
+	timing.Plugins = append([]PluginTiming(nil), timing.Plugins...)
+	return timing
+}
+
+// recordPluginTiming adds a hook timing to the active stage, if any, and
+// returns it. Hooks run outside of a stage (e.g. RunRenderHooksSubset) are
+// not recorded.
+func (m *Manager) recordPluginTiming(plugin string, wall time.Duration) PluginTiming {
+	concurrentWall := time.Duration(m.hookCounters.wall.Load())
+	cpu := wall - concurrentWall + time.Duration(m.hookCounters.busy.Load())
+	if cpu < 0 {
+		cpu = 0
+	}
+	timing := PluginTiming{
+		Plugin: plugin,
+		Wall:   wall,
+		CPU:    cpu,
+		Posts:  int(m.hookCounters.posts.Load()),
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.activeTiming == nil {
+		return timing
+	}
+	m.activeTiming.Plugins = append(m.activeTiming.Plugins, timing)
+	m.activeTiming.CPU += cpu
+	return timing
+}
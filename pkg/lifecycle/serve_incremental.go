@@ -14,8 +14,34 @@ const (
 	CacheKeyServeCachedPosts  = "serve.cached_posts"
 	CacheKeyServeRemovedPaths = "serve.removed_paths"
 	CacheKeyServeGlobDirty    = "serve.glob_dirty"
+	CacheKeyServeMode         = "serve.mode"
 )
 
+// SetServeMode marks the build as a development server build.
+func SetServeMode(m *Manager, serving bool) {
+	if m == nil {
+		return
+	}
+	if !serving {
+		m.Cache().Delete(CacheKeyServeMode)
+		return
+	}
+	m.Cache().Set(CacheKeyServeMode, true)
+}
+
+// IsServeMode returns true when the build runs under the development server.
+func IsServeMode(m *Manager) bool {
+	if m == nil {
+		return false
+	}
+	if raw, ok := m.Cache().Get(CacheKeyServeMode); ok {
+		if serving, ok := raw.(bool); ok {
+			return serving
+		}
+	}
+	return false
+}
+
 // SetServeChangedPaths stores normalized, relative content paths that changed.
 func SetServeChangedPaths(m *Manager, paths []string) {
 	if m == nil {
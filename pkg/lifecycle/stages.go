@@ -28,6 +28,9 @@ const (
 	// StageWrite writes output files.
 	StageWrite Stage = "write"
 
+	// StageVerify checks the written output.
+	StageVerify Stage = "verify"
+
 	// StageCleanup releases resources.
 	StageCleanup Stage = "cleanup"
 )
@@ -42,6 +45,7 @@ var StageOrder = []Stage{
 	StageRender,
 	StageCollect,
 	StageWrite,
+	StageVerify,
 	StageCleanup,
 }
 
@@ -0,0 +1,383 @@
+package lifecycle
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// DefaultWatchDebounce is how long a Watcher waits after the last change
+// before rebuilding.
+const DefaultWatchDebounce = 200 * time.Millisecond
+
+// WatchChange is a batch of changed files that triggers one rebuild.
+type WatchChange struct {
+	// Paths are the absolute paths that changed, sorted.
+	Paths []string
+
+	// Full is true when a change can affect every page (templates, config,
+	// palettes, aesthetics, or assets), so an incremental build is not
+	// enough.
+	Full bool
+}
+
+// WatchOptions configures a Watcher.
+type WatchOptions struct {
+	// Debounce is the quiet period after the last change before a rebuild
+	// starts. Defaults to DefaultWatchDebounce.
+	Debounce time.Duration
+
+	// Rebuild runs a rebuild for a batch of changes. Defaults to
+	// Manager.RunIncremental with the changed paths, or with no paths for a
+	// full rebuild.
+	Rebuild func(ctx context.Context, change WatchChange) error
+
+	// OnRebuild, if set, is called after each rebuild with its error.
+	OnRebuild func(change WatchChange, err error)
+
+	// OnError, if set, is called with errors reported by the file watcher.
+	OnError func(err error)
+}
+
+// Watcher rebuilds a site when its sources change. Content roots derived
+// from the glob patterns trigger incremental rebuilds; the templates,
+// palettes, and aesthetics directories and the config files trigger full
+// rebuilds, as does the assets directory. Rebuild errors are reported and
+// the watcher keeps running.
+//
+// Directories are watched rather than files, so editors that save by
+// writing a temporary file and renaming it over the original are seen as a
+// change to the original. Temporary and backup files are ignored.
+type Watcher struct {
+	opts    WatchOptions
+	manager *Manager
+
+	contentRoots []string
+	fullRoots    []string
+	fullFiles    map[string]bool
+	ignoreDirs   []string
+
+	// rootsChanged is set by SetManager so the loop re-registers the
+	// watched directories after the current rebuild.
+	rootsChanged bool
+}
+
+// NewWatcher returns a Watcher for the sources of m's config.
+func NewWatcher(m *Manager, opts WatchOptions) *Watcher {
+	if opts.Debounce <= 0 {
+		opts.Debounce = DefaultWatchDebounce
+	}
+	w := &Watcher{opts: opts}
+	if w.opts.Rebuild == nil {
+		w.opts.Rebuild = func(ctx context.Context, change WatchChange) error {
+			if change.Full {
+				return w.manager.RunIncrementalContext(ctx, nil)
+			}
+			return w.manager.RunIncrementalContext(ctx, change.Paths)
+		}
+	}
+	w.setRoots(m)
+	return w
+}
+
+// SetManager points the watcher at m, recomputing the watched directories
+// and files from its config. Call it from Rebuild when a full rebuild
+// replaces the manager after a config change; the new roots are watched
+// once Rebuild returns.
+func (w *Watcher) SetManager(m *Manager) {
+	w.setRoots(m)
+	w.rootsChanged = true
+}
+
+// setRoots derives the watched roots of m's config.
+func (w *Watcher) setRoots(m *Manager) {
+	config := m.Config()
+	w.manager = m
+	w.contentRoots = nil
+	w.fullRoots = nil
+	w.fullFiles = make(map[string]bool)
+	for _, root := range watchContentRoots(config) {
+		w.contentRoots = append(w.contentRoots, absPath(root))
+	}
+
+	templatesDir := "templates"
+	if td, ok := config.Extra["templates_dir"].(string); ok && td != "" {
+		templatesDir = td
+	}
+	for _, dir := range []string{templatesDir, "palettes", "aesthetics"} {
+		w.fullRoots = append(w.fullRoots, absPath(dir))
+	}
+	if ad, ok := config.Extra["assets_dir"].(string); ok && ad != "" {
+		w.fullRoots = append(w.fullRoots, absPath(ad))
+	}
+
+	if p, ok := config.Extra["config_path"].(string); ok && p != "" {
+		w.fullFiles[absPath(p)] = true
+	}
+	if paths, ok := config.Extra["config_paths"].([]string); ok {
+		for _, p := range paths {
+			w.fullFiles[absPath(p)] = true
+		}
+	}
+
+	outputDir := config.OutputDir
+	if outputDir == "" {
+		outputDir = "output"
+	}
+	w.ignoreDirs = []string{absPath(outputDir), absPath(m.BuildCacheDir())}
+}
+
+// Run watches the sources until ctx is canceled. It returns an error only
+// if the watch cannot be set up.
+func (w *Watcher) Run(ctx context.Context) error {
+	fsw, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("creating file watcher: %w", err)
+	}
+	defer fsw.Close()
+
+	if err := w.watchRoots(fsw); err != nil {
+		return err
+	}
+
+	w.loop(ctx, fsw.Events, fsw.Errors, func(dir string) {
+		if err := w.addDirRecursive(fsw, dir); err != nil && w.opts.OnError != nil {
+			w.opts.OnError(fmt.Errorf("watching %s: %w", dir, err))
+		}
+	}, func() {
+		for _, path := range fsw.WatchList() {
+			_ = fsw.Remove(path)
+		}
+		if err := w.watchRoots(fsw); err != nil && w.opts.OnError != nil {
+			w.opts.OnError(err)
+		}
+	})
+	return nil
+}
+
+// watchRoots adds the content and full-rebuild roots and the directories of
+// the config files to fsw.
+func (w *Watcher) watchRoots(fsw *fsnotify.Watcher) error {
+	for _, root := range append(append([]string{}, w.contentRoots...), w.fullRoots...) {
+		if _, err := os.Stat(root); err != nil {
+			continue
+		}
+		if err := w.addDirRecursive(fsw, root); err != nil {
+			return fmt.Errorf("watching %s: %w", root, err)
+		}
+	}
+	for file := range w.fullFiles {
+		// Watch the parent so atomic saves that replace the file are seen
+		if err := fsw.Add(filepath.Dir(file)); err != nil {
+			return fmt.Errorf("watching %s: %w", file, err)
+		}
+	}
+	return nil
+}
+
+// loop collects events until the debounce period passes without changes,
+// then rebuilds once for the whole batch. addDir is called for new
+// directories inside the watched roots, and rewatch after a rebuild that
+// called SetManager.
+func (w *Watcher) loop(ctx context.Context, events <-chan fsnotify.Event, errs <-chan error, addDir func(string), rewatch func()) {
+	pending := make(map[string]bool)
+	full := false
+
+	timer := time.NewTimer(w.opts.Debounce)
+	if !timer.Stop() {
+		<-timer.C
+	}
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+			path, isFull, relevant := w.classify(event)
+			if !relevant {
+				continue
+			}
+			if event.Op&fsnotify.Create != 0 && addDir != nil {
+				if info, err := os.Stat(path); err == nil && info.IsDir() {
+					addDir(path)
+				}
+			}
+			pending[path] = true
+			full = full || isFull
+			timer.Reset(w.opts.Debounce)
+		case err, ok := <-errs:
+			if !ok {
+				errs = nil
+				continue
+			}
+			if w.opts.OnError != nil {
+				w.opts.OnError(err)
+			}
+		case <-timer.C:
+			if len(pending) == 0 {
+				continue
+			}
+			change := WatchChange{Paths: make([]string, 0, len(pending)), Full: full}
+			for path := range pending {
+				change.Paths = append(change.Paths, path)
+			}
+			sort.Strings(change.Paths)
+			pending = make(map[string]bool)
+			full = false
+
+			err := w.opts.Rebuild(ctx, change)
+			if w.rootsChanged {
+				w.rootsChanged = false
+				if rewatch != nil {
+					rewatch()
+				}
+			}
+			if w.opts.OnRebuild != nil {
+				w.opts.OnRebuild(change, err)
+			}
+		}
+	}
+}
+
+// classify returns the absolute path of an event, whether it needs a full
+// rebuild, and whether it should trigger a rebuild at all.
+func (w *Watcher) classify(event fsnotify.Event) (path string, full, relevant bool) {
+	if event.Op&(fsnotify.Write|fsnotify.Create|fsnotify.Remove|fsnotify.Rename) == 0 {
+		return "", false, false
+	}
+	if isTemporaryFile(event.Name) {
+		return "", false, false
+	}
+
+	path = absPath(event.Name)
+	for _, dir := range w.ignoreDirs {
+		if withinDir(path, dir) {
+			return "", false, false
+		}
+	}
+
+	if w.fullFiles[path] {
+		return path, true, true
+	}
+	for _, root := range w.fullRoots {
+		if withinDir(path, root) {
+			return path, true, true
+		}
+	}
+	for _, root := range w.contentRoots {
+		if withinDir(path, root) {
+			return path, false, true
+		}
+	}
+	// A sibling of a config file in a directory watched only for it
+	return "", false, false
+}
+
+// addDirRecursive watches root and its subdirectories, skipping hidden and
+// ignored directories.
+func (w *Watcher) addDirRecursive(fsw *fsnotify.Watcher, root string) error {
+	return filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() {
+			return nil
+		}
+		if path != root && strings.HasPrefix(d.Name(), ".") {
+			return filepath.SkipDir
+		}
+		abs := absPath(path)
+		for _, dir := range w.ignoreDirs {
+			if withinDir(abs, dir) {
+				return filepath.SkipDir
+			}
+		}
+		return fsw.Add(path)
+	})
+}
+
+// watchContentRoots returns the directories holding content: the content
+// directory, or when it is the working directory, the literal prefixes of
+// the glob patterns so generated trees are not watched.
+func watchContentRoots(config *Config) []string {
+	contentDir := config.ContentDir
+	if contentDir == "" {
+		contentDir = "."
+	}
+	if contentDir != "." {
+		return []string{contentDir}
+	}
+
+	seen := make(map[string]bool)
+	var roots []string
+	for _, pattern := range config.GlobPatterns {
+		if strings.HasPrefix(pattern, "!") {
+			continue
+		}
+		root := WatchRootFromPattern(pattern)
+		if !seen[root] {
+			seen[root] = true
+			roots = append(roots, root)
+		}
+	}
+	if len(roots) == 0 {
+		return []string{contentDir}
+	}
+	sort.Strings(roots)
+	return roots
+}
+
+// WatchRootFromPattern returns the directory holding the files a glob
+// pattern can match: its directories before the first path segment
+// containing a glob metacharacter. Absolute patterns give absolute roots.
+func WatchRootFromPattern(pattern string) string {
+	dir := filepath.Dir(filepath.Clean(filepath.FromSlash(pattern)))
+	for strings.ContainsAny(dir, "*?[{") {
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			break
+		}
+		dir = parent
+	}
+	return dir
+}
+
+// isTemporaryFile reports whether name looks like an editor's swap, backup,
+// or atomic-save temporary file.
+func isTemporaryFile(name string) bool {
+	base := filepath.Base(name)
+	return strings.HasPrefix(base, ".") ||
+		strings.HasSuffix(base, "~") ||
+		strings.HasSuffix(base, ".swp") ||
+		strings.HasSuffix(base, ".swo") ||
+		strings.HasSuffix(base, ".swx") ||
+		strings.HasSuffix(base, ".tmp") ||
+		(strings.HasPrefix(base, "#") && strings.HasSuffix(base, "#")) ||
+		base == "4913" // vim's write-permission probe
+}
+
+// absPath returns the absolute form of path, or path if it has none.
+func absPath(path string) string {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return filepath.Clean(path)
+	}
+	return abs
+}
+
+// withinDir reports whether path is dir or inside it.
+func withinDir(path, dir string) bool {
+	rel, err := filepath.Rel(dir, path)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
@@ -0,0 +1,264 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// watchHarness runs a Watcher loop over a fake event channel and records
+// the rebuilds it triggers.
+type watchHarness struct {
+	root   string
+	events chan fsnotify.Event
+	cancel context.CancelFunc
+	done   chan struct{}
+
+	mu       sync.Mutex
+	changes  []WatchChange
+	rebuilds chan struct{}
+}
+
+func newWatchHarness(t *testing.T, rebuildErr error) *watchHarness {
+	t.Helper()
+	root := t.TempDir()
+
+	m := NewManager()
+	config := NewConfig()
+	config.ContentDir = filepath.Join(root, "posts")
+	config.OutputDir = filepath.Join(root, "output")
+	config.Extra["templates_dir"] = filepath.Join(root, "templates")
+	config.Extra["config_path"] = filepath.Join(root, "markata-go.toml")
+	m.SetConfig(config)
+
+	h := &watchHarness{
+		root:     root,
+		events:   make(chan fsnotify.Event),
+		done:     make(chan struct{}),
+		rebuilds: make(chan struct{}, 16),
+	}
+	w := NewWatcher(m, WatchOptions{
+		Debounce: 50 * time.Millisecond,
+		Rebuild: func(_ context.Context, change WatchChange) error {
+			h.mu.Lock()
+			h.changes = append(h.changes, change)
+			h.mu.Unlock()
+			h.rebuilds <- struct{}{}
+			return rebuildErr
+		},
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	h.cancel = cancel
+	go func() {
+		defer close(h.done)
+		w.loop(ctx, h.events, nil, nil, nil)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-h.done
+	})
+	return h
+}
+
+func (h *watchHarness) send(op fsnotify.Op, rel string) {
+	h.events <- fsnotify.Event{Name: filepath.Join(h.root, filepath.FromSlash(rel)), Op: op}
+}
+
+// wait returns the next rebuild, failing if none happens in time.
+func (h *watchHarness) wait(t *testing.T) WatchChange {
+	t.Helper()
+	select {
+	case <-h.rebuilds:
+	case <-time.After(2 * time.Second):
+		t.Fatal("no rebuild triggered")
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.changes[len(h.changes)-1]
+}
+
+// expectNoRebuild fails if another rebuild happens within a few debounce
+// periods.
+func (h *watchHarness) expectNoRebuild(t *testing.T) {
+	t.Helper()
+	select {
+	case <-h.rebuilds:
+		t.Fatal("unexpected extra rebuild")
+	case <-time.After(200 * time.Millisecond):
+	}
+}
+
+func TestWatcher_DebounceCoalescesEvents(t *testing.T) {
+	h := newWatchHarness(t, nil)
+
+	for i := 0; i < 5; i++ {
+		h.send(fsnotify.Write, "posts/a.md")
+		h.send(fsnotify.Write, "posts/b.md")
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	change := h.wait(t)
+	want := []string{filepath.Join(h.root, "posts", "a.md"), filepath.Join(h.root, "posts", "b.md")}
+	if change.Full {
+		t.Error("content changes should rebuild incrementally")
+	}
+	if len(change.Paths) != 2 || change.Paths[0] != want[0] || change.Paths[1] != want[1] {
+		t.Errorf("Paths = %v, want %v", change.Paths, want)
+	}
+	h.expectNoRebuild(t)
+}
+
+func TestWatcher_AtomicSave(t *testing.T) {
+	h := newWatchHarness(t, nil)
+
+	// Write a temporary file, then rename it over the original
+	h.send(fsnotify.Create, "posts/.a.md.swp")
+	h.send(fsnotify.Write, "posts/a.md~")
+	h.send(fsnotify.Create, "posts/4913")
+	h.send(fsnotify.Rename, "posts/a.md")
+	h.send(fsnotify.Create, "posts/a.md")
+
+	change := h.wait(t)
+	if want := filepath.Join(h.root, "posts", "a.md"); len(change.Paths) != 1 || change.Paths[0] != want {
+		t.Errorf("Paths = %v, want [%s]", change.Paths, want)
+	}
+}
+
+func TestWatcher_FullRebuildSources(t *testing.T) {
+	tests := []struct {
+		name string
+		path string
+		full bool
+	}{
+		{"content", "posts/a.md", false},
+		{"template", "templates/post.html", true},
+		{"config", "markata-go.toml", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := newWatchHarness(t, nil)
+			h.send(fsnotify.Write, tt.path)
+			if change := h.wait(t); change.Full != tt.full {
+				t.Errorf("Full = %v, want %v", change.Full, tt.full)
+			}
+		})
+	}
+}
+
+func TestWatcher_IgnoresOutputAndUnwatchedFiles(t *testing.T) {
+	h := newWatchHarness(t, nil)
+
+	h.send(fsnotify.Write, "output/index.html")
+	h.send(fsnotify.Write, "notes.txt")
+	h.send(fsnotify.Chmod, "posts/a.md")
+	h.expectNoRebuild(t)
+}
+
+func TestWatcher_KeepsRunningAfterBuildError(t *testing.T) {
+	h := newWatchHarness(t, errors.New("template error"))
+
+	h.send(fsnotify.Write, "posts/a.md")
+	h.wait(t)
+	h.send(fsnotify.Write, "posts/b.md")
+	if change := h.wait(t); change.Paths[0] != filepath.Join(h.root, "posts", "b.md") {
+		t.Errorf("second rebuild Paths = %v", change.Paths)
+	}
+}
+
+func TestWatcher_SetManagerMovesRoots(t *testing.T) {
+	root := t.TempDir()
+	newManager := func(contentDir string) *Manager {
+		m := NewManager()
+		config := NewConfig()
+		config.ContentDir = filepath.Join(root, contentDir)
+		config.OutputDir = filepath.Join(root, "output")
+		config.Extra["config_path"] = filepath.Join(root, "markata-go.toml")
+		m.SetConfig(config)
+		return m
+	}
+
+	changes := make(chan WatchChange, 16)
+	var w *Watcher
+	w = NewWatcher(newManager("posts"), WatchOptions{
+		Debounce: 50 * time.Millisecond,
+		Rebuild: func(_ context.Context, change WatchChange) error {
+			if change.Full {
+				// The reloaded config moved the content directory
+				w.SetManager(newManager("articles"))
+			}
+			changes <- change
+			return nil
+		},
+	})
+
+	events := make(chan fsnotify.Event)
+	rewatched := make(chan struct{}, 1)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		w.loop(ctx, events, nil, nil, func() { rewatched <- struct{}{} })
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+	send := func(rel string) {
+		events <- fsnotify.Event{Name: filepath.Join(root, filepath.FromSlash(rel)), Op: fsnotify.Write}
+	}
+	wait := func() WatchChange {
+		t.Helper()
+		select {
+		case change := <-changes:
+			return change
+		case <-time.After(2 * time.Second):
+			t.Fatal("no rebuild triggered")
+			return WatchChange{}
+		}
+	}
+
+	send("markata-go.toml")
+	if change := wait(); !change.Full {
+		t.Fatal("config change should rebuild fully")
+	}
+	select {
+	case <-rewatched:
+	case <-time.After(2 * time.Second):
+		t.Fatal("watched directories were not re-registered after SetManager")
+	}
+
+	send("posts/a.md")
+	send("articles/a.md")
+	change := wait()
+	if want := filepath.Join(root, "articles", "a.md"); change.Full || len(change.Paths) != 1 || change.Paths[0] != want {
+		t.Errorf("change = %+v, want incremental rebuild of %s", change, want)
+	}
+}
+
+func TestWatchRootFromPattern(t *testing.T) {
+	tests := []struct {
+		pattern string
+		want    string
+	}{
+		{"*.md", "."},
+		{"**/*.md", "."},
+		{"posts/**/*.md", "posts"},
+		{"posts/2024/*.md", filepath.Join("posts", "2024")},
+		{"docs/a*/guide/*.md", "docs"},
+		{"./pages/index.md", "pages"},
+		{"/srv/site/posts/*.md", filepath.FromSlash("/srv/site/posts")},
+		{"/*.md", string(filepath.Separator)},
+	}
+
+	for _, tt := range tests {
+		if got := WatchRootFromPattern(tt.pattern); got != tt.want {
+			t.Errorf("WatchRootFromPattern(%q) = %q, want %q", tt.pattern, got, tt.want)
+		}
+	}
+}
@@ -10,8 +10,10 @@
 //   - Protocol-less URLs (//example.com instead of https://example.com)
 //   - H1 headings in content (templates add H1 from frontmatter title)
 //   - Fenced code blocks in admonitions without blank line (goldmark limitation)
+//   - Unclosed or wrongly nested ::: containers
 //
-// All issues can be auto-fixed using the Fix function (except H1 headings).
+// All issues can be auto-fixed using the Fix function (except H1 headings
+// and unclosed containers).
 package lint
 
 import (
@@ -27,8 +29,8 @@ var (
 	keyRegex          = regexp.MustCompile(`^([a-zA-Z_][a-zA-Z0-9_]*)\s*:`)
 	noAltRegex        = regexp.MustCompile(`!\[\]\(([^)]+)\)`)
 	protocollessRegex = regexp.MustCompile(`(\(|"|\s)//([a-zA-Z0-9][a-zA-Z0-9.-]+\.[a-zA-Z]{2,})`)
-	admonitionRegex   = regexp.MustCompile(`^(\s*)!!!\s+\w+`)
-	fencedCodeRegex   = regexp.MustCompile(`^\s*` + "```")
+	admonitionRegex   = regexp.MustCompile(`^(\s*)(?:!!!|\?\?\?\+?)\s+[\w-]+`)
+	fencedCodeRegex   = regexp.MustCompile("^\\s*(?:```|~~~)")
 )
 
 // Issue represents a linting issue found in a file.
@@ -123,6 +125,12 @@ func convertIssue(di diagnostics.Issue) Issue {
 
 // Lint analyzes content and returns any issues found.
 func Lint(filePath, content string) *Result {
+	return WithOptions(filePath, content, diagnostics.Options{})
+}
+
+// WithOptions is like Lint but applies the given diagnostics options, such
+// as per-rule overrides from the site's diagnostics config.
+func WithOptions(filePath, content string, opts diagnostics.Options) *Result {
 	result := &Result{
 		File:    filePath,
 		Content: content,
@@ -130,7 +138,7 @@ func Lint(filePath, content string) *Result {
 	}
 
 	// Use shared diagnostics (without resolver - no wikilink/mention checks)
-	diagIssues := diagnostics.Check(filePath, content, nil)
+	diagIssues := diagnostics.CheckWithOptions(filePath, content, nil, opts)
 
 	for _, di := range diagIssues {
 		result.Issues = append(result.Issues, convertIssue(di))
@@ -159,15 +167,31 @@ func WithResolver(filePath, content string, resolver diagnostics.Resolver) *Resu
 
 // Fix applies automatic fixes to the content and returns the fixed content.
 func Fix(filePath, content string) *Result {
-	result := Lint(filePath, content)
+	return FixWithOptions(filePath, content, diagnostics.Options{})
+}
+
+// FixWithOptions is like Fix but applies the given diagnostics options.
+// Rules disabled in opts.Config are neither reported nor fixed.
+func FixWithOptions(filePath, content string, opts diagnostics.Options) *Result {
+	result := WithOptions(filePath, content, opts)
 	fixed := content
 
 	// Apply fixes in order
-	fixed = fixDuplicateKeys(fixed)
-	fixed = fixDateFormats(fixed)
-	fixed = fixImageLinks(fixed)
-	fixed = fixProtocollessURLs(fixed)
-	fixed = fixAdmonitionFencedCode(fixed)
+	fixes := []struct {
+		rule string
+		fix  func(string) string
+	}{
+		{"duplicate-key", fixDuplicateKeys},
+		{"invalid-date", fixDateFormats},
+		{"missing-alt-text", fixImageLinks},
+		{"protocol-less-url", fixProtocollessURLs},
+		{"admonition-fenced-code", fixAdmonitionFencedCode},
+	}
+	for _, f := range fixes {
+		if opts.Config.RuleEnabled(f.rule) {
+			fixed = f.fix(fixed)
+		}
+	}
 
 	result.Fixed = fixed
 
@@ -3,6 +3,8 @@ package lint
 import (
 	"strings"
 	"testing"
+
+	"github.com/WaylonWalker/markata-go/pkg/diagnostics"
 )
 
 func TestLint_DuplicateKeys(t *testing.T) {
@@ -513,3 +515,24 @@ print("hello")
 		})
 	}
 }
+
+func TestFixWithOptions_SkipsDisabledRules(t *testing.T) {
+	disabled := false
+	opts := diagnostics.Options{Config: &diagnostics.Config{Rules: map[string]diagnostics.RuleConfig{
+		"missing-alt-text": {Enabled: &disabled},
+	}}}
+	content := "See ![](img.png) and [site](//example.com)\n"
+
+	result := FixWithOptions("test.md", content, opts)
+	for _, issue := range result.Issues {
+		if issue.Type == "missing-alt-text" {
+			t.Errorf("disabled rule reported: %v", issue)
+		}
+	}
+	if !strings.Contains(result.Fixed, "![](img.png)") {
+		t.Errorf("disabled rule was fixed: %q", result.Fixed)
+	}
+	if !strings.Contains(result.Fixed, "https://example.com") {
+		t.Errorf("enabled rule was not fixed: %q", result.Fixed)
+	}
+}
@@ -0,0 +1,132 @@
+package logging
+
+import (
+	"context"
+	stdlog "log"
+	"log/slog"
+	"strconv"
+	"strings"
+)
+
+// Attribute keys the Handler renders as the component and phase of a line.
+const (
+	PluginKey = "plugin"
+	StageKey  = "stage"
+)
+
+// Handler is a slog.Handler that writes records through the standard
+// logger, so structured messages get the same plain or rich formatting as
+// Logger output. The "plugin" and "stage" attributes become the component
+// and phase; other attributes are appended to the message as key=value.
+type Handler struct {
+	level  slog.Leveler
+	attrs  []slog.Attr
+	prefix string
+}
+
+// NewHandler returns a Handler that drops records below level, or below
+// info if level is nil.
+func NewHandler(level slog.Leveler) *Handler {
+	return &Handler{level: level}
+}
+
+// Enabled reports whether records at level are written.
+func (h *Handler) Enabled(_ context.Context, level slog.Level) bool {
+	minLevel := slog.LevelInfo
+	if h.level != nil {
+		minLevel = h.level.Level()
+	}
+	return level >= minLevel
+}
+
+// Handle writes r as one line.
+func (h *Handler) Handle(_ context.Context, r slog.Record) error {
+	entry := Entry{Level: levelName(r.Level)}
+	var b strings.Builder
+	b.WriteString(r.Message)
+
+	add := func(a slog.Attr) {
+		a.Value = a.Value.Resolve()
+		if a.Equal(slog.Attr{}) {
+			return
+		}
+		switch a.Key {
+		case PluginKey:
+			entry.Component = a.Value.String()
+			return
+		case StageKey:
+			entry.Phase = a.Value.String()
+			return
+		}
+		writeAttr(&b, "", a)
+	}
+	for _, a := range h.attrs {
+		add(a)
+	}
+	r.Attrs(func(a slog.Attr) bool {
+		if h.prefix != "" {
+			a.Key = h.prefix + a.Key
+		}
+		add(a)
+		return true
+	})
+
+	stdlog.Print(encodeEntry(entry, b.String()))
+	return nil
+}
+
+// WithAttrs returns a Handler that adds attrs to every record.
+func (h *Handler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	clone := *h
+	clone.attrs = append([]slog.Attr{}, h.attrs...)
+	for _, a := range attrs {
+		if h.prefix != "" {
+			a.Key = h.prefix + a.Key
+		}
+		clone.attrs = append(clone.attrs, a)
+	}
+	return &clone
+}
+
+// WithGroup returns a Handler that qualifies later attribute keys with
+// name, as "name.key".
+func (h *Handler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	clone := *h
+	clone.prefix = h.prefix + name + "."
+	return &clone
+}
+
+// writeAttr appends " key=value" to b, flattening groups.
+func writeAttr(b *strings.Builder, prefix string, a slog.Attr) {
+	if a.Value.Kind() == slog.KindGroup {
+		if a.Key != "" {
+			prefix += a.Key + "."
+		}
+		for _, ga := range a.Value.Group() {
+			writeAttr(b, prefix, ga)
+		}
+		return
+	}
+	value := a.Value.String()
+	if value == "" || strings.ContainsAny(value, " \t\n\"=") {
+		value = strconv.Quote(value)
+	}
+	b.WriteString(" " + prefix + a.Key + "=" + value)
+}
+
+// levelName returns the level name Writer styles messages by.
+func levelName(level slog.Level) string {
+	switch {
+	case level >= slog.LevelError:
+		return "error"
+	case level >= slog.LevelWarn:
+		return "warning"
+	case level >= slog.LevelInfo:
+		return "info"
+	default:
+		return "debug"
+	}
+}
@@ -2,9 +2,11 @@ package logging
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"io"
 	stdlog "log"
+	"log/slog"
 	"os"
 	"regexp"
 	"strings"
@@ -32,6 +34,7 @@ const (
 	FormatAuto  Format = "auto"
 	FormatPlain Format = "plain"
 	FormatRich  Format = "rich"
+	FormatJSON  Format = "json"
 )
 
 type Theme struct {
@@ -49,6 +52,10 @@ type Options struct {
 	NoColor    bool
 	IsTTY      bool
 	Theme      Theme
+
+	// Level, if set, drops lines below it. Lines without a level count as
+	// info unless they start with "Warning:" or "Error:".
+	Level slog.Leveler
 }
 
 type Writer struct {
@@ -57,6 +64,7 @@ type Writer struct {
 	format Format
 	color  bool
 	theme  Theme
+	level  slog.Leveler
 	buf    bytes.Buffer
 }
 
@@ -89,8 +97,10 @@ func ParseFormat(raw string) (Format, error) {
 		return FormatPlain, nil
 	case FormatRich:
 		return FormatRich, nil
+	case FormatJSON:
+		return FormatJSON, nil
 	default:
-		return "", fmt.Errorf("invalid log format %q (expected auto, plain, or rich)", raw)
+		return "", fmt.Errorf("invalid log format %q (expected auto, plain, rich, or json)", raw)
 	}
 }
 
@@ -122,6 +132,7 @@ func NewWriter(opts Options) *Writer {
 		format: resolveFormat(format, opts.IsTTY),
 		color:  allowColor(opts),
 		theme:  theme,
+		level:  opts.Level,
 	}
 }
 
@@ -242,7 +253,11 @@ func (w *Writer) Write(p []byte) (int, error) {
 			_, _ = w.buf.WriteString(remaining)
 			break
 		}
-		if _, err := io.WriteString(w.out, w.render(strings.TrimSuffix(line, "\n"))+"\n"); err != nil {
+		rendered, ok := w.render(strings.TrimSuffix(line, "\n"))
+		if !ok {
+			continue
+		}
+		if _, err := io.WriteString(w.out, rendered+"\n"); err != nil {
 			return written, err
 		}
 	}
@@ -250,27 +265,85 @@ func (w *Writer) Write(p []byte) (int, error) {
 	return written, nil
 }
 
-func (w *Writer) render(msg string) string {
+// render formats one line, reporting false if it is below the level.
+func (w *Writer) render(msg string) (string, bool) {
 	entry, body := decodeEntry(msg)
 	if entry.Component == "" {
 		entry.Component, body = splitComponent(body)
 	}
-	timestamp := time.Now().Format("2006/01/02 15:04:05")
+	level := entryLevel(entry.Level, body)
+	if w.level != nil && level < w.level.Level() {
+		return "", false
+	}
+	now := time.Now()
 
-	if w.format == FormatPlain {
+	switch w.format {
+	case FormatJSON:
+		return renderJSON(now, level, entry, body), true
+	case FormatPlain:
+		timestamp := now.Format("2006/01/02 15:04:05")
+		if level >= slog.LevelWarn && entry.Level != "" {
+			body = w.styleMessage(body, entry.Level) // color is off; adds the level prefix
+		}
 		if entry.Component == "" {
-			return timestamp + " " + body
+			return timestamp + " " + body, true
 		}
-		return fmt.Sprintf("%s [%s] %s", timestamp, entry.Component, body)
+		return fmt.Sprintf("%s [%s] %s", timestamp, entry.Component, body), true
 	}
 
-	styledTimestamp := style(timestamp, w.theme.Timestamp, w.color)
+	styledTimestamp := style(now.Format("2006/01/02 15:04:05"), w.theme.Timestamp, w.color)
 	if entry.Component == "" {
-		return styledTimestamp + " " + w.styleMessage(body, entry.Level)
+		return styledTimestamp + " " + w.styleMessage(body, entry.Level), true
 	}
 
 	styledComponent := style("["+entry.Component+"]", w.componentColor(entry), w.color)
-	return styledTimestamp + " " + styledComponent + " " + w.styleMessage(body, entry.Level)
+	return styledTimestamp + " " + styledComponent + " " + w.styleMessage(body, entry.Level), true
+}
+
+// jsonLine is a line in the json format, with the keys slog's JSON handler
+// uses.
+type jsonLine struct {
+	Time      string `json:"time"`
+	Level     string `json:"level"`
+	Msg       string `json:"msg"`
+	Component string `json:"component,omitempty"`
+	Stage     string `json:"stage,omitempty"`
+}
+
+func renderJSON(now time.Time, level slog.Level, entry Entry, body string) string {
+	data, err := json.Marshal(jsonLine{
+		Time:      now.Format(time.RFC3339Nano),
+		Level:     level.String(),
+		Msg:       body,
+		Component: entry.Component,
+		Stage:     entry.Phase,
+	})
+	if err != nil {
+		return body
+	}
+	return string(data)
+}
+
+// entryLevel returns the level of a line from its metadata, or from a
+// "Warning:" or "Error:" prefix when it has none.
+func entryLevel(level, body string) slog.Level {
+	switch strings.ToLower(level) {
+	case "debug":
+		return slog.LevelDebug
+	case "warning", "warn":
+		return slog.LevelWarn
+	case "error":
+		return slog.LevelError
+	case "":
+		lower := strings.ToLower(body)
+		switch {
+		case strings.HasPrefix(lower, "warning:"):
+			return slog.LevelWarn
+		case strings.HasPrefix(lower, "error:"):
+			return slog.LevelError
+		}
+	}
+	return slog.LevelInfo
 }
 
 func splitComponent(msg string) (component, body string) {
@@ -337,7 +410,7 @@ func resolveFormat(format Format, isTTY bool) Format {
 }
 
 func allowColor(opts Options) bool {
-	if opts.NoColor || opts.Format == FormatPlain {
+	if opts.NoColor || opts.Format == FormatPlain || opts.Format == FormatJSON {
 		return false
 	}
 	if strings.TrimSpace(os.Getenv("NO_COLOR")) != "" {
@@ -402,8 +475,9 @@ func (w *Writer) styleMessage(message, level string) string {
 }
 
 func stylePrefixedMessage(message, prefix, color string, enabled bool) string {
-	if len(message) < len(prefix) {
-		return style(prefix, color, enabled)
+	if !strings.HasPrefix(strings.ToLower(message), strings.ToLower(prefix)) {
+		// Leveled messages need not repeat the level in their text
+		return style(prefix, color, enabled) + " " + message
 	}
 	return style(prefix, color, enabled) + message[len(prefix):]
 }
@@ -2,6 +2,9 @@ package logging
 
 import (
 	"bytes"
+	"encoding/json"
+	"log/slog"
+	"os"
 	"strings"
 	"testing"
 
@@ -110,3 +113,50 @@ func TestLoggerEncodesMetadata(t *testing.T) {
 		t.Fatalf("message = %q", message)
 	}
 }
+
+func TestHandlerRendersFieldsAsComponentAndKeyValues(t *testing.T) {
+	buf := bytes.NewBuffer(nil)
+	ConfigureStandardLogger(Options{Writer: buf, Format: FormatPlain})
+	t.Cleanup(func() { ConfigureStandardLogger(Options{Writer: os.Stderr}) })
+
+	logger := slog.New(NewHandler(slog.LevelInfo)).With(StageKey, "write", PluginKey, "css_bundle")
+	logger.Debug("hidden")
+	logger.Info("created bundle", "name", "main", "files", 3, "path", "css/main bundle.css")
+	logger.Warn("no files found")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("got %d lines, want 2: %q", len(lines), buf.String())
+	}
+	if want := `[css_bundle] created bundle name=main files=3 path="css/main bundle.css"`; !strings.HasSuffix(lines[0], want) {
+		t.Errorf("line = %q, want suffix %q", lines[0], want)
+	}
+	if want := "[css_bundle] Warning: no files found"; !strings.HasSuffix(lines[1], want) {
+		t.Errorf("line = %q, want suffix %q", lines[1], want)
+	}
+}
+
+func TestWriterJSONFormatAndLevel(t *testing.T) {
+	buf := bytes.NewBuffer(nil)
+	writer := NewWriter(Options{Writer: buf, Format: FormatJSON, Level: slog.LevelWarn})
+
+	input := "[mentions] Processing 3 posts\n" +
+		encodeEntry(Entry{Component: "tailwind", Phase: "cleanup", Level: "warning"}, "binary not found") + "\n"
+	if _, err := writer.Write([]byte(input)); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 1 {
+		t.Fatalf("got %d lines, want only the warning: %q", len(lines), buf.String())
+	}
+	var record map[string]any
+	if err := json.Unmarshal([]byte(lines[0]), &record); err != nil {
+		t.Fatalf("line %q is not JSON: %v", lines[0], err)
+	}
+	for key, want := range map[string]string{"level": "WARN", "msg": "binary not found", "component": "tailwind", "stage": "cleanup"} {
+		if record[key] != want {
+			t.Errorf("%s = %v, want %q", key, record[key], want)
+		}
+	}
+}
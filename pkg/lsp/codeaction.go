@@ -0,0 +1,285 @@
+package lsp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/WaylonWalker/markata-go/pkg/lint"
+	"github.com/WaylonWalker/markata-go/pkg/models"
+)
+
+// CodeActionKindQuickFix is the code action kind for fixes to diagnostics.
+const CodeActionKindQuickFix = "quickfix"
+
+// altTextPlaceholder is inserted into images without alt text when no
+// description can be derived from the image's file name.
+const altTextPlaceholder = "image"
+
+// CodeActionParams contains the parameters for textDocument/codeAction.
+type CodeActionParams struct {
+	TextDocument TextDocumentIdentifier `json:"textDocument"`
+	Range        Range                  `json:"range"`
+	Context      CodeActionContext      `json:"context"`
+}
+
+// CodeActionContext carries the diagnostics the client wants actions for.
+type CodeActionContext struct {
+	Diagnostics []Diagnostic `json:"diagnostics"`
+	Only        []string     `json:"only,omitempty"`
+}
+
+// CodeAction represents a change that can be performed in code.
+type CodeAction struct {
+	Title       string         `json:"title"`
+	Kind        string         `json:"kind,omitempty"`
+	Diagnostics []Diagnostic   `json:"diagnostics,omitempty"`
+	IsPreferred bool           `json:"isPreferred,omitempty"`
+	Edit        *WorkspaceEdit `json:"edit,omitempty"`
+}
+
+// CodeActionOptions represents code action provider options.
+type CodeActionOptions struct {
+	CodeActionKinds []string `json:"codeActionKinds,omitempty"`
+}
+
+// WorkspaceEdit represents changes to many resources in the workspace.
+// Changes holds plain text edits by URI; DocumentChanges holds ordered
+// resource operations (CreateFile) and TextDocumentEdits.
+type WorkspaceEdit struct {
+	Changes         map[string][]TextEdit `json:"changes,omitempty"`
+	DocumentChanges []interface{}         `json:"documentChanges,omitempty"`
+}
+
+// CreateFile is a resource operation that creates a file.
+type CreateFile struct {
+	Kind    string             `json:"kind"` // always "create"
+	URI     string             `json:"uri"`
+	Options *CreateFileOptions `json:"options,omitempty"`
+}
+
+// CreateFileOptions controls how CreateFile treats existing files.
+type CreateFileOptions struct {
+	Overwrite      bool `json:"overwrite,omitempty"`
+	IgnoreIfExists bool `json:"ignoreIfExists,omitempty"`
+}
+
+// TextDocumentEdit describes text edits to a single document.
+type TextDocumentEdit struct {
+	TextDocument OptionalVersionedTextDocumentIdentifier `json:"textDocument"`
+	Edits        []TextEdit                              `json:"edits"`
+}
+
+// OptionalVersionedTextDocumentIdentifier identifies a document whose
+// version may be unknown (null), e.g. a file created by the same edit.
+type OptionalVersionedTextDocumentIdentifier struct {
+	URI     string `json:"uri"`
+	Version *int   `json:"version"`
+}
+
+// handleCodeAction handles textDocument/codeAction requests.
+func (s *Server) handleCodeAction(_ context.Context, msg *Message) error {
+	var params CodeActionParams
+	if err := json.Unmarshal(msg.Params, &params); err != nil {
+		return s.sendError(msg.ID, InvalidParams, "invalid code action params")
+	}
+
+	actions := []CodeAction{}
+
+	// Only quick fixes are offered
+	if !codeActionKindRequested(params.Context.Only, CodeActionKindQuickFix) {
+		return s.sendResponse(msg.ID, actions)
+	}
+
+	// Get the document
+	s.docMu.RLock()
+	doc, ok := s.documents[params.TextDocument.URI]
+	s.docMu.RUnlock()
+
+	if !ok {
+		return s.sendResponse(msg.ID, actions)
+	}
+
+	lines := strings.Split(doc.Content, "\n")
+	for _, diag := range params.Context.Diagnostics {
+		if action := quickFixForDiagnostic(params.TextDocument.URI, lines, diag, time.Now()); action != nil {
+			actions = append(actions, *action)
+		}
+	}
+
+	return s.sendResponse(msg.ID, actions)
+}
+
+// codeActionKindRequested reports whether kind is allowed by the client's
+// "only" filter. An empty filter allows every kind; a filter entry also
+// allows its sub-kinds (e.g. "quickfix" allows "quickfix.foo").
+func codeActionKindRequested(only []string, kind string) bool {
+	if len(only) == 0 {
+		return true
+	}
+	for _, k := range only {
+		if k == kind || strings.HasPrefix(kind, k+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// quickFixForDiagnostic returns the quick fix for a diagnostic produced by the
+// diagnostics package, or nil if the diagnostic has no fix.
+func quickFixForDiagnostic(uri string, lines []string, diag Diagnostic, now time.Time) *CodeAction {
+	if diag.Range.Start.Line < 0 || diag.Range.Start.Line >= len(lines) {
+		return nil
+	}
+	line := lines[diag.Range.Start.Line]
+
+	code, _ := diag.Code.(string)
+	var action *CodeAction
+	switch code {
+	case "missing-alt-text":
+		action = altTextQuickFix(uri, line, diag)
+	case "invalid-date":
+		action = dateQuickFix(uri, line, diag)
+	case "broken-wikilink":
+		action = stubPostQuickFix(uri, line, diag, now)
+	}
+
+	if action != nil {
+		action.Kind = CodeActionKindQuickFix
+		action.Diagnostics = []Diagnostic{diag}
+	}
+	return action
+}
+
+// altTextQuickFix inserts alt text into an image like ![](url), derived
+// from the image's file name (e.g. ![](red-panda.png) gets "red panda").
+func altTextQuickFix(uri, line string, diag Diagnostic) *CodeAction {
+	start := diag.Range.Start.Character
+	if start < 0 || start > len(line) || !strings.HasPrefix(line[start:], "![]") {
+		return nil
+	}
+
+	alt := altTextFromImage(line[start+len("![]"):])
+	insertAt := Position{Line: diag.Range.Start.Line, Character: start + len("![")}
+	return &CodeAction{
+		Title:       fmt.Sprintf("Add alt text %q", alt),
+		IsPreferred: true,
+		Edit: &WorkspaceEdit{
+			Changes: map[string][]TextEdit{
+				uri: {{
+					Range:   Range{Start: insertAt, End: insertAt},
+					NewText: alt,
+				}},
+			},
+		},
+	}
+}
+
+// altTextFromImage derives alt text from the file name of the image whose
+// link destination starts rest, as in "(images/red-panda.png)". Separators
+// become spaces; altTextPlaceholder is used when nothing is left.
+func altTextFromImage(rest string) string {
+	if !strings.HasPrefix(rest, "(") {
+		return altTextPlaceholder
+	}
+	dest := rest[1:]
+	if strings.HasPrefix(dest, "<") {
+		// <...> destinations may contain spaces
+		dest = dest[1:]
+		if end := strings.IndexByte(dest, '>'); end >= 0 {
+			dest = dest[:end]
+		}
+	} else if end := strings.IndexAny(dest, ") \t"); end >= 0 {
+		dest = dest[:end]
+	}
+	if end := strings.IndexAny(dest, "?#"); end >= 0 {
+		dest = dest[:end]
+	}
+
+	name := path.Base(dest)
+	name = strings.TrimSuffix(name, path.Ext(name))
+	alt := strings.Join(strings.FieldsFunc(name, func(r rune) bool {
+		return r == '-' || r == '_' || r == '.' || r == '+'
+	}), " ")
+	if alt == "" || alt == "/" {
+		return altTextPlaceholder
+	}
+	return alt
+}
+
+// dateQuickFix rewrites a frontmatter date line in ISO 8601 format.
+func dateQuickFix(uri, line string, diag Diagnostic) *CodeAction {
+	fixer := lint.NewDateTimeFixer(lint.DefaultDateTimeFixerConfig())
+	fixed, changes := fixer.FixDateInContent(line)
+	if len(changes) == 0 {
+		return nil
+	}
+
+	lineNum := diag.Range.Start.Line
+	return &CodeAction{
+		Title:       fmt.Sprintf("Reformat %s as ISO 8601 (%s)", changes[0].Key, changes[0].NewValue),
+		IsPreferred: true,
+		Edit: &WorkspaceEdit{
+			Changes: map[string][]TextEdit{
+				uri: {{
+					Range: Range{
+						Start: Position{Line: lineNum, Character: 0},
+						End:   Position{Line: lineNum, Character: len(line)},
+					},
+					NewText: fixed,
+				}},
+			},
+		},
+	}
+}
+
+// stubPostQuickFix creates a stub post next to the current document so the
+// broken wikilink resolves.
+func stubPostQuickFix(uri, line string, diag Diagnostic, now time.Time) *CodeAction {
+	start, end := diag.Range.Start.Character, diag.Range.End.Character
+	if start < 0 || end > len(line) || start >= end {
+		return nil
+	}
+
+	match := wikilinkRegex.FindStringSubmatch(line[start:end])
+	if match == nil {
+		return nil
+	}
+	target := strings.TrimSpace(match[1])
+	slug := models.Slugify(target)
+	if slug == "" {
+		return nil
+	}
+
+	stubPath := filepath.Join(filepath.Dir(uriToPath(uri)), slug+".md")
+	stubURI := pathToURI(stubPath)
+
+	return &CodeAction{
+		Title: fmt.Sprintf("Create post %s.md", slug),
+		Edit: &WorkspaceEdit{
+			DocumentChanges: []interface{}{
+				CreateFile{
+					Kind:    "create",
+					URI:     stubURI,
+					Options: &CreateFileOptions{IgnoreIfExists: true},
+				},
+				TextDocumentEdit{
+					TextDocument: OptionalVersionedTextDocumentIdentifier{URI: stubURI},
+					Edits: []TextEdit{{
+						Range:   Range{},
+						NewText: stubPostContent(target, now),
+					}},
+				},
+			},
+		},
+	}
+}
+
+// stubPostContent returns the frontmatter for a new unpublished post.
+func stubPostContent(title string, now time.Time) string {
+	return fmt.Sprintf("---\ntitle: %q\ndate: %s\npublished: false\n---\n\n", title, now.Format("2006-01-02"))
+}
@@ -0,0 +1,247 @@
+package lsp
+
+import (
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/WaylonWalker/markata-go/pkg/diagnostics"
+)
+
+// applyLineEdit applies a single-line TextEdit to content.
+func applyLineEdit(t *testing.T, content string, edit TextEdit) string {
+	t.Helper()
+	if edit.Range.Start.Line != edit.Range.End.Line {
+		t.Fatalf("expected single-line edit, got %+v", edit.Range)
+	}
+	lines := strings.Split(content, "\n")
+	line := lines[edit.Range.Start.Line]
+	lines[edit.Range.Start.Line] = line[:edit.Range.Start.Character] + edit.NewText + line[edit.Range.End.Character:]
+	return strings.Join(lines, "\n")
+}
+
+func TestQuickFixForDiagnostic(t *testing.T) {
+	logger := log.New(os.Stderr, "[test] ", 0)
+	server := &Server{
+		index:  NewIndex(logger),
+		logger: logger,
+	}
+	now := time.Date(2024, 3, 9, 0, 0, 0, 0, time.UTC)
+	uri := pathToURI(filepath.Join(t.TempDir(), "post.md"))
+
+	tests := []struct {
+		name        string
+		content     string
+		code        string
+		wantTitle   string
+		wantContent string
+		wantEdit    *TextEdit
+	}{
+		{
+			name:        "missing alt text",
+			content:     "---\ntitle: Test\n---\n\nSee ![](img/red-panda.png?w=2) here.",
+			code:        "missing-alt-text",
+			wantTitle:   `Add alt text "red panda"`,
+			wantContent: "---\ntitle: Test\n---\n\nSee ![red panda](img/red-panda.png?w=2) here.",
+			wantEdit: &TextEdit{
+				Range:   Range{Start: Position{Line: 4, Character: 6}, End: Position{Line: 4, Character: 6}},
+				NewText: "red panda",
+			},
+		},
+		{
+			name:        "invalid date",
+			content:     "---\ntitle: Test\ndate: 2024/01/15\n---\n\nBody",
+			code:        "invalid-date",
+			wantTitle:   "Reformat date as ISO 8601 (2024-01-15)",
+			wantContent: "---\ntitle: Test\ndate: 2024-01-15\n---\n\nBody",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diags := server.computeDiagnostics(uri, tt.content)
+			var diag *Diagnostic
+			for i := range diags {
+				if diags[i].Code == tt.code {
+					diag = &diags[i]
+				}
+			}
+			if diag == nil {
+				t.Fatalf("no %s diagnostic in %v", tt.code, diags)
+			}
+
+			action := quickFixForDiagnostic(uri, strings.Split(tt.content, "\n"), *diag, now)
+			if action == nil {
+				t.Fatal("expected a quick fix")
+			}
+			if action.Kind != CodeActionKindQuickFix {
+				t.Errorf("Kind = %q, want %q", action.Kind, CodeActionKindQuickFix)
+			}
+			if action.Title != tt.wantTitle {
+				t.Errorf("Title = %q, want %q", action.Title, tt.wantTitle)
+			}
+			if len(action.Diagnostics) != 1 || action.Diagnostics[0].Code != tt.code {
+				t.Errorf("Diagnostics = %v, want the %s diagnostic", action.Diagnostics, tt.code)
+			}
+
+			edits := action.Edit.Changes[uri]
+			if len(edits) != 1 {
+				t.Fatalf("got %d edits, want 1", len(edits))
+			}
+			if tt.wantEdit != nil && !reflect.DeepEqual(edits[0], *tt.wantEdit) {
+				t.Errorf("edit = %+v, want %+v", edits[0], *tt.wantEdit)
+			}
+			fixed := applyLineEdit(t, tt.content, edits[0])
+			if fixed != tt.wantContent {
+				t.Errorf("fixed content = %q, want %q", fixed, tt.wantContent)
+			}
+			for _, d := range server.computeDiagnostics(uri, fixed) {
+				if d.Code == tt.code {
+					t.Errorf("%s diagnostic remains after the fix: %+v", tt.code, d)
+				}
+			}
+		})
+	}
+}
+
+func TestQuickFixForDiagnostic_BrokenWikilink(t *testing.T) {
+	logger := log.New(os.Stderr, "[test] ", 0)
+	server := &Server{
+		index:  NewIndex(logger),
+		logger: logger,
+	}
+	now := time.Date(2024, 3, 9, 0, 0, 0, 0, time.UTC)
+	dir := t.TempDir()
+	uri := pathToURI(filepath.Join(dir, "post.md"))
+	content := "---\ntitle: Test\n---\n\nRead [[My New Post|the next one]]."
+
+	diags := server.computeDiagnostics(uri, content)
+	if len(diags) != 1 || diags[0].Code != "broken-wikilink" {
+		t.Fatalf("diagnostics = %v, want one broken-wikilink", diags)
+	}
+
+	action := quickFixForDiagnostic(uri, strings.Split(content, "\n"), diags[0], now)
+	if action == nil {
+		t.Fatal("expected a quick fix")
+	}
+	if action.Title != "Create post my-new-post.md" {
+		t.Errorf("Title = %q", action.Title)
+	}
+	if len(action.Edit.DocumentChanges) != 2 {
+		t.Fatalf("got %d document changes, want 2", len(action.Edit.DocumentChanges))
+	}
+
+	wantURI := pathToURI(filepath.Join(dir, "my-new-post.md"))
+	create, ok := action.Edit.DocumentChanges[0].(CreateFile)
+	if !ok || create.Kind != "create" || create.URI != wantURI {
+		t.Errorf("first change = %+v, want create of %s", action.Edit.DocumentChanges[0], wantURI)
+	}
+	edit, ok := action.Edit.DocumentChanges[1].(TextDocumentEdit)
+	if !ok || edit.TextDocument.URI != wantURI || len(edit.Edits) != 1 {
+		t.Fatalf("second change = %+v, want one edit to %s", action.Edit.DocumentChanges[1], wantURI)
+	}
+	wantStub := "---\ntitle: \"My New Post\"\ndate: 2024-03-09\npublished: false\n---\n\n"
+	if edit.Edits[0].NewText != wantStub {
+		t.Errorf("stub = %q, want %q", edit.Edits[0].NewText, wantStub)
+	}
+
+	// The stub resolves the wikilink once indexed
+	if err := server.index.indexContent(filepath.Join(dir, "my-new-post.md"), wantStub); err != nil {
+		t.Fatalf("indexContent failed: %v", err)
+	}
+	if diags := server.computeDiagnostics(uri, content); len(diags) != 0 {
+		t.Errorf("diagnostics after creating stub = %v, want none", diags)
+	}
+}
+
+func TestQuickFixForDiagnostic_NoFix(t *testing.T) {
+	lines := []string{"---", "date: someday", "---"}
+	tests := []Diagnostic{
+		{Code: "h1-in-content", Range: Range{Start: Position{Line: 0}}},
+		{Code: "invalid-date", Range: Range{Start: Position{Line: 1}}},
+		{Code: "missing-alt-text", Range: Range{Start: Position{Line: 9}}},
+	}
+	for _, diag := range tests {
+		if action := quickFixForDiagnostic("file:///post.md", lines, diag, time.Now()); action != nil {
+			t.Errorf("quickFixForDiagnostic(%v) = %+v, want nil", diag.Code, action)
+		}
+	}
+}
+
+func TestCodeActionKindRequested(t *testing.T) {
+	tests := []struct {
+		only []string
+		want bool
+	}{
+		{nil, true},
+		{[]string{"quickfix"}, true},
+		{[]string{"refactor", "quickfix"}, true},
+		{[]string{"refactor"}, false},
+		{[]string{"quickfix.extra"}, false},
+	}
+	for _, tt := range tests {
+		if got := codeActionKindRequested(tt.only, CodeActionKindQuickFix); got != tt.want {
+			t.Errorf("codeActionKindRequested(%v) = %v, want %v", tt.only, got, tt.want)
+		}
+	}
+}
+
+func TestLoadDiagnosticsConfig(t *testing.T) {
+	dir := t.TempDir()
+	site := "[markata-go.diagnostics.rules]\nh1-in-content = { enabled = false }\nno-such-rule = { enabled = false }\n"
+	if err := os.WriteFile(filepath.Join(dir, "markata-go.toml"), []byte(site), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	lintFile := "[rules]\nheading-skip = { severity = \"info\" }\n"
+	if err := os.WriteFile(filepath.Join(dir, ".markata-lint.toml"), []byte(lintFile), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	var logs strings.Builder
+	server := &Server{index: NewIndex(log.New(io.Discard, "", 0)), logger: log.New(&logs, "", 0)}
+	server.diagnosticsConfig = server.loadDiagnosticsConfig(dir)
+
+	if !strings.Contains(logs.String(), `unknown rule "no-such-rule"`) {
+		t.Errorf("logs = %q, want unknown rule warning", logs.String())
+	}
+
+	diags := server.computeDiagnostics("test.md", "# Title\n\n#### Deep\n")
+	var sawSkip bool
+	for _, d := range diags {
+		switch d.Code {
+		case "h1-in-content":
+			t.Errorf("disabled rule reported: %v", d)
+		case "heading-skip":
+			sawSkip = true
+			if d.Severity != convertSeverity(diagnostics.SeverityInfo) {
+				t.Errorf("heading-skip severity = %d, want info", d.Severity)
+			}
+		}
+	}
+	if !sawSkip {
+		t.Errorf("diagnostics = %v, want heading-skip", diags)
+	}
+}
+
+func TestAltTextFromImage(t *testing.T) {
+	tests := []struct {
+		rest string
+		want string
+	}{
+		{"(cat.png)", "cat"},
+		{"(images/red_panda-2.jpg \"A title\")", "red panda 2"},
+		{"(<my photo.png>)", "my photo"},
+		{"(/.png)", "image"},
+		{"", "image"},
+	}
+	for _, tt := range tests {
+		if got := altTextFromImage(tt.rest); got != tt.want {
+			t.Errorf("altTextFromImage(%q) = %q, want %q", tt.rest, got, tt.want)
+		}
+	}
+}
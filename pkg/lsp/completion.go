@@ -109,6 +109,13 @@ func (s *Server) handleCompletion(_ context.Context, msg *Message) error {
 
 	// Check if we're inside frontmatter
 	frontmatterCtx := getFrontmatterContext(doc.Content, params.Position.Line, col)
+	if prefix, startCol, inTag := getTagValueContext(frontmatterCtx, line, col); inTag {
+		items := getTagCompletions(s.index.AllTags(), params, prefix, startCol)
+		return s.sendResponse(msg.ID, &CompletionList{
+			IsIncomplete: false,
+			Items:        items,
+		})
+	}
 	if frontmatterCtx.InFrontmatter && (frontmatterCtx.IsFieldName || frontmatterCtx.IsFieldValue) {
 		items := getFrontmatterCompletions(frontmatterCtx, params)
 		return s.sendResponse(msg.ID, &CompletionList{
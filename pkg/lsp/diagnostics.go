@@ -1,6 +1,13 @@
 package lsp
 
 import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+
 	"github.com/WaylonWalker/markata-go/pkg/diagnostics"
 )
 
@@ -17,6 +24,62 @@ func (r *indexResolver) ResolveHandle(handle string) bool {
 	return r.index.GetByHandle(handle) != nil
 }
 
+// BaseDir resolves relative links against the document's own directory.
+// Documents without an absolute file path (e.g. unsaved buffers) are skipped.
+func (r *indexResolver) BaseDir(filePath string) string {
+	if !filepath.IsAbs(filePath) {
+		return ""
+	}
+	return filepath.Dir(filePath)
+}
+
+// loadDiagnosticsConfig reads the rule overrides from the [markata-go.diagnostics]
+// section of the first site config found in rootPath, layered under
+// rootPath/.markata-lint.toml. Unknown rules and unreadable files are logged.
+func (s *Server) loadDiagnosticsConfig(rootPath string) *diagnostics.Config {
+	var siteRules *diagnostics.Config
+	configPaths := []string{
+		filepath.Join(rootPath, "markata-go.toml"),
+		filepath.Join(rootPath, "markata.toml"),
+		filepath.Join(rootPath, "markata-go.yaml"),
+		filepath.Join(rootPath, "markata.yaml"),
+	}
+	for _, configPath := range configPaths {
+		content, err := os.ReadFile(configPath)
+		if err != nil {
+			continue
+		}
+
+		var site struct {
+			MarkataGo struct {
+				Diagnostics diagnostics.Config `toml:"diagnostics" yaml:"diagnostics"`
+			} `toml:"markata-go" yaml:"markata-go"`
+		}
+		if strings.HasSuffix(configPath, ".toml") {
+			err = toml.Unmarshal(content, &site)
+		} else {
+			err = yaml.Unmarshal(content, &site)
+		}
+		if err != nil {
+			s.logger.Printf("Failed to read diagnostics config from %s: %v", configPath, err)
+		} else {
+			siteRules = &site.MarkataGo.Diagnostics
+		}
+		break // Only use first config found
+	}
+
+	fileRules, err := diagnostics.LoadConfigFile(filepath.Join(rootPath, diagnostics.LintConfigFile))
+	if err != nil {
+		s.logger.Printf("Failed to read %s: %v", diagnostics.LintConfigFile, err)
+	}
+
+	rules := siteRules.Merge(fileRules)
+	for _, warning := range rules.Validate() {
+		s.logger.Println(warning)
+	}
+	return rules
+}
+
 // publishDiagnostics publishes diagnostics for a document.
 func (s *Server) publishDiagnostics(uri, content string) error {
 	diagnosticsList := s.computeDiagnostics(uri, content)
@@ -38,7 +101,8 @@ func (s *Server) computeDiagnostics(uri, content string) []Diagnostic {
 	resolver := &indexResolver{index: s.index}
 
 	// Use shared diagnostics package
-	issues := diagnostics.Check(filePath, content, resolver)
+	issues := diagnostics.CheckWithOptions(filePath, content, resolver,
+		diagnostics.Options{Config: s.diagnosticsConfig})
 
 	diagnosticsList := make([]Diagnostic, 0, len(issues))
 
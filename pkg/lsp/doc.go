@@ -4,9 +4,12 @@
 //
 // The LSP server enables IDE features for markdown files with wikilink support:
 //   - Autocomplete: Type [[ to get suggestions for post slugs
+//   - Tag completion: Suggest tags already used in the workspace for frontmatter tags
 //   - Diagnostics: Warnings for broken [[wikilinks]] that don't resolve to a post
 //   - Hover: Show post title and description when hovering over a wikilink
 //   - Go to Definition: Navigate to the target post file (Ctrl+click)
+//   - Find References: List wikilinks and feed filters that point to a post
+//   - Quick fixes: Add alt text, reformat dates, and create posts for broken wikilinks
 //
 // # Server
 //
@@ -25,6 +28,7 @@
 //   - Post slugs and file paths
 //   - Titles and descriptions
 //   - Wikilinks contained in each file
+//   - Frontmatter tags, for tag completion with usage counts
 //
 // The index is built when the server initializes and updated incrementally
 // as files change.
@@ -50,6 +54,8 @@
 //   - textDocument/completion
 //   - textDocument/hover
 //   - textDocument/definition
+//   - textDocument/references
+//   - textDocument/codeAction (quickfix)
 //   - textDocument/publishDiagnostics (server->client notification)
 //
 // # Editor Integration
@@ -167,6 +167,12 @@ type FrontmatterContext struct {
 	// CurrentField is the field name if we're editing a value
 	CurrentField string
 
+	// IsListItem indicates if the cursor is on a "- item" line of a block list
+	IsListItem bool
+
+	// ListField is the field that owns the block list when IsListItem is set
+	ListField string
+
 	// Prefix is the text before the cursor (for filtering)
 	Prefix string
 
@@ -207,7 +213,31 @@ func getFrontmatterContext(content string, line, col int) *FrontmatterContext {
 	}
 
 	// Analyze what we're completing
-	return analyzeLineContext(currentLine, col, existingFields)
+	ctx := analyzeLineContext(currentLine, col, existingFields)
+	if ctx.IsListItem {
+		ctx.ListField = findListParentField(lines, line, startLine)
+	}
+	return ctx
+}
+
+// findListParentField returns the top-level field that owns the list item on
+// line, or "" if the item is not under a field.
+func findListParentField(lines []string, line, startLine int) string {
+	for i := line - 1; i > startLine; i-- {
+		candidate := lines[i]
+		if strings.TrimSpace(candidate) == "" {
+			continue
+		}
+		if strings.HasPrefix(candidate, " ") || strings.HasPrefix(candidate, "\t") ||
+			strings.HasPrefix(candidate, "-") {
+			continue
+		}
+		if idx := strings.Index(candidate, ":"); idx > 0 {
+			return strings.TrimSpace(candidate[:idx])
+		}
+		return ""
+	}
+	return ""
 }
 
 // findFrontmatterBoundaries finds the start and end lines of frontmatter.
@@ -265,8 +295,9 @@ func analyzeLineContext(line string, col int, existingFields map[string]bool) *F
 
 	// Check if we're in a list item (indented with - )
 	trimmedLine := strings.TrimLeft(line, " \t")
-	if strings.HasPrefix(trimmedLine, "- ") {
+	if strings.HasPrefix(trimmedLine, "- ") || trimmedLine == "-" {
 		// We're in a list value, don't provide field completion
+		ctx.IsListItem = true
 		return ctx
 	}
 
@@ -33,11 +33,15 @@ func (s *Server) handleInitialize(_ context.Context, msg *Message) error {
 				},
 			},
 			CompletionProvider: &CompletionOptions{
-				TriggerCharacters: []string{"[", "@", "!", "?", " "},
+				TriggerCharacters: []string{"[", "@", "!", "?", " ", ","},
 				ResolveProvider:   false,
 			},
 			HoverProvider:      true,
 			DefinitionProvider: true,
+			ReferencesProvider: true,
+			CodeActionProvider: &CodeActionOptions{
+				CodeActionKinds: []string{CodeActionKindQuickFix},
+			},
 		},
 		ServerInfo: &ServerInfo{
 			Name:    "markata-go-lsp",
@@ -56,6 +60,8 @@ func (s *Server) handleInitialized(_ context.Context, _ *Message) error {
 	// Build the index
 	if s.rootURI != "" {
 		rootPath := uriToPath(s.rootURI)
+		s.diagnosticsConfig = s.loadDiagnosticsConfig(rootPath)
+
 		s.logger.Printf("Building index from: %s", rootPath)
 		if err := s.index.Build(rootPath); err != nil {
 			s.logger.Printf("Failed to build index: %v", err)
@@ -5,6 +5,7 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"sort"
 	"strings"
 	"sync"
 
@@ -54,6 +55,9 @@ type PostInfo struct {
 	// Aliases are alternative slugs that resolve to this post
 	Aliases []string
 
+	// Tags are the tags listed in the post's frontmatter
+	Tags []string
+
 	// Wikilinks contains all wikilinks found in the post
 	Wikilinks []WikilinkInfo
 }
@@ -101,7 +105,7 @@ type WikilinkInfo struct {
 	// DisplayText is the optional display text
 	DisplayText string
 
-	// Line is the 0-based line number
+	// Line is the 0-based line number in the file
 	Line int
 
 	// StartChar is the 0-based character position of [[
@@ -211,12 +215,20 @@ func (idx *Index) indexContent(path, content string) error {
 		description = extractExcerpt(body, 200)
 	}
 
-	// Find wikilinks
+	// Find wikilinks, with line numbers relative to the whole file
 	wikilinks := findWikilinks(body)
+	if bodyLine := strings.Count(content, "\n") - strings.Count(body, "\n"); bodyLine > 0 {
+		for i := range wikilinks {
+			wikilinks[i].Line += bodyLine
+		}
+	}
 
 	// Extract aliases from frontmatter
 	aliases := extractAliases(metadata)
 
+	// Extract tags from frontmatter
+	tags := extractTagsFromMetadata(metadata["tags"])
+
 	// Create post info
 	uri := pathToURI(path)
 	info := &PostInfo{
@@ -227,6 +239,7 @@ func (idx *Index) indexContent(path, content string) error {
 		Description: description,
 		Metadata:    metadata,
 		Aliases:     aliases,
+		Tags:        tags,
 		Wikilinks:   wikilinks,
 	}
 
@@ -339,6 +352,53 @@ func (idx *Index) AllPosts() []*PostInfo {
 	return posts
 }
 
+// TagInfo describes a tag used in the workspace.
+type TagInfo struct {
+	// Name is the tag as written in frontmatter
+	Name string
+
+	// Count is the number of posts using the tag
+	Count int
+}
+
+// AllTags returns every tag used by indexed posts with its usage count,
+// ordered by count (most used first) and then by name.
+func (idx *Index) AllTags() []TagInfo {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+
+	counts := make(map[string]int)
+	seen := make(map[*PostInfo]bool)
+	for _, info := range idx.posts {
+		if seen[info] {
+			continue
+		}
+		seen[info] = true
+
+		postTags := make(map[string]bool, len(info.Tags))
+		for _, tag := range info.Tags {
+			tag = strings.TrimSpace(tag)
+			if tag == "" || postTags[tag] {
+				continue
+			}
+			postTags[tag] = true
+			counts[tag]++
+		}
+	}
+
+	tags := make([]TagInfo, 0, len(counts))
+	for name, count := range counts {
+		tags = append(tags, TagInfo{Name: name, Count: count})
+	}
+	sort.Slice(tags, func(i, j int) bool {
+		if tags[i].Count != tags[j].Count {
+			return tags[i].Count > tags[j].Count
+		}
+		return tags[i].Name < tags[j].Name
+	})
+	return tags
+}
+
 // Match type constants for PostSearchResult.
 const (
 	MatchTypeSlug  = "slug"
@@ -0,0 +1,201 @@
+package lsp
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// ReferenceParams contains the parameters for textDocument/references.
+type ReferenceParams struct {
+	TextDocument TextDocumentIdentifier `json:"textDocument"`
+	Position     Position               `json:"position"`
+	Context      ReferenceContext       `json:"context"`
+}
+
+// ReferenceContext controls which references are returned.
+type ReferenceContext struct {
+	IncludeDeclaration bool `json:"includeDeclaration"`
+}
+
+// feedFilterLineRegex matches a filter key in a TOML or YAML config line.
+var feedFilterLineRegex = regexp.MustCompile(`^\s*["']?filter["']?\s*[=:]`)
+
+// handleReferences handles textDocument/references requests.
+func (s *Server) handleReferences(_ context.Context, msg *Message) error {
+	var params ReferenceParams
+	if err := json.Unmarshal(msg.Params, &params); err != nil {
+		return s.sendError(msg.ID, InvalidParams, "invalid references params")
+	}
+
+	locations := []Location{}
+
+	// Get the document
+	s.docMu.RLock()
+	doc, ok := s.documents[params.TextDocument.URI]
+	s.docMu.RUnlock()
+
+	if !ok {
+		return s.sendResponse(msg.ID, locations)
+	}
+
+	target := s.referenceTarget(params.TextDocument.URI, doc.Content, params.Position)
+	if target == nil {
+		return s.sendResponse(msg.ID, locations)
+	}
+
+	if params.Context.IncludeDeclaration {
+		locations = append(locations, Location{
+			URI: target.URI,
+			Range: Range{
+				Start: Position{Line: 0, Character: 0},
+				End:   Position{Line: 0, Character: 0},
+			},
+		})
+	}
+
+	locations = append(locations, s.index.LinksTo(target)...)
+	if s.rootURI != "" {
+		locations = append(locations, findFeedFilterReferences(uriToPath(s.rootURI), target)...)
+	}
+
+	sortLocations(locations)
+	return s.sendResponse(msg.ID, locations)
+}
+
+// referenceTarget returns the post whose references are requested: the target
+// of a wikilink under the cursor, or the current post when the cursor is in
+// its frontmatter or on an H1 heading.
+func (s *Server) referenceTarget(uri, content string, pos Position) *PostInfo {
+	lines := strings.Split(content, "\n")
+	if pos.Line < 0 || pos.Line >= len(lines) {
+		return nil
+	}
+	line := lines[pos.Line]
+
+	// A wikilink under the cursor refers to its target post
+	if slug, _ := getWikilinkAtPosition(line, pos.Character, pos.Line); slug != "" {
+		return s.index.GetBySlug(slug)
+	}
+
+	startLine, endLine := findFrontmatterBoundaries(lines)
+	inFrontmatter := startLine != -1 && endLine != -1 && pos.Line >= startLine && pos.Line <= endLine
+	if inFrontmatter || strings.HasPrefix(line, "# ") {
+		return s.index.GetByURI(uri)
+	}
+
+	return nil
+}
+
+// LinksTo returns the locations of all wikilinks in the workspace that
+// resolve to target, following the same slug and alias precedence as
+// GetBySlug.
+func (idx *Index) LinksTo(target *PostInfo) []Location {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+
+	posts := idx.getUniquePosts()
+
+	// Resolve normalized slugs first so aliases never shadow a real slug
+	resolved := make(map[string]*PostInfo, len(posts))
+	for _, post := range posts {
+		resolved[normalizeSlug(post.Slug)] = post
+	}
+	for _, post := range posts {
+		for _, alias := range post.Aliases {
+			key := normalizeSlug(alias)
+			if _, exists := resolved[key]; !exists {
+				resolved[key] = post
+			}
+		}
+	}
+
+	var locations []Location
+	for _, post := range posts {
+		for _, link := range post.Wikilinks {
+			if resolved[normalizeSlug(link.Target)] != target {
+				continue
+			}
+			locations = append(locations, Location{
+				URI: post.URI,
+				Range: Range{
+					Start: Position{Line: link.Line, Character: link.StartChar},
+					End:   Position{Line: link.Line, Character: link.EndChar},
+				},
+			})
+		}
+	}
+
+	return locations
+}
+
+// findFeedFilterReferences returns the locations of quoted mentions of the
+// target's slug or aliases in feed filter expressions of the site config.
+func findFeedFilterReferences(rootPath string, target *PostInfo) []Location {
+	configPaths := []string{
+		filepath.Join(rootPath, "markata-go.toml"),
+		filepath.Join(rootPath, "markata.toml"),
+		filepath.Join(rootPath, "markata-go.yaml"),
+		filepath.Join(rootPath, "markata.yaml"),
+	}
+
+	names := append([]string{target.Slug}, target.Aliases...)
+
+	for _, configPath := range configPaths {
+		content, err := os.ReadFile(configPath)
+		if err != nil {
+			continue
+		}
+
+		var locations []Location
+		uri := pathToURI(configPath)
+		for lineNum, line := range strings.Split(string(content), "\n") {
+			if !feedFilterLineRegex.MatchString(line) {
+				continue
+			}
+			for _, name := range names {
+				if name == "" {
+					continue
+				}
+				for _, quoted := range []string{"'" + name + "'", `"` + name + `"`} {
+					for offset := 0; ; {
+						idx := strings.Index(line[offset:], quoted)
+						if idx == -1 {
+							break
+						}
+						start := offset + idx + 1 // skip the opening quote
+						locations = append(locations, Location{
+							URI: uri,
+							Range: Range{
+								Start: Position{Line: lineNum, Character: start},
+								End:   Position{Line: lineNum, Character: start + len(name)},
+							},
+						})
+						offset = start + len(name)
+					}
+				}
+			}
+		}
+		return locations // Only use first config found
+	}
+
+	return nil
+}
+
+// sortLocations sorts locations by file path, then by position.
+func sortLocations(locations []Location) {
+	sort.SliceStable(locations, func(i, j int) bool {
+		a, b := locations[i], locations[j]
+		if pathA, pathB := uriToPath(a.URI), uriToPath(b.URI); pathA != pathB {
+			return pathA < pathB
+		}
+		if a.Range.Start.Line != b.Range.Start.Line {
+			return a.Range.Start.Line < b.Range.Start.Line
+		}
+		return a.Range.Start.Character < b.Range.Start.Character
+	})
+}
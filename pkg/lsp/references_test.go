@@ -0,0 +1,171 @@
+package lsp
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"log"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// newReferencesTestServer builds a server over a temp workspace with a target
+// post, posts linking to it, and a config whose feed filter mentions it.
+func newReferencesTestServer(t *testing.T) (server *Server, root string, out *bytes.Buffer) {
+	t.Helper()
+	root = t.TempDir()
+
+	files := map[string]string{
+		"target.md": "---\ntitle: Target\naliases: [tgt]\n---\n\n# Target\n\nBody",
+		"b.md":      "---\ntitle: B\n---\n\nSee [[target]] and [[tgt|the alias]].\n\n[[Target]] again.",
+		"a.md":      "---\ntitle: A\n---\n[[other]] then [[target]]",
+		"other.md":  "---\ntitle: Other\n---\n\nNo links to the target here.",
+		"markata-go.toml": `[[markata-go.feeds]]
+slug = "featured"
+filter = "slug == 'target' or slug == 'other'"
+`,
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(root, name), []byte(content), 0o600); err != nil {
+			t.Fatalf("write %s: %v", name, err)
+		}
+	}
+
+	logger := log.New(os.Stderr, "[test] ", 0)
+	out = &bytes.Buffer{}
+	server = &Server{
+		logger:    logger,
+		index:     NewIndex(logger),
+		documents: make(map[string]*Document),
+		rootURI:   pathToURI(root),
+		writer:    out,
+	}
+	if err := server.index.Build(root); err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+	for _, name := range []string{"target.md", "b.md"} {
+		uri := pathToURI(filepath.Join(root, name))
+		server.documents[uri] = &Document{URI: uri, Content: files[name]}
+	}
+	return server, root, out
+}
+
+func requestReferences(t *testing.T, server *Server, out *bytes.Buffer, params ReferenceParams) []Location {
+	t.Helper()
+	out.Reset()
+
+	data, err := json.Marshal(params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	msg := &Message{JSONRPC: "2.0", ID: json.RawMessage("1"), Method: "textDocument/references", Params: data}
+	if err := server.handleReferences(context.Background(), msg); err != nil {
+		t.Fatalf("handleReferences failed: %v", err)
+	}
+
+	resp, err := server.readMessage(bufio.NewReader(out))
+	if err != nil {
+		t.Fatalf("reading response: %v", err)
+	}
+	var locations []Location
+	if err := json.Unmarshal(resp.Result, &locations); err != nil {
+		t.Fatalf("unmarshal result %s: %v", resp.Result, err)
+	}
+	return locations
+}
+
+func TestHandleReferences(t *testing.T) {
+	server, root, out := newReferencesTestServer(t)
+	targetURI := pathToURI(filepath.Join(root, "target.md"))
+	aURI := pathToURI(filepath.Join(root, "a.md"))
+	bURI := pathToURI(filepath.Join(root, "b.md"))
+	configURI := pathToURI(filepath.Join(root, "markata-go.toml"))
+
+	type loc struct {
+		uri       string
+		line, col int
+	}
+	links := []loc{
+		{aURI, 3, 15},
+		{bURI, 4, 4},
+		{bURI, 4, 19},
+		{bURI, 6, 0},
+		{configURI, 2, 19},
+	}
+
+	tests := []struct {
+		name   string
+		params ReferenceParams
+		want   []loc
+	}{
+		{
+			name: "from frontmatter",
+			params: ReferenceParams{
+				TextDocument: TextDocumentIdentifier{URI: targetURI},
+				Position:     Position{Line: 1, Character: 3},
+			},
+			want: links,
+		},
+		{
+			name: "from title heading with declaration",
+			params: ReferenceParams{
+				TextDocument: TextDocumentIdentifier{URI: targetURI},
+				Position:     Position{Line: 5, Character: 2},
+				Context:      ReferenceContext{IncludeDeclaration: true},
+			},
+			want: append(append([]loc{}, links...), loc{targetURI, 0, 0}),
+		},
+		{
+			name: "from wikilink",
+			params: ReferenceParams{
+				TextDocument: TextDocumentIdentifier{URI: bURI},
+				Position:     Position{Line: 4, Character: 22},
+			},
+			want: links,
+		},
+		{
+			name: "from body text",
+			params: ReferenceParams{
+				TextDocument: TextDocumentIdentifier{URI: targetURI},
+				Position:     Position{Line: 7, Character: 1},
+			},
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := requestReferences(t, server, out, tt.params)
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %d locations %+v, want %d", len(got), got, len(tt.want))
+			}
+			for i, w := range tt.want {
+				g := got[i]
+				if g.URI != w.uri || g.Range.Start.Line != w.line || g.Range.Start.Character != w.col {
+					t.Errorf("location %d = %s:%d:%d, want %s:%d:%d",
+						i, g.URI, g.Range.Start.Line, g.Range.Start.Character, w.uri, w.line, w.col)
+				}
+			}
+		})
+	}
+}
+
+func TestIndexWikilinkLinesAreFileRelative(t *testing.T) {
+	logger := log.New(os.Stderr, "[test] ", 0)
+	idx := NewIndex(logger)
+
+	content := "---\ntitle: Test\n---\n\nIntro\n[[target]]"
+	if err := idx.indexContent("test.md", content); err != nil {
+		t.Fatalf("indexContent failed: %v", err)
+	}
+
+	post := idx.GetBySlug("test")
+	if post == nil || len(post.Wikilinks) != 1 {
+		t.Fatalf("expected one indexed wikilink, got %+v", post)
+	}
+	if post.Wikilinks[0].Line != 5 {
+		t.Errorf("wikilink line = %d, want 5", post.Wikilinks[0].Line)
+	}
+}
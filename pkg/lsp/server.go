@@ -29,6 +29,8 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+
+	"github.com/WaylonWalker/markata-go/pkg/diagnostics"
 )
 
 // Server is the LSP server implementation for markata-go.
@@ -48,6 +50,9 @@ type Server struct {
 	// rootURI is the workspace root URI
 	rootURI string
 
+	// diagnosticsConfig holds per-rule diagnostics overrides for the workspace
+	diagnosticsConfig *diagnostics.Config
+
 	// shutdown indicates the server is shutting down
 	shutdown bool
 
@@ -167,6 +172,8 @@ func (s *Server) methodHandlers() map[string]methodHandler {
 		"textDocument/completion": s.handleCompletion,
 		"textDocument/hover":      s.handleHover,
 		"textDocument/definition": s.handleDefinition,
+		"textDocument/references": s.handleReferences,
+		"textDocument/codeAction": s.handleCodeAction,
 
 		// Workspace
 		"workspace/didChangeWatchedFiles": s.handleDidChangeWatchedFiles,
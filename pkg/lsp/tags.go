@@ -0,0 +1,103 @@
+package lsp
+
+import (
+	"fmt"
+	"strings"
+)
+
+// tagsField is the frontmatter field whose values are completed from the index.
+const tagsField = "tags"
+
+// getTagValueContext checks if the cursor is on a tag value in frontmatter and
+// returns the partial tag before the cursor. Three forms are recognized:
+//
+//	tags: go                 (inline scalar)
+//	tags: [go, python]       (inline flow list)
+//	tags:
+//	  - go                   (block list item)
+//
+// Returns (prefix, startColumn, isTagValue).
+func getTagValueContext(ctx *FrontmatterContext, line string, col int) (prefix string, startCol int, inTag bool) {
+	if !ctx.InFrontmatter {
+		return "", 0, false
+	}
+	if col > len(line) {
+		col = len(line)
+	}
+
+	switch {
+	case ctx.IsListItem && ctx.ListField == tagsField:
+		dash := strings.Index(line, "-")
+		if dash == -1 || col <= dash {
+			return "", 0, false
+		}
+		startCol = dash + 1
+	case ctx.IsFieldValue && ctx.CurrentField == tagsField:
+		startCol = strings.Index(line, ":") + 1
+		valueBeforeCursor := line[startCol:col]
+		if sep := strings.LastIndexAny(valueBeforeCursor, "[,"); sep != -1 {
+			startCol += sep + 1
+		}
+		// The flow list was already closed before the cursor.
+		if strings.Contains(line[startCol:col], "]") {
+			return "", 0, false
+		}
+	default:
+		return "", 0, false
+	}
+
+	// Skip whitespace and an opening quote before the tag.
+	for startCol < col && (line[startCol] == ' ' || line[startCol] == '\t') {
+		startCol++
+	}
+	if startCol < col && (line[startCol] == '"' || line[startCol] == '\'') {
+		startCol++
+	}
+
+	return line[startCol:col], startCol, true
+}
+
+// getTagCompletions returns completion items for tags already used in the
+// workspace, most used first.
+func getTagCompletions(tags []TagInfo, params CompletionParams, prefix string, startCol int) []CompletionItem {
+	prefixLower := strings.ToLower(prefix)
+	items := make([]CompletionItem, 0, len(tags))
+
+	for _, tag := range tags {
+		if prefix != "" && !strings.HasPrefix(strings.ToLower(tag.Name), prefixLower) {
+			continue
+		}
+
+		item := CompletionItem{
+			Label:            tag.Name,
+			Kind:             CompletionItemKindValue,
+			Detail:           formatTagUsage(tag.Count),
+			InsertText:       tag.Name,
+			InsertTextFormat: InsertTextFormatPlainText,
+			SortText:         fmt.Sprintf("%05d", len(items)), // Preserve usage order
+		}
+
+		// Use TextEdit to replace the prefix
+		if prefix != "" {
+			item.TextEdit = &TextEdit{
+				Range: Range{
+					Start: Position{Line: params.Position.Line, Character: startCol},
+					End:   Position{Line: params.Position.Line, Character: params.Position.Character},
+				},
+				NewText: tag.Name,
+			}
+		}
+
+		items = append(items, item)
+	}
+
+	return items
+}
+
+// formatTagUsage formats a tag usage count for the completion detail.
+func formatTagUsage(count int) string {
+	if count == 1 {
+		return "used in 1 post"
+	}
+	return fmt.Sprintf("used in %d posts", count)
+}
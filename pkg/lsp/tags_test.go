@@ -0,0 +1,206 @@
+package lsp
+
+import (
+	"log"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestGetTagValueContext(t *testing.T) {
+	tests := []struct {
+		name         string
+		content      string
+		line         int
+		col          int
+		wantInTag    bool
+		wantPrefix   string
+		wantStartCol int
+	}{
+		{
+			name:         "inline scalar",
+			content:      "---\ntags: go\n---",
+			line:         1,
+			col:          8,
+			wantInTag:    true,
+			wantPrefix:   "go",
+			wantStartCol: 6,
+		},
+		{
+			name:         "empty inline value",
+			content:      "---\ntags: \n---",
+			line:         1,
+			col:          6,
+			wantInTag:    true,
+			wantPrefix:   "",
+			wantStartCol: 6,
+		},
+		{
+			name:         "flow list first item",
+			content:      "---\ntags: [py\n---",
+			line:         1,
+			col:          9,
+			wantInTag:    true,
+			wantPrefix:   "py",
+			wantStartCol: 7,
+		},
+		{
+			name:         "flow list after comma",
+			content:      "---\ntags: [go, \"py\n---",
+			line:         1,
+			col:          14,
+			wantInTag:    true,
+			wantPrefix:   "py",
+			wantStartCol: 12,
+		},
+		{
+			name:      "after closed flow list",
+			content:   "---\ntags: [go, py]\n---",
+			line:      1,
+			col:       15,
+			wantInTag: false,
+		},
+		{
+			name:         "block list item",
+			content:      "---\ntags:\n  - go\n  - ru\n---",
+			line:         3,
+			col:          6,
+			wantInTag:    true,
+			wantPrefix:   "ru",
+			wantStartCol: 4,
+		},
+		{
+			name:         "empty block list item",
+			content:      "---\ntags:\n  -\n---",
+			line:         2,
+			col:          3,
+			wantInTag:    true,
+			wantPrefix:   "",
+			wantStartCol: 3,
+		},
+		{
+			name:      "block list under another field",
+			content:   "---\naliases:\n  - go\n---",
+			line:      2,
+			col:       6,
+			wantInTag: false,
+		},
+		{
+			name:      "other field value",
+			content:   "---\ntitle: [go\n---",
+			line:      1,
+			col:       11,
+			wantInTag: false,
+		},
+		{
+			name:      "tags field name",
+			content:   "---\ntags: go\n---",
+			line:      1,
+			col:       2,
+			wantInTag: false,
+		},
+		{
+			name:      "document body",
+			content:   "---\ntitle: Test\n---\n\ntags: [go",
+			line:      4,
+			col:       9,
+			wantInTag: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			line := strings.Split(tt.content, "\n")[tt.line]
+			ctx := getFrontmatterContext(tt.content, tt.line, tt.col)
+			prefix, startCol, inTag := getTagValueContext(ctx, line, tt.col)
+
+			if inTag != tt.wantInTag {
+				t.Fatalf("inTag = %v, want %v", inTag, tt.wantInTag)
+			}
+			if !inTag {
+				return
+			}
+			if prefix != tt.wantPrefix {
+				t.Errorf("prefix = %q, want %q", prefix, tt.wantPrefix)
+			}
+			if startCol != tt.wantStartCol {
+				t.Errorf("startCol = %d, want %d", startCol, tt.wantStartCol)
+			}
+		})
+	}
+}
+
+func TestIndexAllTags(t *testing.T) {
+	logger := log.New(os.Stderr, "[test] ", 0)
+	idx := NewIndex(logger)
+
+	posts := map[string]string{
+		"one.md":   "---\ntitle: One\ntags: [go, cli]\naliases: [first]\n---\n",
+		"two.md":   "---\ntitle: Two\ntags:\n  - go\n  - go\n---\n",
+		"three.md": "---\ntitle: Three\ntags: golang\n---\n",
+		"four.md":  "---\ntitle: Four\n---\n",
+	}
+	for path, content := range posts {
+		if err := idx.indexContent(path, content); err != nil {
+			t.Fatalf("indexContent(%s) failed: %v", path, err)
+		}
+	}
+
+	got := idx.AllTags()
+	want := []TagInfo{
+		{Name: "go", Count: 2},
+		{Name: "cli", Count: 1},
+		{Name: "golang", Count: 1},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("AllTags() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("AllTags()[%d] = %v, want %v", i, got[i], want[i])
+		}
+	}
+
+	// Updating a post replaces its tags
+	if err := idx.Update(pathToURI("three.md"), "---\ntitle: Three\ntags: [go]\n---\n"); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+	got = idx.AllTags()
+	if len(got) != 2 || got[0] != (TagInfo{Name: "go", Count: 3}) {
+		t.Errorf("AllTags() after update = %v, want go used 3 times", got)
+	}
+}
+
+func TestGetTagCompletions(t *testing.T) {
+	tags := []TagInfo{
+		{Name: "go", Count: 3},
+		{Name: "python", Count: 2},
+		{Name: "go-lang", Count: 1},
+	}
+	params := CompletionParams{Position: Position{Line: 1, Character: 8}}
+
+	items := getTagCompletions(tags, params, "Go", 6)
+	if len(items) != 2 {
+		t.Fatalf("got %d items, want 2", len(items))
+	}
+	if items[0].Label != "go" || items[1].Label != "go-lang" {
+		t.Errorf("labels = %q, %q; want go, go-lang", items[0].Label, items[1].Label)
+	}
+	if items[0].Detail != "used in 3 posts" {
+		t.Errorf("Detail = %q, want %q", items[0].Detail, "used in 3 posts")
+	}
+	if items[1].Detail != "used in 1 post" {
+		t.Errorf("Detail = %q, want %q", items[1].Detail, "used in 1 post")
+	}
+	if items[0].TextEdit == nil || items[0].TextEdit.Range.Start.Character != 6 {
+		t.Errorf("TextEdit should replace the prefix starting at column 6")
+	}
+
+	items = getTagCompletions(tags, params, "", 8)
+	if len(items) != 3 {
+		t.Fatalf("got %d items without prefix, want 3", len(items))
+	}
+	if items[0].TextEdit != nil {
+		t.Error("TextEdit should be nil without a prefix")
+	}
+}
@@ -158,6 +158,8 @@ type ServerCapabilities struct {
 	CompletionProvider *CompletionOptions       `json:"completionProvider,omitempty"`
 	HoverProvider      bool                     `json:"hoverProvider,omitempty"`
 	DefinitionProvider bool                     `json:"definitionProvider,omitempty"`
+	ReferencesProvider bool                     `json:"referencesProvider,omitempty"`
+	CodeActionProvider *CodeActionOptions       `json:"codeActionProvider,omitempty"`
 	Workspace          *WorkspaceOptions        `json:"workspace,omitempty"`
 }
 
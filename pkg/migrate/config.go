@@ -43,6 +43,13 @@ type MigrationResult struct {
 	// TemplateIssues is the list of template compatibility issues
 	TemplateIssues []TemplateIssue
 
+	// TemplateRewrites is the list of templates MigrateTemplates rewrote,
+	// or would rewrite
+	TemplateRewrites []TemplateRewrite
+
+	// HookMigrations is the list of hooks entries translated or dropped
+	HookMigrations []HookMigration
+
 	// MigratedConfig is the resulting configuration (as generic map)
 	MigratedConfig map[string]interface{}
 
@@ -137,6 +144,18 @@ type TemplateIssue struct {
 	Suggestion string
 }
 
+// TemplateRewrite records the automatic rewrites of one template file.
+type TemplateRewrite struct {
+	// File is the template file path
+	File string
+
+	// Changes lists each rewrite, and each construct left for manual review
+	Changes []Change
+
+	// Written indicates the rewritten template was saved
+	Written bool
+}
+
 // HasErrors returns true if there are any migration errors.
 func (r *MigrationResult) HasErrors() bool {
 	return len(r.Errors) > 0
@@ -332,15 +351,25 @@ func migrateSection(section map[string]interface{}, path string, result *Migrati
 				migrated[newKey] = migratedFeeds
 				continue
 			}
+			// TOML decodes [[markata.feeds]] as a slice of tables
+			if feedTables, ok := value.([]map[string]interface{}); ok {
+				feedsArray := make([]interface{}, len(feedTables))
+				for i, feed := range feedTables {
+					feedsArray[i] = feed
+				}
+				migrated[newKey] = migrateFeedsArray(feedsArray, result)
+				continue
+			}
 			if feedsMap, ok := value.(map[string]interface{}); ok {
 				migratedFeeds := migrateFeedsMap(feedsMap, result)
 				migrated[newKey] = migratedFeeds
 				continue
 			}
-		case "hooks":
-			// Check for unsupported hooks
+		case "hooks", "disabled_hooks":
+			// Translate Python plugin modules to markata-go hooks
 			if hooks, ok := value.([]interface{}); ok {
-				checkUnsupportedHooks(hooks, result)
+				migrated[newKey] = migrateHooks(hooks, fullPath, result)
+				continue
 			}
 		}
 
@@ -495,40 +524,6 @@ func migrateFeedConfig(feed map[string]interface{}, result *MigrationResult) map
 	return migrated
 }
 
-// checkUnsupportedHooks checks for hooks that aren't supported in markata-go.
-func checkUnsupportedHooks(hooks []interface{}, result *MigrationResult) {
-	unsupported := map[string]string{
-		"rich_output":          "No direct equivalent - use Go's standard logging",
-		"console":              "No direct equivalent",
-		"custom_python":        "Python code is not supported - use Go plugins instead",
-		"covers":               "Not implemented - manual cover images or external tool required",
-		"icon_resize":          "Not implemented - manual favicon creation required",
-		"service_worker":       "Not implemented - manual service worker required",
-		"tag_aggregator":       "Use auto_feeds for tag pages instead",
-		"pyinstrument":         "Use Go's pprof for profiling",
-		"tui":                  "Not implemented",
-		"partial_template":     "Not supported",
-		"site_version":         "Not supported",
-		"default_cache_expire": "Not supported - caching handled differently",
-	}
-
-	for _, hook := range hooks {
-		hookName, ok := hook.(string)
-		if !ok {
-			continue
-		}
-
-		if suggestion, unsup := unsupported[hookName]; unsup {
-			result.Warnings = append(result.Warnings, Warning{
-				Category:   "plugin",
-				Message:    fmt.Sprintf("Hook '%s' is not supported in markata-go", hookName),
-				Path:       "hooks",
-				Suggestion: suggestion,
-			})
-		}
-	}
-}
-
 // detectFormat determines the config format from file extension.
 func detectFormat(path string) string {
 	ext := strings.ToLower(filepath.Ext(path))
@@ -6,6 +6,8 @@
 //   - Converting configuration files from Python markata format to markata-go format
 //   - Migrating filter expressions to markata-go syntax
 //   - Checking template compatibility with pongo2
+//   - Rewriting common Jinja2 template constructs for pongo2 and renaming
+//     Python markata template variables (MigrateTemplate, MigrateTemplates)
 //   - Generating detailed migration reports
 //
 // # Configuration Migration
@@ -14,6 +16,9 @@
 //   - Namespace changes ([markata] -> [markata-go])
 //   - Key renames (glob_patterns -> patterns, etc.)
 //   - Nav map to array conversion
+//   - Hook translation (markata.plugins.heading_link -> heading_anchors);
+//     plugins without an equivalent are dropped and reported as unsupported,
+//     custom plugins are kept with a warning
 //   - Feed filter expression migration
 //
 // # Filter Migration
@@ -23,6 +28,16 @@
 //   - `in` operator expansion (x in ['a', 'b'] -> x == 'a' or x == 'b')
 //   - Operator spacing fixes (date<=today -> date <= today)
 //
+// # Template Migration
+//
+// Python markata templates reach data through the markata object, which
+// markata-go templates do not have. Known names are renamed
+// (GetVariableMigrations), for example markata.articles -> posts and
+// post.content -> body, except where a {% for %} loop rebinds post. Other
+// markata references are reported for manual review. CheckTemplates
+// reports the names; MigrateTemplates rewrites them and records each
+// change in a TemplateRewrite for the migration report.
+//
 // # Usage
 //
 // Basic migration:
@@ -33,6 +48,15 @@
 //	}
 //	fmt.Println(result.Report())
 //
+// Whole-project check, grouped by file and severity:
+//
+//	report, err := migrate.CheckProject(".")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Println(report.Report())
+//	os.Exit(report.ExitCode())
+//
 // Filter migration:
 //
 //	migrated, changes := migrate.MigrateFilter("published == 'True'")
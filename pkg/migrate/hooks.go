@@ -0,0 +1,183 @@
+package migrate
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Hook migration statuses.
+const (
+	HookStatusTranslated  = "translated"
+	HookStatusUnchanged   = "unchanged"
+	HookStatusUnsupported = "unsupported"
+	HookStatusCustom      = "custom"
+)
+
+// pythonPluginPrefix is the module path of Python markata's built-in plugins.
+const pythonPluginPrefix = "markata.plugins."
+
+// HookMigration represents the migration of a single hooks entry.
+type HookMigration struct {
+	// Path is the config path of the hooks list (e.g., "markata.hooks")
+	Path string
+
+	// Original is the entry as written in the Python config
+	Original string
+
+	// Migrated is the markata-go hook name; empty when the entry was dropped
+	Migrated string
+
+	// Status is one of "translated", "unchanged", "unsupported", "custom"
+	Status string
+
+	// Note explains why an entry was dropped or kept
+	Note string
+}
+
+// pythonHookEquivalents maps Python markata plugin names to the markata-go
+// hook providing the same behavior. Keys are module names without the
+// "markata.plugins." prefix.
+var pythonHookEquivalents = map[string]string{
+	"default":              "default",
+	"glob":                 "glob",
+	"load":                 "load",
+	"auto_title":           "auto_title",
+	"auto_description":     "description",
+	"render_markdown":      "render_markdown",
+	"jinja_md":             "jinja_md",
+	"heading_link":         "heading_anchors",
+	"md_it_wikilinks":      "wikilinks",
+	"md_it_highlight_code": "chroma_css",
+	"tippy_wikilink":       "wikilink_hover",
+	"copy_assets":          "static_assets",
+	"publish_html":         "publish_html",
+	"feeds":                "feeds",
+	"rss":                  "publish_feeds",
+	"to_json":              "publish_feeds",
+	"sitemap":              "sitemap",
+	"redirects":            "redirects",
+	"prevnext":             "prevnext",
+	"seo":                  "structured_data",
+	"md_video":             "md_video",
+	"youtube":              "youtube",
+	"qrcode":               "qrcode",
+	"mermaid":              "mermaid",
+}
+
+// pythonHooksUnsupported lists Python markata plugins with no markata-go
+// hook, with guidance for each.
+var pythonHooksUnsupported = map[string]string{
+	"rich_output":          "No direct equivalent - use Go's standard logging",
+	"console":              "No direct equivalent",
+	"custom_python":        "Python code is not supported - use Go plugins instead",
+	"covers":               "Not implemented - manual cover images or external tool required",
+	"icon_resize":          "Not implemented - manual favicon creation required",
+	"service_worker":       "Not implemented - manual service worker required",
+	"tag_aggregator":       "Use auto_feeds for tag pages instead",
+	"pyinstrument":         "Use Go's pprof for profiling",
+	"tui":                  "Not implemented",
+	"partial_template":     "Not supported",
+	"site_version":         "Not supported",
+	"default_cache_expire": "Not supported - caching handled differently",
+	"base_cli":             "Built in - the markata-go CLI is always available",
+	"server":               "Built in - use markata-go serve",
+	"setup_logging":        "Built in - use markata-go's -v flag for verbose logging",
+	"manifest":             "Not supported",
+	"generator":            "Built in - the generator meta tag is set by templates",
+	"post_template":        "Built in - templates are rendered by publish_html",
+	"flat_slug":            "Built in - slugs are generated from file paths",
+	"datetime":             "Built in - dates are parsed from frontmatter",
+	"summary":              "Not supported",
+	"config_model":         "Built in - configuration is validated by markata-go",
+	"post_model":           "Built in - post fields are defined by markata-go",
+	"create_models":        "Built in - post fields are defined by markata-go",
+	"didyoumean":           "Not supported",
+	"preview":              "Not supported",
+	"skip":                 "Built in - set skip in frontmatter",
+	"subroute":             "Not supported",
+}
+
+// migrateHooks translates a Python markata hooks list to markata-go hook
+// names. Known plugins are renamed, plugins without an equivalent are
+// dropped, and custom entries are kept verbatim with a warning.
+func migrateHooks(hooks []interface{}, path string, result *MigrationResult) []interface{} {
+	migrated := make([]interface{}, 0, len(hooks))
+	seen := make(map[string]bool)
+	translated, dropped := 0, 0
+
+	for _, hook := range hooks {
+		original, ok := hook.(string)
+		if !ok {
+			migrated = append(migrated, hook)
+			continue
+		}
+
+		hm := translateHook(original)
+		hm.Path = path
+		result.HookMigrations = append(result.HookMigrations, hm)
+
+		switch hm.Status {
+		case HookStatusUnsupported:
+			dropped++
+			result.Warnings = append(result.Warnings, Warning{
+				Category:   "plugin",
+				Message:    fmt.Sprintf("Hook '%s' is not supported in markata-go", original),
+				Path:       path,
+				Suggestion: hm.Note,
+			})
+			continue
+		case HookStatusCustom:
+			result.Warnings = append(result.Warnings, Warning{
+				Category:   "plugin",
+				Message:    fmt.Sprintf("Hook '%s' has no known markata-go equivalent and was kept as-is", original),
+				Path:       path,
+				Suggestion: "Port the plugin to Go and register it, or remove it from hooks",
+			})
+		case HookStatusTranslated:
+			translated++
+		}
+
+		if seen[hm.Migrated] {
+			continue
+		}
+		seen[hm.Migrated] = true
+		migrated = append(migrated, hm.Migrated)
+	}
+
+	if translated > 0 || dropped > 0 {
+		result.Changes = append(result.Changes, ConfigChange{
+			Type:        "transform",
+			Path:        path,
+			OldValue:    hooks,
+			NewValue:    migrated,
+			Description: fmt.Sprintf("Hooks migrated to markata-go names (%d translated, %d dropped)", translated, dropped),
+		})
+	}
+
+	return migrated
+}
+
+// translateHook looks up a single hooks entry in the mapping tables.
+func translateHook(original string) HookMigration {
+	name := strings.TrimSpace(original)
+	builtin := strings.HasPrefix(name, pythonPluginPrefix)
+	name = strings.TrimPrefix(name, pythonPluginPrefix)
+
+	if hook, ok := pythonHookEquivalents[name]; ok {
+		status := HookStatusTranslated
+		if hook == original {
+			status = HookStatusUnchanged
+		}
+		return HookMigration{Original: original, Migrated: hook, Status: status}
+	}
+
+	if note, ok := pythonHooksUnsupported[name]; ok {
+		return HookMigration{Original: original, Status: HookStatusUnsupported, Note: note}
+	}
+
+	if builtin {
+		return HookMigration{Original: original, Status: HookStatusUnsupported, Note: "No markata-go equivalent"}
+	}
+
+	return HookMigration{Original: original, Migrated: original, Status: HookStatusCustom, Note: "Unknown plugin, kept as-is"}
+}
@@ -3,6 +3,8 @@ package migrate
 import (
 	"os"
 	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -758,3 +760,179 @@ func TestConfigFromMap_StringFieldsAsStrings(t *testing.T) {
 		}
 	}
 }
+
+// pythonDefaultHooks is Python markata's default plugin set.
+var pythonDefaultHooks = []interface{}{
+	"markata.plugins.copy_assets",
+	"markata.plugins.heading_link",
+	"markata.plugins.pyinstrument",
+	"markata.plugins.glob",
+	"markata.plugins.load",
+	"markata.plugins.auto_title",
+	"markata.plugins.render_markdown",
+	"markata.plugins.manifest",
+	"markata.plugins.rss",
+	"markata.plugins.icon_resize",
+	"markata.plugins.sitemap",
+	"markata.plugins.to_json",
+	"markata.plugins.base_cli",
+	"markata.plugins.server",
+	"markata.plugins.generator",
+	"markata.plugins.redirects",
+	"markata.plugins.post_template",
+	"markata.plugins.covers",
+	"markata.plugins.publish_html",
+	"markata.plugins.flat_slug",
+	"markata.plugins.datetime",
+	"markata.plugins.rich_output",
+	"markata.plugins.seo",
+	"markata.plugins.tui",
+	"markata.plugins.setup_logging",
+	"markata.plugins.jinja_md",
+	"markata.plugins.auto_description",
+	"markata.plugins.feeds",
+	"markata.plugins.prevnext",
+	"markata.plugins.service_worker",
+	"markata.plugins.md_it_highlight_code",
+	"markata.plugins.md_it_wikilinks",
+}
+
+func TestConfigFromMap_PythonDefaultHooks(t *testing.T) {
+	input := map[string]interface{}{
+		"markata": map[string]interface{}{
+			"hooks": pythonDefaultHooks,
+		},
+	}
+
+	result, err := ConfigFromMap(input)
+	if err != nil {
+		t.Fatalf("ConfigFromMap() error = %v", err)
+	}
+
+	mg, ok := result.MigratedConfig["markata-go"].(map[string]interface{})
+	if !ok {
+		t.Fatal("markata-go section not found")
+	}
+
+	want := []interface{}{
+		"static_assets",
+		"heading_anchors",
+		"glob",
+		"load",
+		"auto_title",
+		"render_markdown",
+		"publish_feeds",
+		"sitemap",
+		"redirects",
+		"publish_html",
+		"structured_data",
+		"jinja_md",
+		"description",
+		"feeds",
+		"prevnext",
+		"chroma_css",
+		"wikilinks",
+	}
+	if !reflect.DeepEqual(mg["hooks"], want) {
+		t.Errorf("hooks = %v, want %v", mg["hooks"], want)
+	}
+
+	if len(result.HookMigrations) != len(pythonDefaultHooks) {
+		t.Fatalf("HookMigrations = %d, want %d", len(result.HookMigrations), len(pythonDefaultHooks))
+	}
+
+	dropped := 0
+	for _, hm := range result.HookMigrations {
+		switch hm.Status {
+		case HookStatusUnsupported:
+			dropped++
+			if hm.Migrated != "" || hm.Note == "" {
+				t.Errorf("unsupported hook %q: Migrated = %q, Note = %q", hm.Original, hm.Migrated, hm.Note)
+			}
+		case HookStatusTranslated:
+		default:
+			t.Errorf("hook %q has status %q, want translated or unsupported", hm.Original, hm.Status)
+		}
+	}
+	if dropped != 14 {
+		t.Errorf("dropped %d hooks, want 14", dropped)
+	}
+
+	for _, w := range result.Warnings {
+		if w.Category == "plugin" && strings.Contains(w.Message, "kept as-is") {
+			t.Errorf("unexpected custom hook warning: %s", w.Message)
+		}
+	}
+}
+
+func TestConfigFromMap_CustomHooks(t *testing.T) {
+	input := map[string]interface{}{
+		"markata": map[string]interface{}{
+			"hooks": []interface{}{
+				"default",
+				"plugins.my_plugin",
+				"markata.plugins.heading_link",
+				"heading_link",
+			},
+			"disabled_hooks": []interface{}{
+				"markata.plugins.copy_assets",
+				"markata.plugins.covers",
+			},
+		},
+	}
+
+	result, err := ConfigFromMap(input)
+	if err != nil {
+		t.Fatalf("ConfigFromMap() error = %v", err)
+	}
+
+	mg := result.MigratedConfig["markata-go"].(map[string]interface{})
+
+	wantHooks := []interface{}{"default", "plugins.my_plugin", "heading_anchors"}
+	if !reflect.DeepEqual(mg["hooks"], wantHooks) {
+		t.Errorf("hooks = %v, want %v", mg["hooks"], wantHooks)
+	}
+	wantDisabled := []interface{}{"static_assets"}
+	if !reflect.DeepEqual(mg["disabled_hooks"], wantDisabled) {
+		t.Errorf("disabled_hooks = %v, want %v", mg["disabled_hooks"], wantDisabled)
+	}
+
+	customWarnings := 0
+	for _, w := range result.Warnings {
+		if w.Category == "plugin" && strings.Contains(w.Message, "plugins.my_plugin") {
+			customWarnings++
+		}
+	}
+	if customWarnings != 1 {
+		t.Errorf("expected 1 custom hook warning, got %d", customWarnings)
+	}
+}
+
+func TestReport_HookMigrations(t *testing.T) {
+	input := map[string]interface{}{
+		"markata": map[string]interface{}{
+			"hooks": []interface{}{
+				"markata.plugins.heading_link",
+				"markata.plugins.covers",
+				"plugins.my_plugin",
+			},
+		},
+	}
+
+	result, err := ConfigFromMap(input)
+	if err != nil {
+		t.Fatalf("ConfigFromMap() error = %v", err)
+	}
+
+	report := result.Report()
+	for _, want := range []string{
+		"HOOK MIGRATIONS",
+		"[MIGRATE] markata.plugins.heading_link -> heading_anchors",
+		"[DROPPED] markata.plugins.covers (unsupported:",
+		"[WARN] plugins.my_plugin (custom, kept as-is)",
+	} {
+		if !strings.Contains(report, want) {
+			t.Errorf("report missing %q", want)
+		}
+	}
+}
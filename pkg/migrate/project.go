@@ -0,0 +1,504 @@
+package migrate
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/WaylonWalker/markata-go/pkg/plugins"
+	"github.com/bmatcuk/doublestar/v4"
+)
+
+// Finding severities, from most to least severe.
+const (
+	SeverityError   = "error"
+	SeverityWarning = "warning"
+	SeverityInfo    = "info"
+)
+
+// defaultTemplatesDir is the Python markata templates directory.
+const defaultTemplatesDir = "templates"
+
+// MaxSamplePosts is the number of posts CheckProject inspects.
+const MaxSamplePosts = 100
+
+// ConfigCandidates are the Python markata config files looked for, in order.
+var ConfigCandidates = []string{
+	"markata.toml",
+	"pyproject.toml",
+	"markata.yaml",
+	"markata.yml",
+}
+
+// skipProjectDirs are directories never scanned for posts.
+var skipProjectDirs = map[string]bool{
+	"node_modules": true,
+	"venv":         true,
+	"markout":      true,
+	"public":       true,
+	"__pycache__":  true,
+}
+
+// ProjectFinding is a single issue found while checking a project.
+type ProjectFinding struct {
+	// File is the file the finding belongs to, relative to the project
+	File string
+
+	// Line is the line number, or 0 if the finding is not tied to a line
+	Line int
+
+	// Path is the config path, for config findings
+	Path string
+
+	// Severity is "error", "warning", or "info"; errors block migration
+	Severity string
+
+	// Category groups related findings
+	Category string // "config", "filter", "plugin", "template", "frontmatter"
+
+	// Message describes the finding
+	Message string
+
+	// Suggestion provides actionable guidance
+	Suggestion string
+}
+
+// FileFindings groups the findings for one file.
+type FileFindings struct {
+	File     string
+	Findings []ProjectFinding
+}
+
+// ProjectReport is the consolidated result of checking a Python markata
+// project.
+type ProjectReport struct {
+	// Dir is the project directory
+	Dir string
+
+	// ConfigFile is the config file found, relative to Dir
+	ConfigFile string
+
+	// Config is the config migration result, nil if no config was found
+	Config *MigrationResult
+
+	// TemplatesDir is the templates directory checked, relative to Dir
+	TemplatesDir string
+
+	// PostsFound is the number of posts matching the glob patterns
+	PostsFound int
+
+	// PostsScanned is the number of posts inspected (at most MaxSamplePosts)
+	PostsScanned int
+
+	// Findings is every issue found, in discovery order
+	Findings []ProjectFinding
+
+	// Timestamp when the check was performed
+	Timestamp time.Time
+}
+
+// FindConfig returns the path of the first Python markata config file in dir.
+func FindConfig(dir string) (string, error) {
+	for _, candidate := range ConfigCandidates {
+		path := filepath.Join(dir, candidate)
+		if info, err := os.Stat(path); err == nil && !info.IsDir() {
+			return path, nil
+		}
+	}
+	return "", fmt.Errorf("no Python markata config file found (tried: %v)", ConfigCandidates)
+}
+
+// CheckProject walks a Python markata project and reports everything that
+// needs attention before migrating: config changes, filter expressions,
+// template incompatibilities, and problems in a sample of posts.
+//
+// Posts are parsed the way markata-go loads them, so frontmatter that
+// Python markata accepted but markata-go rejects is reported as an error.
+func CheckProject(dir string) (*ProjectReport, error) {
+	info, err := os.Stat(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to access project directory: %w", err)
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("not a directory: %s", dir)
+	}
+
+	report := &ProjectReport{
+		Dir:          dir,
+		TemplatesDir: defaultTemplatesDir,
+		Timestamp:    time.Now(),
+	}
+
+	settings := report.checkConfig()
+	report.checkTemplates(settings)
+	if err := report.checkPosts(settings); err != nil {
+		return nil, err
+	}
+
+	return report, nil
+}
+
+// projectSettings are the config values that drive the rest of the check.
+type projectSettings struct {
+	templatesDir string
+	outputDir    string
+	patterns     []string
+}
+
+// checkConfig finds and migrates the project config.
+func (r *ProjectReport) checkConfig() projectSettings {
+	settings := projectSettings{
+		templatesDir: defaultTemplatesDir,
+		outputDir:    "markout",
+		patterns:     []string{"**/*.md"},
+	}
+
+	path, err := FindConfig(r.Dir)
+	if err != nil {
+		r.add(ProjectFinding{
+			File:       ".",
+			Severity:   SeverityError,
+			Category:   "config",
+			Message:    err.Error(),
+			Suggestion: "Run the check from the root of a Python markata project",
+		})
+		return settings
+	}
+	r.ConfigFile = r.rel(path)
+
+	result, err := Config(path, "")
+	if err != nil {
+		r.add(ProjectFinding{
+			File:     r.ConfigFile,
+			Severity: SeverityError,
+			Category: "config",
+			Message:  err.Error(),
+		})
+		return settings
+	}
+	r.Config = result
+
+	for _, c := range result.Changes {
+		r.add(ProjectFinding{
+			File:     r.ConfigFile,
+			Path:     c.Path,
+			Severity: SeverityInfo,
+			Category: "config",
+			Message:  c.Description,
+		})
+	}
+	for _, fm := range result.FilterMigrations {
+		feed := fm.Feed
+		if feed == "" {
+			feed = "(unnamed)"
+		}
+		if len(fm.Changes) > 0 {
+			r.add(ProjectFinding{
+				File:       r.ConfigFile,
+				Path:       "feeds." + feed,
+				Severity:   SeverityWarning,
+				Category:   "filter",
+				Message:    fmt.Sprintf("Filter needs migrating: %s -> %s", fm.Original, fm.Migrated),
+				Suggestion: strings.Join(fm.Changes, "; "),
+			})
+		}
+		if !fm.Valid {
+			r.add(ProjectFinding{
+				File:     r.ConfigFile,
+				Path:     "feeds." + feed,
+				Severity: SeverityError,
+				Category: "filter",
+				Message:  fmt.Sprintf("Invalid filter %q: %s", fm.Migrated, fm.Error),
+			})
+		}
+	}
+	for _, w := range result.Warnings {
+		r.add(ProjectFinding{
+			File:       r.ConfigFile,
+			Path:       w.Path,
+			Severity:   SeverityWarning,
+			Category:   w.Category,
+			Message:    w.Message,
+			Suggestion: w.Suggestion,
+		})
+	}
+	for _, e := range result.Errors {
+		r.add(ProjectFinding{
+			File:     r.ConfigFile,
+			Path:     e.Path,
+			Severity: SeverityError,
+			Category: e.Category,
+			Message:  e.Message,
+		})
+	}
+
+	section, ok := result.MigratedConfig["markata-go"].(map[string]interface{})
+	if !ok {
+		return settings
+	}
+	if s, ok := section["templates_dir"].(string); ok && s != "" {
+		settings.templatesDir = s
+	}
+	if s, ok := section["output_dir"].(string); ok && s != "" {
+		settings.outputDir = s
+	}
+	patterns := stringList(section["patterns"])
+	if glob, ok := section["glob"].(map[string]interface{}); ok && len(patterns) == 0 {
+		patterns = stringList(glob["patterns"])
+	}
+	if len(patterns) > 0 {
+		settings.patterns = patterns
+	}
+
+	return settings
+}
+
+// checkTemplates runs the template compatibility checker over the
+// project's templates directory, if it exists.
+func (r *ProjectReport) checkTemplates(settings projectSettings) {
+	r.TemplatesDir = settings.templatesDir
+	dir := filepath.Join(r.Dir, settings.templatesDir)
+	if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+		return
+	}
+
+	issues, err := CheckTemplates(dir)
+	if err != nil {
+		r.add(ProjectFinding{
+			File:     settings.templatesDir,
+			Severity: SeverityWarning,
+			Category: "template",
+			Message:  fmt.Sprintf("Failed to check templates: %v", err),
+		})
+		return
+	}
+	for _, issue := range issues {
+		r.addTemplateIssue(r.rel(issue.File), issue)
+	}
+}
+
+// checkPosts parses a sample of posts as markata-go would load them.
+func (r *ProjectReport) checkPosts(settings projectSettings) error {
+	files, err := r.findPosts(settings)
+	if err != nil {
+		return fmt.Errorf("failed to find posts: %w", err)
+	}
+
+	r.PostsFound = len(files)
+	if len(files) > MaxSamplePosts {
+		files = files[:MaxSamplePosts]
+	}
+	r.PostsScanned = len(files)
+
+	for _, file := range files {
+		data, err := os.ReadFile(filepath.Join(r.Dir, file))
+		if err != nil {
+			r.add(ProjectFinding{
+				File:     file,
+				Severity: SeverityError,
+				Category: "frontmatter",
+				Message:  err.Error(),
+			})
+			continue
+		}
+		r.checkPost(file, string(data))
+	}
+
+	return nil
+}
+
+// checkPost reports problems with a single post.
+func (r *ProjectReport) checkPost(file, content string) {
+	post, err := plugins.ParsePostFromContent(file, content)
+	if err != nil {
+		r.add(ProjectFinding{
+			File:       file,
+			Severity:   SeverityError,
+			Category:   "frontmatter",
+			Message:    fmt.Sprintf("Post will not load in markata-go: %v", err),
+			Suggestion: "Fix the frontmatter so it is valid YAML with parseable dates",
+		})
+		return
+	}
+
+	_, body, err := plugins.ExtractFrontmatter(content)
+	if err != nil {
+		return
+	}
+	offset := 0
+	if i := strings.Index(content, body); body != "" && i >= 0 {
+		offset = strings.Count(content[:i], "\n")
+	}
+
+	jinja := plugins.GetBool(post.Extra, "jinja", false) || plugins.GetBool(post.Extra, "jinja_md", false)
+	if !jinja {
+		// Python markata renders every post with Jinja unless it opts out;
+		// markata-go only renders posts that opt in.
+		_, optedOut := post.Extra["jinja"]
+		if !optedOut && (strings.Contains(body, "{{") || strings.Contains(body, "{%")) {
+			r.add(ProjectFinding{
+				File:       file,
+				Severity:   SeverityWarning,
+				Category:   "frontmatter",
+				Message:    "Post body contains Jinja syntax but jinja is not enabled",
+				Suggestion: "Add 'jinja: true' to the frontmatter to render it with markata-go",
+			})
+		}
+		return
+	}
+
+	scope := &loopScope{}
+	for i, line := range strings.Split(body, "\n") {
+		for _, issue := range checkTemplateLine(file, offset+i+1, line, scope) {
+			r.addTemplateIssue(file, issue)
+		}
+	}
+}
+
+// findPosts returns the project's posts matching the glob patterns, sorted
+// and relative to the project directory.
+func (r *ProjectReport) findPosts(settings projectSettings) ([]string, error) {
+	fsys := os.DirFS(r.Dir)
+	seen := make(map[string]bool)
+	var files []string
+
+	for _, pattern := range settings.patterns {
+		matches, err := doublestar.Glob(fsys, filepath.ToSlash(pattern), doublestar.WithFilesOnly())
+		if err != nil {
+			return nil, err
+		}
+		for _, match := range matches {
+			if seen[match] || skipPostPath(match, settings.outputDir) {
+				continue
+			}
+			seen[match] = true
+			files = append(files, filepath.FromSlash(match))
+		}
+	}
+
+	sort.Strings(files)
+	return files, nil
+}
+
+// skipPostPath reports whether a matched path is inside a hidden, output,
+// or dependency directory.
+func skipPostPath(match, outputDir string) bool {
+	dirs := strings.Split(match, "/")
+	dirs = dirs[:len(dirs)-1]
+	for _, dir := range dirs {
+		if strings.HasPrefix(dir, ".") || skipProjectDirs[dir] || dir == outputDir {
+			return true
+		}
+	}
+	return false
+}
+
+// add records a finding.
+func (r *ProjectReport) add(f ProjectFinding) {
+	r.Findings = append(r.Findings, f)
+}
+
+// addTemplateIssue records a template compatibility issue as a finding.
+func (r *ProjectReport) addTemplateIssue(file string, issue TemplateIssue) {
+	severity := issue.Severity
+	if severity != SeverityError && severity != SeverityWarning {
+		severity = SeverityInfo
+	}
+	r.add(ProjectFinding{
+		File:       file,
+		Line:       issue.Line,
+		Severity:   severity,
+		Category:   "template",
+		Message:    issue.Issue,
+		Suggestion: issue.Suggestion,
+	})
+}
+
+// rel returns path relative to the project directory.
+func (r *ProjectReport) rel(path string) string {
+	if rel, err := filepath.Rel(r.Dir, path); err == nil {
+		return rel
+	}
+	return path
+}
+
+// Count returns the number of findings with the given severity.
+func (r *ProjectReport) Count(severity string) int {
+	n := 0
+	for _, f := range r.Findings {
+		if f.Severity == severity {
+			n++
+		}
+	}
+	return n
+}
+
+// HasErrors returns true if any finding blocks migration.
+func (r *ProjectReport) HasErrors() bool {
+	return r.Count(SeverityError) > 0
+}
+
+// ExitCode returns 2 if any finding blocks migration, 0 otherwise.
+func (r *ProjectReport) ExitCode() int {
+	if r.HasErrors() {
+		return 2
+	}
+	return 0
+}
+
+// severityRank orders severities for display, most severe first.
+var severityRank = map[string]int{
+	SeverityError:   0,
+	SeverityWarning: 1,
+	SeverityInfo:    2,
+}
+
+// ByFile groups findings by file, sorted by file name. Within a file,
+// findings are ordered by severity and then line.
+func (r *ProjectReport) ByFile() []FileFindings {
+	index := make(map[string]int)
+	var groups []FileFindings
+	for _, f := range r.Findings {
+		i, ok := index[f.File]
+		if !ok {
+			i = len(groups)
+			index[f.File] = i
+			groups = append(groups, FileFindings{File: f.File})
+		}
+		groups[i].Findings = append(groups[i].Findings, f)
+	}
+
+	sort.Slice(groups, func(i, j int) bool { return groups[i].File < groups[j].File })
+	for _, g := range groups {
+		sort.SliceStable(g.Findings, func(i, j int) bool {
+			a, b := g.Findings[i], g.Findings[j]
+			if severityRank[a.Severity] != severityRank[b.Severity] {
+				return severityRank[a.Severity] < severityRank[b.Severity]
+			}
+			return a.Line < b.Line
+		})
+	}
+	return groups
+}
+
+// stringList converts a config value to a list of strings.
+func stringList(v interface{}) []string {
+	switch list := v.(type) {
+	case string:
+		return []string{list}
+	case []string:
+		return list
+	case []interface{}:
+		var out []string
+		for _, item := range list {
+			if s, ok := item.(string); ok {
+				out = append(out, s)
+			}
+		}
+		return out
+	}
+	return nil
+}
@@ -0,0 +1,128 @@
+package migrate
+
+import (
+	"strings"
+	"testing"
+)
+
+func createTestProject(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+
+	createTestFile(t, dir, "markata.toml", `[markata]
+hooks = ["markata.plugins.heading_link", "markata.plugins.covers"]
+glob_patterns = ["pages/**/*.md"]
+
+[[markata.feeds]]
+slug = "blog"
+filter = "published == 'True'"
+`)
+	createTestFile(t, dir, "templates/post.html", "{% macro card(post) %}{% endmacro %}\n")
+	createTestFile(t, dir, "pages/good.md", "---\ntitle: Good\ndate: 2024-01-02\npublished: true\n---\n\n# Good\n")
+	createTestFile(t, dir, "pages/bad-date.md", "---\ntitle: Bad\ndate: sometime last spring\n---\n\nBody\n")
+	createTestFile(t, dir, "pages/jinja.md", "---\ntitle: Jinja\n---\n\n{{ config.title }}\n")
+	createTestFile(t, dir, "pages/jinja-on.md", "---\ntitle: On\njinja: true\n---\n\nIntro\n\n{% do items.append(1) %}\n")
+	createTestFile(t, dir, "pages/.drafts/hidden.md", "---\ndate: nope\n---\n")
+	createTestFile(t, dir, "README.md", "# not a post\n")
+
+	return dir
+}
+
+func TestCheckProject(t *testing.T) {
+	dir := createTestProject(t)
+
+	report, err := CheckProject(dir)
+	if err != nil {
+		t.Fatalf("CheckProject() error = %v", err)
+	}
+
+	if report.ConfigFile != "markata.toml" {
+		t.Errorf("ConfigFile = %q, want markata.toml", report.ConfigFile)
+	}
+	if report.PostsFound != 4 || report.PostsScanned != 4 {
+		t.Errorf("posts found/scanned = %d/%d, want 4/4", report.PostsFound, report.PostsScanned)
+	}
+
+	has := func(file, severity, category, substr string) bool {
+		for _, f := range report.Findings {
+			if f.File == file && f.Severity == severity && f.Category == category && strings.Contains(f.Message, substr) {
+				return true
+			}
+		}
+		return false
+	}
+
+	tests := []struct {
+		file, severity, category, substr string
+	}{
+		{"markata.toml", SeverityInfo, "config", "Namespace change"},
+		{"markata.toml", SeverityWarning, "filter", "published == True"},
+		{"markata.toml", SeverityWarning, "plugin", "markata.plugins.covers"},
+		{"templates/post.html", SeverityError, "template", "macro"},
+		{"pages/bad-date.md", SeverityError, "frontmatter", "will not load"},
+		{"pages/jinja.md", SeverityWarning, "frontmatter", "Jinja syntax"},
+		{"pages/jinja-on.md", SeverityError, "template", "do %}"},
+	}
+	for _, tt := range tests {
+		if !has(tt.file, tt.severity, tt.category, tt.substr) {
+			t.Errorf("missing %s %s finding for %s containing %q", tt.severity, tt.category, tt.file, tt.substr)
+		}
+	}
+
+	for _, f := range report.Findings {
+		if f.File == "pages/jinja-on.md" && f.Line != 8 {
+			t.Errorf("jinja-on.md finding on line %d, want 8", f.Line)
+		}
+		if f.File == "pages/good.md" || strings.Contains(f.File, ".drafts") {
+			t.Errorf("unexpected finding for %s: %s", f.File, f.Message)
+		}
+	}
+
+	if !report.HasErrors() || report.ExitCode() != 2 {
+		t.Errorf("HasErrors() = %v, ExitCode() = %d, want true, 2", report.HasErrors(), report.ExitCode())
+	}
+}
+
+func TestCheckProject_NoConfig(t *testing.T) {
+	dir := t.TempDir()
+	createTestFile(t, dir, "post.md", "---\ntitle: Post\n---\n")
+
+	report, err := CheckProject(dir)
+	if err != nil {
+		t.Fatalf("CheckProject() error = %v", err)
+	}
+	if !report.HasErrors() {
+		t.Error("expected a blocking finding when no config exists")
+	}
+	if report.PostsScanned != 1 {
+		t.Errorf("PostsScanned = %d, want 1", report.PostsScanned)
+	}
+}
+
+func TestProjectReport_ByFile(t *testing.T) {
+	report := &ProjectReport{Findings: []ProjectFinding{
+		{File: "b.md", Severity: SeverityInfo, Line: 1},
+		{File: "a.md", Severity: SeverityWarning, Line: 3},
+		{File: "b.md", Severity: SeverityError, Line: 5},
+		{File: "a.md", Severity: SeverityWarning, Line: 2},
+	}}
+
+	groups := report.ByFile()
+	if len(groups) != 2 || groups[0].File != "a.md" || groups[1].File != "b.md" {
+		t.Fatalf("ByFile() files = %+v, want [a.md b.md]", groups)
+	}
+	if groups[0].Findings[0].Line != 2 {
+		t.Errorf("a.md first finding line = %d, want 2", groups[0].Findings[0].Line)
+	}
+	if groups[1].Findings[0].Severity != SeverityError {
+		t.Errorf("b.md first finding severity = %s, want error", groups[1].Findings[0].Severity)
+	}
+
+	text := report.Report()
+	if !strings.Contains(text, "FINDINGS BY FILE") || !strings.Contains(text, "Blocking issues found") {
+		t.Errorf("Report() missing sections:\n%s", text)
+	}
+	if strings.Index(text, "  a.md") > strings.Index(text, "  b.md") {
+		t.Error("Report() should list files in sorted order")
+	}
+}
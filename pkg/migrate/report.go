@@ -11,6 +11,7 @@ const (
 	symbolWarning = "[WARN]"
 	symbolError   = "[ERROR]"
 	symbolMigrate = "[MIGRATE]"
+	symbolDropped = "[DROPPED]"
 )
 
 // Report generates a human-readable migration report.
@@ -21,9 +22,11 @@ func (r *MigrationResult) Report() string {
 	r.writeSummary(&sb)
 	r.writeConfigChanges(&sb)
 	r.writeFilterMigrations(&sb)
+	r.writeHookMigrations(&sb)
 	r.writeWarnings(&sb)
 	r.writeErrors(&sb)
 	r.writeTemplateIssues(&sb)
+	r.writeTemplateRewrites(&sb)
 	r.writeNextSteps(&sb)
 
 	sb.WriteString(strings.Repeat("=", 80) + "\n")
@@ -62,9 +65,15 @@ func (r *MigrationResult) writeSummary(sb *strings.Builder) {
 	fmt.Fprintf(sb, "  Warnings:            %d\n", len(r.Warnings))
 	fmt.Fprintf(sb, "  Errors:              %d\n", len(r.Errors))
 
+	if len(r.HookMigrations) > 0 {
+		fmt.Fprintf(sb, "  Hook migrations:     %d\n", len(r.HookMigrations))
+	}
 	if len(r.TemplateIssues) > 0 {
 		fmt.Fprintf(sb, "  Template issues:     %d\n", len(r.TemplateIssues))
 	}
+	if len(r.TemplateRewrites) > 0 {
+		fmt.Fprintf(sb, "  Template rewrites:   %d\n", len(r.TemplateRewrites))
+	}
 
 	sb.WriteString("\n")
 }
@@ -119,6 +128,31 @@ func (r *MigrationResult) writeFilterMigrations(sb *strings.Builder) {
 	}
 }
 
+// writeHookMigrations writes the hook migrations section.
+func (r *MigrationResult) writeHookMigrations(sb *strings.Builder) {
+	if len(r.HookMigrations) == 0 {
+		return
+	}
+
+	sb.WriteString(strings.Repeat("-", 80) + "\n")
+	sb.WriteString("HOOK MIGRATIONS\n")
+	sb.WriteString(strings.Repeat("-", 80) + "\n\n")
+
+	for _, hm := range r.HookMigrations {
+		switch hm.Status {
+		case HookStatusTranslated:
+			fmt.Fprintf(sb, "  %s %s -> %s\n", symbolMigrate, hm.Original, hm.Migrated)
+		case HookStatusUnchanged:
+			fmt.Fprintf(sb, "  %s %s\n", symbolSuccess, hm.Original)
+		case HookStatusUnsupported:
+			fmt.Fprintf(sb, "  %s %s (unsupported: %s)\n", symbolDropped, hm.Original, hm.Note)
+		case HookStatusCustom:
+			fmt.Fprintf(sb, "  %s %s (custom, kept as-is)\n", symbolWarning, hm.Original)
+		}
+	}
+	sb.WriteString("\n")
+}
+
 // writeWarnings writes the warnings section.
 func (r *MigrationResult) writeWarnings(sb *strings.Builder) {
 	if len(r.Warnings) == 0 {
@@ -185,6 +219,33 @@ func (r *MigrationResult) writeTemplateIssues(sb *strings.Builder) {
 	}
 }
 
+// writeTemplateRewrites writes the template rewrites section.
+func (r *MigrationResult) writeTemplateRewrites(sb *strings.Builder) {
+	if len(r.TemplateRewrites) == 0 {
+		return
+	}
+
+	sb.WriteString(strings.Repeat("-", 80) + "\n")
+	sb.WriteString("TEMPLATE REWRITES\n")
+	sb.WriteString(strings.Repeat("-", 80) + "\n\n")
+
+	for _, tr := range r.TemplateRewrites {
+		status := "not written"
+		if tr.Written {
+			status = "rewritten"
+		}
+		fmt.Fprintf(sb, "  %s (%s)\n", tr.File, status)
+		for _, c := range tr.Changes {
+			symbol := symbolMigrate
+			if c.Manual {
+				symbol = symbolWarning
+			}
+			fmt.Fprintf(sb, "    %s line %d: %s\n", symbol, c.Line, c.Description)
+		}
+		sb.WriteString("\n")
+	}
+}
+
 // writeNextSteps writes the next steps section.
 func (r *MigrationResult) writeNextSteps(sb *strings.Builder) {
 	sb.WriteString(strings.Repeat("-", 80) + "\n")
@@ -256,5 +317,101 @@ func (r *MigrationResult) JSONReport() map[string]interface{} {
 		"warnings":          r.Warnings,
 		"errors":            r.Errors,
 		"template_issues":   r.TemplateIssues,
+		"template_rewrites": r.TemplateRewrites,
+		"hook_migrations":   r.HookMigrations,
+	}
+}
+
+// Report generates a human-readable project check report, grouped by file
+// and severity.
+func (r *ProjectReport) Report() string {
+	var sb strings.Builder
+
+	sb.WriteString(strings.Repeat("=", 80) + "\n")
+	sb.WriteString("                     markata-go Project Migration Check\n")
+	sb.WriteString(strings.Repeat("=", 80) + "\n\n")
+
+	fmt.Fprintf(&sb, "Project: %s\n", r.Dir)
+	if r.ConfigFile != "" {
+		fmt.Fprintf(&sb, "Configuration File: %s\n", r.ConfigFile)
+	}
+	fmt.Fprintf(&sb, "Templates: %s\n", r.TemplatesDir)
+	fmt.Fprintf(&sb, "Posts scanned: %d of %d\n", r.PostsScanned, r.PostsFound)
+	fmt.Fprintf(&sb, "Generated: %s\n\n", r.Timestamp.Format("2006-01-02 15:04:05"))
+
+	sb.WriteString(strings.Repeat("-", 80) + "\n")
+	sb.WriteString("SUMMARY\n")
+	sb.WriteString(strings.Repeat("-", 80) + "\n\n")
+
+	groups := r.ByFile()
+	errors, warnings := r.Count(SeverityError), r.Count(SeverityWarning)
+	status := "Ready to migrate"
+	if errors > 0 {
+		status = "Blocking issues found"
+	} else if warnings > 0 {
+		status = "Ready to migrate (with warnings)"
+	}
+	fmt.Fprintf(&sb, "  Status: %s\n\n", status)
+	fmt.Fprintf(&sb, "  Errors:              %d\n", errors)
+	fmt.Fprintf(&sb, "  Warnings:            %d\n", warnings)
+	fmt.Fprintf(&sb, "  Info:                %d\n", r.Count(SeverityInfo))
+	fmt.Fprintf(&sb, "  Files with findings: %d\n\n", len(groups))
+
+	if len(groups) > 0 {
+		sb.WriteString(strings.Repeat("-", 80) + "\n")
+		sb.WriteString("FINDINGS BY FILE\n")
+		sb.WriteString(strings.Repeat("-", 80) + "\n\n")
+	}
+
+	for _, g := range groups {
+		fmt.Fprintf(&sb, "  %s\n", g.File)
+		for _, f := range g.Findings {
+			location := ""
+			switch {
+			case f.Line > 0:
+				location = fmt.Sprintf("line %d: ", f.Line)
+			case f.Path != "":
+				location = f.Path + ": "
+			}
+			fmt.Fprintf(&sb, "    %s [%s] %s%s\n", severitySymbol(f.Severity), f.Category, location, f.Message)
+			if f.Suggestion != "" {
+				fmt.Fprintf(&sb, "           Suggestion: %s\n", f.Suggestion)
+			}
+		}
+		sb.WriteString("\n")
+	}
+
+	sb.WriteString(strings.Repeat("=", 80) + "\n")
+
+	return sb.String()
+}
+
+// severitySymbol returns the report symbol for a finding severity.
+func severitySymbol(severity string) string {
+	switch severity {
+	case SeverityError:
+		return symbolError
+	case SeverityWarning:
+		return symbolWarning
+	default:
+		return "[INFO]"
+	}
+}
+
+// JSONReport returns a JSON-friendly structure for programmatic use.
+func (r *ProjectReport) JSONReport() map[string]interface{} {
+	return map[string]interface{}{
+		"dir":            r.Dir,
+		"config_file":    r.ConfigFile,
+		"templates_dir":  r.TemplatesDir,
+		"posts_found":    r.PostsFound,
+		"posts_scanned":  r.PostsScanned,
+		"timestamp":      r.Timestamp.Format("2006-01-02T15:04:05Z07:00"),
+		"errors_count":   r.Count(SeverityError),
+		"warnings_count": r.Count(SeverityWarning),
+		"info_count":     r.Count(SeverityInfo),
+		"exit_code":      r.ExitCode(),
+		"has_errors":     r.HasErrors(),
+		"files":          r.ByFile(),
 	}
 }
@@ -0,0 +1,407 @@
+package migrate
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// Change records a single template rewrite, or a construct that needs
+// manual migration.
+type Change struct {
+	// Line is the line of the template tag in the source
+	Line int
+
+	// Original is the template tag as written
+	Original string
+
+	// Migrated is the tag after all automatic rewrites
+	Migrated string
+
+	// Description explains the change
+	Description string
+
+	// Manual indicates the construct could not be converted automatically
+	Manual bool
+}
+
+// Template rewrite patterns.
+var (
+	// Match {% ... %} and {{ ... }} tags, including whitespace control
+	templateTagPattern = regexp.MustCompile(`(?s)\{%-?.*?-?%\}|\{\{-?.*?-?\}\}`)
+
+	// Match the delimiters of a template tag
+	templateTagDelims = regexp.MustCompile(`(?s)^(\{[%{]-?)(.*?)(-?[%}]\})$`)
+
+	// Match |tojson, |tojson(2) and |tojson(indent=2)
+	tojsonPattern = regexp.MustCompile(`\|\s*tojson\b(?:\s*\(\s*(?:indent\s*=\s*)?(\d*)\s*\))?`)
+
+	// Match Jinja2's capitalized boolean literals
+	boolLiteralPattern = regexp.MustCompile(`\b(True|False)\b`)
+
+	// Match loop attributes pongo2 has no equivalent for
+	loopUnsupportedPattern = regexp.MustCompile(`\bloop\.(length|cycle|previtem|nextitem|depth0?|changed)\b`)
+
+	// Match {% set x = a if cond else b %}
+	setConditionalPattern = regexp.MustCompile(`^set\s+(\w+)\s*=\s*(.+?)\s+if\s+(.+?)\s+else\s+(.+)$`)
+
+	// Match {% for vars in iterable %}
+	forPattern = regexp.MustCompile(`^for\s+(.+?)\s+in\s+(.+)$`)
+
+	// Match one operand of a ~ concatenation: a variable, literal or
+	// filtered variable, with no spaces
+	concatOperandPattern = regexp.MustCompile(`^(?:"[^"]*"|'[^']*'|[\w.]+(?:\|\w+(?::(?:"[^"]*"|'[^']*'|[\w.]+))?)*)$`)
+)
+
+// loopAttributes maps Jinja2 loop attributes to pongo2 forloop attributes.
+var loopAttributes = []struct {
+	pattern *regexp.Regexp
+	jinja   string
+	pongo2  string
+}{
+	{regexp.MustCompile(`\bloop\.index0\b`), "loop.index0", "forloop.Counter0"},
+	{regexp.MustCompile(`\bloop\.index\b`), "loop.index", "forloop.Counter"},
+	{regexp.MustCompile(`\bloop\.revindex0\b`), "loop.revindex0", "forloop.Revcounter0"},
+	{regexp.MustCompile(`\bloop\.revindex\b`), "loop.revindex", "forloop.Revcounter"},
+	{regexp.MustCompile(`\bloop\.first\b`), "loop.first", "forloop.First"},
+	{regexp.MustCompile(`\bloop\.last\b`), "loop.last", "forloop.Last"},
+}
+
+// MigrateTemplate rewrites common Jinja2 template patterns into pongo2
+// equivalents and renames Python markata variables to their markata-go
+// names (see GetVariableMigrations). Constructs that cannot be converted,
+// including markata references with no equivalent, are left as written
+// and recorded as a Change with Manual set.
+func MigrateTemplate(src string) (string, []Change) {
+	var sb strings.Builder
+	var changes []Change
+	last := 0
+	scope := &loopScope{}
+
+	for _, loc := range templateTagPattern.FindAllStringIndex(src, -1) {
+		tag := src[loc[0]:loc[1]]
+		migrated, descriptions, manual := migrateTemplateTag(tag)
+
+		migrated, renamed := renameVariables(migrated, scope)
+		for _, v := range renamed {
+			descriptions = append(descriptions, v.Old+" -> "+v.New)
+		}
+		for _, ref := range unknownMarkataReferences(migrated, scope) {
+			manual = append(manual, ref+" has no markata-go equivalent; review it manually")
+		}
+		scope.observe(tag)
+
+		line := strings.Count(src[:loc[0]], "\n") + 1
+		for _, d := range descriptions {
+			changes = append(changes, Change{Line: line, Original: tag, Migrated: migrated, Description: d})
+		}
+		for _, d := range manual {
+			changes = append(changes, Change{Line: line, Original: tag, Migrated: migrated, Description: d, Manual: true})
+		}
+
+		sb.WriteString(src[last:loc[0]])
+		sb.WriteString(migrated)
+		last = loc[1]
+	}
+	sb.WriteString(src[last:])
+
+	return sb.String(), changes
+}
+
+// MigrateTemplates runs MigrateTemplate over every template in
+// templatesDir and returns the files with changes. When write is true,
+// files with automatic rewrites are saved in place; otherwise the
+// rewrites are only reported.
+func MigrateTemplates(templatesDir string, write bool) ([]TemplateRewrite, error) {
+	var rewrites []TemplateRewrite
+
+	err := filepath.WalkDir(templatesDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || !isTemplateFile(path) {
+			return nil
+		}
+
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		migrated, changes := MigrateTemplate(string(content))
+		if len(changes) == 0 {
+			return nil
+		}
+
+		rewrite := TemplateRewrite{File: path, Changes: changes}
+		if write && migrated != string(content) {
+			info, err := d.Info()
+			if err != nil {
+				return err
+			}
+			if err := os.WriteFile(path, []byte(migrated), info.Mode().Perm()); err != nil {
+				return fmt.Errorf("writing %s: %w", path, err)
+			}
+			rewrite.Written = true
+		}
+		rewrites = append(rewrites, rewrite)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return rewrites, nil
+}
+
+// migrateTemplateTag rewrites a single tag. It returns the rewritten tag,
+// a description of each rewrite, and a description of each construct
+// needing manual migration.
+func migrateTemplateTag(tag string) (migrated string, descriptions, manual []string) {
+	m := templateTagDelims.FindStringSubmatch(tag)
+	if m == nil {
+		return tag, nil, nil
+	}
+	open, inner, closing := m[1], m[2], m[3]
+	body := strings.TrimSpace(inner)
+
+	if strings.HasPrefix(open, "{%") {
+		keyword, _, _ := strings.Cut(body, " ")
+		switch keyword {
+		case "do":
+			return tag, nil, []string{"{% do %} is not supported in pongo2; use {% set %} or restructure the logic"}
+		case "call":
+			return tag, nil, []string{"{% call %} blocks are not supported in pongo2; use {% include %} or standard blocks"}
+		case "from":
+			return tag, nil, []string{"{% from ... import %} is not supported in pongo2; use {% include %} with explicit variables"}
+		case "set":
+			return migrateSetTag(tag, open, body, closing)
+		case "for":
+			body, descriptions, manual = migrateForTag(body)
+		}
+	}
+
+	if pythonExprPattern.MatchString(tag) {
+		manual = append(manual, "Python list comprehensions are not supported; pre-compute the value in Go")
+	}
+
+	body, exprDescriptions, exprManual := migrateExpression(body)
+	descriptions = append(descriptions, exprDescriptions...)
+	manual = append(manual, exprManual...)
+
+	if len(descriptions) == 0 {
+		return tag, nil, manual
+	}
+	return open + " " + body + " " + closing, descriptions, manual
+}
+
+// migrateSetTag rewrites a {% set %} tag.
+func migrateSetTag(tag, open, body, closing string) (migrated string, descriptions, manual []string) {
+	name, value, ok := strings.Cut(strings.TrimPrefix(body, "set"), "=")
+	switch {
+	case !ok:
+		return tag, nil, []string{"Block {% set %}...{% endset %} is not supported in pongo2; assign the value with {% set x = ... %} or use {% filter %}"}
+	case strings.Contains(name, ","):
+		return tag, nil, []string{"Tuple assignment in {% set %} is not supported in pongo2; assign each variable separately"}
+	case strings.Contains(value, "namespace("):
+		return tag, nil, []string{"namespace() is not supported in pongo2; restructure the loop to avoid mutating outer variables"}
+	}
+
+	if m := setConditionalPattern.FindStringSubmatch(body); m != nil {
+		ifValue, ifDescriptions, ifManual := migrateExpression(m[2])
+		cond, condDescriptions, condManual := migrateExpression(m[3])
+		elseValue, elseDescriptions, elseManual := migrateExpression(m[4])
+
+		descriptions = append(descriptions, "Inline if/else in {% set %} -> {% if %} block")
+		descriptions = append(descriptions, ifDescriptions...)
+		descriptions = append(descriptions, condDescriptions...)
+		descriptions = append(descriptions, elseDescriptions...)
+		manual = append(append(append(manual, ifManual...), condManual...), elseManual...)
+
+		migrated = fmt.Sprintf("%s if %s %%}{%% set %s = %s %%}{%% else %%}{%% set %s = %s %%}{%% endif %s",
+			open, cond, m[1], ifValue, m[1], elseValue, closing)
+		return migrated, dedupe(descriptions), manual
+	}
+
+	body, descriptions, manual = migrateExpression(body)
+	if len(descriptions) == 0 {
+		return tag, nil, manual
+	}
+	return open + " " + body + " " + closing, descriptions, manual
+}
+
+// migrateForTag rewrites the iterable of a {% for %} tag.
+func migrateForTag(body string) (migrated string, descriptions, manual []string) {
+	m := forPattern.FindStringSubmatch(body)
+	if m == nil {
+		return body, nil, nil
+	}
+	vars, iterable := m[1], strings.TrimSpace(m[2])
+
+	if strings.HasSuffix(iterable, " recursive") {
+		return body, nil, []string{"Recursive loops are not supported in pongo2; use a recursive {% include %}"}
+	}
+	if strings.Contains(iterable, " if ") {
+		return body, nil, []string{"Loop filtering ({% for x in y if cond %}) is not supported in pongo2; wrap the loop body in {% if %}"}
+	}
+
+	switch {
+	case strings.HasSuffix(iterable, ".items()"):
+		iterable = strings.TrimSuffix(iterable, ".items()")
+		descriptions = append(descriptions, ".items() -> pongo2 iterates key, value pairs directly")
+	case strings.HasSuffix(iterable, ".keys()"):
+		iterable = strings.TrimSuffix(iterable, ".keys()")
+		descriptions = append(descriptions, ".keys() -> pongo2 iterates map keys directly")
+	case strings.HasSuffix(iterable, ".values()"):
+		manual = append(manual, ".values() is not supported in pongo2; use {% for key, value in map %} and ignore the key")
+	case strings.HasSuffix(iterable, "|dictsort"):
+		iterable = strings.TrimSpace(strings.TrimSuffix(iterable, "|dictsort")) + " sorted"
+		descriptions = append(descriptions, "|dictsort -> sorted")
+	}
+
+	return "for " + vars + " in " + iterable, descriptions, manual
+}
+
+// migrateExpression rewrites Jinja2 expression syntax outside string
+// literals.
+func migrateExpression(expr string) (migrated string, descriptions, manual []string) {
+	migrated = expr
+
+	for _, attr := range loopAttributes {
+		if next := replaceOutsideStrings(migrated, attr.pattern, func(string) string { return attr.pongo2 }); next != migrated {
+			migrated = next
+			descriptions = append(descriptions, attr.jinja+" -> "+attr.pongo2)
+		}
+	}
+	if m := loopUnsupportedPattern.FindString(migrated); m != "" {
+		manual = append(manual, m+" has no pongo2 equivalent; restructure the loop")
+	}
+
+	next := replaceOutsideStrings(migrated, tojsonPattern, func(match string) string {
+		if indent := tojsonPattern.FindStringSubmatch(match)[1]; indent != "" {
+			return "|to_json:" + indent
+		}
+		return "|to_json"
+	})
+	if next != migrated {
+		migrated = next
+		descriptions = append(descriptions, "|tojson -> |to_json")
+	}
+
+	next = replaceOutsideStrings(migrated, boolLiteralPattern, strings.ToLower)
+	if next != migrated {
+		migrated = next
+		descriptions = append(descriptions, "True/False -> true/false")
+	}
+
+	if containsOutsideStrings(migrated, "~") {
+		if next, ok := migrateConcat(migrated); ok {
+			migrated = next
+			descriptions = append(descriptions, "~ concatenation -> |add")
+		} else {
+			manual = append(manual, "~ concatenation of complex expressions is not supported in pongo2; use |add or pre-compute the value")
+		}
+	}
+
+	return migrated, descriptions, manual
+}
+
+// migrateConcat rewrites a ~ b ~ c as a|string|add:b|add:c. Only simple
+// operands are rewritten; the |string keeps numbers from being summed.
+func migrateConcat(expr string) (string, bool) {
+	// The concatenation may be the value of a set: keep the assignment
+	prefix := ""
+	if name, value, ok := strings.Cut(expr, "="); ok && strings.HasPrefix(strings.TrimSpace(name), "set ") {
+		prefix, expr = name+"= ", value
+	}
+
+	parts := strings.Split(expr, "~")
+	for i := range parts {
+		parts[i] = strings.TrimSpace(parts[i])
+		if !concatOperandPattern.MatchString(parts[i]) {
+			return "", false
+		}
+	}
+
+	result := parts[0]
+	if !strings.HasPrefix(result, `"`) && !strings.HasPrefix(result, `'`) {
+		result += "|string"
+	}
+	for _, part := range parts[1:] {
+		result += "|add:" + part
+	}
+	return prefix + result, true
+}
+
+// replaceOutsideStrings applies a regexp replacement to the parts of s
+// that are not inside quoted string literals.
+func replaceOutsideStrings(s string, re *regexp.Regexp, repl func(string) string) string {
+	var sb strings.Builder
+	for _, seg := range splitStringLiterals(s) {
+		if seg.literal {
+			sb.WriteString(seg.text)
+		} else {
+			sb.WriteString(re.ReplaceAllStringFunc(seg.text, repl))
+		}
+	}
+	return sb.String()
+}
+
+// containsOutsideStrings reports whether substr occurs in s outside quoted
+// string literals.
+func containsOutsideStrings(s, substr string) bool {
+	for _, seg := range splitStringLiterals(s) {
+		if !seg.literal && strings.Contains(seg.text, substr) {
+			return true
+		}
+	}
+	return false
+}
+
+// exprSegment is a run of an expression that is or is not a string literal.
+type exprSegment struct {
+	text    string
+	literal bool
+}
+
+// splitStringLiterals splits an expression into string literals and the
+// code between them.
+func splitStringLiterals(s string) []exprSegment {
+	var segments []exprSegment
+	start := 0
+	var quote byte
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case quote != 0 && c == '\\':
+			i++
+		case quote != 0 && c == quote:
+			segments = append(segments, exprSegment{text: s[start : i+1], literal: true})
+			start, quote = i+1, 0
+		case quote == 0 && (c == '"' || c == '\''):
+			if i > start {
+				segments = append(segments, exprSegment{text: s[start:i]})
+			}
+			start, quote = i, c
+		}
+	}
+	if start < len(s) {
+		segments = append(segments, exprSegment{text: s[start:], literal: quote != 0})
+	}
+	return segments
+}
+
+// dedupe removes repeated strings, keeping the first occurrence.
+func dedupe(values []string) []string {
+	seen := make(map[string]bool, len(values))
+	result := values[:0]
+	for _, v := range values {
+		if !seen[v] {
+			seen[v] = true
+			result = append(result, v)
+		}
+	}
+	return result
+}
@@ -0,0 +1,309 @@
+package migrate
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestMigrateTemplate(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  string
+		want   string
+		change string
+	}{
+		{
+			name:   "loop.index",
+			input:  "{% for p in posts %}{{ loop.index }}. {{ p.title }}{% endfor %}",
+			want:   "{% for p in posts %}{{ forloop.Counter }}. {{ p.title }}{% endfor %}",
+			change: "loop.index -> forloop.Counter",
+		},
+		{
+			name:   "loop.index0",
+			input:  "{{ loop.index0 }}",
+			want:   "{{ forloop.Counter0 }}",
+			change: "loop.index0 -> forloop.Counter0",
+		},
+		{
+			name:   "loop.first in if",
+			input:  "{% if loop.first %}<hr>{% endif %}",
+			want:   "{% if forloop.First %}<hr>{% endif %}",
+			change: "loop.first -> forloop.First",
+		},
+		{
+			name:   "loop.revindex",
+			input:  "{{ loop.revindex }}",
+			want:   "{{ forloop.Revcounter }}",
+			change: "loop.revindex -> forloop.Revcounter",
+		},
+		{
+			name:   "tojson",
+			input:  "<script>const tags = {{ post.tags|tojson }};</script>",
+			want:   "<script>const tags = {{ post.tags|to_json }};</script>",
+			change: "|tojson -> |to_json",
+		},
+		{
+			name:   "tojson with indent",
+			input:  "{{ config | tojson(indent=2) }}",
+			want:   "{{ config |to_json:2 }}",
+			change: "|tojson -> |to_json",
+		},
+		{
+			name:   "items",
+			input:  "{% for key, value in post.meta.items() %}{{ key }}{% endfor %}",
+			want:   "{% for key, value in post.meta %}{{ key }}{% endfor %}",
+			change: ".items() -> pongo2 iterates key, value pairs directly",
+		},
+		{
+			name:   "keys",
+			input:  "{% for key in links.keys() %}",
+			want:   "{% for key in links %}",
+			change: ".keys() -> pongo2 iterates map keys directly",
+		},
+		{
+			name:   "dictsort",
+			input:  "{%- for k, v in config.nav|dictsort -%}",
+			want:   "{%- for k, v in config.nav sorted -%}",
+			change: "|dictsort -> sorted",
+		},
+		{
+			name:   "set boolean literal",
+			input:  "{% set show = True %}",
+			want:   "{% set show = true %}",
+			change: "True/False -> true/false",
+		},
+		{
+			name:   "set inline conditional",
+			input:  "{% set label = 'Draft' if not post.published else 'Live' %}",
+			want:   "{% if not post.published %}{% set label = 'Draft' %}{% else %}{% set label = 'Live' %}{% endif %}",
+			change: "Inline if/else in {% set %} -> {% if %} block",
+		},
+		{
+			name:   "set concatenation",
+			input:  "{% set url = config.url ~ '/' ~ post.slug %}",
+			want:   "{% set url = config.url|string|add:'/'|add:post.slug %}",
+			change: "~ concatenation -> |add",
+		},
+		{
+			name:   "string literals untouched",
+			input:  "{{ 'True loop.index' }}",
+			want:   "{{ 'True loop.index' }}",
+			change: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, changes := MigrateTemplate(tt.input)
+			if got != tt.want {
+				t.Errorf("MigrateTemplate(%q)\n got: %q\nwant: %q", tt.input, got, tt.want)
+			}
+			if tt.change == "" {
+				if len(changes) != 0 {
+					t.Errorf("expected no changes, got %+v", changes)
+				}
+				return
+			}
+			found := false
+			for _, c := range changes {
+				if c.Description == tt.change && !c.Manual {
+					found = true
+				}
+			}
+			if !found {
+				t.Errorf("missing change %q in %+v", tt.change, changes)
+			}
+		})
+	}
+}
+
+func TestMigrateTemplate_Manual(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"block set", "{% set nav %}<a>{% endset %}", "endset"},
+		{"tuple set", "{% set a, b = pair %}", "Tuple assignment"},
+		{"namespace", "{% set ns = namespace(found=false) %}", "namespace()"},
+		{"do", "{% do items.append(1) %}", "{% do %}"},
+		{"call", "{% call render(x) %}", "{% call %}"},
+		{"loop.length", "{{ loop.length }}", "loop.length"},
+		{"loop filter", "{% for p in posts if p.published %}", "Loop filtering"},
+		{"values", "{% for v in d.values() %}", ".values()"},
+		{"complex concat", "{{ (a + 1) ~ b }}", "~ concatenation"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, changes := MigrateTemplate(tt.input)
+			if got != tt.input {
+				t.Errorf("manual construct was rewritten: %q -> %q", tt.input, got)
+			}
+			if len(changes) != 1 || !changes[0].Manual || !strings.Contains(changes[0].Description, tt.want) {
+				t.Errorf("changes = %+v, want one manual change mentioning %q", changes, tt.want)
+			}
+		})
+	}
+}
+
+func TestMigrateTemplate_Lines(t *testing.T) {
+	src := "<ul>\n{% for p in posts %}\n  <li>{{ loop.index }}</li>\n{% endfor %}\n{% do x %}\n"
+	_, changes := MigrateTemplate(src)
+	if len(changes) != 2 {
+		t.Fatalf("got %d changes, want 2: %+v", len(changes), changes)
+	}
+	if changes[0].Line != 3 || changes[1].Line != 5 {
+		t.Errorf("lines = %d, %d, want 3, 5", changes[0].Line, changes[1].Line)
+	}
+	if changes[0].Original != "{{ loop.index }}" || changes[0].Migrated != "{{ forloop.Counter }}" {
+		t.Errorf("change = %+v", changes[0])
+	}
+}
+
+func TestMigrateTemplate_VariableNames(t *testing.T) {
+	src := `{% extends "base.html" %}
+<h1>{{ markata.config.title }}</h1>
+<article>{{ post.content|safe }}</article>
+<ul>
+{% for post in markata.articles %}
+  <li><a href="{{ post.href }}">{{ post.title }}</a> {{ post.content|truncate:80 }}</li>
+{% endfor %}
+</ul>
+{{ post.article_html }}
+<p>{{ "post.content" }}</p>
+{{ markata.map('title') }}`
+
+	want := `{% extends "base.html" %}
+<h1>{{ config.title }}</h1>
+<article>{{ body|safe }}</article>
+<ul>
+{% for post in posts %}
+  <li><a href="{{ post.href }}">{{ post.title }}</a> {{ post.content|truncate:80 }}</li>
+{% endfor %}
+</ul>
+{{ body }}
+<p>{{ "post.content" }}</p>
+{{ markata.map('title') }}`
+
+	got, changes := MigrateTemplate(src)
+	if got != want {
+		t.Errorf("MigrateTemplate()\n got: %q\nwant: %q", got, want)
+	}
+
+	var renames, manual []string
+	for _, c := range changes {
+		if c.Manual {
+			manual = append(manual, c.Description)
+		} else {
+			renames = append(renames, fmt.Sprintf("%d: %s", c.Line, c.Description))
+		}
+	}
+	wantRenames := []string{
+		"2: markata.config -> config",
+		"3: post.content -> body",
+		"5: markata.articles -> posts",
+		"9: post.article_html -> body",
+	}
+	if !reflect.DeepEqual(renames, wantRenames) {
+		t.Errorf("renames = %q, want %q", renames, wantRenames)
+	}
+	if len(manual) != 1 || !strings.Contains(manual[0], "markata.map has no markata-go equivalent") {
+		t.Errorf("manual = %q, want markata.map flagged for review", manual)
+	}
+}
+
+func TestMigrateTemplates(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"post.html":    "{{ post.content }}\n{{ post.markata.config.url }}\n",
+		"plain.html":   "{{ body }}\n",
+		"notes.txt":    "{{ post.content }}\n",
+		"partials.j2":  "{% for p in markata.articles %}{{ p.title }}{% endfor %}\n",
+		"unknown.html": "{{ markata.describe() }}\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	preview, err := MigrateTemplates(dir, false)
+	if err != nil {
+		t.Fatalf("MigrateTemplates(write=false) error = %v", err)
+	}
+	if len(preview) != 3 {
+		t.Fatalf("got %d rewrites, want 3: %+v", len(preview), preview)
+	}
+	if data, _ := os.ReadFile(filepath.Join(dir, "post.html")); string(data) != files["post.html"] {
+		t.Errorf("preview modified post.html: %q", data)
+	}
+
+	rewrites, err := MigrateTemplates(dir, true)
+	if err != nil {
+		t.Fatalf("MigrateTemplates(write=true) error = %v", err)
+	}
+	for name, want := range map[string]string{
+		"post.html":    "{{ body }}\n{{ config.url }}\n",
+		"partials.j2":  "{% for p in posts %}{{ p.title }}{% endfor %}\n",
+		"unknown.html": files["unknown.html"],
+		"notes.txt":    files["notes.txt"],
+	} {
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(data) != want {
+			t.Errorf("%s = %q, want %q", name, data, want)
+		}
+	}
+	for _, tr := range rewrites {
+		wantWritten := filepath.Base(tr.File) != "unknown.html"
+		if tr.Written != wantWritten {
+			t.Errorf("%s Written = %v, want %v", tr.File, tr.Written, wantWritten)
+		}
+	}
+
+	report := (&MigrationResult{TemplateRewrites: rewrites}).Report()
+	for _, want := range []string{
+		"TEMPLATE REWRITES",
+		"post.html (rewritten)",
+		"[MIGRATE] line 1: post.content -> body",
+		"[MIGRATE] line 2: post.markata.config -> config",
+		"[WARN] line 1: markata.describe has no markata-go equivalent",
+	} {
+		if !strings.Contains(report, want) {
+			t.Errorf("report missing %q:\n%s", want, report)
+		}
+	}
+}
+
+func TestCheckTemplates_VariableNames(t *testing.T) {
+	dir := t.TempDir()
+	content := "{{ post.content }}\n{% for post in markata.articles %}\n{{ post.content }}\n{% endfor %}\n{{ markata.map('x') }}\n<p>Built with markata.</p>\n"
+	if err := os.WriteFile(filepath.Join(dir, "t.html"), []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	issues, err := CheckTemplates(dir)
+	if err != nil {
+		t.Fatalf("CheckTemplates() error = %v", err)
+	}
+
+	var got []string
+	for _, issue := range issues {
+		got = append(got, fmt.Sprintf("%d: %s", issue.Line, issue.Issue))
+	}
+	want := []string{
+		"1: post.content is named body in markata-go",
+		"2: markata.articles is named posts in markata-go",
+		"5: markata.map has no markata-go equivalent",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("issues = %q, want %q", got, want)
+	}
+}
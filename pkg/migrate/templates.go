@@ -2,9 +2,12 @@ package migrate
 
 import (
 	"bufio"
+	"fmt"
 	"os"
 	"path/filepath"
 	"regexp"
+	"slices"
+	"sort"
 	"strings"
 )
 
@@ -18,12 +21,7 @@ func CheckTemplates(templatesDir string) ([]TemplateIssue, error) {
 		}
 
 		// Only check HTML and template files
-		ext := strings.ToLower(filepath.Ext(path))
-		if ext != ".html" && ext != ".jinja" && ext != ".jinja2" && ext != ".j2" {
-			return nil
-		}
-
-		if info.IsDir() {
+		if info.IsDir() || !isTemplateFile(path) {
 			return nil
 		}
 
@@ -49,6 +47,15 @@ func CheckTemplates(templatesDir string) ([]TemplateIssue, error) {
 	return issues, nil
 }
 
+// isTemplateFile reports whether path has a template file extension.
+func isTemplateFile(path string) bool {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".html", ".jinja", ".jinja2", ".j2":
+		return true
+	}
+	return false
+}
+
 // checkTemplateFile checks a single template file for compatibility issues.
 func checkTemplateFile(path string) ([]TemplateIssue, error) {
 	var issues []TemplateIssue
@@ -61,13 +68,14 @@ func checkTemplateFile(path string) ([]TemplateIssue, error) {
 
 	scanner := bufio.NewScanner(file)
 	lineNum := 0
+	scope := &loopScope{}
 
 	for scanner.Scan() {
 		lineNum++
 		line := scanner.Text()
 
 		// Check for various template patterns
-		lineIssues := checkTemplateLine(path, lineNum, line)
+		lineIssues := checkTemplateLine(path, lineNum, line, scope)
 		issues = append(issues, lineIssues...)
 	}
 
@@ -79,7 +87,7 @@ func checkTemplateFile(path string) ([]TemplateIssue, error) {
 }
 
 // checkTemplateLine checks a single line for template compatibility issues.
-func checkTemplateLine(file string, lineNum int, line string) []TemplateIssue {
+func checkTemplateLine(file string, lineNum int, line string, scope *loopScope) []TemplateIssue {
 	var issues []TemplateIssue
 
 	// Check for unsupported Jinja2 features
@@ -128,29 +136,10 @@ func checkTemplateLine(file string, lineNum int, line string) []TemplateIssue {
 		})
 	}
 
-	// 5. post.markata access
-	if markataAccessPattern.MatchString(line) {
-		issues = append(issues, TemplateIssue{
-			File:       file,
-			Line:       lineNum,
-			Issue:      "post.markata access is not available in markata-go",
-			Severity:   "warning",
-			Suggestion: "Use 'config' directly for config access, 'feeds' for feeds",
-		})
-	}
+	// 5. Python markata variable names
+	issues = append(issues, checkTemplateVariables(file, lineNum, line, scope)...)
 
-	// 6. article_html (renamed to content)
-	if articleHTMLPattern.MatchString(line) {
-		issues = append(issues, TemplateIssue{
-			File:       file,
-			Line:       lineNum,
-			Issue:      "post.article_html has been renamed",
-			Severity:   "warning",
-			Suggestion: "Use post.content instead of post.article_html",
-		})
-	}
-
-	// 7. Import statements
+	// 6. Import statements
 	if importPattern.MatchString(line) {
 		issues = append(issues, TemplateIssue{
 			File:       file,
@@ -161,7 +150,7 @@ func checkTemplateLine(file string, lineNum int, line string) []TemplateIssue {
 		})
 	}
 
-	// 8. from...import statements
+	// 7. from...import statements
 	if fromImportPattern.MatchString(line) {
 		issues = append(issues, TemplateIssue{
 			File:       file,
@@ -172,7 +161,7 @@ func checkTemplateLine(file string, lineNum int, line string) []TemplateIssue {
 		})
 	}
 
-	// 9. Check for Python string methods
+	// 8. Check for Python string methods
 	if pythonMethodPattern.MatchString(line) {
 		issues = append(issues, TemplateIssue{
 			File:       file,
@@ -183,7 +172,7 @@ func checkTemplateLine(file string, lineNum int, line string) []TemplateIssue {
 		})
 	}
 
-	// 10. Check for complex with statements
+	// 9. Check for complex with statements
 	if complexWithPattern.MatchString(line) {
 		issues = append(issues, TemplateIssue{
 			File:       file,
@@ -197,18 +186,45 @@ func checkTemplateLine(file string, lineNum int, line string) []TemplateIssue {
 	return issues
 }
 
+// checkTemplateVariables reports Python markata variable names in the
+// template tags on a line, and advances scope past the line's tags.
+func checkTemplateVariables(file string, lineNum int, line string, scope *loopScope) []TemplateIssue {
+	var issues []TemplateIssue
+	for _, tag := range templateTagPattern.FindAllString(line, -1) {
+		renamed, applied := renameVariables(tag, scope)
+		for _, v := range applied {
+			issues = append(issues, TemplateIssue{
+				File:       file,
+				Line:       lineNum,
+				Issue:      fmt.Sprintf("%s is named %s in markata-go", v.Old, v.New),
+				Severity:   "warning",
+				Suggestion: fmt.Sprintf("Use %s instead (%s); 'markata-go migrate templates --rewrite' renames it", v.New, v.Message),
+			})
+		}
+		for _, ref := range unknownMarkataReferences(renamed, scope) {
+			issues = append(issues, TemplateIssue{
+				File:       file,
+				Line:       lineNum,
+				Issue:      fmt.Sprintf("%s has no markata-go equivalent", ref),
+				Severity:   "warning",
+				Suggestion: "Review manually: templates get posts, feeds, and config directly, not through markata",
+			})
+		}
+		scope.observe(tag)
+	}
+	return issues
+}
+
 // Pre-compiled patterns for performance
 var (
-	doPattern            = regexp.MustCompile(`\{%\s*do\s+`)
-	macroPattern         = regexp.MustCompile(`\{%\s*macro\s+`)
-	callPattern          = regexp.MustCompile(`\{%\s*call\s+`)
-	pythonExprPattern    = regexp.MustCompile(`\{\{.*\[.*for.*in.*\].*\}\}`)
-	markataAccessPattern = regexp.MustCompile(`post\.markata`)
-	articleHTMLPattern   = regexp.MustCompile(`post\.article_html|article_html`)
-	importPattern        = regexp.MustCompile(`\{%\s*import\s+`)
-	fromImportPattern    = regexp.MustCompile(`\{%\s*from\s+.*\s+import\s+`)
-	pythonMethodPattern  = regexp.MustCompile(`\{\{.*\.(lower|upper|strip|split|join|replace|format)\(\).*\}\}`)
-	complexWithPattern   = regexp.MustCompile(`\{%\s*with\s+\w+\s*=\s*[^,]+,\s*\w+\s*=`)
+	doPattern           = regexp.MustCompile(`\{%\s*do\s+`)
+	macroPattern        = regexp.MustCompile(`\{%\s*macro\s+`)
+	callPattern         = regexp.MustCompile(`\{%\s*call\s+`)
+	pythonExprPattern   = regexp.MustCompile(`\{\{.*\[.*for.*in.*\].*\}\}`)
+	importPattern       = regexp.MustCompile(`\{%\s*import\s+`)
+	fromImportPattern   = regexp.MustCompile(`\{%\s*from\s+.*\s+import\s+`)
+	pythonMethodPattern = regexp.MustCompile(`\{\{.*\.(lower|upper|strip|split|join|replace|format)\(\).*\}\}`)
+	complexWithPattern  = regexp.MustCompile(`\{%\s*with\s+\w+\s*=\s*[^,]+,\s*\w+\s*=`)
 )
 
 // TemplateVariable represents a template variable and its migration status.
@@ -234,11 +250,23 @@ func GetVariableMigrations() []TemplateVariable {
 			Deprecated: true,
 			Message:    "Access feeds directly without going through post.markata",
 		},
+		{
+			Old:        "post.markata.articles",
+			New:        "posts",
+			Deprecated: true,
+			Message:    "Access the post list directly without going through post.markata",
+		},
 		{
 			Old:        "post.article_html",
-			New:        "post.content",
+			New:        "body",
+			Deprecated: true,
+			Message:    "The rendered post HTML is available as body",
+		},
+		{
+			Old:        "post.content",
+			New:        "body",
 			Deprecated: true,
-			Message:    "Renamed from article_html to content",
+			Message:    "The rendered post HTML is available as body",
 		},
 		{
 			Old:        "markata.config",
@@ -252,7 +280,128 @@ func GetVariableMigrations() []TemplateVariable {
 			Deprecated: true,
 			Message:    "Access feeds directly",
 		},
+		{
+			Old:        "markata.articles",
+			New:        "posts",
+			Deprecated: true,
+			Message:    "The post list is available as posts",
+		},
+	}
+}
+
+// variableRename is a variable migration with a pattern matching whole
+// references to the old name.
+type variableRename struct {
+	TemplateVariable
+	root    string
+	pattern *regexp.Regexp
+}
+
+// variableRenames holds the variable migrations, longest name first so
+// post.markata.config is renamed before markata.config could match.
+var variableRenames = compileVariableRenames(GetVariableMigrations())
+
+func compileVariableRenames(vars []TemplateVariable) []variableRename {
+	renames := make([]variableRename, 0, len(vars))
+	for _, v := range vars {
+		root, _, _ := strings.Cut(v.Old, ".")
+		renames = append(renames, variableRename{
+			TemplateVariable: v,
+			root:             root,
+			pattern:          regexp.MustCompile(`(^|[^\w.])` + regexp.QuoteMeta(v.Old) + `\b`),
+		})
+	}
+	sort.SliceStable(renames, func(i, j int) bool {
+		return len(renames[i].Old) > len(renames[j].Old)
+	})
+	return renames
+}
+
+// markataReferencePattern matches references to the Python markata
+// object, such as markata.map or post.markata.
+var markataReferencePattern = regexp.MustCompile(`(?:^|[^\w.])((?:post\.)?markata(?:\.\w+)?)\b`)
+
+// loopScope tracks the variables bound by enclosing {% for %} loops while
+// walking a template's tags in order. Inside {% for post in posts %},
+// post.content is the loop item's field, not the page's post.
+type loopScope struct {
+	vars [][]string
+}
+
+// observe updates the scope for a template tag.
+func (s *loopScope) observe(tag string) {
+	m := templateTagDelims.FindStringSubmatch(tag)
+	if m == nil || !strings.HasPrefix(m[1], "{%") {
+		return
+	}
+	body := strings.TrimSpace(m[2])
+	keyword, _, _ := strings.Cut(body, " ")
+	switch keyword {
+	case "for":
+		if fm := forPattern.FindStringSubmatch(body); fm != nil {
+			var names []string
+			for _, name := range strings.Split(fm[1], ",") {
+				names = append(names, strings.TrimSpace(name))
+			}
+			s.vars = append(s.vars, names)
+		}
+	case "endfor":
+		if len(s.vars) > 0 {
+			s.vars = s.vars[:len(s.vars)-1]
+		}
+	}
+}
+
+// bound reports whether name is bound by an enclosing loop.
+func (s *loopScope) bound(name string) bool {
+	for _, names := range s.vars {
+		for _, n := range names {
+			if n == name {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// renameVariables rewrites the Python markata variable names in a template
+// tag outside string literals, returning the rewritten tag and the
+// migrations applied. Names whose root is a loop variable are left alone.
+func renameVariables(tag string, scope *loopScope) (string, []TemplateVariable) {
+	var applied []TemplateVariable
+	for _, r := range variableRenames {
+		if scope.bound(r.root) {
+			continue
+		}
+		next := replaceOutsideStrings(tag, r.pattern, func(match string) string {
+			return strings.TrimSuffix(match, r.Old) + r.New
+		})
+		if next != tag {
+			tag = next
+			applied = append(applied, r.TemplateVariable)
+		}
+	}
+	return tag, applied
+}
+
+// unknownMarkataReferences returns the distinct references to the Python
+// markata object in a template tag, outside string literals, that have no
+// markata-go equivalent. Call it after renameVariables.
+func unknownMarkataReferences(tag string, scope *loopScope) []string {
+	var refs []string
+	for _, seg := range splitStringLiterals(tag) {
+		if seg.literal {
+			continue
+		}
+		for _, m := range markataReferencePattern.FindAllStringSubmatch(seg.text, -1) {
+			root, _, _ := strings.Cut(m[1], ".")
+			if scope.bound(root) || slices.Contains(refs, m[1]) {
+				continue
+			}
+			refs = append(refs, m[1])
+		}
 	}
+	return refs
 }
 
 // GetFilterMigrations returns the list of filter migrations for templates.
@@ -647,6 +647,11 @@ type Config struct {
 	// Extra holds arbitrary plugin configurations that aren't part of the core config.
 	// Plugin-specific configs like [markata-go.image_zoom] are stored here.
 	Extra map[string]any `json:"-" yaml:"-" toml:"-"`
+
+	// DeprecatedKeys maps the deprecated keys the config was loaded with to
+	// their replacements. The loader has already moved their values to the
+	// replacements; validation reports them as warnings.
+	DeprecatedKeys map[string]string `json:"-" yaml:"-" toml:"-"`
 }
 
 // DefaultTrustedMediaDomains is the default allowlist for media helpers.
@@ -666,6 +671,51 @@ type TemplatesMediaConfig struct {
 type TemplatesConfig struct {
 	// Media holds settings that affect media helpers (with_size, poster_url, etc.)
 	Media TemplatesMediaConfig `json:"media" yaml:"media" toml:"media"`
+
+	// TimesinceMaxDays is how many days old a date can be before the
+	// timesince filter shows an absolute date (default: 365, 0 = never)
+	TimesinceMaxDays *int `json:"timesince_max_days,omitempty" yaml:"timesince_max_days,omitempty" toml:"timesince_max_days,omitempty"`
+
+	// MaxLoopIterations caps the total {% for %} iterations in one render
+	// (default: 10000000, 0 = unlimited)
+	MaxLoopIterations *int `json:"max_loop_iterations,omitempty" yaml:"max_loop_iterations,omitempty" toml:"max_loop_iterations,omitempty"`
+
+	// MaxOutputBytes caps the size of one rendered template in bytes
+	// (default: 268435456, 0 = unlimited)
+	MaxOutputBytes *int `json:"max_output_bytes,omitempty" yaml:"max_output_bytes,omitempty" toml:"max_output_bytes,omitempty"`
+}
+
+// DefaultTimesinceMaxDays is the default TemplatesConfig.TimesinceMaxDays.
+const DefaultTimesinceMaxDays = 365
+
+// GetTimesinceMaxDays returns TimesinceMaxDays, or the default when unset.
+func (t *TemplatesConfig) GetTimesinceMaxDays() int {
+	if t.TimesinceMaxDays == nil {
+		return DefaultTimesinceMaxDays
+	}
+	return *t.TimesinceMaxDays
+}
+
+// Default template execution limits; see TemplatesConfig.
+const (
+	DefaultTemplateMaxLoopIterations = 10_000_000
+	DefaultTemplateMaxOutputBytes    = 256 << 20
+)
+
+// GetMaxLoopIterations returns MaxLoopIterations, or the default when unset.
+func (t *TemplatesConfig) GetMaxLoopIterations() int {
+	if t.MaxLoopIterations == nil {
+		return DefaultTemplateMaxLoopIterations
+	}
+	return *t.MaxLoopIterations
+}
+
+// GetMaxOutputBytes returns MaxOutputBytes, or the default when unset.
+func (t *TemplatesConfig) GetMaxOutputBytes() int {
+	if t.MaxOutputBytes == nil {
+		return DefaultTemplateMaxOutputBytes
+	}
+	return *t.MaxOutputBytes
 }
 
 // NewTemplatesMediaConfig returns the default TemplatesMediaConfig.
@@ -1285,7 +1335,8 @@ func (b *BackgroundConfig) IsArticleBlurEnabled() bool {
 
 // GlobConfig configures file globbing behavior.
 type GlobConfig struct {
-	// Patterns is the list of glob patterns to match source files
+	// Patterns is the list of glob patterns to match source files, applied
+	// in order. A pattern starting with ! excludes files matched before it.
 	Patterns []string `json:"patterns" yaml:"patterns" toml:"patterns"`
 
 	// UseGitignore determines whether to respect .gitignore files
@@ -1870,6 +1921,15 @@ type ResourceHintsConfig struct {
 
 	// ExcludeDomains is a list of domains to exclude from auto-detection
 	ExcludeDomains []string `json:"exclude_domains,omitempty" yaml:"exclude_domains,omitempty" toml:"exclude_domains,omitempty"`
+
+	// PreloadFonts enables preload hints for fonts declared in @font-face rules
+	// when auto-detection is on (default: true)
+	PreloadFonts *bool `json:"preload_fonts,omitempty" yaml:"preload_fonts,omitempty" toml:"preload_fonts,omitempty"`
+
+	// MaxPreconnect limits preconnect hints per page, counting manually configured
+	// ones (default: 4). Auto-detected domains over the limit get dns-prefetch
+	// instead. A negative value removes the limit.
+	MaxPreconnect *int `json:"max_preconnect,omitempty" yaml:"max_preconnect,omitempty" toml:"max_preconnect,omitempty"`
 }
 
 // DomainHint represents a hint configuration for a specific domain.
@@ -1919,6 +1979,29 @@ func (r *ResourceHintsConfig) IsAutoDetectEnabled() bool {
 	return *r.AutoDetect
 }
 
+// IsPreloadFontsEnabled returns whether fonts from @font-face rules are preloaded.
+// Defaults to true if not explicitly set.
+func (r *ResourceHintsConfig) IsPreloadFontsEnabled() bool {
+	if r.PreloadFonts == nil {
+		return true
+	}
+	return *r.PreloadFonts
+}
+
+// DefaultMaxPreconnect is the default per-page preconnect budget. Each
+// preconnect opens a connection, so beyond a handful they compete with the
+// page's own requests.
+const DefaultMaxPreconnect = 4
+
+// GetMaxPreconnect returns the per-page preconnect budget.
+// Defaults to DefaultMaxPreconnect if not explicitly set.
+func (r *ResourceHintsConfig) GetMaxPreconnect() int {
+	if r.MaxPreconnect == nil {
+		return DefaultMaxPreconnect
+	}
+	return *r.MaxPreconnect
+}
+
 // PostFormatsConfig configures the output formats for individual posts.
 // This controls what file formats are generated for each post.
 type PostFormatsConfig struct {
@@ -2135,6 +2218,12 @@ type CSSPurgeConfig struct {
 	// Example: ["data-theme", "data-palette"]
 	PreserveAttributes []string `json:"preserve_attributes" yaml:"preserve_attributes" toml:"preserve_attributes"`
 
+	// PreserveCustomProperties keeps every rule that declares a CSS custom
+	// property (e.g., --accent), even if its selector looks unused.
+	// Custom properties on :root, html, and [data-*] selectors are always kept.
+	// Default: false
+	PreserveCustomProperties bool `json:"preserve_custom_properties" yaml:"preserve_custom_properties" toml:"preserve_custom_properties"`
+
 	// SkipFiles is a list of CSS file patterns to skip during purging.
 	// Useful for third-party CSS that should not be modified.
 	// Example: ["vendor/*", "normalize.css"]
@@ -2361,6 +2450,11 @@ type CriticalCSSConfig struct {
 	// Default: 900
 	ViewportHeight int `json:"viewport_height,omitempty" yaml:"viewport_height,omitempty" toml:"viewport_height,omitempty"`
 
+	// MobileBreakpoint is the width in pixels from which @media queries target
+	// larger screens (default: 768). Critical selectors under a query requiring
+	// a min-width at or above it stay in the non-critical CSS.
+	MobileBreakpoint int `json:"mobile_breakpoint,omitempty" yaml:"mobile_breakpoint,omitempty" toml:"mobile_breakpoint,omitempty"`
+
 	// Minify controls whether to minify the critical CSS output (default: true)
 	Minify *bool `json:"minify,omitempty" yaml:"minify,omitempty" toml:"minify,omitempty"`
 
@@ -2376,6 +2470,11 @@ type CriticalCSSConfig struct {
 	// Useful for content that should never be inlined (e.g., large animations)
 	ExcludeSelectors []string `json:"exclude_selectors,omitempty" yaml:"exclude_selectors,omitempty" toml:"exclude_selectors,omitempty"`
 
+	// Profiles maps layout names to critical selector lists, replacing the built-in
+	// profiles for "docs", "blog", "landing", and "bare" or adding new layouts.
+	// Pages use the profile for their post's layout.
+	Profiles map[string][]string `json:"profiles,omitempty" yaml:"profiles,omitempty" toml:"profiles,omitempty"`
+
 	// InlineThreshold is the maximum size (in bytes) for the critical CSS before giving up inlining (default: 50000)
 	// If critical CSS exceeds this threshold, the optimization is skipped for that page
 	InlineThreshold int `json:"inline_threshold,omitempty" yaml:"inline_threshold,omitempty" toml:"inline_threshold,omitempty"`
@@ -2392,6 +2491,7 @@ func NewCriticalCSSConfig() CriticalCSSConfig {
 		Enabled:            &enabled,
 		ViewportWidth:      1300,
 		ViewportHeight:     900,
+		MobileBreakpoint:   768,
 		Minify:             &minify,
 		PreloadNonCritical: &preloadNonCritical,
 		ExtraSelectors:     []string{},
@@ -3182,7 +3282,9 @@ type AssetsConfig struct {
 	// Mode controls how external assets are handled:
 	// - "cdn": Always load from external CDN (default, no download)
 	// - "self-hosted": Download and serve from local output/assets/vendor/
-	// - "auto": Use self-hosted if assets are cached, fall back to CDN
+	// - "auto": CDN in development (markata-go serve or a dev environment),
+	//   self-hosted in production, falling back to CDN for assets that are
+	//   not cached
 	Mode string `json:"mode,omitempty" yaml:"mode,omitempty" toml:"mode,omitempty"`
 
 	// CacheDir is the directory for caching downloaded assets (default: ".markata/assets-cache")
@@ -3193,6 +3295,47 @@ type AssetsConfig struct {
 
 	// OutputDir is the subdirectory in output for vendor assets (default: "assets/vendor")
 	OutputDir string `json:"output_dir,omitempty" yaml:"output_dir,omitempty" toml:"output_dir,omitempty"`
+
+	// Concurrency is the number of assets downloaded at once (default: 4)
+	Concurrency int `json:"concurrency,omitempty" yaml:"concurrency,omitempty" toml:"concurrency,omitempty"`
+
+	// MaxAttempts is how many times a download is tried before giving up.
+	// Network errors and HTTP 408, 429, and 5xx responses are retried with
+	// exponential backoff (default: 3)
+	MaxAttempts int `json:"max_attempts,omitempty" yaml:"max_attempts,omitempty" toml:"max_attempts,omitempty"`
+
+	// Versions pins registry assets to a specific version, keyed by asset
+	// name (e.g. "glightbox-js") or library (e.g. "glightbox"). Pinning an
+	// asset clears its registered SRI hash unless Integrity provides one.
+	Versions map[string]string `json:"versions,omitempty" yaml:"versions,omitempty" toml:"versions,omitempty"`
+
+	// Integrity supplies SRI hashes for pinned assets, keyed like Versions
+	Integrity map[string]string `json:"integrity,omitempty" yaml:"integrity,omitempty" toml:"integrity,omitempty"`
+
+	// Custom adds user-defined assets to the registry so they are downloaded,
+	// verified, and copied to output like the built-in ones.
+	Custom []CustomAssetConfig `json:"custom,omitempty" yaml:"custom,omitempty" toml:"custom,omitempty"`
+}
+
+// CustomAssetConfig defines a user asset in [[markata-go.assets.custom]].
+type CustomAssetConfig struct {
+	// Key is the unique asset name, used in asset_urls and the CLI (required)
+	Key string `json:"key" yaml:"key" toml:"key"`
+
+	// URL is the CDN URL to download from (required)
+	URL string `json:"url" yaml:"url" toml:"url"`
+
+	// LocalPath is the path under the vendor output directory (required)
+	LocalPath string `json:"local_path" yaml:"local_path" toml:"local_path"`
+
+	// Integrity is an optional SRI hash (e.g. "sha384-...")
+	Integrity string `json:"integrity,omitempty" yaml:"integrity,omitempty" toml:"integrity,omitempty"`
+
+	// Version is an optional version label shown by "assets list"
+	Version string `json:"version,omitempty" yaml:"version,omitempty" toml:"version,omitempty"`
+
+	// Type is "js", "css", or "other"; inferred from LocalPath when empty
+	Type string `json:"type,omitempty" yaml:"type,omitempty" toml:"type,omitempty"`
 }
 
 // NewAssetsConfig creates a new AssetsConfig with default values.
@@ -3206,7 +3349,9 @@ func NewAssetsConfig() AssetsConfig {
 	}
 }
 
-// IsSelfHosted returns true if assets should be self-hosted.
+// IsSelfHosted returns true if assets may be self-hosted. It is true for
+// "auto", which self-hosts production builds; assets.ResolveMode gives the
+// mode for a particular build.
 func (a *AssetsConfig) IsSelfHosted() bool {
 	return a.Mode == "self-hosted" || a.Mode == "auto"
 }
@@ -3236,6 +3381,22 @@ func (a *AssetsConfig) GetOutputDir() string {
 	return a.OutputDir
 }
 
+// GetConcurrency returns the download parallelism, with default if not set.
+func (a *AssetsConfig) GetConcurrency() int {
+	if a.Concurrency <= 0 {
+		return 4
+	}
+	return a.Concurrency
+}
+
+// GetMaxAttempts returns the download attempt limit, with default if not set.
+func (a *AssetsConfig) GetMaxAttempts() int {
+	if a.MaxAttempts <= 0 {
+		return 3
+	}
+	return a.MaxAttempts
+}
+
 // NewConfig creates a new Config with default values.
 func NewConfig() *Config {
 	return &Config{
@@ -0,0 +1,119 @@
+package models
+
+import (
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/WaylonWalker/markata-go/pkg/htmltotext"
+)
+
+// Excerpt strategies accepted by Post.Excerpt.
+const (
+	// ExcerptDescription uses the frontmatter description.
+	ExcerptDescription = "description"
+
+	// ExcerptFirstParagraph uses the text of the first <p> in the rendered body.
+	ExcerptFirstParagraph = "first_paragraph"
+
+	// ExcerptTruncate truncates the plain text of the rendered body.
+	ExcerptTruncate = "truncate"
+)
+
+// DefaultExcerptLength is the truncate length used when none is given.
+const DefaultExcerptLength = 200
+
+// excerptStrategies is the fallback order tried after the requested strategy.
+var excerptStrategies = []string{ExcerptDescription, ExcerptFirstParagraph, ExcerptTruncate}
+
+var (
+	// Matches <p> elements (but not <pre>, <param>, ...) and captures their content.
+	excerptParagraphRe = regexp.MustCompile(`(?is)<p(?:\s[^>]*)?>(.*?)</p\s*>`)
+
+	// Matches anchor tags, removed so link text is kept without footnote markers.
+	excerptAnchorTagRe = regexp.MustCompile(`(?i)</?a(?:\s[^>]*)?>`)
+)
+
+// IsExcerptStrategy reports whether name is a strategy accepted by Excerpt.
+func IsExcerptStrategy(name string) bool {
+	for _, s := range excerptStrategies {
+		if s == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Excerpt returns a short plain-text summary of the post using strategy:
+//
+//   - "description": the frontmatter description
+//   - "first_paragraph": the text of the first non-empty <p> in ArticleHTML
+//   - "truncate": the text of ArticleHTML cut to at most length characters
+//     at a word boundary, with "..." appended when cut
+//
+// When the strategy yields nothing, the remaining strategies are tried in the
+// order above. An empty or unknown strategy starts with "description", and a
+// length of zero or less uses DefaultExcerptLength.
+func (p *Post) Excerpt(strategy string, length int) string {
+	if length <= 0 {
+		length = DefaultExcerptLength
+	}
+	if !IsExcerptStrategy(strategy) {
+		strategy = ExcerptDescription
+	}
+
+	order := append([]string{strategy}, excerptStrategies...)
+	for _, s := range order {
+		if text := p.excerptWith(s, length); text != "" {
+			return text
+		}
+	}
+	return ""
+}
+
+func (p *Post) excerptWith(strategy string, length int) string {
+	switch strategy {
+	case ExcerptDescription:
+		if p.Description == nil {
+			return ""
+		}
+		return strings.TrimSpace(*p.Description)
+	case ExcerptFirstParagraph:
+		for _, match := range excerptParagraphRe.FindAllStringSubmatch(p.ArticleHTML, -1) {
+			if text := excerptPlainText(match[1]); text != "" {
+				return text
+			}
+		}
+		return ""
+	case ExcerptTruncate:
+		return truncateWords(excerptPlainText(p.ArticleHTML), length)
+	default:
+		return ""
+	}
+}
+
+// excerptPlainText converts HTML to a single line of plain text.
+func excerptPlainText(html string) string {
+	if html == "" {
+		return ""
+	}
+	text := htmltotext.Convert(excerptAnchorTagRe.ReplaceAllString(html, ""))
+	return strings.Join(strings.Fields(text), " ")
+}
+
+// truncateWords cuts text to at most length runes, backing up to the last
+// word boundary, and appends "..." when anything was removed.
+func truncateWords(text string, length int) string {
+	if utf8.RuneCountInString(text) <= length {
+		return text
+	}
+
+	runes := []rune(text)
+	cut := string(runes[:length])
+	if runes[length] != ' ' {
+		if i := strings.LastIndex(cut, " "); i > 0 {
+			cut = cut[:i]
+		}
+	}
+	return strings.TrimRight(cut, " ,;:") + "..."
+}
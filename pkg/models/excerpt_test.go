@@ -0,0 +1,105 @@
+package models
+
+import "testing"
+
+func TestPost_Excerpt_Strategies(t *testing.T) {
+	desc := "  A hand-written summary.  "
+	article := `<div class="admonition"><p></p></div>
+<p>The <a href="/first/">first</a> paragraph.</p>
+<pre><code>code block</code></pre>
+<p>Second paragraph with several more words in it.</p>`
+
+	tests := []struct {
+		name     string
+		post     *Post
+		strategy string
+		length   int
+		want     string
+	}{
+		{
+			name:     "description",
+			post:     &Post{Description: &desc, ArticleHTML: article},
+			strategy: ExcerptDescription,
+			want:     "A hand-written summary.",
+		},
+		{
+			name:     "first paragraph skips empty paragraphs and keeps link text",
+			post:     &Post{Description: &desc, ArticleHTML: article},
+			strategy: ExcerptFirstParagraph,
+			want:     "The first paragraph.",
+		},
+		{
+			name:     "truncate at a word boundary",
+			post:     &Post{ArticleHTML: "<p>one two three four five</p>"},
+			strategy: ExcerptTruncate,
+			length:   12,
+			want:     "one two...",
+		},
+		{
+			name:     "truncate keeps short text whole",
+			post:     &Post{ArticleHTML: "<p>one two</p>"},
+			strategy: ExcerptTruncate,
+			length:   50,
+			want:     "one two",
+		},
+		{
+			name:     "truncate counts runes",
+			post:     &Post{ArticleHTML: "<p>héllo wörld ünïcode</p>"},
+			strategy: ExcerptTruncate,
+			length:   11,
+			want:     "héllo wörld...",
+		},
+		{
+			name: "empty strategy defaults to description",
+			post: &Post{Description: &desc, ArticleHTML: article},
+			want: "A hand-written summary.",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.post.Excerpt(tt.strategy, tt.length); got != tt.want {
+				t.Errorf("Excerpt(%q, %d) = %q, want %q", tt.strategy, tt.length, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPost_Excerpt_Fallback(t *testing.T) {
+	blank := "   "
+
+	t.Run("description falls back to first paragraph", func(t *testing.T) {
+		p := &Post{Description: &blank, ArticleHTML: "<h1>Title</h1><p>Body text.</p>"}
+		if got := p.Excerpt(ExcerptDescription, 0); got != "Body text." {
+			t.Errorf("got %q, want %q", got, "Body text.")
+		}
+	})
+
+	t.Run("first paragraph falls back to truncate", func(t *testing.T) {
+		p := &Post{ArticleHTML: "<div>Only a div.</div>"}
+		if got := p.Excerpt(ExcerptFirstParagraph, 0); got != "Only a div." {
+			t.Errorf("got %q, want %q", got, "Only a div.")
+		}
+	})
+
+	t.Run("truncate falls back to description", func(t *testing.T) {
+		desc := "Summary only."
+		p := &Post{Description: &desc}
+		if got := p.Excerpt(ExcerptTruncate, 0); got != desc {
+			t.Errorf("got %q, want %q", got, desc)
+		}
+	})
+
+	t.Run("unknown strategy uses the default order", func(t *testing.T) {
+		p := &Post{ArticleHTML: "<p>Body text.</p>"}
+		if got := p.Excerpt("nope", 0); got != "Body text." {
+			t.Errorf("got %q, want %q", got, "Body text.")
+		}
+	})
+
+	t.Run("empty post", func(t *testing.T) {
+		if got := (&Post{}).Excerpt("", 0); got != "" {
+			t.Errorf("got %q, want empty", got)
+		}
+	})
+}
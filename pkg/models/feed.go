@@ -385,11 +385,7 @@ func (f *FeedConfig) Paginate(baseURL string) {
 	// Generate page URLs for numbered navigation
 	pageURLs := make([]string, totalPages)
 	for i := 0; i < totalPages; i++ {
-		if i == 0 {
-			pageURLs[i] = baseURL + "/"
-		} else {
-			pageURLs[i] = baseURL + "/page/" + itoa(i+1) + "/"
-		}
+		pageURLs[i] = FeedPageURL(baseURL, i+1)
 	}
 
 	// Set HasNext, URLs, and metadata for each page
@@ -402,21 +398,27 @@ func (f *FeedConfig) Paginate(baseURL string) {
 		pages[i].PaginationType = paginationType
 
 		if pages[i].HasPrev {
-			if i == 1 {
-				pages[i].PrevURL = baseURL + "/"
-			} else {
-				pages[i].PrevURL = baseURL + "/page/" + itoa(i) + "/"
-			}
+			pages[i].PrevURL = FeedPageURL(baseURL, i)
 		}
 
 		if pages[i].HasNext {
-			pages[i].NextURL = baseURL + "/page/" + itoa(i+2) + "/"
+			pages[i].NextURL = FeedPageURL(baseURL, i+2)
 		}
 	}
 
 	f.Pages = pages
 }
 
+// FeedPageURL returns the URL of page number page (1-based) of the feed
+// rooted at baseURL, e.g. "/blog" -> "/blog/" and "/blog/page/2/".
+// The layout is the same for every pagination type.
+func FeedPageURL(baseURL string, page int) string {
+	if page <= 1 {
+		return baseURL + "/"
+	}
+	return baseURL + "/page/" + itoa(page) + "/"
+}
+
 // itoa converts an integer to a string without importing strconv
 func itoa(n int) string {
 	if n == 0 {
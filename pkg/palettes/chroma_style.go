@@ -0,0 +1,145 @@
+package palettes
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strings"
+)
+
+// Minimum contrast ratios against the code background in generated Chroma
+// themes: WCAG AA for body text, and the large-text/UI minimum for token
+// colors, matching the palette's code-comment check.
+const (
+	chromaTextMinContrast  = 4.5
+	chromaTokenMinContrast = 3.0
+)
+
+// chromaRoles lists the colors a generated Chroma theme uses. Each role
+// takes the first color its palette defines, mirroring the fallbacks of the
+// palette-derived chroma.css.
+var chromaRoles = map[string][]string{
+	"bg":       {"code-bg", "bg-surface", "bg-primary"},
+	"text":     {"code-text", "text-primary"},
+	"comment":  {"code-comment", "text-muted"},
+	"keyword":  {"code-keyword", "accent"},
+	"string":   {"code-string", "success"},
+	"number":   {"code-number", "code-keyword", "accent"},
+	"function": {"code-function", "link"},
+	"type":     {"code-type", "code-function", "link"},
+	"operator": {"code-operator", "code-keyword", "accent"},
+	"inserted": {"success"},
+	"error":    {"error"},
+}
+
+type chromaStyleXML struct {
+	XMLName xml.Name         `xml:"style"`
+	Name    string           `xml:"name,attr"`
+	Entries []chromaEntryXML `xml:"entry"`
+}
+
+type chromaEntryXML struct {
+	Type  string `xml:"type,attr"`
+	Style string `xml:"style,attr"`
+}
+
+// GenerateChromaTheme renders p's code colors (code-bg, code-text,
+// code-comment, code-keyword, code-string, code-number, code-function,
+// code-type, and code-operator) as a Chroma XML style, the same mapping the
+// palette-derived syntax highlighting CSS uses. Missing code colors fall
+// back to the palette's semantic colors.
+//
+// The background and text come from the palette's code surface. Token
+// colors that do not reach a minimum contrast against it are lightened or
+// darkened, keeping their hue, until they do. The result can be saved as
+// a Chroma style file and tweaked by hand.
+func GenerateChromaTheme(p *Palette) (string, error) {
+	if p == nil {
+		return "", fmt.Errorf("generate chroma theme: %w", ErrPaletteNotFound)
+	}
+	colors := make(map[string]string, len(chromaRoles))
+	for role, names := range chromaRoles {
+		for _, name := range names {
+			if _, ok := p.lookup(name); !ok {
+				continue
+			}
+			hex, err := p.resolveColor(name, make(map[string]bool))
+			if err != nil {
+				return "", err
+			}
+			colors[role] = hex
+			break
+		}
+	}
+	bg, text := colors["bg"], colors["text"]
+	if bg == "" || text == "" {
+		return "", fmt.Errorf("palette %s has no code-bg or code-text color: %w", p.Name, ErrUnknownColor)
+	}
+	bgColor, err := ParseHexColor(bg)
+	if err != nil {
+		return "", err
+	}
+
+	// fg returns the role's color with enough contrast, or text if unset
+	fg := func(role string, minRatio float64) (string, error) {
+		hex := colors[role]
+		if hex == "" {
+			hex = text
+		}
+		c, err := ParseHexColor(hex)
+		if err != nil {
+			return "", err
+		}
+		if ContrastRatio(c, bgColor) >= minRatio {
+			return hex, nil
+		}
+		if fixed, _, ok := minimalLightnessFix(c, bgColor, minRatio); ok {
+			return fixed.Hex(), nil
+		}
+		return hex, nil
+	}
+
+	text, err = fg("text", chromaTextMinContrast)
+	if err != nil {
+		return "", err
+	}
+	resolved := map[string]string{"text": text}
+	for _, role := range []string{"comment", "keyword", "string", "number", "function", "type", "operator"} {
+		if resolved[role], err = fg(role, chromaTokenMinContrast); err != nil {
+			return "", err
+		}
+	}
+	highlight, err := mixHex(bg, text, 0.10)
+	if err != nil {
+		return "", err
+	}
+
+	style := chromaStyleXML{Name: p.Name}
+	add := func(value string, types ...string) {
+		for _, t := range types {
+			style.Entries = append(style.Entries, chromaEntryXML{Type: t, Style: value})
+		}
+	}
+	add("bg:"+bg+" "+text, "Background")
+	add(text, "CodeLine", "Other", "Name", "NameBuiltin", "NameVariable", "NameOther")
+	add("bg:"+highlight, "LineHighlight")
+	add(resolved["comment"], "LineNumbers", "LineNumbersTable")
+	add("italic "+resolved["comment"], "Comment")
+	add("bold "+resolved["keyword"], "Keyword")
+	add(resolved["string"], "LiteralString")
+	add(resolved["number"], "LiteralNumber")
+	add(resolved["function"], "NameFunction", "NameFunctionMagic", "NameAttribute", "NameProperty", "NameBuiltinPseudo")
+	add(resolved["type"], "NameClass", "NameNamespace", "NameConstant", "NameTag", "NameDecorator", "NameException", "NameLabel")
+	add(resolved["operator"], "Operator")
+	if colors["inserted"] != "" {
+		add(colors["inserted"], "GenericInserted")
+	}
+	if colors["error"] != "" {
+		add(colors["error"], "Error", "GenericDeleted")
+	}
+
+	data, err := xml.MarshalIndent(style, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("marshal chroma theme for %s: %w", p.Name, err)
+	}
+	return strings.ReplaceAll(string(data), "></entry>", "/>") + "\n", nil
+}
@@ -0,0 +1,100 @@
+package palettes
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/alecthomas/chroma/v2"
+)
+
+func parseChromaTheme(t *testing.T, p *Palette) *chroma.Style {
+	t.Helper()
+	theme, err := GenerateChromaTheme(p)
+	if err != nil {
+		t.Fatalf("GenerateChromaTheme() error = %v", err)
+	}
+	style, err := chroma.NewXMLStyle(strings.NewReader(theme))
+	if err != nil {
+		t.Fatalf("generated theme does not parse: %v\n%s", err, theme)
+	}
+	return style
+}
+
+func TestGenerateChromaTheme_UsesComponentColors(t *testing.T) {
+	p, err := NewLoader().Load("catppuccin-mocha")
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	style := parseChromaTheme(t, p)
+
+	if style.Name != p.Name {
+		t.Errorf("Name = %q, want %q", style.Name, p.Name)
+	}
+	tests := []struct {
+		token     chroma.TokenType
+		component string
+	}{
+		{chroma.Keyword, "code-keyword"},
+		{chroma.KeywordReserved, "code-keyword"},
+		{chroma.LiteralString, "code-string"},
+		{chroma.LiteralStringDouble, "code-string"},
+		{chroma.Comment, "code-comment"},
+		{chroma.LiteralNumber, "code-number"},
+		{chroma.NameFunction, "code-function"},
+		{chroma.NameClass, "code-type"},
+		{chroma.Operator, "code-operator"},
+		{chroma.Text, "code-text"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.component, func(t *testing.T) {
+			if got, want := style.Get(tt.token).Colour.String(), p.Resolve(tt.component); got != want {
+				t.Errorf("%s colour = %s, want %s (%s)", tt.token, got, want, tt.component)
+			}
+		})
+	}
+
+	bg := style.Get(chroma.Background)
+	if got, want := bg.Background.String(), p.Resolve("code-bg"); got != want {
+		t.Errorf("background = %s, want code-bg %s", got, want)
+	}
+	if got, want := bg.Colour.String(), p.Resolve("code-text"); got != want {
+		t.Errorf("foreground = %s, want code-text %s", got, want)
+	}
+}
+
+func TestGenerateChromaTheme_MeetsMinimumContrast(t *testing.T) {
+	p := NewPalette("low-contrast", VariantDark)
+	p.Colors["base"] = "#202020"
+	p.Colors["grey"] = "#303030"
+	p.Colors["white"] = "#eeeeee"
+	p.Colors["pink"] = "#ff79c6"
+	p.Components["code-bg"] = "base"
+	p.Components["code-text"] = "white"
+	p.Components["code-comment"] = "grey"
+	p.Components["code-keyword"] = "pink"
+
+	style := parseChromaTheme(t, p)
+
+	bg, _ := ParseHexColor(p.Resolve("code-bg")) //nolint:errcheck // palette colors are valid
+	comment := style.Get(chroma.Comment).Colour.String()
+	c, err := ParseHexColor(comment)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ratio := ContrastRatio(c, bg); ratio < chromaTokenMinContrast {
+		t.Errorf("comment %s contrast = %.2f, want at least %.1f", comment, ratio, chromaTokenMinContrast)
+	}
+	if got := style.Get(chroma.Keyword).Colour.String(); got != "#ff79c6" {
+		t.Errorf("keyword = %s, want the palette color kept", got)
+	}
+	if got := style.Get(chroma.LiteralString).Colour.String(); got != "#eeeeee" {
+		t.Errorf("string = %s, want code-text fallback", got)
+	}
+}
+
+func TestGenerateChromaTheme_MissingCodeSurface(t *testing.T) {
+	p := NewPalette("empty", VariantDark)
+	if _, err := GenerateChromaTheme(p); err == nil {
+		t.Error("GenerateChromaTheme() error = nil, want an error for a palette without code colors")
+	}
+}
@@ -3,19 +3,35 @@ package palettes
 import (
 	"fmt"
 	"sort"
+	"strings"
 )
 
 // ContrastCheck represents a single contrast validation result.
 type ContrastCheck struct {
-	Foreground    string   `json:"foreground"`     // Foreground color name
-	Background    string   `json:"background"`     // Background color name
-	ForegroundHex string   `json:"foreground_hex"` // Resolved foreground hex
-	BackgroundHex string   `json:"background_hex"` // Resolved background hex
-	Ratio         float64  `json:"ratio"`          // Calculated contrast ratio
-	Required      float64  `json:"required"`       // Minimum required ratio
-	Level         string   `json:"level"`          // WCAG level: "AA", "AAA", "AA Large", "UI"
-	Passed        bool     `json:"passed"`         // Whether the check passed
-	PassedLevels  []string `json:"passed_levels"`  // All WCAG levels that pass
+	Foreground    string   `json:"foreground"`          // Foreground color name
+	Background    string   `json:"background"`          // Background color name
+	ForegroundHex string   `json:"foreground_hex"`      // Resolved foreground hex
+	BackgroundHex string   `json:"background_hex"`      // Resolved background hex
+	Ratio         float64  `json:"ratio"`               // Calculated contrast ratio
+	Required      float64  `json:"required"`            // Minimum required ratio
+	Level         string   `json:"level"`               // WCAG level: "AA", "AAA", "AA Large", "UI"
+	Passed        bool     `json:"passed"`              // Whether the check passed
+	PassedLevels  []string `json:"passed_levels"`       // All WCAG levels that pass
+	Component     string   `json:"component,omitempty"` // Component the pair styles, e.g. "primary button"
+	LargeText     bool     `json:"large_text"`          // Treated as large/UI text (3:1) rather than normal text
+}
+
+// Requirement describes the threshold the check was held to, e.g.
+// "AA normal", "AA large", or "UI".
+func (c ContrastCheck) Requirement() string {
+	switch c.Level {
+	case "AA", "AAA":
+		return c.Level + " normal"
+	case "AA Large", "AAA Large":
+		return strings.TrimSuffix(c.Level, " Large") + " large"
+	default:
+		return c.Level
+	}
 }
 
 // ContrastCheckSpec defines a contrast check to perform.
@@ -58,6 +74,61 @@ var RequiredChecks = []ContrastCheckSpec{
 	{"button-secondary-text", "button-secondary-bg", 4.5, "AA"},
 }
 
+// ComponentContrastPair is a component foreground/background pair that
+// must stay readable.
+type ComponentContrastPair struct {
+	Component  string // Component label, e.g. "primary button"
+	Foreground string // Foreground color name
+	Background string // Background color name
+	LargeText  bool   // Large/UI text (3:1) instead of normal text (4.5:1)
+}
+
+// ComponentPairs lists the component colors that are rendered together.
+// CheckContrast includes a pair when the palette defines either color as a
+// component, so palettes without component colors are unaffected.
+var ComponentPairs = []ComponentContrastPair{
+	// Buttons
+	{"primary button", "button-primary-text", "button-primary-bg", false},
+	{"secondary button", "button-secondary-text", "button-secondary-bg", false},
+
+	// Code blocks
+	{"code block", "code-text", "code-bg", false},
+	{"code block", "code-comment", "code-bg", true},
+	{"code block", "code-keyword", "code-bg", false},
+
+	// Navigation and cards
+	{"navigation", "nav-text", "nav-bg", false},
+	{"card", "text-primary", "card-bg", false},
+
+	// Admonition bodies
+	{"note admonition", "text-primary", "admonition-note-bg", false},
+	{"tip admonition", "text-primary", "admonition-tip-bg", false},
+	{"info admonition", "text-primary", "admonition-info-bg", false},
+	{"warning admonition", "text-primary", "admonition-warn-bg", false},
+	{"error admonition", "text-primary", "admonition-error-bg", false},
+	{"success admonition", "text-primary", "admonition-success-bg", false},
+	{"danger admonition", "text-primary", "admonition-danger-bg", false},
+	{"caution admonition", "text-primary", "admonition-caution-bg", false},
+	{"important admonition", "text-primary", "admonition-important-bg", false},
+	{"hint admonition", "text-primary", "admonition-hint-bg", false},
+	{"example admonition", "text-primary", "admonition-example-bg", false},
+	{"quote admonition", "text-primary", "admonition-quote-bg", false},
+	{"bug admonition", "text-primary", "admonition-bug-bg", false},
+	{"abstract admonition", "text-primary", "admonition-abstract-bg", false},
+
+	// Highlighted text
+	{"highlight", "mark-text", "mark-bg", false},
+}
+
+// Spec returns the contrast check for the pair: 3:1 "AA Large" for large
+// or UI text, 4.5:1 "AA" for normal text.
+func (c ComponentContrastPair) Spec() ContrastCheckSpec {
+	if c.LargeText {
+		return ContrastCheckSpec{c.Foreground, c.Background, 3.0, "AA Large"}
+	}
+	return ContrastCheckSpec{c.Foreground, c.Background, 4.5, "AA"}
+}
+
 // StrictChecks defines additional checks for AAA compliance.
 var StrictChecks = []ContrastCheckSpec{
 	// AAA text requirements (7:1 for normal text)
@@ -70,10 +141,11 @@ var StrictChecks = []ContrastCheckSpec{
 	{"accent", "bg-primary", 4.5, "AAA Large"},
 }
 
-// CheckContrast checks all required contrast ratios for the palette.
+// CheckContrast checks all required contrast ratios for the palette,
+// plus the ComponentPairs whose colors the palette defines.
 // Returns a slice of ContrastCheck results.
 func (p *Palette) CheckContrast() []ContrastCheck {
-	return p.checkContrastWith(RequiredChecks)
+	return p.withComponentChecks(p.checkContrastWith(RequiredChecks))
 }
 
 // CheckContrastStrict checks both required and strict contrast ratios.
@@ -82,7 +154,37 @@ func (p *Palette) CheckContrastStrict() []ContrastCheck {
 	allChecks := make([]ContrastCheckSpec, 0, len(RequiredChecks)+len(StrictChecks))
 	allChecks = append(allChecks, RequiredChecks...)
 	allChecks = append(allChecks, StrictChecks...)
-	return p.checkContrastWith(allChecks)
+	return p.withComponentChecks(p.checkContrastWith(allChecks))
+}
+
+// withComponentChecks labels results that match a component pair and
+// appends checks for the remaining pairs the palette defines.
+func (p *Palette) withComponentChecks(results []ContrastCheck) []ContrastCheck {
+	for _, pair := range ComponentPairs {
+		_, fgDefined := p.Components[pair.Foreground]
+		_, bgDefined := p.Components[pair.Background]
+		if !fgDefined && !bgDefined {
+			continue
+		}
+
+		spec := pair.Spec()
+		labeled := false
+		for i := range results {
+			r := &results[i]
+			if r.Foreground == spec.Foreground && r.Background == spec.Background && r.Level == spec.Level {
+				r.Component = pair.Component
+				labeled = true
+			}
+		}
+		if labeled {
+			continue
+		}
+
+		result := p.checkSingleContrast(spec)
+		result.Component = pair.Component
+		results = append(results, result)
+	}
+	return results
 }
 
 // CheckContrastWith checks contrast using custom check specifications.
@@ -109,6 +211,7 @@ func (p *Palette) checkSingleContrast(spec ContrastCheckSpec) ContrastCheck {
 		Background: spec.Background,
 		Required:   spec.MinRatio,
 		Level:      spec.Level,
+		LargeText:  spec.Level == "AA Large" || spec.Level == "AAA Large" || spec.Level == "UI",
 	}
 
 	// Resolve colors
@@ -196,15 +299,35 @@ func FormatContrastResult(r ContrastCheck) string {
 		levelsStr = fmt.Sprintf(" (%s)", joinLevels(r.PassedLevels))
 	}
 
-	return fmt.Sprintf("  %s %s on %s: %.1f:1%s",
+	componentStr := ""
+	if r.Component != "" {
+		componentStr = fmt.Sprintf(" [%s]", r.Component)
+	}
+
+	return fmt.Sprintf("  %s %s on %s: %.1f:1%s%s",
 		status,
 		r.Foreground,
 		r.Background,
 		r.Ratio,
 		levelsStr,
+		componentStr,
 	)
 }
 
+// FormatContrastCheck formats a check with its verdict and requirement,
+// e.g. "button-primary-text on button-primary-bg: 2.9:1 FAIL (AA normal)".
+func FormatContrastCheck(r ContrastCheck) string {
+	if r.ForegroundHex == "" || r.BackgroundHex == "" {
+		return fmt.Sprintf("%s on %s: color not found SKIP (%s)", r.Foreground, r.Background, r.Requirement())
+	}
+
+	verdict := "PASS"
+	if !r.Passed {
+		verdict = "FAIL"
+	}
+	return fmt.Sprintf("%s on %s: %.1f:1 %s (%s)", r.Foreground, r.Background, r.Ratio, verdict, r.Requirement())
+}
+
 // FormatContrastSummary formats a contrast summary as a human-readable string.
 func FormatContrastSummary(summary ContrastSummary) string {
 	result := fmt.Sprintf("Contrast Check: %s\n\n", summary.Palette)
@@ -0,0 +1,80 @@
+package palettes
+
+import "math"
+
+// OKLCH represents a color in the cylindrical form of OKLab.
+// L is lightness (0-1), C is chroma, and H is the hue angle in radians.
+type OKLCH struct {
+	L, C, H float64
+}
+
+// ToOKLCH converts the color to OKLCH.
+func (c Color) ToOKLCH() OKLCH {
+	lab := c.ToOKLab()
+	return OKLCH{L: lab.L, C: math.Hypot(lab.A, lab.B), H: math.Atan2(lab.B, lab.A)}
+}
+
+// ToColor converts OKLCH to an sRGB Color, clamping out-of-gamut values.
+func (lch OKLCH) ToColor() Color {
+	return OKLab{L: lch.L, A: lch.C * math.Cos(lch.H), B: lch.C * math.Sin(lch.H)}.ToColor()
+}
+
+// fixLightnessStep is the OKLCH lightness increment SuggestFix searches in.
+const fixLightnessStep = 0.002
+
+// SuggestFix suggests the smallest OKLCH lightness change, to either the
+// foreground or the background, that brings the pair to at least the target
+// contrast ratio. Hue and chroma are kept, so the result stays recognizably
+// the same color.
+//
+// It returns the adjusted color as hex and whether it is the background;
+// otherwise it is the foreground. When the pair already meets the target,
+// fg is returned unchanged. An empty string means the colors could not be
+// parsed or no lightness change reaches the target.
+func SuggestFix(fg, bg string, target float64) (newFg string, adjustedBackground bool) {
+	fgColor, err := ParseHexColor(fg)
+	if err != nil {
+		return "", false
+	}
+	bgColor, err := ParseHexColor(bg)
+	if err != nil {
+		return "", false
+	}
+	if ContrastRatio(fgColor, bgColor) >= target {
+		return fg, false
+	}
+
+	fgFix, fgDelta, fgOK := minimalLightnessFix(fgColor, bgColor, target)
+	bgFix, bgDelta, bgOK := minimalLightnessFix(bgColor, fgColor, target)
+	switch {
+	case fgOK && (!bgOK || fgDelta <= bgDelta):
+		return fgFix.Hex(), false
+	case bgOK:
+		return bgFix.Hex(), true
+	default:
+		return "", false
+	}
+}
+
+// minimalLightnessFix searches lightening and darkening c for the smallest
+// lightness change that gives at least target contrast against other.
+func minimalLightnessFix(c, other Color, target float64) (Color, float64, bool) {
+	lch := c.ToOKLCH()
+
+	var best Color
+	bestDelta := math.Inf(1)
+	for _, direction := range []float64{1, -1} {
+		for delta := fixLightnessStep; delta < bestDelta; delta += fixLightnessStep {
+			l := math.Max(0, math.Min(1, lch.L+direction*delta))
+			candidate := OKLCH{L: l, C: lch.C, H: lch.H}.ToColor()
+			if ContrastRatio(candidate, other) >= target {
+				best, bestDelta = candidate, delta
+				break
+			}
+			if l == 0 || l == 1 {
+				break
+			}
+		}
+	}
+	return best, bestDelta, !math.IsInf(bestDelta, 1)
+}
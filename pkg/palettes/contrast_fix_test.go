@@ -0,0 +1,103 @@
+package palettes
+
+import (
+	"math"
+	"testing"
+)
+
+func TestOKLCHRoundTrip(t *testing.T) {
+	for _, hex := range []string{"#000000", "#ffffff", "#88c0d0", "#f38ba8", "#1e1e2e"} {
+		c, err := ParseHexColor(hex)
+		if err != nil {
+			t.Fatalf("ParseHexColor(%q) error = %v", hex, err)
+		}
+		if got := c.ToOKLCH().ToColor().Hex(); got != hex {
+			t.Errorf("OKLCH round trip of %s = %s", hex, got)
+		}
+	}
+}
+
+func TestSuggestFix_MeetsTarget(t *testing.T) {
+	tests := []struct {
+		name   string
+		fg, bg string
+		target float64
+	}{
+		{"muted text on dark", "#585b70", "#1e1e2e", 4.5},
+		{"muted text on light", "#9ca0b0", "#eff1f5", 4.5},
+		{"large text", "#6c7086", "#313244", 3.0},
+		{"accent on dark", "#7f849c", "#45475a", 7.0},
+		{"mid gray on mid gray", "#777777", "#888888", 4.5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fixed, adjustedBackground := SuggestFix(tt.fg, tt.bg, tt.target)
+			if fixed == "" {
+				t.Fatalf("SuggestFix(%s, %s, %.1f) found no fix", tt.fg, tt.bg, tt.target)
+			}
+
+			fg, bg := fixed, tt.bg
+			if adjustedBackground {
+				fg, bg = tt.fg, fixed
+			}
+			ratio, err := ContrastRatioFromHex(fg, bg)
+			if err != nil {
+				t.Fatalf("ContrastRatioFromHex() error = %v", err)
+			}
+			if ratio < tt.target {
+				t.Errorf("fixed pair %s on %s has ratio %.2f, want >= %.1f", fg, bg, ratio, tt.target)
+			}
+		})
+	}
+}
+
+func TestSuggestFix_PreservesHueAndChroma(t *testing.T) {
+	original, err := ParseHexColor("#5e81ac")
+	if err != nil {
+		t.Fatal(err)
+	}
+	fixed, adjustedBackground := SuggestFix(original.Hex(), "#2e3440", 4.5)
+	if adjustedBackground {
+		t.Fatalf("expected the foreground to be adjusted, got background %s", fixed)
+	}
+	c, err := ParseHexColor(fixed)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	before, after := original.ToOKLCH(), c.ToOKLCH()
+	if after.L <= before.L {
+		t.Errorf("lightness %.3f should increase from %.3f on a dark background", after.L, before.L)
+	}
+	if math.Abs(after.H-before.H) > 0.05 {
+		t.Errorf("hue changed from %.3f to %.3f", before.H, after.H)
+	}
+	if math.Abs(after.C-before.C) > 0.01 {
+		t.Errorf("chroma changed from %.3f to %.3f", before.C, after.C)
+	}
+}
+
+func TestSuggestFix_AdjustsWhicheverMovesLeast(t *testing.T) {
+	// Light text on a mid background: brightening the text is capped near
+	// white, so only darkening the background can reach 7:1.
+	fixed, adjustedBackground := SuggestFix("#f0f0f0", "#707070", 7)
+	if !adjustedBackground {
+		t.Fatalf("expected the background to be adjusted, got foreground %s", fixed)
+	}
+	if ratio, _ := ContrastRatioFromHex("#f0f0f0", fixed); ratio < 7 {
+		t.Errorf("fixed background %s gives ratio %.2f, want >= 7", fixed, ratio)
+	}
+}
+
+func TestSuggestFix_NoChangeOrNoFix(t *testing.T) {
+	if fixed, bg := SuggestFix("#ffffff", "#000000", 4.5); fixed != "#ffffff" || bg {
+		t.Errorf("passing pair: SuggestFix() = %q, %v; want unchanged foreground", fixed, bg)
+	}
+	if fixed, _ := SuggestFix("not-a-color", "#000000", 4.5); fixed != "" {
+		t.Errorf("invalid color: SuggestFix() = %q, want empty", fixed)
+	}
+	if fixed, _ := SuggestFix("#777777", "#888888", 22); fixed != "" {
+		t.Errorf("unreachable target: SuggestFix() = %q, want empty", fixed)
+	}
+}
@@ -0,0 +1,189 @@
+package palettes
+
+import "strings"
+
+// maxRepairLightness is the largest OKLCH lightness change AutoRepair makes
+// to a single color. Pairs that need more are left unfixed rather than
+// turned into a different-looking color.
+const maxRepairLightness = 0.25
+
+// Change records one color AutoRepair changed, or a failing pair it could
+// not fix.
+type Change struct {
+	Color      string  `json:"color"`               // Name of the color that changed
+	Before     string  `json:"before"`              // Hex value before the repair
+	After      string  `json:"after,omitempty"`     // Hex value after the repair, empty when unfixed
+	Foreground string  `json:"foreground"`          // Foreground of the pair that needed the change
+	Background string  `json:"background"`          // Background of the pair that needed the change
+	Level      string  `json:"level"`               // Level of the failing check, e.g. "AA Large"
+	Required   float64 `json:"required"`            // Ratio the pair must reach
+	Ratio      float64 `json:"ratio"`               // Ratio before the repair
+	NewRatio   float64 `json:"new_ratio,omitempty"` // Ratio after the repair
+	Unfixed    bool    `json:"unfixed,omitempty"`   // No repair within the lightness limit was found
+}
+
+// AutoRepair returns a copy of the palette adjusted so the contrast checks
+// for level pass: CheckContrast for "AA", CheckContrastStrict for "AAA".
+// Any other level is treated as AA. The receiver is not modified.
+//
+// Colors are adjusted in OKLCH by lightness only, so hue and chroma, and
+// with them brand and accent hues, are kept. The foreground of a failing
+// pair is preferred; the background is adjusted only when the foreground
+// cannot be. A candidate is accepted only if every check that passed before
+// still passes. A pair that would need a lightness change above
+// maxRepairLightness is left as is and reported with Unfixed set.
+//
+// The new value replaces the color where it is defined, so a semantic or
+// component color that referenced a raw color becomes a direct hex value.
+func (p *Palette) AutoRepair(level string) (*Palette, []Change) {
+	repaired := p.Clone()
+	check := repaired.CheckContrast
+	if strings.EqualFold(strings.TrimSpace(level), string(WCAGLevelAAA)) {
+		check = repaired.CheckContrastStrict
+	}
+
+	var changes []Change
+	unfixed := make(map[string]bool)
+	results := check()
+	for passes := 2 * len(results); passes > 0; passes-- {
+		idx := firstRepairable(results, unfixed)
+		if idx < 0 {
+			break
+		}
+		change := repaired.repairCheck(results, idx, check)
+		if change.Unfixed {
+			unfixed[checkKey(&results[idx])] = true
+		}
+		changes = append(changes, change)
+		results = check()
+	}
+	return repaired, changes
+}
+
+// repairCheck adjusts the foreground, then the background, of results[idx]
+// and describes the outcome.
+func (p *Palette) repairCheck(results []ContrastCheck, idx int, check func() []ContrastCheck) Change {
+	r := &results[idx]
+	change := Change{
+		Color:      r.Foreground,
+		Before:     r.ForegroundHex,
+		Foreground: r.Foreground,
+		Background: r.Background,
+		Level:      r.Level,
+		Required:   r.Required,
+		Ratio:      r.Ratio,
+	}
+
+	for _, side := range []struct{ name, hex string }{
+		{r.Foreground, r.ForegroundHex},
+		{r.Background, r.BackgroundHex},
+	} {
+		if after, ratio, ok := p.repairColor(side.name, results, idx, check); ok {
+			change.Color, change.Before = side.name, side.hex
+			change.After, change.NewRatio = after, ratio
+			return change
+		}
+	}
+
+	change.Unfixed = true
+	return change
+}
+
+// repairColor searches for the smallest lightness change to name that makes
+// results[idx] pass without failing any check that passed. On success the
+// change is kept and the new hex and the pair's new ratio are returned;
+// otherwise the palette is left unchanged.
+func (p *Palette) repairColor(name string, results []ContrastCheck, idx int, check func() []ContrastCheck) (string, float64, bool) {
+	layer := p.definingLayer(name)
+	if layer == nil {
+		return "", 0, false
+	}
+	original := layer[name]
+	hex := p.Resolve(name)
+	c, err := ParseHexColor(hex)
+	if err != nil {
+		return "", 0, false
+	}
+
+	target := checkKey(&results[idx])
+	passing := make(map[string]bool)
+	for i := range results {
+		if results[i].Passed {
+			passing[checkKey(&results[i])] = true
+		}
+	}
+
+	lch := c.ToOKLCH()
+	for delta := fixLightnessStep; delta <= maxRepairLightness; delta += fixLightnessStep {
+		for _, direction := range []float64{1, -1} {
+			l := lch.L + direction*delta
+			if l < 0 || l > 1 {
+				continue
+			}
+			candidate := OKLCH{L: l, C: lch.C, H: lch.H}.ToColor().Hex()
+			if candidate == hex {
+				continue
+			}
+
+			p.setColor(layer, name, candidate)
+			if ratio, ok := passesKeepingOthers(check(), target, passing); ok {
+				return candidate, ratio, true
+			}
+		}
+	}
+
+	p.setColor(layer, name, original)
+	return "", 0, false
+}
+
+// definingLayer returns the map that defines name, checked in the same
+// order Resolve uses, or nil if the palette does not define it.
+func (p *Palette) definingLayer(name string) map[string]string {
+	for _, layer := range []map[string]string{p.Colors, p.Semantic, p.Components} {
+		if _, ok := layer[name]; ok {
+			return layer
+		}
+	}
+	return nil
+}
+
+// setColor sets name in layer and drops the resolved cache.
+func (p *Palette) setColor(layer map[string]string, name, value string) {
+	layer[name] = value
+	p.resolved = nil
+}
+
+// passesKeepingOthers reports whether the target check passes and every
+// check in passing still does, returning the target's ratio.
+func passesKeepingOthers(results []ContrastCheck, target string, passing map[string]bool) (float64, bool) {
+	var ratio float64
+	targetPassed := false
+	for i := range results {
+		key := checkKey(&results[i])
+		if key == target {
+			ratio, targetPassed = results[i].Ratio, results[i].Passed
+		}
+		if passing[key] && !results[i].Passed {
+			return 0, false
+		}
+	}
+	return ratio, targetPassed
+}
+
+// firstRepairable returns the index of the first failing check whose
+// colors resolve and that has not been given up on, or -1.
+func firstRepairable(results []ContrastCheck, unfixed map[string]bool) int {
+	for i := range results {
+		r := &results[i]
+		if r.Passed || r.ForegroundHex == "" || r.BackgroundHex == "" || unfixed[checkKey(r)] {
+			continue
+		}
+		return i
+	}
+	return -1
+}
+
+// checkKey identifies a check across runs.
+func checkKey(r *ContrastCheck) string {
+	return r.Foreground + "|" + r.Background + "|" + r.Level
+}
@@ -0,0 +1,184 @@
+package palettes
+
+import (
+	"math"
+	"testing"
+)
+
+// lowContrastLightPalette returns a light palette whose muted text, link,
+// warning, and primary button colors are too light for AA, and whose
+// secondary text is too light for AAA.
+func lowContrastLightPalette() *Palette {
+	p := NewPalette("washed-out", VariantLight)
+	p.Colors = map[string]string{
+		"white": "#ffffff",
+		"paper": "#f6f6f4",
+		"ink":   "#1c1c1c",
+		"gray":  "#6b6b6b",
+		"light": "#b0b0b0",
+		"blue":  "#6fa8dc",
+		"green": "#93c47d",
+		"amber": "#f1c232",
+		"red":   "#e06666",
+	}
+	p.Semantic = map[string]string{
+		"bg-primary":     "white",
+		"bg-surface":     "paper",
+		"bg-elevated":    "white",
+		"text-primary":   "ink",
+		"text-secondary": "gray",
+		"text-muted":     "light",
+		"link":           "blue",
+		"accent":         "blue",
+		"success":        "green",
+		"warning":        "amber",
+		"error":          "red",
+		"info":           "blue",
+		"code-bg":        "paper",
+		"code-text":      "ink",
+		"code-comment":   "light",
+		"code-keyword":   "blue",
+	}
+	p.Components = map[string]string{
+		"button-primary-bg":     "blue",
+		"button-primary-text":   "white",
+		"button-secondary-bg":   "paper",
+		"button-secondary-text": "ink",
+	}
+	return p
+}
+
+func failingChecks(results []ContrastCheck) []ContrastCheck {
+	var failed []ContrastCheck
+	for i := range results {
+		if !results[i].Passed {
+			failed = append(failed, results[i])
+		}
+	}
+	return failed
+}
+
+func TestAutoRepair_PassesRequestedLevel(t *testing.T) {
+	tests := []struct {
+		level string
+		check func(*Palette) []ContrastCheck
+	}{
+		{"AA", (*Palette).CheckContrast},
+		{"AAA", (*Palette).CheckContrastStrict},
+		{"aaa", (*Palette).CheckContrastStrict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.level, func(t *testing.T) {
+			original := lowContrastLightPalette()
+			if len(failingChecks(tt.check(original))) == 0 {
+				t.Fatal("fixture should fail some checks before repair")
+			}
+
+			repaired, changes := original.AutoRepair(tt.level)
+
+			if failed := failingChecks(tt.check(repaired)); len(failed) > 0 {
+				for i := range failed {
+					t.Errorf("still failing after repair: %s", FormatContrastCheck(failed[i]))
+				}
+			}
+			if len(changes) == 0 {
+				t.Fatal("AutoRepair() reported no changes")
+			}
+			for _, change := range changes {
+				if change.Unfixed {
+					t.Errorf("unexpected unfixed pair %s on %s", change.Foreground, change.Background)
+				}
+				if change.NewRatio < change.Required {
+					t.Errorf("%s: new ratio %.2f below required %.1f", change.Color, change.NewRatio, change.Required)
+				}
+			}
+			if got := original.Semantic["text-muted"]; got != "light" {
+				t.Errorf("original palette modified: text-muted = %q", got)
+			}
+		})
+	}
+}
+
+func TestAutoRepair_PrefersForegroundAndKeepsHue(t *testing.T) {
+	original := lowContrastLightPalette()
+	repaired, changes := original.AutoRepair("AA")
+
+	for _, change := range changes {
+		want := change.Foreground
+		if change.Foreground == "button-primary-text" {
+			// White text cannot get lighter, so the button background darkens.
+			want = change.Background
+		}
+		if change.Color != want {
+			t.Errorf("changed %s for %s on %s, want %s", change.Color, change.Foreground, change.Background, want)
+		}
+	}
+
+	for _, name := range []string{"link", "warning", "button-primary-bg"} {
+		before, err := ParseHexColor(original.Resolve(name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		after, err := ParseHexColor(repaired.Resolve(name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if before == after {
+			t.Errorf("%s was not changed", name)
+			continue
+		}
+		// Allow a few degrees for sRGB gamut clipping of saturated colors.
+		b, a := before.ToOKLCH(), after.ToOKLCH()
+		if diff := math.Abs(math.Remainder(a.H-b.H, 2*math.Pi)); diff > 0.1 {
+			t.Errorf("%s hue moved by %.3f rad (%s -> %s)", name, diff, before.Hex(), after.Hex())
+		}
+	}
+
+	// Colors shared with passing pairs keep their raw definitions.
+	if got := repaired.Colors["blue"]; got != "#6fa8dc" {
+		t.Errorf("raw color blue = %s, want unchanged", got)
+	}
+	if errs := repaired.Validate(); len(errs) > 0 {
+		t.Errorf("repaired palette is invalid: %v", errs)
+	}
+}
+
+func TestAutoRepair_LeavesExcessiveChangesUnfixed(t *testing.T) {
+	p := NewPalette("fog", VariantLight)
+	p.Colors = map[string]string{"mid": "#808080", "near": "#777777", "ink": "#000000"}
+	p.Semantic = map[string]string{
+		"bg-primary":   "mid",
+		"text-primary": "near",
+		"link":         "ink",
+	}
+
+	repaired, changes := p.AutoRepair("AA")
+
+	var unfixed []Change
+	for _, change := range changes {
+		if change.Unfixed {
+			unfixed = append(unfixed, change)
+		}
+	}
+	if len(unfixed) != 1 || unfixed[0].Foreground != "text-primary" || unfixed[0].After != "" {
+		t.Fatalf("unfixed changes = %+v, want text-primary on bg-primary", unfixed)
+	}
+	if got := repaired.Resolve("text-primary"); got != "#777777" {
+		t.Errorf("text-primary = %s, want unchanged #777777", got)
+	}
+	if got := repaired.Resolve("bg-primary"); got != "#808080" {
+		t.Errorf("bg-primary = %s, want unchanged #808080", got)
+	}
+}
+
+func TestAutoRepair_NoChangesWhenPassing(t *testing.T) {
+	p := NewPalette("crisp", VariantLight)
+	p.Colors = map[string]string{"white": "#ffffff", "black": "#000000"}
+	p.Semantic = map[string]string{"bg-primary": "white", "text-primary": "black"}
+
+	_, changes := p.AutoRepair("AAA")
+	if len(changes) != 0 {
+		t.Errorf("AutoRepair() changes = %+v, want none", changes)
+	}
+}
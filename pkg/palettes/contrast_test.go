@@ -62,6 +62,108 @@ func TestPalette_CheckContrast(t *testing.T) {
 	}
 }
 
+func TestPalette_CheckContrast_ComponentPairs(t *testing.T) {
+	p := &Palette{
+		Name:    "Unreadable Buttons",
+		Variant: VariantDark,
+		Colors: map[string]string{
+			"white":  "#ffffff",
+			"black":  "#000000",
+			"gray":   "#777777",
+			"silver": "#aaaaaa",
+		},
+		Semantic: map[string]string{
+			"text-primary": "white",
+			"bg-primary":   "black",
+		},
+		Components: map[string]string{
+			// Deliberately unreadable: ~1.9:1
+			"button-primary-text": "silver",
+			"button-primary-bg":   "gray",
+			"nav-text":            "white",
+			"nav-bg":              "black",
+			"card-bg":             "black",
+		},
+	}
+
+	results := p.CheckContrast()
+	byPair := make(map[string]ContrastCheck)
+	for _, r := range results {
+		byPair[r.Foreground+" on "+r.Background] = r
+	}
+
+	button, ok := byPair["button-primary-text on button-primary-bg"]
+	if !ok {
+		t.Fatal("CheckContrast() missing primary button pair")
+	}
+	if button.Passed {
+		t.Errorf("button pair should fail, got ratio %.2f", button.Ratio)
+	}
+	if button.Component != "primary button" {
+		t.Errorf("button Component = %q, want %q", button.Component, "primary button")
+	}
+	if button.LargeText {
+		t.Error("button text should be treated as normal text")
+	}
+	if got, want := FormatContrastCheck(button), "button-primary-text on button-primary-bg: 1.9:1 FAIL (AA normal)"; got != want {
+		t.Errorf("FormatContrastCheck() = %q, want %q", got, want)
+	}
+
+	nav, ok := byPair["nav-text on nav-bg"]
+	if !ok || !nav.Passed || nav.Component != "navigation" {
+		t.Errorf("nav pair = %+v, want passing navigation check", nav)
+	}
+	if card, ok := byPair["text-primary on card-bg"]; !ok || card.Component != "card" {
+		t.Errorf("card pair = %+v, want card check", card)
+	}
+
+	// Pairs whose components the palette does not define are not added
+	if _, ok := byPair["mark-text on mark-bg"]; ok {
+		t.Error("undefined component pair should not be checked")
+	}
+
+	// Component pairs already in RequiredChecks are labeled, not duplicated
+	count := 0
+	for _, r := range results {
+		if r.Foreground == "button-primary-text" && r.Background == "button-primary-bg" {
+			count++
+		}
+	}
+	if count != 1 {
+		t.Errorf("button pair checked %d times, want 1", count)
+	}
+
+	if summary := SummarizeContrast(p.Name, results); summary.AllPassed {
+		t.Error("summary should report the failing button pair")
+	}
+}
+
+func TestContrastCheck_Requirement(t *testing.T) {
+	tests := map[string]string{
+		"AA":        "AA normal",
+		"AAA":       "AAA normal",
+		"AA Large":  "AA large",
+		"AAA Large": "AAA large",
+		"UI":        "UI",
+	}
+	for level, want := range tests {
+		if got := (ContrastCheck{Level: level}).Requirement(); got != want {
+			t.Errorf("Requirement() for %q = %q, want %q", level, got, want)
+		}
+	}
+}
+
+func TestComponentContrastPair_Spec(t *testing.T) {
+	normal := ComponentContrastPair{"card", "text-primary", "card-bg", false}.Spec()
+	if normal.MinRatio != 4.5 || normal.Level != "AA" {
+		t.Errorf("normal text spec = %+v, want 4.5 AA", normal)
+	}
+	large := ComponentContrastPair{"code block", "code-comment", "code-bg", true}.Spec()
+	if large.MinRatio != 3.0 || large.Level != "AA Large" {
+		t.Errorf("large text spec = %+v, want 3.0 AA Large", large)
+	}
+}
+
 func TestPalette_CheckContrastStrict(t *testing.T) {
 	p := &Palette{
 		Name:    "Test",
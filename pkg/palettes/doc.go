@@ -28,6 +28,12 @@
 //	// Generate CSS
 //	css := p.GenerateCSS()
 //
+//	// Export with every reference resolved: "css", "scss", "tailwind", or "json"
+//	tw, err := p.Export("tailwind")
+//
+//	// Chroma XML style from the code-* component colors
+//	theme, err := palettes.GenerateChromaTheme(p)
+//
 //	// Check contrast ratios
 //	results := p.CheckContrast()
 //	for _, r := range results {
@@ -36,9 +42,40 @@
 //	    }
 //	}
 //
+// # Light and Dark Pairs
+//
+// LoadPair resolves the light and dark variants of a palette family, trying
+// {base}-light and {base}-dark before falling back to {base} itself for a
+// missing side. IsDark reports whether a palette's background is dark:
+//
+//	light, dark, err := palettes.LoadPair("everforest")
+//
+// # Interpolation
+//
+// Interpolate blends two palettes in OKLab space, e.g. for crossfading
+// between seasonal themes; InterpolateSteps precomputes a gradient of
+// palettes between them:
+//
+//	steps, err := palettes.InterpolateSteps(winter, spring, 5)
+//
 // # WCAG Compliance
 //
 // The package includes WCAG 2.1 contrast ratio validation:
 //   - AA: 4.5:1 for normal text, 3:1 for large text/UI
 //   - AAA: 7:1 for normal text, 4.5:1 for large text
+//
+// CheckContrast also covers the ComponentPairs a palette defines, such as
+// button-primary-text on button-primary-bg, labeling each result with its
+// component. FormatContrastCheck renders a result as
+// "button-primary-text on button-primary-bg: 2.9:1 FAIL (AA normal)".
+//
+// SuggestFix proposes the smallest OKLCH lightness change to the foreground
+// or background that makes a failing pair reach a target ratio:
+//
+//	fixed, isBackground := palettes.SuggestFix("#5c6166", "#fcfcfc", 7)
+//
+// AutoRepair applies such fixes to a whole palette, returning a repaired
+// copy and a Change for each color it adjusted or pair it left unfixed:
+//
+//	fixed, changes := p.AutoRepair("AAA")
 package palettes
@@ -0,0 +1,51 @@
+package palettes
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Export formats supported by Palette.Export.
+const (
+	ExportFormatCSS      = "css"
+	ExportFormatSCSS     = "scss"
+	ExportFormatTailwind = "tailwind"
+	ExportFormatJSON     = "json"
+)
+
+// ExportFormats lists the formats accepted by Palette.Export.
+var ExportFormats = []string{ExportFormatCSS, ExportFormatSCSS, ExportFormatTailwind, ExportFormatJSON}
+
+// Export renders the palette in the given format:
+//
+//   - "css": CSS custom properties (same as GenerateCSS)
+//   - "scss": SCSS variables (same as GenerateSCSS)
+//   - "tailwind": a theme.extend.colors snippet for tailwind.config.js
+//   - "json": a flat map of every color name to its resolved hex value
+//
+// Every semantic and component reference is resolved before rendering, so a
+// reference to an undefined color is an error naming the offending entry
+// rather than an empty or undefined value in the output.
+func (p *Palette) Export(format string) (string, error) {
+	resolved, err := p.ResolveAll()
+	if err != nil {
+		return "", err
+	}
+
+	switch format {
+	case ExportFormatCSS:
+		return p.GenerateCSS(), nil
+	case ExportFormatSCSS:
+		return p.GenerateSCSS(), nil
+	case ExportFormatTailwind:
+		return p.GenerateTailwind(), nil
+	case ExportFormatJSON:
+		data, err := json.MarshalIndent(resolved, "", "  ")
+		if err != nil {
+			return "", fmt.Errorf("marshal palette %s: %w", p.Name, err)
+		}
+		return string(data) + "\n", nil
+	default:
+		return "", fmt.Errorf("unknown export format %q (supported: css, scss, tailwind, json)", format)
+	}
+}
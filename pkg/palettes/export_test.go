@@ -0,0 +1,104 @@
+package palettes
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func exportTestPalette() *Palette {
+	return &Palette{
+		Name:    "Test",
+		Variant: VariantDark,
+		Colors: map[string]string{
+			"red":  "#FF0000",
+			"blue": "#0000ff",
+		},
+		Semantic: map[string]string{
+			"primary": "red",
+			"link":    "primary",
+		},
+		Components: map[string]string{
+			"button-bg": "link",
+		},
+	}
+}
+
+func TestPalette_Export_JSON(t *testing.T) {
+	out, err := exportTestPalette().Export(ExportFormatJSON)
+	if err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+
+	var colors map[string]string
+	if err := json.Unmarshal([]byte(out), &colors); err != nil {
+		t.Fatalf("output is not a flat string map: %v\n%s", err, out)
+	}
+
+	want := map[string]string{
+		"red":       "#ff0000",
+		"blue":      "#0000ff",
+		"primary":   "#ff0000",
+		"link":      "#ff0000",
+		"button-bg": "#ff0000",
+	}
+	if len(colors) != len(want) {
+		t.Errorf("got %d colors, want %d: %v", len(colors), len(want), colors)
+	}
+	for name, hex := range want {
+		if colors[name] != hex {
+			t.Errorf("colors[%q] = %q, want %q", name, colors[name], hex)
+		}
+	}
+}
+
+func TestPalette_Export_Formats(t *testing.T) {
+	p := exportTestPalette()
+
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{ExportFormatCSS, "--palette-red: #FF0000;"},
+		{ExportFormatSCSS, "$palette-red"},
+		{ExportFormatTailwind, "'button-bg': '#ff0000'"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			out, err := p.Export(tt.format)
+			if err != nil {
+				t.Fatalf("Export() error = %v", err)
+			}
+			if !strings.Contains(out, tt.want) {
+				t.Errorf("output missing %q:\n%s", tt.want, out)
+			}
+		})
+	}
+
+	if _, err := p.Export("xml"); err == nil {
+		t.Error("expected error for unknown format")
+	}
+}
+
+func TestPalette_Export_UndefinedReference(t *testing.T) {
+	p := exportTestPalette()
+	p.Components["card-bg"] = "surfce"
+
+	for _, format := range ExportFormats {
+		t.Run(format, func(t *testing.T) {
+			out, err := p.Export(format)
+			if err == nil {
+				t.Fatalf("expected error, got output:\n%s", out)
+			}
+			if !errors.Is(err, ErrUnknownColor) {
+				t.Errorf("error should wrap ErrUnknownColor: %v", err)
+			}
+			msg := err.Error()
+			if !strings.Contains(msg, "components.card-bg") || !strings.Contains(msg, `"surfce"`) {
+				t.Errorf("error should name the entry and reference: %v", err)
+			}
+		})
+	}
+}
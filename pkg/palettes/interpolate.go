@@ -0,0 +1,188 @@
+package palettes
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// OKLab represents a color in the OKLab perceptual color space.
+// L is lightness (0-1); A and B are the green-red and blue-yellow axes.
+type OKLab struct {
+	L, A, B float64
+}
+
+// ToOKLab converts the color to OKLab.
+func (c Color) ToOKLab() OKLab {
+	r := linearize(float64(c.R) / 255.0)
+	g := linearize(float64(c.G) / 255.0)
+	b := linearize(float64(c.B) / 255.0)
+
+	l := math.Cbrt(0.4122214708*r + 0.5363325363*g + 0.0514459929*b)
+	m := math.Cbrt(0.2119034982*r + 0.6806995451*g + 0.1073969566*b)
+	s := math.Cbrt(0.0883024619*r + 0.2817188376*g + 0.6299787005*b)
+
+	return OKLab{
+		L: 0.2104542553*l + 0.7936177850*m - 0.0040720468*s,
+		A: 1.9779984951*l - 2.4285922050*m + 0.4505937099*s,
+		B: 0.0259040371*l + 0.7827717662*m - 0.8086757660*s,
+	}
+}
+
+// ToColor converts OKLab to an sRGB Color, clamping out-of-gamut values.
+func (lab OKLab) ToColor() Color {
+	l := lab.L + 0.3963377774*lab.A + 0.2158037573*lab.B
+	m := lab.L - 0.1055613458*lab.A - 0.0638541728*lab.B
+	s := lab.L - 0.0894841775*lab.A - 1.2914855480*lab.B
+	l, m, s = l*l*l, m*m*m, s*s*s
+
+	r := 4.0767416621*l - 3.3077115913*m + 0.2309699292*s
+	g := -1.2684380046*l + 2.6097574011*m - 0.3413193965*s
+	b := -0.0041960863*l - 0.7034186147*m + 1.7076147010*s
+
+	return Color{
+		R: uint8(clamp(delinearize(r))*255.0 + 0.5),
+		G: uint8(clamp(delinearize(g))*255.0 + 0.5),
+		B: uint8(clamp(delinearize(b))*255.0 + 0.5),
+	}
+}
+
+// delinearize converts linear RGB to a gamma-corrected sRGB value.
+func delinearize(v float64) float64 {
+	if v <= 0.0031308 {
+		return 12.92 * v
+	}
+	return 1.055*math.Pow(v, 1/2.4) - 0.055
+}
+
+// MixOKLab blends two colors in OKLab space. t=0 returns a, t=1 returns b.
+func MixOKLab(a, b Color, t float64) Color {
+	if t <= 0 {
+		return a
+	}
+	if t >= 1 {
+		return b
+	}
+	la, lb := a.ToOKLab(), b.ToOKLab()
+	return OKLab{
+		L: la.L + (lb.L-la.L)*t,
+		A: la.A + (lb.A-la.A)*t,
+		B: la.B + (lb.B-la.B)*t,
+	}.ToColor()
+}
+
+// Interpolate blends two palettes at factor t in [0, 1], returning a new
+// palette. Each color is resolved in both palettes and blended in OKLab
+// space; colors defined in only one palette pass through unchanged. The
+// result keeps the raw/semantic/component layers but every entry is a
+// resolved hex value. t=0 reproduces a and t=1 reproduces b.
+func Interpolate(a, b *Palette, t float64) (*Palette, error) {
+	if a == nil || b == nil {
+		return nil, errors.New("interpolate: both palettes are required")
+	}
+	if math.IsNaN(t) || t < 0 || t > 1 {
+		return nil, fmt.Errorf("interpolate: factor %v must be between 0 and 1", t)
+	}
+
+	resolvedA, err := a.ResolveAll()
+	if err != nil {
+		return nil, fmt.Errorf("interpolate: %w", err)
+	}
+	resolvedB, err := b.ResolveAll()
+	if err != nil {
+		return nil, fmt.Errorf("interpolate: %w", err)
+	}
+
+	variant := a.Variant
+	if t > 0.5 {
+		variant = b.Variant
+	}
+	result := NewPalette(fmt.Sprintf("%s to %s (%.0f%%)", a.Name, b.Name, t*100), variant)
+	result.Description = fmt.Sprintf("Interpolated between %s and %s at %.2f", a.Name, b.Name, t)
+	result.Source = "generated"
+
+	layers := []struct {
+		dst, fromA, fromB map[string]string
+	}{
+		{result.Colors, a.Colors, b.Colors},
+		{result.Semantic, a.Semantic, b.Semantic},
+		{result.Components, a.Components, b.Components},
+	}
+	for _, layer := range layers {
+		for name := range layer.fromA {
+			layer.dst[name] = resolvedA[name]
+		}
+		for name := range layer.fromB {
+			if _, ok := result.lookup(name); !ok {
+				layer.dst[name] = resolvedB[name]
+			}
+		}
+	}
+
+	for _, layer := range layers {
+		for name := range layer.dst {
+			hexA, okA := resolvedA[name]
+			hexB, okB := resolvedB[name]
+			if !okA || !okB {
+				continue
+			}
+			mixed, err := mixHex(hexA, hexB, t)
+			if err != nil {
+				return nil, fmt.Errorf("interpolate %s: %w", name, err)
+			}
+			layer.dst[name] = mixed
+		}
+	}
+
+	return result, nil
+}
+
+// InterpolateSteps returns n palettes evenly spaced from a to b, inclusive
+// of both endpoints. n must be at least 2.
+func InterpolateSteps(a, b *Palette, n int) ([]*Palette, error) {
+	if n < 2 {
+		return nil, fmt.Errorf("interpolate: need at least 2 steps, got %d", n)
+	}
+
+	steps := make([]*Palette, n)
+	for i := range steps {
+		p, err := Interpolate(a, b, float64(i)/float64(n-1))
+		if err != nil {
+			return nil, err
+		}
+		steps[i] = p
+	}
+	return steps, nil
+}
+
+// lookup reports the value of name in any layer of the palette.
+func (p *Palette) lookup(name string) (string, bool) {
+	if v, ok := p.Colors[name]; ok {
+		return v, true
+	}
+	if v, ok := p.Semantic[name]; ok {
+		return v, true
+	}
+	v, ok := p.Components[name]
+	return v, ok
+}
+
+// mixHex blends two hex colors in OKLab space, returning the endpoints
+// verbatim at t=0 and t=1.
+func mixHex(hexA, hexB string, t float64) (string, error) {
+	switch t {
+	case 0:
+		return hexA, nil
+	case 1:
+		return hexB, nil
+	}
+	ca, err := ParseHexColor(hexA)
+	if err != nil {
+		return "", err
+	}
+	cb, err := ParseHexColor(hexB)
+	if err != nil {
+		return "", err
+	}
+	return MixOKLab(ca, cb, t).Hex(), nil
+}
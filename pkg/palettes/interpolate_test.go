@@ -0,0 +1,181 @@
+package palettes
+
+import (
+	"math"
+	"testing"
+)
+
+func interpolateTestPalettes() (a, b *Palette) {
+	a = &Palette{
+		Name:    "Winter",
+		Variant: VariantDark,
+		Colors: map[string]string{
+			"base":  "#000000",
+			"text":  "#ffffff",
+			"frost": "#88c0d0",
+		},
+		Semantic: map[string]string{
+			"bg-primary":   "base",
+			"text-primary": "text",
+		},
+		Components: map[string]string{
+			"code-bg": "base",
+		},
+	}
+	b = &Palette{
+		Name:    "Summer",
+		Variant: VariantLight,
+		Colors: map[string]string{
+			"base": "#ffffff",
+			"text": "#000000",
+			"sun":  "#f9e2af",
+		},
+		Semantic: map[string]string{
+			"bg-primary":   "base",
+			"text-primary": "text",
+			"accent":       "sun",
+		},
+	}
+	return a, b
+}
+
+func TestInterpolate_Endpoints(t *testing.T) {
+	a, b := interpolateTestPalettes()
+	wantA, _ := a.ResolveAll()
+	wantB, _ := b.ResolveAll()
+
+	for _, tc := range []struct {
+		t    float64
+		want map[string]string
+	}{
+		{0, wantA},
+		{1, wantB},
+	} {
+		p, err := Interpolate(a, b, tc.t)
+		if err != nil {
+			t.Fatalf("Interpolate(t=%v) error = %v", tc.t, err)
+		}
+		got, err := p.ResolveAll()
+		if err != nil {
+			t.Fatalf("ResolveAll() error = %v", err)
+		}
+		for name, hex := range tc.want {
+			if got[name] != hex {
+				t.Errorf("t=%v: %s = %q, want %q", tc.t, name, got[name], hex)
+			}
+		}
+	}
+}
+
+func TestInterpolate_Midpoint(t *testing.T) {
+	a, b := interpolateTestPalettes()
+
+	p, err := Interpolate(a, b, 0.5)
+	if err != nil {
+		t.Fatalf("Interpolate() error = %v", err)
+	}
+
+	for _, name := range []string{"base", "bg-primary", "text-primary"} {
+		mid, err := ParseHexColor(p.Resolve(name))
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		from, _ := ParseHexColor(a.Resolve(name))
+		to, _ := ParseHexColor(b.Resolve(name))
+
+		lMid, lFrom, lTo := mid.ToOKLab().L, from.ToOKLab().L, to.ToOKLab().L
+		if lMid <= math.Min(lFrom, lTo) || lMid >= math.Max(lFrom, lTo) {
+			t.Errorf("%s: lightness %.3f not between %.3f and %.3f", name, lMid, lFrom, lTo)
+		}
+		if diff := math.Abs(oklabDistance(mid, from) - oklabDistance(mid, to)); diff > 0.01 {
+			t.Errorf("%s: midpoint %s is not perceptually centered (distance diff %.3f)", name, mid.Hex(), diff)
+		}
+	}
+}
+
+func TestInterpolate_PassThrough(t *testing.T) {
+	a, b := interpolateTestPalettes()
+
+	p, err := Interpolate(a, b, 0.3)
+	if err != nil {
+		t.Fatalf("Interpolate() error = %v", err)
+	}
+
+	if got := p.Resolve("frost"); got != "#88c0d0" {
+		t.Errorf("frost (only in a) = %q, want unchanged", got)
+	}
+	if got := p.Resolve("accent"); got != "#f9e2af" {
+		t.Errorf("accent (only in b) = %q, want unchanged", got)
+	}
+	if got := p.Components["code-bg"]; got != "#000000" {
+		t.Errorf("code-bg (only in a) = %q, want unchanged", got)
+	}
+	if p.Variant != VariantDark {
+		t.Errorf("Variant = %q, want dark below t=0.5", p.Variant)
+	}
+}
+
+func TestInterpolate_Errors(t *testing.T) {
+	a, b := interpolateTestPalettes()
+
+	for _, factor := range []float64{-0.1, 1.1, math.NaN()} {
+		if _, err := Interpolate(a, b, factor); err == nil {
+			t.Errorf("Interpolate(t=%v) expected error", factor)
+		}
+	}
+	if _, err := Interpolate(nil, b, 0.5); err == nil {
+		t.Error("expected error for nil palette")
+	}
+
+	b.Semantic["link"] = "missing"
+	if _, err := Interpolate(a, b, 0.5); err == nil {
+		t.Error("expected error for unresolvable palette")
+	}
+}
+
+func TestInterpolateSteps(t *testing.T) {
+	a, b := interpolateTestPalettes()
+
+	steps, err := InterpolateSteps(a, b, 5)
+	if err != nil {
+		t.Fatalf("InterpolateSteps() error = %v", err)
+	}
+	if len(steps) != 5 {
+		t.Fatalf("got %d steps, want 5", len(steps))
+	}
+	if got := steps[0].Resolve("base"); got != "#000000" {
+		t.Errorf("first step base = %q, want a's color", got)
+	}
+	if got := steps[4].Resolve("base"); got != "#ffffff" {
+		t.Errorf("last step base = %q, want b's color", got)
+	}
+
+	// Lightness should increase monotonically from black to white
+	prev := -1.0
+	for i, step := range steps {
+		c, _ := ParseHexColor(step.Resolve("base"))
+		if l := c.ToOKLab().L; l <= prev {
+			t.Errorf("step %d lightness %.3f not above previous %.3f", i, l, prev)
+		} else {
+			prev = l
+		}
+	}
+
+	if _, err := InterpolateSteps(a, b, 1); err == nil {
+		t.Error("expected error for fewer than 2 steps")
+	}
+}
+
+func TestOKLab_RoundTrip(t *testing.T) {
+	for _, hex := range []string{"#000000", "#ffffff", "#ff0000", "#88c0d0", "#1e1e2e"} {
+		c, _ := ParseHexColor(hex)
+		if got := c.ToOKLab().ToColor().Hex(); got != hex {
+			t.Errorf("round trip %s = %s", hex, got)
+		}
+	}
+}
+
+func oklabDistance(x, y Color) float64 {
+	a, b := x.ToOKLab(), y.ToOKLab()
+	return math.Sqrt((a.L-b.L)*(a.L-b.L) + (a.A-b.A)*(a.A-b.A) + (a.B-b.B)*(a.B-b.B))
+}
@@ -1,6 +1,10 @@
 package palettes
 
-import "strings"
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
 
 // Variant represents the light/dark mode of a palette.
 type Variant string
@@ -122,13 +126,16 @@ func (p *Palette) resolveColor(name string, visited map[string]bool) (string, er
 	return "", NewColorResolutionError(name, p.Name, "color not found", ErrUnknownColor)
 }
 
-// ResolveAll resolves all colors and returns a map of name -> hex.
-// Returns an error if any color cannot be resolved.
+// ResolveAll resolves all colors and returns a map of name -> hex,
+// flattening semantic and component references to concrete hex values.
+// Returns an error naming the offending entry and reference if any color
+// cannot be resolved.
 func (p *Palette) ResolveAll() (map[string]string, error) {
 	result := make(map[string]string)
 
 	// Resolve all raw colors
-	for name, hex := range p.Colors {
+	for _, name := range sortedKeys(p.Colors) {
+		hex := p.Colors[name]
 		if !isHexColor(hex) {
 			return nil, NewColorResolutionError(name, p.Name, "raw color is not a hex value", ErrInvalidHexColor)
 		}
@@ -136,19 +143,19 @@ func (p *Palette) ResolveAll() (map[string]string, error) {
 	}
 
 	// Resolve all semantic colors
-	for name := range p.Semantic {
+	for _, name := range sortedKeys(p.Semantic) {
 		hex, err := p.resolveColor(name, make(map[string]bool))
 		if err != nil {
-			return nil, err
+			return nil, p.referenceError("semantic", name, p.Semantic[name], err)
 		}
 		result[name] = hex
 	}
 
 	// Resolve all component colors
-	for name := range p.Components {
+	for _, name := range sortedKeys(p.Components) {
 		hex, err := p.resolveColor(name, make(map[string]bool))
 		if err != nil {
-			return nil, err
+			return nil, p.referenceError("components", name, p.Components[name], err)
 		}
 		result[name] = hex
 	}
@@ -156,6 +163,21 @@ func (p *Palette) ResolveAll() (map[string]string, error) {
 	return result, nil
 }
 
+// referenceError wraps a resolution failure with the palette entry whose
+// reference could not be resolved, e.g. semantic.link references "blu".
+func (p *Palette) referenceError(layer, name, ref string, err error) error {
+	msg := err.Error()
+	var resErr *ColorResolutionError
+	if errors.As(err, &resErr) {
+		msg = resErr.Message
+		if errors.Is(err, ErrUnknownColor) {
+			msg = fmt.Sprintf("undefined color %q", resErr.Color)
+		}
+	}
+	return NewColorResolutionError(name, p.Name,
+		fmt.Sprintf("%s.%s references %q: %s", layer, name, ref, msg), err)
+}
+
 // Validate validates the palette structure and color references.
 func (p *Palette) Validate() []error {
 	var errs []error
@@ -247,6 +269,30 @@ func (p *Palette) Clone() *Palette {
 	return clone
 }
 
+// darkLuminanceThreshold is the background luminance below which white text
+// contrasts better than black text (the two ratios are equal at ~0.179).
+const darkLuminanceThreshold = 0.179
+
+// IsDark reports whether the palette is a dark theme, judged by the relative
+// luminance of its bg-primary color (or a raw "background" color). Palettes
+// without a parseable background fall back to their declared Variant. Use it
+// when a palette's variant field is missing or untrusted, such as when one
+// palette serves both modes.
+func (p *Palette) IsDark() bool {
+	for _, name := range []string{"bg-primary", "background"} {
+		hex := p.Resolve(name)
+		if hex == "" {
+			continue
+		}
+		bg, err := ParseHexColor(hex)
+		if err != nil {
+			continue
+		}
+		return bg.RelativeLuminance() < darkLuminanceThreshold
+	}
+	return p.Variant == VariantDark
+}
+
 // isHexColor checks if a string is a valid hex color.
 func isHexColor(s string) bool {
 	return hexColorRegex.MatchString(s)
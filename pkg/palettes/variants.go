@@ -1,6 +1,9 @@
 package palettes
 
-import "strings"
+import (
+	"slices"
+	"strings"
+)
 
 // PaletteVariants holds the light and dark variants for a palette.
 type PaletteVariants struct {
@@ -81,6 +84,65 @@ func detectVariantsWithLoader(name string, loader *Loader) PaletteVariants {
 	return result
 }
 
+// LoadPair loads the light and dark palettes for a base palette name using
+// the default loader. See Loader.LoadPair.
+func LoadPair(base string) (light, dark *Palette, err error) {
+	return DefaultLoader.LoadPair(base)
+}
+
+// LoadPair loads the light and dark palettes for a base palette name.
+//
+// It tries "<base>-light" and "<base>-dark" (or the known pair for families
+// such as catppuccin), so "everforest" and "everforest-dark" both load
+// everforest-light and everforest-dark. A side with no variant falls back to
+// the base palette itself, and then to the other side, so a single palette
+// such as "dracula" is returned for both modes; use Palette.IsDark to tell
+// which mode it suits. It returns ErrPaletteNotFound if neither side resolves.
+func (l *Loader) LoadPair(base string) (light, dark *Palette, err error) {
+	lightName := strings.TrimSuffix(strings.TrimSuffix(base, "-dark"), "-light") + "-light"
+	darkName := strings.TrimSuffix(lightName, "-light") + "-dark"
+	if known := getKnownVariants(base); known != nil {
+		lightName, darkName = known.Light, known.Dark
+	}
+
+	tried := make([]string, 0, 3)
+	load := func(name string) *Palette {
+		if name == "" || slices.Contains(tried, name) {
+			return nil
+		}
+		tried = append(tried, name)
+		p, loadErr := l.Load(name)
+		if loadErr != nil {
+			return nil
+		}
+		return p
+	}
+
+	light = load(lightName)
+	dark = load(darkName)
+	if light == nil || dark == nil {
+		if p := load(base); p != nil {
+			if light == nil {
+				light = p
+			}
+			if dark == nil {
+				dark = p.Clone()
+			}
+		}
+	}
+
+	switch {
+	case light == nil && dark == nil:
+		return nil, nil, NewPaletteLoadError(base, "",
+			"no light or dark variant found (tried "+strings.Join(tried, ", ")+")", ErrPaletteNotFound)
+	case light == nil:
+		light = dark.Clone()
+	case dark == nil:
+		dark = light.Clone()
+	}
+	return light, dark, nil
+}
+
 // extractBaseName extracts the base palette name without variant suffixes.
 func extractBaseName(name string) string {
 	// Common suffixes to strip
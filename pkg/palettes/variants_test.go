@@ -1,6 +1,10 @@
 package palettes
 
-import "testing"
+import (
+	"errors"
+	"strings"
+	"testing"
+)
 
 func TestExtractBaseName(t *testing.T) {
 	tests := []struct {
@@ -181,3 +185,80 @@ func TestPaletteVariantsStruct(t *testing.T) {
 		t.Errorf("Base = %q, want %q", v.Base, "test")
 	}
 }
+
+// newTestPalette returns a palette whose bg-primary is bg.
+func newTestPalette(name string, variant Variant, bg string) *Palette {
+	p := NewPalette(name, variant)
+	p.Colors["background"] = bg
+	p.Semantic["bg-primary"] = "background"
+	return p
+}
+
+func TestLoader_LoadPair(t *testing.T) {
+	loader := NewLoaderWithPaths(nil)
+	loader.AddPalette("testpair-light", newTestPalette("testpair-light", VariantLight, "#fafafa"))
+	loader.AddPalette("testpair-dark", newTestPalette("testpair-dark", VariantDark, "#1e1e1e"))
+	loader.AddPalette("testsolo", newTestPalette("testsolo", VariantDark, "#101010"))
+	loader.AddPalette("testhalf-dark", newTestPalette("testhalf-dark", VariantDark, "#202020"))
+
+	tests := []struct {
+		name      string
+		base      string
+		wantLight string
+		wantDark  string
+	}{
+		{"full pair", "testpair", "testpair-light", "testpair-dark"},
+		{"variant name", "testpair-dark", "testpair-light", "testpair-dark"},
+		{"base only", "testsolo", "testsolo", "testsolo"},
+		{"one variant", "testhalf", "testhalf-dark", "testhalf-dark"},
+		{"known family", "catppuccin-mocha", "Catppuccin Latte", "Catppuccin Mocha"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			light, dark, err := loader.LoadPair(tt.base)
+			if err != nil {
+				t.Fatalf("LoadPair(%q) error: %v", tt.base, err)
+			}
+			if light.Name != tt.wantLight || dark.Name != tt.wantDark {
+				t.Errorf("LoadPair(%q) = (%s, %s), want (%s, %s)", tt.base, light.Name, dark.Name, tt.wantLight, tt.wantDark)
+			}
+		})
+	}
+}
+
+func TestLoader_LoadPair_NotFound(t *testing.T) {
+	loader := NewLoaderWithPaths(nil)
+
+	_, _, err := loader.LoadPair("no-such-palette")
+	if !errors.Is(err, ErrPaletteNotFound) {
+		t.Fatalf("LoadPair() error = %v, want ErrPaletteNotFound", err)
+	}
+	for _, name := range []string{"no-such-palette-light", "no-such-palette-dark"} {
+		if !strings.Contains(err.Error(), name) {
+			t.Errorf("error %q should list tried name %q", err, name)
+		}
+	}
+}
+
+func TestPalette_IsDark(t *testing.T) {
+	tests := []struct {
+		name    string
+		palette *Palette
+		want    bool
+	}{
+		{"dark background", newTestPalette("a", VariantLight, "#1e1e2e"), true},
+		{"light background", newTestPalette("b", VariantDark, "#eff1f5"), false},
+		{"mid gray is light", newTestPalette("c", VariantDark, "#808080"), false},
+		{"no background uses variant", NewPalette("d", VariantDark), true},
+		{"no background light variant", NewPalette("e", VariantLight), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.palette.IsDark(); got != tt.want {
+				t.Errorf("IsDark() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
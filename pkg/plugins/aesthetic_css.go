@@ -5,7 +5,6 @@ import (
 	"crypto/sha256"
 	"encoding/json"
 	"fmt"
-	"log"
 	"os"
 	"path/filepath"
 	"sort"
@@ -56,7 +55,7 @@ func (p *AestheticCSSPlugin) Configure(m *lifecycle.Manager) error {
 	m.SetAssetHash("css/aesthetic.css", hash)
 	templates.SetAssetHashes(map[string]string{"css/aesthetic.css": hash})
 
-	log.Printf("[aesthetic_css] Registered hash %s for aesthetic.css", hash)
+	m.Logger().Debug("registered asset hash", "asset", "css/aesthetic.css", "hash", hash)
 
 	return nil
 }
@@ -76,7 +75,7 @@ func (p *AestheticCSSPlugin) Write(m *lifecycle.Manager) error {
 		return nil
 	}
 
-	log.Printf("[aesthetic_css] Generating CSS for aesthetic: %s", aestheticName)
+	m.Logger().Debug("generating aesthetic CSS", "aesthetic", aestheticName)
 
 	switcherEnabled := p.isSwitcherEnabled(config.Extra)
 	loader := aesthetic.NewLoader()
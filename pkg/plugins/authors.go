@@ -2,8 +2,6 @@
 package plugins
 
 import (
-	"log"
-
 	"github.com/WaylonWalker/markata-go/pkg/lifecycle"
 	"github.com/WaylonWalker/markata-go/pkg/models"
 )
@@ -108,7 +106,7 @@ func (p *AuthorsPlugin) Transform(m *lifecycle.Manager) error {
 
 				objects = append(objects, author)
 			} else {
-				log.Printf("[authors] Warning: unknown author ID %q in post %s", id, post.Path)
+				m.Logger().Warn("unknown author ID", "author", id, "post", post.Path)
 			}
 		}
 
@@ -119,8 +117,7 @@ func (p *AuthorsPlugin) Transform(m *lifecycle.Manager) error {
 	}
 
 	if resolved > 0 || defaulted > 0 {
-		log.Printf("[authors] Resolved authors for %d posts, assigned default author to %d posts",
-			resolved, defaulted)
+		m.Logger().Info("resolved authors", "resolved", resolved, "defaulted", defaulted)
 	}
 
 	return nil
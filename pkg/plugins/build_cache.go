@@ -8,11 +8,8 @@ import (
 
 	"github.com/WaylonWalker/markata-go/pkg/buildcache"
 	"github.com/WaylonWalker/markata-go/pkg/lifecycle"
-	"github.com/WaylonWalker/markata-go/pkg/logging"
 )
 
-var buildCacheLog = logging.Component("build_cache")
-
 // BuildCachePlugin manages incremental build caching.
 // It loads the build cache at the start and saves it at the end.
 // Other plugins can use the cache to skip unchanged posts.
@@ -53,20 +50,14 @@ func (p *BuildCachePlugin) Configure(m *lifecycle.Manager) error {
 	config := m.Config()
 
 	// Check if caching is disabled
-	if !p.isEnabled(config) {
+	if !m.BuildCacheEnabled() {
 		p.enabled = false
 		return nil
 	}
 
-	// Load existing cache. Default to content-dir-local .markata so incremental
+	// Load existing cache. Defaults to content-dir-local .markata so incremental
 	// build state survives when output is redirected to a temp/workspace path.
-	cacheDir := filepath.Join(config.ContentDir, ".markata")
-	if config.Extra != nil {
-		if dir, ok := config.Extra["cache_dir"].(string); ok && dir != "" {
-			cacheDir = dir
-		}
-	}
-
+	cacheDir := m.BuildCacheDir()
 	cache, err := buildcache.Load(cacheDir)
 	if err != nil {
 		// Non-fatal: start with empty cache
@@ -90,7 +81,7 @@ func (p *BuildCachePlugin) Configure(m *lifecycle.Manager) error {
 	configHash := buildcache.ContentHash(configHashInput(config, configFiles))
 	if configHash != "" && cache.SetConfigHash(configHash) {
 		// Config changed - cache was invalidated
-		buildCacheLog.Phase("configure").Printf("Config changed, full rebuild required")
+		m.Logger().Info("config changed, full rebuild required")
 	}
 
 	// Compute and check templates hash
@@ -101,7 +92,7 @@ func (p *BuildCachePlugin) Configure(m *lifecycle.Manager) error {
 	if hash, err := buildcache.HashDirectory(templatesDir, []string{".html", ".txt", ".md"}); err == nil && hash != "" {
 		if cache.SetTemplatesHash(hash) {
 			// Templates changed - cache was invalidated
-			buildCacheLog.Phase("configure").Printf("Templates changed, full rebuild required")
+			m.Logger().Info("templates changed, full rebuild required")
 		}
 	}
 
@@ -117,21 +108,6 @@ func (p *BuildCachePlugin) Configure(m *lifecycle.Manager) error {
 	return p.configureIncrementalServe(m, cache)
 }
 
-func (p *BuildCachePlugin) isEnabled(config *lifecycle.Config) bool {
-	if config == nil || config.Extra == nil {
-		return true
-	}
-	cacheConfig, ok := config.Extra["build_cache"].(map[string]interface{})
-	if !ok {
-		return true
-	}
-	enabled, ok := cacheConfig["enabled"].(bool)
-	if !ok {
-		return true
-	}
-	return enabled
-}
-
 func configFilesHash(paths []string) string {
 	if len(paths) == 0 {
 		return ""
@@ -212,19 +188,20 @@ func (p *BuildCachePlugin) Cleanup(m *lifecycle.Manager) error {
 
 	if extra := m.Config().Extra; extra != nil {
 		if async, ok := extra["cache_cleanup_async"].(bool); ok && async {
+			logger := m.Logger()
 			go func() {
-				if err := p.cleanupCache(m); err != nil {
-					buildCacheLog.Phase("cleanup").Errorf("async cleanup failed: %v", err)
+				if err := p.cleanupCache(m, logger); err != nil {
+					logger.Error("async cleanup failed", "error", err)
 				}
 			}()
 			return nil
 		}
 	}
 
-	return p.cleanupCache(m)
+	return p.cleanupCache(m, m.Logger())
 }
 
-func (p *BuildCachePlugin) cleanupCache(m *lifecycle.Manager) error {
+func (p *BuildCachePlugin) cleanupCache(m *lifecycle.Manager, logger lifecycle.Logger) error {
 	if p.cache == nil {
 		return nil
 	}
@@ -232,7 +209,7 @@ func (p *BuildCachePlugin) cleanupCache(m *lifecycle.Manager) error {
 	// Remove stale entries (posts that no longer exist)
 	if config := m.Config(); config.Extra != nil {
 		if fast, ok := config.Extra["fast_mode"].(bool); ok && fast {
-			return p.saveCache(m)
+			return p.saveCache(m, logger)
 		}
 	}
 	posts := m.Posts()
@@ -242,32 +219,32 @@ func (p *BuildCachePlugin) cleanupCache(m *lifecycle.Manager) error {
 	}
 	removed := p.cache.RemoveStale(currentPaths)
 	if removed > 0 {
-		buildCacheLog.Phase("cleanup").Printf("Removed %d stale cache entries", removed)
+		logger.Info("removed stale cache entries", "entries", removed)
 	}
 	removedMermaid, err := p.cache.CleanupMermaidSVG()
 	if err != nil {
 		return err
 	}
 	if removedMermaid > 0 {
-		buildCacheLog.Phase("cleanup").Printf("Removed %d stale Mermaid SVG cache entries", removedMermaid)
+		logger.Info("removed stale Mermaid SVG cache entries", "entries", removedMermaid)
 	}
 
 	// Save cache
-	return p.saveCache(m)
+	return p.saveCache(m, logger)
 }
 
-func (p *BuildCachePlugin) saveCache(m *lifecycle.Manager) error {
+func (p *BuildCachePlugin) saveCache(m *lifecycle.Manager, logger lifecycle.Logger) error {
 	if p.cache == nil {
 		return nil
 	}
 	if err := p.cache.Save(); err != nil {
-		buildCacheLog.Phase("cleanup").Errorf("failed to save build cache: %v", err)
+		logger.Error("failed to save build cache", "error", err)
 	}
 
 	// Log stats
 	skipped, rebuilt := p.cache.Stats()
 	if skipped > 0 || rebuilt > 0 {
-		buildCacheLog.Phase("cleanup").Printf("Incremental build: %d skipped, %d rebuilt", skipped, rebuilt)
+		logger.Info("incremental build", "skipped", skipped, "rebuilt", rebuilt)
 		m.Cache().Set("build_cache_skipped", skipped)
 		m.Cache().Set("build_cache_rebuilt", rebuilt)
 	}
@@ -275,7 +252,7 @@ func (p *BuildCachePlugin) saveCache(m *lifecycle.Manager) error {
 	// Log dependency graph stats
 	graphSize := p.cache.GraphSize()
 	if graphSize > 0 {
-		buildCacheLog.Phase("cleanup").Printf("Dependency graph: %d posts with dependencies tracked", graphSize)
+		logger.Info("dependency graph", "posts", graphSize)
 	}
 
 	return nil
@@ -302,7 +279,7 @@ func (p *BuildCachePlugin) Transform(m *lifecycle.Manager) error {
 	}
 
 	if depsRecorded > 0 {
-		buildCacheLog.Phase("transform").Printf("Recorded dependencies for %d posts", depsRecorded)
+		m.Logger().Info("recorded dependencies", "posts", depsRecorded)
 	}
 
 	return nil
@@ -8,6 +8,7 @@ import (
 
 	"github.com/WaylonWalker/markata-go/pkg/buildcache"
 	"github.com/WaylonWalker/markata-go/pkg/lifecycle"
+	"github.com/WaylonWalker/markata-go/pkg/models"
 )
 
 func TestConfigFilesHash_ChangesWhenOverlayChanges(t *testing.T) {
@@ -151,3 +152,97 @@ func TestBuildCacheConfigure_DefaultsCacheDirToContentDir(t *testing.T) {
 func cachePathForTest(c *buildcache.Cache) string {
 	return filepath.Clean(reflect.ValueOf(c).Elem().FieldByName("path").String())
 }
+
+func TestBuildCacheConfigure_ManagerOptions(t *testing.T) {
+	cacheDir := filepath.Join(t.TempDir(), "custom-cache")
+
+	manager := lifecycle.NewManager()
+	manager.Config().ContentDir = t.TempDir()
+	manager.SetBuildCacheDir(cacheDir)
+
+	if err := NewBuildCachePlugin().Configure(manager); err != nil {
+		t.Fatalf("Configure() error = %v", err)
+	}
+	cache := GetBuildCache(manager)
+	if cache == nil {
+		t.Fatal("expected build cache to be stored on manager")
+	}
+	if got, want := cachePathForTest(cache), filepath.Join(cacheDir, buildcache.CacheFileName); got != want {
+		t.Errorf("cache path = %q, want %q", got, want)
+	}
+
+	disabled := lifecycle.NewManager()
+	disabled.SetBuildCacheEnabled(false)
+	if err := NewBuildCachePlugin().Configure(disabled); err != nil {
+		t.Fatalf("Configure() error = %v", err)
+	}
+	if GetBuildCache(disabled) != nil {
+		t.Error("SetBuildCacheEnabled(false) should leave the build cache unset")
+	}
+}
+
+func TestRenderMarkdown_ArticleCacheKey(t *testing.T) {
+	const sentinel = "<p>from cache</p>"
+	const content = "He said \"hi\" -- then left."
+
+	// render configures a fresh plugin with extra, renders a post with the
+	// given content against cache, and returns its ArticleHTML.
+	render := func(t *testing.T, cache *buildcache.Cache, extra map[string]interface{}, content string) (string, *RenderMarkdownPlugin) {
+		t.Helper()
+		m := lifecycle.NewManager()
+		m.Config().Extra = extra
+		m.Cache().Set("build_cache", cache)
+
+		p := NewRenderMarkdownPlugin()
+		if err := p.Configure(m); err != nil {
+			t.Fatalf("Configure() error = %v", err)
+		}
+		post := models.NewPost("posts/hello.md")
+		post.Content = content
+		m.SetPosts([]*models.Post{post})
+		if err := p.Render(m); err != nil {
+			t.Fatalf("Render() error = %v", err)
+		}
+		return post.ArticleHTML, p
+	}
+
+	noTypographer := map[string]interface{}{
+		"markdown": map[string]interface{}{
+			"extensions": map[string]interface{}{"typographer": false},
+		},
+	}
+	otherTheme := map[string]interface{}{
+		"markdown": map[string]interface{}{
+			"highlight": map[string]interface{}{"theme": "dracula"},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		extra    map[string]interface{}
+		content  string
+		wantHits bool
+	}{
+		{"unchanged content and config", map[string]interface{}{}, content, true},
+		{"changed content", map[string]interface{}{}, content + " Bye.", false},
+		{"changed markdown extension", noTypographer, content, false},
+		{"changed highlight theme", otherTheme, content, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cache := buildcache.New(t.TempDir())
+
+			// Seed the cache with a sentinel under the default settings' key
+			_, seeded := render(t, cache, map[string]interface{}{}, content)
+			if err := cache.CacheArticleHTML("posts/hello.md", seeded.articleHash(content), sentinel); err != nil {
+				t.Fatalf("CacheArticleHTML() error = %v", err)
+			}
+
+			got, _ := render(t, cache, tt.extra, tt.content)
+			if hit := got == sentinel; hit != tt.wantHits {
+				t.Errorf("cache hit = %v, want %v (ArticleHTML %q)", hit, tt.wantHits, got)
+			}
+		})
+	}
+}
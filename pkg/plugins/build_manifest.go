@@ -0,0 +1,117 @@
+package plugins
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/WaylonWalker/markata-go/pkg/lifecycle"
+	"github.com/WaylonWalker/markata-go/pkg/models"
+)
+
+// BuildManifestPlugin writes manifest.json to the output directory, listing
+// every output file with its size, SHA-256 hash, and the post or feed it was
+// generated from. Deploy tooling can compare manifests between builds to
+// upload only changed files or purge only changed URLs.
+//
+// Although the manifest describes written output, it runs in the Cleanup
+// stage after every other plugin, since cleanup plugins such as css_purge,
+// js_minify, and pagefind still rewrite or add files after the Write stage.
+// The entries are also available through Manager.Manifest.
+//
+// The manifest is opt-in through [markata-go.build_manifest] enabled = true
+// and is skipped in fast mode and while serving, where deploys don't happen.
+type BuildManifestPlugin struct{}
+
+// NewBuildManifestPlugin creates a new BuildManifestPlugin.
+func NewBuildManifestPlugin() *BuildManifestPlugin {
+	return &BuildManifestPlugin{}
+}
+
+// Name returns the unique name of the plugin.
+func (p *BuildManifestPlugin) Name() string {
+	return "build_manifest"
+}
+
+// Priority returns the plugin priority for the given stage.
+// The manifest is built after pagefind, which runs at PriorityLast.
+func (p *BuildManifestPlugin) Priority(stage lifecycle.Stage) int {
+	if stage == lifecycle.StageCleanup {
+		return lifecycle.PriorityLast + 100
+	}
+	return lifecycle.PriorityDefault
+}
+
+// Cleanup hashes the output directory and writes the manifest.
+func (p *BuildManifestPlugin) Cleanup(m *lifecycle.Manager) error {
+	config := m.Config()
+	if !buildManifestEnabled(config.Extra) {
+		return nil
+	}
+
+	// Skip in fast mode and serve mode - the manifest is only useful to deploys
+	if fast, ok := config.Extra["fast_mode"].(bool); ok && fast {
+		return nil
+	}
+	if lifecycle.IsServeMode(m) {
+		return nil
+	}
+
+	outputDir := config.OutputDir
+
+	var postSlugs []string
+	for _, post := range m.Posts() {
+		if post.Skip || post.Draft {
+			continue
+		}
+		postSlugs = append(postSlugs, post.Slug)
+	}
+
+	var feedSlugs []string
+	if cached, ok := m.Cache().Get("feed_configs"); ok {
+		if feedConfigs, ok := cached.([]models.FeedConfig); ok {
+			for i := range feedConfigs {
+				feedSlugs = append(feedSlugs, feedConfigs[i].Slug)
+			}
+		}
+	}
+
+	entries, err := lifecycle.BuildManifest(outputDir, postSlugs, feedSlugs)
+	if err != nil {
+		return fmt.Errorf("building manifest: %w", err)
+	}
+	if entries == nil {
+		entries = []lifecycle.ManifestEntry{}
+	}
+
+	data, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding manifest: %w", err)
+	}
+	manifestPath := filepath.Join(outputDir, lifecycle.ManifestFileName)
+	if err := os.WriteFile(manifestPath, append(data, '\n'), 0o644); err != nil { //nolint:gosec // G306: Public-facing output needs 644 permissions
+		return fmt.Errorf("writing %s: %w", manifestPath, err)
+	}
+
+	m.SetManifest(entries)
+	return nil
+}
+
+// buildManifestEnabled reports whether [markata-go.build_manifest] has
+// enabled = true. The manifest is disabled by default.
+func buildManifestEnabled(extra map[string]interface{}) bool {
+	raw, ok := extra["build_manifest"].(map[string]interface{})
+	if !ok {
+		return false
+	}
+	enabled, ok := raw["enabled"].(bool)
+	return ok && enabled
+}
+
+// Ensure BuildManifestPlugin implements the required interfaces.
+var (
+	_ lifecycle.Plugin         = (*BuildManifestPlugin)(nil)
+	_ lifecycle.CleanupPlugin  = (*BuildManifestPlugin)(nil)
+	_ lifecycle.PriorityPlugin = (*BuildManifestPlugin)(nil)
+)
@@ -0,0 +1,233 @@
+package plugins
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/WaylonWalker/markata-go/pkg/lifecycle"
+	"github.com/WaylonWalker/markata-go/pkg/models"
+)
+
+func TestBuildManifestPlugin_Entries(t *testing.T) {
+	outputDir := t.TempDir()
+	files := map[string]string{
+		"blog/hello/index.html":  "<h1>Hello</h1>",
+		"blog/index.html":        "<ul>feed</ul>",
+		"blog/rss.xml":           "<rss/>",
+		"blog/page/2/index.html": "<ul>page 2</ul>",
+		"css/main.css":           "body{}",
+		"index.html":             "<h1>Home</h1>",
+		"rss.xml":                "<rss/>",
+		"robots.txt":             "User-agent: *",
+		"draft/index.html":       "stale",
+	}
+	for rel, content := range files {
+		path := filepath.Join(outputDir, filepath.FromSlash(rel))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	m := newBuildManifestManager(outputDir)
+	m.SetPosts([]*models.Post{
+		{Slug: "blog/hello"},
+		{Slug: ""},
+		{Slug: "draft", Draft: true},
+	})
+	m.Cache().Set("feed_configs", []models.FeedConfig{{Slug: "blog"}, {Slug: ""}})
+
+	if err := NewBuildManifestPlugin().Cleanup(m); err != nil {
+		t.Fatalf("Cleanup() error = %v", err)
+	}
+
+	entries := m.Manifest()
+	if len(entries) != len(files) {
+		t.Fatalf("Manifest() has %d entries, want %d: %+v", len(entries), len(files), entries)
+	}
+	byPath := make(map[string]lifecycle.ManifestEntry, len(entries))
+	for _, e := range entries {
+		byPath[e.Path] = e
+	}
+
+	tests := []struct {
+		path string
+		post string
+		feed string
+	}{
+		{"blog/hello/index.html", "blog/hello", ""},
+		{"blog/index.html", "", "blog"},
+		{"blog/rss.xml", "", "blog"},
+		{"blog/page/2/index.html", "", "blog"},
+		{"css/main.css", "", ""},
+		{"index.html", "/", ""},
+		{"rss.xml", "", "/"},
+		{"robots.txt", "", ""},
+		{"draft/index.html", "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			e, ok := byPath[tt.path]
+			if !ok {
+				t.Fatalf("no manifest entry for %s", tt.path)
+			}
+			content := files[tt.path]
+			sum := sha256.Sum256([]byte(content))
+			if e.Hash != hex.EncodeToString(sum[:]) {
+				t.Errorf("Hash = %s, want sha256 of %q", e.Hash, content)
+			}
+			if e.Size != int64(len(content)) {
+				t.Errorf("Size = %d, want %d", e.Size, len(content))
+			}
+			if e.Post != tt.post || e.Feed != tt.feed {
+				t.Errorf("source = (post %q, feed %q), want (post %q, feed %q)", e.Post, e.Feed, tt.post, tt.feed)
+			}
+		})
+	}
+
+	data, err := os.ReadFile(filepath.Join(outputDir, lifecycle.ManifestFileName))
+	if err != nil {
+		t.Fatalf("reading manifest.json: %v", err)
+	}
+	var written []lifecycle.ManifestEntry
+	if err := json.Unmarshal(data, &written); err != nil {
+		t.Fatalf("manifest.json is not valid JSON: %v", err)
+	}
+	if len(written) != len(entries) {
+		t.Errorf("manifest.json has %d entries, want %d", len(written), len(entries))
+	}
+	for i := 1; i < len(written); i++ {
+		if written[i-1].Path >= written[i].Path {
+			t.Errorf("manifest.json not sorted: %s before %s", written[i-1].Path, written[i].Path)
+		}
+	}
+}
+
+func TestBuildManifestPlugin_RebuildSkipsOwnManifest(t *testing.T) {
+	outputDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(outputDir, "index.html"), []byte("home"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	m := newBuildManifestManager(outputDir)
+	plugin := NewBuildManifestPlugin()
+	for i := 0; i < 2; i++ {
+		if err := plugin.Cleanup(m); err != nil {
+			t.Fatalf("Cleanup() error = %v", err)
+		}
+	}
+
+	entries := m.Manifest()
+	if len(entries) != 1 || entries[0].Path != "index.html" {
+		t.Errorf("Manifest() = %+v, want only index.html", entries)
+	}
+}
+
+func TestBuildManifestPlugin_Skipped(t *testing.T) {
+	tests := []struct {
+		name  string
+		setup func(m *lifecycle.Manager)
+	}{
+		{"disabled by default", func(m *lifecycle.Manager) { delete(m.Config().Extra, "build_manifest") }},
+		{"fast mode", func(m *lifecycle.Manager) { m.Config().Extra["fast_mode"] = true }},
+		{"serve mode", func(m *lifecycle.Manager) { lifecycle.SetServeMode(m, true) }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			outputDir := t.TempDir()
+			if err := os.WriteFile(filepath.Join(outputDir, "index.html"), []byte("home"), 0o600); err != nil {
+				t.Fatal(err)
+			}
+			m := newBuildManifestManager(outputDir)
+			tt.setup(m)
+
+			if err := NewBuildManifestPlugin().Cleanup(m); err != nil {
+				t.Fatalf("Cleanup() error = %v", err)
+			}
+			if entries := m.Manifest(); entries != nil {
+				t.Errorf("Manifest() = %+v, want nil", entries)
+			}
+			if _, err := os.Stat(filepath.Join(outputDir, lifecycle.ManifestFileName)); !os.IsNotExist(err) {
+				t.Errorf("manifest.json written, want none (stat error = %v)", err)
+			}
+		})
+	}
+}
+
+func TestBuildManifestPlugin_ReusesUnchangedHashes(t *testing.T) {
+	outputDir := t.TempDir()
+	old := time.Now().Add(-time.Hour)
+	for _, rel := range []string{"kept.html", "rewritten.html"} {
+		path := filepath.Join(outputDir, rel)
+		if err := os.WriteFile(path, []byte("v1"), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chtimes(path, old, old); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// A previous manifest with stale hashes shows which entries are reused.
+	previous := []lifecycle.ManifestEntry{
+		{Path: "kept.html", Size: 2, Hash: "previous"},
+		{Path: "rewritten.html", Size: 2, Hash: "previous"},
+	}
+	data, err := json.Marshal(previous)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(outputDir, lifecycle.ManifestFileName), data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	// rewritten.html is written after the previous manifest, like a rebuilt page.
+	if err := os.WriteFile(filepath.Join(outputDir, "rewritten.html"), []byte("v2"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	future := time.Now().Add(time.Hour)
+	if err := os.Chtimes(filepath.Join(outputDir, "rewritten.html"), future, future); err != nil {
+		t.Fatal(err)
+	}
+
+	m := newBuildManifestManager(outputDir)
+	if err := NewBuildManifestPlugin().Cleanup(m); err != nil {
+		t.Fatalf("Cleanup() error = %v", err)
+	}
+
+	hashes := make(map[string]string)
+	for _, e := range m.Manifest() {
+		hashes[e.Path] = e.Hash
+	}
+	if hashes["kept.html"] != "previous" {
+		t.Errorf("kept.html hash = %q, want reused %q", hashes["kept.html"], "previous")
+	}
+	sum := sha256.Sum256([]byte("v2"))
+	if want := hex.EncodeToString(sum[:]); hashes["rewritten.html"] != want {
+		t.Errorf("rewritten.html hash = %q, want %q", hashes["rewritten.html"], want)
+	}
+}
+
+func TestBuildManifestPlugin_Priority(t *testing.T) {
+	plugin := NewBuildManifestPlugin()
+	if got := plugin.Priority(lifecycle.StageCleanup); got <= NewPagefindPlugin().Priority(lifecycle.StageCleanup) {
+		t.Errorf("Priority(StageCleanup) = %d, want after pagefind", got)
+	}
+}
+
+// newBuildManifestManager returns a manager writing to outputDir with the
+// build manifest enabled.
+func newBuildManifestManager(outputDir string) *lifecycle.Manager {
+	m := lifecycle.NewManager()
+	m.Config().OutputDir = outputDir
+	m.Config().Extra = map[string]interface{}{
+		"build_manifest": map[string]interface{}{"enabled": true},
+	}
+	return m
+}
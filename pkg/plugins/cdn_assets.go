@@ -3,11 +3,13 @@ package plugins
 import (
 	"context"
 	"fmt"
-	"log"
+	"io/fs"
+	"os"
 	"path/filepath"
 	"strings"
 
 	"github.com/WaylonWalker/markata-go/pkg/assets"
+	"github.com/WaylonWalker/markata-go/pkg/config"
 	"github.com/WaylonWalker/markata-go/pkg/lifecycle"
 	"github.com/WaylonWalker/markata-go/pkg/models"
 	"github.com/WaylonWalker/markata-go/pkg/runtimeenv"
@@ -18,6 +20,7 @@ import (
 // 1. Downloads CDN assets during Configure stage
 // 2. Copies them to output during Write stage
 // 3. Provides URL mappings for templates to use local paths
+// 4. Adds integrity attributes to local asset tags during Cleanup stage
 type CDNAssetsPlugin struct{}
 
 // NewCDNAssetsPlugin creates a new CDNAssetsPlugin.
@@ -36,25 +39,31 @@ func (p *CDNAssetsPlugin) Configure(m *lifecycle.Manager) error {
 	assetsConfig := p.getAssetsConfig(config)
 	requestedAssets := p.requestedAssets(config)
 
+	// Apply custom assets and version pins before any plugin reads the registry
+	if err := assets.Configure(assetsConfig); err != nil {
+		return fmt.Errorf("cdn_assets: %w", err)
+	}
+
 	// Skip if not self-hosting
-	if !assetsConfig.IsSelfHosted() && len(requestedAssets) == 0 {
+	mode := assets.ResolveMode(assetsConfig, assetsEnv(m))
+	if mode != assets.ModeSelfHosted && len(requestedAssets) == 0 {
 		return nil
 	}
 
-	log.Printf("[cdn_assets] Self-hosting enabled (mode: %s)", assetsConfig.Mode)
+	if mode == assets.ModeSelfHosted {
+		m.Logger().Info("self-hosting enabled", "mode", assetsConfig.Mode)
+	}
 
 	// Create downloader
-	cacheDir := assetsConfig.GetCacheDir()
-	verifyIntegrity := assetsConfig.IsVerifyIntegrityEnabled()
-	downloader := assets.NewDownloader(cacheDir, verifyIntegrity)
+	downloader := assets.NewDownloaderFromConfig(assetsConfig)
 	assetsToDownload := requestedAssets
-	if assetsConfig.IsSelfHosted() {
+	if mode == assets.ModeSelfHosted {
 		assetsToDownload = mergeRequestedAssets(assets.Registry(), requestedAssets)
 	}
 
 	// Download all assets
 	ctx := context.Background()
-	results := downloader.DownloadAssets(ctx, assetsToDownload, 4)
+	results := downloader.DownloadAssets(ctx, assetsToDownload, 0)
 
 	// Check for errors and log them (but don't fail - we can still use CDN fallback)
 	var successCount, cachedCount, errorCount int
@@ -63,7 +72,7 @@ func (p *CDNAssetsPlugin) Configure(m *lifecycle.Manager) error {
 		result := &results[i]
 		switch {
 		case result.Error != nil:
-			log.Printf("[cdn_assets] Warning: failed to download %s: %v", result.Asset.Name, result.Error)
+			m.Logger().Warn("failed to download asset", "asset", result.Asset.Name, "error", result.Error)
 			errorCount++
 			missingAssets = append(missingAssets, result.Asset.Name)
 		case result.Cached:
@@ -72,9 +81,12 @@ func (p *CDNAssetsPlugin) Configure(m *lifecycle.Manager) error {
 			successCount++
 		}
 	}
-	log.Printf("[cdn_assets] Download complete: %d downloaded, %d cached, %d errors",
-		successCount, cachedCount, errorCount)
-	if errorCount > 0 && runtimeenv.OfflineEnabled() {
+	m.Logger().Info("download complete", "downloaded", successCount, "cached", cachedCount, "errors", errorCount)
+	switch {
+	case errorCount > 0 && assetsConfig.Mode == assets.ModeAuto:
+		// auto prefers a working build over self-hosting everything
+		m.Logger().Warn("assets not cached, falling back to CDN", "assets", strings.Join(missingAssets, ", "))
+	case errorCount > 0 && runtimeenv.OfflineEnabled():
 		return fmt.Errorf("offline mode missing required CDN assets: %s", strings.Join(missingAssets, ", "))
 	}
 
@@ -102,7 +114,8 @@ func (p *CDNAssetsPlugin) Write(m *lifecycle.Manager) error {
 	requestedAssets := p.requestedAssets(config)
 
 	// Skip if not self-hosting
-	if !assetsConfig.IsSelfHosted() && len(requestedAssets) == 0 {
+	mode := assets.ResolveMode(assetsConfig, assetsEnv(m))
+	if mode != assets.ModeSelfHosted && len(requestedAssets) == 0 {
 		return nil
 	}
 
@@ -113,7 +126,7 @@ func (p *CDNAssetsPlugin) Write(m *lifecycle.Manager) error {
 	// Determine output directory for vendor assets
 	vendorOutputDir := filepath.Join(config.OutputDir, assetsConfig.GetOutputDir())
 	assetsToCopy := requestedAssets
-	if assetsConfig.IsSelfHosted() {
+	if mode == assets.ModeSelfHosted {
 		assetsToCopy = mergeRequestedAssets(assets.Registry(), requestedAssets)
 	}
 
@@ -122,11 +135,56 @@ func (p *CDNAssetsPlugin) Write(m *lifecycle.Manager) error {
 		return fmt.Errorf("copying assets to output: %w", err)
 	}
 
-	log.Printf("[cdn_assets] Copied assets to %s", vendorOutputDir)
+	m.Logger().Debug("copied assets", "dir", vendorOutputDir)
 
 	return nil
 }
 
+// Cleanup adds integrity and crossorigin attributes to the <script> and
+// <link> tags that reference self-hosted assets. It runs after the write
+// stage so hashes reflect the files as served, including any minification.
+func (p *CDNAssetsPlugin) Cleanup(m *lifecycle.Manager) error {
+	config := m.Config()
+	if config.Extra == nil {
+		return nil
+	}
+	urlMappings, ok := config.Extra["asset_urls"].(map[string]string)
+	if !ok || len(urlMappings) == 0 {
+		return nil
+	}
+
+	assetsConfig := p.getAssetsConfig(config)
+	assets.SetIntegrityDir(filepath.Join(config.OutputDir, assetsConfig.GetOutputDir()))
+
+	urls := make(map[string]string, len(urlMappings))
+	for name, url := range urlMappings {
+		urls[url] = name
+	}
+
+	return filepath.WalkDir(config.OutputDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || !strings.HasSuffix(strings.ToLower(path), ".html") {
+			return nil
+		}
+
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil // Skip files we can't read
+		}
+		updated := assets.InjectIntegrity(string(content), urls)
+		if updated == string(content) {
+			return nil
+		}
+		//nolint:gosec // output HTML needs web-readable permissions
+		if err := os.WriteFile(path, []byte(updated), 0o644); err != nil {
+			return fmt.Errorf("cdn_assets: writing %s: %w", path, err)
+		}
+		return nil
+	})
+}
+
 // getAssetsConfig extracts AssetsConfig from lifecycle.Config.Extra.
 func (p *CDNAssetsPlugin) getAssetsConfig(config *lifecycle.Config) *models.AssetsConfig {
 	if config.Extra == nil {
@@ -143,6 +201,16 @@ func (p *CDNAssetsPlugin) getAssetsConfig(config *lifecycle.Config) *models.Asse
 	return &defaultConfig
 }
 
+// assetsEnv returns the environment that decides what assets mode "auto"
+// means: development under markata-go serve, otherwise the environment
+// selected with MARKATA_GO_ENV, where none is production.
+func assetsEnv(m *lifecycle.Manager) string {
+	if lifecycle.IsServeMode(m) {
+		return assets.EnvDevelopment
+	}
+	return config.ActiveEnvironment()
+}
+
 // buildURLMappings creates a map of asset names to their local URLs.
 // This allows templates to conditionally use local or CDN URLs.
 func (p *CDNAssetsPlugin) buildURLMappings(outputDir string, assetList []assets.Asset) map[string]string {
@@ -218,5 +286,6 @@ var (
 	_ lifecycle.Plugin          = (*CDNAssetsPlugin)(nil)
 	_ lifecycle.ConfigurePlugin = (*CDNAssetsPlugin)(nil)
 	_ lifecycle.WritePlugin     = (*CDNAssetsPlugin)(nil)
+	_ lifecycle.CleanupPlugin   = (*CDNAssetsPlugin)(nil)
 	_ lifecycle.PriorityPlugin  = (*CDNAssetsPlugin)(nil)
 )
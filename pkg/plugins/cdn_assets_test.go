@@ -1,6 +1,10 @@
 package plugins
 
 import (
+	"crypto/sha512"
+	"encoding/base64"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -40,3 +44,94 @@ func TestCDNAssetsPlugin_OfflineMissingAssetFails(t *testing.T) {
 		t.Fatalf("expected missing asset name in error, got %v", err)
 	}
 }
+
+func TestCDNAssetsPlugin_AutoFallsBackToCDN(t *testing.T) {
+	t.Setenv(runtimeenv.EnvOffline, "true")
+	t.Setenv(runtimeenv.EnvBundledAssetsCacheDir, t.TempDir())
+
+	config := &lifecycle.Config{
+		OutputDir: t.TempDir(),
+		Extra: map[string]interface{}{
+			"assets": models.AssetsConfig{Mode: assets.ModeAuto, CacheDir: t.TempDir()},
+			"cdn_assets_extra": []assets.Asset{
+				{
+					Name:      "missing-extra",
+					URL:       "https://example.invalid/missing.js",
+					LocalPath: "missing/missing.js",
+					Type:      "js",
+				},
+			},
+		},
+	}
+
+	manager := lifecycle.NewManager()
+	manager.SetConfig(config)
+	manager.SetLogger(lifecycle.NopLogger())
+
+	// A production build self-hosts, but nothing is cached offline
+	if err := NewCDNAssetsPlugin().Configure(manager); err != nil {
+		t.Fatalf("auto mode should fall back to CDN for uncached assets, got %v", err)
+	}
+	urls, ok := config.Extra["asset_urls"].(map[string]string)
+	if !ok {
+		t.Fatalf("asset_urls = %T, want map[string]string", config.Extra["asset_urls"])
+	}
+	if _, ok := urls["missing-extra"]; ok {
+		t.Errorf("asset_urls has a local URL for an uncached asset: %v", urls)
+	}
+}
+
+func TestCDNAssetsPlugin_AutoUsesCDNWhileServing(t *testing.T) {
+	config := &lifecycle.Config{
+		OutputDir: t.TempDir(),
+		Extra: map[string]interface{}{
+			"assets": models.AssetsConfig{Mode: assets.ModeAuto, CacheDir: t.TempDir()},
+		},
+	}
+
+	manager := lifecycle.NewManager()
+	manager.SetConfig(config)
+	lifecycle.SetServeMode(manager, true)
+
+	if err := NewCDNAssetsPlugin().Configure(manager); err != nil {
+		t.Fatalf("Configure() error = %v", err)
+	}
+	if _, ok := config.Extra["asset_urls"]; ok {
+		t.Errorf("asset_urls set while serving in auto mode: %v", config.Extra["asset_urls"])
+	}
+}
+
+func TestCDNAssetsPlugin_CleanupInjectsIntegrity(t *testing.T) {
+	outputDir := t.TempDir()
+	writeVerifyFixture(t, outputDir, map[string]string{
+		"assets/vendor/htmx/htmx.min.js": "htmx",
+		"post/index.html":                `<head><script src="/assets/vendor/htmx/htmx.min.js"></script><script src="/app.js"></script></head>`,
+	})
+
+	config := &lifecycle.Config{
+		OutputDir: outputDir,
+		Extra: map[string]interface{}{
+			"assets":     models.NewAssetsConfig(),
+			"asset_urls": map[string]string{"htmx": "/assets/vendor/htmx/htmx.min.js"},
+		},
+	}
+	manager := lifecycle.NewManager()
+	manager.SetConfig(config)
+	t.Cleanup(func() { assets.SetIntegrityDir("") })
+
+	if err := NewCDNAssetsPlugin().Cleanup(manager); err != nil {
+		t.Fatalf("Cleanup() error = %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(outputDir, "post", "index.html"))
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	sum := sha512.Sum384([]byte("htmx"))
+	want := `<head><script src="/assets/vendor/htmx/htmx.min.js" integrity="sha384-` +
+		base64.StdEncoding.EncodeToString(sum[:]) +
+		`" crossorigin="anonymous"></script><script src="/app.js"></script></head>`
+	if string(content) != want {
+		t.Errorf("post HTML =\n%s\nwant:\n%s", content, want)
+	}
+}
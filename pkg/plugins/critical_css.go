@@ -4,7 +4,6 @@ package plugins
 import (
 	"bytes"
 	"fmt"
-	"log"
 	"os"
 	"path/filepath"
 	"regexp"
@@ -30,9 +29,15 @@ var (
 // 3. Async loading non-critical CSS via link rel="preload"
 //
 // This typically improves FCP by 200-800ms by eliminating render-blocking CSS.
+//
+// Pages rendered from a post use the critical selector profile for the
+// post's layout (see criticalcss.Profile), so docs pages and landing pages
+// each inline their own above-the-fold rules. Other pages, and posts whose
+// layout has no profile, use the default selectors.
 type CriticalCSSPlugin struct {
-	config    models.CriticalCSSConfig
-	extractor *criticalcss.Extractor
+	config       models.CriticalCSSConfig
+	extractor    *criticalcss.Extractor
+	layoutConfig *models.LayoutConfig
 }
 
 // NewCriticalCSSPlugin creates a new CriticalCSSPlugin.
@@ -68,6 +73,9 @@ func (p *CriticalCSSPlugin) Configure(m *lifecycle.Manager) error {
 	if pluginConfig.ViewportHeight <= 0 {
 		pluginConfig.ViewportHeight = 900
 	}
+	if pluginConfig.MobileBreakpoint <= 0 {
+		pluginConfig.MobileBreakpoint = criticalcss.DefaultMobileBreakpoint
+	}
 	if pluginConfig.InlineThreshold <= 0 {
 		pluginConfig.InlineThreshold = 50000
 	}
@@ -78,11 +86,72 @@ func (p *CriticalCSSPlugin) Configure(m *lifecycle.Manager) error {
 	p.extractor = criticalcss.NewExtractor().
 		WithMinify(p.config.IsMinify()).
 		WithSelectors(p.config.ExtraSelectors).
-		WithExcludeSelectors(p.config.ExcludeSelectors)
+		WithExcludeSelectors(p.config.ExcludeSelectors).
+		WithMobileBreakpoint(p.config.MobileBreakpoint)
+
+	switch lc := config.Extra["layout"].(type) {
+	case *models.LayoutConfig:
+		p.layoutConfig = lc
+	case models.LayoutConfig:
+		p.layoutConfig = &lc
+	}
 
 	return nil
 }
 
+// layoutExtractor returns an extractor for a layout's critical selector
+// profile, with the configured extra and excluded selectors applied.
+// Profiles from config take precedence over registered ones. Returns false
+// if the layout has no profile.
+func (p *CriticalCSSPlugin) layoutExtractor(layout string) (*criticalcss.Extractor, bool) {
+	selectors, ok := p.config.Profiles[layout]
+	if !ok {
+		selectors, ok = criticalcss.Profile(layout)
+	}
+	if !ok {
+		return nil, false
+	}
+
+	return criticalcss.NewExtractorWithSelectors(selectors).
+		WithMinify(p.config.IsMinify()).
+		WithSelectors(p.config.ExtraSelectors).
+		WithExcludeSelectors(p.config.ExcludeSelectors).
+		WithMobileBreakpoint(p.config.MobileBreakpoint), true
+}
+
+// postLayout returns the layout a post is rendered with: a layout template
+// named in frontmatter (e.g. "layouts/docs.html"), or else the layout
+// resolved from the layout config.
+func (p *CriticalCSSPlugin) postLayout(post *models.Post) string {
+	if post.Template != "" {
+		name := strings.TrimSuffix(strings.TrimPrefix(post.Template, "layouts/"), ".html")
+		if _, ok := p.config.Profiles[name]; ok {
+			return name
+		}
+		if _, ok := criticalcss.Profile(name); ok {
+			return name
+		}
+	}
+	if p.layoutConfig == nil {
+		return ""
+	}
+	return resolvePostLayout(p.layoutConfig, post)
+}
+
+// pageLayouts maps the HTML output path of each post to its layout.
+func (p *CriticalCSSPlugin) pageLayouts(m *lifecycle.Manager, outputDir string) map[string]string {
+	layouts := make(map[string]string)
+	for _, post := range m.Posts() {
+		if post.Slug == "" {
+			continue
+		}
+		if layout := p.postLayout(post); layout != "" {
+			layouts[filepath.Join(outputDir, post.Slug, "index.html")] = layout
+		}
+	}
+	return layouts
+}
+
 func parseCriticalCSSConfig(raw map[string]interface{}) models.CriticalCSSConfig {
 	config := models.NewCriticalCSSConfig()
 
@@ -101,6 +170,20 @@ func parseCriticalCSSConfig(raw map[string]interface{}) models.CriticalCSSConfig
 	if viewportHeight, ok := parseIntFromInterface(raw["viewport_height"]); ok {
 		config.ViewportHeight = viewportHeight
 	}
+	if breakpoint, ok := parseIntFromInterface(raw["mobile_breakpoint"]); ok {
+		config.MobileBreakpoint = breakpoint
+	}
+	if profiles, ok := raw["profiles"].(map[string]interface{}); ok {
+		config.Profiles = make(map[string][]string, len(profiles))
+		for layout, selectors := range profiles {
+			switch v := selectors.(type) {
+			case []interface{}:
+				config.Profiles[layout] = toStringSlice(v)
+			case []string:
+				config.Profiles[layout] = v
+			}
+		}
+	}
 	if threshold, ok := parseIntFromInterface(raw["inline_threshold"]); ok {
 		config.InlineThreshold = threshold
 	}
@@ -140,41 +223,128 @@ func (p *CriticalCSSPlugin) Write(m *lifecycle.Manager) error {
 	config := m.Config()
 	outputDir := config.OutputDir
 
-	log.Printf("[critical_css] Processing HTML files in %s", outputDir)
+	m.Logger().Debug("processing HTML files", "dir", outputDir)
 
 	// Load all CSS files from the output directory
-	cssContent, err := p.loadCSSFiles(outputDir)
+	cssContent, err := p.loadCSSFiles(m.Logger(), outputDir)
 	if err != nil {
 		return fmt.Errorf("loading CSS files: %w", err)
 	}
 
 	if len(cssContent) == 0 {
-		log.Printf("[critical_css] No CSS files found, skipping")
+		m.Logger().Info("no CSS files found, skipping")
 		return nil
 	}
 
 	// Extract critical CSS once (same for all pages with this approach)
+	p.extractor.WithImportRoot(outputDir)
 	result, err := p.extractor.ExtractMultiple(cssContent)
 	if err != nil {
 		return fmt.Errorf("extracting critical CSS: %w", err)
 	}
 
-	log.Printf("[critical_css] Extracted %d bytes critical CSS (%.1f%% of %d total)",
-		result.CriticalSize, float64(result.CriticalSize)/float64(result.TotalSize)*100, result.TotalSize)
+	m.Logger().Info("extracted critical CSS",
+		"critical_bytes", result.CriticalSize, "total_bytes", result.TotalSize,
+		"share", fmt.Sprintf("%.1f%%", float64(result.CriticalSize)/float64(result.TotalSize)*100))
 
-	// Check if critical CSS exceeds threshold
+	// Extract critical CSS per layout profile, once per layout in use
+	pageLayouts := p.pageLayouts(m, outputDir)
+	layoutCritical := make(map[string]*criticalcss.Result)
+	for _, layout := range pageLayouts {
+		if _, done := layoutCritical[layout]; done {
+			continue
+		}
+		extractor, ok := p.layoutExtractor(layout)
+		if !ok {
+			layoutCritical[layout] = result
+			continue
+		}
+		layoutResult, err := extractor.WithImportRoot(outputDir).ExtractMultiple(cssContent)
+		if err != nil {
+			return fmt.Errorf("extracting critical CSS for layout %q: %w", layout, err)
+		}
+		m.Logger().Info("extracted critical CSS for layout", "layout", layout, "critical_bytes", layoutResult.CriticalSize)
+		layoutCritical[layout] = layoutResult
+	}
+
+	// Pick the critical CSS for each page; nil skips the page
+	criticalFor := func(path string) *criticalcss.Result {
+		pageResult := result
+		if layout, ok := pageLayouts[path]; ok {
+			pageResult = layoutCritical[layout]
+		}
+		// Check if critical CSS exceeds threshold
+		if pageResult.Critical == "" || pageResult.CriticalSize > p.config.InlineThreshold {
+			return nil
+		}
+		return pageResult
+	}
+
+	for layout, layoutResult := range layoutCritical {
+		if layoutResult.CriticalSize > p.config.InlineThreshold {
+			m.Logger().Warn("critical CSS for layout exceeds threshold, skipping inline",
+				"layout", layout, "critical_bytes", layoutResult.CriticalSize, "threshold", p.config.InlineThreshold)
+		}
+	}
 	if result.CriticalSize > p.config.InlineThreshold {
-		log.Printf("[critical_css] Critical CSS (%d bytes) exceeds threshold (%d bytes), skipping inline",
-			result.CriticalSize, p.config.InlineThreshold)
-		return nil
+		m.Logger().Warn("critical CSS exceeds threshold, skipping inline",
+			"critical_bytes", result.CriticalSize, "threshold", p.config.InlineThreshold)
 	}
 
 	// Process all HTML files
-	return p.processHTMLFiles(outputDir, result.Critical)
+	report, err := p.processHTMLFiles(m.Logger(), outputDir, criticalFor)
+	if err != nil {
+		return err
+	}
+
+	m.Cache().Set("critical_css.report", report)
+	if report.DuplicateBytes > 0 {
+		m.Logger().Info("pages share critical CSS blocks",
+			"pages", report.Pages, "blocks", len(report.Blocks), "duplicate_bytes", report.DuplicateBytes)
+	}
+
+	return nil
+}
+
+// CriticalCSSReport summarizes the critical CSS inlined during a build, so
+// tooling can see how much is duplicated between pages. The critical_css
+// plugin stores it in the manager's cache as "critical_css.report".
+type CriticalCSSReport struct {
+	// Pages is the number of pages with critical CSS inlined
+	Pages int
+
+	// Blocks maps each distinct critical CSS hash (criticalcss.Result.Hash)
+	// to its usage
+	Blocks map[string]CriticalCSSBlock
+
+	// DuplicateBytes is the number of bytes inlined beyond the first copy
+	// of each block
+	DuplicateBytes int
+}
+
+// CriticalCSSBlock describes one distinct block of inlined critical CSS.
+type CriticalCSSBlock struct {
+	// Size is the block's size in bytes
+	Size int
+
+	// Pages is the number of pages the block is inlined in
+	Pages int
+}
+
+// add records a block inlined in one page.
+func (r *CriticalCSSReport) add(result *criticalcss.Result) {
+	block, seen := r.Blocks[result.Hash]
+	if seen {
+		r.DuplicateBytes += result.CriticalSize
+	}
+	block.Size = result.CriticalSize
+	block.Pages++
+	r.Blocks[result.Hash] = block
+	r.Pages++
 }
 
 // loadCSSFiles loads all CSS files from the output directory's css folder.
-func (p *CriticalCSSPlugin) loadCSSFiles(outputDir string) (map[string]string, error) {
+func (p *CriticalCSSPlugin) loadCSSFiles(logger lifecycle.Logger, outputDir string) (map[string]string, error) {
 	cssDir := filepath.Join(outputDir, "css")
 	cssContent := make(map[string]string)
 
@@ -197,19 +367,23 @@ func (p *CriticalCSSPlugin) loadCSSFiles(outputDir string) (map[string]string, e
 		path := filepath.Join(cssDir, entry.Name())
 		content, err := os.ReadFile(path)
 		if err != nil {
-			log.Printf("[critical_css] Warning: could not read %s: %v", path, err)
+			logger.Warn("could not read CSS file", "path", path, "error", err)
 			continue
 		}
 
-		cssContent[entry.Name()] = string(content)
+		// Keyed by output path so @import URLs resolve against it
+		cssContent["css/"+entry.Name()] = string(content)
 	}
 
 	return cssContent, nil
 }
 
 // processHTMLFiles walks the output directory and processes all HTML files.
-func (p *CriticalCSSPlugin) processHTMLFiles(outputDir, criticalCSS string) error {
+// criticalFor returns the critical CSS to inline for a file, or nil to leave
+// the file unchanged.
+func (p *CriticalCSSPlugin) processHTMLFiles(logger lifecycle.Logger, outputDir string, criticalFor func(path string) *criticalcss.Result) (*CriticalCSSReport, error) {
 	processedCount := 0
+	report := &CriticalCSSReport{Blocks: make(map[string]CriticalCSSBlock)}
 
 	err := filepath.Walk(outputDir, func(path string, info os.FileInfo, err error) error {
 		if err != nil {
@@ -221,6 +395,11 @@ func (p *CriticalCSSPlugin) processHTMLFiles(outputDir, criticalCSS string) erro
 			return nil
 		}
 
+		critical := criticalFor(path)
+		if critical == nil {
+			return nil
+		}
+
 		// Read HTML file
 		content, err := os.ReadFile(path)
 		if err != nil {
@@ -228,7 +407,7 @@ func (p *CriticalCSSPlugin) processHTMLFiles(outputDir, criticalCSS string) erro
 		}
 
 		// Process the HTML
-		modified, changed := p.processHTML(string(content), criticalCSS)
+		modified, changed := p.processHTML(string(content), critical.Critical, critical.Hash)
 		if !changed {
 			return nil
 		}
@@ -240,19 +419,22 @@ func (p *CriticalCSSPlugin) processHTMLFiles(outputDir, criticalCSS string) erro
 		}
 
 		processedCount++
+		report.add(critical)
 		return nil
 	})
 
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	log.Printf("[critical_css] Processed %d HTML files", processedCount)
-	return nil
+	logger.Info("processed HTML files", "files", processedCount)
+	return report, nil
 }
 
 // processHTML modifies an HTML document to inline critical CSS and async load the rest.
-func (p *CriticalCSSPlugin) processHTML(html, criticalCSS string) (string, bool) {
+// The inlined <style> carries the CSS hash in data-critical-hash, so
+// identical blocks can be found across pages.
+func (p *CriticalCSSPlugin) processHTML(html, criticalCSS, hash string) (string, bool) {
 	// Skip if already processed (has critical-css id)
 	if strings.Contains(html, `id="critical-css"`) {
 		return html, false
@@ -275,7 +457,7 @@ func (p *CriticalCSSPlugin) processHTML(html, criticalCSS string) (string, bool)
 	// Insert critical CSS before first stylesheet
 	firstMatch := matches[0]
 	buf.WriteString(html[:firstMatch[0]])
-	buf.WriteString("\n<style id=\"critical-css\">\n")
+	fmt.Fprintf(&buf, "\n<style id=\"critical-css\" data-critical-hash=%q>\n", hash)
 	buf.WriteString(criticalCSS)
 	buf.WriteString("\n</style>\n")
 
@@ -0,0 +1,130 @@
+package plugins
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/WaylonWalker/markata-go/pkg/lifecycle"
+	"github.com/WaylonWalker/markata-go/pkg/models"
+)
+
+func TestCriticalCSSPlugin_LayoutProfiles(t *testing.T) {
+	outputDir := t.TempDir()
+
+	css := `body { margin: 0; }
+.hero { min-height: 80vh; }
+.layout-sidebar { width: 280px; }
+`
+	page := `<html><head><link rel="stylesheet" href="/css/main.css"></head><body></body></html>`
+
+	files := map[string]string{
+		"css/main.css":                css,
+		"docs/install/index.html":     page,
+		"welcome/index.html":          page,
+		"tags/index.html":             page,
+		"landing-template/index.html": page,
+	}
+	for name, content := range files {
+		path := filepath.Join(outputDir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	enabled := true
+	criticalConfig := models.NewCriticalCSSConfig()
+	criticalConfig.Enabled = &enabled
+
+	layout := models.LayoutConfig{
+		Name:  "blog",
+		Paths: map[string]string{"/docs/": "docs", "/welcome/": "landing"},
+	}
+
+	m := lifecycle.NewManager()
+	m.SetConfig(&lifecycle.Config{
+		OutputDir: outputDir,
+		Extra: map[string]interface{}{
+			"critical_css": criticalConfig,
+			"layout":       layout,
+		},
+	})
+
+	docs := models.NewPost("docs/install.md")
+	docs.Slug = "docs/install"
+	docs.Href = "/docs/install/"
+	welcome := models.NewPost("welcome.md")
+	welcome.Slug = "welcome"
+	welcome.Href = "/welcome/"
+	templated := models.NewPost("landing-template.md")
+	templated.Slug = "landing-template"
+	templated.Href = "/landing-template/"
+	templated.Template = "layouts/landing.html"
+	m.SetPosts([]*models.Post{docs, welcome, templated})
+
+	p := NewCriticalCSSPlugin()
+	if err := p.Configure(m); err != nil {
+		t.Fatalf("Configure() error = %v", err)
+	}
+	if err := p.Write(m); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+
+	read := func(name string) string {
+		content, err := os.ReadFile(filepath.Join(outputDir, filepath.FromSlash(name)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		html := string(content)
+		start := strings.Index(html, `<style id="critical-css"`)
+		end := strings.Index(html, "</style>")
+		if start < 0 || end < start {
+			t.Fatalf("%s: no critical CSS inlined:\n%s", name, html)
+		}
+		return html[start:end]
+	}
+
+	docsCritical := read("docs/install/index.html")
+	if strings.Contains(docsCritical, ".hero") {
+		t.Error("landing-only selector .hero should not be inlined on a docs page")
+	}
+	if !strings.Contains(docsCritical, ".layout-sidebar") {
+		t.Error("docs page should inline .layout-sidebar")
+	}
+
+	for _, name := range []string{"welcome/index.html", "landing-template/index.html"} {
+		critical := read(name)
+		if !strings.Contains(critical, ".hero") {
+			t.Errorf("%s: landing page should inline .hero", name)
+		}
+		if strings.Contains(critical, ".layout-sidebar") {
+			t.Errorf("%s: docs-only selector should not be inlined on a landing page", name)
+		}
+	}
+
+	// Pages without a post use the default selectors
+	if critical := read("tags/index.html"); !strings.Contains(critical, "margin:0") {
+		t.Errorf("tags page should inline base rules, got %q", critical)
+	}
+	// Both landing pages share one block; the report counts the duplicate
+	cached, ok := m.Cache().Get("critical_css.report")
+	if !ok {
+		t.Fatal("expected critical_css.report in cache")
+	}
+	report := cached.(*CriticalCSSReport)
+	if report.Pages != 4 || len(report.Blocks) != 3 {
+		t.Errorf("report = %d pages, %d blocks; want 4 pages, 3 blocks", report.Pages, len(report.Blocks))
+	}
+	landing := read("welcome/index.html")
+	wantDuplicate := len(strings.TrimSpace(landing[strings.Index(landing, ">")+1:]))
+	if report.DuplicateBytes != wantDuplicate {
+		t.Errorf("DuplicateBytes = %d, want %d", report.DuplicateBytes, wantDuplicate)
+	}
+	if !strings.Contains(landing, `data-critical-hash="`) {
+		t.Error("inlined critical CSS should carry its hash")
+	}
+}
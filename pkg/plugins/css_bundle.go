@@ -4,7 +4,6 @@ package plugins
 import (
 	"bytes"
 	"fmt"
-	"log"
 	"os"
 	"path/filepath"
 	"sort"
@@ -169,11 +168,11 @@ func (p *CSSBundlePlugin) Write(m *lifecycle.Manager) error {
 	config := m.Config()
 	outputDir := config.OutputDir
 
-	log.Printf("[css_bundle] Starting CSS bundling with %d bundle(s)", len(p.config.Bundles))
+	m.Logger().Debug("starting CSS bundling", "bundles", len(p.config.Bundles))
 
 	// Process each bundle configuration
 	for _, bundleConfig := range p.config.Bundles {
-		if err := p.processBundle(bundleConfig, outputDir); err != nil {
+		if err := p.processBundle(m.Logger(), bundleConfig, outputDir); err != nil {
 			return fmt.Errorf("processing bundle %q: %w", bundleConfig.Name, err)
 		}
 	}
@@ -181,13 +180,13 @@ func (p *CSSBundlePlugin) Write(m *lifecycle.Manager) error {
 	// Store bundle paths in cache for template access
 	p.storeBundlePaths(m)
 
-	log.Printf("[css_bundle] Completed CSS bundling: %d bundle(s) created", len(p.bundles))
+	m.Logger().Info("completed CSS bundling", "bundles", len(p.bundles))
 
 	return nil
 }
 
 // processBundle creates a single CSS bundle from the configuration.
-func (p *CSSBundlePlugin) processBundle(bundleConfig models.BundleConfig, outputDir string) error {
+func (p *CSSBundlePlugin) processBundle(logger lifecycle.Logger, bundleConfig models.BundleConfig, outputDir string) error {
 	if bundleConfig.Name == "" {
 		return fmt.Errorf("bundle name is required")
 	}
@@ -212,7 +211,7 @@ func (p *CSSBundlePlugin) processBundle(bundleConfig models.BundleConfig, output
 	for _, source := range bundleConfig.Sources {
 		files, err := p.resolveSourcePattern(source, outputDir)
 		if err != nil {
-			log.Printf("[css_bundle] Warning: failed to resolve pattern %q: %v", source, err)
+			logger.Warn("failed to resolve pattern", "pattern", source, "error", err)
 			continue
 		}
 
@@ -222,13 +221,13 @@ func (p *CSSBundlePlugin) processBundle(bundleConfig models.BundleConfig, output
 		for _, file := range files {
 			// Check if file should be excluded
 			if p.isExcluded(file) {
-				log.Printf("[css_bundle] Skipping excluded file: %s", file)
+				logger.Debug("skipping excluded file", "file", file)
 				continue
 			}
 
 			content, err := os.ReadFile(file)
 			if err != nil {
-				log.Printf("[css_bundle] Warning: failed to read %q: %v", file, err)
+				logger.Warn("failed to read file", "file", file, "error", err)
 				continue
 			}
 
@@ -258,7 +257,7 @@ func (p *CSSBundlePlugin) processBundle(bundleConfig models.BundleConfig, output
 	}
 
 	if filesIncluded == 0 {
-		log.Printf("[css_bundle] Warning: no files found for bundle %q", bundleConfig.Name)
+		logger.Warn("no files found for bundle", "bundle", bundleConfig.Name)
 		return nil
 	}
 
@@ -278,8 +277,7 @@ func (p *CSSBundlePlugin) processBundle(bundleConfig models.BundleConfig, output
 	// Record the bundle
 	p.bundles[bundleConfig.Name] = "/" + bundleConfig.Output
 
-	log.Printf("[css_bundle] Created bundle %q: %d files, %d bytes -> %s",
-		bundleConfig.Name, filesIncluded, totalBytes, bundleConfig.Output)
+	logger.Debug("created bundle", "bundle", bundleConfig.Name, "files", filesIncluded, "bytes", totalBytes, "output", bundleConfig.Output)
 
 	return nil
 }
@@ -133,7 +133,7 @@ func (p *CSSMinifyPlugin) Write(m *lifecycle.Manager) error {
 		return fmt.Errorf("finding CSS files: %w", err)
 	}
 
-	runMinification("css_minify", cssFiles, p.isExcluded, func(path string) (int64, int64, error) {
+	runMinification(m.Logger(), cssFiles, p.isExcluded, func(path string) (int64, int64, error) {
 		return p.minifyFile(path)
 	}, m.Concurrency())
 
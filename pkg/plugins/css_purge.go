@@ -1,19 +1,22 @@
 package plugins
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 	"sync"
 
 	"github.com/WaylonWalker/markata-go/pkg/csspurge"
 	"github.com/WaylonWalker/markata-go/pkg/lifecycle"
-	"github.com/WaylonWalker/markata-go/pkg/logging"
 	"github.com/WaylonWalker/markata-go/pkg/models"
 )
 
-var cssPurgeLog = logging.Component("css_purge").Phase("cleanup")
+// cssPurgeReportFile is the report written to the output directory in
+// verbose mode.
+const cssPurgeReportFile = "css-purge-report.json"
 
 // CSSPurgePlugin removes unused CSS rules from stylesheets based on
 // which selectors are actually used in the generated HTML files.
@@ -55,13 +58,14 @@ func (p *CSSPurgePlugin) Cleanup(m *lifecycle.Manager) error {
 
 	outputDir := config.OutputDir
 	verbose := purgeConfig.Verbose
+	logger := m.Logger()
 
 	if verbose {
-		cssPurgeLog.Printf("Analyzing CSS usage in %s", outputDir)
+		logger.Info("analyzing CSS usage", "dir", outputDir)
 	}
 
 	// Step 1: Find and scan HTML files
-	used, err := scanHTMLFilesForSelectors(outputDir, m.Concurrency(), verbose)
+	used, err := scanHTMLFilesForSelectors(logger, outputDir, m.Concurrency(), verbose)
 	if err != nil {
 		return err
 	}
@@ -77,7 +81,7 @@ func (p *CSSPurgePlugin) Cleanup(m *lifecycle.Manager) error {
 
 	if len(cssFiles) == 0 {
 		if verbose {
-			cssPurgeLog.Printf("No CSS files found, skipping")
+			logger.Info("no CSS files found, skipping")
 		}
 		return nil
 	}
@@ -86,10 +90,17 @@ func (p *CSSPurgePlugin) Cleanup(m *lifecycle.Manager) error {
 	opts := buildPurgeOptions(purgeConfig, verbose)
 
 	// Step 4: Process CSS files
-	stats := processCSSFiles(cssFiles, outputDir, used, opts, purgeConfig, verbose)
+	stats := processCSSFiles(logger, cssFiles, outputDir, used, opts, purgeConfig, verbose)
 
 	// Step 5: Report summary
-	reportPurgeSummary(stats, purgeConfig, verbose)
+	reportPurgeSummary(logger, stats, purgeConfig, verbose)
+
+	// Step 6: Write the detailed report
+	if verbose {
+		if err := writePurgeReport(logger, outputDir, stats.files); err != nil {
+			return err
+		}
+	}
 
 	return nil
 }
@@ -100,10 +111,62 @@ type purgeProcessingStats struct {
 	totalPurged    int
 	filesProcessed int
 	filesSkipped   int
+	files          []cssFileResult
+}
+
+// cssPurgeReport is the JSON report of removed selectors across CSS files.
+type cssPurgeReport struct {
+	Files          []cssPurgeFileReport     `json:"files"`
+	Preserve       []csspurge.PreserveMatch `json:"preserve"`
+	UnusedPreserve []string                 `json:"unused_preserve"`
+	RemovedCount   int                      `json:"removed_count"`
+}
+
+// cssPurgeFileReport lists the selectors removed from one CSS file.
+type cssPurgeFileReport struct {
+	File    string                     `json:"file"`
+	Removed []csspurge.RemovedSelector `json:"removed"`
+}
+
+// writePurgeReport writes the removed selectors and preserve pattern usage
+// of all processed CSS files to css-purge-report.json in the output directory.
+func writePurgeReport(logger lifecycle.Logger, outputDir string, files []cssFileResult) error {
+	sort.Slice(files, func(i, j int) bool { return files[i].relPath < files[j].relPath })
+
+	var merged csspurge.PurgeReport
+	report := cssPurgeReport{Files: make([]cssPurgeFileReport, 0, len(files))}
+	for _, file := range files {
+		merged.Merge(file.report)
+		report.Files = append(report.Files, cssPurgeFileReport{
+			File:    filepath.ToSlash(file.relPath),
+			Removed: file.report.Removed,
+		})
+	}
+	report.Preserve = merged.Preserve
+	report.UnusedPreserve = merged.UnusedPreservePatterns()
+	report.RemovedCount = len(merged.Removed)
+
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding css purge report: %w", err)
+	}
+
+	reportPath := filepath.Join(outputDir, cssPurgeReportFile)
+	//nolint:gosec // G306: report lives alongside other 0644 output files
+	if err := os.WriteFile(reportPath, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("writing css purge report: %w", err)
+	}
+
+	logger.Info("wrote purge report", "removed_selectors", report.RemovedCount, "path", reportPath)
+	if len(report.UnusedPreserve) > 0 {
+		logger.Info("preserve patterns matched nothing", "patterns", strings.Join(report.UnusedPreserve, ", "))
+	}
+
+	return nil
 }
 
 // scanHTMLFilesForSelectors finds and scans HTML files for used selectors.
-func scanHTMLFilesForSelectors(outputDir string, concurrency int, verbose bool) (*csspurge.UsedSelectors, error) {
+func scanHTMLFilesForSelectors(logger lifecycle.Logger, outputDir string, concurrency int, verbose bool) (*csspurge.UsedSelectors, error) {
 	htmlFiles, err := findHTMLFiles(outputDir)
 	if err != nil {
 		return nil, fmt.Errorf("failed to find HTML files: %w", err)
@@ -111,20 +174,20 @@ func scanHTMLFilesForSelectors(outputDir string, concurrency int, verbose bool)
 
 	if len(htmlFiles) == 0 {
 		if verbose {
-			cssPurgeLog.Printf("No HTML files found, skipping")
+			logger.Info("no HTML files found, skipping")
 		}
 		return nil, nil
 	}
 
 	if verbose {
-		cssPurgeLog.Printf("Found %d HTML files to analyze", len(htmlFiles))
+		logger.Info("analyzing HTML files", "files", len(htmlFiles))
 	}
 
-	used := scanHTMLFilesConcurrently(htmlFiles, concurrency)
+	used := scanHTMLFilesConcurrently(logger, htmlFiles, concurrency)
 
 	if verbose {
-		cssPurgeLog.Printf("Found %d classes, %d IDs, %d elements, %d attributes",
-			len(used.Classes), len(used.IDs), len(used.Elements), len(used.Attributes))
+		logger.Info("found selectors",
+			"classes", len(used.Classes), "ids", len(used.IDs), "elements", len(used.Elements), "attributes", len(used.Attributes))
 	}
 
 	return used, nil
@@ -143,14 +206,15 @@ func buildPurgeOptions(purgeConfig models.CSSPurgeConfig, verbose bool) csspurge
 	}
 
 	return csspurge.PurgeOptions{
-		Preserve:           preserve,
-		PreserveAttributes: preserveAttrs,
-		Verbose:            verbose,
+		Preserve:                 preserve,
+		PreserveAttributes:       preserveAttrs,
+		PreserveCustomProperties: purgeConfig.PreserveCustomProperties,
+		Verbose:                  verbose,
 	}
 }
 
 // processCSSFiles processes each CSS file concurrently and returns statistics.
-func processCSSFiles(cssFiles []string, outputDir string, used *csspurge.UsedSelectors, opts csspurge.PurgeOptions, purgeConfig models.CSSPurgeConfig, verbose bool) purgeProcessingStats {
+func processCSSFiles(logger lifecycle.Logger, cssFiles []string, outputDir string, used *csspurge.UsedSelectors, opts csspurge.PurgeOptions, purgeConfig models.CSSPurgeConfig, verbose bool) purgeProcessingStats {
 	filteredFiles := make([]string, 0, len(cssFiles))
 	skippedCount := 0
 
@@ -162,7 +226,7 @@ func processCSSFiles(cssFiles []string, outputDir string, used *csspurge.UsedSel
 
 		if shouldSkipCSSFile(relPath, purgeConfig.SkipFiles) {
 			if verbose {
-				cssPurgeLog.Printf("Skipping %s (matches skip pattern)", relPath)
+				logger.Info("skipping file matching skip pattern", "file", relPath)
 			}
 			skippedCount++
 			continue
@@ -186,6 +250,7 @@ type cssFileResult struct {
 	purgedSize int
 	rules      int
 	removed    int
+	report     csspurge.PurgeReport
 }
 
 // processCSSFilesConcurrently processes CSS files using a worker pool.
@@ -222,12 +287,15 @@ func processCSSFilesConcurrently(cssFiles []string, outputDir string, used *cssp
 					continue
 				}
 
-				purged, purgeStats := csspurge.PurgeCSS(string(content), used, opts)
+				purged, purgeStats, report := csspurge.PurgeCSS(string(content), used, opts)
 
 				result.origSize = purgeStats.OriginalSize
 				result.purgedSize = purgeStats.PurgedSize
 				result.rules = purgeStats.TotalRules
 				result.removed = purgeStats.RemovedRules
+				if verbose {
+					result.report = report
+				}
 
 				if purgeStats.RemovedRules > 0 {
 					//nolint:gosec // G306: CSS output files need 0644 for web serving
@@ -262,6 +330,7 @@ func processCSSFilesConcurrently(cssFiles []string, outputDir string, used *cssp
 		stats.totalOriginal += result.origSize
 		stats.totalPurged += result.purgedSize
 		stats.filesProcessed++
+		stats.files = append(stats.files, result)
 
 		if verbose && result.removed > 0 {
 			savings := float64(result.origSize-result.purgedSize) / float64(result.origSize) * 100
@@ -276,20 +345,21 @@ func processCSSFilesConcurrently(cssFiles []string, outputDir string, used *cssp
 }
 
 // reportPurgeSummary reports the purging summary.
-func reportPurgeSummary(stats purgeProcessingStats, purgeConfig models.CSSPurgeConfig, verbose bool) {
+func reportPurgeSummary(logger lifecycle.Logger, stats purgeProcessingStats, purgeConfig models.CSSPurgeConfig, verbose bool) {
 	if stats.filesProcessed > 0 {
 		savings := float64(stats.totalOriginal-stats.totalPurged) / float64(stats.totalOriginal) * 100
-		cssPurgeLog.Printf("Processed %d CSS files: %d -> %d bytes (%.1f%% reduction)",
-			stats.filesProcessed, stats.totalOriginal, stats.totalPurged, savings)
+		logger.Info("purged CSS",
+			"files", stats.filesProcessed, "original_bytes", stats.totalOriginal, "purged_bytes", stats.totalPurged,
+			"reduction", fmt.Sprintf("%.1f%%", savings))
 
 		if purgeConfig.WarningThreshold > 0 && int(savings) > purgeConfig.WarningThreshold {
-			cssPurgeLog.Warnf("Removed %.1f%% of CSS (threshold: %d%%). This might indicate overly aggressive purging. Consider adding patterns to 'preserve' config.",
-				savings, purgeConfig.WarningThreshold)
+			logger.Warn("purging removed more CSS than the warning threshold, consider adding patterns to 'preserve' config",
+				"reduction", fmt.Sprintf("%.1f%%", savings), "threshold", fmt.Sprintf("%d%%", purgeConfig.WarningThreshold))
 		}
 	}
 
 	if stats.filesSkipped > 0 && verbose {
-		cssPurgeLog.Printf("Skipped %d CSS files", stats.filesSkipped)
+		logger.Info("skipped CSS files", "files", stats.filesSkipped)
 	}
 }
 
@@ -334,7 +404,7 @@ func findCSSFiles(dir string) ([]string, error) {
 }
 
 // scanHTMLFilesConcurrently scans HTML files using a worker pool.
-func scanHTMLFilesConcurrently(files []string, concurrency int) *csspurge.UsedSelectors {
+func scanHTMLFilesConcurrently(logger lifecycle.Logger, files []string, concurrency int) *csspurge.UsedSelectors {
 	if concurrency < 1 {
 		concurrency = 1
 	}
@@ -379,7 +449,7 @@ func scanHTMLFilesConcurrently(files []string, concurrency int) *csspurge.UsedSe
 	// Check for errors
 	for err := range errors {
 		// Log but don't fail - partial analysis is still useful
-		cssPurgeLog.Warnf("%v", err)
+		logger.Warn("failed to scan HTML file", "error", err)
 	}
 
 	// Merge results
@@ -446,6 +516,9 @@ func parseCSSPurgeConfigFromMap(rawConfig map[string]interface{}) models.CSSPurg
 	if preserveAttrs, ok := rawConfig["preserve_attributes"]; ok {
 		result.PreserveAttributes = parseStringSlice(preserveAttrs)
 	}
+	if preserveVars, ok := rawConfig["preserve_custom_properties"].(bool); ok {
+		result.PreserveCustomProperties = preserveVars
+	}
 	if threshold, ok := parseIntFromInterface(rawConfig["warning_threshold"]); ok {
 		result.WarningThreshold = threshold
 	}
@@ -51,34 +51,32 @@ func (p *CSVFencePlugin) Priority(stage lifecycle.Stage) int {
 	return lifecycle.PriorityDefault
 }
 
+// csvFenceConfig is the [markata-go.csv_fence] section.
+type csvFenceConfig struct {
+	Enabled    bool   `json:"enabled"`
+	TableClass string `json:"table_class"`
+	HasHeader  bool   `json:"has_header"`
+	Delimiter  string `json:"delimiter"`
+}
+
 // Configure reads configuration options for the plugin from config.Extra.
 // Configuration is expected in config.Extra["csv_fence"] as a map.
 func (p *CSVFencePlugin) Configure(m *lifecycle.Manager) error {
-	config := m.Config()
-	if config.Extra == nil {
-		return nil
+	cfg := csvFenceConfig{
+		Enabled:    p.enabled,
+		TableClass: p.tableClass,
+		HasHeader:  p.hasHeader,
+		Delimiter:  string(p.delimiter),
 	}
-
-	// Look for csv_fence configuration
-	cfgRaw, ok := config.Extra["csv_fence"]
-	if !ok {
-		return nil
+	if err := m.UnmarshalPluginConfig("csv_fence", &cfg); err != nil {
+		return err
 	}
 
-	// Handle map[string]interface{} configuration
-	if cfgMap, ok := cfgRaw.(map[string]interface{}); ok {
-		if enabled, ok := cfgMap["enabled"].(bool); ok {
-			p.enabled = enabled
-		}
-		if tableClass, ok := cfgMap["table_class"].(string); ok {
-			p.tableClass = tableClass
-		}
-		if hasHeader, ok := cfgMap["has_header"].(bool); ok {
-			p.hasHeader = hasHeader
-		}
-		if delimiter, ok := cfgMap["delimiter"].(string); ok && delimiter != "" {
-			p.delimiter = rune(delimiter[0])
-		}
+	p.enabled = cfg.Enabled
+	p.tableClass = cfg.TableClass
+	p.hasHeader = cfg.HasHeader
+	if cfg.Delimiter != "" {
+		p.delimiter = rune(cfg.Delimiter[0])
 	}
 
 	return nil
@@ -8,6 +8,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/WaylonWalker/markata-go/pkg/config"
 	"github.com/WaylonWalker/markata-go/pkg/filter"
 	"github.com/WaylonWalker/markata-go/pkg/lifecycle"
 	"github.com/WaylonWalker/markata-go/pkg/models"
@@ -42,6 +43,7 @@ func (p *FeedsPlugin) Collect(m *lifecycle.Manager) error {
 	// Get feed configs from manager's extra config
 	feedConfigs := getFeedConfigs(config)
 	feedDefaults := getFeedDefaults(config)
+	warnFeedPostSlugCollisions(m.Logger(), feedConfigs, posts)
 
 	feeds := make([]*lifecycle.Feed, 0, len(feedConfigs))
 
@@ -53,66 +55,15 @@ func (p *FeedsPlugin) Collect(m *lifecycle.Manager) error {
 
 		// Apply defaults
 		fc.ApplyDefaults(feedDefaults)
-
-		usePresetPosts := fc.Type == models.FeedTypeSeries && len(fc.Posts) > 0
-
-		var filteredPosts []*models.Post
-		if usePresetPosts {
-			filteredPosts = cloneFeedPosts(fc.Posts)
-		} else {
-			// Filter posts
-			var err error
-			filteredPosts, err = filterCache.FilterPosts(fc.Filter, fc.IncludePrivate)
-			if err != nil {
-				return fmt.Errorf("feed %q: %w", fc.Slug, err)
-			}
-			filteredPosts = cloneFeedPosts(filteredPosts)
-		}
-		if !usePresetPosts {
-			// Determine sort field and direction based on feed type
-			sortField := fc.Sort
-			reverse := fc.Reverse
-
-			// For guide-type feeds, default to sorting by guide_order (ascending)
-			if fc.Type == models.FeedTypeGuide {
-				if sortField == "" {
-					sortField = "guide_order"
-					reverse = false // Guides should be in ascending order by default
-				}
-			} else {
-				// Default to date sorting for non-guide feeds
-				if sortField == "" {
-					sortField = "date"
-					reverse = true // Newest first by default
-				}
-			}
-
-			sortPosts(filteredPosts, sortField, reverse)
-
-			// For guide-type feeds, set up prev/next navigation on each post
-			if fc.Type == models.FeedTypeGuide || fc.Type == models.FeedTypeSeries {
-				setGuideNavigation(filteredPosts, fc.Slug)
-			}
+		if err := collectFeed(fc, filterCache, true); err != nil {
+			return err
 		}
 
-		// Store posts in feed config
-		filteredPosts = applyFeedLimitOffset(filteredPosts, fc)
-		fc.Posts = filteredPosts
-
-		// Get base URL for pagination
-		baseURL := "/" + fc.Slug
-		if fc.Slug == "" {
-			baseURL = ""
-		}
-
-		// Paginate results
-		fc.Paginate(baseURL)
-
 		// Create lifecycle.Feed for each page
 		feed := &lifecycle.Feed{
 			Name:  fc.Slug,
 			Title: fc.Title,
-			Posts: filteredPosts,
+			Posts: fc.Posts,
 			Path:  fc.Slug,
 		}
 
@@ -127,6 +78,95 @@ func (p *FeedsPlugin) Collect(m *lifecycle.Manager) error {
 	return nil
 }
 
+// ApplyFeedDefaults applies the feed defaults from config to fc, as the
+// collect stage does before collecting each feed.
+func ApplyFeedDefaults(config *lifecycle.Config, fc *models.FeedConfig) {
+	fc.ApplyDefaults(getFeedDefaults(config))
+}
+
+// CollectFeed fills fc with its posts the way the collect stage does: posts
+// are filtered, sorted, limited, and paginated. Defaults must already be
+// applied with ApplyFeedDefaults. Unlike the collect stage, guide and series
+// feeds do not set prev/next links, so posts are left unchanged.
+func CollectFeed(posts []*models.Post, fc *models.FeedConfig) error {
+	return collectFeed(fc, newFeedFilterCache(posts), false)
+}
+
+// collectFeed filters, sorts, limits, and paginates the posts of one feed
+// whose defaults are already applied. When setNavigation is true, guide and
+// series feeds also set prev/next links on their posts.
+func collectFeed(fc *models.FeedConfig, filterCache *feedFilterCache, setNavigation bool) error {
+	usePresetPosts := fc.Type == models.FeedTypeSeries && len(fc.Posts) > 0
+
+	var filteredPosts []*models.Post
+	if usePresetPosts {
+		filteredPosts = cloneFeedPosts(fc.Posts)
+	} else {
+		// Filter posts
+		var err error
+		filteredPosts, err = filterCache.FilterPosts(fc.Filter, fc.IncludePrivate)
+		if err != nil {
+			return fmt.Errorf("feed %q: %w", fc.Slug, err)
+		}
+		filteredPosts = cloneFeedPosts(filteredPosts)
+	}
+	if !usePresetPosts {
+		// Determine sort field and direction based on feed type
+		sortField := fc.Sort
+		reverse := fc.Reverse
+
+		// For guide-type feeds, default to sorting by guide_order (ascending)
+		if fc.Type == models.FeedTypeGuide {
+			if sortField == "" {
+				sortField = "guide_order"
+				reverse = false // Guides should be in ascending order by default
+			}
+		} else {
+			// Default to date sorting for non-guide feeds
+			if sortField == "" {
+				sortField = "date"
+				reverse = true // Newest first by default
+			}
+		}
+
+		sortPosts(filteredPosts, sortField, reverse)
+
+		// For guide-type feeds, set up prev/next navigation on each post
+		if setNavigation && (fc.Type == models.FeedTypeGuide || fc.Type == models.FeedTypeSeries) {
+			setGuideNavigation(filteredPosts, fc.Slug)
+		}
+	}
+
+	// Store posts in feed config
+	filteredPosts = applyFeedLimitOffset(filteredPosts, fc)
+	fc.Posts = filteredPosts
+
+	// Get base URL for pagination
+	baseURL := "/" + fc.Slug
+	if fc.Slug == "" {
+		baseURL = ""
+	}
+
+	// Paginate results
+	fc.Paginate(baseURL)
+
+	return nil
+}
+
+// warnFeedPostSlugCollisions logs a warning for each feed that writes the
+// same page as a post.
+func warnFeedPostSlugCollisions(logger lifecycle.Logger, feedConfigs []models.FeedConfig, posts []*models.Post) {
+	slugs := make([]string, 0, len(posts))
+	for _, post := range posts {
+		if !post.Skip {
+			slugs = append(slugs, post.Slug)
+		}
+	}
+	for _, err := range config.ValidateFeedPostSlugs(feedConfigs, slugs) {
+		logger.Warn("feed slug collides with a post", "error", err)
+	}
+}
+
 func applyFeedLimitOffset(posts []*models.Post, fc *models.FeedConfig) []*models.Post {
 	if len(posts) == 0 {
 		return posts
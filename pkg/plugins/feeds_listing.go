@@ -3,7 +3,6 @@ package plugins
 
 import (
 	"fmt"
-	"log"
 	"os"
 	"path/filepath"
 	"sort"
@@ -137,7 +136,7 @@ func (p *FeedsListingPlugin) Write(m *lifecycle.Manager) error {
 		return nil
 	}
 
-	if err := p.renderFeedsPage(config, &feedsPage, sections, feedsPage.SlugPrefix, feedsPage.Title, feedsPage.Description, nil, nil); err != nil {
+	if err := p.renderFeedsPage(m.Logger(), config, &feedsPage, sections, feedsPage.SlugPrefix, feedsPage.Title, feedsPage.Description, nil, nil); err != nil {
 		return err
 	}
 
@@ -157,6 +156,7 @@ func (p *FeedsListingPlugin) Write(m *lifecycle.Manager) error {
 				Feeds:       generatedFeedPages[i].Feeds,
 			}
 			if err := p.renderFeedsPage(
+				m.Logger(),
 				config,
 				&feedsPage,
 				[]FeedListingSection{section},
@@ -694,6 +694,7 @@ func pathJoinURL(base, suffix string) string {
 }
 
 func (p *FeedsListingPlugin) renderFeedsPage(
+	logger lifecycle.Logger,
 	config *lifecycle.Config,
 	feedsPage *models.FeedsPageConfig,
 	sections []FeedListingSection,
@@ -714,7 +715,7 @@ func (p *FeedsListingPlugin) renderFeedsPage(
 	}
 
 	if !engine.TemplateExists(feedsPage.Template) {
-		log.Printf("[feeds_listing] Warning: template %q not found, skipping feeds listing page", feedsPage.Template)
+		logger.Warn("template not found, skipping feeds listing page", "template", feedsPage.Template)
 		return nil
 	}
 
@@ -756,7 +757,7 @@ func (p *FeedsListingPlugin) renderFeedsPage(
 		return fmt.Errorf("writing feeds listing page: %w", err)
 	}
 
-	log.Printf("[feeds_listing] Generated /%s/ with %d feeds", pageSlug, totalFeeds)
+	logger.Info("generated feeds listing", "path", "/"+pageSlug+"/", "feeds", totalFeeds)
 	return nil
 }
 
@@ -4,7 +4,6 @@ package plugins
 import (
 	"encoding/json"
 	"fmt"
-	"log"
 	"os"
 	"path/filepath"
 	"sort"
@@ -161,14 +160,14 @@ func (p *GardenViewPlugin) Write(m *lifecycle.Manager) error {
 
 	posts := m.Posts()
 	if len(posts) == 0 {
-		log.Printf("[garden_view] No posts found, skipping garden view")
+		m.Logger().Debug("no posts found, skipping garden view")
 		return nil
 	}
 
 	// Filter posts for the graph
 	filteredPosts := p.filterPosts(posts, &gardenConfig)
 	if len(filteredPosts) == 0 {
-		log.Printf("[garden_view] No visible posts after filtering, skipping garden view")
+		m.Logger().Debug("no visible posts after filtering, skipping garden view")
 		return nil
 	}
 
@@ -197,13 +196,12 @@ func (p *GardenViewPlugin) Write(m *lifecycle.Manager) error {
 
 	// Render garden page
 	if gardenConfig.IsRenderPage() {
-		if err := p.renderGardenPage(config, &gardenConfig, &graph); err != nil {
+		if err := p.renderGardenPage(m.Logger(), config, &gardenConfig, &graph); err != nil {
 			return err
 		}
 	}
 
-	log.Printf("[garden_view] Generated /%s/ with %d nodes and %d edges",
-		gardenConfig.GetPath(), len(graph.Nodes), len(graph.Edges))
+	m.Logger().Info("generated garden view", "path", "/"+gardenConfig.GetPath()+"/", "nodes", len(graph.Nodes), "edges", len(graph.Edges))
 
 	return nil
 }
@@ -518,7 +516,7 @@ func (p *GardenViewPlugin) exportGraphJSON(gardenDir string, graph *GardenGraph)
 }
 
 // renderGardenPage renders the garden HTML page.
-func (p *GardenViewPlugin) renderGardenPage(config *lifecycle.Config, gardenConfig *models.GardenConfig, graph *GardenGraph) error {
+func (p *GardenViewPlugin) renderGardenPage(logger lifecycle.Logger, config *lifecycle.Config, gardenConfig *models.GardenConfig, graph *GardenGraph) error {
 	// Create output directory
 	outputDir := config.OutputDir
 	gardenDir := filepath.Join(outputDir, gardenConfig.GetPath())
@@ -534,7 +532,7 @@ func (p *GardenViewPlugin) renderGardenPage(config *lifecycle.Config, gardenConf
 
 	templateName := gardenConfig.GetTemplate()
 	if !engine.TemplateExists(templateName) {
-		log.Printf("[garden_view] Warning: template %q not found, skipping garden page", templateName)
+		logger.Warn("template not found, skipping garden page", "template", templateName)
 		return nil
 	}
 
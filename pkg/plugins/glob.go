@@ -3,6 +3,7 @@ package plugins
 import (
 	"bufio"
 	"os"
+	"path"
 	"path/filepath"
 	"sort"
 	"strings"
@@ -14,8 +15,9 @@ import (
 
 // GlobPlugin discovers content files using glob patterns.
 type GlobPlugin struct {
-	// patterns are the glob patterns to match files against.
-	// Supports ** for recursive matching (doublestar patterns).
+	// patterns are the glob patterns to match files against, applied in
+	// order. Supports ** for recursive matching (doublestar patterns).
+	// Patterns starting with ! remove previously matched files.
 	patterns []string
 
 	// useGitignore determines whether to parse and respect .gitignore.
@@ -202,10 +204,18 @@ func shouldReuseCachedGlobFiles(m *lifecycle.Manager) bool {
 }
 
 // scanFiles performs full glob scan.
+// Patterns are applied in order: a pattern starting with ! removes the files
+// matched so far, and a later pattern can add some of them back. Gitignored
+// files are never added.
 func (p *GlobPlugin) scanFiles(absBaseDir string) []string {
 	fileSet := make(map[string]struct{})
 
 	for _, pattern := range p.patterns {
+		if negated, ok := NegatedGlobPattern(pattern); ok {
+			RemoveGlobMatches(fileSet, absBaseDir, negated)
+			continue
+		}
+
 		fullPattern := pattern
 		if !filepath.IsAbs(pattern) {
 			fullPattern = filepath.Join(absBaseDir, pattern)
@@ -243,6 +253,36 @@ func (p *GlobPlugin) scanFiles(absBaseDir string) []string {
 	return files
 }
 
+// NegatedGlobPattern reports whether pattern excludes files (starts with !)
+// and returns it without the leading !.
+func NegatedGlobPattern(pattern string) (string, bool) {
+	return strings.CutPrefix(pattern, "!")
+}
+
+// RemoveGlobMatches deletes from files every path matched by pattern.
+// Paths in files are relative to absBaseDir; a relative pattern is too.
+// A path is also removed when one of its parent directories matches, so
+// "drafts" excludes everything under drafts/ just like "drafts/**".
+func RemoveGlobMatches(files map[string]struct{}, absBaseDir, pattern string) {
+	if filepath.IsAbs(pattern) {
+		rel, err := filepath.Rel(absBaseDir, pattern)
+		if err != nil {
+			return
+		}
+		pattern = rel
+	}
+	pattern = strings.TrimSuffix(filepath.ToSlash(filepath.Clean(pattern)), "/")
+
+	for file := range files {
+		for candidate := filepath.ToSlash(file); candidate != "." && candidate != "/"; candidate = path.Dir(candidate) {
+			if matched, err := doublestar.Match(pattern, candidate); err == nil && matched {
+				delete(files, file)
+				break
+			}
+		}
+	}
+}
+
 // SetPatterns sets the glob patterns to use for file discovery.
 func (p *GlobPlugin) SetPatterns(patterns []string) {
 	p.patterns = patterns
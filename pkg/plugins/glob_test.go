@@ -61,3 +61,105 @@ func TestGlobPlugin_FastBuildRescansMovedFiles(t *testing.T) {
 		t.Fatalf("stale glob cache reused after move: %v", got)
 	}
 }
+
+func writeGlobFiles(t *testing.T, dir string, files ...string) {
+	t.Helper()
+	for _, file := range files {
+		path := filepath.Join(dir, filepath.FromSlash(file))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("MkdirAll(%s) error = %v", file, err)
+		}
+		if err := os.WriteFile(path, []byte("# "+file), 0o600); err != nil {
+			t.Fatalf("WriteFile(%s) error = %v", file, err)
+		}
+	}
+}
+
+func globFiles(t *testing.T, dir string, patterns []string, gitignore bool) []string {
+	t.Helper()
+	m := lifecycle.NewManager()
+	m.Config().ContentDir = dir
+	m.Config().GlobPatterns = patterns
+	m.Config().Extra = map[string]any{"use_gitignore": gitignore}
+
+	plugin := NewGlobPlugin()
+	if err := plugin.Configure(m); err != nil {
+		t.Fatalf("Configure() error = %v", err)
+	}
+	if err := plugin.Glob(m); err != nil {
+		t.Fatalf("Glob() error = %v", err)
+	}
+
+	files := m.Files()
+	for i := range files {
+		files[i] = filepath.ToSlash(files[i])
+	}
+	return files
+}
+
+func TestGlobPlugin_NegatedPatterns(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeGlobFiles(t, tmpDir,
+		"posts/hello.md",
+		"posts/2024/recap.md",
+		"posts/drafts/idea.md",
+		"posts/drafts/ready/launch.md",
+		"posts/drafts/ready/notes.md",
+		"pages/about.md",
+	)
+
+	tests := []struct {
+		name     string
+		patterns []string
+		want     []string
+	}{
+		{
+			name:     "exclude directory contents",
+			patterns: []string{"posts/**/*.md", "!posts/drafts/**"},
+			want:     []string{"posts/2024/recap.md", "posts/hello.md"},
+		},
+		{
+			name:     "exclude directory by name",
+			patterns: []string{"**/*.md", "!posts/drafts"},
+			want:     []string{"pages/about.md", "posts/2024/recap.md", "posts/hello.md"},
+		},
+		{
+			name:     "exclude single file",
+			patterns: []string{"posts/**/*.md", "!posts/hello.md"},
+			want:     []string{"posts/2024/recap.md", "posts/drafts/idea.md", "posts/drafts/ready/launch.md", "posts/drafts/ready/notes.md"},
+		},
+		{
+			name:     "re-include subset after broad exclude",
+			patterns: []string{"posts/**/*.md", "!posts/drafts/**", "posts/drafts/ready/*.md", "!**/notes.md"},
+			want:     []string{"posts/2024/recap.md", "posts/drafts/ready/launch.md", "posts/hello.md"},
+		},
+		{
+			name:     "negation only affects earlier patterns",
+			patterns: []string{"!pages/**", "pages/*.md"},
+			want:     []string{"pages/about.md"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := globFiles(t, tmpDir, tt.patterns, false)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Files() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGlobPlugin_ReincludeKeepsGitignore(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeGlobFiles(t, tmpDir, "posts/hello.md", "posts/drafts/launch.md", "posts/drafts/secret.md")
+	if err := os.WriteFile(filepath.Join(tmpDir, ".gitignore"), []byte("secret.md\n"), 0o600); err != nil {
+		t.Fatalf("WriteFile(.gitignore) error = %v", err)
+	}
+
+	got := globFiles(t, tmpDir, []string{"**/*.md", "!posts/drafts/**", "posts/drafts/*.md"}, true)
+	want := []string{"posts/drafts/launch.md", "posts/hello.md"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Files() = %v, want %v", got, want)
+	}
+}
@@ -4,7 +4,6 @@ package plugins
 import (
 	"fmt"
 	"html"
-	"log"
 	"regexp"
 	"strings"
 
@@ -69,11 +68,11 @@ func (p *HashtagTagsPlugin) Transform(m *lifecycle.Manager) error {
 	tagStats := p.buildTagStats(m)
 
 	if len(tagStats) == 0 {
-		log.Printf("[hashtag_tags] No tags found, skipping")
+		m.Logger().Debug("no tags found, skipping")
 		return nil
 	}
 
-	log.Printf("[hashtag_tags] Found %d tags", len(tagStats))
+	m.Logger().Debug("found tags", "tags", len(tagStats))
 
 	// Cache tag stats for other plugins
 	m.Cache().Set("hashtag_tag_stats", tagStats)
@@ -95,7 +94,7 @@ func (p *HashtagTagsPlugin) Transform(m *lifecycle.Manager) error {
 		}
 	}
 
-	log.Printf("[hashtag_tags] Processing %d posts", len(posts))
+	m.Logger().Debug("processing posts", "posts", len(posts))
 
 	return m.ProcessPostsSliceConcurrently(posts, func(post *models.Post) error {
 		content := p.processHashtagsInContent(post.Content, tagStats)
@@ -17,12 +17,9 @@ import (
 	"golang.org/x/net/html/atom"
 
 	"github.com/WaylonWalker/markata-go/pkg/lifecycle"
-	"github.com/WaylonWalker/markata-go/pkg/logging"
 	"github.com/WaylonWalker/markata-go/pkg/models"
 )
 
-var imageOptimizationLog = logging.Component("image_optimization").Phase("write")
-
 // ImageOptimizationPlugin generates modern image formats for local images.
 // It rewrites HTML to use <picture> with AVIF/WebP sources and caches encodes.
 type ImageOptimizationPlugin struct {
@@ -102,7 +99,7 @@ func (p *ImageOptimizationPlugin) Priority(stage lifecycle.Stage) int {
 
 func (p *ImageOptimizationPlugin) Configure(m *lifecycle.Manager) error {
 	p.config = parseImageOptimizationConfig(m.Config())
-	p.detectAvailableFormats()
+	p.detectAvailableFormats(m.Logger())
 	return nil
 }
 
@@ -145,13 +142,13 @@ func (p *ImageOptimizationPlugin) Write(m *lifecycle.Manager) error {
 	for _, target := range targets {
 		outputPath, err := resolveImageOutputPath(outputDir, target)
 		if err != nil {
-			imageOptimizationLog.Warnf("%v", err)
+			m.Logger().Warn("failed to resolve image path", "error", err)
 			continue
 		}
 
 		info, err := os.Stat(outputPath)
 		if err != nil {
-			imageOptimizationLog.Warnf("source image not found: %s", outputPath)
+			m.Logger().Warn("source image not found", "path", outputPath)
 			continue
 		}
 
@@ -162,7 +159,7 @@ func (p *ImageOptimizationPlugin) Write(m *lifecycle.Manager) error {
 			quality := p.qualityForFormat(format)
 			encoder := p.encoderPathForFormat(format)
 			if encoder == "" {
-				p.warnMissingEncoder(format)
+				p.warnMissingEncoder(m.Logger(), format)
 				continue
 			}
 
@@ -181,12 +178,12 @@ func (p *ImageOptimizationPlugin) Write(m *lifecycle.Manager) error {
 				}
 
 				if err := p.encodeImage(outputPath, variant.Path, format, quality, encoder, variant.Width); err != nil {
-					imageOptimizationLog.Warnf("%v", err)
+					m.Logger().Warn("failed to encode image", "path", variant.Path, "error", err)
 					continue
 				}
 
 				if err := writeImageCache(cachePath, outputPath, info, format, variant.Width, quality, encoder); err != nil {
-					imageOptimizationLog.Warnf("cache write failed: %v", err)
+					m.Logger().Warn("cache write failed", "error", err)
 				}
 			}
 		}
@@ -348,7 +345,7 @@ func parseIntSlice(values []any) []int {
 	return result
 }
 
-func (p *ImageOptimizationPlugin) detectAvailableFormats() {
+func (p *ImageOptimizationPlugin) detectAvailableFormats(logger lifecycle.Logger) {
 	formats := make([]string, 0, len(p.config.Formats))
 	seen := make(map[string]bool)
 
@@ -371,7 +368,7 @@ func (p *ImageOptimizationPlugin) detectAvailableFormats() {
 				p.avifencPath = path
 				formats = append(formats, normalized)
 			} else {
-				p.warnMissingEncoder(normalized)
+				p.warnMissingEncoder(logger, normalized)
 			}
 		case formatWebP:
 			path := p.config.CwebpPath
@@ -384,7 +381,7 @@ func (p *ImageOptimizationPlugin) detectAvailableFormats() {
 				p.cwebpPath = path
 				formats = append(formats, normalized)
 			} else {
-				p.warnMissingEncoder(normalized)
+				p.warnMissingEncoder(logger, normalized)
 			}
 		}
 	}
@@ -392,16 +389,16 @@ func (p *ImageOptimizationPlugin) detectAvailableFormats() {
 	p.availableFormats = formats
 }
 
-func (p *ImageOptimizationPlugin) warnMissingEncoder(format string) {
+func (p *ImageOptimizationPlugin) warnMissingEncoder(logger lifecycle.Logger, format string) {
 	if p.warnedEncoders[format] {
 		return
 	}
 	p.warnedEncoders[format] = true
 	switch format {
 	case formatAVIF:
-		imageOptimizationLog.Warnf("avifenc not found; skipping AVIF output")
+		logger.Warn("avifenc not found, skipping AVIF output")
 	case formatWebP:
-		imageOptimizationLog.Warnf("cwebp not found; skipping WebP output")
+		logger.Warn("cwebp not found, skipping WebP output")
 	}
 }
 
@@ -122,7 +122,7 @@ func (p *JSMinifyPlugin) Write(m *lifecycle.Manager) error {
 		return fmt.Errorf("finding JS files: %w", err)
 	}
 
-	runMinification("js_minify", jsFiles, p.isExcluded, func(path string) (int64, int64, error) {
+	runMinification(m.Logger(), jsFiles, p.isExcluded, func(path string) (int64, int64, error) {
 		return p.minifyFile(path)
 	}, m.Concurrency())
 
@@ -201,70 +201,69 @@ func (p *LoadPlugin) loadCachedPost(
 	return p.loadFile(file, baseDir, cache)
 }
 
+// loadAllFiles loads every file on the manager's worker pool, restoring
+// unchanged files from the build cache when there is one. Posts are added
+// in file order.
 func (p *LoadPlugin) loadAllFiles(m *lifecycle.Manager, files []string, baseDir string, cache *buildcache.Cache) error {
-	if cache == nil {
-		return p.loadSequential(m, files, baseDir, cache)
-	}
-
-	posts := make([]*models.Post, 0, len(files))
-	var firstErr error
 	affected := lifecycle.GetServeAffectedPaths(m)
-	useFastCache := lifecycle.IsServeFastMode(m) && len(affected) > 0
+	useFastCache := cache != nil && lifecycle.IsServeFastMode(m) && len(affected) > 0
 
-	for _, file := range files {
-		if useFastCache {
-			if len(affected) > 0 && affected[file] {
-				post, err := p.loadFile(file, baseDir, cache)
-				if err != nil {
-					if firstErr == nil {
-						firstErr = err
-					}
-					continue
-				}
-				posts = append(posts, post)
-				continue
-			}
-			cachedData := cache.GetCachedPostDataLatest(file)
-			if cachedData != nil {
-				posts = append(posts, p.restorePostFromCache(cachedData, cache))
-				continue
-			}
-		}
-
-		fullPath := file
-		if !filepath.IsAbs(file) {
-			fullPath = filepath.Join(baseDir, file)
-		}
-		stat, err := os.Stat(fullPath)
-		if err != nil {
-			if firstErr == nil {
-				firstErr = fmt.Errorf("failed to stat %s: %w", file, err)
-			}
-			continue
-		}
-		cachedData := cache.GetCachedPostData(file, stat.ModTime().UnixNano())
-		if cachedData != nil {
-			posts = append(posts, p.restorePostFromCache(cachedData, cache))
-			continue
-		}
-		post, err := p.loadFile(file, baseDir, cache)
+	posts := make([]*models.Post, len(files))
+	err := m.ProcessFilesConcurrently(files, func(i int, file string) error {
+		post, err := p.loadFileOrCached(file, baseDir, cache, useFastCache, affected)
 		if err != nil {
-			if firstErr == nil {
-				firstErr = err
-			}
-			continue
+			return err
 		}
-		posts = append(posts, post)
-	}
-	if firstErr != nil {
-		return firstErr
+		posts[i] = post
+		return nil
+	})
+	if err != nil {
+		return err
 	}
+
 	for _, post := range posts {
 		m.AddPost(post)
 	}
 	return nil
 }
 
+// loadFileOrCached restores a file's post from the build cache when it is
+// unchanged, and loads it otherwise. In serve fast mode, files outside
+// affected are restored from their latest cache entry without a stat.
+func (p *LoadPlugin) loadFileOrCached(
+	file string,
+	baseDir string,
+	cache *buildcache.Cache,
+	useFastCache bool,
+	affected map[string]bool,
+) (*models.Post, error) {
+	if cache == nil {
+		return p.loadFile(file, baseDir, cache)
+	}
+
+	if useFastCache {
+		if affected[file] {
+			return p.loadFile(file, baseDir, cache)
+		}
+		if cachedData := cache.GetCachedPostDataLatest(file); cachedData != nil {
+			return p.restorePostFromCache(cachedData, cache), nil
+		}
+	}
+
+	fullPath := file
+	if !filepath.IsAbs(file) {
+		fullPath = filepath.Join(baseDir, file)
+	}
+	stat, err := os.Stat(fullPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to stat %s: %w", file, err)
+	}
+	if cachedData := cache.GetCachedPostData(file, stat.ModTime().UnixNano()); cachedData != nil {
+		return p.restorePostFromCache(cachedData, cache), nil
+	}
+	return p.loadFile(file, baseDir, cache)
+}
+
 func (p *LoadPlugin) restoreFromCacheOrLoadChanged(
 	files []string,
 	baseDir string,
@@ -576,19 +575,6 @@ func (p *LoadPlugin) postToCachedData(post *models.Post) *buildcache.CachedPostD
 	}
 }
 
-// loadSequential loads files one at a time (used for small file counts).
-func (p *LoadPlugin) loadSequential(m *lifecycle.Manager, files []string, baseDir string, cache *buildcache.Cache) error {
-	for _, file := range files {
-		post, err := p.loadFile(file, baseDir, cache)
-		if err != nil {
-			return err
-		}
-		m.AddPost(post)
-	}
-
-	return nil
-}
-
 // ParsePostFromContent parses a markdown file content into a Post.
 // This is a lightweight helper for cache-backed workflows that need
 // frontmatter parsing and slug generation without running the full lifecycle.
@@ -7,7 +7,6 @@ import (
 	"fmt"
 	"html"
 	"io"
-	"log"
 	"net/http"
 	"net/url"
 	"os"
@@ -82,11 +81,11 @@ func (p *MentionsPlugin) Transform(m *lifecycle.Manager) error {
 	// Build handle resolution map from blogroll config
 	handleMap := p.buildHandleMap(m)
 
-	log.Printf("[mentions] Found %d handles in handleMap", len(handleMap))
+	m.Logger().Debug("built handle map", "handles", len(handleMap))
 
 	if len(handleMap) == 0 {
 		// No blogroll entries, nothing to resolve
-		log.Printf("[mentions] No handle map entries, skipping")
+		m.Logger().Debug("no handle map entries, skipping")
 		return nil
 	}
 
@@ -103,7 +102,7 @@ func (p *MentionsPlugin) Transform(m *lifecycle.Manager) error {
 		return !post.Skip && post.Content != ""
 	})
 
-	log.Printf("[mentions] Processing %d posts", len(posts))
+	m.Logger().Debug("processing posts", "posts", len(posts))
 
 	return m.ProcessPostsSliceConcurrently(posts, func(post *models.Post) error {
 		content := p.processChatAdmonitionTitles(post, handleMap)
@@ -123,7 +122,7 @@ type mentionEntry struct {
 }
 
 // registerFeedConfig registers a feed config's handle and aliases in the map.
-func (p *MentionsPlugin) registerFeedConfig(feedConfig *models.ExternalFeedConfig, handleMap map[string]*mentionEntry) {
+func (p *MentionsPlugin) registerFeedConfig(logger lifecycle.Logger, feedConfig *models.ExternalFeedConfig, handleMap map[string]*mentionEntry) {
 	if !feedConfig.IsActive() {
 		return
 	}
@@ -172,18 +171,18 @@ func (p *MentionsPlugin) registerFeedConfig(feedConfig *models.ExternalFeedConfi
 
 	// Register manual aliases
 	for _, alias := range feedConfig.Aliases {
-		p.registerAlias(alias, entry, handleMap)
+		p.registerAlias(logger, alias, entry, handleMap)
 	}
 }
 
 // registerAlias registers an alias in the handle map.
-func (p *MentionsPlugin) registerAlias(alias string, entry *mentionEntry, handleMap map[string]*mentionEntry) {
+func (p *MentionsPlugin) registerAlias(logger lifecycle.Logger, alias string, entry *mentionEntry, handleMap map[string]*mentionEntry) {
 	normalizedAlias := strings.ToLower(alias)
 	if normalizedAlias == "" {
 		return
 	}
 	if _, exists := handleMap[normalizedAlias]; exists {
-		log.Printf("[mentions] Warning: duplicate alias %q (first entry wins)", normalizedAlias)
+		logger.Warn("duplicate alias, first entry wins", "alias", normalizedAlias)
 		return
 	}
 	handleMap[normalizedAlias] = entry
@@ -192,7 +191,7 @@ func (p *MentionsPlugin) registerAlias(alias string, entry *mentionEntry, handle
 // registerAuthors registers authors from the site configuration as mentionable contacts.
 // Authors with a URL are registered using their config key (ID) as the handle.
 // Author metadata (name, bio, avatar) is used for hovercards.
-func (p *MentionsPlugin) registerAuthors(config *lifecycle.Config, handleMap map[string]*mentionEntry) {
+func (p *MentionsPlugin) registerAuthors(logger lifecycle.Logger, config *lifecycle.Config, handleMap map[string]*mentionEntry) {
 	modelsConfig, ok := getModelsConfig(config)
 	if !ok {
 		return
@@ -241,7 +240,7 @@ func (p *MentionsPlugin) registerAuthors(config *lifecycle.Config, handleMap map
 	}
 
 	if registered > 0 {
-		log.Printf("[mentions] Registered %d authors as mentionable contacts", registered)
+		logger.Debug("registered authors as mentionable contacts", "authors", registered)
 	}
 }
 
@@ -286,13 +285,13 @@ func (p *MentionsPlugin) buildHandleMap(m *lifecycle.Manager) map[string]*mentio
 	blogrollConfig := getBlogrollConfig(config)
 	mentionsConfig := getMentionsConfig(config)
 
-	log.Printf("[mentions] Blogroll config enabled: %v, feeds count: %d", blogrollConfig.Enabled, len(blogrollConfig.Feeds))
+	m.Logger().Debug("read blogroll config", "enabled", blogrollConfig.Enabled, "feeds", len(blogrollConfig.Feeds))
 
 	// Register from blogroll if enabled
 	if blogrollConfig.Enabled {
 		// Register feed configs
 		for i := range blogrollConfig.Feeds {
-			p.registerFeedConfig(&blogrollConfig.Feeds[i], handleMap)
+			p.registerFeedConfig(m.Logger(), &blogrollConfig.Feeds[i], handleMap)
 		}
 
 		// Register cached feeds
@@ -306,7 +305,7 @@ func (p *MentionsPlugin) buildHandleMap(m *lifecycle.Manager) map[string]*mentio
 	}
 
 	// Register authors from site configuration
-	p.registerAuthors(config, handleMap)
+	p.registerAuthors(m.Logger(), config, handleMap)
 
 	// Register from internal posts (from_posts sources)
 	p.registerFromPosts(m, mentionsConfig, handleMap)
@@ -366,7 +365,7 @@ func (p *MentionsPlugin) saveToCache(metadata *models.MentionMetadata, cacheDir
 }
 
 // fetchMetadata fetches metadata for a single domain, using cache if available.
-func (p *MentionsPlugin) fetchMetadata(domain, cacheDir string, maxAge, timeout time.Duration) *models.MentionMetadata {
+func (p *MentionsPlugin) fetchMetadata(logger lifecycle.Logger, domain, cacheDir string, maxAge, timeout time.Duration) *models.MentionMetadata {
 	// Try cache first
 	if cached := p.loadFromCache(domain, cacheDir, maxAge); cached != nil {
 		return cached
@@ -422,7 +421,7 @@ func (p *MentionsPlugin) fetchMetadata(domain, cacheDir string, maxAge, timeout
 
 	// Cache successful metadata
 	if err := p.saveToCache(metadata, cacheDir); err != nil {
-		log.Printf("mentions: failed to cache metadata for %s: %v", domain, err)
+		logger.Warn("failed to cache metadata", "domain", domain, "error", err)
 	}
 
 	return metadata
@@ -522,6 +521,7 @@ func (p *MentionsPlugin) fetchAllMetadata(m *lifecycle.Manager, handleMap map[st
 
 	timeout := time.Duration(config.GetTimeout()) * time.Second
 	cacheDir := config.GetCacheDir()
+	logger := m.Logger()
 
 	// Concurrent fetching with semaphore
 	semaphore := make(chan struct{}, config.GetConcurrentRequests())
@@ -536,7 +536,7 @@ func (p *MentionsPlugin) fetchAllMetadata(m *lifecycle.Manager, handleMap map[st
 			semaphore <- struct{}{}        // Acquire
 			defer func() { <-semaphore }() // Release
 
-			metadata := p.fetchMetadata(d, cacheDir, cacheDuration, timeout)
+			metadata := p.fetchMetadata(logger, d, cacheDir, cacheDuration, timeout)
 			mu.Lock()
 			metadataMap[d] = metadata
 			mu.Unlock()
@@ -1044,20 +1044,20 @@ func (p *MentionsPlugin) registerFromPosts(m *lifecycle.Manager, config models.M
 		// Parse and apply the filter
 		f, err := filter.Parse(source.Filter)
 		if err != nil {
-			log.Printf("mentions: error parsing from_posts filter %q: %v", source.Filter, err)
+			m.Logger().Warn("invalid from_posts filter", "filter", source.Filter, "error", err)
 			continue
 		}
 
 		matchedPosts := f.MatchAll(posts)
 
 		for _, post := range matchedPosts {
-			p.registerPostAsHandle(post, source, handleMap)
+			p.registerPostAsHandle(m.Logger(), post, source, handleMap)
 		}
 	}
 }
 
 // registerPostAsHandle registers a single post as a handle source.
-func (p *MentionsPlugin) registerPostAsHandle(post *models.Post, source models.MentionPostSource, handleMap map[string]*mentionEntry) {
+func (p *MentionsPlugin) registerPostAsHandle(logger lifecycle.Logger, post *models.Post, source models.MentionPostSource, handleMap map[string]*mentionEntry) {
 	// Get the handle from the specified field or fall back to slug
 	handle := p.getHandleFromPost(post, source.HandleField)
 	if handle == "" {
@@ -1093,7 +1093,7 @@ func (p *MentionsPlugin) registerPostAsHandle(post *models.Post, source models.M
 	if source.AliasesField != "" {
 		aliases := p.getAliasesFromPost(post, source.AliasesField)
 		for _, alias := range aliases {
-			p.registerAlias(alias, entry, handleMap)
+			p.registerAlias(logger, alias, entry, handleMap)
 		}
 	}
 }
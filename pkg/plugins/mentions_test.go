@@ -1211,7 +1211,7 @@ func TestMentionsPlugin_RegisterAuthors(t *testing.T) {
 	}
 
 	handleMap := make(map[string]*mentionEntry)
-	p.registerAuthors(config, handleMap)
+	p.registerAuthors(lifecycle.NopLogger(), config, handleMap)
 
 	// Waylon should be registered (has URL)
 	if entry, exists := handleMap["waylon"]; !exists {
@@ -1266,7 +1266,7 @@ func TestMentionsPlugin_RegisterAuthors_FirstEntryWins(t *testing.T) {
 		"alice": {Handle: "alice", SiteURL: "https://existing.com", Title: "Existing"},
 	}
 
-	p.registerAuthors(config, handleMap)
+	p.registerAuthors(lifecycle.NopLogger(), config, handleMap)
 
 	// Existing entry should win
 	if entry := handleMap["alice"]; entry.SiteURL != "https://existing.com" {
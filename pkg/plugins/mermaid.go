@@ -4,7 +4,6 @@ package plugins
 import (
 	"fmt"
 	"html"
-	"log"
 	"path/filepath"
 	"regexp"
 	"strings"
@@ -84,7 +83,7 @@ func (p *MermaidPlugin) Configure(m *lifecycle.Manager) error {
 	// For pre-render modes, resolve palette colors at build time so
 	// mermaid diagrams use the site's color scheme instead of defaults.
 	if p.config.Mode != mermaidModeClient && p.config.UseCSSVariables {
-		p.paletteColors = resolvePaletteColors(config.Extra)
+		p.paletteColors = resolvePaletteColors(m.Logger(), config.Extra)
 	}
 
 	return p.validateMode()
@@ -202,7 +201,7 @@ func (p *MermaidPlugin) Render(m *lifecycle.Manager) (err error) {
 	p.renderKey = p.rendererCacheKey()
 
 	if p.config.Mode != mermaidModeClient {
-		renderer, createErr := newMermaidRenderer(p.config, p.paletteColors)
+		renderer, createErr := newMermaidRenderer(m.Logger(), p.config, p.paletteColors)
 		if createErr != nil {
 			p.config.Mode = mermaidModeClient
 		} else {
@@ -470,7 +469,6 @@ func (p *MermaidPlugin) processPost(post *models.Post) error {
 			// For pre-rendering modes (cli/chromium): render to SVG
 			svgOutput, err := p.renderDiagram(post, diagramCode)
 			if err != nil {
-				log.Printf("[mermaid] render error for %s: %v", post.Path, err)
 				renderErr = models.NewMermaidRenderError(post.Path, p.config.Mode, "failed to render diagram", err)
 				return match
 			}
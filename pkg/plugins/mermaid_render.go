@@ -5,7 +5,6 @@ import (
 	"encoding/json"
 	"fmt"
 	"html"
-	"log"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -16,6 +15,7 @@ import (
 	cdruntime "github.com/chromedp/cdproto/runtime"
 	"github.com/chromedp/chromedp"
 
+	"github.com/WaylonWalker/markata-go/pkg/lifecycle"
 	"github.com/WaylonWalker/markata-go/pkg/models"
 	"github.com/WaylonWalker/markata-go/pkg/palettes"
 	"github.com/WaylonWalker/markata-go/pkg/runtimeenv"
@@ -47,7 +47,7 @@ type mermaidPaletteColors struct {
 
 // resolvePaletteColors loads and resolves palette colors for mermaid theming.
 // Returns nil if no palette is configured or colors cannot be resolved.
-func resolvePaletteColors(extra map[string]interface{}) *mermaidPaletteColors {
+func resolvePaletteColors(logger lifecycle.Logger, extra map[string]interface{}) *mermaidPaletteColors {
 	if extra == nil {
 		return nil
 	}
@@ -76,7 +76,7 @@ func resolvePaletteColors(extra map[string]interface{}) *mermaidPaletteColors {
 	loader := palettes.NewLoader()
 	palette, err := loader.Load(paletteName)
 	if err != nil {
-		log.Printf("[mermaid] could not load palette %q: %v", paletteName, err)
+		logger.Warn("could not load palette", "palette", paletteName, "error", err)
 		return nil
 	}
 
@@ -91,12 +91,12 @@ func resolvePaletteColors(extra map[string]interface{}) *mermaidPaletteColors {
 
 	// Only return if we got at least the accent color
 	if colors.Accent == "" {
-		log.Printf("[mermaid] palette %q has no accent color, using default theme", paletteName)
+		logger.Info("palette has no accent color, using default theme", "palette", paletteName)
 		return nil
 	}
 
-	log.Printf("[mermaid] resolved palette %q colors for theming (accent=%s, bg=%s, text=%s)",
-		paletteName, colors.Accent, colors.Background, colors.TextColor)
+	logger.Debug("resolved palette colors for theming",
+		"palette", paletteName, "accent", colors.Accent, "bg", colors.Background, "text", colors.TextColor)
 	return colors
 }
 
@@ -152,7 +152,6 @@ func (c *mermaidPaletteColors) themeVariablesJSON() string {
 
 	b, err := json.Marshal(vars)
 	if err != nil {
-		log.Printf("[mermaid] failed to marshal theme variables: %v", err)
 		return "{}"
 	}
 	return string(b)
@@ -230,6 +229,7 @@ func (r *cliRenderer) close() error {
 // themeVariables to mermaid.initialize() for palette-aware theming.
 // Otherwise, it falls back to mermaidcdp.Compiler for default theming.
 type chromiumRenderer struct {
+	logger        lifecycle.Logger
 	config        models.MermaidConfig
 	paletteColors *mermaidPaletteColors
 	once          sync.Once
@@ -265,7 +265,7 @@ func getMermaidJSCacheDir() (string, error) {
 	return cacheDir, nil
 }
 
-func loadBundledMermaidJSSource(version string) (string, error) {
+func loadBundledMermaidJSSource(logger lifecycle.Logger, version string) (string, error) {
 	bundledDir := runtimeenv.BundledMermaidDir()
 	if bundledDir == "" {
 		return "", os.ErrNotExist
@@ -278,24 +278,24 @@ func loadBundledMermaidJSSource(version string) (string, error) {
 	if len(data) == 0 {
 		return "", fmt.Errorf("bundled MermaidJS source is empty: %s", cacheFile)
 	}
-	log.Printf("[mermaid] chromium: loaded MermaidJS from bundled cache (%d bytes)", len(data))
+	logger.Debug("loaded MermaidJS from bundled cache", "bytes", len(data))
 	return string(data), nil
 }
 
 // loadOrDownloadJSSource loads the MermaidJS source from the local cache,
 // downloading it if not already cached. The cached file is stored at
 // ~/.cache/markata-go/mermaid/mermaid-v{version}.min.js
-func loadOrDownloadJSSource(ctx context.Context, version string) (string, error) {
+func loadOrDownloadJSSource(ctx context.Context, logger lifecycle.Logger, version string) (string, error) {
 	cacheDir, err := getMermaidJSCacheDir()
 	if err != nil {
-		if bundledSource, bundledErr := loadBundledMermaidJSSource(version); bundledErr == nil {
+		if bundledSource, bundledErr := loadBundledMermaidJSSource(logger, version); bundledErr == nil {
 			return bundledSource, nil
 		}
 		if runtimeenv.OfflineEnabled() {
 			return "", fmt.Errorf("failed to access MermaidJS cache in offline mode: %w", err)
 		}
 		// Cache dir unavailable; fall back to direct download
-		log.Printf("[mermaid] chromium: cache unavailable (%v), downloading directly", err)
+		logger.Info("MermaidJS cache unavailable, downloading directly", "error", err)
 		return mermaidcdp.DownloadJSSource(ctx, version)
 	}
 
@@ -303,10 +303,10 @@ func loadOrDownloadJSSource(ctx context.Context, version string) (string, error)
 
 	// Try loading from cache first
 	if data, err := os.ReadFile(cacheFile); err == nil && len(data) > 0 {
-		log.Printf("[mermaid] chromium: loaded MermaidJS from cache (%d bytes)", len(data))
+		logger.Debug("loaded MermaidJS from cache", "bytes", len(data))
 		return string(data), nil
 	}
-	if bundledSource, err := loadBundledMermaidJSSource(version); err == nil {
+	if bundledSource, err := loadBundledMermaidJSSource(logger, version); err == nil {
 		return bundledSource, nil
 	}
 	if runtimeenv.OfflineEnabled() {
@@ -314,18 +314,18 @@ func loadOrDownloadJSSource(ctx context.Context, version string) (string, error)
 	}
 
 	// Download and cache
-	log.Println("[mermaid] chromium: downloading MermaidJS source...")
+	logger.Info("downloading MermaidJS source", "version", version)
 	jsSource, err := mermaidcdp.DownloadJSSource(ctx, version)
 	if err != nil {
 		return "", err
 	}
-	log.Printf("[mermaid] chromium: downloaded MermaidJS (%d bytes)", len(jsSource))
+	logger.Debug("downloaded MermaidJS", "bytes", len(jsSource))
 
 	// Write to cache (best-effort, don't fail the build if caching fails)
 	if writeErr := os.WriteFile(cacheFile, []byte(jsSource), 0o600); writeErr != nil {
-		log.Printf("[mermaid] chromium: warning: failed to cache MermaidJS source: %v", writeErr)
+		logger.Warn("failed to cache MermaidJS source", "error", writeErr)
 	} else {
-		log.Printf("[mermaid] chromium: cached MermaidJS source at %s", cacheFile)
+		logger.Debug("cached MermaidJS source", "path", cacheFile)
 	}
 
 	return jsSource, nil
@@ -404,7 +404,7 @@ func (r *chromiumRenderer) ensureCompilerCustom(jsSource string) {
 		return
 	}
 
-	log.Println("[mermaid] chromium: initialized with palette-aware theming")
+	r.logger.Debug("initialized chromium with palette-aware theming")
 }
 
 // ensureCompilerFallback sets up the standard mermaidcdp.Compiler (no palette).
@@ -419,7 +419,6 @@ func (r *chromiumRenderer) ensureCompilerFallback(jsSource string) {
 	r.compiler, err = mermaidcdp.New(cfg)
 	if err != nil {
 		r.initErr = fmt.Errorf("failed to start chromium compiler: %w", err)
-		log.Printf("[mermaid] chromium: browser launch failed: %v", err)
 		return
 	}
 }
@@ -429,13 +428,13 @@ func (r *chromiumRenderer) ensureCompiler() error {
 		dlCtx, dlCancel := context.WithTimeout(context.Background(), 60*time.Second)
 		defer dlCancel()
 
-		jsSource, err := loadOrDownloadJSSource(dlCtx, mermaidJSVersion)
+		jsSource, err := loadOrDownloadJSSource(dlCtx, r.logger, mermaidJSVersion)
 		if err != nil {
 			r.initErr = fmt.Errorf("failed to obtain MermaidJS source: %w", err)
 			return
 		}
 
-		log.Println("[mermaid] chromium: launching headless browser...")
+		r.logger.Info("launching headless browser")
 
 		if r.paletteColors != nil {
 			r.ensureCompilerCustom(jsSource)
@@ -447,7 +446,7 @@ func (r *chromiumRenderer) ensureCompiler() error {
 			return
 		}
 
-		log.Println("[mermaid] chromium: browser ready")
+		r.logger.Debug("browser ready")
 
 		// Initialize the semaphore for concurrent renders
 		maxConcurrent := 4
@@ -531,12 +530,12 @@ func (r *chromiumRenderer) renderFallback(ctx context.Context, diagramCode strin
 
 func (r *chromiumRenderer) close() error {
 	if r.browserCancel != nil {
-		log.Println("[mermaid] chromium: closing browser (custom)")
+		r.logger.Debug("closing browser")
 		r.browserCancel()
 		return nil
 	}
 	if r.compiler != nil {
-		log.Println("[mermaid] chromium: closing browser")
+		r.logger.Debug("closing browser")
 		return r.compiler.Close()
 	}
 	return nil
@@ -557,7 +556,7 @@ func mergeContextLifetime(parentCtx, timeCtx context.Context) (context.Context,
 }
 
 // newMermaidRenderer creates the appropriate renderer based on the config mode
-func newMermaidRenderer(config models.MermaidConfig, paletteColors *mermaidPaletteColors) (mermaidRenderer, error) {
+func newMermaidRenderer(logger lifecycle.Logger, config models.MermaidConfig, paletteColors *mermaidPaletteColors) (mermaidRenderer, error) {
 	switch config.Mode {
 	case "client":
 		return &clientRenderer{config: config}, nil
@@ -580,7 +579,7 @@ func newMermaidRenderer(config models.MermaidConfig, paletteColors *mermaidPalet
 			err.Suggestion = info.InstallInstructions + "\n\n" + info.FallbackSuggestion
 			return nil, err
 		}
-		return &chromiumRenderer{config: config, paletteColors: paletteColors}, nil
+		return &chromiumRenderer{logger: logger, config: config, paletteColors: paletteColors}, nil
 
 	default:
 		return nil, fmt.Errorf("invalid mermaid rendering mode: %q", config.Mode)
@@ -7,6 +7,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/WaylonWalker/markata-go/pkg/lifecycle"
 	"github.com/WaylonWalker/markata-go/pkg/runtimeenv"
 )
 
@@ -22,7 +23,7 @@ func TestLoadOrDownloadJSSource_UsesBundledMermaidDir(t *testing.T) {
 	t.Setenv(runtimeenv.EnvOffline, "true")
 	t.Setenv("XDG_CACHE_HOME", t.TempDir())
 
-	got, err := loadOrDownloadJSSource(context.Background(), mermaidJSVersion)
+	got, err := loadOrDownloadJSSource(context.Background(), lifecycle.NopLogger(), mermaidJSVersion)
 	if err != nil {
 		t.Fatalf("loadOrDownloadJSSource() error = %v", err)
 	}
@@ -36,7 +37,7 @@ func TestLoadOrDownloadJSSource_OfflineMissingFails(t *testing.T) {
 	t.Setenv(runtimeenv.EnvOffline, "true")
 	t.Setenv("XDG_CACHE_HOME", t.TempDir())
 
-	_, err := loadOrDownloadJSSource(context.Background(), mermaidJSVersion)
+	_, err := loadOrDownloadJSSource(context.Background(), lifecycle.NopLogger(), mermaidJSVersion)
 	if err == nil {
 		t.Fatal("expected offline MermaidJS load to fail when cache is empty")
 	}
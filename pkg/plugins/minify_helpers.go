@@ -2,10 +2,12 @@
 package plugins
 
 import (
-	"log"
+	"fmt"
 	"path/filepath"
 	"strings"
 	"sync"
+
+	"github.com/WaylonWalker/markata-go/pkg/lifecycle"
 )
 
 // minifyFunc processes a single file and returns original and minified sizes.
@@ -23,20 +25,20 @@ type minifyResult struct {
 // runMinification processes a list of files through a minifier, logging statistics.
 // It is shared between css_minify and js_minify plugins.
 // Files are processed concurrently using a worker pool sized to the given concurrency.
-func runMinification(pluginName string, files []string, isExcluded excludeFunc, minify minifyFunc, concurrency int) {
+func runMinification(logger lifecycle.Logger, files []string, isExcluded excludeFunc, minify minifyFunc, concurrency int) {
 	if len(files) == 0 {
-		log.Printf("[%s] No files found", pluginName)
+		logger.Debug("no files found")
 		return
 	}
 
-	log.Printf("[%s] Starting minification", pluginName)
+	logger.Debug("starting minification", "files", len(files))
 
 	// Filter excluded files first (cheap, serial)
 	toProcess := make([]string, 0, len(files))
 	var filesSkipped int
 	for _, file := range files {
 		if isExcluded(file) {
-			log.Printf("[%s] Skipping excluded file: %s", pluginName, filepath.Base(file))
+			logger.Debug("skipping excluded file", "file", filepath.Base(file))
 			filesSkipped++
 			continue
 		}
@@ -44,7 +46,7 @@ func runMinification(pluginName string, files []string, isExcluded excludeFunc,
 	}
 
 	if len(toProcess) == 0 {
-		log.Printf("[%s] All files excluded (%d skipped)", pluginName, filesSkipped)
+		logger.Debug("all files excluded", "skipped", filesSkipped)
 		return
 	}
 
@@ -70,7 +72,7 @@ func runMinification(pluginName string, files []string, isExcluded excludeFunc,
 
 			original, minifiedSize, err := minify(f)
 			if err != nil {
-				log.Printf("[%s] Warning: failed to minify %s: %v", pluginName, filepath.Base(f), err)
+				logger.Warn("failed to minify", "file", filepath.Base(f), "error", err)
 				return
 			}
 			resultsCh <- minifyResult{original: original, minified: minifiedSize}
@@ -94,9 +96,10 @@ func runMinification(pluginName string, files []string, isExcluded excludeFunc,
 
 	if totalOriginal > 0 {
 		reduction := float64(totalOriginal-totalMinified) / float64(totalOriginal) * 100
-		log.Printf("[%s] Completed: %d files processed, %d skipped", pluginName, filesProcessed, filesSkipped)
-		log.Printf("[%s] Size reduction: %d -> %d bytes (%.1f%% smaller)",
-			pluginName, totalOriginal, totalMinified, reduction)
+		logger.Info("minification complete",
+			"processed", filesProcessed, "skipped", filesSkipped,
+			"original_bytes", totalOriginal, "minified_bytes", totalMinified,
+			"reduction", fmt.Sprintf("%.1f%%", reduction))
 	}
 }
 
@@ -14,12 +14,9 @@ import (
 
 	"github.com/WaylonWalker/markata-go/pkg/buildcache"
 	"github.com/WaylonWalker/markata-go/pkg/lifecycle"
-	"github.com/WaylonWalker/markata-go/pkg/logging"
 	"github.com/WaylonWalker/markata-go/pkg/models"
 )
 
-var pagefindLog = logging.Component("pagefind").Phase("cleanup")
-
 // defaultBundleDir is the default directory name for Pagefind search index files.
 const defaultBundleDir = "_pagefind"
 
@@ -68,18 +65,17 @@ func (p *PagefindPlugin) Cleanup(m *lifecycle.Manager) error {
 	cache := GetBuildCache(m)
 	corpusHash := p.computeCorpusHash(m, searchConfig)
 	if cache != nil && corpusHash != "" && cache.GetPagefindCorpusHash() == corpusHash && p.indexExists(config, searchConfig) {
-		pagefindLog.Printf("Skipping search index update (search corpus unchanged)")
+		m.Logger().Info("skipping search index update, search corpus unchanged")
 		return nil
 	}
 
 	verbose := searchConfig.Pagefind.IsVerbose()
 
 	// Try to find or install Pagefind
-	pagefindPath, err := p.findOrInstallPagefind(searchConfig, verbose)
+	pagefindPath, err := p.findOrInstallPagefind(m.Logger(), searchConfig, verbose)
 	if err != nil {
 		// Log warning but don't fail the build
-		pagefindLog.Warnf("%v", err)
-		pagefindLog.Printf("The site will work fine, just without search functionality")
+		m.Logger().Warn("pagefind unavailable, the site will work without search", "error", err)
 		return nil
 	}
 
@@ -88,14 +84,14 @@ func (p *PagefindPlugin) Cleanup(m *lifecycle.Manager) error {
 		return nil
 	}
 
-	if err := p.runPagefind(pagefindPath, config, searchConfig, verbose); err != nil {
+	if err := p.runPagefind(m.Logger(), pagefindPath, config, searchConfig, verbose); err != nil {
 		return err
 	}
 
 	if cache != nil && corpusHash != "" {
 		cache.SetPagefindCorpusHash(corpusHash)
 		if err := cache.Save(); err != nil {
-			pagefindLog.Warnf("failed to persist pagefind corpus hash: %v", err)
+			m.Logger().Warn("failed to persist pagefind corpus hash", "error", err)
 		}
 	}
 
@@ -104,7 +100,7 @@ func (p *PagefindPlugin) Cleanup(m *lifecycle.Manager) error {
 
 // findOrInstallPagefind locates or automatically installs the Pagefind binary.
 // It first checks the system PATH, then attempts auto-install if enabled.
-func (p *PagefindPlugin) findOrInstallPagefind(searchConfig models.SearchConfig, verbose bool) (string, error) {
+func (p *PagefindPlugin) findOrInstallPagefind(logger lifecycle.Logger, searchConfig models.SearchConfig, verbose bool) (string, error) {
 	// First, check if pagefind is in PATH
 	pagefindPath, err := exec.LookPath("pagefind")
 	if err == nil {
@@ -113,9 +109,9 @@ func (p *PagefindPlugin) findOrInstallPagefind(searchConfig models.SearchConfig,
 
 	// Check if auto-install is enabled
 	if !searchConfig.Pagefind.IsAutoInstallEnabled() {
-		pagefindLog.Warnf("pagefind not found in PATH, skipping search index generation")
-		pagefindLog.Printf("Install with: npm install -g pagefind OR cargo install pagefind")
-		pagefindLog.Printf("Or enable auto_install in config: [search.pagefind] auto_install = true")
+		logger.Warn("pagefind not found in PATH, skipping search index generation",
+			"install", "npm install -g pagefind OR cargo install pagefind",
+			"config", "[search.pagefind] auto_install = true")
 		return "", nil
 	}
 
@@ -125,10 +121,11 @@ func (p *PagefindPlugin) findOrInstallPagefind(searchConfig models.SearchConfig,
 		CacheDir: searchConfig.Pagefind.CacheDir,
 	})
 	installer.Verbose = verbose
+	installer.Logger = logger
 
 	// Attempt to install
 	if verbose {
-		pagefindLog.Printf("Pagefind not found in PATH, attempting auto-install...")
+		logger.Info("pagefind not found in PATH, attempting auto-install")
 	}
 	installedPath, err := installer.Install()
 	if err != nil {
@@ -139,7 +136,7 @@ func (p *PagefindPlugin) findOrInstallPagefind(searchConfig models.SearchConfig,
 }
 
 // runPagefind executes the Pagefind CLI to generate the search index.
-func (p *PagefindPlugin) runPagefind(pagefindPath string, config *lifecycle.Config, searchConfig models.SearchConfig, verbose bool) error {
+func (p *PagefindPlugin) runPagefind(logger lifecycle.Logger, pagefindPath string, config *lifecycle.Config, searchConfig models.SearchConfig, verbose bool) error {
 	outputDir := config.OutputDir
 
 	// Verify output directory exists
@@ -172,12 +169,12 @@ func (p *PagefindPlugin) runPagefind(pagefindPath string, config *lifecycle.Conf
 
 	// Run pagefind
 	if verbose {
-		pagefindLog.Printf("Generating search index in %s/%s", outputDir, bundleDir)
+		logger.Info("generating search index", "dir", filepath.Join(outputDir, bundleDir))
 	}
 	cmd := exec.Command(pagefindPath, args...)
 	cmd.Dir = "." // Run from project root
 	start := time.Now()
-	pagefindLog.Printf("Running subprocess: %s %s", pagefindPath, strings.Join(args, " "))
+	logger.Debug("running subprocess", "command", pagefindPath+" "+strings.Join(args, " "))
 
 	// Capture output - show all in verbose mode, only errors when quiet
 	if verbose {
@@ -196,7 +193,7 @@ func (p *PagefindPlugin) runPagefind(pagefindPath string, config *lifecycle.Conf
 			}
 			return fmt.Errorf("pagefind indexing failed: %w", err)
 		}
-		pagefindLog.Printf("Subprocess completed in %v", time.Since(start))
+		logger.Debug("subprocess completed", "duration", time.Since(start))
 
 		// Verify the index was created
 		indexPath := filepath.Join(outputDir, bundleDir, "pagefind.js")
@@ -210,7 +207,7 @@ func (p *PagefindPlugin) runPagefind(pagefindPath string, config *lifecycle.Conf
 	if err := cmd.Run(); err != nil {
 		return fmt.Errorf("pagefind indexing failed: %w", err)
 	}
-	pagefindLog.Printf("Subprocess completed in %v", time.Since(start))
+	logger.Debug("subprocess completed", "duration", time.Since(start))
 
 	// Verify the index was created
 	indexPath := filepath.Join(outputDir, bundleDir, "pagefind.js")
@@ -218,7 +215,7 @@ func (p *PagefindPlugin) runPagefind(pagefindPath string, config *lifecycle.Conf
 		return fmt.Errorf("pagefind did not create expected index file: %s", indexPath)
 	}
 
-	pagefindLog.Printf("Search index generated successfully")
+	logger.Info("search index generated")
 	return nil
 }
 
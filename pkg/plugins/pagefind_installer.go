@@ -17,11 +17,9 @@ import (
 	"strings"
 	"time"
 
-	"github.com/WaylonWalker/markata-go/pkg/logging"
+	"github.com/WaylonWalker/markata-go/pkg/lifecycle"
 )
 
-var pagefindInstallLog = logging.Component("pagefind_installer").Phase("cleanup")
-
 // Constants for Pagefind installer.
 const (
 	// pagefindBinaryName is the name of the Pagefind binary.
@@ -55,6 +53,9 @@ type PagefindInstaller struct {
 	// Verbose enables verbose output during installation.
 	Verbose bool
 
+	// Logger receives verbose output. If nil, it goes to a text logger.
+	Logger lifecycle.Logger
+
 	// client is the HTTP client used for downloads.
 	client *http.Client
 }
@@ -146,6 +147,14 @@ func NewPagefindInstaller() *PagefindInstaller {
 	}
 }
 
+// logger returns the Logger verbose output is written to.
+func (i *PagefindInstaller) logger() lifecycle.Logger {
+	if i.Logger != nil {
+		return i.Logger
+	}
+	return lifecycle.NewTextLogger(nil)
+}
+
 // NewPagefindInstallerWithConfig creates a new PagefindInstaller from config.
 func NewPagefindInstallerWithConfig(config PagefindInstallerConfig) *PagefindInstaller {
 	installer := NewPagefindInstaller()
@@ -348,7 +357,7 @@ func (i *PagefindInstaller) downloadAsset(version, platformAsset string) (string
 	assetURL := buildAssetURL(version, platformAsset)
 
 	if i.Verbose {
-		pagefindInstallLog.Printf("Downloading Pagefind %s for %s...", version, platformAsset)
+		i.logger().Info("downloading Pagefind", "version", version, "asset", platformAsset)
 	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), defaultHTTPTimeout)
@@ -388,14 +397,14 @@ func (i *PagefindInstaller) downloadAsset(version, platformAsset string) (string
 	}
 
 	if i.Verbose {
-		pagefindInstallLog.Printf("Downloaded %d bytes", written)
+		i.logger().Info("downloaded Pagefind", "bytes", written)
 	}
 
 	return tmpFile.Name(), nil
 }
 
 // verifyChecksum verifies the SHA256 checksum of a downloaded file.
-func verifyChecksum(filePath, expectedChecksum string, verbose bool) error {
+func verifyChecksum(filePath, expectedChecksum string) error {
 	file, err := os.Open(filePath)
 	if err != nil {
 		return NewPagefindInstallError("verify", "failed to open file for verification", err)
@@ -417,9 +426,6 @@ func verifyChecksum(filePath, expectedChecksum string, verbose bool) error {
 		)
 	}
 
-	if verbose {
-		pagefindInstallLog.Printf("Checksum verified: %s", actualChecksum[:16]+"...")
-	}
 	return nil
 }
 
@@ -495,7 +501,7 @@ func (i *PagefindInstaller) extractBinary(archivePath, version string) (string,
 		}
 
 		if i.Verbose {
-			pagefindInstallLog.Printf("Extracted %s to %s", binaryName, versionDir)
+			i.logger().Info("extracted Pagefind", "binary", binaryName, "dir", versionDir)
 		}
 		break
 	}
@@ -528,7 +534,7 @@ func (i *PagefindInstaller) Install() (string, error) {
 			return "", err
 		}
 		if i.Verbose {
-			pagefindInstallLog.Printf("Using cached Pagefind %s", version)
+			i.logger().Info("using cached Pagefind", "version", version)
 		}
 		return binaryPath, nil
 	}
@@ -553,9 +559,12 @@ func (i *PagefindInstaller) Install() (string, error) {
 	defer os.Remove(archivePath)
 
 	// Verify checksum (CRITICAL for security)
-	if err := verifyChecksum(archivePath, expectedChecksum, i.Verbose); err != nil {
+	if err := verifyChecksum(archivePath, expectedChecksum); err != nil {
 		return "", err
 	}
+	if i.Verbose {
+		i.logger().Info("checksum verified", "sha256", expectedChecksum[:16]+"...")
+	}
 
 	// Extract the binary
 	binaryPath, err := i.extractBinary(archivePath, version)
@@ -564,7 +573,7 @@ func (i *PagefindInstaller) Install() (string, error) {
 	}
 
 	if i.Verbose {
-		pagefindInstallLog.Printf("Successfully installed Pagefind %s", version)
+		i.logger().Info("installed Pagefind", "version", version)
 	}
 	return binaryPath, nil
 }
@@ -11,14 +11,11 @@ import (
 	"strings"
 
 	"github.com/WaylonWalker/markata-go/pkg/lifecycle"
-	"github.com/WaylonWalker/markata-go/pkg/logging"
 	"github.com/WaylonWalker/markata-go/pkg/models"
 	"github.com/WaylonWalker/markata-go/pkg/palettes"
 	"github.com/WaylonWalker/markata-go/pkg/templates"
 )
 
-var paletteCSSLog = logging.Component("palette_css")
-
 const (
 	themeModeDark  = "dark"
 	themeModeLight = "light"
@@ -66,19 +63,19 @@ func (p *PaletteCSSPlugin) Configure(m *lifecycle.Manager) error {
 
 	if paletteName == "generated" {
 		if seedColor == "" {
-			paletteCSSLog.Phase("configure").Warnf("palette is 'generated' but no seed_color provided. Using fallback.")
+			m.Logger().Warn("palette is 'generated' but no seed_color provided, using fallback")
 		} else {
 			lightP, err := palettes.GenerateTriadicPalette(seedColor, palettes.VariantLight)
 			if err == nil {
 				loader.AddPalette("generated-light", lightP)
 			} else {
-				paletteCSSLog.Phase("configure").Errorf("generating light palette from seed %q: %v", seedColor, err)
+				m.Logger().Error("failed to generate light palette from seed", "seed_color", seedColor, "error", err)
 			}
 			darkP, err := palettes.GenerateTriadicPalette(seedColor, palettes.VariantDark)
 			if err == nil {
 				loader.AddPalette("generated-dark", darkP)
 			} else {
-				paletteCSSLog.Phase("configure").Errorf("generating dark palette from seed %q: %v", seedColor, err)
+				m.Logger().Error("failed to generate dark palette from seed", "seed_color", seedColor, "error", err)
 			}
 		}
 	}
@@ -100,7 +97,7 @@ func (p *PaletteCSSPlugin) Configure(m *lifecycle.Manager) error {
 	m.SetAssetHash("css/palette.css", hash)
 	templates.SetAssetHashes(map[string]string{"css/palette.css": hash})
 
-	paletteCSSLog.Phase("configure").Printf("Registered hash %s for palette.css", hash)
+	m.Logger().Debug("registered asset hash", "asset", "css/palette.css", "hash", hash)
 
 	return nil
 }
@@ -121,11 +118,11 @@ func (p *PaletteCSSPlugin) Write(m *lifecycle.Manager) error {
 	userVariables := p.getThemeVariables(config.Extra)
 	if paletteName == "" {
 		// No palette configured, skip
-		paletteCSSLog.Phase("write").Printf("No palette configured, skipping CSS generation")
+		m.Logger().Debug("no palette configured, skipping CSS generation")
 		return nil
 	}
 
-	paletteCSSLog.Phase("write").Printf("Generating CSS for palette: %s (light: %s, dark: %s)", paletteName, paletteLight, paletteDark)
+	m.Logger().Debug("generating palette CSS", "palette", paletteName, "light", paletteLight, "dark", paletteDark)
 
 	// Check if theme switcher is enabled
 	switcherEnabled := p.isSwitcherEnabled(config.Extra)
@@ -135,19 +132,19 @@ func (p *PaletteCSSPlugin) Write(m *lifecycle.Manager) error {
 
 	if paletteName == "generated" {
 		if seedColor == "" {
-			paletteCSSLog.Phase("write").Warnf("palette is 'generated' but no seed_color provided. Using fallback.")
+			m.Logger().Warn("palette is 'generated' but no seed_color provided, using fallback")
 		} else {
 			lightP, err := palettes.GenerateTriadicPalette(seedColor, palettes.VariantLight)
 			if err == nil {
 				loader.AddPalette("generated-light", lightP)
 			} else {
-				paletteCSSLog.Phase("write").Errorf("generating light palette from seed %q: %v", seedColor, err)
+				m.Logger().Error("failed to generate light palette from seed", "seed_color", seedColor, "error", err)
 			}
 			darkP, err := palettes.GenerateTriadicPalette(seedColor, palettes.VariantDark)
 			if err == nil {
 				loader.AddPalette("generated-dark", darkP)
 			} else {
-				paletteCSSLog.Phase("write").Errorf("generating dark palette from seed %q: %v", seedColor, err)
+				m.Logger().Error("failed to generate dark palette from seed", "seed_color", seedColor, "error", err)
 			}
 		}
 	}
@@ -166,7 +163,7 @@ func (p *PaletteCSSPlugin) Write(m *lifecycle.Manager) error {
 	cssPath := filepath.Join(cssDir, "palette.css")
 	if existing, err := os.ReadFile(cssPath); err == nil {
 		if bytes.Equal(existing, []byte(css)) {
-			paletteCSSLog.Phase("write").Printf("CSS unchanged, skipping write")
+			m.Logger().Debug("CSS unchanged, skipping write")
 			return nil
 		}
 	} else if !os.IsNotExist(err) {
@@ -190,7 +187,7 @@ func (p *PaletteCSSPlugin) Write(m *lifecycle.Manager) error {
 		}
 	}
 
-	paletteCSSLog.Phase("write").Printf("Wrote %d bytes to %s", len(css), cssPath)
+	m.Logger().Debug("wrote palette CSS", "bytes", len(css), "path", cssPath)
 
 	return nil
 }
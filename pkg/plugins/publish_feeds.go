@@ -18,14 +18,11 @@ import (
 
 	"github.com/WaylonWalker/markata-go/pkg/buildcache"
 	"github.com/WaylonWalker/markata-go/pkg/lifecycle"
-	"github.com/WaylonWalker/markata-go/pkg/logging"
 	"github.com/WaylonWalker/markata-go/pkg/models"
 	"github.com/WaylonWalker/markata-go/pkg/templates"
 	"github.com/WaylonWalker/markata-go/pkg/themes"
 )
 
-var publishFeedsLog = logging.Component("publish_feeds").Phase("write")
-
 // PublishFeedsPlugin writes feeds to multiple output formats during the write stage.
 // It also registers synthetic posts in the Configure stage so they can be resolved by wikilinks.
 type PublishFeedsPlugin struct {
@@ -130,9 +127,11 @@ func (p *PublishFeedsPlugin) Write(m *lifecycle.Manager) error {
 	}
 
 	if shouldPublishFeedsAsync(m) {
+		// The build moves on to later stages, so keep this stage's logger
+		logger := m.Logger()
 		go func() {
-			if err := p.publishFeedsAsync(m, feedConfigs); err != nil {
-				publishFeedsLog.Errorf("async publish failed: %v", err)
+			if err := p.publishFeedsAsync(m, logger, feedConfigs); err != nil {
+				logger.Error("async publish failed", "error", err)
 			}
 		}()
 		return nil
@@ -167,6 +166,7 @@ func shouldPublishFeedsAsync(m *lifecycle.Manager) bool {
 
 func (p *PublishFeedsPlugin) publishFeeds(m *lifecycle.Manager, config *lifecycle.Config, feedConfigs []models.FeedConfig) error {
 	outputDir := config.OutputDir
+	logger := m.Logger()
 	// Copy XSL stylesheets to output directory for styled RSS/Atom feeds
 	if err := p.copyXSLStylesheets(config, outputDir); err != nil {
 		return fmt.Errorf("copying XSL stylesheets: %w", err)
@@ -195,7 +195,7 @@ func (p *PublishFeedsPlugin) publishFeeds(m *lifecycle.Manager, config *lifecycl
 				skippedCount++
 				continue
 			}
-			if err := p.publishFeed(fc, config, outputDir); err != nil {
+			if err := p.publishFeed(logger, fc, config, outputDir); err != nil {
 				return fmt.Errorf("publishing feed %q: %w", fc.Slug, err)
 			}
 			p.cacheFeedHash(fc, buildCache, hash)
@@ -223,7 +223,7 @@ func (p *PublishFeedsPlugin) publishFeeds(m *lifecycle.Manager, config *lifecycl
 			semaphore <- struct{}{}        // Acquire
 			defer func() { <-semaphore }() // Release
 
-			if err := p.publishFeed(fc, config, outputDir); err != nil {
+			if err := p.publishFeed(logger, fc, config, outputDir); err != nil {
 				errChan <- fmt.Errorf("publishing feed %q: %w", fc.Slug, err)
 				return
 			}
@@ -240,7 +240,7 @@ func (p *PublishFeedsPlugin) publishFeeds(m *lifecycle.Manager, config *lifecycl
 
 	// Log incremental stats if any feeds were skipped
 	if skippedCount > 0 {
-		publishFeedsLog.Printf("Incremental: %d feeds skipped, %d rebuilt", skippedCount, rebuiltCount)
+		logger.Info("incremental feed publish", "skipped", skippedCount, "rebuilt", rebuiltCount)
 	}
 
 	// Check for errors
@@ -253,7 +253,7 @@ func (p *PublishFeedsPlugin) publishFeeds(m *lifecycle.Manager, config *lifecycl
 	return nil
 }
 
-func (p *PublishFeedsPlugin) publishFeedsAsync(m *lifecycle.Manager, feedConfigs []models.FeedConfig) error {
+func (p *PublishFeedsPlugin) publishFeedsAsync(m *lifecycle.Manager, logger lifecycle.Logger, feedConfigs []models.FeedConfig) error {
 	config := m.Config()
 	outputDir := config.OutputDir
 	if len(feedConfigs) == 0 {
@@ -281,7 +281,7 @@ func (p *PublishFeedsPlugin) publishFeedsAsync(m *lifecycle.Manager, feedConfigs
 				skippedCount++
 				continue
 			}
-			if err := p.publishFeed(fc, config, outputDir); err != nil {
+			if err := p.publishFeed(logger, fc, config, outputDir); err != nil {
 				return fmt.Errorf("publishing feed %q: %w", fc.Slug, err)
 			}
 			rebuiltCount++
@@ -305,7 +305,7 @@ func (p *PublishFeedsPlugin) publishFeedsAsync(m *lifecycle.Manager, feedConfigs
 					skippedCount++
 					return
 				}
-				if err := p.publishFeed(fc, config, outputDir); err != nil {
+				if err := p.publishFeed(logger, fc, config, outputDir); err != nil {
 					errChan <- fmt.Errorf("publishing feed %q: %w", fc.Slug, err)
 					return
 				}
@@ -324,7 +324,7 @@ func (p *PublishFeedsPlugin) publishFeedsAsync(m *lifecycle.Manager, feedConfigs
 	}
 
 	if skippedCount > 0 || rebuiltCount > 0 {
-		publishFeedsLog.Printf("Async: %d feeds skipped, %d rebuilt", skippedCount, rebuiltCount)
+		logger.Info("async feed publish", "skipped", skippedCount, "rebuilt", rebuiltCount)
 	}
 
 	return nil
@@ -559,7 +559,7 @@ type feedFormatPublisher struct {
 }
 
 // publishFeed publishes a single feed in all configured formats.
-func (p *PublishFeedsPlugin) publishFeed(fc *models.FeedConfig, config *lifecycle.Config, outputDir string) error {
+func (p *PublishFeedsPlugin) publishFeed(logger lifecycle.Logger, fc *models.FeedConfig, config *lifecycle.Config, outputDir string) error {
 	feedDir := p.determineFeedDir(outputDir, fc.Slug)
 	modelsConfig := ToModelsConfig(config)
 	syndication := getSyndicationConfig(config)
@@ -572,8 +572,8 @@ func (p *PublishFeedsPlugin) publishFeed(fc *models.FeedConfig, config *lifecycl
 
 	// Define all format publishers with their configurations
 	publishers := []feedFormatPublisher{
-		{name: "HTML", enabled: fc.Formats.HTML, publish: func() error { return p.publishHTMLPages(htmlFC, config, modelsConfig, feedDir) }},
-		{name: "SimpleHTML", enabled: fc.Formats.SimpleHTML, publish: func() error { return p.publishSimpleHTMLPages(htmlFC, config, modelsConfig, feedDir) }},
+		{name: "HTML", enabled: fc.Formats.HTML, publish: func() error { return p.publishHTMLPages(logger, htmlFC, config, modelsConfig, feedDir) }},
+		{name: "SimpleHTML", enabled: fc.Formats.SimpleHTML, publish: func() error { return p.publishSimpleHTMLPages(logger, htmlFC, config, modelsConfig, feedDir) }},
 		{name: "RSS", enabled: fc.Formats.RSS, publish: func() error { return p.publishRSS(syndicationFC, config, feedDir, false) }},
 		{name: "Atom", enabled: fc.Formats.Atom, publish: func() error { return p.publishAtom(syndicationFC, config, feedDir, false) }},
 		{name: "JSON", enabled: fc.Formats.JSON, publish: func() error { return p.publishJSON(syndicationFC, config, feedDir, false) }, ext: "json", targetFile: "feed.json"},
@@ -610,6 +610,33 @@ func (p *PublishFeedsPlugin) publishFeed(fc *models.FeedConfig, config *lifecycl
 	return nil
 }
 
+// RenderFeedFormat renders one format of a collected feed to a string
+// instead of writing it: "rss", "atom", "json", "sitemap", or "html" for
+// the first HTML page. The output matches what a build publishes.
+func (p *PublishFeedsPlugin) RenderFeedFormat(logger lifecycle.Logger, fc *models.FeedConfig, config *lifecycle.Config, format string) (string, error) {
+	syndicationFC := feedConfigWithOutputPosts(fc)
+
+	switch format {
+	case "rss":
+		return GenerateRSSFromFeedConfig(p.syndicationFeedConfig(syndicationFC, config, false), config)
+	case "atom":
+		return GenerateAtomFromFeedConfig(p.syndicationFeedConfig(syndicationFC, config, false), config)
+	case "json":
+		return GenerateJSONFeedFromFeedConfig(p.syndicationFeedConfig(syndicationFC, config, false), config)
+	case "sitemap":
+		return generateFeedSitemap(syndicationFC, config)
+	case "html":
+		htmlFC := feedConfigWithRenderablePosts(fc)
+		page := models.FeedPage{Number: 1}
+		if len(htmlFC.Pages) > 0 {
+			page = htmlFC.Pages[0]
+		}
+		return p.generateFeedPageHTML(logger, htmlFC, &page, config, nil)
+	default:
+		return "", fmt.Errorf("unsupported feed format %q", format)
+	}
+}
+
 func feedConfigWithRenderablePosts(fc *models.FeedConfig) *models.FeedConfig {
 	clone := cloneFeedConfigWithPosts(fc, filterFeedPagePosts(fc.Posts, fc.IncludePrivate))
 	baseURL := "/" + clone.Slug
@@ -687,7 +714,7 @@ func (p *PublishFeedsPlugin) publishFormat(pub feedFormatPublisher, slug, output
 }
 
 // publishHTMLPages publishes HTML pages for a paginated feed.
-func (p *PublishFeedsPlugin) publishHTMLPages(fc *models.FeedConfig, config *lifecycle.Config, modelsConfig *models.Config, feedDir string) error {
+func (p *PublishFeedsPlugin) publishHTMLPages(logger lifecycle.Logger, fc *models.FeedConfig, config *lifecycle.Config, modelsConfig *models.Config, feedDir string) error {
 	if err := p.cleanupPaginatedFeedDirs(feedDir, "", fc.Pages); err != nil {
 		return fmt.Errorf("cleaning html pagination dirs: %w", err)
 	}
@@ -707,7 +734,7 @@ func (p *PublishFeedsPlugin) publishHTMLPages(fc *models.FeedConfig, config *lif
 		}
 
 		// Generate HTML content
-		html, err := p.generateFeedPageHTML(fc, page, config, modelsConfig)
+		html, err := p.generateFeedPageHTML(logger, fc, page, config, modelsConfig)
 		if err != nil {
 			return fmt.Errorf("generating page %d: %w", page.Number, err)
 		}
@@ -722,7 +749,7 @@ func (p *PublishFeedsPlugin) publishHTMLPages(fc *models.FeedConfig, config *lif
 
 // publishSimpleHTMLPages publishes the simple (compact list) HTML pages for a feed.
 // Output is written to feedDir/simple/ with pagination at feedDir/simple/page/N/.
-func (p *PublishFeedsPlugin) publishSimpleHTMLPages(fc *models.FeedConfig, config *lifecycle.Config, modelsConfig *models.Config, feedDir string) error {
+func (p *PublishFeedsPlugin) publishSimpleHTMLPages(logger lifecycle.Logger, fc *models.FeedConfig, config *lifecycle.Config, modelsConfig *models.Config, feedDir string) error {
 	simpleDir := filepath.Join(feedDir, "simple")
 	if err := p.cleanupPaginatedFeedDirs(feedDir, "simple", fc.Pages); err != nil {
 		return fmt.Errorf("cleaning simple pagination dirs: %w", err)
@@ -758,7 +785,7 @@ func (p *PublishFeedsPlugin) publishSimpleHTMLPages(fc *models.FeedConfig, confi
 		}
 
 		// Generate HTML content using simple-feed.html template
-		htmlContent, err := p.generateSimpleFeedPageHTML(fc, &adjustedPage, config, modelsConfig)
+		htmlContent, err := p.generateSimpleFeedPageHTML(logger, fc, &adjustedPage, config, modelsConfig)
 		if err != nil {
 			return fmt.Errorf("generating simple page %d: %w", adjustedPage.Number, err)
 		}
@@ -842,7 +869,7 @@ func (p *PublishFeedsPlugin) adjustPageURLsForSimple(page *models.FeedPage, simp
 }
 
 // generateSimpleFeedPageHTML generates HTML for a simple feed page using the simple-feed.html template.
-func (p *PublishFeedsPlugin) generateSimpleFeedPageHTML(fc *models.FeedConfig, page *models.FeedPage, config *lifecycle.Config, modelsConfig *models.Config) (string, error) {
+func (p *PublishFeedsPlugin) generateSimpleFeedPageHTML(logger lifecycle.Logger, fc *models.FeedConfig, page *models.FeedPage, config *lifecycle.Config, modelsConfig *models.Config) (string, error) {
 	// Get templates directory from config
 	templatesDir := PluginNameTemplates
 	if extra, ok := config.Extra["templates_dir"].(string); ok && extra != "" {
@@ -887,7 +914,7 @@ func (p *PublishFeedsPlugin) generateSimpleFeedPageHTML(fc *models.FeedConfig, p
 
 		htmlContent, err := engine.Render(templateName, ctx)
 		if err != nil {
-			publishFeedsLog.Warnf("template rendering failed for %s: %v (falling back to built-in template)", templateName, err)
+			logger.Warn("template rendering failed, falling back to built-in template", "template", templateName, "error", err)
 		} else {
 			return htmlContent, nil
 		}
@@ -898,7 +925,7 @@ func (p *PublishFeedsPlugin) generateSimpleFeedPageHTML(fc *models.FeedConfig, p
 }
 
 // generateFeedPageHTML generates HTML for a feed page.
-func (p *PublishFeedsPlugin) generateFeedPageHTML(fc *models.FeedConfig, page *models.FeedPage, config *lifecycle.Config, modelsConfig *models.Config) (string, error) {
+func (p *PublishFeedsPlugin) generateFeedPageHTML(logger lifecycle.Logger, fc *models.FeedConfig, page *models.FeedPage, config *lifecycle.Config, modelsConfig *models.Config) (string, error) {
 	templateName := fc.Templates.HTML
 	if templateName == "" {
 		templateName = "feed.html"
@@ -960,7 +987,7 @@ func (p *PublishFeedsPlugin) generateFeedPageHTML(fc *models.FeedConfig, page *m
 		html, err := engine.Render(templateName, ctx)
 		if err != nil {
 			// Log template rendering errors to help debug issues
-			publishFeedsLog.Warnf("template rendering failed for %s: %v (falling back to built-in template)", templateName, err)
+			logger.Warn("template rendering failed, falling back to built-in template", "template", templateName, "error", err)
 		} else {
 			return html, nil
 		}
@@ -1228,6 +1255,18 @@ func (p *PublishFeedsPlugin) publishText(fc *models.FeedConfig, slug, outputDir
 
 // publishSitemap generates and writes a sitemap XML file for feed posts.
 func (p *PublishFeedsPlugin) publishSitemap(fc *models.FeedConfig, config *lifecycle.Config, feedDir string) error {
+	xmlContent, err := generateFeedSitemap(fc, config)
+	if err != nil {
+		return err
+	}
+
+	// Write sitemap.xml
+	sitemapPath := filepath.Join(feedDir, "sitemap.xml")
+	return p.safeWriteFile(sitemapPath, []byte(xmlContent))
+}
+
+// generateFeedSitemap returns the sitemap XML for a feed's posts.
+func generateFeedSitemap(fc *models.FeedConfig, config *lifecycle.Config) (string, error) {
 	// Get site URL
 	siteURL := getSiteURL(config)
 	if siteURL == "" {
@@ -1282,15 +1321,11 @@ func (p *PublishFeedsPlugin) publishSitemap(fc *models.FeedConfig, config *lifec
 	// Marshal to XML
 	output, err := xml.MarshalIndent(sitemap, "", "    ")
 	if err != nil {
-		return fmt.Errorf("marshaling sitemap: %w", err)
+		return "", fmt.Errorf("marshaling sitemap: %w", err)
 	}
 
 	// Add XML declaration
-	xmlContent := xml.Header + string(output)
-
-	// Write sitemap.xml
-	sitemapPath := filepath.Join(feedDir, "sitemap.xml")
-	return p.safeWriteFile(sitemapPath, []byte(xmlContent))
+	return xml.Header + string(output), nil
 }
 
 // writeFeedFormatRedirect writes a redirect from /slug.ext to /slug/targetFile.
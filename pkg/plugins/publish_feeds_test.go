@@ -56,7 +56,7 @@ func TestGenerateFeedPageHTML_UsesConfiguredHTMLTemplate(t *testing.T) {
 	}
 	page := &models.FeedPage{Posts: fc.Posts, TotalPages: 1}
 
-	html, err := p.generateFeedPageHTML(fc, page, config, nil)
+	html, err := p.generateFeedPageHTML(lifecycle.NopLogger(), fc, page, config, nil)
 	if err != nil {
 		t.Fatalf("generateFeedPageHTML() error = %v", err)
 	}
@@ -708,7 +708,7 @@ func TestPublishFeedsPlugin_ShouldSkipFeedWhenOutputsExist(t *testing.T) {
 		PageURLs:     []string{"/archive/"},
 	}}
 
-	if err := plugin.publishFeed(feed, config, outputDir); err != nil {
+	if err := plugin.publishFeed(lifecycle.NopLogger(), feed, config, outputDir); err != nil {
 		t.Fatalf("publishFeed() error = %v", err)
 	}
 
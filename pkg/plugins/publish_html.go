@@ -11,14 +11,11 @@ import (
 
 	"github.com/WaylonWalker/markata-go/pkg/buildcache"
 	"github.com/WaylonWalker/markata-go/pkg/lifecycle"
-	"github.com/WaylonWalker/markata-go/pkg/logging"
 	"github.com/WaylonWalker/markata-go/pkg/models"
 	"github.com/WaylonWalker/markata-go/pkg/palettes"
 	"github.com/WaylonWalker/markata-go/pkg/templates"
 )
 
-var publishHTMLLog = logging.Component("publish_html").Phase("write")
-
 // defaultTxtTemplate is the default template name for txt output.
 const defaultTxtTemplate = "default.txt"
 
@@ -99,20 +96,7 @@ func (p *PublishHTMLPlugin) Write(m *lifecycle.Manager) error {
 		return fmt.Errorf("creating output directory: %w", err)
 	}
 
-	// Get template engine from cache (may be nil if templates plugin not used)
-	var engine *templates.Engine
-	if cached, ok := m.Cache().Get("templates.engine"); ok && cached != nil {
-		if e, ok := cached.(*templates.Engine); ok {
-			engine = e
-		}
-	}
-
-	// Get templates plugin from cache for per-format template resolution
-	if cached, ok := m.Cache().Get("templates.plugin"); ok {
-		if tp, ok := cached.(*TemplatesPlugin); ok {
-			p.templatesPlugin = tp
-		}
-	}
+	engine := p.templateEngine(m)
 
 	// Pre-pass: Identify all posts that changed (hash mismatch) and mark their slugs.
 	// This enables dependency-based invalidation in FilterPosts below.
@@ -149,6 +133,69 @@ func (p *PublishHTMLPlugin) Write(m *lifecycle.Manager) error {
 	})
 }
 
+// templateEngine returns the shared template engine (nil if the templates
+// plugin is not used) and picks up the templates plugin for per-format
+// template resolution.
+func (p *PublishHTMLPlugin) templateEngine(m *lifecycle.Manager) *templates.Engine {
+	var engine *templates.Engine
+	if cached, ok := m.Cache().Get("templates.engine"); ok && cached != nil {
+		if e, ok := cached.(*templates.Engine); ok {
+			engine = e
+		}
+	}
+
+	if cached, ok := m.Cache().Get("templates.plugin"); ok {
+		if tp, ok := cached.(*TemplatesPlugin); ok {
+			p.templatesPlugin = tp
+		}
+	}
+
+	return engine
+}
+
+// PlanWrites reports the primary output of every post without writing it:
+// index.html and the content files of enabled markdown, text, and ANSI formats.
+// Redirect stubs and OG cards are derived from these and are not listed.
+func (p *PublishHTMLPlugin) PlanWrites(m *lifecycle.Manager, plan *lifecycle.WritePlan) error {
+	config := m.Config()
+	engine := p.templateEngine(m)
+
+	for _, post := range m.Posts() {
+		if post.Skip || post.Draft {
+			continue
+		}
+		if !post.Has("_slug_explicit") && post.Slug == "" {
+			post.GenerateSlugWithMode(configuredSlugMode(config, post.Path))
+		}
+
+		postFormats := resolvePostFormats(post, config)
+		if postFormats.IsHTMLEnabled() {
+			htmlContent := post.HTML
+			if htmlContent == "" && post.ArticleHTML != "" {
+				htmlContent = p.wrapInTemplate(post, config)
+			}
+			if htmlContent != "" {
+				plan.Add(filepath.Join(config.OutputDir, post.Slug, "index.html"), []byte(htmlContent))
+			}
+		}
+
+		if post.Private {
+			continue
+		}
+		if postFormats.Markdown {
+			plan.Add(filepath.Join(config.OutputDir, post.Slug+".md"), []byte(p.buildFormatContent(post, config, m, "markdown")))
+		}
+		if postFormats.Text {
+			plan.Add(filepath.Join(config.OutputDir, post.Slug+".txt"), []byte(p.renderTextContent(post, config, engine)))
+		}
+		if postFormats.ANSI {
+			plan.Add(filepath.Join(config.OutputDir, post.Slug+".ansi"), []byte(p.renderANSIContent(post, config, engine)))
+		}
+	}
+
+	return nil
+}
+
 func (p *PublishHTMLPlugin) markChangedPosts(cache *buildcache.Cache, m *lifecycle.Manager, config *lifecycle.Config) error {
 	if cache == nil || m == nil || config == nil {
 		return nil
@@ -271,7 +318,7 @@ func (p *PublishHTMLPlugin) writePost(post *models.Post, config *lifecycle.Confi
 	// Write OG format (social card HTML)
 	// Skip for private posts to prevent metadata leaks in OG cards
 	if postFormats.OG && !post.Private {
-		if err := p.writeOGFormat(post, config, postDir, engine); err != nil {
+		if err := p.writeOGFormat(m.Logger(), post, config, postDir, engine); err != nil {
 			return err
 		}
 	}
@@ -676,7 +723,7 @@ func (p *PublishHTMLPlugin) writeRegularFormatOutput(slug, ext, content, outputD
 }
 
 // writeOGFormat writes the OpenGraph card HTML for social image generation.
-func (p *PublishHTMLPlugin) writeOGFormat(post *models.Post, config *lifecycle.Config, postDir string, engine *templates.Engine) error {
+func (p *PublishHTMLPlugin) writeOGFormat(logger lifecycle.Logger, post *models.Post, config *lifecycle.Config, postDir string, engine *templates.Engine) error {
 	// Create og subdirectory
 	ogDir := filepath.Join(postDir, "og")
 	if err := os.MkdirAll(ogDir, 0o755); err != nil {
@@ -684,7 +731,7 @@ func (p *PublishHTMLPlugin) writeOGFormat(post *models.Post, config *lifecycle.C
 	}
 
 	// Generate OG HTML
-	ogHTML := p.generateOGHTML(post, config, engine)
+	ogHTML := p.generateOGHTML(logger, post, config, engine)
 
 	// Write og/index.html
 	outputPath := filepath.Join(ogDir, "index.html")
@@ -698,10 +745,10 @@ func (p *PublishHTMLPlugin) writeOGFormat(post *models.Post, config *lifecycle.C
 
 // generateOGHTML generates OpenGraph card HTML optimized for 1200x630 screenshots.
 // It first tries to use a theme template (og-card.html), falling back to a built-in template.
-func (p *PublishHTMLPlugin) generateOGHTML(post *models.Post, config *lifecycle.Config, engine *templates.Engine) string {
+func (p *PublishHTMLPlugin) generateOGHTML(logger lifecycle.Logger, post *models.Post, config *lifecycle.Config, engine *templates.Engine) string {
 	// Try theme template first if engine is available
 	if engine != nil && engine.TemplateExists("og-card.html") {
-		return p.renderOGWithThemeTemplate(post, config, engine)
+		return p.renderOGWithThemeTemplate(logger, post, config, engine)
 	}
 
 	// Fall back to built-in template
@@ -709,14 +756,14 @@ func (p *PublishHTMLPlugin) generateOGHTML(post *models.Post, config *lifecycle.
 }
 
 // renderOGWithThemeTemplate renders the OG card using the theme's og-card.html template.
-func (p *PublishHTMLPlugin) renderOGWithThemeTemplate(post *models.Post, config *lifecycle.Config, engine *templates.Engine) string {
+func (p *PublishHTMLPlugin) renderOGWithThemeTemplate(logger lifecycle.Logger, post *models.Post, config *lifecycle.Config, engine *templates.Engine) string {
 	// Build context for pongo2 template
 	ctx := templates.NewContext(post, "", applyPostFormatsToConfig(ToModelsConfig(config), resolvePostFormats(post, config)))
 
 	result, err := engine.Render("og-card.html", ctx)
 	if err != nil {
 		// Log error and fall back to built-in template
-		publishHTMLLog.Warnf("failed to render og-card.html template: %v, falling back to built-in", err)
+		logger.Warn("failed to render og-card.html template, falling back to built-in", "post", post.Path, "error", err)
 		return p.renderOGWithBuiltinTemplate(post, config)
 	}
 
@@ -1142,8 +1189,9 @@ func (p *PublishHTMLPlugin) wrapInTemplate(post *models.Post, config *lifecycle.
 
 // Ensure PublishHTMLPlugin implements the required interfaces.
 var (
-	_ lifecycle.Plugin      = (*PublishHTMLPlugin)(nil)
-	_ lifecycle.WritePlugin = (*PublishHTMLPlugin)(nil)
+	_ lifecycle.Plugin       = (*PublishHTMLPlugin)(nil)
+	_ lifecycle.WritePlugin  = (*PublishHTMLPlugin)(nil)
+	_ lifecycle.WritePlanner = (*PublishHTMLPlugin)(nil)
 )
 
 // getPostFormatsConfig extracts PostFormatsConfig from lifecycle.Config.Extra.
@@ -1307,3 +1307,51 @@ func TestPublishHTMLPlugin_PostFrontmatterDisablesInheritedFormats(t *testing.T)
 		t.Fatal("expected ansi output to be enabled by post override")
 	}
 }
+
+func TestPublishHTMLPlugin_PlanWritesMatchesWrite(t *testing.T) {
+	tempDir := t.TempDir()
+	plugin := NewPublishHTMLPlugin()
+	config := &lifecycle.Config{
+		OutputDir: tempDir,
+		Extra: map[string]interface{}{
+			"post_formats": models.PostFormatsConfig{HTML: boolPtr(true), Markdown: true},
+		},
+	}
+	m := createTestManager(t, config)
+	m.SetPosts([]*models.Post{
+		{Path: "a.md", Slug: "a", Content: "# A", HTML: "<p>A</p>", Published: true},
+		{Path: "draft.md", Slug: "draft", HTML: "<p>draft</p>", Draft: true},
+	})
+
+	plan := &lifecycle.WritePlan{}
+	if err := plugin.PlanWrites(m, plan); err != nil {
+		t.Fatalf("PlanWrites() error = %v", err)
+	}
+
+	htmlPath := filepath.Join(tempDir, "a", "index.html")
+	mdPath := filepath.Join(tempDir, "a.md")
+	writes := plan.Writes()
+	if len(writes) != 2 || writes[0].Path != mdPath || writes[1].Path != htmlPath {
+		t.Fatalf("Writes() = %+v, want %s and %s", writes, mdPath, htmlPath)
+	}
+	for _, w := range writes {
+		if w.Action != lifecycle.WriteActionCreate {
+			t.Errorf("%s action = %s, want create", w.Path, w.Action)
+		}
+	}
+	if _, err := os.Stat(htmlPath); !os.IsNotExist(err) {
+		t.Fatalf("PlanWrites() wrote %s", htmlPath)
+	}
+
+	// After a real write the same plan reports every file as unchanged.
+	if err := plugin.Write(m); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	plan = &lifecycle.WritePlan{}
+	if err := plugin.PlanWrites(m, plan); err != nil {
+		t.Fatalf("PlanWrites() error = %v", err)
+	}
+	if got := plan.Count(lifecycle.WriteActionUnchanged); got != 2 {
+		t.Errorf("Count(unchanged) = %d, want 2 (writes: %+v)", got, plan.Writes())
+	}
+}
@@ -6,7 +6,6 @@ import (
 	"bytes"
 	"fmt"
 	"html/template"
-	"log"
 	"os"
 	"path/filepath"
 	"strings"
@@ -134,7 +133,7 @@ func (p *RedirectsPlugin) Write(m *lifecycle.Manager) error {
 	}
 
 	// Load template
-	tmpl, err := p.loadTemplate()
+	tmpl, err := p.loadTemplate(m.Logger())
 	if err != nil {
 		return fmt.Errorf("loading redirect template: %w", err)
 	}
@@ -208,17 +207,17 @@ func (p *RedirectsPlugin) parseRedirects(content string) []Redirect {
 }
 
 // loadTemplate loads the redirect template (custom or default).
-func (p *RedirectsPlugin) loadTemplate() (*template.Template, error) {
+func (p *RedirectsPlugin) loadTemplate(logger lifecycle.Logger) (*template.Template, error) {
 	if p.config.RedirectTemplate != "" {
 		// Load custom template
 		content, err := os.ReadFile(p.config.RedirectTemplate)
 		if err != nil {
-			log.Printf("warning: failed to read custom redirect template %s: %v, using default", p.config.RedirectTemplate, err)
+			logger.Warn("failed to read custom redirect template, using default", "template", p.config.RedirectTemplate, "error", err)
 			return template.New("redirect").Parse(defaultRedirectTemplate)
 		}
 		tmpl, err := template.New("redirect").Parse(string(content))
 		if err != nil {
-			log.Printf("warning: failed to parse custom redirect template %s: %v, using default", p.config.RedirectTemplate, err)
+			logger.Warn("failed to parse custom redirect template, using default", "template", p.config.RedirectTemplate, "error", err)
 			return template.New("redirect").Parse(defaultRedirectTemplate)
 		}
 		return tmpl, nil
@@ -71,6 +71,7 @@ func registerBuiltinPluginsLocked() {
 	pluginRegistry.constructors["overwrite_check"] = func() lifecycle.Plugin { return NewOverwriteCheckPlugin() }
 	pluginRegistry.constructors["structured_data"] = func() lifecycle.Plugin { return NewStructuredDataPlugin() }
 	pluginRegistry.constructors["pagefind"] = func() lifecycle.Plugin { return NewPagefindPlugin() }
+	pluginRegistry.constructors["build_manifest"] = func() lifecycle.Plugin { return NewBuildManifestPlugin() }
 	pluginRegistry.constructors["stats"] = func() lifecycle.Plugin { return NewStatsPlugin() }
 	pluginRegistry.constructors["breadcrumbs"] = func() lifecycle.Plugin { return NewBreadcrumbsPlugin() }
 	pluginRegistry.constructors["embeds"] = func() lifecycle.Plugin { return NewEmbedsPlugin() }
@@ -105,6 +106,7 @@ func registerBuiltinPluginsLocked() {
 	pluginRegistry.constructors["authors"] = func() lifecycle.Plugin { return NewAuthorsPlugin() }
 	pluginRegistry.constructors["series"] = func() lifecycle.Plugin { return NewSeriesPlugin() }
 	pluginRegistry.constructors["tailwind"] = func() lifecycle.Plugin { return NewTailwindPlugin() }
+	pluginRegistry.constructors["verify_output"] = func() lifecycle.Plugin { return NewVerifyOutputPlugin() }
 }
 
 // RegisterPluginConstructor registers a plugin constructor with the given name.
@@ -229,11 +231,15 @@ func DefaultPlugins() []lifecycle.Plugin {
 		// NewResourceHintsPlugin(), // Inject resource hints (after HTML written) // DISABLED: Performance issue on large sites
 		NewSitemapPlugin(),
 
+		// Verify stage plugins
+		NewVerifyOutputPlugin(), // Check written HTML (skippable via [markata-go.verify])
+
 		// Cleanup stage plugins
-		NewCSSMinifyPlugin(), // Minify CSS files (before purge for optimal results)
-		NewJSMinifyPlugin(),  // Minify JS files (reduces ~50% file size)
-		NewCSSPurgePlugin(),  // Remove unused CSS (before search index)
-		NewPagefindPlugin(),  // Generate search index (requires all HTML written first)
+		NewCSSMinifyPlugin(),     // Minify CSS files (before purge for optimal results)
+		NewJSMinifyPlugin(),      // Minify JS files (reduces ~50% file size)
+		NewCSSPurgePlugin(),      // Remove unused CSS (before search index)
+		NewPagefindPlugin(),      // Generate search index (requires all HTML written first)
+		NewBuildManifestPlugin(), // Write manifest.json of output files (runs after everything else)
 	}
 }
 
@@ -3,6 +3,7 @@ package plugins
 
 import (
 	"bytes"
+	"fmt"
 	"regexp"
 	"strings"
 	"sync"
@@ -14,6 +15,7 @@ import (
 	"github.com/WaylonWalker/markata-go/pkg/lifecycle"
 	"github.com/WaylonWalker/markata-go/pkg/models"
 	"github.com/WaylonWalker/markata-go/pkg/palettes"
+	"github.com/WaylonWalker/markata-go/pkg/templates"
 	"github.com/yuin/goldmark"
 	emoji "github.com/yuin/goldmark-emoji"
 	highlighting "github.com/yuin/goldmark-highlighting/v2"
@@ -43,10 +45,18 @@ var figureBlockquoteCaptionParagraphRegex = regexp.MustCompile(`(?s)^<p>.*?</p>$
 // in the markdown pre-processing step, and detected in the post-processing step.
 const attributionMarker = "<!--markata-attribution-->"
 
+// renderCacheVersion is part of every cached article's key. Bump it when a
+// change to the renderer alters its output for the same markdown.
+const renderCacheVersion = 1
+
 // RenderMarkdownPlugin converts markdown content to HTML using goldmark.
 type RenderMarkdownPlugin struct {
 	md    goldmark.Markdown
 	cache *buildcache.Cache // build cache for HTML caching
+
+	// renderKey fingerprints the settings that shape rendered HTML, so
+	// cached articles rendered with other settings are not reused.
+	renderKey string
 }
 
 // CacheKeyMarkdownRenderer is the manager cache key for the markdown render
@@ -219,15 +229,32 @@ func (p *RenderMarkdownPlugin) Configure(m *lifecycle.Manager) error {
 
 	// Reconfigure the markdown renderer with the resolved theme and extensions
 	p.md = createMarkdownRenderer(chromaTheme, lineNumbers, extConfig)
+	p.renderKey = markdownRenderKey(chromaTheme, lineNumbers, extConfig)
 
 	// Register the render function so other plugins (e.g. feed helpers during
 	// jinja_md transform) can render markdown on-demand when ArticleHTML has
 	// not yet been populated by the Render stage.
 	m.Cache().Set(CacheKeyMarkdownRenderer, MarkdownRenderFunc(p.doRender))
 
+	// {% markdown %} blocks in templates render like post content
+	templates.SetMarkdownRenderer(p.doRender)
+
 	return nil
 }
 
+// markdownRenderKey fingerprints the renderer settings and markata-go build
+// that cached article HTML depends on.
+func markdownRenderKey(chromaTheme string, lineNumbers bool, extConfig MarkdownExtensionConfig) string {
+	return buildcache.ContentHash(fmt.Sprintf("v%d\x00%s\x00%s\x00%t\x00%+v",
+		renderCacheVersion, buildcache.BinaryVersion(), chromaTheme, lineNumbers, extConfig))
+}
+
+// articleHash returns the build cache key for a post's rendered HTML: its
+// markdown combined with the render settings.
+func (p *RenderMarkdownPlugin) articleHash(content string) string {
+	return buildcache.ContentHash(p.renderKey + "\x00" + content)
+}
+
 // resolveHighlightConfig extracts highlight configuration from the config.Extra map.
 // Returns the Chroma theme name and whether line numbers should be shown.
 func (p *RenderMarkdownPlugin) resolveHighlightConfig(extra map[string]interface{}) (string, bool) {
@@ -371,10 +398,9 @@ func (p *RenderMarkdownPlugin) Render(m *lifecycle.Manager) error {
 			return false
 		}
 
-		// Try to get cached HTML if content hasn't changed
+		// Try to get cached HTML if neither content nor render settings changed
 		if p.cache != nil && !isSourceEncryptedPost(post) {
-			contentHash := buildcache.ContentHash(post.Content)
-			if cachedHTML := p.cache.GetCachedArticleHTML(post.Path, contentHash); cachedHTML != "" {
+			if cachedHTML := p.cache.GetCachedArticleHTML(post.Path, p.articleHash(post.Content)); cachedHTML != "" {
 				post.ArticleHTML = cachedHTML
 				// Detect CSS requirements from cached HTML
 				p.detectCSSRequirements(post)
@@ -427,9 +453,8 @@ func (p *RenderMarkdownPlugin) renderPost(post *models.Post) error {
 
 	// Cache the result for future incremental builds
 	if p.cache != nil && !isSourceEncryptedPost(post) {
-		contentHash := buildcache.ContentHash(post.Content)
 		//nolint:errcheck // caching is best-effort, failures are non-fatal
-		p.cache.CacheArticleHTML(post.Path, contentHash, renderedHTML)
+		p.cache.CacheArticleHTML(post.Path, p.articleHash(post.Content), renderedHTML)
 	}
 
 	// Detect CSS requirements from rendered HTML
@@ -2,6 +2,7 @@
 package plugins
 
 import (
+	"net/url"
 	"os"
 	"path/filepath"
 	"regexp"
@@ -24,6 +25,9 @@ type ResourceHintsPlugin struct {
 	// autoDetect enables automatic detection of external domains
 	autoDetect bool
 
+	// preloadFonts enables preload hints for fonts declared in @font-face rules
+	preloadFonts bool
+
 	// config holds the resource hints configuration
 	config *models.ResourceHintsConfig
 
@@ -37,10 +41,11 @@ type ResourceHintsPlugin struct {
 // NewResourceHintsPlugin creates a new ResourceHintsPlugin with default settings.
 func NewResourceHintsPlugin() *ResourceHintsPlugin {
 	return &ResourceHintsPlugin{
-		enabled:    true,
-		autoDetect: true,
-		detector:   resourcehints.NewDetector(),
-		generator:  resourcehints.NewGenerator(),
+		enabled:      true,
+		autoDetect:   true,
+		preloadFonts: true,
+		detector:     resourcehints.NewDetector(),
+		generator:    resourcehints.NewGenerator(),
 	}
 }
 
@@ -70,6 +75,7 @@ func (p *ResourceHintsPlugin) Configure(m *lifecycle.Manager) error {
 	// Apply configuration
 	p.enabled = rhConfig.IsEnabled()
 	p.autoDetect = rhConfig.IsAutoDetectEnabled()
+	p.preloadFonts = rhConfig.IsPreloadFontsEnabled()
 
 	// Set excluded domains on detector
 	if len(rhConfig.ExcludeDomains) > 0 {
@@ -89,6 +95,12 @@ func (p *ResourceHintsPlugin) Write(m *lifecycle.Manager) error {
 	config := m.Config()
 	outputDir := config.OutputDir
 
+	// Links the user added through head.link win over generated duplicates
+	headLinks := headLinksFromExtra(config.Extra)
+
+	// Font hints per local stylesheet, shared across pages
+	stylesheetFonts := make(map[string][]resourcehints.SuggestedHint)
+
 	// Process each HTML file individually for page-specific hints
 	return filepath.Walk(outputDir, func(path string, info os.FileInfo, err error) error {
 		if err != nil {
@@ -125,7 +137,15 @@ func (p *ResourceHintsPlugin) Write(m *lifecycle.Manager) error {
 		}
 
 		// Generate hint tags for this page
-		hintTags := p.generator.GenerateFromConfig(p.config, detectedDomains)
+		hints := p.generator.HintsFromConfig(p.config, detectedDomains)
+		hintTags := p.generator.GenerateHintTags(p.generator.MergeWithExisting(headLinks, hints))
+		if p.autoDetect && p.preloadFonts {
+			fontHints := p.detectFontPreloads(outputDir, path, htmlContent, stylesheetFonts)
+			fontHints = p.generator.MergeWithExisting(headLinks, fontHints)
+			if fontTags := p.generator.GenerateHintTags(fontHints); fontTags != "" {
+				hintTags = strings.TrimPrefix(hintTags+"\n"+fontTags, "\n")
+			}
+		}
 		if hintTags == "" {
 			return nil // No hints to inject for this page
 		}
@@ -145,6 +165,71 @@ func (p *ResourceHintsPlugin) Write(m *lifecycle.Manager) error {
 	})
 }
 
+// styleBlockRegex matches inline <style> elements.
+var styleBlockRegex = regexp.MustCompile(`(?is)<style[^>]*>(.*?)</style>`)
+
+// stylesheetLinkRegex matches <link> tags for stylesheets.
+var stylesheetLinkRegex = regexp.MustCompile(`(?i)<link[^>]*\srel\s*=\s*["']?stylesheet["']?[^>]*>`)
+
+// linkTagHrefRegex extracts the href of a <link> tag.
+var linkTagHrefRegex = regexp.MustCompile(`(?i)\shref\s*=\s*["']([^"']+)["']`)
+
+// detectFontPreloads returns font preload hints for a page, from its inline
+// styles and the local stylesheets it links. Relative font URLs are resolved
+// against the page or stylesheet URL. Results for each stylesheet are cached
+// in stylesheetFonts, since most pages share the same CSS.
+func (p *ResourceHintsPlugin) detectFontPreloads(outputDir, pagePath, htmlContent string, stylesheetFonts map[string][]resourcehints.SuggestedHint) []resourcehints.SuggestedHint {
+	rel, err := filepath.Rel(outputDir, pagePath)
+	if err != nil {
+		return nil
+	}
+	pageURL, err := url.Parse("/" + filepath.ToSlash(rel))
+	if err != nil {
+		return nil
+	}
+
+	seen := make(map[string]bool)
+	var hints []resourcehints.SuggestedHint
+	add := func(found []resourcehints.SuggestedHint) {
+		for _, h := range found {
+			if !seen[h.URL] {
+				seen[h.URL] = true
+				hints = append(hints, h)
+			}
+		}
+	}
+
+	for _, match := range styleBlockRegex.FindAllStringSubmatch(htmlContent, -1) {
+		add(p.detector.DetectFontPreloads(match[1], pageURL.String()))
+	}
+
+	for _, link := range stylesheetLinkRegex.FindAllString(htmlContent, -1) {
+		href := linkTagHrefRegex.FindStringSubmatch(link)
+		if href == nil {
+			continue
+		}
+		ref, err := url.Parse(href[1])
+		if err != nil || ref.Host != "" || (ref.Scheme != "" && ref.Scheme != "file") {
+			continue // external stylesheets are not fetched
+		}
+		sheetURL := pageURL.ResolveReference(ref)
+		sheetURL.RawQuery, sheetURL.Fragment = "", ""
+		key := sheetURL.Path
+
+		found, ok := stylesheetFonts[key]
+		if !ok {
+			css, err := os.ReadFile(filepath.Join(outputDir, filepath.FromSlash(strings.TrimPrefix(key, "/"))))
+			if err == nil {
+				found = p.detector.DetectFontPreloads(string(css), key)
+			}
+			stylesheetFonts[key] = found
+		}
+		add(found)
+	}
+
+	return hints
+}
+
 // headOpenRegex matches the opening <head> tag.
 var headOpenRegex = regexp.MustCompile(`(?i)(<head[^>]*>)`)
 
@@ -172,6 +257,15 @@ func (p *ResourceHintsPlugin) injectHints(htmlContent, hintBlock string) string
 	return htmlContent
 }
 
+// headLinksFromExtra returns the head.link entries from the config, which
+// may hold a models.HeadConfig or the raw map from the config file.
+func headLinksFromExtra(extra map[string]interface{}) []models.LinkTag {
+	if headMap, ok := extra["head"].(map[string]interface{}); ok {
+		return headFromMap(headMap, models.HeadConfig{}).Link
+	}
+	return extractHeadConfig(extra).Link
+}
+
 // getResourceHintsConfig extracts ResourceHintsConfig from lifecycle.Config.
 func getResourceHintsConfig(config *lifecycle.Config) models.ResourceHintsConfig {
 	// Check if we have a ResourceHints field in Extra
@@ -142,6 +142,125 @@ func TestResourceHintsPageSpecific(t *testing.T) {
 	t.Logf("Page 2 hints: %d", page2HintCount)
 }
 
+func TestResourceHintsFontPreloads(t *testing.T) {
+	tempDir := t.TempDir()
+
+	css := `@font-face { font-family: Inter; src: url(../fonts/inter.woff2) format("woff2"), url(../fonts/inter.woff) format("woff"); }`
+	page := `<!DOCTYPE html>
+<html>
+<head>
+  <meta charset="UTF-8">
+  <link rel="stylesheet" href="../css/site.css?v=1">
+  <style>@font-face { font-family: Mono; src: url(mono.woff2); }</style>
+</head>
+<body></body>
+</html>`
+
+	files := map[string]string{
+		"blog/css/site.css":    css,
+		"blog/post/index.html": page,
+	}
+	for name, content := range files {
+		path := filepath.Join(tempDir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	plugin := NewResourceHintsPlugin()
+	manager := &lifecycle.Manager{}
+	manager.SetConfig(&lifecycle.Config{OutputDir: tempDir})
+	if err := plugin.Configure(manager); err != nil {
+		t.Fatal(err)
+	}
+	if err := plugin.Write(manager); err != nil {
+		t.Fatal(err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(tempDir, "blog", "post", "index.html"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	html := string(content)
+
+	for _, want := range []string{
+		`<link rel="preload" href="/blog/fonts/inter.woff2" crossorigin as="font" type="font/woff2">`,
+		`<link rel="preload" href="/blog/post/mono.woff2" crossorigin as="font" type="font/woff2">`,
+	} {
+		if !strings.Contains(html, want) {
+			t.Errorf("page should contain %s, got:\n%s", want, html)
+		}
+	}
+	if strings.Contains(html, "inter.woff\"") {
+		t.Error("only the preferred woff2 source should be preloaded")
+	}
+
+	// Font preloads can be turned off
+	disabled := NewResourceHintsPlugin()
+	manager.SetConfig(&lifecycle.Config{
+		OutputDir: tempDir,
+		Extra: map[string]interface{}{
+			"resource_hints": models.ResourceHintsConfig{PreloadFonts: boolPtr(false)},
+		},
+	})
+	if err := disabled.Configure(manager); err != nil {
+		t.Fatal(err)
+	}
+	if disabled.preloadFonts {
+		t.Error("preload_fonts = false should disable font preloads")
+	}
+}
+
+func TestResourceHintsSkipsHeadLinks(t *testing.T) {
+	tempDir := t.TempDir()
+	page := `<!DOCTYPE html>
+<html>
+<head>
+  <meta charset="UTF-8">
+  <link rel="preconnect" href="https://fonts.googleapis.com">
+  <link href="https://fonts.googleapis.com/css2?family=Inter" rel="stylesheet">
+</head>
+<body><img src="https://cdn.jsdelivr.net/image.png"></body>
+</html>`
+	pagePath := filepath.Join(tempDir, "index.html")
+	if err := os.WriteFile(pagePath, []byte(page), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	plugin := NewResourceHintsPlugin()
+	manager := &lifecycle.Manager{}
+	manager.SetConfig(&lifecycle.Config{
+		OutputDir: tempDir,
+		Extra: map[string]interface{}{
+			"head": models.HeadConfig{
+				Link: []models.LinkTag{{Rel: "preconnect", Href: "https://fonts.googleapis.com/"}},
+			},
+		},
+	})
+	if err := plugin.Configure(manager); err != nil {
+		t.Fatal(err)
+	}
+	if err := plugin.Write(manager); err != nil {
+		t.Fatal(err)
+	}
+
+	content, err := os.ReadFile(pagePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	html := string(content)
+
+	if got := strings.Count(html, `rel="preconnect" href="https://fonts.googleapis.com"`); got != 1 {
+		t.Errorf("fonts.googleapis.com preconnect appears %d times, want only the user's, got:\n%s", got, html)
+	}
+	if !strings.Contains(html, `href="https://cdn.jsdelivr.net"`) {
+		t.Errorf("hints for other origins should still be generated, got:\n%s", html)
+	}
+}
+
 func boolPtr(b bool) *bool {
 	return &b
 }
@@ -3,7 +3,6 @@ package plugins
 
 import (
 	"fmt"
-	"log"
 	"sort"
 	"strings"
 
@@ -110,7 +109,7 @@ func (p *SeriesPlugin) Collect(m *lifecycle.Manager) error {
 		}
 
 		// Sort posts within the series
-		sortSeriesPosts(group, true)
+		sortSeriesPosts(group, m.Logger())
 
 		// Build feed slug
 		feedSlug := buildSeriesFeedSlug(seriesCfg.SlugPrefix, group.slug)
@@ -120,7 +119,7 @@ func (p *SeriesPlugin) Collect(m *lifecycle.Manager) error {
 		setSeriesMetadata(group.posts, feedSlug, len(publishedPosts))
 
 		if len(publishedPosts) == 0 {
-			log.Printf("[series] warning: no published posts in series %q", group.name)
+			m.Logger().Warn("no published posts in series", "series", group.name)
 			continue
 		}
 
@@ -197,7 +196,9 @@ func resolveSeriesOverride(cfg seriesConfig, seriesName, seriesSlug string) *ser
 //  2. Posts without series_order are placed after ordered posts, sorted by date
 //  3. If no post has series_order, sort by date ascending
 //  4. Ties broken by file path
-func sortSeriesPosts(group *seriesGroup, logDuplicates bool) {
+//
+// Duplicate series_order values are logged to logger, if it is not nil.
+func sortSeriesPosts(group *seriesGroup, logger lifecycle.Logger) {
 	// Check if any post has series_order
 	hasExplicitOrder := false
 	for _, post := range group.posts {
@@ -207,14 +208,13 @@ func sortSeriesPosts(group *seriesGroup, logDuplicates bool) {
 		}
 	}
 
-	if hasExplicitOrder && logDuplicates {
+	if hasExplicitOrder && logger != nil {
 		// Check for duplicate series_order values
 		orderSeen := make(map[int]string) // order -> first post path
 		for _, post := range group.posts {
 			if order, ok := getSeriesOrder(post); ok {
 				if prevPath, exists := orderSeen[order]; exists {
-					log.Printf("[series] warning: duplicate series_order %d in series %q: %q and %q",
-						order, group.name, prevPath, post.Path)
+					logger.Warn("duplicate series_order", "series", group.name, "series_order", order, "first", prevPath, "duplicate", post.Path)
 				} else {
 					orderSeen[order] = post.Path
 				}
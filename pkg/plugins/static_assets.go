@@ -2,14 +2,16 @@ package plugins
 
 import (
 	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
 	"fmt"
 	"io"
 	"io/fs"
-	"log"
 	"os"
 	"path/filepath"
 	"strings"
 
+	"github.com/WaylonWalker/markata-go/pkg/assets"
 	"github.com/WaylonWalker/markata-go/pkg/buildcache"
 	"github.com/WaylonWalker/markata-go/pkg/lifecycle"
 	"github.com/WaylonWalker/markata-go/pkg/templates"
@@ -85,12 +87,20 @@ func (p *StaticAssetsPlugin) Configure(m *lifecycle.Manager) error {
 	// Set hashes in templates package for theme_asset_hashed filter
 	templates.SetAssetHashes(assetHashes)
 
+	// Index every static file so the static filter can tell known paths
+	// from typos
+	staticPaths, err := p.collectStaticPaths(themeName, themeStaticDir, projectStaticDir)
+	if err != nil {
+		return fmt.Errorf("indexing static files: %w", err)
+	}
+	templates.SetStaticPaths(staticPaths)
+
 	// Update build cache with combined assets hash
 	// This ensures all pages are rebuilt when any JS/CSS file changes
 	if cache := GetBuildCache(m); cache != nil {
 		assetsHash := buildcache.HashAssetMap(assetHashes)
 		if cache.SetAssetsHash(assetsHash) {
-			log.Printf("[static_assets] JS/CSS assets changed, full rebuild required")
+			m.Logger().Info("JS/CSS assets changed, full rebuild required")
 		}
 	}
 
@@ -158,9 +168,113 @@ func (p *StaticAssetsPlugin) Cleanup(m *lifecycle.Manager) error {
 		return fmt.Errorf("creating hashed asset copies: %w", err)
 	}
 
+	if err := p.resolveSRIPlaceholders(m.Logger(), outputDir); err != nil {
+		return fmt.Errorf("resolving sri hashes: %w", err)
+	}
+
+	// Cleanup errors are reported as build warnings
+	if unknown := templates.UnknownStaticRefs(); len(unknown) > 0 {
+		return fmt.Errorf("static/sri filters used with unknown assets (left unchanged): %s", strings.Join(unknown, ", "))
+	}
+
 	return nil
 }
 
+// resolveSRIPlaceholders replaces the placeholders rendered by the sri
+// filter with the sha384 of each asset as served. Local assets are hashed
+// from the output directory, so the value matches minified files; CDN
+// assets use their registered hash. Assets without a hash get an empty
+// value, which browsers treat as no integrity check.
+func (p *StaticAssetsPlugin) resolveSRIPlaceholders(logger lifecycle.Logger, outputDir string) error {
+	values := make(map[string]string)
+	resolve := func(ref string) string {
+		if value, ok := values[ref]; ok {
+			return value
+		}
+		var value string
+		url, _ := templates.ResolveStatic(ref)
+		if strings.HasPrefix(url, "/") && !strings.HasPrefix(url, "//") {
+			if data, err := os.ReadFile(filepath.Join(outputDir, filepath.FromSlash(url))); err == nil {
+				sum := sha512.Sum384(data)
+				value = "sha384-" + base64.StdEncoding.EncodeToString(sum[:])
+			}
+		} else if asset := assets.GetAsset(ref); asset != nil {
+			value = asset.Integrity
+		}
+		if value == "" {
+			logger.Warn("no integrity hash", "asset", ref)
+		}
+		values[ref] = value
+		return value
+	}
+
+	return filepath.WalkDir(outputDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || !strings.HasSuffix(strings.ToLower(path), ".html") {
+			return nil
+		}
+
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil // Skip files we can't read
+		}
+		updated := templates.ReplaceSRIPlaceholders(string(content), resolve)
+		if updated == string(content) {
+			return nil
+		}
+		//nolint:gosec // output HTML needs web-readable permissions
+		return os.WriteFile(path, []byte(updated), 0o644)
+	})
+}
+
+// collectStaticPaths lists the files copied to the output root by Write,
+// as slash-separated paths relative to it.
+func (p *StaticAssetsPlugin) collectStaticPaths(themeName, themeStaticDir, projectStaticDir string) ([]string, error) {
+	var paths []string
+
+	if themeName == ThemeDefault {
+		if staticFS := themes.DefaultStatic(); staticFS != nil {
+			err := fs.WalkDir(staticFS, ".", func(path string, d fs.DirEntry, err error) error {
+				if err != nil || d.IsDir() {
+					return err
+				}
+				paths = append(paths, path)
+				return nil
+			})
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	for _, dir := range []string{themeStaticDir, projectStaticDir} {
+		if dir == "" {
+			continue
+		}
+		if _, err := os.Stat(dir); err != nil {
+			continue
+		}
+		err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+			if err != nil || d.IsDir() {
+				return err
+			}
+			rel, err := filepath.Rel(dir, path)
+			if err != nil {
+				return err
+			}
+			paths = append(paths, filepath.ToSlash(rel))
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return paths, nil
+}
+
 // findThemeStaticDir searches for theme static directory in various locations.
 func (p *StaticAssetsPlugin) findThemeStaticDir(themeName string) string {
 	// 1. Check current working directory
@@ -3,13 +3,17 @@ package plugins
 import (
 	"bytes"
 	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
 	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/WaylonWalker/markata-go/pkg/lifecycle"
+	"github.com/WaylonWalker/markata-go/pkg/templates"
 )
 
 func TestStaticAssets_CreateHashedCopies_UsesRegistryHash(t *testing.T) {
@@ -61,3 +65,48 @@ func TestStaticAssets_CreateHashedCopies_UsesRegistryHash(t *testing.T) {
 		}
 	}
 }
+
+func TestStaticAssets_CleanupResolvesSRI(t *testing.T) {
+	outputDir := t.TempDir()
+	writeVerifyFixture(t, outputDir, map[string]string{
+		"js/app.js":          "minified()",
+		"js/app.abc12345.js": "stale",
+		"index.html":         `<script src="/js/app.abc12345.js" integrity="` + templates.SRIPlaceholderPrefix + `js/app.js"></script>`,
+	})
+
+	templates.SetStaticPaths([]string{"js/app.js"})
+	t.Cleanup(func() { templates.SetStaticPaths(nil) })
+
+	manager := lifecycle.NewManager()
+	config := lifecycle.NewConfig()
+	config.OutputDir = outputDir
+	manager.SetConfig(config)
+	manager.SetAssetHash("js/app.js", "abc12345")
+
+	if err := NewStaticAssetsPlugin().Cleanup(manager); err != nil {
+		t.Fatalf("Cleanup() error = %v", err)
+	}
+
+	// The hashed copy is refreshed from the final file before hashing
+	sum := sha512.Sum384([]byte("minified()"))
+	want := `<script src="/js/app.abc12345.js" integrity="sha384-` + base64.StdEncoding.EncodeToString(sum[:]) + `"></script>`
+	content, err := os.ReadFile(filepath.Join(outputDir, "index.html"))
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	if string(content) != want {
+		t.Errorf("index.html =\n%s\nwant:\n%s", content, want)
+	}
+
+	engine, err := templates.NewEngine("")
+	if err != nil {
+		t.Fatalf("NewEngine: %v", err)
+	}
+	if _, err := engine.RenderString(`{{ "js/typo.js"|static }}`, templates.NewContext(nil, "", nil)); err != nil {
+		t.Fatalf("RenderString: %v", err)
+	}
+	err = NewStaticAssetsPlugin().Cleanup(manager)
+	if err == nil || !strings.Contains(err.Error(), "js/typo.js") {
+		t.Errorf("Cleanup() error = %v, want warning naming js/typo.js", err)
+	}
+}
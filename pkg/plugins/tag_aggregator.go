@@ -1,7 +1,6 @@
 package plugins
 
 import (
-	"log"
 	"sort"
 	"strings"
 
@@ -106,8 +105,7 @@ func (p *TagAggregatorPlugin) Load(m *lifecycle.Manager) error {
 	}
 
 	if synonymCount > 0 || addedCount > 0 {
-		log.Printf("[tag_aggregator] Processed tags: %d posts with synonym normalization, %d tags added via expansion",
-			synonymCount, addedCount)
+		m.Logger().Info("processed tags", "normalized_posts", synonymCount, "expanded_tags", addedCount)
 	}
 
 	return nil
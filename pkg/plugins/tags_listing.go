@@ -3,7 +3,6 @@ package plugins
 
 import (
 	"fmt"
-	"log"
 	"os"
 	"path/filepath"
 	"sort"
@@ -116,7 +115,7 @@ func (p *TagsListingPlugin) Write(m *lifecycle.Manager) error {
 	// Collect and filter tags
 	tagInfos := p.collectTags(m.Posts(), &tagsConfig)
 	if len(tagInfos) == 0 {
-		log.Printf("[tags_listing] No tags found, skipping tags listing page")
+		m.Logger().Debug("no tags found, skipping tags listing page")
 		return nil
 	}
 
@@ -126,7 +125,7 @@ func (p *TagsListingPlugin) Write(m *lifecycle.Manager) error {
 	})
 
 	// Generate the tags listing page
-	return p.renderTagsPage(config, &tagsConfig, tagInfos)
+	return p.renderTagsPage(m.Logger(), config, &tagsConfig, tagInfos)
 }
 
 // collectTags gathers all visible tags from posts with their counts.
@@ -213,7 +212,7 @@ func computeTagsListingHash(posts []*models.Post, tagsConfig *models.TagsConfig)
 }
 
 // renderTagsPage renders and writes the tags listing HTML page.
-func (p *TagsListingPlugin) renderTagsPage(config *lifecycle.Config, tagsConfig *models.TagsConfig, tagInfos []TagInfo) error {
+func (p *TagsListingPlugin) renderTagsPage(logger lifecycle.Logger, config *lifecycle.Config, tagsConfig *models.TagsConfig, tagInfos []TagInfo) error {
 	slugPrefix := tagsConfig.SlugPrefix
 	if slugPrefix == "" {
 		slugPrefix = "tags"
@@ -240,7 +239,7 @@ func (p *TagsListingPlugin) renderTagsPage(config *lifecycle.Config, tagsConfig
 
 	// Check if template exists
 	if !engine.TemplateExists(templateName) {
-		log.Printf("[tags_listing] Warning: template %q not found, skipping tags listing page", templateName)
+		logger.Warn("template not found, skipping tags listing page", "template", templateName)
 		return nil
 	}
 
@@ -271,7 +270,7 @@ func (p *TagsListingPlugin) renderTagsPage(config *lifecycle.Config, tagsConfig
 		return fmt.Errorf("writing tags listing page: %w", err)
 	}
 
-	log.Printf("[tags_listing] Generated /tags/ with %d tags", len(tagInfos))
+	logger.Info("generated tags listing", "path", "/"+slugPrefix+"/", "tags", len(tagInfos))
 
 	return nil
 }
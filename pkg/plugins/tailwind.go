@@ -13,7 +13,6 @@ import (
 
 	"github.com/WaylonWalker/markata-go/pkg/buildcache"
 	"github.com/WaylonWalker/markata-go/pkg/lifecycle"
-	"github.com/WaylonWalker/markata-go/pkg/logging"
 	"github.com/WaylonWalker/markata-go/pkg/models"
 	"github.com/WaylonWalker/markata-go/pkg/templates"
 )
@@ -30,13 +29,13 @@ type tailwindInstaller interface {
 
 var (
 	tailwindLookPath     = exec.LookPath
-	newTailwindInstaller = func(config TailwindInstallerConfig) tailwindInstaller {
-		return NewTailwindInstallerWithConfig(config)
+	newTailwindInstaller = func(config TailwindInstallerConfig, logger lifecycle.Logger) tailwindInstaller {
+		installer := NewTailwindInstallerWithConfig(config)
+		installer.Logger = logger
+		return installer
 	}
 )
 
-var tailwindLog = logging.Component("tailwind").Phase("cleanup")
-
 // TailwindPlugin runs the Tailwind standalone CLI and wires inclusion into the head.
 type TailwindPlugin struct {
 	config    models.TailwindConfig
@@ -98,8 +97,8 @@ func (p *TailwindPlugin) Configure(m *lifecycle.Manager) error {
 		config.Extra["head"] = modelsConfig.Head
 		templates.ClearConfigMapCache()
 		if p.config.IsVerbose() {
-			tailwindLog.Printf("theme in extra: %#v", config.Extra["theme"])
-			tailwindLog.Printf("modelsConfig theme: %#v", modelsConfig.Theme)
+			m.Logger().Info("theme in extra", "theme", fmt.Sprintf("%#v", config.Extra["theme"]))
+			m.Logger().Info("models config theme", "theme", fmt.Sprintf("%#v", modelsConfig.Theme))
 		}
 	}
 
@@ -127,7 +126,7 @@ func (p *TailwindPlugin) Cleanup(m *lifecycle.Manager) error {
 	defer plan.cleanup()
 
 	if plan.shouldBuild {
-		if err := p.runTailwindBuild(config, plan.contentPaths); err != nil {
+		if err := p.runTailwindBuild(m.Logger(), config, plan.contentPaths); err != nil {
 			return err
 		}
 	}
@@ -289,14 +288,14 @@ func (p *TailwindPlugin) parseTailwindConfigBools(raw map[string]interface{}, re
 	}
 }
 
-func (p *TailwindPlugin) runTailwindBuild(config *lifecycle.Config, contentPaths []string) error {
-	inputPath, cleanupInput, err := p.resolveBuildInput(config)
+func (p *TailwindPlugin) runTailwindBuild(logger lifecycle.Logger, config *lifecycle.Config, contentPaths []string) error {
+	inputPath, cleanupInput, err := p.resolveBuildInput(logger, config)
 	if err != nil {
 		return err
 	}
 	defer cleanupInput()
 
-	configPath, cleanupConfig, err := p.resolveBuildConfigFile(config, contentPaths)
+	configPath, cleanupConfig, err := p.resolveBuildConfigFile(logger, config, contentPaths)
 	if err != nil {
 		return err
 	}
@@ -312,7 +311,7 @@ func (p *TailwindPlugin) runTailwindBuild(config *lifecycle.Config, contentPaths
 		return fmt.Errorf("tailwind: creating output directory: %w", err)
 	}
 
-	cliPath, err := p.findOrInstallTailwind()
+	cliPath, err := p.findOrInstallTailwind(logger)
 	if err != nil {
 		return err
 	}
@@ -334,7 +333,7 @@ func (p *TailwindPlugin) runTailwindBuild(config *lifecycle.Config, contentPaths
 	cmd.Dir = "."
 	cmd.Env = os.Environ()
 	start := time.Now()
-	tailwindLog.Printf("Running subprocess: %s %s", cliPath, strings.Join(args, " "))
+	logger.Debug("running subprocess", "command", cliPath+" "+strings.Join(args, " "))
 
 	if p.config.IsVerbose() {
 		cmd.Stdout = os.Stdout
@@ -349,19 +348,19 @@ func (p *TailwindPlugin) runTailwindBuild(config *lifecycle.Config, contentPaths
 			}
 			return fmt.Errorf("tailwind build failed: %w", err)
 		}
-		tailwindLog.Printf("Subprocess completed in %v", time.Since(start))
+		logger.Debug("subprocess completed", "duration", time.Since(start))
 		return nil
 	}
 
 	if err := cmd.Run(); err != nil {
 		return fmt.Errorf("tailwind build failed: %w", err)
 	}
-	tailwindLog.Printf("Subprocess completed in %v", time.Since(start))
+	logger.Debug("subprocess completed", "duration", time.Since(start))
 
 	return nil
 }
 
-func (p *TailwindPlugin) findOrInstallTailwind() (string, error) {
+func (p *TailwindPlugin) findOrInstallTailwind(logger lifecycle.Logger) (string, error) {
 	if p.config.Binary != "" {
 		if _, err := os.Stat(p.config.Binary); err == nil {
 			return p.config.Binary, nil
@@ -373,8 +372,8 @@ func (p *TailwindPlugin) findOrInstallTailwind() (string, error) {
 	}
 
 	if !p.config.IsAutoInstallEnabled() {
-		tailwindLog.Warnf("tailwindcss not found in PATH, skipping build")
-		tailwindLog.Printf("Install it or set [markata-go.tailwind].auto_install = true")
+		logger.Warn("tailwindcss not found in PATH, skipping build",
+			"config", "[markata-go.tailwind].auto_install = true")
 		return "", nil
 	}
 
@@ -387,10 +386,10 @@ func (p *TailwindPlugin) findOrInstallTailwind() (string, error) {
 		Version:  version,
 		CacheDir: p.config.CacheDir,
 		Verbose:  p.config.Verbose,
-	})
+	}, logger)
 
 	if p.config.IsVerbose() {
-		tailwindLog.Printf("Using managed Tailwind CLI %s", version)
+		logger.Info("using managed Tailwind CLI", "version", version)
 	}
 
 	installedPath, err := installer.Install()
@@ -561,7 +560,7 @@ func (p *TailwindPlugin) writeHashedTailwindCopy(destPath string, data []byte) e
 	return nil
 }
 
-func (p *TailwindPlugin) resolveBuildInput(config *lifecycle.Config) (inputPath string, cleanup func(), err error) {
+func (p *TailwindPlugin) resolveBuildInput(logger lifecycle.Logger, config *lifecycle.Config) (inputPath string, cleanup func(), err error) {
 	inputPath = p.resolveAssetPath(config, p.config.Input)
 	if inputPath != "" {
 		if _, err := os.Stat(inputPath); err == nil {
@@ -591,13 +590,13 @@ func (p *TailwindPlugin) resolveBuildInput(config *lifecycle.Config) (inputPath
 	}
 
 	if p.config.IsVerbose() {
-		tailwindLog.Printf("Input CSS missing, using generated default input")
+		logger.Info("input CSS missing, using generated default input")
 	}
 
 	return tmpFile.Name(), cleanup, nil
 }
 
-func (p *TailwindPlugin) resolveBuildConfigFile(_ *lifecycle.Config, contentPaths []string) (configPath string, cleanup func(), err error) {
+func (p *TailwindPlugin) resolveBuildConfigFile(logger lifecycle.Logger, _ *lifecycle.Config, contentPaths []string) (configPath string, cleanup func(), err error) {
 	if p.config.ConfigFile != "" || len(p.config.ExtraArgs) > 0 {
 		return p.config.ConfigFile, func() {}, nil
 	}
@@ -632,7 +631,7 @@ func (p *TailwindPlugin) resolveBuildConfigFile(_ *lifecycle.Config, contentPath
 	}
 
 	if p.config.IsVerbose() {
-		tailwindLog.Printf("Generated default config with %d content patterns", len(contentPaths))
+		logger.Info("generated default config", "content_patterns", len(contentPaths))
 	}
 
 	return tmpFile.Name(), cleanup, nil
@@ -15,11 +15,9 @@ import (
 	"strings"
 	"time"
 
-	"github.com/WaylonWalker/markata-go/pkg/logging"
+	"github.com/WaylonWalker/markata-go/pkg/lifecycle"
 )
 
-var tailwindInstallLog = logging.Component("tailwind_installer").Phase("cleanup")
-
 const (
 	tailwindBinaryName        = "tailwindcss"
 	tailwindBinaryNameWindows = "tailwindcss.exe"
@@ -34,7 +32,11 @@ type TailwindInstaller struct {
 	CacheDir string
 	Version  string
 	Verbose  bool
-	client   *http.Client
+
+	// Logger receives verbose output. If nil, it goes to a text logger.
+	Logger lifecycle.Logger
+
+	client *http.Client
 }
 
 // TailwindInstallError indicates an error during Tailwind installation.
@@ -117,6 +119,14 @@ func NewTailwindInstaller() *TailwindInstaller {
 	}
 }
 
+// logger returns the Logger verbose output is written to.
+func (i *TailwindInstaller) logger() lifecycle.Logger {
+	if i.Logger != nil {
+		return i.Logger
+	}
+	return lifecycle.NewTextLogger(nil)
+}
+
 // NewTailwindInstallerWithConfig creates a new TailwindInstaller from config.
 func NewTailwindInstallerWithConfig(config TailwindInstallerConfig) *TailwindInstaller {
 	installer := NewTailwindInstaller()
@@ -325,7 +335,7 @@ func (i *TailwindInstaller) downloadTailwindAsset(version, platformAsset string)
 	assetURL := buildTailwindAssetURL(version, platformAsset)
 
 	if i.Verbose {
-		tailwindInstallLog.Printf("Downloading Tailwind %s for %s...", version, platformAsset)
+		i.logger().Info("downloading Tailwind", "version", version, "asset", platformAsset)
 	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), tailwindHTTPTimeout)
@@ -368,14 +378,14 @@ func (i *TailwindInstaller) downloadTailwindAsset(version, platformAsset string)
 	}
 
 	if i.Verbose {
-		tailwindInstallLog.Printf("Downloaded %d bytes", written)
+		i.logger().Info("downloaded Tailwind", "bytes", written)
 	}
 
 	return tmpFile.Name(), nil
 }
 
 // verifyTailwindChecksum verifies the SHA256 checksum of a downloaded file.
-func verifyTailwindChecksum(filePath, expectedChecksum string, verbose bool) error {
+func verifyTailwindChecksum(filePath, expectedChecksum string) error {
 	file, err := os.Open(filePath)
 	if err != nil {
 		return NewTailwindInstallError("verify", "failed to open file for verification", err)
@@ -396,10 +406,6 @@ func verifyTailwindChecksum(filePath, expectedChecksum string, verbose bool) err
 		)
 	}
 
-	if verbose {
-		tailwindInstallLog.Printf("Checksum verified: %s", actualChecksum[:16]+"...")
-	}
-
 	return nil
 }
 
@@ -440,7 +446,7 @@ func (i *TailwindInstaller) installTailwindBinary(downloadPath, version string)
 	}
 
 	if i.Verbose {
-		tailwindInstallLog.Printf("Installed Tailwind %s to %s", version, destPath)
+		i.logger().Info("installed Tailwind", "version", version, "path", destPath)
 	}
 
 	return destPath, nil
@@ -464,7 +470,7 @@ func (i *TailwindInstaller) Install() (string, error) {
 			return "", err
 		}
 		if i.Verbose {
-			tailwindInstallLog.Printf("Using cached Tailwind %s", version)
+			i.logger().Info("using cached Tailwind", "version", version)
 		}
 		return binaryPath, nil
 	}
@@ -485,9 +491,12 @@ func (i *TailwindInstaller) Install() (string, error) {
 	}
 	defer os.Remove(downloadPath)
 
-	if err := verifyTailwindChecksum(downloadPath, expectedChecksum, i.Verbose); err != nil {
+	if err := verifyTailwindChecksum(downloadPath, expectedChecksum); err != nil {
 		return "", err
 	}
+	if i.Verbose {
+		i.logger().Info("checksum verified", "sha256", expectedChecksum[:16]+"...")
+	}
 
 	installedPath, err := i.installTailwindBinary(downloadPath, version)
 	if err != nil {
@@ -60,7 +60,7 @@ func TestTailwindPlugin_ResolveBuildConfigFile_GeneratesContentConfig(t *testing
 
 	config := &lifecycle.Config{}
 
-	configPath, cleanup, err := plugin.resolveBuildConfigFile(config, []string{"/tmp/manifest.txt", "/tmp/templates/**/*.html"})
+	configPath, cleanup, err := plugin.resolveBuildConfigFile(lifecycle.NopLogger(), config, []string{"/tmp/manifest.txt", "/tmp/templates/**/*.html"})
 	if err != nil {
 		t.Fatalf("resolveBuildConfigFile() error = %v", err)
 	}
@@ -87,7 +87,7 @@ func TestTailwindPlugin_ResolveBuildConfigFile_AllowsPreflightOptIn(t *testing.T
 	preflight := true
 	plugin.config.Preflight = &preflight
 
-	configPath, cleanup, err := plugin.resolveBuildConfigFile(&lifecycle.Config{}, []string{"/tmp/manifest.txt"})
+	configPath, cleanup, err := plugin.resolveBuildConfigFile(lifecycle.NopLogger(), &lifecycle.Config{}, []string{"/tmp/manifest.txt"})
 	if err != nil {
 		t.Fatalf("resolveBuildConfigFile() error = %v", err)
 	}
@@ -180,7 +180,7 @@ func TestTailwindPlugin_ResolveBuildInput_GeneratesDefaultInput(t *testing.T) {
 	tmpDir := t.TempDir()
 	config := &lifecycle.Config{Extra: map[string]interface{}{"assets_dir": filepath.Join(tmpDir, "static")}}
 
-	inputPath, cleanup, err := plugin.resolveBuildInput(config)
+	inputPath, cleanup, err := plugin.resolveBuildInput(lifecycle.NopLogger(), config)
 	if err != nil {
 		t.Fatalf("resolveBuildInput() error = %v", err)
 	}
@@ -214,7 +214,7 @@ func TestTailwindPlugin_FindOrInstallTailwind_PrefersPathWhenAvailable(t *testin
 	}
 
 	installed := false
-	newTailwindInstaller = func(config TailwindInstallerConfig) tailwindInstaller {
+	newTailwindInstaller = func(config TailwindInstallerConfig, _ lifecycle.Logger) tailwindInstaller {
 		installed = true
 		if config.Version != "v3.4.19" {
 			t.Fatalf("installer version = %q, want v3.4.19", config.Version)
@@ -222,7 +222,7 @@ func TestTailwindPlugin_FindOrInstallTailwind_PrefersPathWhenAvailable(t *testin
 		return stubTailwindInstaller{path: "/managed/tailwindcss"}
 	}
 
-	path, err := plugin.findOrInstallTailwind()
+	path, err := plugin.findOrInstallTailwind(lifecycle.NopLogger())
 	if err != nil {
 		t.Fatalf("findOrInstallTailwind() error = %v", err)
 	}
@@ -253,12 +253,12 @@ func TestTailwindPlugin_FindOrInstallTailwind_UsesPathWhenAutoInstallDisabled(t
 	tailwindLookPath = func(_ string) (string, error) {
 		return "/usr/bin/tailwindcss", nil
 	}
-	newTailwindInstaller = func(_ TailwindInstallerConfig) tailwindInstaller {
+	newTailwindInstaller = func(_ TailwindInstallerConfig, _ lifecycle.Logger) tailwindInstaller {
 		t.Fatal("installer should not be used when auto_install is false")
 		return stubTailwindInstaller{}
 	}
 
-	path, err := plugin.findOrInstallTailwind()
+	path, err := plugin.findOrInstallTailwind(lifecycle.NopLogger())
 	if err != nil {
 		t.Fatalf("findOrInstallTailwind() error = %v", err)
 	}
@@ -283,14 +283,14 @@ func TestTailwindPlugin_FindOrInstallTailwind_UsesManagedInstallerWhenPathMissin
 		return "", os.ErrNotExist
 	}
 
-	newTailwindInstaller = func(config TailwindInstallerConfig) tailwindInstaller {
+	newTailwindInstaller = func(config TailwindInstallerConfig, _ lifecycle.Logger) tailwindInstaller {
 		if config.Version != "v3.4.19" {
 			t.Fatalf("installer version = %q, want v3.4.19", config.Version)
 		}
 		return stubTailwindInstaller{path: "/managed/tailwindcss"}
 	}
 
-	path, err := plugin.findOrInstallTailwind()
+	path, err := plugin.findOrInstallTailwind(lifecycle.NopLogger())
 	if err != nil {
 		t.Fatalf("findOrInstallTailwind() error = %v", err)
 	}
@@ -12,15 +12,12 @@ import (
 
 	"github.com/WaylonWalker/markata-go/pkg/buildcache"
 	"github.com/WaylonWalker/markata-go/pkg/lifecycle"
-	"github.com/WaylonWalker/markata-go/pkg/logging"
 	"github.com/WaylonWalker/markata-go/pkg/models"
 	"github.com/WaylonWalker/markata-go/pkg/templates"
 	"golang.org/x/text/cases"
 	"golang.org/x/text/language"
 )
 
-var templatesLog = logging.Component("templates").Phase("render")
-
 // Output format constants.
 const (
 	formatHTML     = "html"
@@ -61,7 +58,16 @@ func (p *TemplatesPlugin) Configure(m *lifecycle.Manager) error {
 	}
 	if modelsConfig, ok := config.Extra["models_config"].(*models.Config); ok && modelsConfig != nil {
 		templates.SetTrustedMediaDomains(modelsConfig.Templates.Media.TrustedDomains)
+		templates.SetSiteURL(modelsConfig.URL)
+		templates.SetTimesinceMaxDays(modelsConfig.Templates.GetTimesinceMaxDays())
+		templates.SetDefaultLimits(templates.Limits{
+			MaxLoopIterations: modelsConfig.Templates.GetMaxLoopIterations(),
+			MaxOutputBytes:    modelsConfig.Templates.GetMaxOutputBytes(),
+		})
 	}
+	// cdn_assets runs earlier in configure and has set the self-hosted URLs
+	templates.SetAssetURLs(assetURLsFromConfig(config))
+	templates.SetRoutes(templates.NewRouteRegistry(routeConfigFromConfig(config)))
 
 	// Get templates directory from config
 	templatesDir := PluginNameTemplates
@@ -169,22 +175,8 @@ func (p *TemplatesPlugin) resolveTemplateForFormat(post *models.Post, format str
 
 	// 5. Use layout configuration to determine template
 	if p.layoutConfig != nil {
-		// Get feed slug for feed-based layout lookup
-		feedSlug := post.PrevNextFeed
-		if feedSlug == "" {
-			if feed, ok := post.Extra["feed"].(string); ok {
-				feedSlug = feed
-			}
-		}
-
-		// Get post path for path-based layout lookup
-		postPath := post.Href
-		if postPath == "" {
-			postPath = "/" + strings.TrimPrefix(post.Path, "/")
-		}
-
 		// Resolve layout based on path and feed
-		layout := p.layoutConfig.ResolveLayout(postPath, feedSlug)
+		layout := resolvePostLayout(p.layoutConfig, post)
 		if layout != "" {
 			baseTemplate := models.LayoutToTemplate(layout)
 			return adaptTemplateForFormat(baseTemplate, format)
@@ -203,6 +195,26 @@ func (p *TemplatesPlugin) resolveTemplateForFormat(post *models.Post, format str
 	return getHardcodedDefault(format)
 }
 
+// resolvePostLayout returns the layout configured for a post by its href
+// (or source path) and feed, or "" if none applies.
+func resolvePostLayout(lc *models.LayoutConfig, post *models.Post) string {
+	// Get feed slug for feed-based layout lookup
+	feedSlug := post.PrevNextFeed
+	if feedSlug == "" {
+		if feed, ok := post.Extra["feed"].(string); ok {
+			feedSlug = feed
+		}
+	}
+
+	// Get post path for path-based layout lookup
+	postPath := post.Href
+	if postPath == "" {
+		postPath = "/" + strings.TrimPrefix(post.Path, "/")
+	}
+
+	return lc.ResolveLayout(postPath, feedSlug)
+}
+
 // adaptTemplateForFormat adapts a template name for a specific output format.
 // For example: post.html → post.txt, post.md, post-og.html
 func adaptTemplateForFormat(template, format string) string {
@@ -356,7 +368,7 @@ func (p *TemplatesPlugin) Render(m *lifecycle.Manager) error {
 		}
 	}
 	t1 := time.Now()
-	templatesLog.Printf("Phase 1a classify: %d cacheable, %d need render (took %v)", len(cacheablePosts), len(postsNeedingRender), t1.Sub(t0))
+	m.Logger().Debug("classified posts", "cacheable", len(cacheablePosts), "need_render", len(postsNeedingRender), "duration", t1.Sub(t0))
 
 	// Phase 1b: Batch-read all cached HTML files concurrently.
 	// This converts ~2900 sequential os.ReadFile calls into a parallel batch,
@@ -365,7 +377,7 @@ func (p *TemplatesPlugin) Render(m *lifecycle.Manager) error {
 		p.batchRestoreCachedHTML(cacheablePosts, cache, &postsNeedingRender, m.Concurrency())
 	}
 	t2 := time.Now()
-	templatesLog.Printf("Phase 1b batch restore: took %v, %d now need render", t2.Sub(t1), len(postsNeedingRender))
+	m.Logger().Debug("restored cached HTML", "need_render", len(postsNeedingRender), "duration", t2.Sub(t1))
 
 	// Phase 2: Process only posts that need rendering concurrently
 	err := m.ProcessPostsSliceConcurrently(postsNeedingRender, func(post *models.Post) error {
@@ -389,7 +401,7 @@ func (p *TemplatesPlugin) Render(m *lifecycle.Manager) error {
 		return nil
 	})
 	t3 := time.Now()
-	templatesLog.Printf("Phase 2 render: took %v", t3.Sub(t2))
+	m.Logger().Debug("rendered posts", "duration", t3.Sub(t2))
 	return err
 }
 
@@ -974,7 +986,7 @@ func (p *TemplatesPlugin) getSeriesSidebarPosts(post *models.Post, config *lifec
 		cfg:   resolveSeriesOverride(seriesCfg, seriesName, seriesSlugValue),
 	}
 
-	sortSeriesPosts(group, false)
+	sortSeriesPosts(group, nil)
 	publishedPosts := filterSeriesOutputPosts(group.posts)
 	if len(publishedPosts) == 0 {
 		return nil, nil
@@ -1977,3 +1989,15 @@ func feedsPageConfigFromExtra(raw interface{}) models.FeedsPageConfig {
 		return defaults
 	}
 }
+
+// routeConfigFromConfig returns the settings url_for builds URLs from:
+// the auto_feeds tag prefix and the authors URL pattern.
+func routeConfigFromConfig(config *lifecycle.Config) templates.RouteConfig {
+	cfg := templates.RouteConfig{
+		TagPrefix: getAutoFeedsConfig(config).Tags.SlugPrefix,
+	}
+	if modelsConfig, ok := config.Extra["models_config"].(*models.Config); ok && modelsConfig != nil {
+		cfg.AuthorURLPattern = modelsConfig.Authors.URLPattern
+	}
+	return cfg
+}
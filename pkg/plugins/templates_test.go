@@ -1411,3 +1411,23 @@ func TestCollectPrivatePaths(t *testing.T) {
 		})
 	}
 }
+
+func TestRouteConfigFromConfig_UsesTagPrefixAndAuthorPattern(t *testing.T) {
+	config := &lifecycle.Config{Extra: map[string]interface{}{
+		"auto_feeds": map[string]any{
+			"tags": map[string]any{"slug_prefix": "topics"},
+		},
+		"models_config": &models.Config{
+			Authors: models.AuthorsConfig{URLPattern: "/people/{author}/"},
+		},
+	}}
+
+	routes := templates.NewRouteRegistry(routeConfigFromConfig(config))
+
+	if got, err := routes.URL("tag", []string{"Go Lang"}, nil); err != nil || got != "/topics/go-lang/" {
+		t.Errorf("tag URL = %q, %v; want /topics/go-lang/", got, err)
+	}
+	if got, err := routes.URL("author", []string{"ada"}, nil); err != nil || got != "/people/ada/" {
+		t.Errorf("author URL = %q, %v; want /people/ada/", got, err)
+	}
+}
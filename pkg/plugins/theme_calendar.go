@@ -2,7 +2,6 @@ package plugins
 
 import (
 	"fmt"
-	"log"
 	"strconv"
 	"strings"
 	"time"
@@ -72,26 +71,25 @@ func (p *ThemeCalendarPlugin) Configure(m *lifecycle.Manager) error {
 	currentMonth := int(now.Month())
 	currentDay := now.Day()
 
-	log.Printf("[theme_calendar] Checking %d rules for date %02d-%02d", len(calendarConfig.Rules), currentMonth, currentDay)
+	m.Logger().Debug("checking rules", "rules", len(calendarConfig.Rules), "date", fmt.Sprintf("%02d-%02d", currentMonth, currentDay))
 
 	// Find matching rule
 	var matchingRule *models.ThemeCalendarRule
 	for i := range calendarConfig.Rules {
 		rule := &calendarConfig.Rules[i]
-		if p.isDateInRange(currentMonth, currentDay, rule.StartDate, rule.EndDate) {
+		if p.isDateInRange(m.Logger(), currentMonth, currentDay, rule.StartDate, rule.EndDate) {
 			matchingRule = rule
-			log.Printf("[theme_calendar] Matched rule: %s", rule.Name)
 			break
 		}
 	}
 
 	if matchingRule == nil {
-		log.Printf("[theme_calendar] No matching rule found, using base theme")
+		m.Logger().Debug("no matching rule found, using base theme")
 		return nil
 	}
 
 	// Apply the matching rule to theme config
-	p.applyRule(config, matchingRule)
+	p.applyRule(m.Logger(), config, matchingRule)
 
 	return nil
 }
@@ -261,16 +259,16 @@ func (p *ThemeCalendarPlugin) parseFontConfig(m map[string]interface{}) *models.
 
 // isDateInRange checks if the given month/day falls within the start-end range.
 // Handles year-boundary crossings (e.g., Dec 1 to Feb 28).
-func (p *ThemeCalendarPlugin) isDateInRange(month, day int, startDate, endDate string) bool {
+func (p *ThemeCalendarPlugin) isDateInRange(logger lifecycle.Logger, month, day int, startDate, endDate string) bool {
 	startMonth, startDay, err := p.parseMMDD(startDate)
 	if err != nil {
-		log.Printf("[theme_calendar] Invalid start_date %q: %v", startDate, err)
+		logger.Warn("invalid start_date", "start_date", startDate, "error", err)
 		return false
 	}
 
 	endMonth, endDay, err := p.parseMMDD(endDate)
 	if err != nil {
-		log.Printf("[theme_calendar] Invalid end_date %q: %v", endDate, err)
+		logger.Warn("invalid end_date", "end_date", endDate, "error", err)
 		return false
 	}
 
@@ -310,7 +308,7 @@ func (p *ThemeCalendarPlugin) parseMMDD(s string) (month, day int, err error) {
 }
 
 // applyRule applies the matching rule's theme overrides to the config.
-func (p *ThemeCalendarPlugin) applyRule(config *lifecycle.Config, rule *models.ThemeCalendarRule) {
+func (p *ThemeCalendarPlugin) applyRule(logger lifecycle.Logger, config *lifecycle.Config, rule *models.ThemeCalendarRule) {
 	// Get or create theme map in Extra
 	if config.Extra == nil {
 		config.Extra = make(map[string]interface{})
@@ -324,7 +322,6 @@ func (p *ThemeCalendarPlugin) applyRule(config *lifecycle.Config, rule *models.T
 	// Apply custom CSS override
 	if rule.CustomCSS != "" {
 		themeMap["custom_css"] = rule.CustomCSS
-		log.Printf("[theme_calendar] Setting custom_css to %q", rule.CustomCSS)
 	}
 
 	// Merge variables (deep merge with existing)
@@ -339,8 +336,22 @@ func (p *ThemeCalendarPlugin) applyRule(config *lifecycle.Config, rule *models.T
 	// Store the updated theme map
 	config.Extra["theme"] = themeMap
 
-	// Log the active rule
-	log.Printf("[theme_calendar] Applied rule %q for current date", rule.Name)
+	// Log the active rule and what it overrides
+	args := []any{"rule", rule.Name}
+	for _, field := range [][2]string{
+		{"palette", rule.Palette},
+		{"palette_light", rule.PaletteLight},
+		{"palette_dark", rule.PaletteDark},
+		{"custom_css", rule.CustomCSS},
+	} {
+		if field[1] != "" {
+			args = append(args, field[0], field[1])
+		}
+	}
+	if len(rule.Variables) > 0 {
+		args = append(args, "variables", len(rule.Variables))
+	}
+	logger.Info("applied rule for current date", args...)
 }
 
 // getOrCreateThemeMap retrieves existing theme map or creates a new one.
@@ -355,15 +366,12 @@ func (p *ThemeCalendarPlugin) getOrCreateThemeMap(config *lifecycle.Config) map[
 func (p *ThemeCalendarPlugin) applyPaletteOverrides(themeMap map[string]interface{}, rule *models.ThemeCalendarRule) {
 	if rule.Palette != "" {
 		themeMap["palette"] = rule.Palette
-		log.Printf("[theme_calendar] Setting palette to %q", rule.Palette)
 	}
 	if rule.PaletteLight != "" {
 		themeMap["palette_light"] = rule.PaletteLight
-		log.Printf("[theme_calendar] Setting palette_light to %q", rule.PaletteLight)
 	}
 	if rule.PaletteDark != "" {
 		themeMap["palette_dark"] = rule.PaletteDark
-		log.Printf("[theme_calendar] Setting palette_dark to %q", rule.PaletteDark)
 	}
 }
 
@@ -380,7 +388,6 @@ func (p *ThemeCalendarPlugin) applyVariablesOverride(themeMap map[string]interfa
 		existingVars[k] = v
 	}
 	themeMap["variables"] = existingVars
-	log.Printf("[theme_calendar] Merged %d CSS variables", len(rule.Variables))
 }
 
 // applyBackgroundOverride applies background decoration override from the rule.
@@ -407,7 +414,6 @@ func (p *ThemeCalendarPlugin) applyBackgroundOverride(themeMap map[string]interf
 		bgMap["backgrounds"] = bgs
 	}
 	themeMap["background"] = bgMap
-	log.Printf("[theme_calendar] Applied background override")
 }
 
 // applyFontOverride applies font configuration override from the rule.
@@ -441,7 +447,6 @@ func (p *ThemeCalendarPlugin) applyFontOverride(themeMap map[string]interface{},
 		existingFont["custom_urls"] = rule.Font.CustomURLs
 	}
 	themeMap["font"] = existingFont
-	log.Printf("[theme_calendar] Applied font override")
 }
 
 // Compile-time interface verification.
@@ -251,7 +251,7 @@ func TestThemeCalendarPlugin_IsDateInRange(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := p.isDateInRange(tt.month, tt.day, tt.startDate, tt.endDate)
+			got := p.isDateInRange(lifecycle.NopLogger(), tt.month, tt.day, tt.startDate, tt.endDate)
 			if got != tt.want {
 				t.Errorf("isDateInRange(%d, %d, %q, %q) = %v, want %v",
 					tt.month, tt.day, tt.startDate, tt.endDate, got, tt.want)
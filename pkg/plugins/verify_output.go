@@ -0,0 +1,269 @@
+// Package plugins provides lifecycle plugins for markata-go.
+package plugins
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"golang.org/x/net/html"
+
+	"github.com/WaylonWalker/markata-go/pkg/lifecycle"
+	"github.com/WaylonWalker/markata-go/pkg/models"
+)
+
+// maxReportedOutputProblems caps how many problems an OutputVerificationError
+// lists in its message.
+const maxReportedOutputProblems = 20
+
+// verifyOutputConfig is the [markata-go.verify] section.
+type verifyOutputConfig struct {
+	// Enabled runs the verifier after the write stage (default: true).
+	Enabled bool `json:"enabled"`
+
+	// CheckLinks also checks that internal links and images in each post's
+	// HTML resolve to written files (default: false).
+	CheckLinks bool `json:"check_links"`
+
+	// IgnoreLinks lists path prefixes, such as "/downloads/", that link
+	// checking skips.
+	IgnoreLinks []string `json:"ignore_links"`
+}
+
+// OutputVerificationError lists the problems found in the written output.
+type OutputVerificationError struct {
+	Problems []string
+}
+
+func (e *OutputVerificationError) Error() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "output verification found %d problem(s)", len(e.Problems))
+	for i, problem := range e.Problems {
+		if i == maxReportedOutputProblems {
+			fmt.Fprintf(&sb, "\n  ... and %d more", len(e.Problems)-i)
+			break
+		}
+		sb.WriteString("\n  - ")
+		sb.WriteString(problem)
+	}
+	return sb.String()
+}
+
+// VerifyOutputPlugin checks the output after the write stage: every post that
+// should have HTML must have a non-empty index.html, and, when link checking
+// is enabled, internal links and images in that HTML must resolve to written
+// files. It is skipped in fast mode.
+type VerifyOutputPlugin struct {
+	config verifyOutputConfig
+}
+
+// NewVerifyOutputPlugin creates a new VerifyOutputPlugin with default settings.
+func NewVerifyOutputPlugin() *VerifyOutputPlugin {
+	return &VerifyOutputPlugin{
+		config: verifyOutputConfig{Enabled: true},
+	}
+}
+
+// Name returns the unique name of the plugin.
+func (p *VerifyOutputPlugin) Name() string {
+	return "verify_output"
+}
+
+// Configure reads the [markata-go.verify] section.
+func (p *VerifyOutputPlugin) Configure(m *lifecycle.Manager) error {
+	return m.UnmarshalPluginConfig("verify", &p.config)
+}
+
+// Verify checks the written HTML for every post that should have it.
+func (p *VerifyOutputPlugin) Verify(m *lifecycle.Manager) error {
+	config := m.Config()
+	if !p.config.Enabled || lifecycle.IsServeFastModeFromConfig(config) {
+		return nil
+	}
+
+	posts := m.FilterPosts(func(post *models.Post) bool {
+		if post.Skip || post.Draft || post.Slug == "" {
+			return false
+		}
+		if post.HTML == "" && post.ArticleHTML == "" {
+			return false
+		}
+		postFormats := resolvePostFormats(post, config)
+		return postFormats.IsHTMLEnabled()
+	})
+
+	v := &outputVerifier{
+		outputDir:   config.OutputDir,
+		checkLinks:  p.config.CheckLinks,
+		ignoreLinks: p.config.IgnoreLinks,
+		resolved:    make(map[string]bool),
+	}
+	if err := m.ProcessPostsSliceConcurrently(posts, v.verifyPost); err != nil {
+		return err
+	}
+
+	if len(v.problems) == 0 {
+		return nil
+	}
+	sort.Strings(v.problems)
+	return &OutputVerificationError{Problems: v.problems}
+}
+
+// outputVerifier collects problems from concurrent post checks.
+type outputVerifier struct {
+	outputDir   string
+	checkLinks  bool
+	ignoreLinks []string
+
+	mu       sync.Mutex
+	problems []string
+	resolved map[string]bool // Output-relative link target -> exists
+}
+
+func (v *outputVerifier) report(format string, args ...any) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.problems = append(v.problems, fmt.Sprintf(format, args...))
+}
+
+func (v *outputVerifier) verifyPost(post *models.Post) error {
+	outputPath := filepath.Join(v.outputDir, post.Slug, "index.html")
+	info, err := os.Stat(outputPath)
+	switch {
+	case err != nil:
+		v.report("%s: missing %s", post.Path, outputPath)
+		return nil
+	case info.IsDir():
+		v.report("%s: %s is a directory", post.Path, outputPath)
+		return nil
+	case info.Size() == 0:
+		v.report("%s: %s is empty", post.Path, outputPath)
+		return nil
+	}
+
+	if !v.checkLinks {
+		return nil
+	}
+
+	f, err := os.Open(outputPath)
+	if err != nil {
+		v.report("%s: %v", post.Path, err)
+		return nil
+	}
+	defer f.Close()
+
+	pageDir := "/" + strings.Trim(filepath.ToSlash(post.Slug), "/") + "/"
+	seen := make(map[string]bool)
+	for _, link := range htmlLinks(f) {
+		target, ok := internalLinkTarget(pageDir, link)
+		if !ok || seen[link] || v.ignored(target) {
+			continue
+		}
+		seen[link] = true
+		if !v.exists(target) {
+			v.report("%s: broken link %q in %s", post.Path, link, outputPath)
+		}
+	}
+	return nil
+}
+
+func (v *outputVerifier) ignored(target string) bool {
+	for _, prefix := range v.ignoreLinks {
+		if prefix != "" && strings.HasPrefix(target, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// exists reports whether the output-relative target was written, as a file
+// or as a directory with an index.html. Results are cached across posts.
+func (v *outputVerifier) exists(target string) bool {
+	v.mu.Lock()
+	found, ok := v.resolved[target]
+	v.mu.Unlock()
+	if ok {
+		return found
+	}
+
+	fsPath := filepath.Join(v.outputDir, filepath.FromSlash(target))
+	info, err := os.Stat(fsPath)
+	if err == nil && info.IsDir() {
+		_, err = os.Stat(filepath.Join(fsPath, "index.html"))
+	}
+	if errors.Is(err, os.ErrNotExist) && path.Ext(target) == "" {
+		// Extensionless links may point at a page written as target.html
+		_, err = os.Stat(fsPath + ".html")
+	}
+	found = err == nil
+
+	v.mu.Lock()
+	v.resolved[target] = found
+	v.mu.Unlock()
+	return found
+}
+
+// htmlLinks returns the href of every <a> and the src of every <img> in r.
+func htmlLinks(r io.Reader) []string {
+	var links []string
+	tokenizer := html.NewTokenizer(r)
+	for {
+		switch tokenizer.Next() {
+		case html.ErrorToken:
+			return links
+		case html.StartTagToken, html.SelfClosingTagToken:
+			name, hasAttr := tokenizer.TagName()
+			var want string
+			switch string(name) {
+			case "a":
+				want = "href"
+			case "img":
+				want = "src"
+			default:
+				continue
+			}
+			for hasAttr {
+				var key, val []byte
+				key, val, hasAttr = tokenizer.TagAttr()
+				if string(key) == want {
+					links = append(links, string(val))
+				}
+			}
+		}
+	}
+}
+
+// internalLinkTarget resolves link against the page directory and returns
+// the output-relative path it points to. External links, protocol-relative
+// links, fragments, and special schemes are not internal.
+func internalLinkTarget(pageDir, link string) (string, bool) {
+	link = strings.TrimSpace(link)
+	if link == "" || strings.HasPrefix(link, "#") || strings.HasPrefix(link, "//") {
+		return "", false
+	}
+
+	u, err := url.Parse(link)
+	if err != nil || u.Scheme != "" || u.Host != "" || u.Path == "" {
+		return "", false
+	}
+
+	target := u.Path
+	if !strings.HasPrefix(target, "/") {
+		target = pageDir + target
+	}
+	return path.Clean(target), true
+}
+
+// Ensure VerifyOutputPlugin implements the required interfaces.
+var (
+	_ lifecycle.Plugin          = (*VerifyOutputPlugin)(nil)
+	_ lifecycle.ConfigurePlugin = (*VerifyOutputPlugin)(nil)
+	_ lifecycle.VerifyPlugin    = (*VerifyOutputPlugin)(nil)
+)
@@ -0,0 +1,135 @@
+package plugins
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/WaylonWalker/markata-go/pkg/lifecycle"
+	"github.com/WaylonWalker/markata-go/pkg/models"
+)
+
+// writeVerifyFixture writes files relative to dir, creating parent directories.
+func writeVerifyFixture(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("MkdirAll: %v", err)
+		}
+		//nolint:gosec // Test files need 0644
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatalf("WriteFile: %v", err)
+		}
+	}
+}
+
+func runVerifyOutput(t *testing.T, outputDir string, verify map[string]interface{}, posts ...*models.Post) error {
+	t.Helper()
+	config := &lifecycle.Config{OutputDir: outputDir, Extra: map[string]interface{}{}}
+	if verify != nil {
+		config.Extra["verify"] = verify
+	}
+	m := createTestManager(t, config)
+	m.SetPosts(posts)
+
+	p := NewVerifyOutputPlugin()
+	if err := p.Configure(m); err != nil {
+		t.Fatalf("Configure() error = %v", err)
+	}
+	return p.Verify(m)
+}
+
+func verifyProblems(t *testing.T, err error) []string {
+	t.Helper()
+	if err == nil {
+		return nil
+	}
+	var verifyErr *OutputVerificationError
+	if !errors.As(err, &verifyErr) {
+		t.Fatalf("Verify() error = %v, want *OutputVerificationError", err)
+	}
+	return verifyErr.Problems
+}
+
+func TestVerifyOutputPlugin_MissingAndEmptyHTML(t *testing.T) {
+	dir := t.TempDir()
+	writeVerifyFixture(t, dir, map[string]string{
+		"good/index.html":  "<p>ok</p>",
+		"empty/index.html": "",
+	})
+
+	err := runVerifyOutput(t, dir, nil,
+		&models.Post{Path: "good.md", Slug: "good", HTML: "<p>ok</p>"},
+		&models.Post{Path: "empty.md", Slug: "empty", HTML: "<p>lost</p>"},
+		&models.Post{Path: "missing.md", Slug: "missing", ArticleHTML: "<p>never written</p>"},
+		&models.Post{Path: "draft.md", Slug: "draft", HTML: "<p>draft</p>", Draft: true},
+		&models.Post{Path: "skip.md", Slug: "skip", HTML: "<p>skip</p>", Skip: true},
+		&models.Post{Path: "no-content.md", Slug: "no-content"},
+	)
+
+	want := []string{
+		"empty.md: " + filepath.Join(dir, "empty", "index.html") + " is empty",
+		"missing.md: missing " + filepath.Join(dir, "missing", "index.html"),
+	}
+	if got := verifyProblems(t, err); !reflect.DeepEqual(got, want) {
+		t.Errorf("problems = %q, want %q", got, want)
+	}
+}
+
+func TestVerifyOutputPlugin_CheckLinks(t *testing.T) {
+	dir := t.TempDir()
+	page := `<a href="/other/">ok</a>
+<a href="../other/#intro">relative ok</a>
+<a href="/feed.xml?v=1">file ok</a>
+<img src="/images/missing.png">
+<a href="/gone/">gone</a>
+<a href="/downloads/app.zip">ignored</a>
+<a href="https://example.com/gone/">external</a>
+<a href="mailto:me@example.com">mail</a>
+<a href="#top">fragment</a>`
+	writeVerifyFixture(t, dir, map[string]string{
+		"post/index.html":  page,
+		"other/index.html": "<p>other</p>",
+		"feed.xml":         "<rss/>",
+	})
+	post := &models.Post{Path: "post.md", Slug: "post", HTML: page}
+
+	if err := runVerifyOutput(t, dir, nil, post); err != nil {
+		t.Fatalf("links should not be checked by default: %v", err)
+	}
+
+	err := runVerifyOutput(t, dir, map[string]interface{}{
+		"check_links":  true,
+		"ignore_links": []interface{}{"/downloads/"},
+	}, post)
+
+	problems := verifyProblems(t, err)
+	if len(problems) != 2 {
+		t.Fatalf("problems = %q, want the missing image and page", problems)
+	}
+	for i, link := range []string{`"/gone/"`, `"/images/missing.png"`} {
+		if !strings.Contains(problems[i], "broken link "+link) {
+			t.Errorf("problems[%d] = %q, want broken link %s", i, problems[i], link)
+		}
+	}
+}
+
+func TestVerifyOutputPlugin_Skippable(t *testing.T) {
+	dir := t.TempDir()
+	missing := &models.Post{Path: "missing.md", Slug: "missing", HTML: "<p>x</p>"}
+
+	if err := runVerifyOutput(t, dir, map[string]interface{}{"enabled": false}, missing); err != nil {
+		t.Errorf("disabled verifier returned %v", err)
+	}
+
+	config := &lifecycle.Config{OutputDir: dir, Extra: map[string]interface{}{"fast_mode": true}}
+	m := createTestManager(t, config)
+	m.SetPosts([]*models.Post{missing})
+	if err := NewVerifyOutputPlugin().Verify(m); err != nil {
+		t.Errorf("verifier should be skipped in fast mode, got %v", err)
+	}
+}
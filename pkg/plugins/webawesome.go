@@ -90,7 +90,7 @@ func (p *WebAwesomePlugin) Configure(m *lifecycle.Manager) error {
 			}
 		}
 	}
-	if runtimeenv.OfflineEnabled() && !sourceExplicit && !hasExplicitSelfHostedAssetsConfig(config) {
+	if runtimeenv.OfflineEnabled() && !sourceExplicit && !resolvesToSelfHostedAssets(m) {
 		p.config.Source = webAwesomeSourceCDN
 	}
 	p.enableVendorAsset(config)
@@ -129,21 +129,25 @@ func (p *WebAwesomePlugin) parseConfigMap(cfgMap map[string]interface{}) bool {
 	return sourceExplicit
 }
 
-func hasExplicitSelfHostedAssetsConfig(config *lifecycle.Config) bool {
+// resolvesToSelfHostedAssets reports whether the configured assets mode
+// self-hosts assets in this build, resolving "auto" the way cdn_assets does.
+func resolvesToSelfHostedAssets(m *lifecycle.Manager) bool {
+	config := m.Config()
 	if config == nil || config.Extra == nil {
 		return false
 	}
 
-	switch assetsConfig := config.Extra["assets"].(type) {
+	var assetsConfig *models.AssetsConfig
+	switch v := config.Extra["assets"].(type) {
 	case models.AssetsConfig:
-		return assetsConfig.IsSelfHosted()
+		assetsConfig = &v
 	case *models.AssetsConfig:
-		return assetsConfig != nil && assetsConfig.IsSelfHosted()
+		assetsConfig = v
 	case map[string]interface{}:
-		mode, ok := assetsConfig["mode"].(string)
-		return ok && (mode == "self-hosted" || mode == "auto")
+		mode, _ := v["mode"].(string)
+		assetsConfig = &models.AssetsConfig{Mode: mode}
 	}
-	return false
+	return assets.ResolveMode(assetsConfig, assetsEnv(m)) == assets.ModeSelfHosted
 }
 
 // Render processes Web Awesome markdown containers and marks pages that need assets.
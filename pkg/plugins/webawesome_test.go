@@ -407,6 +407,39 @@ func TestWebAwesomePlugin_OfflineExplicitSelfHostedAssetsStillRequiresVendorAsse
 	}
 }
 
+func TestWebAwesomePlugin_OfflineAutoAssetsFollowsResolvedMode(t *testing.T) {
+	t.Setenv(runtimeenv.EnvOffline, "true")
+	t.Setenv("MARKATA_GO_ENV", "")
+
+	tests := []struct {
+		name       string
+		serve      bool
+		wantSource string
+	}{
+		{"serve resolves auto to cdn", true, "cdn"},
+		{"build resolves auto to self-hosted", false, "vendor"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plugin := NewWebAwesomePlugin()
+			m := lifecycle.NewManager()
+			m.SetConfig(&lifecycle.Config{Extra: map[string]interface{}{
+				"assets": map[string]interface{}{
+					"mode": "auto",
+				},
+			}})
+			lifecycle.SetServeMode(m, tt.serve)
+
+			if err := plugin.Configure(m); err != nil {
+				t.Fatalf("Configure() error = %v", err)
+			}
+			if plugin.config.Source != tt.wantSource {
+				t.Errorf("source = %q, want %q", plugin.config.Source, tt.wantSource)
+			}
+		})
+	}
+}
+
 func TestWebAwesomePlugin_ConfigureOmitsIntegrityForVersionOverride(t *testing.T) {
 	plugin := NewWebAwesomePlugin()
 	m := lifecycle.NewManager()
@@ -3,7 +3,10 @@ package resourcehints
 import (
 	"net/url"
 	"regexp"
+	"sort"
 	"strings"
+
+	"github.com/WaylonWalker/markata-go/pkg/models"
 )
 
 // HintType represents a resource hint type.
@@ -33,6 +36,9 @@ type DetectedDomain struct {
 
 	// SourceType indicates where the domain was found
 	SourceType string // "html", "css", "script", "font", etc.
+
+	// Count is how many references to the domain were found
+	Count int
 }
 
 // KnownDomainHint contains pre-configured hints for common services.
@@ -40,6 +46,10 @@ type KnownDomainHint struct {
 	HintTypes   []HintType
 	CrossOrigin string
 	As          string
+
+	// Critical marks domains that block rendering when slow, so they keep
+	// their preconnect ahead of other domains when the budget is exceeded
+	Critical bool
 }
 
 // knownDomains maps common external domains to their recommended hint types.
@@ -48,10 +58,12 @@ var knownDomains = map[string]KnownDomainHint{
 	"fonts.googleapis.com": {
 		HintTypes:   []HintType{HintTypePreconnect},
 		CrossOrigin: "",
+		Critical:    true,
 	},
 	"fonts.gstatic.com": {
 		HintTypes:   []HintType{HintTypePreconnect},
 		CrossOrigin: "anonymous",
+		Critical:    true,
 	},
 
 	// CDNs - dns-prefetch is usually sufficient
@@ -127,15 +139,26 @@ var knownDomains = map[string]KnownDomainHint{
 type Detector struct {
 	// excludeDomains is a set of domains to exclude from detection
 	excludeDomains map[string]bool
+
+	// maxPreconnect limits preconnect hints from SuggestHints; negative means no limit
+	maxPreconnect int
 }
 
 // NewDetector creates a new Detector.
 func NewDetector() *Detector {
 	return &Detector{
 		excludeDomains: make(map[string]bool),
+		maxPreconnect:  models.DefaultMaxPreconnect,
 	}
 }
 
+// SetMaxPreconnect sets how many preconnect hints SuggestHints may return.
+// Domains over the budget are downgraded to dns-prefetch. A negative value
+// removes the limit.
+func (d *Detector) SetMaxPreconnect(n int) {
+	d.maxPreconnect = n
+}
+
 // SetExcludeDomains sets the list of domains to exclude from detection.
 func (d *Detector) SetExcludeDomains(domains []string) {
 	d.excludeDomains = make(map[string]bool, len(domains))
@@ -164,20 +187,29 @@ var (
 
 // DetectExternalDomains scans HTML content and returns a list of detected external domains.
 func (d *Detector) DetectExternalDomains(htmlContent string) []DetectedDomain {
-	seen := make(map[string]bool)
+	seen := make(map[string]int)
 	var domains []DetectedDomain
 
-	// Helper to add domain if not seen
-	addDomain := func(rawURL, sourceType string) {
+	// Helper to add domain if not seen. Repeat references are counted only
+	// when counted is set, since the later, narrower passes match URLs the
+	// first two passes already saw.
+	addDomain := func(rawURL, sourceType string, counted bool) {
 		domain := d.extractDomain(rawURL)
-		if domain == "" || seen[domain] || d.excludeDomains[domain] {
+		if domain == "" || d.excludeDomains[domain] {
+			return
+		}
+		if i, ok := seen[domain]; ok {
+			if counted {
+				domains[i].Count++
+			}
 			return
 		}
-		seen[domain] = true
+		seen[domain] = len(domains)
 		domains = append(domains, DetectedDomain{
 			Domain:     domain,
 			Scheme:     d.extractScheme(rawURL),
 			SourceType: sourceType,
+			Count:      1,
 		})
 	}
 
@@ -192,27 +224,27 @@ func (d *Detector) DetectExternalDomains(htmlContent string) []DetectedDomain {
 		if attrName == "src" {
 			sourceType = "script"
 		}
-		addDomain(matchedURL, sourceType)
+		addDomain(matchedURL, sourceType, true)
 	}
 
 	// Search for CSS url() functions
 	for _, match := range cssURLRegex.FindAllStringSubmatch(htmlContent, -1) {
 		if len(match) >= 2 {
-			addDomain(match[1], "css")
+			addDomain(match[1], "css", true)
 		}
 	}
 
 	// Search for inline style URLs
 	for _, match := range inlineStyleRegex.FindAllStringSubmatch(htmlContent, -1) {
 		if len(match) >= 2 {
-			addDomain(match[1], "style")
+			addDomain(match[1], "style", false)
 		}
 	}
 
 	// Search for script src specifically
 	for _, match := range scriptSrcRegex.FindAllStringSubmatch(htmlContent, -1) {
 		if len(match) >= 2 {
-			addDomain(match[1], "script")
+			addDomain(match[1], "script", false)
 		}
 	}
 
@@ -225,7 +257,7 @@ func (d *Detector) DetectExternalDomains(htmlContent string) []DetectedDomain {
 				strings.Contains(strings.ToLower(match[0]), "font") {
 				sourceType = "font"
 			}
-			addDomain(match[1], sourceType)
+			addDomain(match[1], sourceType, false)
 		}
 	}
 
@@ -276,6 +308,10 @@ func (d *Detector) extractScheme(rawURL string) string {
 
 // SuggestHints suggests appropriate hints for detected domains.
 // Uses known domain database for optimal hint types.
+//
+// At most the detector's preconnect budget (models.DefaultMaxPreconnect
+// unless set with SetMaxPreconnect) of hints are preconnects; see
+// applyPreconnectBudget.
 func (d *Detector) SuggestHints(domains []DetectedDomain) []SuggestedHint {
 	hints := make([]SuggestedHint, 0, len(domains))
 
@@ -298,9 +334,78 @@ func (d *Detector) SuggestHints(domains []DetectedDomain) []SuggestedHint {
 		hints = append(hints, hint)
 	}
 
+	d.applyPreconnectBudget(hints, domains)
+
 	return hints
 }
 
+// applyPreconnectBudget downgrades preconnect hints beyond the detector's
+// budget to dns-prefetch, in place. hints[i] must correspond to domains[i].
+//
+// Preconnects are ranked by known-critical domains (such as Google Fonts)
+// first, then by how often the domain is referenced, then by the order the
+// domains were found.
+func (d *Detector) applyPreconnectBudget(hints []SuggestedHint, domains []DetectedDomain) {
+	if d.maxPreconnect < 0 {
+		return
+	}
+
+	var candidates []int
+	for i, hint := range hints {
+		if hasHintType(hint.HintTypes, HintTypePreconnect) {
+			candidates = append(candidates, i)
+		}
+	}
+	if len(candidates) <= d.maxPreconnect {
+		return
+	}
+
+	score := func(i int) (critical bool, count int) {
+		count = domains[i].Count
+		if count < 1 {
+			count = 1
+		}
+		return knownDomains[domains[i].Domain].Critical, count
+	}
+	sort.SliceStable(candidates, func(a, b int) bool {
+		criticalA, countA := score(candidates[a])
+		criticalB, countB := score(candidates[b])
+		if criticalA != criticalB {
+			return criticalA
+		}
+		return countA > countB
+	})
+
+	for _, i := range candidates[d.maxPreconnect:] {
+		hints[i].HintTypes = downgradePreconnect(hints[i].HintTypes)
+		hints[i].CrossOrigin = ""
+	}
+}
+
+// hasHintType reports whether types contains t.
+func hasHintType(types []HintType, t HintType) bool {
+	for _, ht := range types {
+		if ht == t {
+			return true
+		}
+	}
+	return false
+}
+
+// downgradePreconnect returns types with preconnect replaced by dns-prefetch.
+func downgradePreconnect(types []HintType) []HintType {
+	result := make([]HintType, 0, len(types))
+	for _, t := range types {
+		if t == HintTypePreconnect {
+			t = HintTypeDNSPrefetch
+		}
+		if !hasHintType(result, t) {
+			result = append(result, t)
+		}
+	}
+	return result
+}
+
 // SuggestedHint represents a suggested resource hint for a domain.
 type SuggestedHint struct {
 	Domain      string
@@ -308,6 +413,13 @@ type SuggestedHint struct {
 	HintTypes   []HintType
 	CrossOrigin string
 	As          string
+
+	// URL is the full resource URL for preload hints; when empty the hint
+	// points at the domain's origin
+	URL string
+
+	// Type is the MIME type of a preloaded resource (e.g., "font/woff2")
+	Type string
 }
 
 // GetKnownDomains returns the list of domains with predefined hints.
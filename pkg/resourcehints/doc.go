@@ -26,6 +26,25 @@
 //	generator := resourcehints.NewGenerator()
 //	tags := generator.GenerateHintTags(hints)
 //
+// GenerateHintTags emits preconnect, then dns-prefetch, then preload (font,
+// style, script, image), then prefetch, sorted by domain and URL within each
+// group so the output is stable across builds.
+//
+// MergeWithExisting drops hints that a user already added through head.link,
+// matching on normalized origin (or URL), rel, and crossorigin, so a page never
+// carries two preconnects to the same origin:
+//
+//	hints = generator.MergeWithExisting(config.Head.Link, hints)
+//
+// # Font Preloads
+//
+// DetectFontPreloads parses @font-face rules and returns preload hints for
+// each face's preferred source (woff2 first), with relative URLs resolved
+// against the stylesheet's location:
+//
+//	hints := detector.DetectFontPreloads(css, "/css/fonts.css")
+//	tags := generator.GenerateHintTags(hints)
+//
 // # Known Domains
 //
 // The package includes built-in knowledge of common CDNs and services:
@@ -0,0 +1,195 @@
+package resourcehints
+
+import (
+	"net/url"
+	"path"
+	"regexp"
+	"strings"
+)
+
+// Regular expressions for parsing @font-face rules.
+var (
+	// Match @font-face blocks; descriptors never contain nested braces
+	fontFaceRegex = regexp.MustCompile(`(?is)@font-face\s*\{([^}]*)\}`)
+
+	// Match the URL of a url() function, quoted or unquoted
+	fontURLRegex = regexp.MustCompile(`(?i)url\(\s*(?:"([^"]*)"|'([^']*)'|([^)'"\s]*))\s*\)`)
+
+	// Match the format() hint of a src entry
+	fontFormatRegex = regexp.MustCompile(`(?i)format\(\s*["']?([^"')]+)["']?\s*\)`)
+)
+
+// fontFormats maps font formats to their preference (lower is preferred)
+// and MIME type. Formats not listed here are never preloaded.
+var fontFormats = map[string]struct {
+	rank     int
+	mimeType string
+}{
+	"woff2":    {0, "font/woff2"},
+	"woff":     {1, "font/woff"},
+	"truetype": {2, "font/ttf"},
+	"opentype": {2, "font/otf"},
+}
+
+// fontExtensionFormats infers a font format from a file extension when a
+// src entry has no format() hint.
+var fontExtensionFormats = map[string]string{
+	".woff2": "woff2",
+	".woff":  "woff",
+	".ttf":   "truetype",
+	".otf":   "opentype",
+}
+
+// fontSource is one url() entry of a @font-face src descriptor.
+type fontSource struct {
+	url    string
+	format string
+}
+
+// DetectFontPreloads parses @font-face rules in CSS and returns a preload
+// hint for each font face.
+//
+// Each face is preloaded from its preferred source: woff2, then woff, then
+// truetype or opentype. Faces whose preferred source is a data: URI are
+// skipped because the font arrives with the stylesheet. Relative URLs are
+// resolved against stylesheetURL, the location the CSS was served from
+// (e.g. "/css/fonts.css" or "https://cdn.example.com/fonts.css"); if it is
+// empty, URLs are returned as written.
+func (d *Detector) DetectFontPreloads(css, stylesheetURL string) []SuggestedHint {
+	var base *url.URL
+	if stylesheetURL != "" {
+		if parsed, err := url.Parse(stylesheetURL); err == nil {
+			base = parsed
+		}
+	}
+
+	seen := make(map[string]bool)
+	var hints []SuggestedHint
+
+	for _, face := range fontFaceRegex.FindAllStringSubmatch(css, -1) {
+		src, ok := preferredFontSource(face[1])
+		if !ok || strings.HasPrefix(strings.ToLower(src.url), "data:") {
+			continue
+		}
+
+		href := src.url
+		if base != nil {
+			ref, err := url.Parse(href)
+			if err != nil {
+				continue
+			}
+			href = base.ResolveReference(ref).String()
+		}
+
+		domain := d.extractDomain(href)
+		if seen[href] || (domain != "" && d.excludeDomains[domain]) {
+			continue
+		}
+		seen[href] = true
+
+		hints = append(hints, SuggestedHint{
+			Domain:      domain,
+			Scheme:      d.extractScheme(href),
+			URL:         href,
+			HintTypes:   []HintType{HintTypePreload},
+			CrossOrigin: "anonymous",
+			As:          "font",
+			Type:        fontFormats[src.format].mimeType,
+		})
+	}
+
+	return hints
+}
+
+// preferredFontSource returns the best supported source of a @font-face
+// block. When src is declared more than once, the last declaration wins,
+// as in CSS.
+func preferredFontSource(block string) (fontSource, bool) {
+	var src string
+	for _, decl := range splitCSSTopLevel(block, ';') {
+		name, value, ok := strings.Cut(decl, ":")
+		if ok && strings.EqualFold(strings.TrimSpace(name), "src") {
+			src = value
+		}
+	}
+
+	var best fontSource
+	found := false
+	for _, entry := range splitCSSTopLevel(src, ',') {
+		candidate, ok := parseFontSource(entry)
+		if !ok {
+			continue
+		}
+		if !found || fontFormats[candidate.format].rank < fontFormats[best.format].rank {
+			best, found = candidate, true
+		}
+	}
+	return best, found
+}
+
+// parseFontSource parses a single src entry such as
+// url("a.woff2") format("woff2"). local() entries and unsupported formats
+// are rejected.
+func parseFontSource(entry string) (fontSource, bool) {
+	m := fontURLRegex.FindStringSubmatch(entry)
+	if m == nil {
+		return fontSource{}, false
+	}
+	src := fontSource{url: m[1] + m[2] + m[3]}
+	if src.url == "" {
+		return fontSource{}, false
+	}
+
+	if f := fontFormatRegex.FindStringSubmatch(entry); f != nil {
+		src.format = strings.ToLower(strings.TrimSpace(f[1]))
+	} else if lower := strings.ToLower(src.url); strings.HasPrefix(lower, "data:") {
+		// Embedded fonts only need a format to be ranked, so the MIME type is enough
+		mimeType, _, _ := strings.Cut(lower, ";")
+		switch {
+		case strings.Contains(mimeType, "woff2"):
+			src.format = "woff2"
+		case strings.Contains(mimeType, "woff"):
+			src.format = "woff"
+		}
+	} else {
+		p := src.url
+		if i := strings.IndexAny(p, "?#"); i >= 0 {
+			p = p[:i]
+		}
+		src.format = fontExtensionFormats[strings.ToLower(path.Ext(p))]
+	}
+
+	if _, ok := fontFormats[src.format]; !ok {
+		return fontSource{}, false
+	}
+	return src, true
+}
+
+// splitCSSTopLevel splits a CSS value on sep outside parentheses and
+// quotes, so data: URIs and quoted URLs stay intact.
+func splitCSSTopLevel(value string, sep rune) []string {
+	var parts []string
+	depth := 0
+	var quote rune
+	start := 0
+	for i, r := range value {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '"' || r == '\'':
+			quote = r
+		case r == '(':
+			depth++
+		case r == ')':
+			if depth > 0 {
+				depth--
+			}
+		case r == sep && depth == 0:
+			parts = append(parts, value[start:i])
+			start = i + 1
+		}
+	}
+	return append(parts, value[start:])
+}
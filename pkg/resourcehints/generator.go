@@ -2,6 +2,7 @@ package resourcehints
 
 import (
 	"fmt"
+	"net/url"
 	"sort"
 	"strings"
 
@@ -18,32 +19,54 @@ func NewGenerator() *Generator {
 
 // GenerateHintTags generates HTML link tags for the given hints.
 // Returns a string of newline-separated link tags.
+//
+// Tags are emitted in the order browsers benefit from most: preconnect,
+// dns-prefetch, preload, then prefetch. Preloads are further ordered by their
+// "as" destination (font, style, script, image, then anything else). Ties are
+// broken by domain, URL, and MIME type so the output is identical from build
+// to build regardless of detection order.
 func (g *Generator) GenerateHintTags(hints []SuggestedHint) string {
 	if len(hints) == 0 {
 		return ""
 	}
 
-	var tags []string
+	// A hint may carry several types; order each (hint, type) pair on its own
+	// so every preconnect precedes every dns-prefetch, and so on.
+	type hintTag struct {
+		hint     SuggestedHint
+		hintType HintType
+	}
+	var pending []hintTag
+	for _, hint := range hints {
+		for _, hintType := range hint.HintTypes {
+			pending = append(pending, hintTag{hint: hint, hintType: hintType})
+		}
+	}
 
-	// Sort hints for consistent output (preconnect first, then dns-prefetch, etc.)
-	sortedHints := make([]SuggestedHint, len(hints))
-	copy(sortedHints, hints)
-	sort.Slice(sortedHints, func(i, j int) bool {
-		// Priority: preconnect > dns-prefetch > preload > prefetch
-		iPriority := hintTypePriority(sortedHints[i].HintTypes)
-		jPriority := hintTypePriority(sortedHints[j].HintTypes)
-		if iPriority != jPriority {
-			return iPriority < jPriority
-		}
-		return sortedHints[i].Domain < sortedHints[j].Domain
+	sort.SliceStable(pending, func(i, j int) bool {
+		a, b := pending[i], pending[j]
+		if pa, pb := hintTypePriority(a.hintType), hintTypePriority(b.hintType); pa != pb {
+			return pa < pb
+		}
+		if a.hintType == HintTypePreload {
+			if pa, pb := preloadAsPriority(a.hint.As), preloadAsPriority(b.hint.As); pa != pb {
+				return pa < pb
+			}
+		}
+		if a.hint.Domain != b.hint.Domain {
+			return a.hint.Domain < b.hint.Domain
+		}
+		if a.hint.URL != b.hint.URL {
+			return a.hint.URL < b.hint.URL
+		}
+		return a.hint.Type < b.hint.Type
 	})
 
-	for _, hint := range sortedHints {
-		for _, hintType := range hint.HintTypes {
-			tag := g.generateTag(hint, hintType)
-			if tag != "" {
-				tags = append(tags, tag)
-			}
+	var tags []string
+	for _, p := range pending {
+		tag := g.generateTag(p.hint, p.hintType)
+		if tag != "" {
+			tags = append(tags, tag)
 		}
 	}
 
@@ -56,18 +79,32 @@ func (g *Generator) GenerateHintTags(hints []SuggestedHint) string {
 
 // hintTypePriority returns a priority value for sorting hint types.
 // Lower values = higher priority (should come first).
-func hintTypePriority(types []HintType) int {
-	for _, t := range types {
-		switch t {
-		case HintTypePreconnect:
-			return 0
-		case HintTypeDNSPrefetch:
-			return 1
-		case HintTypePreload:
-			return 2
-		case HintTypePrefetch:
-			return 3
-		}
+func hintTypePriority(t HintType) int {
+	switch t {
+	case HintTypePreconnect:
+		return 0
+	case HintTypeDNSPrefetch:
+		return 1
+	case HintTypePreload:
+		return 2
+	case HintTypePrefetch:
+		return 3
+	}
+	return 4
+}
+
+// preloadAsPriority returns a priority value for sorting preloads by their
+// "as" destination. Lower values = higher priority (should come first).
+func preloadAsPriority(as string) int {
+	switch strings.ToLower(as) {
+	case "font":
+		return 0
+	case "style":
+		return 1
+	case "script":
+		return 2
+	case "image":
+		return 3
 	}
 	return 4
 }
@@ -80,6 +117,9 @@ func (g *Generator) generateTag(hint SuggestedHint, hintType HintType) string {
 	}
 
 	href := fmt.Sprintf("%s://%s", scheme, hint.Domain)
+	if hint.URL != "" {
+		href = hint.URL
+	}
 
 	attrs := []string{
 		fmt.Sprintf("rel=%q", string(hintType)),
@@ -98,6 +138,9 @@ func (g *Generator) generateTag(hint SuggestedHint, hintType HintType) string {
 	// Add "as" attribute for preload hints
 	if hintType == HintTypePreload && hint.As != "" {
 		attrs = append(attrs, fmt.Sprintf("as=%q", hint.As))
+		if hint.Type != "" {
+			attrs = append(attrs, fmt.Sprintf("type=%q", hint.Type))
+		}
 	}
 
 	return fmt.Sprintf("<link %s>", strings.Join(attrs, " "))
@@ -106,6 +149,12 @@ func (g *Generator) generateTag(hint SuggestedHint, hintType HintType) string {
 // GenerateFromConfig generates hint tags from a ResourceHintsConfig.
 // Combines manually configured domains with auto-detected ones.
 func (g *Generator) GenerateFromConfig(config *models.ResourceHintsConfig, detectedDomains []DetectedDomain) string {
+	return g.GenerateHintTags(g.HintsFromConfig(config, detectedDomains))
+}
+
+// HintsFromConfig returns the hints GenerateFromConfig would render: the
+// manually configured domains followed by the auto-detected ones.
+func (g *Generator) HintsFromConfig(config *models.ResourceHintsConfig, detectedDomains []DetectedDomain) []SuggestedHint {
 	var allHints []SuggestedHint
 
 	// Add manually configured domains
@@ -141,6 +190,18 @@ func (g *Generator) GenerateFromConfig(config *models.ResourceHintsConfig, detec
 		detector := NewDetector()
 		detector.SetExcludeDomains(config.ExcludeDomains)
 
+		// Manually configured preconnects count against the budget
+		budget := config.GetMaxPreconnect()
+		if budget >= 0 {
+			for _, hint := range allHints {
+				if hasHintType(hint.HintTypes, HintTypePreconnect) {
+					budget--
+				}
+			}
+			budget = max(budget, 0)
+		}
+		detector.SetMaxPreconnect(budget)
+
 		// Filter out domains that are already manually configured
 		configuredDomains := make(map[string]bool)
 		for _, d := range config.Domains {
@@ -158,7 +219,104 @@ func (g *Generator) GenerateFromConfig(config *models.ResourceHintsConfig, detec
 		allHints = append(allHints, suggestedHints...)
 	}
 
-	return g.GenerateHintTags(allHints)
+	return allHints
+}
+
+// MergeWithExisting drops the hints that duplicate a <link> tag the user
+// already added through head.link, so a page never carries two preconnects
+// to the same origin. A hint type is skipped when an existing link has the
+// same rel, the same crossorigin setting, and the same normalized origin (or
+// full URL, for hints that target a specific resource). Hints left with no
+// types are removed.
+func (g *Generator) MergeWithExisting(existing []models.LinkTag, hints []SuggestedHint) []SuggestedHint {
+	if len(existing) == 0 || len(hints) == 0 {
+		return hints
+	}
+
+	present := make(map[hintKey]bool, len(existing))
+	for _, link := range existing {
+		target := normalizeHintTarget(link.Href)
+		if target == "" {
+			continue
+		}
+		for _, rel := range strings.Fields(strings.ToLower(link.Rel)) {
+			present[hintKey{rel: rel, target: target, crossOrigin: link.Crossorigin}] = true
+		}
+	}
+
+	merged := make([]SuggestedHint, 0, len(hints))
+	for _, hint := range hints {
+		target := normalizeHintTarget(hintHref(hint))
+		var kept []HintType
+		for _, hintType := range hint.HintTypes {
+			key := hintKey{rel: string(hintType), target: target, crossOrigin: hint.CrossOrigin != ""}
+			if !present[key] {
+				kept = append(kept, hintType)
+			}
+		}
+		if len(kept) == 0 {
+			continue
+		}
+		hint.HintTypes = kept
+		merged = append(merged, hint)
+	}
+	return merged
+}
+
+// hintKey identifies a rendered hint for MergeWithExisting.
+type hintKey struct {
+	rel         string
+	target      string
+	crossOrigin bool
+}
+
+// hintHref returns the href generateTag renders for a hint.
+func hintHref(hint SuggestedHint) string {
+	if hint.URL != "" {
+		return hint.URL
+	}
+	scheme := hint.Scheme
+	if scheme == "" {
+		scheme = "https"
+	}
+	return scheme + "://" + hint.Domain
+}
+
+// normalizeHintTarget lowercases an href's scheme and host, treats
+// protocol-relative URLs as https, and drops default ports and a bare
+// trailing slash, so "https://Fonts.gstatic.com/" matches
+// "https://fonts.gstatic.com". Root-relative paths such as local font
+// preloads are kept as-is; any other href without a host returns "".
+func normalizeHintTarget(href string) string {
+	href = strings.TrimSpace(href)
+	if strings.HasPrefix(href, "//") {
+		href = "https:" + href
+	}
+	u, err := url.Parse(href)
+	if err != nil {
+		return ""
+	}
+	if u.Host == "" {
+		if u.Scheme == "" && strings.HasPrefix(u.Path, "/") {
+			return href
+		}
+		return ""
+	}
+
+	scheme := strings.ToLower(u.Scheme)
+	host := strings.ToLower(u.Hostname())
+	if port := u.Port(); port != "" && !(scheme == "https" && port == "443") && !(scheme == "http" && port == "80") {
+		host += ":" + port
+	}
+
+	target := scheme + "://" + host
+	if path := u.EscapedPath(); path != "" && path != "/" {
+		target += path
+	}
+	if u.RawQuery != "" {
+		target += "?" + u.RawQuery
+	}
+	return target
 }
 
 // GenerateComment generates an HTML comment to wrap resource hints.
@@ -1,7 +1,10 @@
 package resourcehints
 
 import (
+	"strings"
 	"testing"
+
+	"github.com/WaylonWalker/markata-go/pkg/models"
 )
 
 func TestDetector_DetectExternalDomains(t *testing.T) {
@@ -178,6 +181,120 @@ func TestDetector_SuggestHints(t *testing.T) {
 	}
 }
 
+func TestDetector_DetectExternalDomainsCount(t *testing.T) {
+	html := `<a href="https://www.youtube.com/watch?v=1">One</a>
+             <a href="https://www.youtube.com/watch?v=2">Two</a>
+             <script src="https://cdn.jsdelivr.net/a.js"></script>`
+
+	domains := NewDetector().DetectExternalDomains(html)
+
+	counts := make(map[string]int)
+	for _, d := range domains {
+		counts[d.Domain] = d.Count
+	}
+	if counts["www.youtube.com"] != 2 {
+		t.Errorf("www.youtube.com count = %d, want 2", counts["www.youtube.com"])
+	}
+	if counts["cdn.jsdelivr.net"] != 1 {
+		t.Errorf("cdn.jsdelivr.net count = %d, want 1", counts["cdn.jsdelivr.net"])
+	}
+}
+
+func TestDetector_PreconnectBudget(t *testing.T) {
+	// Five preconnect candidates, listed in detection order
+	domains := []DetectedDomain{
+		{Domain: "www.youtube.com", Scheme: "https", Count: 1},
+		{Domain: "player.vimeo.com", Scheme: "https", Count: 3},
+		{Domain: "www.youtube-nocookie.com", Scheme: "https", Count: 1},
+		{Domain: "fonts.gstatic.com", Scheme: "https", Count: 1},
+		{Domain: "fonts.googleapis.com", Scheme: "https", Count: 1},
+		{Domain: "cdn.jsdelivr.net", Scheme: "https", Count: 9},
+	}
+
+	tests := []struct {
+		name       string
+		max        int
+		preconnect []string
+	}{
+		// Critical fonts first, then most referenced, then first found
+		{"budget of three", 3, []string{"player.vimeo.com", "fonts.gstatic.com", "fonts.googleapis.com"}},
+		{"budget of four", 4, []string{"www.youtube.com", "player.vimeo.com", "fonts.gstatic.com", "fonts.googleapis.com"}},
+		{"zero budget", 0, nil},
+		{"no limit", -1, []string{"www.youtube.com", "player.vimeo.com", "www.youtube-nocookie.com", "fonts.gstatic.com", "fonts.googleapis.com"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			detector := NewDetector()
+			detector.SetMaxPreconnect(tt.max)
+			hints := detector.SuggestHints(domains)
+
+			if len(hints) != len(domains) {
+				t.Fatalf("Expected %d hints, got %d", len(domains), len(hints))
+			}
+
+			var preconnect []string
+			for i, hint := range hints {
+				if hint.Domain != domains[i].Domain {
+					t.Errorf("hints[%d].Domain = %q, want detection order %q", i, hint.Domain, domains[i].Domain)
+				}
+				if len(hint.HintTypes) != 1 {
+					t.Errorf("%s: expected a single hint type, got %v", hint.Domain, hint.HintTypes)
+					continue
+				}
+				switch hint.HintTypes[0] {
+				case HintTypePreconnect:
+					preconnect = append(preconnect, hint.Domain)
+				case HintTypeDNSPrefetch:
+					if hint.CrossOrigin != "" {
+						t.Errorf("%s: downgraded hint should drop crossorigin", hint.Domain)
+					}
+				}
+			}
+
+			if len(preconnect) != len(tt.preconnect) {
+				t.Fatalf("preconnect = %v, want %v", preconnect, tt.preconnect)
+			}
+			for i := range preconnect {
+				if preconnect[i] != tt.preconnect[i] {
+					t.Errorf("preconnect = %v, want %v", preconnect, tt.preconnect)
+					break
+				}
+			}
+		})
+	}
+}
+
+func TestGenerator_GenerateFromConfigPreconnectBudget(t *testing.T) {
+	maxPreconnect := 2
+	config := models.NewResourceHintsConfig()
+	config.MaxPreconnect = &maxPreconnect
+	config.Domains = []models.DomainHint{
+		{Domain: "api.example.com", HintTypes: []string{"preconnect"}},
+	}
+
+	detected := []DetectedDomain{
+		{Domain: "www.youtube.com", Scheme: "https", Count: 1},
+		{Domain: "fonts.googleapis.com", Scheme: "https", Count: 1},
+	}
+
+	result := NewGenerator().GenerateFromConfig(&config, detected)
+
+	// The manual preconnect uses one slot; Google Fonts wins the other
+	for _, want := range []string{
+		`<link rel="preconnect" href="https://api.example.com">`,
+		`<link rel="preconnect" href="https://fonts.googleapis.com">`,
+		`<link rel="dns-prefetch" href="https://www.youtube.com">`,
+	} {
+		if !containsString(result, want) {
+			t.Errorf("Result should contain %q, got:\n%s", want, result)
+		}
+	}
+	if indexOf(result, "www.youtube.com") < indexOf(result, "fonts.googleapis.com") {
+		t.Error("downgraded dns-prefetch hints should come after preconnects")
+	}
+}
+
 func TestGenerator_GenerateHintTags(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -280,6 +397,45 @@ func TestGenerator_SortOrder(t *testing.T) {
 	}
 }
 
+func TestGenerator_SortOrderMixedHints(t *testing.T) {
+	hints := []SuggestedHint{
+		{Domain: "z.com", URL: "https://z.com/next/", HintTypes: []HintType{HintTypePrefetch}},
+		{Domain: "img.com", URL: "https://img.com/hero.webp", As: "image", HintTypes: []HintType{HintTypePreload}},
+		{Domain: "cdn.com", URL: "https://cdn.com/app.js", As: "script", HintTypes: []HintType{HintTypePreload}},
+		{Domain: "b.com", Scheme: "https", HintTypes: []HintType{HintTypeDNSPrefetch, HintTypePreconnect}},
+		{Domain: "cdn.com", URL: "https://cdn.com/site.css", As: "style", HintTypes: []HintType{HintTypePreload}},
+		{Domain: "fonts.com", URL: "https://fonts.com/b.woff2", As: "font", Type: "font/woff2", CrossOrigin: "anonymous", HintTypes: []HintType{HintTypePreload}},
+		{Domain: "a.com", Scheme: "https", HintTypes: []HintType{HintTypeDNSPrefetch}},
+		{Domain: "fonts.com", URL: "https://fonts.com/a.woff2", As: "font", Type: "font/woff2", CrossOrigin: "anonymous", HintTypes: []HintType{HintTypePreload}},
+	}
+
+	want := strings.Join([]string{
+		`<link rel="preconnect" href="https://b.com">`,
+		`<link rel="dns-prefetch" href="https://a.com">`,
+		`<link rel="dns-prefetch" href="https://b.com">`,
+		`<link rel="preload" href="https://fonts.com/a.woff2" crossorigin as="font" type="font/woff2">`,
+		`<link rel="preload" href="https://fonts.com/b.woff2" crossorigin as="font" type="font/woff2">`,
+		`<link rel="preload" href="https://cdn.com/site.css" as="style">`,
+		`<link rel="preload" href="https://cdn.com/app.js" as="script">`,
+		`<link rel="preload" href="https://img.com/hero.webp" as="image">`,
+		`<link rel="prefetch" href="https://z.com/next/">`,
+	}, "\n")
+
+	generator := NewGenerator()
+	if got := generator.GenerateHintTags(hints); got != want {
+		t.Errorf("GenerateHintTags() =\n%s\nwant:\n%s", got, want)
+	}
+
+	// Output must not depend on input order
+	reversed := make([]SuggestedHint, len(hints))
+	for i, hint := range hints {
+		reversed[len(hints)-1-i] = hint
+	}
+	if got := generator.GenerateHintTags(reversed); got != want {
+		t.Errorf("GenerateHintTags(reversed) =\n%s\nwant:\n%s", got, want)
+	}
+}
+
 func TestGenerateComment(t *testing.T) {
 	content := `<link rel="preconnect" href="https://example.com">`
 	result := GenerateComment(content)
@@ -324,3 +480,163 @@ func lastIndexOf(s, substr string) int {
 	}
 	return last
 }
+
+func TestDetector_DetectFontPreloads(t *testing.T) {
+	tests := []struct {
+		name       string
+		css        string
+		stylesheet string
+		wantURLs   []string
+		wantTypes  []string
+	}{
+		{
+			name: "Prefers woff2 over other formats",
+			css: `@font-face {
+  font-family: "Inter";
+  src: url("inter.ttf") format("truetype"), url("inter.woff") format("woff"), url("inter.woff2") format("woff2");
+}`,
+			stylesheet: "/css/fonts.css",
+			wantURLs:   []string{"/css/inter.woff2"},
+			wantTypes:  []string{"font/woff2"},
+		},
+		{
+			name:       "Relative URL resolved against CDN stylesheet",
+			css:        `@font-face { font-family: X; src: url(../fonts/x.woff2?v=2) format('woff2'); }`,
+			stylesheet: "https://cdn.example.com/css/x.css",
+			wantURLs:   []string{"https://cdn.example.com/fonts/x.woff2?v=2"},
+			wantTypes:  []string{"font/woff2"},
+		},
+		{
+			name:       "Format inferred from extension",
+			css:        `@font-face { src: local("Mono"), url('/fonts/mono.woff'); }`,
+			stylesheet: "/style.css",
+			wantURLs:   []string{"/fonts/mono.woff"},
+			wantTypes:  []string{"font/woff"},
+		},
+		{
+			name:       "Embedded woff2 skips the face",
+			css:        `@font-face { src: url(data:font/woff2;base64,d09GMgABAAAA) format("woff2"), url(a.woff) format("woff"); }`,
+			stylesheet: "/style.css",
+			wantURLs:   []string{},
+		},
+		{
+			name:       "Data URI fallback ignored",
+			css:        `@font-face { src: url("b.woff2") format("woff2"), url(data:font/ttf;base64,AAEAAA) format("truetype"); }`,
+			stylesheet: "",
+			wantURLs:   []string{"b.woff2"},
+			wantTypes:  []string{"font/woff2"},
+		},
+		{
+			name:       "Last src declaration wins",
+			css:        `@font-face { src: url(old.eot); src: url(new.woff2) format("woff2"); }`,
+			stylesheet: "/",
+			wantURLs:   []string{"/new.woff2"},
+			wantTypes:  []string{"font/woff2"},
+		},
+		{
+			name:       "Unsupported formats skipped",
+			css:        `@font-face { src: url(a.eot) format("embedded-opentype"), url(a.svg#font) format("svg"); }`,
+			stylesheet: "/",
+			wantURLs:   []string{},
+		},
+		{
+			name: "Multiple faces deduplicated",
+			css: `@font-face { font-weight: 400; src: url(/f/a.woff2); }
+@font-face { font-weight: 700; src: url(/f/b.woff2); }
+@font-face { font-style: italic; src: url(/f/a.woff2); }`,
+			stylesheet: "/css/site.css",
+			wantURLs:   []string{"/f/a.woff2", "/f/b.woff2"},
+			wantTypes:  []string{"font/woff2", "font/woff2"},
+		},
+	}
+
+	detector := NewDetector()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hints := detector.DetectFontPreloads(tt.css, tt.stylesheet)
+
+			if len(hints) != len(tt.wantURLs) {
+				t.Fatalf("Expected %d hints, got %d: %+v", len(tt.wantURLs), len(hints), hints)
+			}
+			for i, hint := range hints {
+				if hint.URL != tt.wantURLs[i] {
+					t.Errorf("hints[%d].URL = %q, want %q", i, hint.URL, tt.wantURLs[i])
+				}
+				if hint.Type != tt.wantTypes[i] {
+					t.Errorf("hints[%d].Type = %q, want %q", i, hint.Type, tt.wantTypes[i])
+				}
+				if hint.As != "font" || hint.CrossOrigin != "anonymous" {
+					t.Errorf("hints[%d] should be a cross-origin font preload, got %+v", i, hint)
+				}
+			}
+		})
+	}
+}
+
+func TestDetector_DetectFontPreloadsExcludeDomains(t *testing.T) {
+	detector := NewDetector()
+	detector.SetExcludeDomains([]string{"fonts.gstatic.com"})
+
+	css := `@font-face { src: url(https://fonts.gstatic.com/s/inter.woff2) format("woff2"); }`
+	if hints := detector.DetectFontPreloads(css, ""); len(hints) != 0 {
+		t.Errorf("Expected excluded font domain to be skipped, got %+v", hints)
+	}
+}
+
+func TestGenerator_FontPreloadTag(t *testing.T) {
+	detector := NewDetector()
+	hints := detector.DetectFontPreloads(`@font-face { src: url(inter.woff2) format("woff2"); }`, "/css/fonts.css")
+
+	result := NewGenerator().GenerateHintTags(hints)
+	expected := `<link rel="preload" href="/css/inter.woff2" crossorigin as="font" type="font/woff2">`
+	if result != expected {
+		t.Errorf("GenerateHintTags() = %q, want %q", result, expected)
+	}
+}
+
+func TestGenerator_MergeWithExisting(t *testing.T) {
+	hints := []SuggestedHint{
+		{Domain: "fonts.googleapis.com", Scheme: "https", HintTypes: []HintType{HintTypePreconnect}},
+		{Domain: "fonts.gstatic.com", Scheme: "https", HintTypes: []HintType{HintTypePreconnect}, CrossOrigin: "anonymous"},
+		{Domain: "www.youtube.com", Scheme: "https", HintTypes: []HintType{HintTypePreconnect, HintTypeDNSPrefetch}},
+		{Domain: "example.com", URL: "https://example.com/fonts/inter.woff2", HintTypes: []HintType{HintTypePreload}, As: "font", CrossOrigin: "anonymous"},
+		{URL: "/fonts/mono.woff2", HintTypes: []HintType{HintTypePreload}, As: "font", CrossOrigin: "anonymous"},
+	}
+
+	existing := []models.LinkTag{
+		// Different case and a trailing slash still match the origin
+		{Rel: "preconnect", Href: "https://Fonts.GoogleAPIs.com/"},
+		// Without crossorigin this is a different connection than the hint's
+		{Rel: "preconnect", Href: "https://fonts.gstatic.com"},
+		// Protocol-relative dns-prefetch suppresses only that hint type
+		{Rel: "dns-prefetch", Href: "//www.youtube.com"},
+		{Rel: "preload", Href: "https://example.com/fonts/inter.woff2", Crossorigin: true},
+		{Rel: "stylesheet", Href: "/css/main.css"},
+		{Rel: "preload", Href: "/fonts/mono.woff2", Crossorigin: true},
+	}
+
+	got := NewGenerator().GenerateHintTags(NewGenerator().MergeWithExisting(existing, hints))
+	want := strings.Join([]string{
+		`<link rel="preconnect" href="https://fonts.gstatic.com" crossorigin>`,
+		`<link rel="preconnect" href="https://www.youtube.com">`,
+	}, "\n")
+	if got != want {
+		t.Errorf("MergeWithExisting() tags =\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestGenerator_MergeWithExistingUserPreconnect(t *testing.T) {
+	config := models.NewResourceHintsConfig()
+	detected := []DetectedDomain{{Domain: "fonts.googleapis.com", Scheme: "https", Count: 1}}
+	existing := []models.LinkTag{{Rel: "preconnect", Href: "https://fonts.googleapis.com"}}
+
+	g := NewGenerator()
+	hints := g.HintsFromConfig(&config, detected)
+	if len(hints) == 0 {
+		t.Fatal("expected an auto-detected preconnect before merging")
+	}
+	if merged := g.MergeWithExisting(existing, hints); len(merged) != 0 {
+		t.Errorf("user-specified preconnect should suppress the auto one, got %+v", merged)
+	}
+}
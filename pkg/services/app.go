@@ -10,6 +10,8 @@ func NewApp(manager *lifecycle.Manager) *App {
 		Posts:   newPostService(manager),
 		Feeds:   newFeedService(manager),
 		Tags:    newTagService(manager),
+		Search:  newSearchService(manager),
+		Stats:   newStatsService(manager),
 		Build:   newBuildService(manager),
 		Manager: manager,
 	}
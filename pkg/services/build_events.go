@@ -0,0 +1,183 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/WaylonWalker/markata-go/pkg/lifecycle"
+)
+
+// maxBufferedEvents is how many events the buffer policy holds for a
+// consumer that falls behind before it drops the oldest.
+const maxBufferedEvents = 1024
+
+// eventSink delivers events to a BuildOptions.Events channel without
+// blocking the sender.
+type eventSink struct {
+	ctx    context.Context
+	ch     chan<- BuildEvent
+	policy EventPolicy
+
+	// Buffer policy state: pending events wait in queue until the
+	// forwarding goroutine hands them to ch. Once queue holds limit events
+	// the oldest are discarded and counted in dropped.
+	mu      sync.Mutex
+	cond    *sync.Cond
+	queue   []BuildEvent
+	limit   int
+	dropped int
+	closed  bool
+}
+
+// newEventSink returns a sink for ch, or nil if ch is nil. Buffered events
+// stop being delivered once ctx is canceled.
+func newEventSink(ctx context.Context, ch chan<- BuildEvent, policy EventPolicy) *eventSink {
+	if ch == nil {
+		return nil
+	}
+	s := &eventSink{ctx: ctx, ch: ch, policy: policy, limit: maxBufferedEvents}
+	if policy == EventPolicyBuffer {
+		s.cond = sync.NewCond(&s.mu)
+		go s.forward()
+	}
+	return s
+}
+
+// send delivers or queues event according to the policy.
+func (s *eventSink) send(event BuildEvent) {
+	if s == nil {
+		return
+	}
+	if s.policy != EventPolicyBuffer {
+		select {
+		case s.ch <- event:
+		default:
+			// Don't block if the consumer is not ready
+		}
+		return
+	}
+
+	s.mu.Lock()
+	if len(s.queue) >= s.limit {
+		s.queue = s.queue[1:]
+		s.dropped++
+	}
+	s.queue = append(s.queue, event)
+	s.mu.Unlock()
+	s.cond.Signal()
+}
+
+// close stops accepting events. Queued events are still delivered.
+func (s *eventSink) close() {
+	if s == nil || s.policy != EventPolicyBuffer {
+		return
+	}
+	s.mu.Lock()
+	s.closed = true
+	s.mu.Unlock()
+	s.cond.Signal()
+}
+
+// forward hands queued events to the channel in order until the sink is
+// closed and the queue is empty, or until the sink's context is canceled.
+// Events dropped since the last batch are reported first with a
+// BuildEventDropped event.
+func (s *eventSink) forward() {
+	for {
+		s.mu.Lock()
+		for len(s.queue) == 0 && !s.closed {
+			s.cond.Wait()
+		}
+		if len(s.queue) == 0 {
+			s.mu.Unlock()
+			return
+		}
+		batch := s.queue
+		dropped := s.dropped
+		s.queue = nil
+		s.dropped = 0
+		s.mu.Unlock()
+
+		if dropped > 0 {
+			batch = append([]BuildEvent{{
+				Type:    BuildEventDropped,
+				Message: fmt.Sprintf("%d events dropped", dropped),
+				Dropped: dropped,
+			}}, batch...)
+		}
+		for _, event := range batch {
+			select {
+			case s.ch <- event:
+			case <-s.ctx.Done():
+				return
+			}
+		}
+	}
+}
+
+// buildObserver turns lifecycle notifications into build events.
+type buildObserver struct {
+	manager *lifecycle.Manager
+	emit    func(BuildEvent)
+}
+
+// StageStarted sends BuildEventStage with the share of stages already done.
+func (o *buildObserver) StageStarted(stage lifecycle.Stage) {
+	o.emit(BuildEvent{
+		Type:     BuildEventStage,
+		Stage:    string(stage),
+		Message:  fmt.Sprintf("Running %s stage", stage),
+		Progress: lifecycle.StageIndex(stage) * 100 / len(lifecycle.StageOrder),
+	})
+}
+
+// PluginStarted sends BuildEventPluginStart.
+func (o *buildObserver) PluginStarted(stage lifecycle.Stage, plugin string) {
+	o.emit(BuildEvent{
+		Type:   BuildEventPluginStart,
+		Stage:  string(stage),
+		Plugin: plugin,
+	})
+}
+
+// PluginFinished sends BuildEventPluginFinish with the plugin's run time.
+func (o *buildObserver) PluginFinished(stage lifecycle.Stage, timing lifecycle.PluginTiming, err error) {
+	o.emit(BuildEvent{
+		Type:     BuildEventPluginFinish,
+		Stage:    string(stage),
+		Plugin:   timing.Plugin,
+		Duration: timing.Wall,
+		Error:    err,
+	})
+}
+
+// StageFinished sends a BuildEventWarning per warning, and after the render
+// stage a BuildEventRenderSummary listing the posts it rendered.
+func (o *buildObserver) StageFinished(timing lifecycle.StageTiming, warnings []*lifecycle.HookError) {
+	for _, w := range warnings {
+		o.emit(BuildEvent{
+			Type:    BuildEventWarning,
+			Stage:   string(w.Stage),
+			Plugin:  w.Plugin,
+			Message: w.Err.Error(),
+			Error:   w,
+		})
+	}
+
+	if timing.Stage != lifecycle.StageRender {
+		return
+	}
+	var rendered []string
+	for _, post := range o.manager.Posts() {
+		if !post.Skip {
+			rendered = append(rendered, post.Slug)
+		}
+	}
+	o.emit(BuildEvent{
+		Type:    BuildEventRenderSummary,
+		Stage:   string(timing.Stage),
+		Message: fmt.Sprintf("Rendered %d posts", len(rendered)),
+		Posts:   rendered,
+	})
+}
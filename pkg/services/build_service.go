@@ -2,13 +2,22 @@ package services
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
 	"sync"
 	"time"
 
+	"github.com/WaylonWalker/markata-go/pkg/htmltotext"
 	"github.com/WaylonWalker/markata-go/pkg/lifecycle"
 	"github.com/WaylonWalker/markata-go/pkg/listcache"
+	"github.com/WaylonWalker/markata-go/pkg/models"
 )
 
+// ErrPostNotFound is returned when no post matches a slug.
+var ErrPostNotFound = errors.New("post not found")
+
 // buildService implements BuildService using lifecycle.Manager.
 type buildService struct {
 	manager     *lifecycle.Manager
@@ -24,11 +33,26 @@ func newBuildService(m *lifecycle.Manager) BuildService {
 	}
 }
 
-// Build runs the build process.
-func (s *buildService) Build(_ context.Context, opts BuildOptions) (*BuildResult, error) {
+// Build runs the build process. Canceling ctx stops the build, which then
+// returns ctx.Err().
+//
+// Progress events go to Subscribe channels and to opts.Events: stage and
+// plugin starts, plugin run times, a summary of rendered posts, warnings,
+// and a final complete or error event. A slow consumer never holds up the
+// build; see EventPolicy.
+func (s *buildService) Build(ctx context.Context, opts BuildOptions) (*BuildResult, error) {
 	start := time.Now()
 
-	s.emit(BuildEvent{
+	sink := newEventSink(ctx, opts.Events, opts.EventPolicy)
+	defer sink.close()
+	emit := func(event BuildEvent) {
+		s.emit(event)
+		sink.send(event)
+	}
+	s.manager.SetObserver(&buildObserver{manager: s.manager, emit: emit})
+	defer s.manager.SetObserver(nil)
+
+	emit(BuildEvent{
 		Type:    BuildEventStart,
 		Message: "Starting build",
 	})
@@ -36,8 +60,9 @@ func (s *buildService) Build(_ context.Context, opts BuildOptions) (*BuildResult
 	if opts.Concurrency > 0 {
 		s.manager.SetConcurrency(opts.Concurrency)
 	}
+	s.manager.SetFailFast(opts.FailFast)
 
-	err := s.manager.Run()
+	err := s.manager.RunContext(ctx)
 
 	result := &BuildResult{
 		Success:        err == nil,
@@ -47,13 +72,13 @@ func (s *buildService) Build(_ context.Context, opts BuildOptions) (*BuildResult
 
 	if err != nil {
 		result.Errors = []error{err}
-		s.emit(BuildEvent{
+		emit(BuildEvent{
 			Type:    BuildEventError,
 			Message: err.Error(),
 			Error:   err,
 		})
 	} else {
-		s.emit(BuildEvent{
+		emit(BuildEvent{
 			Type:     BuildEventComplete,
 			Message:  "Build complete",
 			Progress: 100,
@@ -82,6 +107,196 @@ func (s *buildService) LoadForTUI(ctx context.Context) error {
 	return s.manager.RunTo(lifecycle.StageCollect)
 }
 
+// BuildSingle reloads, transforms, and renders one post in isolation.
+//
+// The post's file is loaded again and run through the manager's load,
+// transform, and render plugins on a scratch manager, so the result
+// reflects the file on disk and the manager's posts are left untouched.
+// Other posts are present but skipped, so wikilinks and lookups still
+// resolve. Nothing is written to disk: the build cache is hidden from the
+// scratch manager and the write stage never runs.
+//
+// Feeds and link graphs are collect-stage data. They are reused from a
+// prior full build when the manager has run the collect stage; otherwise
+// they are listed in RenderedPost.Unavailable.
+//
+// BuildSingle must not be called while Build is running.
+func (s *buildService) BuildSingle(ctx context.Context, slug string) (*RenderedPost, error) {
+	source := s.manager.PostIndex().LookupBySlug(slug)
+	if source == nil {
+		return nil, fmt.Errorf("%w: %s", ErrPostNotFound, slug)
+	}
+
+	scratch := lifecycle.NewManager()
+	scratch.SetConfig(s.manager.Config())
+	scratch.SetConcurrency(s.manager.Concurrency())
+	scratch.SetCache(newOverlayCache(s.manager.Cache()))
+	for _, p := range s.manager.Plugins() {
+		if p.Name() != "build_cache" {
+			scratch.RegisterPlugin(p)
+		}
+	}
+
+	loaded, err := lifecycle.RunLoadHooksSubset(scratch, []string{s.sourceFile(source)})
+	if err != nil {
+		return nil, fmt.Errorf("loading %s: %w", source.Path, err)
+	}
+	var post *models.Post
+	for _, p := range loaded {
+		if filepath.Clean(p.Path) == filepath.Clean(source.Path) {
+			post = p
+			break
+		}
+	}
+	if post == nil {
+		return nil, fmt.Errorf("loading %s: %w", source.Path, ErrPostNotFound)
+	}
+
+	result := &RenderedPost{Post: post}
+	collected := s.manager.HasRun(lifecycle.StageCollect)
+	if collected {
+		scratch.SetFeeds(s.manager.Feeds())
+		post.Inlinks = source.Inlinks
+		post.Outlinks = source.Outlinks
+	} else {
+		result.Unavailable = []string{"feeds", "links"}
+	}
+
+	posts := []*models.Post{post}
+	for _, p := range s.manager.Posts() {
+		if p == source {
+			continue
+		}
+		skipped := *p
+		skipped.Skip = true
+		posts = append(posts, &skipped)
+	}
+	scratch.SetPosts(posts)
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if err := lifecycle.RunTransformHooksSubset(scratch, posts); err != nil {
+		return nil, fmt.Errorf("transforming %s: %w", source.Path, err)
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if err := lifecycle.RunRenderHooksSubset(scratch, posts); err != nil {
+		return nil, fmt.Errorf("rendering %s: %w", source.Path, err)
+	}
+
+	result.ArticleHTML = post.ArticleHTML
+	result.HTML = post.HTML
+	result.Text = htmltotext.Convert(post.ArticleHTML)
+	result.Frontmatter = frontmatterOf(post)
+
+	return result, nil
+}
+
+// sourceFile returns the manager's file entry for a post, falling back to
+// the post's path.
+func (s *buildService) sourceFile(p *models.Post) string {
+	for _, f := range s.manager.Files() {
+		if filepath.Clean(f) == filepath.Clean(p.Path) {
+			return f
+		}
+	}
+	return p.Path
+}
+
+// frontmatterOf returns a post's metadata as a flat map, with Extra fields
+// alongside the built-in ones.
+func frontmatterOf(p *models.Post) map[string]interface{} {
+	fm := make(map[string]interface{}, len(p.Extra)+10)
+	for k, v := range p.Extra {
+		fm[k] = v
+	}
+
+	fm["path"] = p.Path
+	fm["slug"] = p.Slug
+	fm["href"] = p.Href
+	fm["tags"] = p.Tags
+	fm["published"] = p.Published
+	fm["draft"] = p.Draft
+	fm["private"] = p.Private
+	fm["template"] = p.Template
+	if p.Title != nil {
+		fm["title"] = *p.Title
+	}
+	if p.Description != nil {
+		fm["description"] = *p.Description
+	}
+	if p.Date != nil {
+		fm["date"] = *p.Date
+	}
+
+	return fm
+}
+
+// overlayCache reads through to a base cache and keeps writes local, so a
+// scratch manager sees the plugins' configured state without changing it.
+// Build cache and serve-mode keys are hidden so plugins neither reuse
+// cached output nor persist anything.
+type overlayCache struct {
+	base lifecycle.Cache
+
+	mu      sync.RWMutex
+	items   map[string]interface{}
+	deleted map[string]bool
+}
+
+// newOverlayCache creates an overlay over base.
+func newOverlayCache(base lifecycle.Cache) *overlayCache {
+	return &overlayCache{
+		base:    base,
+		items:   make(map[string]interface{}),
+		deleted: make(map[string]bool),
+	}
+}
+
+// hiddenCacheKey reports whether a base cache key is hidden from the overlay.
+func hiddenCacheKey(key string) bool {
+	return strings.HasPrefix(key, "build_cache") || strings.HasPrefix(key, "serve.")
+}
+
+// Get returns the overlay's value, falling back to the base cache.
+func (c *overlayCache) Get(key string) (interface{}, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if v, ok := c.items[key]; ok {
+		return v, true
+	}
+	if c.deleted[key] || hiddenCacheKey(key) || c.base == nil {
+		return nil, false
+	}
+	return c.base.Get(key)
+}
+
+// Set stores a value in the overlay only.
+func (c *overlayCache) Set(key string, value interface{}) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.items[key] = value
+	delete(c.deleted, key)
+}
+
+// Delete hides a key without removing it from the base cache.
+func (c *overlayCache) Delete(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.items, key)
+	c.deleted[key] = true
+}
+
+// Clear hides every key.
+func (c *overlayCache) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.items = make(map[string]interface{})
+	c.base = nil
+}
+
 // Subscribe returns a channel for build progress events.
 func (s *buildService) Subscribe() <-chan BuildEvent {
 	s.mu.Lock()
@@ -0,0 +1,316 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/WaylonWalker/markata-go/pkg/lifecycle"
+	"github.com/WaylonWalker/markata-go/pkg/models"
+)
+
+// fileLoadPlugin loads each file as a post whose content is the file body.
+type fileLoadPlugin struct{}
+
+func (fileLoadPlugin) Name() string { return "test_load" }
+
+func (fileLoadPlugin) Load(m *lifecycle.Manager) error {
+	for _, f := range m.Files() {
+		data, err := os.ReadFile(f)
+		if err != nil {
+			return err
+		}
+		p := models.NewPost(f)
+		p.Slug = strings.TrimSuffix(filepath.Base(f), ".md")
+		p.Content = string(data)
+		m.AddPost(p)
+	}
+	return nil
+}
+
+// paragraphRenderPlugin wraps content in a paragraph and records which
+// posts it rendered.
+type paragraphRenderPlugin struct {
+	rendered []string
+}
+
+func (p *paragraphRenderPlugin) Name() string { return "test_render" }
+
+func (p *paragraphRenderPlugin) Render(m *lifecycle.Manager) error {
+	for _, post := range m.Posts() {
+		if post.Skip {
+			continue
+		}
+		post.ArticleHTML = "<p>" + post.Content + "</p>"
+		post.HTML = "<html><body>" + post.ArticleHTML + "</body></html>"
+		p.rendered = append(p.rendered, post.Slug)
+	}
+	m.Cache().Set("test_render.ran", true)
+	return nil
+}
+
+func newBuildSingleFixture(t *testing.T) (*buildService, *lifecycle.Manager, *paragraphRenderPlugin, string) {
+	t.Helper()
+
+	dir, files := writeTestFiles(t, map[string]string{"a.md": "alpha", "b.md": "beta"})
+	render := &paragraphRenderPlugin{}
+	m := newTestManager(nil)
+	m.RegisterPlugins(fileLoadPlugin{}, render)
+	m.SetFiles(files)
+	if err := m.RunTo(lifecycle.StageRender); err != nil {
+		t.Fatalf("RunTo() error = %v", err)
+	}
+	render.rendered = nil
+
+	return &buildService{manager: m}, m, render, dir
+}
+
+func TestBuildService_BuildSingle(t *testing.T) {
+	svc, m, render, dir := newBuildSingleFixture(t)
+
+	// Edit the file after the build; BuildSingle should pick it up
+	if err := os.WriteFile(filepath.Join(dir, "a.md"), []byte("alpha edited"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := svc.BuildSingle(context.Background(), "a")
+	if err != nil {
+		t.Fatalf("BuildSingle() error = %v", err)
+	}
+
+	if result.ArticleHTML != "<p>alpha edited</p>" {
+		t.Errorf("ArticleHTML = %q", result.ArticleHTML)
+	}
+	if !strings.Contains(result.HTML, "<body><p>alpha edited</p></body>") {
+		t.Errorf("HTML = %q", result.HTML)
+	}
+	if result.Text != "alpha edited" {
+		t.Errorf("Text = %q", result.Text)
+	}
+	if result.Frontmatter["slug"] != "a" {
+		t.Errorf("Frontmatter[slug] = %v", result.Frontmatter["slug"])
+	}
+	if want := []string{"a"}; !reflect.DeepEqual(render.rendered, want) {
+		t.Errorf("rendered = %v, want %v", render.rendered, want)
+	}
+	if want := []string{"feeds", "links"}; !reflect.DeepEqual(result.Unavailable, want) {
+		t.Errorf("Unavailable = %v, want %v", result.Unavailable, want)
+	}
+
+	// The manager's posts and cache are untouched
+	if got := m.Posts()[0].ArticleHTML; got != "<p>alpha</p>" {
+		t.Errorf("source post ArticleHTML = %q, want unchanged", got)
+	}
+	if m.Posts()[1].Skip {
+		t.Error("source posts should not be marked skipped")
+	}
+	m.Cache().Delete("test_render.ran")
+	if _, err := svc.BuildSingle(context.Background(), "b"); err != nil {
+		t.Fatalf("BuildSingle() error = %v", err)
+	}
+	if _, ok := m.Cache().Get("test_render.ran"); ok {
+		t.Error("BuildSingle should not write to the manager's cache")
+	}
+}
+
+func TestBuildService_BuildSingleUnknownSlug(t *testing.T) {
+	svc, _, _, _ := newBuildSingleFixture(t)
+
+	_, err := svc.BuildSingle(context.Background(), "missing")
+	if !errors.Is(err, ErrPostNotFound) {
+		t.Errorf("error = %v, want ErrPostNotFound", err)
+	}
+}
+
+func TestOverlayCache(t *testing.T) {
+	base := lifecycle.NewManager().Cache()
+	base.Set("feed_configs", "feeds")
+	base.Set("build_cache", "cache")
+	base.Set("serve.changed", true)
+
+	c := newOverlayCache(base)
+	if v, ok := c.Get("feed_configs"); !ok || v != "feeds" {
+		t.Errorf("Get(feed_configs) = %v, %v; want read-through", v, ok)
+	}
+	for _, key := range []string{"build_cache", "serve.changed"} {
+		if _, ok := c.Get(key); ok {
+			t.Errorf("Get(%q) should be hidden", key)
+		}
+	}
+
+	c.Set("feed_configs", "local")
+	c.Delete("serve.changed")
+	if v, _ := base.Get("feed_configs"); v != "feeds" {
+		t.Error("Set should not write through to the base cache")
+	}
+	if _, ok := base.Get("serve.changed"); !ok {
+		t.Error("Delete should not remove keys from the base cache")
+	}
+}
+
+func TestBuildService_BuildCanceled(t *testing.T) {
+	m := lifecycle.NewManager()
+	m.RegisterPlugins(fileLoadPlugin{}, &paragraphRenderPlugin{})
+	svc := &buildService{manager: m}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := svc.Build(ctx, BuildOptions{})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("error = %v, want context.Canceled", err)
+	}
+}
+
+// warnWritePlugin fails its write hook, which the build reports as a warning.
+type warnWritePlugin struct{}
+
+func (warnWritePlugin) Name() string { return "test_warn" }
+
+func (warnWritePlugin) Write(*lifecycle.Manager) error { return errors.New("disk full") }
+
+func newEventsFixture(t *testing.T) *buildService {
+	t.Helper()
+
+	_, files := writeTestFiles(t, map[string]string{"a.md": "alpha", "b.md": "beta"})
+	m := newTestManager(nil)
+	m.RegisterPlugins(fileLoadPlugin{}, &paragraphRenderPlugin{}, warnWritePlugin{})
+	m.SetFiles(files)
+	return &buildService{manager: m}
+}
+
+func TestBuildService_BuildEvents(t *testing.T) {
+	svc := newEventsFixture(t)
+
+	// An unbuffered channel read only after Build returns: with the buffer
+	// policy every event still arrives, in order.
+	events := make(chan BuildEvent)
+	if _, err := svc.Build(context.Background(), BuildOptions{Events: events, EventPolicy: EventPolicyBuffer}); err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+
+	var got []string
+	var finish, warning BuildEvent
+	for event := range events {
+		desc := string(event.Type)
+		for _, field := range append([]string{event.Stage, event.Plugin}, event.Posts...) {
+			if field != "" {
+				desc += " " + field
+			}
+		}
+		got = append(got, desc)
+
+		switch event.Type {
+		case BuildEventPluginFinish:
+			if event.Plugin == "test_render" {
+				finish = event
+			}
+		case BuildEventWarning:
+			warning = event
+		}
+		if event.Type == BuildEventComplete || event.Type == BuildEventError {
+			break
+		}
+	}
+
+	want := []string{
+		"start",
+		"stage configure",
+		"stage validate",
+		"stage glob",
+		"stage load",
+		"plugin_start load test_load",
+		"plugin_finish load test_load",
+		"stage transform",
+		"stage render",
+		"plugin_start render test_render",
+		"plugin_finish render test_render",
+		"render_summary render a b",
+		"stage collect",
+		"stage write",
+		"plugin_start write test_warn",
+		"plugin_finish write test_warn",
+		"warning write test_warn",
+		"stage verify",
+		"stage cleanup",
+		"complete",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("events =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+
+	for _, stage := range svc.manager.Profile() {
+		if stage.Stage == lifecycle.StageRender && finish.Duration != stage.Plugins[0].Wall {
+			t.Errorf("plugin_finish Duration = %v, want profiled %v", finish.Duration, stage.Plugins[0].Wall)
+		}
+	}
+	if warning.Message != "disk full" || warning.Error == nil {
+		t.Errorf("warning = %+v, want message %q", warning, "disk full")
+	}
+}
+
+func TestEventSink_BufferDropsOldest(t *testing.T) {
+	events := make(chan BuildEvent, 10)
+	s := &eventSink{ctx: context.Background(), ch: events, policy: EventPolicyBuffer, limit: 2}
+	s.cond = sync.NewCond(&s.mu)
+	for _, msg := range []string{"1", "2", "3", "4"} {
+		s.send(BuildEvent{Type: BuildEventProgress, Message: msg})
+	}
+	s.close()
+	s.forward()
+
+	var got []string
+	for len(events) > 0 {
+		event := <-events
+		got = append(got, string(event.Type)+" "+event.Message)
+	}
+	want := []string{"events_dropped 2 events dropped", "progress 3", "progress 4"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("events = %q, want %q", got, want)
+	}
+}
+
+func TestEventSink_BufferStopsOnCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	// Nobody reads this channel; canceling must still end forwarding.
+	s := &eventSink{ctx: ctx, ch: make(chan BuildEvent), policy: EventPolicyBuffer, limit: maxBufferedEvents}
+	s.cond = sync.NewCond(&s.mu)
+	s.send(BuildEvent{Type: BuildEventStart})
+	s.close()
+
+	done := make(chan struct{})
+	go func() {
+		s.forward()
+		close(done)
+	}()
+	cancel()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("forward() still blocked after the context was canceled")
+	}
+}
+
+func TestBuildService_BuildEventsDropDoesNotBlock(t *testing.T) {
+	svc := newEventsFixture(t)
+
+	// Nobody reads this channel; the build must still finish.
+	events := make(chan BuildEvent, 2)
+	if _, err := svc.Build(context.Background(), BuildOptions{Events: events}); err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+
+	if len(events) != 2 {
+		t.Fatalf("len(events) = %d, want the first 2 events", len(events))
+	}
+	if first := <-events; first.Type != BuildEventStart {
+		t.Errorf("first event = %s, want %s", first.Type, BuildEventStart)
+	}
+}
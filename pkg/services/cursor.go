@@ -0,0 +1,87 @@
+package services
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/WaylonWalker/markata-go/pkg/models"
+)
+
+// ErrInvalidCursor is returned when ListOptions.Cursor cannot be decoded or
+// was issued for a different sort.
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// pageCursor marks the last post of a page by its sort key and path.
+type pageCursor struct {
+	SortBy string    `json:"s"`
+	Order  SortOrder `json:"o"`
+	Key    string    `json:"k"`
+	Path   string    `json:"p"`
+}
+
+// encodeCursor returns the opaque string form of c.
+func encodeCursor(c pageCursor) string {
+	data, _ := json.Marshal(c) //nolint:errcheck // marshaling strings cannot fail
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// decodeCursor parses a cursor produced by encodeCursor.
+func decodeCursor(s string) (pageCursor, error) {
+	var c pageCursor
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return c, fmt.Errorf("%w: %v", ErrInvalidCursor, err)
+	}
+	if err := json.Unmarshal(data, &c); err != nil {
+		return c, fmt.Errorf("%w: %v", ErrInvalidCursor, err)
+	}
+	return c, nil
+}
+
+// sortKeyTimeFormat is a fixed-width UTC timestamp, so keys compare in
+// date order as strings.
+const sortKeyTimeFormat = "2006-01-02T15:04:05.000000000Z"
+
+// postSortKey returns a string that orders posts by field when compared
+// lexically. Posts without a value get an empty key and sort first, as in
+// sortPosts. Unknown fields give every post the same key, leaving path
+// to decide the order.
+func postSortKey(p *models.Post, field string) string {
+	switch field {
+	case "date":
+		if p.Date == nil {
+			return ""
+		}
+		return p.Date.UTC().Format(sortKeyTimeFormat)
+	case "title":
+		if p.Title == nil {
+			return ""
+		}
+		return strings.ToLower(*p.Title)
+	case "path":
+		return p.Path
+	case "words":
+		return fmt.Sprintf("%020d", getWordCount(p))
+	case "reading_time":
+		return fmt.Sprintf("%020d", getReadingTime(p))
+	case "tags":
+		return strings.ToLower(strings.Join(p.Tags, ", "))
+	}
+	return ""
+}
+
+// comparePageKeys compares two posts by sort key, then path, in the
+// given order.
+func comparePageKeys(keyA, pathA, keyB, pathB string, order SortOrder) int {
+	cmp := strings.Compare(keyA, keyB)
+	if cmp == 0 {
+		cmp = strings.Compare(pathA, pathB)
+	}
+	if order == SortDesc {
+		return -cmp
+	}
+	return cmp
+}
@@ -35,4 +35,102 @@
 //	    SortBy:    "date",
 //	    Limit:     10,
 //	})
+//
+// ListPage pages through large result sets with an opaque cursor that holds
+// the last post's sort key, so pages stay stable as posts are added:
+//
+//	page, err := app.Posts.ListPage(ctx, services.ListOptions{Limit: 50})
+//	next, err := app.Posts.ListPage(ctx, services.ListOptions{Limit: 50, Cursor: page.NextCursor})
+//
+// Related posts are scored by shared tags and wikilinks in either direction:
+//
+//	related, err := app.Posts.Related(ctx, post.Slug, 5)
+//
+// SetFrontmatter edits a post's source file in place, rewriting only the
+// given keys so other keys keep their order, formatting, and comments:
+//
+//	err := app.Posts.SetFrontmatter(ctx, "hello-world", map[string]any{
+//	    "published": true,
+//	    "tags":      []string{"go", "yaml"},
+//	})
+//
+// # Search
+//
+// SearchService ranks posts by matches in their title, tags, description,
+// and body text. Field filters narrow the results:
+//
+//	hits, err := app.Search.Search(ctx, "tag:golang title:generics channels", services.SearchOptions{
+//	    Limit: 20,
+//	})
+//	for _, hit := range hits {
+//	    fmt.Println(hit.Post.Path, hit.Score, hit.Snippet)
+//	}
+//
+// Rendered post HTML is converted to plain text on the first search and
+// cached per post until its content changes.
+//
+// # Feed validation
+//
+// FeedService.Validate checks configured feeds against the loaded posts
+// without collecting them, reporting filter syntax errors, unknown filter and
+// sort fields, and filters that match nothing:
+//
+//	issues, err := app.Feeds.Validate(ctx)
+//	for _, issue := range issues {
+//	    fmt.Println(issue.Severity, issue.Slug, issue.Field, issue.Message)
+//	}
+//
+// # Feed previews
+//
+// FeedService.Render collects one feed from the loaded posts, with its
+// filter, sort, and pagination, and returns a single format as a string
+// instead of writing files, for debugging feed output:
+//
+//	rss, err := app.Feeds.Render(ctx, "blog", "rss")
+//
+// The format is "html", "rss", "atom", "json", or "sitemap" and must be
+// enabled on the feed. Output formats include post content, so run the
+// render stage first.
+//
+// # Site statistics
+//
+// StatsService.Summary counts posts, drafts, tags, posts per year, average
+// reading time, broken wikilinks, and orphan posts in one pass over the
+// loaded posts:
+//
+//	stats, err := app.Stats.Summary(ctx)
+//	fmt.Println(stats.Published, stats.UniqueTags, len(stats.OrphanPosts))
+//
+// # Single-post builds
+//
+// BuildSingle reloads one post from disk and runs it through the configured
+// transform and render plugins without writing anything, for previews:
+//
+//	rendered, err := app.Build.BuildSingle(ctx, "my-post")
+//	fmt.Println(rendered.HTML, rendered.Unavailable)
+//
+// Feeds and inlinks come from the last full build; without one they are
+// listed in RenderedPost.Unavailable.
+//
+// # Build events
+//
+// Build sends typed progress events to BuildOptions.Events as it runs:
+// stage and plugin starts, plugin run times, a summary of rendered posts,
+// warnings, and a final complete or error event. The build never waits for
+// the consumer; EventPolicyDrop (the default) discards events it is not ready
+// for, while EventPolicyBuffer queues them so they arrive in order, dropping
+// the oldest only if the consumer falls far behind:
+//
+//	events := make(chan services.BuildEvent, 64)
+//	go func() {
+//	    for e := range events {
+//	        if e.Type == services.BuildEventPluginFinish {
+//	            fmt.Println(e.Stage, e.Plugin, e.Duration)
+//	        }
+//	    }
+//	}()
+//	result, err := app.Build.Build(ctx, services.BuildOptions{
+//	    Events:      events,
+//	    EventPolicy: services.EventPolicyBuffer,
+//	})
 package services
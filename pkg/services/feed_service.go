@@ -2,11 +2,39 @@ package services
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
 
+	"github.com/WaylonWalker/markata-go/pkg/filter"
 	"github.com/WaylonWalker/markata-go/pkg/lifecycle"
 	"github.com/WaylonWalker/markata-go/pkg/models"
+	"github.com/WaylonWalker/markata-go/pkg/plugins"
 )
 
+// Errors returned by FeedService.Render.
+var (
+	// ErrFeedNotFound is returned when no configured feed has the slug.
+	ErrFeedNotFound = errors.New("feed not found")
+
+	// ErrUnsupportedFeedFormat is returned for a format Render cannot produce.
+	ErrUnsupportedFeedFormat = errors.New("unsupported feed format")
+
+	// ErrFeedFormatDisabled is returned when the feed does not enable the format.
+	ErrFeedFormatDisabled = errors.New("feed format not enabled")
+)
+
+// feedRenderFormats maps the formats Render produces to whether a feed
+// enables them.
+var feedRenderFormats = map[string]func(models.FeedFormats) bool{
+	"html":    func(f models.FeedFormats) bool { return f.HTML },
+	"rss":     func(f models.FeedFormats) bool { return f.RSS },
+	"atom":    func(f models.FeedFormats) bool { return f.Atom },
+	"json":    func(f models.FeedFormats) bool { return f.JSON },
+	"sitemap": func(f models.FeedFormats) bool { return f.Sitemap },
+}
+
 // feedService implements FeedService using lifecycle.Manager.
 type feedService struct {
 	manager *lifecycle.Manager
@@ -60,3 +88,138 @@ func (s *feedService) GetPosts(ctx context.Context, feedName string, opts ListOp
 
 	return posts, nil
 }
+
+// Render collects the configured feed with the given slug from the loaded
+// posts and renders one format in memory, without running the rest of the
+// build or writing files. Post content comes from the last render, so
+// formats that include it need the render stage to have run.
+func (s *feedService) Render(ctx context.Context, slug, format string) (string, error) {
+	format = strings.ToLower(strings.TrimSpace(format))
+	enabled, ok := feedRenderFormats[format]
+	if !ok {
+		return "", fmt.Errorf("%w: %q (expected html, rss, atom, json, or sitemap)", ErrUnsupportedFeedFormat, format)
+	}
+
+	config := s.manager.Config()
+	var feedConfigs []models.FeedConfig
+	if config != nil && config.Extra != nil {
+		feedConfigs, _ = config.Extra["feeds"].([]models.FeedConfig)
+	}
+	slug = strings.Trim(slug, "/")
+	index := -1
+	for i := range feedConfigs {
+		if feedConfigs[i].Slug == slug {
+			index = i
+			break
+		}
+	}
+	if index < 0 {
+		return "", fmt.Errorf("%w: %q", ErrFeedNotFound, slug)
+	}
+
+	// Collect a copy so the configured feed is left as it was
+	fc := feedConfigs[index]
+	plugins.ApplyFeedDefaults(config, &fc)
+	if !enabled(fc.Formats) {
+		return "", fmt.Errorf("%w: feed %q does not enable %s", ErrFeedFormatDisabled, slug, format)
+	}
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+	if err := plugins.CollectFeed(s.manager.Posts(), &fc); err != nil {
+		return "", err
+	}
+
+	return plugins.NewPublishFeedsPlugin().RenderFeedFormat(s.manager.Logger(), &fc, config, format)
+}
+
+// Validate checks each configured feed before a build. A filter that fails
+// to parse is an error. A filter that reads a field no post has, a sort key
+// that is neither a post field nor set on any post, and a filter that
+// matches no posts are warnings. The zero-match check is skipped when no
+// posts are loaded.
+func (s *feedService) Validate(ctx context.Context) ([]FeedIssue, error) {
+	config := s.manager.Config()
+	if config == nil || config.Extra == nil {
+		return nil, nil
+	}
+	feedConfigs, ok := config.Extra["feeds"].([]models.FeedConfig)
+	if !ok {
+		return nil, nil
+	}
+
+	posts := s.manager.Posts()
+	extraFields := make(map[string]bool)
+	for _, p := range posts {
+		for key := range p.Extra {
+			extraFields[key] = true
+			extraFields[strings.ToLower(key)] = true
+		}
+	}
+
+	var issues []FeedIssue
+	for i := range feedConfigs {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		issues = append(issues, validateFeed(&feedConfigs[i], posts, extraFields)...)
+	}
+	return issues, nil
+}
+
+// validateFeed returns the issues for a single feed.
+func validateFeed(fc *models.FeedConfig, posts []*models.Post, extraFields map[string]bool) []FeedIssue {
+	var issues []FeedIssue
+	issue := func(severity FeedIssueSeverity, field, format string, args ...interface{}) {
+		issues = append(issues, FeedIssue{
+			Slug:     fc.Slug,
+			Severity: severity,
+			Field:    field,
+			Message:  fmt.Sprintf(format, args...),
+		})
+	}
+
+	if fc.Sort != "" && !isPostSortField(fc.Sort) && !extraFields[fc.Sort] {
+		issue(FeedIssueWarning, "sort", "sort field %q is not a post field and is not set on any post", fc.Sort)
+	}
+
+	if fc.Filter == "" {
+		return issues
+	}
+	f, err := filter.Parse(fc.Filter)
+	if err != nil {
+		issue(FeedIssueError, "filter", "%v", err)
+		return issues
+	}
+	for _, name := range f.Fields() {
+		if !filter.IsKnownField(name) && !extraFields[name] && !extraFields[strings.ToLower(name)] {
+			issue(FeedIssueWarning, "filter", "filter field %q is not a post field and is not set on any post", name)
+		}
+	}
+
+	if len(posts) > 0 {
+		candidates := posts
+		if !fc.IncludePrivate {
+			candidates = make([]*models.Post, 0, len(posts))
+			for _, p := range posts {
+				if !p.Private {
+					candidates = append(candidates, p)
+				}
+			}
+		}
+		if len(f.MatchAll(candidates)) == 0 {
+			issue(FeedIssueWarning, "filter", "filter matches none of the %d loaded posts", len(candidates))
+		}
+	}
+
+	return issues
+}
+
+// isPostSortField reports whether name is a models.Post field that feeds
+// can sort by. Like feed sorting, the match ignores case.
+func isPostSortField(name string) bool {
+	_, ok := reflect.TypeOf(models.Post{}).FieldByNameFunc(func(field string) bool {
+		return strings.EqualFold(field, name)
+	})
+	return ok
+}
@@ -0,0 +1,191 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/WaylonWalker/markata-go/pkg/lifecycle"
+	"github.com/WaylonWalker/markata-go/pkg/models"
+)
+
+func newFeedFixture(feeds []models.FeedConfig) *lifecycle.Manager {
+	post := func(slug string, published bool, tags ...string) *models.Post {
+		p := newTestPost(slug, tags...)
+		p.Published = published
+		p.Extra = map[string]interface{}{"series": "intro"}
+		return p
+	}
+
+	config := lifecycle.NewConfig()
+	config.Extra = map[string]interface{}{"feeds": feeds}
+	return newTestManager(config,
+		post("go", true, "golang"),
+		post("rust", true, "rust"),
+		post("draft", false, "golang"),
+	)
+}
+
+func TestFeedService_Validate(t *testing.T) {
+	m := newFeedFixture([]models.FeedConfig{
+		{Slug: "", Filter: "published == True", Sort: "date"},
+		{Slug: "go", Filter: "published == True and (tags contains golang or tags contains rust)", Sort: "series"},
+		{Slug: "broken", Filter: "published == True)"},
+		{Slug: "typo", Filter: "publshed == True", Sort: "dat"},
+		{Slug: "empty", Filter: "'python' in tags"},
+	})
+
+	issues, err := newFeedService(m).Validate(context.Background())
+	if err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+
+	want := []FeedIssue{
+		{Slug: "broken", Severity: FeedIssueError, Field: "filter", Message: `failed to parse filter expression "published == True)": unexpected RPAREN()) at position 17`},
+		{Slug: "typo", Severity: FeedIssueWarning, Field: "sort", Message: `sort field "dat" is not a post field and is not set on any post`},
+		{Slug: "typo", Severity: FeedIssueWarning, Field: "filter", Message: `filter field "publshed" is not a post field and is not set on any post`},
+		{Slug: "typo", Severity: FeedIssueWarning, Field: "filter", Message: "filter matches none of the 3 loaded posts"},
+		{Slug: "empty", Severity: FeedIssueWarning, Field: "filter", Message: "filter matches none of the 3 loaded posts"},
+	}
+	if !reflect.DeepEqual(issues, want) {
+		t.Errorf("Validate() =\n%+v\nwant:\n%+v", issues, want)
+	}
+}
+
+func TestFeedService_ValidateNoPosts(t *testing.T) {
+	m := newFeedFixture([]models.FeedConfig{{Slug: "blog", Filter: "published == True", Sort: "date"}})
+	m.SetPosts(nil)
+
+	issues, err := newFeedService(m).Validate(context.Background())
+	if err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	if len(issues) != 0 {
+		t.Errorf("Validate() = %+v, want no issues without loaded posts", issues)
+	}
+}
+
+func TestFeedService_Render(t *testing.T) {
+	m := newFeedFixture([]models.FeedConfig{
+		{
+			Slug:    "golang",
+			Title:   "Go posts",
+			Filter:  "published == True and tags contains golang",
+			Formats: models.FeedFormats{RSS: true, JSON: true},
+		},
+	})
+	// Output formats skip posts without content
+	for _, p := range m.Posts() {
+		p.Content = "Body of " + p.Slug
+		p.ArticleHTML = "<p>Body of " + p.Slug + "</p>"
+	}
+	svc := newFeedService(m)
+	ctx := context.Background()
+
+	rss, err := svc.Render(ctx, "golang", "rss")
+	if err != nil {
+		t.Fatalf("Render(rss) error = %v", err)
+	}
+	var doc struct {
+		XMLName xml.Name `xml:"rss"`
+		Channel struct {
+			Title string `xml:"title"`
+			Items []struct {
+				Link string `xml:"link"`
+			} `xml:"item"`
+		} `xml:"channel"`
+	}
+	if err := xml.Unmarshal([]byte(rss), &doc); err != nil {
+		t.Fatalf("Render(rss) is not well-formed XML: %v\n%s", err, rss)
+	}
+	if doc.Channel.Title != "Go posts" || len(doc.Channel.Items) != 1 {
+		t.Errorf("Render(rss) channel = %+v, want title %q and 1 item (drafts filtered)", doc.Channel, "Go posts")
+	}
+
+	jsonFeed, err := svc.Render(ctx, "/golang/", "JSON")
+	if err != nil {
+		t.Fatalf("Render(json) error = %v", err)
+	}
+	var feed struct {
+		Version string            `json:"version"`
+		Items   []json.RawMessage `json:"items"`
+	}
+	if err := json.Unmarshal([]byte(jsonFeed), &feed); err != nil {
+		t.Fatalf("Render(json) is not valid JSON: %v\n%s", err, jsonFeed)
+	}
+	if !strings.HasPrefix(feed.Version, "https://jsonfeed.org/version/") || len(feed.Items) != 1 {
+		t.Errorf("Render(json) version = %q with %d items, want a JSON Feed with 1 item", feed.Version, len(feed.Items))
+	}
+
+	// Rendering does not collect into the configured feed
+	if fcs := m.Config().Extra["feeds"].([]models.FeedConfig); len(fcs[0].Posts) != 0 {
+		t.Errorf("configured feed has %d posts after Render, want 0", len(fcs[0].Posts))
+	}
+}
+
+func TestFeedService_RenderFormatsFromDefaults(t *testing.T) {
+	m := newFeedFixture([]models.FeedConfig{
+		{Slug: "golang", Filter: "published == True and tags contains golang"},
+	})
+	defaults := models.NewFeedDefaults()
+	defaults.Formats = models.FeedFormats{Atom: true}
+	m.Config().Extra["feed_defaults"] = defaults
+	for _, p := range m.Posts() {
+		p.Content = "Body of " + p.Slug
+		p.ArticleHTML = "<p>Body of " + p.Slug + "</p>"
+	}
+	svc := newFeedService(m)
+	ctx := context.Background()
+
+	atom, err := svc.Render(ctx, "golang", "atom")
+	if err != nil {
+		t.Fatalf("Render(atom) error = %v", err)
+	}
+	if !strings.Contains(atom, "<feed") {
+		t.Errorf("Render(atom) = %q, want an Atom feed", atom)
+	}
+	if _, err := svc.Render(ctx, "golang", "rss"); !errors.Is(err, ErrFeedFormatDisabled) {
+		t.Errorf("Render(rss) error = %v, want %v", err, ErrFeedFormatDisabled)
+	}
+}
+
+func TestFeedService_RenderLeavesPostNavigation(t *testing.T) {
+	m := newFeedFixture([]models.FeedConfig{
+		{Slug: "guide", Type: models.FeedTypeGuide, Filter: "published == True", Formats: models.FeedFormats{HTML: true}},
+	})
+	if _, err := newFeedService(m).Render(context.Background(), "guide", "html"); err != nil {
+		t.Fatalf("Render(html) error = %v", err)
+	}
+
+	for _, p := range m.Posts() {
+		if p.Prev != nil || p.Next != nil || p.PrevNextFeed != "" {
+			t.Errorf("post %q navigation = (prev %v, next %v, feed %q) after Render, want unset", p.Slug, p.Prev, p.Next, p.PrevNextFeed)
+		}
+	}
+}
+
+func TestFeedService_RenderErrors(t *testing.T) {
+	m := newFeedFixture([]models.FeedConfig{
+		{Slug: "golang", Formats: models.FeedFormats{RSS: true}},
+	})
+	svc := newFeedService(m)
+	ctx := context.Background()
+
+	tests := []struct {
+		slug, format string
+		want         error
+	}{
+		{"missing", "rss", ErrFeedNotFound},
+		{"golang", "yaml", ErrUnsupportedFeedFormat},
+		{"golang", "atom", ErrFeedFormatDisabled},
+	}
+	for _, tt := range tests {
+		if _, err := svc.Render(ctx, tt.slug, tt.format); !errors.Is(err, tt.want) {
+			t.Errorf("Render(%q, %q) error = %v, want %v", tt.slug, tt.format, err, tt.want)
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package services
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/WaylonWalker/markata-go/pkg/lifecycle"
+	"github.com/WaylonWalker/markata-go/pkg/models"
+)
+
+// newTestPost returns a post loaded from slug.md with the given tags.
+func newTestPost(slug string, tags ...string) *models.Post {
+	p := models.NewPost(slug + ".md")
+	p.Slug = slug
+	p.Tags = tags
+	return p
+}
+
+// newTestManager returns a manager holding posts, using config when it is
+// not nil.
+func newTestManager(config *lifecycle.Config, posts ...*models.Post) *lifecycle.Manager {
+	m := lifecycle.NewManager()
+	if config != nil {
+		m.SetConfig(config)
+	}
+	m.SetPosts(posts)
+	return m
+}
+
+// newTagSynonymConfig returns a config whose tag aggregator collapses "js"
+// into "javascript".
+func newTagSynonymConfig() *lifecycle.Config {
+	config := lifecycle.NewConfig()
+	tagAggregator := models.NewTagAggregatorConfig()
+	tagAggregator.Synonyms = map[string][]string{"javascript": {"js"}}
+	config.Extra = map[string]interface{}{
+		"models_config": &models.Config{TagAggregator: tagAggregator},
+	}
+	return config
+}
+
+// writeTestFiles writes each name to body in a new temporary directory and
+// returns the directory and the written paths, sorted.
+func writeTestFiles(t *testing.T, files map[string]string) (string, []string) {
+	t.Helper()
+	dir := t.TempDir()
+
+	paths := make([]string, 0, len(files))
+	for name, body := range files {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(body), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return dir, paths
+}
+
+func postSlugs(posts []*models.Post) []string {
+	slugs := make([]string, len(posts))
+	for i, p := range posts {
+		slugs[i] = p.Slug
+	}
+	return slugs
+}
@@ -0,0 +1,354 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/WaylonWalker/markata-go/pkg/models"
+)
+
+var (
+	// ErrAmbiguousSlug is returned when more than one post has a slug.
+	ErrAmbiguousSlug = errors.New("slug matches more than one post")
+
+	// ErrSourceMissing is returned when a post's source file is no longer
+	// where it was loaded from.
+	ErrSourceMissing = errors.New("post source file not found")
+
+	// ErrInvalidFrontmatterValue is returned when an update has the wrong
+	// type for a known frontmatter field.
+	ErrInvalidFrontmatterValue = errors.New("invalid frontmatter value")
+)
+
+// frontmatterFieldKind is the value type a known frontmatter field takes.
+type frontmatterFieldKind int
+
+const (
+	fieldBool frontmatterFieldKind = iota
+	fieldString
+	fieldStringList
+	fieldDate
+)
+
+// frontmatterFieldKinds lists the frontmatter fields SetFrontmatter checks.
+// Other keys are written as given.
+var frontmatterFieldKinds = map[string]frontmatterFieldKind{
+	"published":   fieldBool,
+	"draft":       fieldBool,
+	"private":     fieldBool,
+	"skip":        fieldBool,
+	"title":       fieldString,
+	"description": fieldString,
+	"slug":        fieldString,
+	"template":    fieldString,
+	"author":      fieldString,
+	"tags":        fieldStringList,
+	"authors":     fieldStringList,
+	"aliases":     fieldStringList,
+	"date":        fieldDate,
+	"publishdate": fieldDate,
+	"pubdate":     fieldDate,
+	"modified":    fieldDate,
+	"lastmod":     fieldDate,
+	"updated":     fieldDate,
+}
+
+// SetFrontmatter updates the frontmatter of the post with the given slug in
+// its source file. Only the keys in updates are rewritten; other keys keep
+// their order, formatting, and comments. A nil value removes the key, and
+// keys not yet present are appended. The file is replaced atomically.
+func (s *postService) SetFrontmatter(ctx context.Context, slug string, updates map[string]any) error {
+	if err := validateFrontmatterUpdates(updates); err != nil {
+		return err
+	}
+
+	var post *models.Post
+	for _, p := range s.manager.Posts() {
+		if p.Slug != slug {
+			continue
+		}
+		if post != nil {
+			return fmt.Errorf("%w: %q is used by %s and %s", ErrAmbiguousSlug, slug, post.Path, p.Path)
+		}
+		post = p
+	}
+	if post == nil {
+		return fmt.Errorf("%w: %s", ErrPostNotFound, slug)
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	path := post.Path
+	if !filepath.IsAbs(path) {
+		if config := s.manager.Config(); config != nil && config.ContentDir != "" {
+			path = filepath.Join(config.ContentDir, path)
+		}
+	}
+	info, err := os.Stat(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("%w: %s (moved or deleted since it was loaded?)", ErrSourceMissing, path)
+	}
+	if err != nil {
+		return err
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	updated, err := setFrontmatterKeys(string(content), updates)
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	if updated == string(content) {
+		return nil
+	}
+	return writeFileAtomic(path, []byte(updated), info.Mode().Perm())
+}
+
+// validateFrontmatterUpdates checks the values of known fields.
+func validateFrontmatterUpdates(updates map[string]any) error {
+	for key, value := range updates {
+		if key == "" {
+			return fmt.Errorf("%w: empty key", ErrInvalidFrontmatterValue)
+		}
+		kind, known := frontmatterFieldKinds[key]
+		if !known || value == nil {
+			continue
+		}
+		var ok bool
+		switch kind {
+		case fieldBool:
+			_, ok = value.(bool)
+		case fieldString:
+			_, ok = value.(string)
+		case fieldStringList:
+			ok = isStringList(value)
+		case fieldDate:
+			switch value.(type) {
+			case string, time.Time:
+				ok = true
+			}
+		}
+		if !ok {
+			return fmt.Errorf("%w: %s cannot be %T", ErrInvalidFrontmatterValue, key, value)
+		}
+	}
+	return nil
+}
+
+func isStringList(value any) bool {
+	switch v := value.(type) {
+	case []string:
+		return true
+	case []any:
+		for _, item := range v {
+			if _, ok := item.(string); !ok {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// setFrontmatterKeys applies updates to the frontmatter of content. Each
+// changed key's lines are replaced with a fresh encoding of its value, so
+// the rest of the file is left byte for byte. A flow-style list or mapping
+// stays flow style, a quoted string keeps its quotes, and a trailing
+// comment on a one-line value is kept.
+func setFrontmatterKeys(content string, updates map[string]any) (string, error) {
+	lines := strings.SplitAfter(content, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	eol := "\n"
+	if len(lines) > 0 && strings.HasSuffix(lines[0], "\r\n") {
+		eol = "\r\n"
+	}
+
+	if len(lines) == 0 || strings.TrimRight(lines[0], "\r\n") != "---" {
+		added, err := encodeNewFrontmatterKeys(updates, nil, eol)
+		if err != nil {
+			return "", err
+		}
+		if added == "" {
+			return content, nil
+		}
+		return "---" + eol + added + "---" + eol + content, nil
+	}
+
+	end := -1
+	for i := 1; i < len(lines); i++ {
+		if strings.TrimRight(lines[i], "\r\n") == "---" {
+			end = i
+			break
+		}
+	}
+	if end == -1 {
+		return "", errors.New("unclosed frontmatter delimiter")
+	}
+	fmLines := lines[1:end]
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal([]byte(strings.Join(fmLines, "")), &doc); err != nil {
+		return "", fmt.Errorf("parsing frontmatter: %w", err)
+	}
+	var root *yaml.Node
+	if len(doc.Content) > 0 {
+		root = doc.Content[0]
+		if root.Kind != yaml.MappingNode {
+			return "", errors.New("frontmatter is not a mapping")
+		}
+	}
+
+	out := make([]string, 0, len(lines)+len(updates))
+	out = append(out, lines[0])
+	next := 0 // first line of fmLines not yet copied
+	present := make(map[string]bool)
+	if root != nil {
+		for i := 0; i+1 < len(root.Content); i += 2 {
+			key, value := root.Content[i], root.Content[i+1]
+			if present[key.Value] {
+				continue
+			}
+			present[key.Value] = true
+			newValue, ok := updates[key.Value]
+			if !ok {
+				continue
+			}
+
+			start := key.Line - 1
+			stop := len(fmLines)
+			if i+2 < len(root.Content) {
+				stop = root.Content[i+2].Line - 1
+			}
+			// Leave trailing blank and comment lines in place; they belong
+			// to the next key or the end of the frontmatter. Comment-like
+			// lines inside a block scalar are part of its value.
+			block := value.Style&(yaml.LiteralStyle|yaml.FoldedStyle) != 0
+			for stop > start+1 {
+				trimmed := strings.TrimSpace(fmLines[stop-1])
+				if trimmed != "" && (block || !strings.HasPrefix(trimmed, "#")) {
+					break
+				}
+				stop--
+			}
+
+			out = append(out, fmLines[next:start]...)
+			next = stop
+			if newValue == nil {
+				continue
+			}
+			entry, err := encodeFrontmatterEntry(key, newValue, value, eol)
+			if err != nil {
+				return "", err
+			}
+			out = append(out, entry)
+		}
+	}
+	out = append(out, fmLines[next:]...)
+
+	added, err := encodeNewFrontmatterKeys(updates, present, eol)
+	if err != nil {
+		return "", err
+	}
+	if added != "" {
+		out = append(out, added)
+	}
+	out = append(out, lines[end:]...)
+	return strings.Join(out, ""), nil
+}
+
+// encodeNewFrontmatterKeys encodes the non-nil updates whose keys are not
+// in present, sorted by key.
+func encodeNewFrontmatterKeys(updates map[string]any, present map[string]bool, eol string) (string, error) {
+	keys := make([]string, 0, len(updates))
+	for key, value := range updates {
+		if value != nil && !present[key] {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	for _, key := range keys {
+		entry, err := encodeFrontmatterEntry(&yaml.Node{Kind: yaml.ScalarNode, Value: key}, updates[key], nil, eol)
+		if err != nil {
+			return "", err
+		}
+		b.WriteString(entry)
+	}
+	return b.String(), nil
+}
+
+// encodeFrontmatterEntry encodes one "key: value" entry, borrowing the
+// style of the value it replaces when there is one.
+func encodeFrontmatterEntry(key *yaml.Node, value any, old *yaml.Node, eol string) (string, error) {
+	var v yaml.Node
+	if err := v.Encode(value); err != nil {
+		return "", fmt.Errorf("encoding %s: %w", key.Value, err)
+	}
+	if old != nil {
+		switch {
+		case old.Style&yaml.FlowStyle != 0 && (v.Kind == yaml.SequenceNode || v.Kind == yaml.MappingNode):
+			v.Style = yaml.FlowStyle
+		case old.Kind == yaml.ScalarNode && v.Kind == yaml.ScalarNode && v.Tag == "!!str" &&
+			old.Style&(yaml.DoubleQuotedStyle|yaml.SingleQuotedStyle) != 0:
+			v.Style = old.Style
+		}
+		if v.Kind == yaml.ScalarNode || v.Style&yaml.FlowStyle != 0 {
+			v.LineComment = old.LineComment
+		}
+	}
+
+	k := &yaml.Node{Kind: yaml.ScalarNode, Tag: key.Tag, Style: key.Style, Value: key.Value}
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(&yaml.Node{Kind: yaml.MappingNode, Content: []*yaml.Node{k, &v}}); err != nil {
+		return "", fmt.Errorf("encoding %s: %w", key.Value, err)
+	}
+	if err := enc.Close(); err != nil {
+		return "", fmt.Errorf("encoding %s: %w", key.Value, err)
+	}
+	entry := buf.String()
+	if eol != "\n" {
+		entry = strings.ReplaceAll(entry, "\n", eol)
+	}
+	return entry, nil
+}
+
+// writeFileAtomic writes data to a temporary file next to path and renames
+// it over path, so readers never see a partial file.
+func writeFileAtomic(path string, data []byte, perm fs.FileMode) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name()) //nolint:errcheck // already renamed on success
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close() //nolint:errcheck // the write error is returned
+		return err
+	}
+	if err := tmp.Chmod(perm); err != nil {
+		tmp.Close() //nolint:errcheck // the chmod error is returned
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
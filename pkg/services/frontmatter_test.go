@@ -0,0 +1,178 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/WaylonWalker/markata-go/pkg/lifecycle"
+	"github.com/WaylonWalker/markata-go/pkg/models"
+)
+
+const frontmatterFixture = `---
+# Post metadata
+title: "Hello, World"
+published: false # flip when ready
+tags: [go, testing]
+description: >
+  A short
+  description.
+
+date: 2024-01-02
+---
+# Hello
+
+Body text.
+`
+
+// newFrontmatterFixture writes source files under a content directory and
+// returns a manager with a post for each, keyed by slug.
+func newFrontmatterFixture(t *testing.T, files map[string]string) *lifecycle.Manager {
+	t.Helper()
+
+	sources := make(map[string]string, len(files))
+	posts := make([]*models.Post, 0, len(files))
+	for slug, content := range files {
+		sources[slug+".md"] = content
+		posts = append(posts, newTestPost(slug))
+	}
+	dir, _ := writeTestFiles(t, sources)
+
+	config := lifecycle.NewConfig()
+	config.ContentDir = dir
+	return newTestManager(config, posts...)
+}
+
+func readFixture(t *testing.T, m *lifecycle.Manager, slug string) string {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join(m.Config().ContentDir, slug+".md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func TestPostService_SetFrontmatter(t *testing.T) {
+	tests := []struct {
+		name    string
+		updates map[string]any
+		want    string
+	}{
+		{
+			name:    "toggle published",
+			updates: map[string]any{"published": true},
+			want: `---
+# Post metadata
+title: "Hello, World"
+published: true # flip when ready
+tags: [go, testing]
+description: >
+  A short
+  description.
+
+date: 2024-01-02
+---
+# Hello
+
+Body text.
+`,
+		},
+		{
+			name:    "add tag",
+			updates: map[string]any{"tags": []string{"go", "testing", "yaml"}},
+			want: `---
+# Post metadata
+title: "Hello, World"
+published: false # flip when ready
+tags: [go, testing, yaml]
+description: >
+  A short
+  description.
+
+date: 2024-01-02
+---
+# Hello
+
+Body text.
+`,
+		},
+		{
+			name:    "replace folded value, remove and add keys",
+			updates: map[string]any{"description": "Short.", "date": nil, "template": "note.html"},
+			want: `---
+# Post metadata
+title: "Hello, World"
+published: false # flip when ready
+tags: [go, testing]
+description: Short.
+
+template: note.html
+---
+# Hello
+
+Body text.
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newFrontmatterFixture(t, map[string]string{"hello": frontmatterFixture})
+			svc := newPostService(m)
+
+			if err := svc.SetFrontmatter(context.Background(), "hello", tt.updates); err != nil {
+				t.Fatalf("SetFrontmatter() error = %v", err)
+			}
+			if got := readFixture(t, m, "hello"); got != tt.want {
+				t.Errorf("file =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPostService_SetFrontmatter_NoFrontmatter(t *testing.T) {
+	m := newFrontmatterFixture(t, map[string]string{"bare": "Just a body.\n"})
+	svc := newPostService(m)
+
+	if err := svc.SetFrontmatter(context.Background(), "bare", map[string]any{"title": "Bare", "draft": true}); err != nil {
+		t.Fatalf("SetFrontmatter() error = %v", err)
+	}
+	if got, want := readFixture(t, m, "bare"), "---\ndraft: true\ntitle: Bare\n---\nJust a body.\n"; got != want {
+		t.Errorf("file = %q, want %q", got, want)
+	}
+}
+
+func TestPostService_SetFrontmatter_Errors(t *testing.T) {
+	m := newFrontmatterFixture(t, map[string]string{"hello": frontmatterFixture, "moved": frontmatterFixture})
+	dup := models.NewPost("other/hello.md")
+	dup.Slug = "hello"
+	m.SetPosts(append(m.Posts(), dup))
+	if err := os.Remove(filepath.Join(m.Config().ContentDir, "moved.md")); err != nil {
+		t.Fatal(err)
+	}
+	svc := newPostService(m)
+	ctx := context.Background()
+
+	tests := []struct {
+		name    string
+		slug    string
+		updates map[string]any
+		wantErr error
+	}{
+		{"unknown slug", "missing", map[string]any{"draft": true}, ErrPostNotFound},
+		{"ambiguous slug", "hello", map[string]any{"draft": true}, ErrAmbiguousSlug},
+		{"moved file", "moved", map[string]any{"draft": true}, ErrSourceMissing},
+		{"wrong bool type", "moved", map[string]any{"published": "yes"}, ErrInvalidFrontmatterValue},
+		{"wrong tags type", "moved", map[string]any{"tags": []any{"go", 1}}, ErrInvalidFrontmatterValue},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := svc.SetFrontmatter(ctx, tt.slug, tt.updates)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("SetFrontmatter() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -14,6 +14,10 @@ type PostService interface {
 	// List returns posts matching the given options.
 	List(ctx context.Context, opts ListOptions) ([]*models.Post, error)
 
+	// ListPage returns one page of posts matching the given options, with a
+	// cursor for the next page.
+	ListPage(ctx context.Context, opts ListOptions) (*ListResult, error)
+
 	// Get returns a single post by path.
 	Get(ctx context.Context, path string) (*models.Post, error)
 
@@ -22,6 +26,17 @@ type PostService interface {
 
 	// Count returns the total number of posts matching options.
 	Count(ctx context.Context, opts ListOptions) (int, error)
+
+	// Related returns up to limit posts related to the post with the given
+	// slug, scored by shared tags and wikilinks using DefaultRelatedOptions.
+	Related(ctx context.Context, slug string, limit int) ([]*models.Post, error)
+
+	// RelatedWithOptions is like Related with custom scoring weights.
+	RelatedWithOptions(ctx context.Context, slug string, opts RelatedOptions) ([]*models.Post, error)
+
+	// SetFrontmatter edits only the given frontmatter keys in the source
+	// file of the post with the given slug. A nil value removes a key.
+	SetFrontmatter(ctx context.Context, slug string, updates map[string]any) error
 }
 
 // FeedService provides business logic for feed operations.
@@ -34,6 +49,15 @@ type FeedService interface {
 
 	// GetPosts returns posts belonging to a feed.
 	GetPosts(ctx context.Context, feedName string, opts ListOptions) ([]*models.Post, error)
+
+	// Validate checks every configured feed's filter and sort against the
+	// loaded posts and returns the problems found, in feed order.
+	Validate(ctx context.Context) ([]FeedIssue, error)
+
+	// Render collects the feed with the given slug from the loaded posts
+	// and renders one format ("html", "rss", "atom", "json", or
+	// "sitemap") to a string without writing files.
+	Render(ctx context.Context, slug, format string) (string, error)
 }
 
 // TagService provides business logic for tag operations.
@@ -43,6 +67,23 @@ type TagService interface {
 
 	// GetPosts returns posts with a specific tag.
 	GetPosts(ctx context.Context, tag string, opts ListOptions) ([]*models.Post, error)
+
+	// GetPostsWithAll returns posts that have every one of the given tags.
+	GetPostsWithAll(ctx context.Context, tags []string, opts ListOptions) ([]*models.Post, error)
+}
+
+// SearchService provides full-text search over post content.
+type SearchService interface {
+	// Search returns posts matching a query, ranked by relevance.
+	// The query may contain field filters such as "tag:golang" or
+	// "title:foo" alongside free-text terms.
+	Search(ctx context.Context, query string, opts SearchOptions) ([]SearchHit, error)
+}
+
+// StatsService summarizes the site for dashboards.
+type StatsService interface {
+	// Summary returns aggregate statistics for the loaded posts.
+	Summary(ctx context.Context) (SiteStats, error)
 }
 
 // BuildService provides build orchestration.
@@ -57,16 +98,22 @@ type BuildService interface {
 	// This includes Transform (for stats, titles) and Collect (for feeds).
 	LoadForTUI(ctx context.Context) error
 
+	// BuildSingle reloads, transforms, and renders one post in isolation
+	// and returns the result without writing to disk.
+	BuildSingle(ctx context.Context, slug string) (*RenderedPost, error)
+
 	// Subscribe returns a channel for build progress events.
 	Subscribe() <-chan BuildEvent
 }
 
 // App bundles all services together for easy dependency injection.
 type App struct {
-	Posts PostService
-	Feeds FeedService
-	Tags  TagService
-	Build BuildService
+	Posts  PostService
+	Feeds  FeedService
+	Tags   TagService
+	Search SearchService
+	Stats  StatsService
+	Build  BuildService
 
 	// Manager is the underlying lifecycle manager (for advanced access)
 	Manager *lifecycle.Manager
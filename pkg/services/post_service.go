@@ -2,6 +2,8 @@ package services
 
 import (
 	"context"
+	"fmt"
+	"regexp"
 	"sort"
 	"strings"
 	"time"
@@ -22,6 +24,100 @@ func newPostService(m *lifecycle.Manager) PostService {
 
 // List returns posts matching the given options.
 func (s *postService) List(_ context.Context, opts ListOptions) ([]*models.Post, error) {
+	posts, err := s.filter(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	// Apply sorting
+	if opts.SortBy != "" {
+		sortPosts(posts, opts.SortBy, opts.SortOrder)
+	}
+
+	// Apply pagination
+	if opts.Offset > 0 && opts.Offset < len(posts) {
+		posts = posts[opts.Offset:]
+	}
+	if opts.Limit > 0 && opts.Limit < len(posts) {
+		posts = posts[:opts.Limit]
+	}
+
+	return posts, nil
+}
+
+// ListPage returns one page of posts matching the given options.
+//
+// Posts are sorted by opts.SortBy (default: date) and opts.SortOrder
+// (default: desc for date, asc otherwise), with path as a tie-breaker.
+// Pass the previous page's NextCursor as opts.Cursor to continue after
+// its last post; because the cursor holds that post's sort key rather than
+// a position, posts added between calls do not shift later pages. Offset
+// is only used when Cursor is empty.
+func (s *postService) ListPage(_ context.Context, opts ListOptions) (*ListResult, error) {
+	posts, err := s.filter(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	sortBy := strings.ToLower(opts.SortBy)
+	if sortBy == "" {
+		sortBy = "date"
+	}
+	order := opts.SortOrder
+	if order == "" {
+		order = SortAsc
+		if sortBy == "date" {
+			order = SortDesc
+		}
+	}
+
+	keys := make(map[*models.Post]string, len(posts))
+	for _, p := range posts {
+		keys[p] = postSortKey(p, sortBy)
+	}
+	sort.SliceStable(posts, func(i, j int) bool {
+		return comparePageKeys(keys[posts[i]], posts[i].Path, keys[posts[j]], posts[j].Path, order) < 0
+	})
+
+	result := &ListResult{Total: len(posts)}
+
+	start := 0
+	if opts.Cursor != "" {
+		c, err := decodeCursor(opts.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		if c.SortBy != sortBy || c.Order != order {
+			return nil, fmt.Errorf("%w: cursor is for sort %s %s", ErrInvalidCursor, c.SortBy, c.Order)
+		}
+		start = sort.Search(len(posts), func(i int) bool {
+			return comparePageKeys(keys[posts[i]], posts[i].Path, c.Key, c.Path, order) > 0
+		})
+	} else if opts.Offset > 0 {
+		start = min(opts.Offset, len(posts))
+	}
+
+	end := len(posts)
+	if opts.Limit > 0 && start+opts.Limit < end {
+		end = start + opts.Limit
+	}
+	result.Posts = posts[start:end]
+
+	if end < len(posts) && end > start {
+		last := posts[end-1]
+		result.NextCursor = encodeCursor(pageCursor{
+			SortBy: sortBy,
+			Order:  order,
+			Key:    keys[last],
+			Path:   last.Path,
+		})
+	}
+
+	return result, nil
+}
+
+// filter returns the posts matching the filters in opts, unsorted.
+func (s *postService) filter(opts ListOptions) ([]*models.Post, error) {
 	posts := s.manager.Posts()
 
 	// Apply filter expression
@@ -53,19 +149,6 @@ func (s *postService) List(_ context.Context, opts ListOptions) ([]*models.Post,
 		posts = filterByDateRange(posts, opts.DateRange)
 	}
 
-	// Apply sorting
-	if opts.SortBy != "" {
-		sortPosts(posts, opts.SortBy, opts.SortOrder)
-	}
-
-	// Apply pagination
-	if opts.Offset > 0 && opts.Offset < len(posts) {
-		posts = posts[opts.Offset:]
-	}
-	if opts.Limit > 0 && opts.Limit < len(posts) {
-		posts = posts[:opts.Limit]
-	}
-
 	return posts, nil
 }
 
@@ -107,8 +190,100 @@ func (s *postService) Count(ctx context.Context, opts ListOptions) (int, error)
 	return len(posts), nil
 }
 
+// Related returns up to limit posts related to the post with the given
+// slug, scored by shared tags and wikilinks using DefaultRelatedOptions.
+func (s *postService) Related(ctx context.Context, slug string, limit int) ([]*models.Post, error) {
+	opts := DefaultRelatedOptions()
+	opts.Limit = limit
+	return s.RelatedWithOptions(ctx, slug, opts)
+}
+
+// RelatedWithOptions returns posts related to the post with the given slug.
+// Each other post scores TagWeight per shared tag plus LinkWeight if either
+// post wikilinks to the other. Posts scoring zero are left out; the rest
+// are sorted by score, then newest first. Returns nil if no post has slug.
+func (s *postService) RelatedWithOptions(ctx context.Context, slug string, opts RelatedOptions) ([]*models.Post, error) {
+	idx := s.manager.PostIndex()
+	source := idx.LookupBySlug(slug)
+	if source == nil {
+		return nil, nil
+	}
+
+	sourceTags := make(map[string]bool, len(source.Tags))
+	for _, t := range source.Tags {
+		sourceTags[strings.ToLower(t)] = true
+	}
+	sourceLinks := linkedPosts(source, idx)
+
+	scores := make(map[*models.Post]float64)
+	var related []*models.Post
+	for _, p := range s.manager.Posts() {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		if p == source {
+			continue
+		}
+
+		var score float64
+		seen := make(map[string]bool, len(p.Tags))
+		for _, t := range p.Tags {
+			t = strings.ToLower(t)
+			if sourceTags[t] && !seen[t] {
+				seen[t] = true
+				score += opts.TagWeight
+			}
+		}
+		if sourceLinks[p] || linkedPosts(p, idx)[source] {
+			score += opts.LinkWeight
+		}
+
+		if score > 0 {
+			scores[p] = score
+			related = append(related, p)
+		}
+	}
+
+	sort.SliceStable(related, func(i, j int) bool {
+		if scores[related[i]] != scores[related[j]] {
+			return scores[related[i]] > scores[related[j]]
+		}
+		if cmp := compareDates(related[i].Date, related[j].Date); cmp != 0 {
+			return cmp > 0
+		}
+		return related[i].Path < related[j].Path
+	})
+
+	if opts.Limit > 0 && opts.Limit < len(related) {
+		related = related[:opts.Limit]
+	}
+
+	return related, nil
+}
+
 // Helper functions
 
+// linkedPosts returns the posts p links to: resolved outlinks once links
+// are collected, plus wikilinks still present in the raw content.
+func linkedPosts(p *models.Post, idx *lifecycle.PostIndex) map[*models.Post]bool {
+	linked := make(map[*models.Post]bool)
+	for _, link := range p.Outlinks {
+		if link != nil && link.TargetPost != nil {
+			linked[link.TargetPost] = true
+		}
+	}
+	for _, match := range wikilinkPattern.FindAllStringSubmatch(p.Content, -1) {
+		target, _, _ := strings.Cut(strings.TrimSpace(match[1]), "#")
+		if target := idx.LookupBySlug(target); target != nil {
+			linked[target] = true
+		}
+	}
+	return linked
+}
+
+// wikilinkPattern matches [[target]] and [[target|label]] wikilinks.
+var wikilinkPattern = regexp.MustCompile(`\[\[([^\]|]+)(?:\|[^\]]+)?\]\]`)
+
 func filterByTags(posts []*models.Post, tags []string) []*models.Post {
 	var result []*models.Post
 	for _, p := range posts {
@@ -0,0 +1,254 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/WaylonWalker/markata-go/pkg/lifecycle"
+	"github.com/WaylonWalker/markata-go/pkg/models"
+)
+
+// newRelatedFixture builds a small graph:
+//
+//	go-basics   tags: go, tutorial       links to [[go-channels]]
+//	go-channels tags: go, concurrency
+//	rust-intro  tags: rust, tutorial     links to [[go-basics|Go]]
+//	go-generics tags: Go, tutorial       (newer than go-channels)
+//	cooking     tags: food               links out to an unknown post
+func newRelatedFixture() *lifecycle.Manager {
+	day := func(d int) *time.Time {
+		t := time.Date(2024, 1, d, 0, 0, 0, 0, time.UTC)
+		return &t
+	}
+	post := func(slug string, date *time.Time, content string, tags ...string) *models.Post {
+		p := newTestPost(slug, tags...)
+		p.Date = date
+		p.Content = content
+		return p
+	}
+
+	return newTestManager(nil,
+		post("go-basics", day(1), "Next read [[go-channels]].", "go", "tutorial"),
+		post("go-channels", day(2), "", "go", "concurrency"),
+		post("rust-intro", day(3), "Compare with [[go-basics|Go]].", "rust", "tutorial"),
+		post("go-generics", day(4), "", "Go", "tutorial"),
+		post("cooking", day(5), "See [[missing-post]].", "food"),
+	)
+}
+
+func TestPostService_Related(t *testing.T) {
+	svc := newPostService(newRelatedFixture())
+	ctx := context.Background()
+
+	tests := []struct {
+		name  string
+		slug  string
+		limit int
+		want  []string
+	}{
+		// rust-intro: 1 tag + inlink; go-channels: 1 tag + outlink (older); go-generics: 2 tags
+		{"tags and links in both directions", "go-basics", 0, []string{"rust-intro", "go-channels", "go-generics"}},
+		{"limit", "go-basics", 1, []string{"rust-intro"}},
+		// go-basics: 1 tag + inlink; go-generics: 1 tag (case-insensitive)
+		{"inlinks count", "go-channels", 0, []string{"go-basics", "go-generics"}},
+		{"unresolved links ignored", "cooking", 0, nil},
+		{"slug lookup is case insensitive", "GO-CHANNELS", 1, []string{"go-basics"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			posts, err := svc.Related(ctx, tt.slug, tt.limit)
+			if err != nil {
+				t.Fatalf("Related() error = %v", err)
+			}
+			if got := postSlugs(posts); !reflect.DeepEqual(got, tt.want) && (len(got) != 0 || len(tt.want) != 0) {
+				t.Errorf("Related(%q) = %v, want %v", tt.slug, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPostService_RelatedWithOptions(t *testing.T) {
+	svc := newPostService(newRelatedFixture())
+	ctx := context.Background()
+
+	// Links only: go-channels (outlink) and rust-intro (inlink), newest first
+	posts, err := svc.RelatedWithOptions(ctx, "go-basics", RelatedOptions{LinkWeight: 1})
+	if err != nil {
+		t.Fatalf("RelatedWithOptions() error = %v", err)
+	}
+	if got, want := postSlugs(posts), []string{"rust-intro", "go-channels"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("links only = %v, want %v", got, want)
+	}
+
+	// Heavy tags: two shared tags outrank one tag plus a link
+	posts, err = svc.RelatedWithOptions(ctx, "go-basics", RelatedOptions{TagWeight: 3, LinkWeight: 1})
+	if err != nil {
+		t.Fatalf("RelatedWithOptions() error = %v", err)
+	}
+	if got, want := postSlugs(posts), []string{"go-generics", "rust-intro", "go-channels"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("heavy tags = %v, want %v", got, want)
+	}
+}
+
+func TestPostService_RelatedCollectedLinks(t *testing.T) {
+	m := newRelatedFixture()
+	posts := m.Posts()
+
+	// After rendering, wikilinks are anchors and only outlinks remain
+	cooking, channels := posts[4], posts[1]
+	cooking.Content = ""
+	cooking.Outlinks = []*models.Link{{SourcePost: cooking, TargetPost: channels}}
+
+	svc := newPostService(m)
+	related, err := svc.Related(context.Background(), "go-channels", 0)
+	if err != nil {
+		t.Fatalf("Related() error = %v", err)
+	}
+	if got, want := postSlugs(related), []string{"go-basics", "cooking", "go-generics"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Related() = %v, want %v", got, want)
+	}
+}
+
+func TestPostService_RelatedUnknownSlug(t *testing.T) {
+	svc := newPostService(newRelatedFixture())
+
+	posts, err := svc.Related(context.Background(), "nope", 5)
+	if err != nil || posts != nil {
+		t.Errorf("Related(unknown) = %v, %v; want nil, nil", posts, err)
+	}
+}
+
+// newPagingFixture returns a manager with posts dated day 1..n, two of
+// which share a date so paging must break ties by path.
+func newPagingFixture(n int) *lifecycle.Manager {
+	posts := make([]*models.Post, 0, n)
+	for d := 1; d <= n; d++ {
+		p := newTestPost(fmt.Sprintf("post-%02d", d))
+		date := time.Date(2024, 1, d, 0, 0, 0, 0, time.UTC)
+		if d == 2 {
+			date = date.AddDate(0, 0, 1) // same date as post-03
+		}
+		p.Date = &date
+		posts = append(posts, p)
+	}
+	return newTestManager(nil, posts...)
+}
+
+func postPaths(posts []*models.Post) []string {
+	paths := make([]string, len(posts))
+	for i, p := range posts {
+		paths[i] = p.Path
+	}
+	return paths
+}
+
+func TestPostService_ListPage(t *testing.T) {
+	svc := newPostService(newPagingFixture(7))
+	ctx := context.Background()
+
+	var got []string
+	opts := ListOptions{Limit: 3}
+	for page := 0; ; page++ {
+		if page > 3 {
+			t.Fatal("paging did not terminate")
+		}
+		result, err := svc.ListPage(ctx, opts)
+		if err != nil {
+			t.Fatalf("ListPage() error = %v", err)
+		}
+		if result.Total != 7 {
+			t.Errorf("Total = %d, want 7", result.Total)
+		}
+		got = append(got, postPaths(result.Posts)...)
+		if result.NextCursor == "" {
+			break
+		}
+		opts.Cursor = result.NextCursor
+	}
+
+	// Default sort is date descending; post-02 and post-03 tie and sort
+	// by path descending
+	want := []string{"post-07.md", "post-06.md", "post-05.md", "post-04.md", "post-03.md", "post-02.md", "post-01.md"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("paged posts = %v, want %v", got, want)
+	}
+}
+
+func TestPostService_ListPageStableUnderInserts(t *testing.T) {
+	m := newPagingFixture(7)
+	svc := newPostService(m)
+	ctx := context.Background()
+
+	first, err := svc.ListPage(ctx, ListOptions{Limit: 3})
+	if err != nil {
+		t.Fatalf("ListPage() error = %v", err)
+	}
+
+	// A new post sorts before the cursor; an offset would repeat post-05
+	newest := models.NewPost("post-new.md")
+	date := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+	newest.Date = &date
+	m.AddPost(newest)
+
+	second, err := svc.ListPage(ctx, ListOptions{Limit: 3, Cursor: first.NextCursor})
+	if err != nil {
+		t.Fatalf("ListPage() error = %v", err)
+	}
+	if got, want := postPaths(second.Posts), []string{"post-04.md", "post-03.md", "post-02.md"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("second page = %v, want %v", got, want)
+	}
+	if second.Total != 8 {
+		t.Errorf("Total = %d, want 8", second.Total)
+	}
+}
+
+func TestPostService_ListPageOptions(t *testing.T) {
+	svc := newPostService(newPagingFixture(5))
+	ctx := context.Background()
+
+	t.Run("ascending", func(t *testing.T) {
+		result, err := svc.ListPage(ctx, ListOptions{SortOrder: SortAsc, Limit: 2})
+		if err != nil {
+			t.Fatalf("ListPage() error = %v", err)
+		}
+		if got, want := postPaths(result.Posts), []string{"post-01.md", "post-02.md"}; !reflect.DeepEqual(got, want) {
+			t.Errorf("posts = %v, want %v", got, want)
+		}
+	})
+
+	t.Run("offset without cursor", func(t *testing.T) {
+		result, err := svc.ListPage(ctx, ListOptions{Offset: 4, Limit: 2})
+		if err != nil {
+			t.Fatalf("ListPage() error = %v", err)
+		}
+		if got, want := postPaths(result.Posts), []string{"post-01.md"}; !reflect.DeepEqual(got, want) {
+			t.Errorf("posts = %v, want %v", got, want)
+		}
+		if result.NextCursor != "" {
+			t.Error("last page should have no NextCursor")
+		}
+	})
+
+	t.Run("cursor for another sort", func(t *testing.T) {
+		result, err := svc.ListPage(ctx, ListOptions{Limit: 2})
+		if err != nil {
+			t.Fatalf("ListPage() error = %v", err)
+		}
+		_, err = svc.ListPage(ctx, ListOptions{SortBy: "path", Limit: 2, Cursor: result.NextCursor})
+		if !errors.Is(err, ErrInvalidCursor) {
+			t.Errorf("error = %v, want ErrInvalidCursor", err)
+		}
+	})
+
+	t.Run("malformed cursor", func(t *testing.T) {
+		_, err := svc.ListPage(ctx, ListOptions{Cursor: "not a cursor!"})
+		if !errors.Is(err, ErrInvalidCursor) {
+			t.Errorf("error = %v, want ErrInvalidCursor", err)
+		}
+	})
+}
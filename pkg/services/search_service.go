@@ -0,0 +1,402 @@
+package services
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/WaylonWalker/markata-go/pkg/htmltotext"
+	"github.com/WaylonWalker/markata-go/pkg/lifecycle"
+	"github.com/WaylonWalker/markata-go/pkg/models"
+)
+
+// Search fields, as used in query filters and SearchHit.Fields.
+const (
+	searchFieldTitle       = "title"
+	searchFieldDescription = "description"
+	searchFieldTags        = "tags"
+	searchFieldBody        = "body"
+)
+
+// searchFieldWeights ranks matches by the field they occur in.
+var searchFieldWeights = map[string]float64{
+	searchFieldTitle:       10,
+	searchFieldTags:        5,
+	searchFieldDescription: 3,
+	searchFieldBody:        1,
+}
+
+// maxBodyMatches caps how many body occurrences of a term add to the score,
+// so long posts that repeat a word do not drown out title matches.
+const maxBodyMatches = 5
+
+// snippetRadius is the number of characters shown on each side of a match.
+const snippetRadius = 80
+
+// searchService implements SearchService using lifecycle.Manager.
+//
+// Posts are always read from the manager. The only state is a cache of
+// plain-text bodies, built lazily on the first search and refreshed for
+// posts whose content has changed since.
+type searchService struct {
+	manager *lifecycle.Manager
+
+	mu     sync.Mutex
+	bodies map[*models.Post]searchBody
+}
+
+// searchBody is the cached plain text of a post's body.
+type searchBody struct {
+	source string // HTML or markdown the text was derived from
+	text   string
+}
+
+// newSearchService creates a new SearchService.
+func newSearchService(m *lifecycle.Manager) SearchService {
+	return &searchService{manager: m}
+}
+
+// Search returns posts matching a query, ranked by relevance.
+//
+// Free-text terms (or "quoted phrases") must all appear in one of the
+// searched fields: title, description, tags, and body. opts.Fields narrows
+// the fields searched. Filters restrict results without affecting which
+// fields free-text terms search:
+//
+//	tag:golang          post has the tag "golang"
+//	title:foo           title contains "foo"
+//	description:foo     description contains "foo"
+//	body:foo            body contains "foo"
+//
+// opts.Fuzzy is not supported and is ignored.
+func (s *searchService) Search(ctx context.Context, query string, opts SearchOptions) ([]SearchHit, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	q := parseSearchQuery(query, opts.CaseSensitive)
+	if len(q.terms) == 0 && len(q.filters) == 0 {
+		return nil, nil
+	}
+
+	fields := []string{searchFieldTitle, searchFieldDescription, searchFieldTags, searchFieldBody}
+	if len(opts.Fields) > 0 {
+		fields = fields[:0]
+		for _, f := range opts.Fields {
+			if name, ok := searchFieldName(f); ok {
+				fields = append(fields, name)
+			}
+		}
+	}
+
+	posts := s.manager.Posts()
+	bodies := s.plainBodies(posts)
+
+	var hits []SearchHit
+	for _, p := range posts {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		doc := newSearchDoc(p, bodies[p], opts.CaseSensitive)
+		if hit, ok := doc.match(q, fields); ok {
+			hits = append(hits, hit)
+		}
+	}
+
+	sort.SliceStable(hits, func(i, j int) bool {
+		if hits[i].Score != hits[j].Score {
+			return hits[i].Score > hits[j].Score
+		}
+		return hits[i].Post.Path < hits[j].Post.Path
+	})
+
+	if opts.Limit > 0 && len(hits) > opts.Limit {
+		hits = hits[:opts.Limit]
+	}
+
+	return hits, nil
+}
+
+// plainBodies returns the plain-text body of each post, converting only
+// posts that are new or changed since the last search.
+func (s *searchService) plainBodies(posts []*models.Post) map[*models.Post]string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cache := make(map[*models.Post]searchBody, len(posts))
+	result := make(map[*models.Post]string, len(posts))
+	for _, p := range posts {
+		source := p.ArticleHTML
+		if source == "" {
+			source = p.Content
+		}
+
+		body, ok := s.bodies[p]
+		if !ok || body.source != source {
+			body = searchBody{source: source, text: plainText(p, source)}
+		}
+		cache[p] = body
+		result[p] = body.text
+	}
+	s.bodies = cache
+
+	return result
+}
+
+// plainText converts a post body to plain text for searching. Rendered HTML
+// is converted with htmltotext, minus its link references section; posts
+// that have not been rendered yet are searched as raw markdown.
+func plainText(p *models.Post, source string) string {
+	if p.ArticleHTML == "" {
+		return source
+	}
+	text := htmltotext.Convert(source)
+	if i := strings.Index(text, "\n\nReferences:\n"); i >= 0 {
+		text = text[:i]
+	}
+	return text
+}
+
+// searchQuery is a parsed search query.
+type searchQuery struct {
+	terms   []string
+	filters []searchFilter
+}
+
+// searchFilter restricts results to posts whose field contains value.
+type searchFilter struct {
+	field string
+	value string
+}
+
+// parseSearchQuery splits a query into free-text terms and field filters.
+// Double quotes group words into a phrase, for terms and filter values.
+// Unless caseSensitive is set, terms and values are lowercased.
+func parseSearchQuery(query string, caseSensitive bool) searchQuery {
+	var q searchQuery
+	for _, token := range tokenizeQuery(query) {
+		if !caseSensitive {
+			token = strings.ToLower(token)
+		}
+		if name, value, ok := strings.Cut(token, ":"); ok {
+			if field, known := searchFieldName(name); known {
+				if value = strings.Trim(value, `"`); value != "" {
+					q.filters = append(q.filters, searchFilter{field: field, value: value})
+				}
+				continue
+			}
+		}
+		if token = strings.Trim(token, `"`); token != "" {
+			q.terms = append(q.terms, token)
+		}
+	}
+	return q
+}
+
+// tokenizeQuery splits a query on whitespace outside double quotes.
+func tokenizeQuery(query string) []string {
+	var tokens []string
+	var current strings.Builder
+	inQuotes := false
+	for _, r := range query {
+		switch {
+		case r == '"':
+			inQuotes = !inQuotes
+			current.WriteRune(r)
+		case unicode.IsSpace(r) && !inQuotes:
+			if current.Len() > 0 {
+				tokens = append(tokens, current.String())
+				current.Reset()
+			}
+		default:
+			current.WriteRune(r)
+		}
+	}
+	if current.Len() > 0 {
+		tokens = append(tokens, current.String())
+	}
+	return tokens
+}
+
+// searchFieldName maps a field name or alias to its search field.
+func searchFieldName(name string) (string, bool) {
+	switch strings.ToLower(name) {
+	case "title":
+		return searchFieldTitle, true
+	case "description", "desc":
+		return searchFieldDescription, true
+	case "tag", "tags":
+		return searchFieldTags, true
+	case "body", "content":
+		return searchFieldBody, true
+	}
+	return "", false
+}
+
+// searchDoc holds a post's searchable text, lowercased unless the search
+// is case sensitive.
+type searchDoc struct {
+	post        *models.Post
+	title       string
+	description string
+	tags        []string
+	body        string // original case, for snippets
+	matchBody   string
+}
+
+// newSearchDoc prepares a post for matching.
+func newSearchDoc(p *models.Post, body string, caseSensitive bool) *searchDoc {
+	fold := func(s string) string {
+		if caseSensitive {
+			return s
+		}
+		return strings.ToLower(s)
+	}
+
+	doc := &searchDoc{post: p, body: body, matchBody: fold(body)}
+	if p.Title != nil {
+		doc.title = fold(*p.Title)
+	}
+	if p.Description != nil {
+		doc.description = fold(*p.Description)
+	}
+	for _, tag := range p.Tags {
+		doc.tags = append(doc.tags, fold(tag))
+	}
+	return doc
+}
+
+// count returns how often value occurs in field. For tags, each tag
+// containing value counts once.
+func (d *searchDoc) count(field, value string) int {
+	switch field {
+	case searchFieldTitle:
+		return strings.Count(d.title, value)
+	case searchFieldDescription:
+		return strings.Count(d.description, value)
+	case searchFieldBody:
+		return strings.Count(d.matchBody, value)
+	case searchFieldTags:
+		n := 0
+		for _, tag := range d.tags {
+			if strings.Contains(tag, value) {
+				n++
+			}
+		}
+		return n
+	}
+	return 0
+}
+
+// hasTag reports whether the post has a tag equal to value.
+func (d *searchDoc) hasTag(value string) bool {
+	for _, tag := range d.tags {
+		if tag == value {
+			return true
+		}
+	}
+	return false
+}
+
+// match scores the document against q, searching terms in fields.
+// It returns false if a filter fails or a term is not found.
+func (d *searchDoc) match(q searchQuery, fields []string) (SearchHit, bool) {
+	hit := SearchHit{Post: d.post}
+	matched := make(map[string]bool)
+
+	for _, f := range q.filters {
+		if f.field == searchFieldTags {
+			if !d.hasTag(f.value) {
+				return SearchHit{}, false
+			}
+		} else if d.count(f.field, f.value) == 0 {
+			return SearchHit{}, false
+		}
+		matched[f.field] = true
+		hit.Score += searchFieldWeights[f.field]
+	}
+
+	for _, term := range q.terms {
+		found := false
+		for _, field := range fields {
+			n := d.count(field, term)
+			if n == 0 {
+				continue
+			}
+			if field == searchFieldBody && n > maxBodyMatches {
+				n = maxBodyMatches
+			}
+			found = true
+			matched[field] = true
+			hit.Score += searchFieldWeights[field] * float64(n)
+		}
+		if !found {
+			return SearchHit{}, false
+		}
+	}
+
+	for _, field := range []string{searchFieldTitle, searchFieldDescription, searchFieldTags, searchFieldBody} {
+		if matched[field] {
+			hit.Fields = append(hit.Fields, field)
+		}
+	}
+	hit.Snippet = d.snippet(q)
+
+	return hit, true
+}
+
+// snippet returns body text around the first match of a term or body
+// filter, or the start of the body if neither occurs in it.
+func (d *searchDoc) snippet(q searchQuery) string {
+	needles := append([]string{}, q.terms...)
+	for _, f := range q.filters {
+		if f.field == searchFieldBody {
+			needles = append(needles, f.value)
+		}
+	}
+
+	start, end := 0, 0
+	for _, needle := range needles {
+		if i := strings.Index(d.matchBody, needle); i >= 0 && len(d.matchBody) == len(d.body) {
+			start, end = i, i+len(needle)
+			break
+		}
+	}
+
+	from := clampRuneStart(d.body, start-snippetRadius)
+	to := clampRuneStart(d.body, end+snippetRadius)
+	if end == 0 {
+		to = clampRuneStart(d.body, 2*snippetRadius)
+	}
+
+	snippet := strings.Join(strings.Fields(d.body[from:to]), " ")
+	if snippet == "" {
+		return ""
+	}
+	if from > 0 {
+		snippet = "…" + snippet
+	}
+	if to < len(d.body) {
+		snippet += "…"
+	}
+	return snippet
+}
+
+// clampRuneStart clamps i to [0, len(s)] and moves it back to the start of
+// a UTF-8 character.
+func clampRuneStart(s string, i int) int {
+	if i <= 0 {
+		return 0
+	}
+	if i >= len(s) {
+		return len(s)
+	}
+	for i > 0 && !utf8.RuneStart(s[i]) {
+		i--
+	}
+	return i
+}
@@ -0,0 +1,149 @@
+package services
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/WaylonWalker/markata-go/pkg/lifecycle"
+	"github.com/WaylonWalker/markata-go/pkg/models"
+)
+
+func newSearchTestPost(path, title, description, articleHTML string, tags ...string) *models.Post {
+	p := models.NewPost(path)
+	p.Title = &title
+	if description != "" {
+		p.Description = &description
+	}
+	p.ArticleHTML = articleHTML
+	p.Tags = tags
+	return p
+}
+
+func newSearchTestService() (SearchService, *lifecycle.Manager) {
+	m := lifecycle.NewManager()
+	m.SetPosts([]*models.Post{
+		newSearchTestPost("go.md", "Learning Go", "Notes on Go concurrency",
+			`<p>Goroutines and <a href="https://go.dev">channels</a> make concurrency easy.</p>`, "golang"),
+		newSearchTestPost("python.md", "Python Tips", "",
+			`<p>Python has a GIL, unlike Go.</p>`, "python"),
+		newSearchTestPost("rust.md", "Rust Ownership", "Borrowing explained",
+			`<p>The borrow checker enforces ownership.</p>`, "rust", "golang-adjacent"),
+	})
+	return newSearchService(m), m
+}
+
+func hitPaths(hits []SearchHit) []string {
+	paths := make([]string, len(hits))
+	for i, hit := range hits {
+		paths[i] = hit.Post.Path
+	}
+	return paths
+}
+
+func TestSearchService_Search(t *testing.T) {
+	svc, _ := newSearchTestService()
+
+	tests := []struct {
+		name  string
+		query string
+		opts  SearchOptions
+		want  []string
+	}{
+		{"ranked by field weight", "go", SearchOptions{}, []string{"go.md", "rust.md", "python.md"}},
+		{"all terms must match", "go gil", SearchOptions{}, []string{"python.md"}},
+		{"case insensitive", "BORROW", SearchOptions{}, []string{"rust.md"}},
+		{"case sensitive", "BORROW", SearchOptions{CaseSensitive: true}, nil},
+		{"tag filter is exact", "tag:golang", SearchOptions{}, []string{"go.md"}},
+		{"title filter", "title:python", SearchOptions{}, []string{"python.md"}},
+		{"filter with term", "tag:golang channels", SearchOptions{}, []string{"go.md"}},
+		{"filter excludes term match", "tag:rust gil", SearchOptions{}, nil},
+		{"quoted phrase", `"borrow checker"`, SearchOptions{}, []string{"rust.md"}},
+		{"quoted filter value", `title:"rust ownership"`, SearchOptions{}, []string{"rust.md"}},
+		{"fields option", "concurrency", SearchOptions{Fields: []string{"title"}}, nil},
+		{"link references not indexed", "go.dev", SearchOptions{}, nil},
+		{"limit", "go", SearchOptions{Limit: 1}, []string{"go.md"}},
+		{"unknown field is a term", "http:x", SearchOptions{}, nil},
+		{"empty query", "  ", SearchOptions{}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hits, err := svc.Search(context.Background(), tt.query, tt.opts)
+			if err != nil {
+				t.Fatalf("Search() error = %v", err)
+			}
+			if got := hitPaths(hits); !reflect.DeepEqual(got, tt.want) && (len(got) != 0 || len(tt.want) != 0) {
+				t.Errorf("Search(%q) = %v, want %v", tt.query, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSearchService_HitDetails(t *testing.T) {
+	svc, _ := newSearchTestService()
+
+	hits, err := svc.Search(context.Background(), "concurrency", SearchOptions{})
+	if err != nil {
+		t.Fatalf("Search() error = %v", err)
+	}
+	if len(hits) != 1 {
+		t.Fatalf("got %d hits, want 1", len(hits))
+	}
+
+	hit := hits[0]
+	if want := []string{"description", "body"}; !reflect.DeepEqual(hit.Fields, want) {
+		t.Errorf("Fields = %v, want %v", hit.Fields, want)
+	}
+	if hit.Snippet != "Goroutines and channels [1] make concurrency easy." {
+		t.Errorf("Snippet = %q", hit.Snippet)
+	}
+}
+
+func TestSearchService_Snippet(t *testing.T) {
+	m := lifecycle.NewManager()
+	body := "<p>" + strings.Repeat("filler ", 40) + "needle " + strings.Repeat("padding ", 40) + "</p>"
+	m.SetPosts([]*models.Post{newSearchTestPost("long.md", "Long", "", body)})
+	svc := newSearchService(m)
+
+	hits, err := svc.Search(context.Background(), "needle", SearchOptions{})
+	if err != nil || len(hits) != 1 {
+		t.Fatalf("Search() = %v, %v", hits, err)
+	}
+	snippet := hits[0].Snippet
+	if !strings.HasPrefix(snippet, "…") || !strings.HasSuffix(snippet, "…") {
+		t.Errorf("snippet should be elided on both sides, got %q", snippet)
+	}
+	if !strings.Contains(snippet, "needle") {
+		t.Errorf("snippet should contain the match, got %q", snippet)
+	}
+}
+
+func TestSearchService_ReindexesChangedPosts(t *testing.T) {
+	svc, m := newSearchTestService()
+	ctx := context.Background()
+
+	if hits, _ := svc.Search(ctx, "lifetimes", SearchOptions{}); len(hits) != 0 {
+		t.Fatalf("unexpected hits before change: %v", hitPaths(hits))
+	}
+
+	posts := m.Posts()
+	posts[2].ArticleHTML = "<p>Lifetimes annotate references.</p>"
+	m.SetPosts(posts)
+
+	hits, _ := svc.Search(ctx, "lifetimes", SearchOptions{})
+	if got := hitPaths(hits); !reflect.DeepEqual(got, []string{"rust.md"}) {
+		t.Errorf("Search after change = %v, want [rust.md]", got)
+	}
+}
+
+func TestSearchService_CanceledContext(t *testing.T) {
+	svc, _ := newSearchTestService()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := svc.Search(ctx, "go", SearchOptions{}); err == nil {
+		t.Error("expected an error for a canceled context")
+	}
+}
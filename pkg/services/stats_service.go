@@ -0,0 +1,91 @@
+package services
+
+import (
+	"context"
+	"strings"
+
+	"github.com/WaylonWalker/markata-go/pkg/lifecycle"
+	"github.com/WaylonWalker/markata-go/pkg/models"
+)
+
+// statsService implements StatsService using lifecycle.Manager.
+type statsService struct {
+	manager *lifecycle.Manager
+}
+
+// newStatsService creates a new StatsService.
+func newStatsService(m *lifecycle.Manager) StatsService {
+	return &statsService{manager: m}
+}
+
+// Summary computes site-wide statistics from the loaded posts in a single
+// pass. Tags are counted like TagService.List, with synonyms collapsed and
+// each tag counted once per post. Reading time averages the posts that have
+// one; it is set by the reading_time transform, so LoadOnly leaves it zero.
+func (s *statsService) Summary(ctx context.Context) (SiteStats, error) {
+	posts := s.manager.Posts()
+	idx := s.manager.PostIndex()
+	synonyms := tagSynonyms(s.manager)
+
+	stats := SiteStats{
+		TotalPosts:   len(posts),
+		PostsPerYear: make(map[int]int),
+	}
+	uniqueTags := make(map[string]bool)
+	linkedTo := make(map[*models.Post]bool)
+	var readingTotal, readingCount int
+
+	for _, p := range posts {
+		if err := ctx.Err(); err != nil {
+			return SiteStats{}, err
+		}
+
+		if p.Published {
+			stats.Published++
+		}
+		if p.Draft {
+			stats.Drafts++
+		}
+		if p.Date != nil {
+			stats.PostsPerYear[p.Date.Year()]++
+		}
+		if minutes, ok := p.Extra["reading_time"].(int); ok {
+			readingTotal += minutes
+			readingCount++
+		}
+
+		seen := make(map[string]bool, len(p.Tags))
+		for _, tag := range p.Tags {
+			name := canonicalTag(tag, synonyms)
+			if !seen[name] {
+				seen[name] = true
+				uniqueTags[name] = true
+				stats.TotalTags++
+			}
+		}
+
+		for target := range linkedPosts(p, idx) {
+			if target != p {
+				linkedTo[target] = true
+			}
+		}
+		for _, match := range wikilinkPattern.FindAllStringSubmatch(p.Content, -1) {
+			target, _, _ := strings.Cut(strings.TrimSpace(match[1]), "#")
+			if idx.LookupBySlug(target) == nil {
+				stats.BrokenWikilinks++
+			}
+		}
+	}
+
+	stats.UniqueTags = len(uniqueTags)
+	if readingCount > 0 {
+		stats.AverageReadingTime = float64(readingTotal) / float64(readingCount)
+	}
+	for _, p := range posts {
+		if !linkedTo[p] {
+			stats.OrphanPosts = append(stats.OrphanPosts, p)
+		}
+	}
+
+	return stats, nil
+}
@@ -0,0 +1,76 @@
+package services
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/WaylonWalker/markata-go/pkg/lifecycle"
+	"github.com/WaylonWalker/markata-go/pkg/models"
+)
+
+// newStatsFixture builds a small site: three published posts across two
+// years, a draft, and an undated page, linked by wikilinks and outlinks.
+func newStatsFixture() *lifecycle.Manager {
+	post := func(slug string, year int, content string, tags ...string) *models.Post {
+		p := newTestPost(slug, tags...)
+		p.Published = true
+		p.Content = content
+		if year > 0 {
+			date := time.Date(year, 3, 1, 0, 0, 0, 0, time.UTC)
+			p.Date = &date
+		}
+		return p
+	}
+
+	intro := post("intro", 2023, "Start here, then read [[setup]] and [[missing-post]].", "js", "web")
+	intro.Set("reading_time", 2)
+	setup := post("setup", 2024, "See [[intro#start|the intro]].", "javascript")
+	setup.Set("reading_time", 4)
+	deploy := post("deploy", 2024, "Nothing links here. [[also-missing]]", "web", "ops")
+	draft := post("draft", 2024, "Work in progress on [[deploy]].")
+	draft.Published = false
+	draft.Draft = true
+	about := post("about", 0, "About this site.")
+	about.Outlinks = []*models.Link{{TargetPost: deploy}}
+
+	return newTestManager(newTagSynonymConfig(), intro, setup, deploy, draft, about)
+}
+
+func TestStatsService_Summary(t *testing.T) {
+	stats, err := NewApp(newStatsFixture()).Stats.Summary(context.Background())
+	if err != nil {
+		t.Fatalf("Summary() error = %v", err)
+	}
+
+	if stats.TotalPosts != 5 || stats.Published != 4 || stats.Drafts != 1 {
+		t.Errorf("posts = %d total, %d published, %d drafts; want 5, 4, 1",
+			stats.TotalPosts, stats.Published, stats.Drafts)
+	}
+	if stats.TotalTags != 5 || stats.UniqueTags != 3 {
+		t.Errorf("tags = %d total, %d unique; want 5, 3", stats.TotalTags, stats.UniqueTags)
+	}
+	if want := map[int]int{2023: 1, 2024: 3}; !reflect.DeepEqual(stats.PostsPerYear, want) {
+		t.Errorf("PostsPerYear = %v, want %v", stats.PostsPerYear, want)
+	}
+	if stats.AverageReadingTime != 3 {
+		t.Errorf("AverageReadingTime = %v, want 3", stats.AverageReadingTime)
+	}
+	if stats.BrokenWikilinks != 2 {
+		t.Errorf("BrokenWikilinks = %d, want 2", stats.BrokenWikilinks)
+	}
+	if got, want := postSlugs(stats.OrphanPosts), []string{"draft", "about"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("OrphanPosts = %v, want %v", got, want)
+	}
+}
+
+func TestStatsService_Summary_Empty(t *testing.T) {
+	stats, err := newStatsService(lifecycle.NewManager()).Summary(context.Background())
+	if err != nil {
+		t.Fatalf("Summary() error = %v", err)
+	}
+	if stats.TotalPosts != 0 || stats.AverageReadingTime != 0 || len(stats.OrphanPosts) != 0 {
+		t.Errorf("Summary() = %+v, want zero stats", stats)
+	}
+}
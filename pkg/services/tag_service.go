@@ -19,23 +19,44 @@ func newTagService(m *lifecycle.Manager) TagService {
 	return &tagService{manager: m}
 }
 
-// List returns all tags with their post counts.
+// List returns all tags with their post counts. Tags configured as
+// tag_aggregator synonyms are counted under their canonical tag, so posts
+// tagged "js" and "javascript" count once toward "javascript".
 func (s *tagService) List(_ context.Context) ([]TagInfo, error) {
 	posts := s.manager.Posts()
+	synonyms := s.synonyms()
 	tagCounts := make(map[string]int)
+	aliases := make(map[string]map[string]bool)
 
 	for _, p := range posts {
+		seen := make(map[string]bool, len(p.Tags))
 		for _, tag := range p.Tags {
-			tagCounts[tag]++
+			name := canonicalTag(tag, synonyms)
+			if name != tag {
+				if aliases[name] == nil {
+					aliases[name] = make(map[string]bool)
+				}
+				aliases[name][tag] = true
+			}
+			if !seen[name] {
+				seen[name] = true
+				tagCounts[name]++
+			}
 		}
 	}
 
 	tags := make([]TagInfo, 0, len(tagCounts))
 	for name, count := range tagCounts {
+		var names []string
+		for alias := range aliases[name] {
+			names = append(names, alias)
+		}
+		sort.Strings(names)
 		tags = append(tags, TagInfo{
-			Name:  name,
-			Count: count,
-			Slug:  slugify(name),
+			Name:    name,
+			Count:   count,
+			Slug:    slugify(name),
+			Aliases: names,
 		})
 	}
 
@@ -51,16 +72,22 @@ func (s *tagService) List(_ context.Context) ([]TagInfo, error) {
 }
 
 // GetPosts returns posts with a specific tag.
-func (s *tagService) GetPosts(_ context.Context, tag string, opts ListOptions) ([]*models.Post, error) {
+func (s *tagService) GetPosts(ctx context.Context, tag string, opts ListOptions) ([]*models.Post, error) {
+	return s.GetPostsWithAll(ctx, []string{tag}, opts)
+}
+
+// GetPostsWithAll returns posts that have every one of the given tags.
+// Tags match case-insensitively, with synonyms resolved to their
+// canonical tag.
+func (s *tagService) GetPostsWithAll(_ context.Context, tags []string, opts ListOptions) ([]*models.Post, error) {
 	posts := s.manager.Posts()
+	synonyms := s.synonyms()
+	required := canonicalTags(tags, synonyms)
 
 	var result []*models.Post
 	for _, p := range posts {
-		for _, t := range p.Tags {
-			if strings.EqualFold(t, tag) {
-				result = append(result, p)
-				break
-			}
+		if hasAllTags(canonicalTags(p.Tags, synonyms), required) {
+			result = append(result, p)
 		}
 	}
 
@@ -80,6 +107,52 @@ func (s *tagService) GetPosts(_ context.Context, tag string, opts ListOptions) (
 	return result, nil
 }
 
+// synonyms returns the tag_aggregator synonyms from the site config, or nil
+// when none are configured or tag aggregation is disabled.
+func (s *tagService) synonyms() map[string][]string {
+	return tagSynonyms(s.manager)
+}
+
+// tagSynonyms returns the tag_aggregator synonyms configured for m, or nil
+// when the aggregator is disabled.
+func tagSynonyms(m *lifecycle.Manager) map[string][]string {
+	cfg := m.Config()
+	if cfg == nil {
+		return nil
+	}
+	modelsConfig, ok := cfg.Extra["models_config"].(*models.Config)
+	if !ok || !modelsConfig.TagAggregator.IsEnabled() {
+		return nil
+	}
+	return modelsConfig.TagAggregator.Synonyms
+}
+
+// canonicalTag maps a synonym to its canonical tag, matching
+// case-insensitively like the tag_aggregator plugin. Other tags are
+// returned unchanged.
+func canonicalTag(tag string, synonyms map[string][]string) string {
+	for canonical, variants := range synonyms {
+		for _, variant := range variants {
+			if strings.EqualFold(tag, variant) {
+				return canonical
+			}
+		}
+	}
+	return tag
+}
+
+// canonicalTags applies canonicalTag to each tag.
+func canonicalTags(tags []string, synonyms map[string][]string) []string {
+	if len(synonyms) == 0 {
+		return tags
+	}
+	result := make([]string, len(tags))
+	for i, tag := range tags {
+		result[i] = canonicalTag(tag, synonyms)
+	}
+	return result
+}
+
 // slugify converts a string to a URL-safe slug.
 // This is a convenience wrapper around models.Slugify.
 func slugify(s string) string {
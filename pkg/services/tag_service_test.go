@@ -0,0 +1,80 @@
+package services
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/WaylonWalker/markata-go/pkg/lifecycle"
+	"github.com/WaylonWalker/markata-go/pkg/models"
+)
+
+// newTagFixture builds posts tagged with both spellings of javascript,
+// collapsed by a configured synonym.
+func newTagFixture() *lifecycle.Manager {
+	return newTestManager(newTagSynonymConfig(),
+		newTestPost("react", "js", "web"),
+		newTestPost("node", "javascript", "backend"),
+		newTestPost("both", "JS", "javascript", "web"),
+		newTestPost("flask", "python", "web"),
+	)
+}
+
+func TestTagService_List_CollapsesSynonyms(t *testing.T) {
+	tags, err := newTagService(newTagFixture()).List(context.Background())
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+
+	want := []TagInfo{
+		{Name: "javascript", Count: 3, Slug: "javascript", Aliases: []string{"JS", "js"}},
+		{Name: "web", Count: 3, Slug: "web"},
+		{Name: "backend", Count: 1, Slug: "backend"},
+		{Name: "python", Count: 1, Slug: "python"},
+	}
+	if !reflect.DeepEqual(tags, want) {
+		t.Errorf("List() = %+v, want %+v", tags, want)
+	}
+}
+
+func TestTagService_GetPostsWithAll(t *testing.T) {
+	svc := newTagService(newTagFixture())
+	ctx := context.Background()
+
+	tests := []struct {
+		name string
+		tags []string
+		want []string
+	}{
+		{"synonym matches canonical tag", []string{"js"}, []string{"react", "node", "both"}},
+		{"tags are ANDed", []string{"javascript", "web"}, []string{"react", "both"}},
+		{"case insensitive", []string{"WEB", "Python"}, []string{"flask"}},
+		{"no post has every tag", []string{"python", "backend"}, []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			posts, err := svc.GetPostsWithAll(ctx, tt.tags, ListOptions{})
+			if err != nil {
+				t.Fatalf("GetPostsWithAll() error = %v", err)
+			}
+			if got := postSlugs(posts); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetPostsWithAll(%v) = %v, want %v", tt.tags, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTagService_NoSynonymsWhenDisabled(t *testing.T) {
+	m := newTagFixture()
+	disabled := false
+	m.Config().Extra["models_config"].(*models.Config).TagAggregator.Enabled = &disabled
+
+	posts, err := newTagService(m).GetPosts(context.Background(), "js", ListOptions{})
+	if err != nil {
+		t.Fatalf("GetPosts() error = %v", err)
+	}
+	if got := postSlugs(posts); !reflect.DeepEqual(got, []string{"react", "both"}) {
+		t.Errorf("GetPosts(js) = %v, want only posts tagged js", got)
+	}
+}
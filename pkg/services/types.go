@@ -1,6 +1,10 @@
 package services
 
-import "time"
+import (
+	"time"
+
+	"github.com/WaylonWalker/markata-go/pkg/models"
+)
 
 // SortOrder defines the sort direction.
 type SortOrder string
@@ -38,6 +42,23 @@ type ListOptions struct {
 
 	// Limit is the maximum number of items to return (0 = no limit)
 	Limit int
+
+	// Cursor continues a ListPage call after the page that returned it as
+	// NextCursor. Takes precedence over Offset.
+	Cursor string
+}
+
+// ListResult is one page of posts from PostService.ListPage.
+type ListResult struct {
+	// Posts is the current page
+	Posts []*models.Post
+
+	// NextCursor fetches the next page when set as ListOptions.Cursor
+	// (empty on the last page)
+	NextCursor string
+
+	// Total is the number of posts matching the filters, across all pages
+	Total int
 }
 
 // DateRange defines a date range filter.
@@ -61,6 +82,42 @@ type SearchOptions struct {
 	Limit int
 }
 
+// RelatedOptions configures how PostService scores related posts.
+// Use DefaultRelatedOptions and adjust; a zero weight ignores that signal.
+type RelatedOptions struct {
+	// TagWeight is the score per tag shared with the source post
+	TagWeight float64
+
+	// LinkWeight is the score when either post wikilinks to the other
+	LinkWeight float64
+
+	// Limit is the maximum number of posts to return (0 = no limit)
+	Limit int
+}
+
+// DefaultRelatedOptions returns the default related post weights.
+func DefaultRelatedOptions() RelatedOptions {
+	return RelatedOptions{
+		TagWeight:  1,
+		LinkWeight: 2,
+	}
+}
+
+// SearchHit is a post matched by SearchService.Search.
+type SearchHit struct {
+	// Post is the matched post
+	Post *models.Post
+
+	// Score ranks the hit; higher is more relevant
+	Score float64
+
+	// Fields lists the fields that matched (title, description, tags, body)
+	Fields []string
+
+	// Snippet is plain text around the first match, for display
+	Snippet string
+}
+
 // TagInfo represents a tag with metadata.
 type TagInfo struct {
 	// Name is the tag name
@@ -71,8 +128,67 @@ type TagInfo struct {
 
 	// Slug is the URL-safe version of the tag
 	Slug string
+
+	// Aliases lists synonym spellings counted under this tag
+	Aliases []string
+}
+
+// SiteStats summarizes a site, as returned by StatsService.Summary.
+type SiteStats struct {
+	// TotalPosts is the number of loaded posts
+	TotalPosts int
+
+	// Published is the number of published posts
+	Published int
+
+	// Drafts is the number of draft posts
+	Drafts int
+
+	// TotalTags is the number of tag assignments across all posts
+	TotalTags int
+
+	// UniqueTags is the number of distinct tags, with synonyms collapsed
+	UniqueTags int
+
+	// PostsPerYear counts dated posts by year
+	PostsPerYear map[int]int
+
+	// AverageReadingTime is the mean reading time in minutes of the posts
+	// that have one (0 if none do)
+	AverageReadingTime float64
+
+	// BrokenWikilinks is the number of wikilinks whose target does not exist
+	BrokenWikilinks int
+
+	// OrphanPosts are the posts no other post links to, in post order
+	OrphanPosts []*models.Post
+}
+
+// FeedIssue is a problem with a feed's configuration found by
+// FeedService.Validate.
+type FeedIssue struct {
+	// Slug identifies the feed ("" is the home feed)
+	Slug string
+
+	// Severity is FeedIssueError for feeds that cannot build correctly and
+	// FeedIssueWarning for likely mistakes
+	Severity FeedIssueSeverity
+
+	// Field is the feed setting at fault: "filter" or "sort"
+	Field string
+
+	// Message describes the problem
+	Message string
 }
 
+// FeedIssueSeverity classifies a FeedIssue.
+type FeedIssueSeverity string
+
+const (
+	FeedIssueError   FeedIssueSeverity = "error"
+	FeedIssueWarning FeedIssueSeverity = "warning"
+)
+
 // BuildOptions configures build operations.
 type BuildOptions struct {
 	// Watch enables watch mode for continuous rebuilding
@@ -89,8 +205,37 @@ type BuildOptions struct {
 
 	// Concurrency sets parallel processing level
 	Concurrency int
+
+	// FailFast stops per-post processing at the first failing post instead
+	// of processing every post and reporting all failures
+	FailFast bool
+
+	// Events receives the progress events of this build, in addition to
+	// channels from Subscribe. The channel is never closed; the last event
+	// of a build is BuildEventComplete or BuildEventError
+	Events chan<- BuildEvent
+
+	// EventPolicy controls what happens when Events is not ready for an
+	// event (default: EventPolicyDrop)
+	EventPolicy EventPolicy
 }
 
+// EventPolicy controls how build events reach a consumer that falls behind.
+// Either way the build never waits for the consumer.
+type EventPolicy string
+
+const (
+	// EventPolicyDrop discards events the consumer is not ready to receive.
+	EventPolicyDrop EventPolicy = "drop"
+
+	// EventPolicyBuffer queues events in memory and delivers them in
+	// order, possibly after Build has returned. If the consumer falls more
+	// than 1024 events behind, the oldest are discarded and reported with a
+	// BuildEventDropped event. Delivery stops when the build's context is
+	// canceled.
+	EventPolicyBuffer EventPolicy = "buffer"
+)
+
 // BuildResult contains the result of a build operation.
 type BuildResult struct {
 	// Success indicates if the build completed successfully
@@ -112,6 +257,28 @@ type BuildResult struct {
 	Warnings []string
 }
 
+// RenderedPost is the output of BuildService.BuildSingle.
+type RenderedPost struct {
+	// Post is the rebuilt copy of the post; the manager's post is unchanged
+	Post *models.Post
+
+	// ArticleHTML is the rendered markdown content
+	ArticleHTML string
+
+	// HTML is the full page from templates (empty if no template rendered)
+	HTML string
+
+	// Text is the article rendered to plain text
+	Text string
+
+	// Frontmatter holds the post's resolved metadata after transforms
+	Frontmatter map[string]interface{}
+
+	// Unavailable lists collect-stage data ("feeds", "links") that no prior
+	// full build provided, so templates saw it empty
+	Unavailable []string
+}
+
 // BuildEvent represents a build progress event.
 type BuildEvent struct {
 	// Type is the event type
@@ -120,13 +287,28 @@ type BuildEvent struct {
 	// Stage is the current build stage
 	Stage string
 
+	// Plugin is the plugin the event is about, for plugin events and
+	// warnings
+	Plugin string
+
+	// Posts are the slugs of the posts the render stage rendered, for
+	// BuildEventRenderSummary
+	Posts []string
+
 	// Message is the event message
 	Message string
 
 	// Progress is the progress percentage (0-100)
 	Progress int
 
-	// Error is the error if Type is BuildEventError
+	// Duration is the plugin's run time for BuildEventPluginFinish
+	Duration time.Duration
+
+	// Dropped is the number of events discarded for BuildEventDropped
+	Dropped int
+
+	// Error is the error for BuildEventError and BuildEventWarning, or the
+	// error a plugin returned for BuildEventPluginFinish
 	Error error
 }
 
@@ -135,8 +317,14 @@ type BuildEventType string
 
 const (
 	BuildEventStart    BuildEventType = "start"
-	BuildEventStage    BuildEventType = "stage"
+	BuildEventStage    BuildEventType = "stage" // a stage started
 	BuildEventProgress BuildEventType = "progress"
 	BuildEventComplete BuildEventType = "complete"
 	BuildEventError    BuildEventType = "error"
+
+	BuildEventPluginStart   BuildEventType = "plugin_start"
+	BuildEventPluginFinish  BuildEventType = "plugin_finish"
+	BuildEventRenderSummary BuildEventType = "render_summary" // sent once the render stage ends
+	BuildEventWarning       BuildEventType = "warning"
+	BuildEventDropped       BuildEventType = "events_dropped" // the buffer policy discarded events
 )
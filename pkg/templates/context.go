@@ -196,6 +196,11 @@ func postToMapUncached(p *models.Post) map[string]interface{} {
 		m["Extra"] = extraMap
 	}
 
+	// Computed excerpt; a frontmatter excerpt takes precedence
+	if _, exists := m["excerpt"]; !exists {
+		m["excerpt"] = p.Excerpt("", 0)
+	}
+
 	return m
 }
 
@@ -459,6 +464,7 @@ func configToMap(c *models.Config) map[string]interface{} {
 			"media": map[string]interface{}{
 				"trusted_domains": append([]string{}, c.Templates.Media.TrustedDomains...),
 			},
+			"timesince_max_days": c.Templates.GetTimesinceMaxDays(),
 		},
 	}
 
@@ -1442,45 +1448,82 @@ func addExtraContext(ctx *pongo2.Context, extra map[string]interface{}) {
 	}
 }
 
-// Merge merges another context into this one.
-// Values from the other context override existing values.
-func (c *Context) Merge(other Context) {
+// Shortcut keys derived from the post, config, and feed by ToPongo2.
+// Merge drops stale copies of these from Extra so they are recomputed
+// from the merged fields at render time.
+var (
+	postShortcutKeys   = []string{"title", "date", "tags", "slug", "href", "published", "draft", "private", "description", "article_html"}
+	configShortcutKeys = []string{"site_title", "site_url", "site_description", "site_author", "authors", "default_author", "default_author_id"}
+	feedShortcutKeys   = []string{"feed_slug", "feed_title", "feed_description"}
+)
+
+// Merge returns a new Context with other's values layered over the receiver's.
+// Neither context is modified.
+//
+// Precedence rules:
+//   - Non-zero fields in other (Post, Body, Config, Feed, ...) replace the receiver's.
+//   - Extra keys from other override the receiver's; other keys are kept.
+//     This includes shortcut keys (title, tags, site_title, feed_title, ...);
+//     ToPongo2 only uses those when the merged context does not derive them.
+//   - When other replaces the Post, Config, or Feed, the receiver's copies
+//     of the matching shortcut keys are dropped from Extra, so ToPongo2
+//     recomputes them from the merged context.
+func (c *Context) Merge(other *Context) *Context {
+	merged := c.Clone()
+	if other == nil {
+		return &merged
+	}
+
 	if other.Post != nil {
-		c.Post = other.Post
+		merged.Post = other.Post
+		deleteKeys(merged.Extra, postShortcutKeys)
 	}
 	if other.Body != "" {
-		c.Body = other.Body
+		merged.Body = other.Body
 	}
 	if other.Config != nil {
-		c.Config = other.Config
+		merged.Config = other.Config
+		deleteKeys(merged.Extra, configShortcutKeys)
 	}
 	if other.Feed != nil {
-		c.Feed = other.Feed
+		merged.Feed = other.Feed
+		deleteKeys(merged.Extra, feedShortcutKeys)
 	}
 	if other.FeedPage != nil {
-		c.FeedPage = other.FeedPage
+		merged.FeedPage = other.FeedPage
 	}
 	if other.Posts != nil {
-		c.Posts = other.Posts
+		merged.Posts = make([]*models.Post, len(other.Posts))
+		copy(merged.Posts, other.Posts)
 	}
 	if other.Core != nil {
-		c.Core = other.Core
+		merged.Core = other.Core
 	}
 	if other.SidebarItems != nil {
-		c.SidebarItems = other.SidebarItems
+		merged.SidebarItems = make([]models.SidebarNavItem, len(other.SidebarItems))
+		copy(merged.SidebarItems, other.SidebarItems)
 	}
 	if other.SidebarTitle != "" {
-		c.SidebarTitle = other.SidebarTitle
+		merged.SidebarTitle = other.SidebarTitle
 	}
 
 	if other.Extra != nil {
-		if c.Extra == nil {
-			c.Extra = make(map[string]interface{})
+		if merged.Extra == nil {
+			merged.Extra = make(map[string]interface{}, len(other.Extra))
 		}
 		for k, v := range other.Extra {
-			c.Extra[k] = v
+			merged.Extra[k] = v
 		}
 	}
+
+	return &merged
+}
+
+// deleteKeys removes keys from m. A nil map is a no-op.
+func deleteKeys(m map[string]interface{}, keys []string) {
+	for _, k := range keys {
+		delete(m, k)
+	}
 }
 
 // Clone creates a copy of the context.
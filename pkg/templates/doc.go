@@ -26,6 +26,11 @@
 //	ctx := templates.NewContext(post, articleHTML, config)
 //	ctx.Set("custom_key", "custom_value")
 //
+// Contexts can be layered with Merge, which returns a new Context where the
+// argument's values win and neither input is modified:
+//
+//	pageCtx := baseCtx.Merge(&overrides)
+//
 // Available variables in templates:
 //   - post: The current post object
 //   - body: Rendered article HTML
@@ -53,12 +58,16 @@
 //   - rss_date: Format for RSS feeds (RFC1123Z)
 //   - atom_date: Format for Atom feeds (RFC3339)
 //   - date_format: Custom date format
+//   - timesince: Relative time ("3 days ago"), absolute past a cutoff
 //
 // String manipulation:
 //   - slugify: Convert to URL-safe slug
 //   - truncate: Truncate string with ellipsis
 //   - truncatewords: Truncate by word count
 //   - striptags: Remove HTML tags
+//   - read_time/read_time_human: Estimated minutes to read ("4 min read")
+//   - excerpt: HTML excerpt of a body, or a plain-text post summary
+//   - regex_replace/regex_match: Regular expression replace and first match (RE2 syntax)
 //
 // Collections:
 //   - length: Length of string/slice
@@ -66,12 +75,57 @@
 //   - join: Join with separator
 //   - reverse: Reverse string/slice
 //   - sort: Sort slice
+//   - dictsort/dictsortreversed: Sort a map or a slice of maps by key path
+//   - batch: Split a slice into rows of N, optionally padding the last
+//   - slice: Distribute a slice into N columns, or take a "from:to" range
 //
 // Other:
 //   - default_if_none: Default value for nil/empty
 //   - urlencode: URL-encode string
 //   - absolute_url: Convert to absolute URL
+//   - static/sri: Final URL and integrity hash of a static file or managed asset
 //   - linebreaks/linebreaksbr: Convert newlines to HTML
+//   - to_json/json_script: Serialize as JSON safe to embed in HTML
+//   - filesizeformat: Human-readable byte count ("1.2 MB", or "1.1 MiB" with "binary")
+//
+// # Custom Tags
+//
+// The toc tag builds a table of contents from the rendered body HTML:
+//
+//	{% toc min=2 max=4 %}
+//	{% toc include_h1=true %}
+//
+// The markdown block tag renders its body as markdown, after interpolating
+// variables and tags in it. With raw the body is converted literally.
+// SetMarkdownRenderer selects the renderer; the render_markdown plugin
+// registers the one used for posts:
+//
+//	{% markdown %}Welcome to **{{ config.title }}**{% endmarkdown %}
+//	{% markdown raw %}Write `{{ post.title }}` in templates{% endmarkdown %}
+//
+// # URL Routing
+//
+// The url_for function returns the canonical URL of a route, so templates
+// do not repeat routing rules. Routes come from the registry set with
+// SetRoutes; the templates plugin builds it from the site config:
+//
+//	{{ url_for('post', post.slug) }}          /my-post/
+//	{{ url_for('tag', tag) }}                 /tags/my-tag/
+//	{{ url_for('author', author_id) }}        /authors/waylon/
+//	{{ url_for('feed', 'blog', page=2) }}     /blog/page/2/
+//
+// pongo2 has no keyword arguments, so the engine rewrites those in url_for
+// calls before parsing. Unknown routes and missing or unknown arguments
+// fail the render.
+//
+// # Execution Limits
+//
+// Renders are capped by the engine's Limits: the total number of {% for %}
+// iterations, counted across nested loops and includes, and the size of the
+// output. Exceeding either fails the render with ErrLimitExceeded. Engines
+// start with SetDefaultLimits' value; Engine.SetLimits overrides it:
+//
+//	engine.SetLimits(templates.Limits{MaxLoopIterations: 100_000})
 //
 // # Example Templates
 //
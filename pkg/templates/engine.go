@@ -2,10 +2,12 @@
 package templates
 
 import (
+	"bytes"
 	"fmt"
 	"io"
 	"io/fs"
 	"os"
+	"path"
 	"path/filepath"
 	"strings"
 	"sync"
@@ -15,10 +17,12 @@ import (
 	"github.com/flosch/pongo2/v6"
 )
 
-// Constants for theme names and embedded file markers
+// Constants for theme names and filesystem layer markers
 const (
 	defaultThemeName   = "default"
 	embeddedFilePrefix = "embedded:"
+	fsFilePrefix       = "fs:"
+	themeFilePrefix    = "theme:"
 )
 
 // Engine provides template rendering capabilities using pongo2.
@@ -37,45 +41,92 @@ type Engine struct {
 	// themeName is the current theme name
 	themeName string
 
+	// templateFS holds templates supplied via NewEngineFromFS.
+	// It is searched after searchPaths and before the embedded default theme.
+	templateFS fs.FS
+
+	// themeFS holds the embedded templates registered for themeName, if any.
+	// It is searched after templateFS and before the embedded default theme.
+	themeFS fs.FS
+
 	// embeddedFS holds the embedded default theme templates as fallback
 	embeddedFS fs.FS
 
 	// useEmbedded indicates whether to use embedded templates as fallback
 	useEmbedded bool
+
+	// limits caps loop iterations and output size for each render
+	limits Limits
 }
 
 // NewEngine creates a new template engine with the given templates directory.
-// The templates directory is used as the base path for template loading.
+// The templates directory is used as the base path for template loading,
+// ahead of the theme directories on disk and the embedded default theme.
+// Use NewEngineFromFS to load templates from an fs.FS instead.
 // If templatesDir is empty, templates can only be rendered from strings.
 func NewEngine(templatesDir string) (*Engine, error) {
-	return NewEngineWithTheme(templatesDir, "default")
+	return NewEngineWithTheme(templatesDir, defaultThemeName)
+}
+
+// NewEngineFromFS creates a new template engine that loads templates from
+// root within fsys, such as an embed.FS shipped inside the binary.
+// Extends and includes resolve within fsys first, falling back to the
+// embedded default theme. A nil fsys yields an engine that only renders
+// strings and embedded templates.
+func NewEngineFromFS(fsys fs.FS, root string) (*Engine, error) {
+	if fsys != nil && root != "" && root != "." {
+		sub, err := fs.Sub(fsys, root)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open template root %q: %w", root, err)
+		}
+		fsys = sub
+	}
+
+	e := newEngine(defaultThemeName)
+	e.templateFS = fsys
+
+	return e, nil
 }
 
 // NewEngineWithTheme creates a new template engine with theme support.
 func NewEngineWithTheme(templatesDir, themeName string) (*Engine, error) {
+	e := newEngine(themeName)
+	e.dir = templatesDir
+
+	// Build search paths
+	e.buildSearchPaths(templatesDir)
+
+	return e, nil
+}
+
+// newEngine creates an engine with no search paths and the embedded default
+// theme as fallback.
+func newEngine(themeName string) *Engine {
 	e := &Engine{
-		dir:           templatesDir,
 		templateCache: make(map[string]*pongo2.Template),
 		searchPaths:   make([]string, 0),
 		themeName:     themeName,
 		embeddedFS:    themes.DefaultTemplates(),
 		useEmbedded:   true,
+		limits:        currentDefaultLimits(),
 	}
 
 	if e.themeName == "" {
 		e.themeName = defaultThemeName
 	}
+	if e.themeName != defaultThemeName {
+		if fsys, ok := themes.Templates(e.themeName); ok {
+			e.themeFS = fsys
+		}
+	}
 
 	// Register custom filters
 	registerFilters()
 
-	// Build search paths
-	e.buildSearchPaths(templatesDir)
-
 	// Create a basic template set (we'll handle loading ourselves)
 	e.set = pongo2.NewSet(defaultThemeName, pongo2.MustNewLocalFileSystemLoader(""))
 
-	return e, nil
+	return e
 }
 
 // buildSearchPaths constructs the ordered list of template directories.
@@ -143,7 +194,7 @@ func (e *Engine) Render(templateName string, ctx Context) (string, error) {
 		return "", fmt.Errorf("failed to load template %q: %w", templateName, err)
 	}
 
-	result, err := tpl.Execute(ctx.ToPongo2())
+	result, err := e.execute(tpl, ctx.ToPongo2())
 	if err != nil {
 		return "", fmt.Errorf("failed to execute template %q: %w", templateName, err)
 	}
@@ -154,12 +205,12 @@ func (e *Engine) Render(templateName string, ctx Context) (string, error) {
 // RenderString renders a template string with the given context.
 // This is useful for inline templates in markdown content (jinja_md).
 func (e *Engine) RenderString(templateStr string, ctx Context) (string, error) {
-	tpl, err := pongo2.FromString(templateStr)
+	tpl, err := pongo2.FromBytes(preprocessTemplate([]byte(templateStr)))
 	if err != nil {
 		return "", fmt.Errorf("failed to parse template string: %w", err)
 	}
 
-	result, err := tpl.Execute(ctx.ToPongo2())
+	result, err := e.execute(tpl, ctx.ToPongo2())
 	if err != nil {
 		return "", fmt.Errorf("failed to execute template string: %w", err)
 	}
@@ -192,35 +243,31 @@ func (e *Engine) LoadTemplate(name string) (*pongo2.Template, error) {
 		}
 
 		// Create a template set with a multi-directory loader for proper include/extends support
-		tplSet := pongo2.NewSet(name, &searchPathLoader{
-			searchPaths: e.searchPaths,
-			embeddedFS:  e.embeddedFS,
-		})
+		tplSet := pongo2.NewSet(name, e.loader())
 
 		// Load the template using absolute path
 		tpl, err = tplSet.FromFile(absPath)
 		if err != nil {
 			return nil, fmt.Errorf("failed to parse template %q: %w", name, err)
 		}
-	case e.useEmbedded && e.embeddedFS != nil:
-		// Try embedded templates as fallback
-		content, readErr := fs.ReadFile(e.embeddedFS, name)
-		if readErr != nil {
+	default:
+		// Fall back to the filesystem layers (NewEngineFromFS, then embedded)
+		layer, ok := e.findLayer(name)
+		if !ok {
 			return nil, fmt.Errorf("template %q not found in search paths %v or embedded templates", name, e.searchPaths)
 		}
+		content, readErr := fs.ReadFile(layer.fsys, name)
+		if readErr != nil {
+			return nil, fmt.Errorf("failed to read template %q: %w", name, readErr)
+		}
 
-		// Create a template set with embedded loader for include/extends support
-		tplSet := pongo2.NewSet(name, &searchPathLoader{
-			searchPaths: e.searchPaths,
-			embeddedFS:  e.embeddedFS,
-		})
+		// Create a template set with the layered loader for include/extends support
+		tplSet := pongo2.NewSet(name, e.loader())
 
-		tpl, err = tplSet.FromBytes(content)
+		tpl, err = tplSet.FromBytes(preprocessTemplate(content))
 		if err != nil {
-			return nil, fmt.Errorf("failed to parse embedded template %q: %w", name, err)
+			return nil, fmt.Errorf("failed to parse template %q: %w", name, err)
 		}
-	default:
-		return nil, fmt.Errorf("template %q not found in search paths %v", name, e.searchPaths)
 	}
 
 	// Cache the compiled template
@@ -231,10 +278,62 @@ func (e *Engine) LoadTemplate(name string) (*pongo2.Template, error) {
 	return tpl, nil
 }
 
+// fsLayer is a filesystem searched for templates after the search paths.
+// Files resolved from a layer are identified by prefix + path.
+type fsLayer struct {
+	prefix string
+	fsys   fs.FS
+}
+
+// fsLayers returns the filesystem layers in resolution order: templates
+// from NewEngineFromFS, the active theme's embedded templates, then the
+// embedded default theme.
+func (e *Engine) fsLayers() []fsLayer {
+	layers := make([]fsLayer, 0, 3)
+	if e.templateFS != nil {
+		layers = append(layers, fsLayer{prefix: fsFilePrefix, fsys: e.templateFS})
+	}
+	if e.themeFS != nil {
+		layers = append(layers, fsLayer{prefix: themeFilePrefix, fsys: e.themeFS})
+	}
+	if e.useEmbedded && e.embeddedFS != nil {
+		layers = append(layers, fsLayer{prefix: embeddedFilePrefix, fsys: e.embeddedFS})
+	}
+	return layers
+}
+
+// findLayer returns the first filesystem layer containing name.
+func (e *Engine) findLayer(name string) (fsLayer, bool) {
+	for _, layer := range e.fsLayers() {
+		if _, err := fs.Stat(layer.fsys, name); err == nil {
+			return layer, true
+		}
+	}
+	return fsLayer{}, false
+}
+
+// loader returns a pongo2 loader over the engine's search paths and layers.
+func (e *Engine) loader() *searchPathLoader {
+	return &searchPathLoader{
+		searchPaths: e.searchPaths,
+		layers:      e.fsLayers(),
+	}
+}
+
 // searchPathLoader implements pongo2.TemplateLoader for multi-directory support.
 type searchPathLoader struct {
 	searchPaths []string
-	embeddedFS  fs.FS
+	layers      []fsLayer
+}
+
+// layerFor returns the layer whose prefix marks p, and p without the prefix.
+func (l *searchPathLoader) layerFor(p string) (fsLayer, string, bool) {
+	for _, layer := range l.layers {
+		if strings.HasPrefix(p, layer.prefix) {
+			return layer, p[len(layer.prefix):], true
+		}
+	}
+	return fsLayer{}, "", false
 }
 
 func (l *searchPathLoader) Abs(base, name string) string {
@@ -243,33 +342,30 @@ func (l *searchPathLoader) Abs(base, name string) string {
 		return name
 	}
 
-	// If name already has embedded prefix, return it
-	if strings.HasPrefix(name, embeddedFilePrefix) {
+	// If name already has a layer prefix, return it
+	if _, _, ok := l.layerFor(name); ok {
 		return name
 	}
 
-	// Handle case where base is an embedded file
-	if strings.HasPrefix(base, embeddedFilePrefix) {
-		// For embedded base, first check if the file exists in embedded FS
-		if l.embeddedFS != nil {
-			// Try the name directly first
-			if _, err := fs.Stat(l.embeddedFS, name); err == nil {
-				return embeddedFilePrefix + name
-			}
-			// Also try resolving relative to the base's directory within embedded FS
-			embeddedBase := base[len(embeddedFilePrefix):]
-			baseDir := filepath.Dir(embeddedBase)
-			if baseDir != "." {
-				candidate := filepath.Join(baseDir, name)
-				if _, err := fs.Stat(l.embeddedFS, candidate); err == nil {
-					return embeddedFilePrefix + candidate
-				}
+	// Handle case where base is a file from a filesystem layer
+	if layer, layerBase, ok := l.layerFor(base); ok {
+		// For a layered base, first check if the file exists in the same layer
+		// Try the name directly first
+		if _, err := fs.Stat(layer.fsys, name); err == nil {
+			return layer.prefix + name
+		}
+		// Also try resolving relative to the base's directory within the layer
+		baseDir := path.Dir(layerBase)
+		if baseDir != "." {
+			candidate := path.Join(baseDir, name)
+			if _, err := fs.Stat(layer.fsys, candidate); err == nil {
+				return layer.prefix + candidate
 			}
 		}
 	}
 
 	// If base is provided and is a regular file, try relative to base directory first
-	if base != "" && !strings.HasPrefix(base, embeddedFilePrefix) {
+	if _, _, ok := l.layerFor(base); base != "" && !ok {
 		baseDir := filepath.Dir(base)
 		candidate := filepath.Join(baseDir, name)
 		if _, err := os.Stat(candidate); err == nil {
@@ -293,11 +389,11 @@ func (l *searchPathLoader) Abs(base, name string) string {
 		}
 	}
 
-	// Check embedded filesystem
-	if l.embeddedFS != nil {
-		if _, err := fs.Stat(l.embeddedFS, name); err == nil {
-			// Return a special marker for embedded files
-			return embeddedFilePrefix + name
+	// Check filesystem layers
+	for _, layer := range l.layers {
+		if _, err := fs.Stat(layer.fsys, name); err == nil {
+			// Return a special marker for layered files
+			return layer.prefix + name
 		}
 	}
 
@@ -311,21 +407,41 @@ func (l *searchPathLoader) Abs(base, name string) string {
 	return name
 }
 
-func (l *searchPathLoader) Get(path string) (io.Reader, error) {
-	// Check for embedded file marker
-	if strings.HasPrefix(path, embeddedFilePrefix) {
-		embeddedPath := path[len(embeddedFilePrefix):]
-		if l.embeddedFS != nil {
-			file, err := l.embeddedFS.Open(embeddedPath)
-			if err == nil {
-				return file, nil
-			}
+// Get reads a template's source and applies preprocessTemplate.
+func (l *searchPathLoader) Get(name string) (io.Reader, error) {
+	file, err := l.open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	content, err := io.ReadAll(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read template %q: %w", name, err)
+	}
+	return bytes.NewReader(preprocessTemplate(content)), nil
+}
+
+// preprocessTemplate rewrites template source into syntax pongo2 can
+// parse: {% markdown raw %} bodies become verbatim and url_for keyword
+// arguments become marked positional ones.
+func preprocessTemplate(src []byte) []byte {
+	return urlForKeywordArgs(rawMarkdownBlocks(src))
+}
+
+// open finds and opens the named template.
+func (l *searchPathLoader) open(name string) (io.ReadCloser, error) {
+	// Check for a layer marker
+	if layer, layerPath, ok := l.layerFor(name); ok {
+		file, err := layer.fsys.Open(layerPath)
+		if err == nil {
+			return file, nil
 		}
 	}
 
 	// If path is already absolute, just try to open it
-	if filepath.IsAbs(path) {
-		file, err := os.Open(path)
+	if filepath.IsAbs(name) {
+		file, err := os.Open(name)
 		if err == nil {
 			return file, nil
 		}
@@ -333,25 +449,25 @@ func (l *searchPathLoader) Get(path string) (io.Reader, error) {
 
 	// Search through all search paths
 	for _, dir := range l.searchPaths {
-		candidate := filepath.Join(dir, path)
+		candidate := filepath.Join(dir, name)
 		file, err := os.Open(candidate)
 		if err == nil {
 			return file, nil
 		}
 	}
 
-	// Try embedded filesystem
-	if l.embeddedFS != nil {
-		file, err := l.embeddedFS.Open(path)
+	// Try filesystem layers
+	for _, layer := range l.layers {
+		file, err := layer.fsys.Open(name)
 		if err == nil {
 			return file, nil
 		}
 	}
 
 	// Last resort: try to open path as-is
-	file, err := os.Open(path)
+	file, err := os.Open(name)
 	if err != nil {
-		return nil, fmt.Errorf("template %q not found in search paths or embedded", path)
+		return nil, fmt.Errorf("template %q not found in search paths or embedded", name)
 	}
 	return file, nil
 }
@@ -366,14 +482,9 @@ func (e *Engine) TemplateExists(name string) bool {
 		}
 	}
 
-	// Check embedded templates
-	if e.useEmbedded && e.embeddedFS != nil {
-		if _, err := fs.Stat(e.embeddedFS, name); err == nil {
-			return true
-		}
-	}
-
-	return false
+	// Check templates from NewEngineFromFS and embedded templates
+	_, ok := e.findLayer(name)
+	return ok
 }
 
 // FindTemplate returns the full path to the template, searching through all paths.
@@ -430,6 +541,13 @@ func (e *Engine) SetTheme(themeName string) {
 		e.themeName = defaultThemeName
 	}
 
+	e.themeFS = nil
+	if e.themeName != defaultThemeName {
+		if fsys, ok := themes.Templates(e.themeName); ok {
+			e.themeFS = fsys
+		}
+	}
+
 	// Clear cache and rebuild search paths
 	e.templateCache = make(map[string]*pongo2.Template)
 	e.buildSearchPaths(e.dir)
@@ -461,7 +579,7 @@ func (e *Engine) RenderToString(templateName string, ctx map[string]interface{})
 	// Convert map to pongo2.Context
 	pctx := pongo2.Context(ctx)
 
-	result, err := tpl.Execute(pctx)
+	result, err := e.execute(tpl, pctx)
 	if err != nil {
 		return "", fmt.Errorf("failed to execute template %q: %w", templateName, err)
 	}
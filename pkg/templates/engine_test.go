@@ -1,11 +1,15 @@
 package templates
 
 import (
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
+	"testing/fstest"
 	"time"
 
 	"github.com/WaylonWalker/markata-go/pkg/models"
+	"github.com/WaylonWalker/markata-go/pkg/themes"
 )
 
 func TestNewEngine(t *testing.T) {
@@ -414,34 +418,112 @@ func TestContext_Merge(t *testing.T) {
 
 	ctx1 := NewContext(post1, "body1", nil)
 	ctx1.Set("key1", "value1")
+	ctx1.Set("shared", "from ctx1")
 
 	ctx2 := NewContext(post2, "body2", config)
 	ctx2.Set("key2", "value2")
+	ctx2.Set("shared", "from ctx2")
 
-	ctx1.Merge(ctx2)
+	merged := ctx1.Merge(&ctx2)
 
 	// Post should be overwritten
-	if ctx1.Post != post2 {
+	if merged.Post != post2 {
 		t.Error("Post not merged")
 	}
 
 	// Body should be overwritten
-	if ctx1.Body != "body2" {
+	if merged.Body != "body2" {
 		t.Error("Body not merged")
 	}
 
 	// Config should be set
-	if ctx1.Config != config {
+	if merged.Config != config {
 		t.Error("Config not merged")
 	}
 
-	// Both extra keys should exist
-	if ctx1.Get("key1") != "value1" {
+	// Both extra keys should exist, with other winning on conflicts
+	if merged.Get("key1") != "value1" {
 		t.Error("Original extra key lost")
 	}
-	if ctx1.Get("key2") != "value2" {
+	if merged.Get("key2") != "value2" {
 		t.Error("Merged extra key not added")
 	}
+	if merged.Get("shared") != "from ctx2" {
+		t.Errorf("shared = %v, want other's value", merged.Get("shared"))
+	}
+
+	// Neither input should be mutated
+	if ctx1.Post != post1 || ctx1.Body != "body1" || ctx1.Config != nil {
+		t.Error("Merge mutated the receiver")
+	}
+	if ctx1.Get("key2") != nil || ctx1.Get("shared") != "from ctx1" {
+		t.Error("Merge mutated the receiver's Extra")
+	}
+	if ctx2.Get("key1") != nil {
+		t.Error("Merge mutated other's Extra")
+	}
+}
+
+func TestContext_Merge_RecomputesShortcuts(t *testing.T) {
+	baseTitle := "Base"
+	overrideTitle := "Override"
+	base := NewContext(&models.Post{Title: &baseTitle, Tags: []string{"old"}}, "", &models.Config{Title: "Old Site"})
+
+	// Simulate stale shortcut copies captured from an earlier render
+	for k, v := range base.ToPongo2() {
+		if k == "title" || k == "tags" || k == "site_title" {
+			base.Set(k, v)
+		}
+	}
+
+	override := NewContext(&models.Post{Title: &overrideTitle, Tags: []string{"new"}}, "", &models.Config{Title: "New Site"})
+	merged := base.Merge(&override)
+
+	pctx := merged.ToPongo2()
+	if pctx["title"] != "Override" {
+		t.Errorf("title = %v, want %q", pctx["title"], "Override")
+	}
+	if tags, ok := pctx["tags"].([]string); !ok || len(tags) != 1 || tags[0] != "new" {
+		t.Errorf("tags = %v, want [new]", pctx["tags"])
+	}
+	if pctx["site_title"] != "New Site" {
+		t.Errorf("site_title = %v, want %q", pctx["site_title"], "New Site")
+	}
+	if merged.Get("title") != nil || merged.Get("site_title") != nil {
+		t.Error("stale shortcut copies should be dropped from Extra")
+	}
+}
+
+func TestContext_Merge_CopiesShortcutKeysFromOther(t *testing.T) {
+	base := NewContext(nil, "", nil)
+	base.Set("title", "Base")
+
+	override := NewContext(nil, "", nil)
+	override.Set("title", "Explicit")
+	merged := base.Merge(&override)
+
+	if got := merged.Get("title"); got != "Explicit" {
+		t.Errorf("Extra title = %v, want other's %q", got, "Explicit")
+	}
+	if got := merged.ToPongo2()["title"]; got != "Explicit" {
+		t.Errorf("title = %v, want %q without a post", got, "Explicit")
+	}
+
+	postTitle := "From Post"
+	merged.Post = &models.Post{Title: &postTitle}
+	if got := merged.ToPongo2()["title"]; got != "From Post" {
+		t.Errorf("title = %v, want the post's %q", got, "From Post")
+	}
+}
+
+func TestContext_Merge_Nil(t *testing.T) {
+	ctx := NewContext(nil, "body", nil)
+	ctx.Set("key", "value")
+
+	merged := ctx.Merge(nil)
+	if merged == &ctx || merged.Body != "body" || merged.Get("key") != "value" {
+		t.Error("Merge(nil) should return an equivalent copy")
+	}
 }
 
 func TestEngine_Render_SlidesTemplate(t *testing.T) {
@@ -528,3 +610,159 @@ func TestEngine_Render_SlidesTemplate_UsesVendoredRevealAssets(t *testing.T) {
 		}
 	}
 }
+
+func TestNewEngineFromFS_ExtendsAndIncludes(t *testing.T) {
+	fsys := fstest.MapFS{
+		"theme/templates/base.html":            {Data: []byte(`<main>{% block content %}{% endblock %}</main>{% include "partials/footer.html" %}`)},
+		"theme/templates/post.html":            {Data: []byte(`{% extends "base.html" %}{% block content %}<h1>{{ post.title }}</h1>{% include "partials/tags.html" %}{% endblock %}`)},
+		"theme/templates/partials/footer.html": {Data: []byte(`<footer>{{ config.title }}</footer>`)},
+		"theme/templates/partials/tags.html":   {Data: []byte(`{% for tag in post.tags %}<span>{{ tag }}</span>{% endfor %}`)},
+	}
+
+	engine, err := NewEngineFromFS(fsys, "theme/templates")
+	if err != nil {
+		t.Fatalf("NewEngineFromFS() error: %v", err)
+	}
+
+	if !engine.TemplateExists("post.html") {
+		t.Fatal("TemplateExists(post.html) = false, want true")
+	}
+	if path := engine.FindTemplate("post.html"); path != "" {
+		t.Errorf("FindTemplate() = %q, want empty for FS templates", path)
+	}
+
+	title := "Embedded"
+	post := &models.Post{Title: &title, Tags: []string{"go", "embed"}}
+	config := &models.Config{Title: "FS Site"}
+
+	result, err := engine.Render("post.html", NewContext(post, "", config))
+	if err != nil {
+		t.Fatalf("Render() error: %v", err)
+	}
+
+	expected := `<main><h1>Embedded</h1><span>go</span><span>embed</span></main><footer>FS Site</footer>`
+	if result != expected {
+		t.Errorf("Render() = %q, want %q", result, expected)
+	}
+}
+
+func TestNewEngineFromFS_SetThemeKeepsFS(t *testing.T) {
+	fsys := fstest.MapFS{
+		"only_fs.html": {Data: []byte(`<p>{{ config.title }}</p>`)},
+	}
+
+	engine, err := NewEngineFromFS(fsys, ".")
+	if err != nil {
+		t.Fatalf("NewEngineFromFS() error: %v", err)
+	}
+	engine.SetTheme("default")
+
+	if !engine.TemplateExists("only_fs.html") {
+		t.Fatal("TemplateExists(only_fs.html) = false after SetTheme, want true")
+	}
+	result, err := engine.Render("only_fs.html", NewContext(nil, "", &models.Config{Title: "FS"}))
+	if err != nil {
+		t.Fatalf("Render() error: %v", err)
+	}
+	if result != "<p>FS</p>" {
+		t.Errorf("Render() = %q, want %q", result, "<p>FS</p>")
+	}
+}
+
+func TestNewEngineFromFS_PrefersFSOverEmbeddedDefaults(t *testing.T) {
+	fsys := fstest.MapFS{
+		"base.html": {Data: []byte(`custom base {% block content %}{% endblock %}`)},
+		"page.html": {Data: []byte(`{% extends "base.html" %}{% block content %}page{% endblock %}`)},
+	}
+
+	engine, err := NewEngineFromFS(fsys, ".")
+	if err != nil {
+		t.Fatalf("NewEngineFromFS() error: %v", err)
+	}
+
+	result, err := engine.Render("page.html", NewContext(nil, "", nil))
+	if err != nil {
+		t.Fatalf("Render() error: %v", err)
+	}
+	if result != "custom base page" {
+		t.Errorf("Render() = %q, want %q", result, "custom base page")
+	}
+
+	if _, err := engine.LoadTemplate("missing.html"); err == nil {
+		t.Error("LoadTemplate(missing.html) expected error")
+	}
+}
+
+func TestNewEngineWithTheme_EmbeddedThemeFallback(t *testing.T) {
+	themes.RegisterTemplates("engine-test", fstest.MapFS{
+		"page.html": {Data: []byte(`{% extends "base.html" %}{% block content %}themed{% endblock %}`)},
+	})
+
+	engine, err := NewEngineWithTheme(t.TempDir(), "engine-test")
+	if err != nil {
+		t.Fatalf("NewEngineWithTheme() error: %v", err)
+	}
+
+	// page.html comes from the theme and extends the built-in base.html
+	tpl, err := engine.LoadTemplate("page.html")
+	if err != nil {
+		t.Fatalf("LoadTemplate(page.html) error: %v", err)
+	}
+	if tpl == nil {
+		t.Fatal("LoadTemplate(page.html) = nil")
+	}
+	if !engine.TemplateExists("feed.html") {
+		t.Error("TemplateExists(feed.html) = false, want built-in fallback")
+	}
+	if engine.TemplateExists("missing.html") {
+		t.Error("TemplateExists(missing.html) = true, want false")
+	}
+}
+
+func TestNewEngineFromFS_InvalidRoot(t *testing.T) {
+	if _, err := NewEngineFromFS(fstest.MapFS{}, "../outside"); err == nil {
+		t.Error("NewEngineFromFS() with invalid root expected error")
+	}
+}
+
+func TestNewEngine_ResolvesDefaultThemeTemplates(t *testing.T) {
+	root := t.TempDir()
+	t.Chdir(root)
+
+	themeDir := filepath.Join(root, "themes", "default", "templates")
+	if err := os.MkdirAll(themeDir, 0o755); err != nil {
+		t.Fatalf("MkdirAll() error: %v", err)
+	}
+	themeFile := filepath.Join(themeDir, "only_theme.html")
+	if err := os.WriteFile(themeFile, []byte(`<p>{{ config.title }}</p>`), 0o600); err != nil {
+		t.Fatalf("WriteFile() error: %v", err)
+	}
+
+	projectDir := filepath.Join(root, "templates")
+	if err := os.MkdirAll(projectDir, 0o755); err != nil {
+		t.Fatalf("MkdirAll() error: %v", err)
+	}
+
+	engine, err := NewEngine(projectDir)
+	if err != nil {
+		t.Fatalf("NewEngine() error: %v", err)
+	}
+
+	if len(engine.SearchPaths()) == 0 {
+		t.Fatal("SearchPaths() is empty, want project and theme directories")
+	}
+	if !engine.TemplateExists("only_theme.html") {
+		t.Fatal("TemplateExists(only_theme.html) = false, want true")
+	}
+	if got := engine.FindTemplate("only_theme.html"); got != filepath.Join("themes", "default", "templates", "only_theme.html") && got != themeFile {
+		t.Errorf("FindTemplate() = %q, want theme template path", got)
+	}
+
+	result, err := engine.Render("only_theme.html", NewContext(nil, "", &models.Config{Title: "Theme"}))
+	if err != nil {
+		t.Fatalf("Render() error: %v", err)
+	}
+	if result != "<p>Theme</p>" {
+		t.Errorf("Render() = %q, want %q", result, "<p>Theme</p>")
+	}
+}
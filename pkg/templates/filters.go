@@ -3,14 +3,17 @@ package templates
 import (
 	"encoding/json"
 	"fmt"
+	"math"
 	"net/url"
 	"path/filepath"
 	"reflect"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
 	"time"
+	"unicode"
 
 	"github.com/WaylonWalker/markata-go/pkg/htmltotext"
 	"github.com/WaylonWalker/markata-go/pkg/models"
@@ -98,7 +101,7 @@ func compileBlockTagPatterns(openTag bool) map[string]*regexp.Regexp {
 	return patterns
 }
 
-// registerFilters registers all custom template filters with pongo2.
+// registerFilters registers all custom template filters and tags with pongo2.
 // This is called once when the first Engine is created.
 // The pongo2 registration functions return errors only for duplicate registrations,
 // which won't occur due to sync.Once protection.
@@ -111,6 +114,7 @@ func registerFilters() {
 		pongo2.RegisterFilter("atom_date", filterAtomDate)
 		pongo2.RegisterFilter("date_format", filterDateFormat)
 		pongo2.RegisterFilter("human_date", filterHumanDate)
+		pongo2.RegisterFilter("timesince", filterTimesince)
 		// Override the built-in date filter to handle *time.Time and string parsing
 		pongo2.ReplaceFilter("date", filterDate)
 
@@ -122,6 +126,8 @@ func registerFilters() {
 		pongo2.RegisterFilter("startswith", filterStartsWith)
 		pongo2.RegisterFilter("split", filterSplit)
 		pongo2.RegisterFilter("replace", filterReplace)
+		pongo2.RegisterFilter("regex_replace", filterRegexReplace)
+		pongo2.RegisterFilter("regex_match", filterRegexMatch)
 
 		// Default/fallback filter
 		pongo2.RegisterFilter("default_if_none", filterDefaultIfNone)
@@ -133,9 +139,16 @@ func registerFilters() {
 		pongo2.RegisterFilter("join", filterJoin)
 		pongo2.RegisterFilter("reverse", filterReverse)
 		pongo2.RegisterFilter("sort", filterSort)
+		pongo2.RegisterFilter("dictsort", filterDictSort)
+		pongo2.RegisterFilter("dictsortreversed", filterDictSortReversed)
 		pongo2.RegisterFilter("selectattr", filterSelectAttr)
 		pongo2.RegisterFilter("rejectattr", filterRejectAttr)
 		pongo2.RegisterFilter("getitem", filterGetItem)
+		pongo2.RegisterFilter("groupby_date", filterGroupByDate)
+		pongo2.RegisterFilter("group_consecutive", filterGroupConsecutive)
+		pongo2.RegisterFilter("batch", filterBatch)
+		// Override the built-in slice filter to add column distribution
+		pongo2.ReplaceFilter("slice", filterSlice)
 
 		// HTML/text filters
 		pongo2.ReplaceFilter("striptags", filterStripTags)
@@ -146,12 +159,15 @@ func registerFilters() {
 		// URL filters
 		pongo2.RegisterFilter("urlencode", filterURLEncode)
 		pongo2.RegisterFilter("absolute_url", filterAbsoluteURL)
+		pongo2.RegisterFilter("absolute_urls", filterAbsoluteURLs)
 		pongo2.RegisterFilter("domain", filterDomain)
 
 		// Theme/asset filters (per THEMES.md spec)
 		pongo2.RegisterFilter("theme_asset", filterThemeAsset)
 		pongo2.RegisterFilter("theme_asset_hashed", filterThemeAssetHashed)
 		pongo2.RegisterFilter("asset_url", filterAssetURL)
+		pongo2.RegisterFilter("static", filterStatic)
+		pongo2.RegisterFilter("sri", filterSRI)
 
 		// ISO date format filter (per THEMES.md spec)
 		pongo2.RegisterFilter("isoformat", filterISOFormat)
@@ -164,6 +180,11 @@ func registerFilters() {
 
 		// Reading time filter
 		pongo2.RegisterFilter("reading_time", filterReadingTime)
+		pongo2.RegisterFilter("read_time", filterReadTime)
+		pongo2.RegisterFilter("read_time_human", filterReadTimeHuman)
+
+		// File size filter
+		pongo2.RegisterFilter("filesizeformat", filterFileSizeFormat)
 
 		// Excerpt filter
 		pongo2.RegisterFilter("excerpt", filterExcerpt)
@@ -171,6 +192,8 @@ func registerFilters() {
 
 		// Type conversion filter
 		pongo2.RegisterFilter("string", filterString)
+		pongo2.RegisterFilter("to_json", filterToJSON)
+		pongo2.RegisterFilter("json_script", filterJSONScript)
 
 		// Contribution data filter for Cal-Heatmap
 		pongo2.RegisterFilter("contribution_data", filterContributionData)
@@ -181,6 +204,13 @@ func registerFilters() {
 		pongo2.RegisterFilter("with_size", filterWithSize)
 		pongo2.RegisterFilter("video_mime", filterVideoMIME)
 		pongo2.RegisterFilter("poster_url", filterPosterURL)
+
+		// Template tags
+		pongo2.RegisterTag("toc", tagTocParser)
+		pongo2.RegisterTag("markdown", tagMarkdownParser)
+
+		// Loops count against the render's Limits
+		pongo2.ReplaceTag("for", tagForParser)
 	})
 }
 
@@ -251,6 +281,88 @@ func filterHumanDate(in, _ *pongo2.Value) (*pongo2.Value, *pongo2.Error) {
 	return pongo2.AsValue(FormatHumanDate(t)), nil
 }
 
+var (
+	timesinceMu      sync.RWMutex
+	timesinceMaxDays = models.DefaultTimesinceMaxDays
+)
+
+// SetTimesinceMaxDays sets how many days old a date can be before timesince
+// shows it as an absolute date. Zero or less always shows relative time.
+func SetTimesinceMaxDays(days int) {
+	timesinceMu.Lock()
+	defer timesinceMu.Unlock()
+	timesinceMaxDays = days
+}
+
+// filterTimesince formats a date, or a post's date, relative to now, e.g.
+// "just now", "5 minutes ago", "3 days ago", or "in 2 hours" for future dates.
+// Dates older than the SetTimesinceMaxDays threshold are shown with
+// FormatHumanDate instead. The optional parameter is the reference time to
+// measure against (default: now).
+// Usage: {{ post.date|timesince }}, {{ post|timesince }}, {{ post.date|timesince:"2024-06-01" }}
+func filterTimesince(in, param *pongo2.Value) (*pongo2.Value, *pongo2.Error) {
+	if post := valueToStringMap(in); post != nil {
+		in = pongo2.AsValue(post["date"])
+	}
+	t, err := toTime(in)
+	if err != nil || t.IsZero() {
+		return pongo2.AsValue(""), nil
+	}
+
+	now := time.Now()
+	if param != nil && !param.IsNil() && param.String() != "" {
+		now, err = toTime(param)
+		if err != nil {
+			return nil, &pongo2.Error{Sender: "filter:timesince", OrigError: fmt.Errorf("invalid reference time: %w", err)}
+		}
+	}
+
+	timesinceMu.RLock()
+	maxDays := timesinceMaxDays
+	timesinceMu.RUnlock()
+
+	return pongo2.AsValue(Timesince(t, now, maxDays)), nil
+}
+
+// Timesince returns t relative to now in words. When maxDays is positive and
+// t is more than maxDays in the past, the absolute date is returned instead.
+func Timesince(t, now time.Time, maxDays int) string {
+	diff := now.Sub(t)
+	future := diff < 0
+	if future {
+		diff = -diff
+	}
+	if !future && maxDays > 0 && diff > time.Duration(maxDays)*24*time.Hour {
+		return FormatHumanDate(t)
+	}
+
+	days := int(diff / (24 * time.Hour))
+	var amount int
+	var unit string
+	switch {
+	case diff < time.Minute:
+		return "just now"
+	case diff < time.Hour:
+		amount, unit = int(diff/time.Minute), "minute"
+	case diff < 24*time.Hour:
+		amount, unit = int(diff/time.Hour), "hour"
+	case days < 30:
+		amount, unit = days, "day"
+	case days < 365:
+		amount, unit = days/30, "month"
+	default:
+		amount, unit = days/365, "year"
+	}
+	if amount != 1 {
+		unit += "s"
+	}
+
+	if future {
+		return fmt.Sprintf("in %d %s", amount, unit)
+	}
+	return fmt.Sprintf("%d %s ago", amount, unit)
+}
+
 // filterSlugify converts a string to a URL-safe slug.
 // Converts to lowercase, replaces non-alphanumeric chars with hyphens,
 // collapses multiple hyphens, and trims leading/trailing hyphens.
@@ -347,6 +459,101 @@ func filterReplace(in, param *pongo2.Value) (*pongo2.Value, *pongo2.Error) {
 	return pongo2.AsValue(strings.ReplaceAll(s, old, replacement)), nil
 }
 
+// maxCachedRegexps bounds the compiled pattern cache. Template patterns are
+// normally literals, so this is only reached by patterns built from data.
+const maxCachedRegexps = 512
+
+var (
+	regexpCacheMu sync.RWMutex
+	regexpCache   = make(map[string]*regexp.Regexp)
+)
+
+// compileFilterRegexp compiles pattern once and reuses it across renders.
+// Patterns use Go's RE2 syntax, which runs in linear time, so there are no
+// catastrophic patterns to guard against at match time; invalid ones,
+// including backreferences, fail here with the parser's message.
+func compileFilterRegexp(sender, pattern string) (*regexp.Regexp, *pongo2.Error) {
+	regexpCacheMu.RLock()
+	re, ok := regexpCache[pattern]
+	regexpCacheMu.RUnlock()
+	if ok {
+		return re, nil
+	}
+
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, &pongo2.Error{
+			Sender:    sender,
+			OrigError: fmt.Errorf("invalid pattern %q (RE2 syntax): %w", pattern, err),
+		}
+	}
+
+	regexpCacheMu.Lock()
+	if len(regexpCache) < maxCachedRegexps {
+		regexpCache[pattern] = re
+	}
+	regexpCacheMu.Unlock()
+	return re, nil
+}
+
+// splitRegexParam splits a "pattern,replacement" filter argument at the
+// first comma that is not escaped with a backslash or inside a [...] class
+// or {m,n} repetition. Without such a comma the replacement is empty.
+func splitRegexParam(param string) (pattern, replacement string) {
+	depth := 0
+	for i := 0; i < len(param); i++ {
+		switch param[i] {
+		case '\\':
+			i++ // skip the escaped character
+		case '[', '{':
+			depth++
+		case ']', '}':
+			if depth > 0 {
+				depth--
+			}
+		case ',':
+			if depth == 0 {
+				return param[:i], param[i+1:]
+			}
+		}
+	}
+	return param, ""
+}
+
+// filterRegexReplace replaces every match of a regular expression.
+// Usage: {{ title|regex_replace:"\\s+, " }} - collapses whitespace runs to one space
+// Format: "pattern,replacement". The replacement may refer to capture groups
+// as $1 or ${name}; without a comma matches are removed. Patterns use Go's
+// RE2 syntax, which has no backreferences.
+func filterRegexReplace(in, param *pongo2.Value) (*pongo2.Value, *pongo2.Error) {
+	pattern, replacement := splitRegexParam(param.String())
+	re, err := compileFilterRegexp("filter:regex_replace", pattern)
+	if err != nil {
+		return nil, err
+	}
+	return pongo2.AsValue(re.ReplaceAllString(in.String(), replacement)), nil
+}
+
+// filterRegexMatch returns the first match of a regular expression, or an
+// empty string if there is none. When the pattern has capture groups the
+// first group is returned instead of the whole match.
+// Usage: {{ post.slug|regex_match:"-(\\d+)$" }} - "issue-42" -> "42"
+func filterRegexMatch(in, param *pongo2.Value) (*pongo2.Value, *pongo2.Error) {
+	re, err := compileFilterRegexp("filter:regex_match", param.String())
+	if err != nil {
+		return nil, err
+	}
+	match := re.FindStringSubmatch(in.String())
+	switch {
+	case match == nil:
+		return pongo2.AsValue(""), nil
+	case len(match) > 1:
+		return pongo2.AsValue(match[1]), nil
+	default:
+		return pongo2.AsValue(match[0]), nil
+	}
+}
+
 // filterDefaultIfNone returns a default value if the input is nil or empty.
 // Usage: {{ value|default_if_none:"fallback" }}
 func filterDefaultIfNone(in, param *pongo2.Value) (*pongo2.Value, *pongo2.Error) {
@@ -492,6 +699,262 @@ func filterSort(in, _ *pongo2.Value) (*pongo2.Value, *pongo2.Error) {
 	return pongo2.AsValue(result), nil
 }
 
+// filterDictSort sorts a map into a slice of {"key", "value"} entries, or a
+// slice of maps or structs, by a key path.
+// Usage: {% for item in tags|dictsort:"count" %}{{ item.key }}{% endfor %}
+//
+// For a map, no argument or "key" sorts by the map key, "value" by the value
+// itself, and any other path (e.g. "count" or "author.name") by that field of
+// the value. For a slice, the path is looked up on each item. Numbers and
+// dates compare by value, everything else as strings, and items missing the
+// field sort last. Ties keep map-key order (or the slice order), so output is
+// the same on every build.
+func filterDictSort(in, param *pongo2.Value) (*pongo2.Value, *pongo2.Error) {
+	return dictSort(in, param, false)
+}
+
+// filterDictSortReversed is dictsort in descending order. Ties keep the
+// same order as with dictsort, and missing fields still sort last.
+// Usage: {% for item in tags|dictsortreversed:"count" %}
+func filterDictSortReversed(in, param *pongo2.Value) (*pongo2.Value, *pongo2.Error) {
+	return dictSort(in, param, true)
+}
+
+// dictSortItem is an item being sorted and the value it sorts by.
+type dictSortItem struct {
+	item  interface{}
+	sort  *pongo2.Value
+	valid bool
+}
+
+func dictSort(in, param *pongo2.Value, reversed bool) (*pongo2.Value, *pongo2.Error) {
+	path := ""
+	if param != nil && !param.IsNil() {
+		path = param.String()
+	}
+
+	rv := reflect.ValueOf(in.Interface())
+	for rv.Kind() == reflect.Ptr || rv.Kind() == reflect.Interface {
+		rv = rv.Elem()
+	}
+
+	var items []dictSortItem
+	switch rv.Kind() {
+	case reflect.Map:
+		keys := rv.MapKeys()
+		sort.Slice(keys, func(i, j int) bool {
+			return fmt.Sprint(keys[i].Interface()) < fmt.Sprint(keys[j].Interface())
+		})
+		for _, k := range keys {
+			key := fmt.Sprint(k.Interface())
+			value := rv.MapIndex(k).Interface()
+			entry := map[string]interface{}{"key": key, "value": value}
+
+			var sortBy *pongo2.Value
+			switch {
+			case path == "" || path == "key":
+				sortBy = pongo2.AsValue(key)
+			case path == "value":
+				sortBy = pongo2.AsValue(value)
+			default:
+				sortBy = lookupAttrPath(pongo2.AsValue(value), strings.TrimPrefix(path, "value."))
+			}
+			items = append(items, dictSortItem{item: entry, sort: sortBy, valid: sortBy != nil && !sortBy.IsNil()})
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < in.Len(); i++ {
+			item := in.Index(i)
+			sortBy := item
+			if path != "" {
+				sortBy = lookupAttrPath(item, path)
+			}
+			items = append(items, dictSortItem{item: item.Interface(), sort: sortBy, valid: sortBy != nil && !sortBy.IsNil()})
+		}
+	default:
+		return in, nil
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		a, b := items[i], items[j]
+		if !a.valid || !b.valid {
+			return a.valid && !b.valid
+		}
+		c := compareSortValues(a.sort, b.sort)
+		if reversed {
+			return c > 0
+		}
+		return c < 0
+	})
+
+	result := make([]interface{}, len(items))
+	for i, it := range items {
+		result[i] = it.item
+	}
+	return pongo2.AsValue(result), nil
+}
+
+// lookupAttrPath follows a dotted path of map keys or struct fields, such
+// as "author.name". It returns nil if any step is missing.
+func lookupAttrPath(v *pongo2.Value, path string) *pongo2.Value {
+	for _, part := range strings.Split(path, ".") {
+		if v == nil {
+			return nil
+		}
+		v = getAttr(v, part)
+	}
+	return v
+}
+
+// compareSortValues orders two values: numerically when both are numbers,
+// chronologically when both are dates, and as strings otherwise.
+func compareSortValues(a, b *pongo2.Value) int {
+	if a.IsNumber() && b.IsNumber() {
+		af, bf := a.Float(), b.Float()
+		switch {
+		case af < bf:
+			return -1
+		case af > bf:
+			return 1
+		default:
+			return 0
+		}
+	}
+	if at, ok := sortTime(a); ok {
+		if bt, ok := sortTime(b); ok {
+			return at.Compare(bt)
+		}
+	}
+	return strings.Compare(a.String(), b.String())
+}
+
+// sortTime returns the time held by v as a time.Time or *time.Time.
+func sortTime(v *pongo2.Value) (time.Time, bool) {
+	switch t := v.Interface().(type) {
+	case time.Time:
+		return t, true
+	case *time.Time:
+		if t != nil {
+			return *t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// filterBatch splits a slice into rows of size items. An optional fill
+// value after a comma pads the last row to full size; without one the last
+// row is just shorter.
+// Usage: {% for row in photos|batch:3 %} or {% for row in photos|batch:"3,empty" %}
+func filterBatch(in, param *pongo2.Value) (*pongo2.Value, *pongo2.Error) {
+	size, fill, hasFill, err := parseChunkParam("filter:batch", param)
+	if err != nil {
+		return nil, err
+	}
+	if !in.CanSlice() {
+		return pongo2.AsValue([][]interface{}{}), nil
+	}
+
+	length := in.Len()
+	rows := make([][]interface{}, 0, (length+size-1)/size)
+	for start := 0; start < length; start += size {
+		row := make([]interface{}, 0, size)
+		for i := start; i < start+size && i < length; i++ {
+			row = append(row, in.Index(i).Interface())
+		}
+		for hasFill && len(row) < size {
+			row = append(row, fill)
+		}
+		rows = append(rows, row)
+	}
+	return pongo2.AsValue(rows), nil
+}
+
+// filterSlice distributes a slice into a number of columns, filling them
+// in order so the first columns get one extra item when the items do not
+// divide evenly. An optional fill value after a comma pads the shorter
+// columns. A "from:to" parameter keeps the built-in slicing behavior.
+// Usage: {% for column in posts|slice:3 %} or {{ tags|slice:":3" }}
+func filterSlice(in, param *pongo2.Value) (*pongo2.Value, *pongo2.Error) {
+	if !param.IsInteger() && strings.Contains(param.String(), ":") {
+		return sliceRange(in, param.String()), nil
+	}
+	columns, fill, hasFill, err := parseChunkParam("filter:slice", param)
+	if err != nil {
+		return nil, err
+	}
+	if !in.CanSlice() {
+		return pongo2.AsValue([][]interface{}{}), nil
+	}
+
+	length := in.Len()
+	perColumn, extra := length/columns, length%columns
+	result := make([][]interface{}, 0, columns)
+	offset := 0
+	for c := 0; c < columns; c++ {
+		size := perColumn
+		if c < extra {
+			size++
+		}
+		column := make([]interface{}, 0, perColumn+1)
+		for i := offset; i < offset+size; i++ {
+			column = append(column, in.Index(i).Interface())
+		}
+		offset += size
+		if hasFill && extra > 0 && c >= extra {
+			column = append(column, fill)
+		}
+		result = append(result, column)
+	}
+	return pongo2.AsValue(result), nil
+}
+
+// sliceRange applies a Django-style "from:to" slice, where either bound may
+// be omitted or negative.
+func sliceRange(in *pongo2.Value, spec string) *pongo2.Value {
+	if !in.CanSlice() {
+		return in
+	}
+	from, to, _ := strings.Cut(spec, ":")
+	length := in.Len()
+	bound := func(s string, def int) int {
+		s = strings.TrimSpace(s)
+		if s == "" {
+			return def
+		}
+		n, err := strconv.Atoi(s)
+		if err != nil {
+			return def
+		}
+		if n < 0 {
+			n += length
+		}
+		return max(0, min(n, length))
+	}
+	start, end := bound(from, 0), bound(to, length)
+	if end < start {
+		end = start
+	}
+	return in.Slice(start, end)
+}
+
+// parseChunkParam parses the "count" or "count,fill" parameter of batch
+// and slice. The count must be a positive integer.
+func parseChunkParam(sender string, param *pongo2.Value) (n int, fill interface{}, hasFill bool, perr *pongo2.Error) {
+	if param.IsInteger() {
+		n = param.Integer()
+	} else {
+		countStr, fillStr, found := strings.Cut(param.String(), ",")
+		count, err := strconv.Atoi(strings.TrimSpace(countStr))
+		if err != nil {
+			return 0, nil, false, &pongo2.Error{Sender: sender, OrigError: fmt.Errorf("invalid count %q", countStr)}
+		}
+		n, fill, hasFill = count, fillStr, found
+	}
+	if n <= 0 {
+		return 0, nil, false, &pongo2.Error{Sender: sender, OrigError: fmt.Errorf("count must be positive, got %d", n)}
+	}
+	return n, fill, hasFill, nil
+}
+
 // filterSelectAttr filters a slice of maps/structs to only include items
 // where the specified attribute equals the given value.
 // Usage: {{ items|selectattr:"key:value" }}
@@ -554,6 +1017,164 @@ func filterRejectAttr(in, param *pongo2.Value) (*pongo2.Value, *pongo2.Error) {
 	return pongo2.AsValue(result), nil
 }
 
+// DateGroup is a bucket of posts sharing the same date key, as returned by
+// the groupby_date filter.
+type DateGroup struct {
+	// Key is the formatted date ("2024", "2024-01", "2024-01-15") or
+	// UnknownDateGroupKey for posts without a parseable date.
+	Key string
+
+	// Posts holds the group's posts in their incoming order.
+	Posts []interface{}
+}
+
+// UnknownDateGroupKey is the key of the trailing group that collects posts
+// whose date is missing or cannot be parsed.
+const UnknownDateGroupKey = "unknown"
+
+// dateGroupLayouts maps groupby_date granularities to their key layouts.
+var dateGroupLayouts = map[string]string{
+	"year":  "2006",
+	"month": "2006-01",
+	"day":   "2006-01-02",
+}
+
+// filterGroupByDate groups a slice of posts by year, month, or day.
+// Groups appear in the order their first post is encountered, and posts keep
+// their incoming order within each group. Posts without a parseable date are
+// collected into a trailing "unknown" group.
+// Usage: {% for group in posts|groupby_date:"year" %}{{ group.Key }}{% endfor %}
+func filterGroupByDate(in, param *pongo2.Value) (*pongo2.Value, *pongo2.Error) {
+	if !in.CanSlice() {
+		return pongo2.AsValue([]DateGroup{}), nil
+	}
+
+	granularity := "year"
+	if param != nil && !param.IsNil() && param.String() != "" {
+		granularity = strings.ToLower(strings.TrimSpace(param.String()))
+	}
+	layout, ok := dateGroupLayouts[granularity]
+	if !ok {
+		return nil, &pongo2.Error{
+			Sender:    "filter:groupby_date",
+			OrigError: fmt.Errorf("unknown granularity %q (expected year, month, or day)", granularity),
+		}
+	}
+
+	groups := []DateGroup{}
+	index := make(map[string]int)
+	var unknown []interface{}
+
+	for i := 0; i < in.Len(); i++ {
+		item := in.Index(i)
+		t, found := itemDate(item)
+		if !found {
+			unknown = append(unknown, item.Interface())
+			continue
+		}
+
+		key := t.Format(layout)
+		idx, exists := index[key]
+		if !exists {
+			idx = len(groups)
+			index[key] = idx
+			groups = append(groups, DateGroup{Key: key})
+		}
+		groups[idx].Posts = append(groups[idx].Posts, item.Interface())
+	}
+
+	if len(unknown) > 0 {
+		groups = append(groups, DateGroup{Key: UnknownDateGroupKey, Posts: unknown})
+	}
+
+	return pongo2.AsValue(groups), nil
+}
+
+// ConsecutiveGroup is a run of adjacent items sharing a field value, as
+// returned by the group_consecutive filter.
+type ConsecutiveGroup struct {
+	// Key is the shared field value as a string, or "" for items without
+	// the field.
+	Key string
+
+	// Items holds the run's items in their incoming order.
+	Items []interface{}
+}
+
+// filterGroupConsecutive splits a slice into runs of adjacent items with
+// the same value for a field. Unlike groupby_date, a value that comes back
+// after a different one starts a new group, so timelines keep their order.
+// Items without the field form runs of their own with an empty Key.
+// Usage: {% for run in posts|group_consecutive:"author" %}{{ run.Key }}{% endfor %}
+func filterGroupConsecutive(in, param *pongo2.Value) (*pongo2.Value, *pongo2.Error) {
+	if !in.CanSlice() {
+		return pongo2.AsValue([]ConsecutiveGroup{}), nil
+	}
+
+	field := strings.TrimSpace(param.String())
+	if field == "" {
+		return nil, &pongo2.Error{
+			Sender:    "filter:group_consecutive",
+			OrigError: fmt.Errorf("field name is required"),
+		}
+	}
+
+	groups := []ConsecutiveGroup{}
+	var lastKey string
+	var lastMissing bool
+	for i := 0; i < in.Len(); i++ {
+		item := in.Index(i)
+		key := ""
+		attr := getAttr(item, field)
+		missing := attr == nil || attr.IsNil()
+		if !missing {
+			key = attr.String()
+		}
+
+		if len(groups) == 0 || key != lastKey || missing != lastMissing {
+			groups = append(groups, ConsecutiveGroup{Key: key})
+			lastKey, lastMissing = key, missing
+		}
+		last := &groups[len(groups)-1]
+		last.Items = append(last.Items, item.Interface())
+	}
+
+	return pongo2.AsValue(groups), nil
+}
+
+// itemDate extracts the date from a post, post map, or struct value.
+func itemDate(item *pongo2.Value) (time.Time, bool) {
+	if item == nil || item.IsNil() {
+		return time.Time{}, false
+	}
+
+	switch post := item.Interface().(type) {
+	case *models.Post:
+		if post != nil && post.Date != nil {
+			return *post.Date, true
+		}
+		return time.Time{}, false
+	case models.Post:
+		if post.Date != nil {
+			return *post.Date, true
+		}
+		return time.Time{}, false
+	}
+
+	dateVal := getAttr(item, "date")
+	if dateVal == nil {
+		dateVal = getAttr(item, "Date")
+	}
+	if dateVal == nil {
+		return time.Time{}, false
+	}
+	t, err := toTime(dateVal)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return t, true
+}
+
 // getAttr gets an attribute from a pongo2.Value (works with maps and structs).
 func getAttr(v *pongo2.Value, key string) *pongo2.Value {
 	// Get the underlying interface
@@ -683,6 +1304,27 @@ func filterAbsoluteURL(in, param *pongo2.Value) (*pongo2.Value, *pongo2.Error) {
 	return pongo2.AsValue(baseURL + path), nil
 }
 
+// filterAbsoluteURLs rewrites relative href, src, and srcset attributes in an
+// HTML fragment into absolute URLs. The base URL defaults to the site URL set
+// via SetSiteURL and can be overridden with the parameter.
+// Usage: {{ body | absolute_urls }} or {{ body | absolute_urls:config.url }}
+func filterAbsoluteURLs(in, param *pongo2.Value) (*pongo2.Value, *pongo2.Error) {
+	baseURL := ""
+	if param != nil && !param.IsNil() {
+		baseURL = param.String()
+	}
+	if baseURL == "" {
+		baseURL = GetSiteURL()
+	}
+
+	content := in.String()
+	if baseURL == "" || content == "" {
+		return pongo2.AsSafeValue(content), nil
+	}
+
+	return pongo2.AsSafeValue(AbsoluteURLs(content, baseURL)), nil
+}
+
 // filterDomain extracts the hostname from a URL string.
 // Usage: {{ "https://htmx.org/examples/foo/" | domain }} => "htmx.org"
 func filterDomain(in, _ *pongo2.Value) (*pongo2.Value, *pongo2.Error) {
@@ -906,6 +1548,132 @@ func filterReadingTime(in, _ *pongo2.Value) (*pongo2.Value, *pongo2.Error) {
 	return pongo2.AsValue(fmt.Sprintf("%d min read", minutes)), nil
 }
 
+// defaultReadTimeWPM is the read_time filters' default reading speed.
+const defaultReadTimeWPM = 200
+
+// filterReadTime estimates minutes to read HTML or text content, rounded up
+// with a minimum of 1. The optional parameter sets words per minute
+// (default 200): {{ body|read_time }} or {{ body|read_time:265 }}.
+func filterReadTime(in, param *pongo2.Value) (*pongo2.Value, *pongo2.Error) {
+	minutes, err := readTimeMinutes(in, param, "filter:read_time")
+	if err != nil {
+		return nil, err
+	}
+	return pongo2.AsValue(minutes), nil
+}
+
+// filterReadTimeHuman is like filterReadTime but returns "N min read".
+func filterReadTimeHuman(in, param *pongo2.Value) (*pongo2.Value, *pongo2.Error) {
+	minutes, err := readTimeMinutes(in, param, "filter:read_time_human")
+	if err != nil {
+		return nil, err
+	}
+	return pongo2.AsValue(fmt.Sprintf("%d min read", minutes)), nil
+}
+
+// fileSizeUnits are the unit labels above bytes for each filesizeformat
+// unit system, with the step between them.
+var fileSizeUnits = map[string]struct {
+	base  float64
+	units []string
+}{
+	"decimal": {1000, []string{"KB", "MB", "GB", "TB", "PB"}},
+	"binary":  {1024, []string{"KiB", "MiB", "GiB", "TiB", "PiB"}},
+}
+
+// filterFileSizeFormat formats a byte count as a human-readable size, such
+// as "900 bytes", "340 KB", or "1.2 MB". The input may be a number or a
+// numeric string; anything else counts as zero. The optional argument picks
+// the unit system: "decimal" (default, powers of 1000 like Django) or
+// "binary" (powers of 1024 with KiB, MiB, ...). Values past the largest
+// unit stay in PB/PiB.
+// Usage: {{ attachment.size|filesizeformat }} or {{ size|filesizeformat:"binary" }}
+func filterFileSizeFormat(in, param *pongo2.Value) (*pongo2.Value, *pongo2.Error) {
+	system := "decimal"
+	if param != nil && !param.IsNil() && param.String() != "" {
+		system = strings.ToLower(param.String())
+	}
+	sizes, ok := fileSizeUnits[system]
+	if !ok {
+		return nil, &pongo2.Error{
+			Sender:    "filter:filesizeformat",
+			OrigError: fmt.Errorf("unit system must be \"decimal\" or \"binary\", got %q", param.String()),
+		}
+	}
+
+	var size float64
+	switch {
+	case in.IsNumber():
+		size = in.Float()
+	case in.IsString():
+		if f, err := strconv.ParseFloat(strings.TrimSpace(in.String()), 64); err == nil {
+			size = f
+		}
+	}
+	if math.IsNaN(size) || math.IsInf(size, 0) {
+		size = 0
+	}
+
+	sign := ""
+	if size < 0 {
+		sign, size = "-", -size
+	}
+
+	if size < sizes.base {
+		n := int64(size)
+		if n == 1 {
+			return pongo2.AsValue(sign + "1 byte"), nil
+		}
+		return pongo2.AsValue(fmt.Sprintf("%s%d bytes", sign, n)), nil
+	}
+
+	unit := -1
+	for unit < len(sizes.units)-1 && size >= sizes.base {
+		size /= sizes.base
+		unit++
+	}
+	// 999.96 KB rounds to "1000 KB"; show it as "1 MB" instead
+	if math.Round(size*10)/10 >= sizes.base && unit < len(sizes.units)-1 {
+		size /= sizes.base
+		unit++
+	}
+
+	number := strings.TrimSuffix(strconv.FormatFloat(size, 'f', 1, 64), ".0")
+	return pongo2.AsValue(sign + number + " " + sizes.units[unit]), nil
+}
+
+// readTimeMinutes strips tags with striptags and counts words at the given
+// words per minute. Only tokens with a letter or digit count as words, so
+// code punctuation such as "{", "=>", or "});" doesn't inflate the estimate.
+func readTimeMinutes(in, param *pongo2.Value, sender string) (int, *pongo2.Error) {
+	wpm := defaultReadTimeWPM
+	if param != nil && !param.IsNil() {
+		if !param.IsNumber() || param.Integer() <= 0 {
+			return 0, &pongo2.Error{
+				Sender:    sender,
+				OrigError: fmt.Errorf("words per minute must be a positive number, got %q", param.String()),
+			}
+		}
+		wpm = param.Integer()
+	}
+
+	// Space out tags so adjacent blocks like "</p><p>" don't join words
+	text, _ := filterStripTags(pongo2.AsValue(htmlTagRe.ReplaceAllString(in.String(), " $0")), nil)
+
+	words := 0
+	for _, field := range strings.Fields(text.String()) {
+		if strings.IndexFunc(field, func(r rune) bool { return unicode.IsLetter(r) || unicode.IsDigit(r) }) >= 0 {
+			words++
+		}
+	}
+
+	minutes := (words + wpm - 1) / wpm // Round up
+	if minutes < 1 {
+		minutes = 1
+	}
+	return minutes, nil
+}
+
 // excerptConfig holds configuration for excerpt extraction.
 type excerptConfig struct {
 	maxParagraphs int
@@ -1041,7 +1809,22 @@ func collectParagraphs(html string, cfg excerptConfig) []string {
 // Usage: {{ post.article_html|excerpt:"paragraphs=3" }}
 // Usage: {{ post.article_html|excerpt:"chars=500" }}
 // Usage: {{ post.article_html|excerpt:"paragraphs=2,chars=800" }}
+//
+// Given a post instead of HTML, it returns post.Excerpt as plain text using
+// the strategy and optional length in param ("description", "first_paragraph",
+// or "truncate"), falling back through the other strategies when empty.
+// Usage: {{ post|excerpt }}
+// Usage: {{ post|excerpt:"first_paragraph" }}
+// Usage: {{ post|excerpt:"truncate,160" }}
 func filterExcerpt(in, param *pongo2.Value) (*pongo2.Value, *pongo2.Error) {
+	if post := excerptPostInput(in); post != nil {
+		strategy, length, err := parsePostExcerptParam(param)
+		if err != nil {
+			return nil, &pongo2.Error{Sender: "filter:excerpt", OrigError: err}
+		}
+		return pongo2.AsValue(post.Excerpt(strategy, length)), nil
+	}
+
 	html := in.String()
 	if html == "" {
 		return pongo2.AsValue(""), nil
@@ -1269,6 +2052,64 @@ func filterString(in, _ *pongo2.Value) (*pongo2.Value, *pongo2.Error) {
 	return pongo2.AsValue(in.String()), nil
 }
 
+// filterToJSON serializes a value as JSON that is safe to embed in HTML,
+// like Jinja2's tojson. An optional parameter sets the indent width.
+// Usage: {{ post.tags|to_json }} or {{ config|to_json:2 }}
+func filterToJSON(in, param *pongo2.Value) (*pongo2.Value, *pongo2.Error) {
+	indent := 0
+	if param != nil && param.IsInteger() && param.Integer() > 0 {
+		indent = param.Integer()
+	}
+	data, err := marshalJSON(in.Interface(), indent)
+	if err != nil {
+		return nil, &pongo2.Error{
+			Sender:    "filter:to_json",
+			OrigError: err,
+		}
+	}
+
+	// encoding/json already escapes <, > and &; escape ' too so the output
+	// is safe inside single-quoted attributes
+	return pongo2.AsSafeValue(strings.ReplaceAll(string(data), "'", `\u0027`)), nil
+}
+
+// filterJSONScript serializes a value as JSON for a
+// <script type="application/json"> element. <, > and & are escaped as
+// \u003c, \u003e and \u0026, so strings such as "</script>" can't close the
+// element. Output is compact unless the parameter is "pretty".
+// Usage: <script type="application/json" id="post-data">{{ post|json_script }}</script>
+func filterJSONScript(in, param *pongo2.Value) (*pongo2.Value, *pongo2.Error) {
+	indent := 0
+	if param != nil && !param.IsNil() {
+		if param.String() != "pretty" {
+			return nil, &pongo2.Error{
+				Sender:    "filter:json_script",
+				OrigError: fmt.Errorf("unknown option %q (expected \"pretty\")", param.String()),
+			}
+		}
+		indent = 2
+	}
+
+	data, err := marshalJSON(in.Interface(), indent)
+	if err != nil {
+		return nil, &pongo2.Error{
+			Sender:    "filter:json_script",
+			OrigError: err,
+		}
+	}
+	return pongo2.AsSafeValue(string(data)), nil
+}
+
+// marshalJSON encodes v as compact JSON, or indented by indent spaces.
+// encoding/json escapes <, >, & and U+2028/U+2029, keeping the output safe
+// to embed in HTML.
+func marshalJSON(v interface{}, indent int) ([]byte, error) {
+	if indent > 0 {
+		return json.MarshalIndent(v, "", strings.Repeat(" ", indent))
+	}
+	return json.Marshal(v)
+}
+
 // filterIsVideo returns true if the input string has a video file extension.
 // Usage: {% if post.image|is_video %}...{% endif %}
 func filterIsVideo(in, _ *pongo2.Value) (*pongo2.Value, *pongo2.Error) {
@@ -1338,6 +2179,50 @@ func filterPosterURL(in, param *pongo2.Value) (*pongo2.Value, *pongo2.Error) {
 	return pongo2.AsValue(""), nil
 }
 
+// excerptPostInput returns the post behind a *models.Post or post map input,
+// or nil when the input is not a post.
+func excerptPostInput(in *pongo2.Value) *models.Post {
+	if in == nil || in.IsNil() {
+		return nil
+	}
+	switch v := in.Interface().(type) {
+	case *models.Post:
+		return v
+	case map[string]interface{}:
+		if _, ok := v["article_html"]; !ok {
+			return nil
+		}
+		post := &models.Post{}
+		post.ArticleHTML, _ = v["article_html"].(string)
+		if desc, ok := v["description"].(string); ok {
+			post.Description = &desc
+		}
+		return post
+	}
+	return nil
+}
+
+// parsePostExcerptParam parses "strategy" or "strategy,length" for the
+// post form of the excerpt filter.
+func parsePostExcerptParam(param *pongo2.Value) (strategy string, length int, err error) {
+	if param == nil || param.IsNil() || param.String() == "" {
+		return "", 0, nil
+	}
+	strategy, lengthStr, hasLength := strings.Cut(param.String(), ",")
+	strategy = strings.TrimSpace(strategy)
+	if !models.IsExcerptStrategy(strategy) {
+		return "", 0, fmt.Errorf("unknown excerpt strategy %q (valid strategies: %s, %s, %s)",
+			strategy, models.ExcerptDescription, models.ExcerptFirstParagraph, models.ExcerptTruncate)
+	}
+	if hasLength {
+		length, err = strconv.Atoi(strings.TrimSpace(lengthStr))
+		if err != nil || length <= 0 {
+			return "", 0, fmt.Errorf("invalid excerpt length %q", lengthStr)
+		}
+	}
+	return strategy, length, nil
+}
+
 func valueToStringMap(v *pongo2.Value) map[string]interface{} {
 	if v == nil || v.IsNil() {
 		return nil
@@ -1382,32 +2267,7 @@ func filterContributionData(in, param *pongo2.Value) (*pongo2.Value, *pongo2.Err
 			continue
 		}
 
-		// Try to get the date from the post
-		var postDate time.Time
-		var found bool
-
-		// Try different ways to access the date
-		iface := item.Interface()
-		if post, ok := iface.(*models.Post); ok && post != nil && post.Date != nil {
-			postDate = *post.Date
-			found = true
-		} else if post, ok := iface.(models.Post); ok && post.Date != nil {
-			postDate = *post.Date
-			found = true
-		} else {
-			// Try to get date field from map or struct (try both lowercase and capitalized)
-			dateVal := getAttr(item, "date")
-			if dateVal == nil {
-				dateVal = getAttr(item, "Date")
-			}
-			if dateVal != nil {
-				if t, err := toTime(dateVal); err == nil {
-					postDate = t
-					found = true
-				}
-			}
-		}
-
+		postDate, found := itemDate(item)
 		if !found {
 			continue
 		}
@@ -1,11 +1,15 @@
 package templates
 
 import (
+	"encoding/json"
+	"fmt"
 	"os"
+	"reflect"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/WaylonWalker/markata-go/pkg/assets"
 	"github.com/WaylonWalker/markata-go/pkg/models"
 
 	"github.com/flosch/pongo2/v6"
@@ -113,6 +117,86 @@ func TestFilterHumanDate(t *testing.T) {
 	}
 }
 
+func TestTimesince(t *testing.T) {
+	now := time.Date(2024, 6, 15, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		date    time.Time
+		maxDays int
+		want    string
+	}{
+		{"just now", now.Add(-30 * time.Second), 365, "just now"},
+		{"one minute", now.Add(-time.Minute), 365, "1 minute ago"},
+		{"minutes", now.Add(-5 * time.Minute), 365, "5 minutes ago"},
+		{"hours", now.Add(-3 * time.Hour), 365, "3 hours ago"},
+		{"one day", now.Add(-24 * time.Hour), 365, "1 day ago"},
+		{"days", now.AddDate(0, 0, -3), 365, "3 days ago"},
+		{"months", now.AddDate(0, 0, -75), 365, "2 months ago"},
+		{"older than threshold", now.AddDate(-2, 0, 0), 365, "Jun 15, 2022"},
+		{"years without threshold", now.AddDate(-2, 0, 0), 0, "2 years ago"},
+		{"future", now.Add(2 * time.Hour), 365, "in 2 hours"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Timesince(tt.date, now, tt.maxDays); got != tt.want {
+				t.Errorf("Timesince() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFilterTimesince(t *testing.T) {
+	engine, err := NewEngine("")
+	if err != nil {
+		t.Fatalf("Failed to create engine: %v", err)
+	}
+
+	date := time.Date(2024, 6, 12, 12, 0, 0, 0, time.UTC)
+	post := &models.Post{Path: "post.md", Date: &date}
+	ctx := NewContext(post, "", nil)
+	ctx.Set("now", time.Date(2024, 6, 15, 12, 0, 0, 0, time.UTC))
+
+	tests := []struct {
+		template string
+		want     string
+	}{
+		{`{{ post.date|timesince:now }}`, "3 days ago"},
+		{`{{ post|timesince:now }}`, "3 days ago"},
+		{`{{ "2024-06-15T11:55:00Z"|timesince:now }}`, "5 minutes ago"},
+		{`{{ "2024-06-10"|timesince:"2024-06-15" }}`, "5 days ago"},
+		{`{{ "2020-01-01"|timesince:"2024-06-15" }}`, "Jan 1, 2020"},
+		{`{{ "not a date"|timesince:now }}`, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.template, func(t *testing.T) {
+			result, err := engine.RenderString(tt.template, ctx)
+			if err != nil {
+				t.Fatalf("RenderString() error: %v", err)
+			}
+			if result != tt.want {
+				t.Errorf("got %q, want %q", result, tt.want)
+			}
+		})
+	}
+
+	SetTimesinceMaxDays(0)
+	t.Cleanup(func() { SetTimesinceMaxDays(models.DefaultTimesinceMaxDays) })
+	result, err := engine.RenderString(`{{ "2020-01-01"|timesince:"2024-06-15" }}`, ctx)
+	if err != nil {
+		t.Fatalf("RenderString() error: %v", err)
+	}
+	if result != "4 years ago" {
+		t.Errorf("without a threshold got %q, want %q", result, "4 years ago")
+	}
+
+	if _, err := engine.RenderString(`{{ post.date|timesince:"soon" }}`, ctx); err == nil {
+		t.Error("expected an error for an invalid reference time")
+	}
+}
+
 func TestTemplateTrees_UseHumanDateForVisibleHTMLDates(t *testing.T) {
 	files := []string{
 		"../../templates/components/post_byline.html",
@@ -762,6 +846,60 @@ func TestFilterExcerpt_StripsBrokenPreTags(t *testing.T) {
 	}
 }
 
+func TestFilterExcerpt_Post(t *testing.T) {
+	engine, err := NewEngine("")
+	if err != nil {
+		t.Fatalf("Failed to create engine: %v", err)
+	}
+
+	desc := "Frontmatter summary."
+	post := &models.Post{
+		Path:        "post.md",
+		Slug:        "post",
+		Description: &desc,
+		ArticleHTML: "<p>First paragraph of the body.</p><p>Second paragraph.</p>",
+	}
+
+	tests := []struct {
+		tmpl string
+		want string
+	}{
+		{"{{ post.excerpt }}", "Frontmatter summary."},
+		{"{{ post|excerpt }}", "Frontmatter summary."},
+		{`{{ post|excerpt:"first_paragraph" }}`, "First paragraph of the body."},
+		{`{{ post|excerpt:"truncate,20" }}`, "First paragraph of..."},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.tmpl, func(t *testing.T) {
+			ctx := NewContext(post, "", nil)
+			result, err := engine.RenderString(tt.tmpl, ctx)
+			if err != nil {
+				t.Fatalf("RenderString() error: %v", err)
+			}
+			if result != tt.want {
+				t.Errorf("got %q, want %q", result, tt.want)
+			}
+		})
+	}
+
+	ctx := NewContext(post, "", nil)
+	if _, err := engine.RenderString(`{{ post|excerpt:"bogus" }}`, ctx); err == nil {
+		t.Error("expected an error for an unknown strategy")
+	}
+}
+
+func TestPostExcerpt_FrontmatterTakesPrecedence(t *testing.T) {
+	post := &models.Post{
+		Path:        "post.md",
+		ArticleHTML: "<p>Body.</p>",
+		Extra:       map[string]interface{}{"excerpt": "Hand-written excerpt."},
+	}
+	if got := postToMapUncached(post)["excerpt"]; got != "Hand-written excerpt." {
+		t.Errorf("excerpt = %v, want the frontmatter value", got)
+	}
+}
+
 func contains(s, substr string) bool {
 	return len(s) >= len(substr) && (s == substr || len(s) > len(substr) && (s[:len(substr)] == substr || s[len(s)-len(substr):] == substr || stringContains(s, substr)))
 }
@@ -970,3 +1108,826 @@ func TestFilterPosterURLAliasAndFallback(t *testing.T) {
 		t.Errorf("poster_url should derive .webp when no alias exists, got %q", fallback)
 	}
 }
+
+func TestFilterAbsoluteURLs(t *testing.T) {
+	engine, err := NewEngine("")
+	if err != nil {
+		t.Fatalf("Failed to create engine: %v", err)
+	}
+
+	config := &models.Config{URL: "https://example.com/"}
+
+	tests := []struct {
+		name     string
+		body     string
+		expected string
+	}{
+		{
+			name:     "root-relative href",
+			body:     `<a href="/posts/one/">one</a>`,
+			expected: `<a href="https://example.com/posts/one/">one</a>`,
+		},
+		{
+			name:     "document-relative src",
+			body:     `<img src="images/cat.png" alt="cat">`,
+			expected: `<img src="https://example.com/images/cat.png" alt="cat">`,
+		},
+		{
+			name:     "srcset candidates",
+			body:     `<img srcset="/a.png 1x, b.png 2x, https://cdn.example.net/c.png 3x">`,
+			expected: `<img srcset="https://example.com/a.png 1x, https://example.com/b.png 2x, https://cdn.example.net/c.png 3x">`,
+		},
+		{
+			name:     "absolute, protocol-relative, anchor, and mailto untouched",
+			body:     `<a href="https://other.com/x">x</a><a href="//cdn.com/y">y</a><a href="#top">top</a><a href="mailto:a@b.c">m</a>`,
+			expected: `<a href="https://other.com/x">x</a><a href="//cdn.com/y">y</a><a href="#top">top</a><a href="mailto:a@b.c">m</a>`,
+		},
+		{
+			name:     "single and unquoted values keep attribute order",
+			body:     `<a class='x' href='/a' title=t>a</a><img src=/b.png alt=b>`,
+			expected: `<a class='x' href='https://example.com/a' title=t>a</a><img src="https://example.com/b.png" alt=b>`,
+		},
+		{
+			name:     "inline svg is preserved",
+			body:     `<svg viewBox="0 0 10 10"><use href="#icon"/><image href="/i.png" preserveAspectRatio="none"/></svg>`,
+			expected: `<svg viewBox="0 0 10 10"><use href="#icon"/><image href="https://example.com/i.png" preserveAspectRatio="none"/></svg>`,
+		},
+		{
+			name:     "attribute-like text in other values is ignored",
+			body:     `<a title="see src=/x" href="/y">y</a>`,
+			expected: `<a title="see src=/x" href="https://example.com/y">y</a>`,
+		},
+		{
+			name:     "parent-relative paths resolve under the site",
+			body:     `<img src="../img/cat.png"><a href="./a/../b/">b</a>`,
+			expected: `<img src="https://example.com/img/cat.png"><a href="https://example.com/b/">b</a>`,
+		},
+		{
+			name:     "query-only urls keep their query",
+			body:     `<a href="?page=2#list">next</a>`,
+			expected: `<a href="https://example.com/?page=2#list">next</a>`,
+		},
+		{
+			name:     "srcset keeps commas inside urls",
+			body:     `<img srcset="data:image/png;base64,iVBORw0KGgo= 1x, /a,b.png 2x,/c.png">`,
+			expected: `<img srcset="data:image/png;base64,iVBORw0KGgo= 1x, https://example.com/a,b.png 2x, https://example.com/c.png">`,
+		},
+		{
+			name:     "script content is untouched",
+			body:     `<script>var s = '<a href="/z">';</script>`,
+			expected: `<script>var s = '<a href="/z">';</script>`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := NewContext(nil, tt.body, config)
+			result, err := engine.RenderString("{{ body | absolute_urls:config.url }}", ctx)
+			if err != nil {
+				t.Fatalf("RenderString() error: %v", err)
+			}
+			if result != tt.expected {
+				t.Errorf("got %q, want %q", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestAbsoluteURLs_BasePath(t *testing.T) {
+	body := `<a href="/about/">about</a><img src="../cat.png">`
+	expected := `<a href="https://example.com/blog/about/">about</a><img src="https://example.com/cat.png">`
+	if got := AbsoluteURLs(body, "https://example.com/blog"); got != expected {
+		t.Errorf("AbsoluteURLs() = %q, want %q", got, expected)
+	}
+}
+
+func TestFilterAbsoluteURLs_UsesSiteURL(t *testing.T) {
+	engine, err := NewEngine("")
+	if err != nil {
+		t.Fatalf("Failed to create engine: %v", err)
+	}
+
+	SetSiteURL("https://site.example")
+	defer SetSiteURL("")
+
+	ctx := NewContext(nil, `<a href="/about/">about</a>`, nil)
+
+	result, err := engine.RenderString("{{ body | absolute_urls }}", ctx)
+	if err != nil {
+		t.Fatalf("RenderString() error: %v", err)
+	}
+
+	expected := `<a href="https://site.example/about/">about</a>`
+	if result != expected {
+		t.Errorf("got %q, want %q", result, expected)
+	}
+}
+
+func TestFilterGroupByDate(t *testing.T) {
+	engine, err := NewEngine("")
+	if err != nil {
+		t.Fatalf("Failed to create engine: %v", err)
+	}
+
+	date := func(y int, m time.Month, d int) *time.Time {
+		t := time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+		return &t
+	}
+	title := func(s string) *string { return &s }
+
+	posts := []*models.Post{
+		{Title: title("a"), Date: date(2024, 3, 2)},
+		{Title: title("b"), Date: date(2024, 3, 1)},
+		{Title: title("c")},
+		{Title: title("d"), Date: date(2024, 1, 9)},
+		{Title: title("e"), Date: date(2023, 12, 31)},
+	}
+	ctx := NewContext(nil, "", nil).WithPosts(posts)
+
+	tests := []struct {
+		name     string
+		template string
+		expected string
+	}{
+		{
+			name:     "year",
+			template: `{% for g in posts|groupby_date:"year" %}{{ g.Key }}:{% for p in g.Posts %}{{ p.title }}{% endfor %};{% endfor %}`,
+			expected: "2024:abd;2023:e;unknown:c;",
+		},
+		{
+			name:     "month",
+			template: `{% for g in posts|groupby_date:"month" %}{{ g.Key }}:{% for p in g.Posts %}{{ p.title }}{% endfor %};{% endfor %}`,
+			expected: "2024-03:ab;2024-01:d;2023-12:e;unknown:c;",
+		},
+		{
+			name:     "day",
+			template: `{% for g in posts|groupby_date:"day" %}{{ g.Key }}={{ g.Posts|length }};{% endfor %}`,
+			expected: "2024-03-02=1;2024-03-01=1;2024-01-09=1;2023-12-31=1;unknown=1;",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := engine.RenderString(tt.template, ctx)
+			if err != nil {
+				t.Fatalf("RenderString() error: %v", err)
+			}
+			if result != tt.expected {
+				t.Errorf("got %q, want %q", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestFilterGroupByDate_ParsesStringDates(t *testing.T) {
+	engine, err := NewEngine("")
+	if err != nil {
+		t.Fatalf("Failed to create engine: %v", err)
+	}
+
+	ctx := NewContext(nil, "", nil)
+	ctx.Set("items", []map[string]interface{}{
+		{"date": "2024-05-01"},
+		{"date": "not a date"},
+		{"date": "Jan 2, 2023"},
+	})
+
+	result, err := engine.RenderString(`{% for g in items|groupby_date:"year" %}{{ g.Key }};{% endfor %}`, ctx)
+	if err != nil {
+		t.Fatalf("RenderString() error: %v", err)
+	}
+
+	expected := "2024;2023;unknown;"
+	if result != expected {
+		t.Errorf("got %q, want %q", result, expected)
+	}
+}
+
+func TestFilterGroupConsecutive(t *testing.T) {
+	engine, err := NewEngine("")
+	if err != nil {
+		t.Fatalf("Failed to create engine: %v", err)
+	}
+
+	ctx := NewContext(nil, "", nil)
+	ctx.Set("items", []map[string]interface{}{
+		{"title": "a", "author": "ann"},
+		{"title": "b", "author": "ann"},
+		{"title": "c", "author": "bob"},
+		{"title": "d", "author": "ann"},
+		{"title": "e"},
+		{"title": "f"},
+		{"title": "g", "author": "bob"},
+	})
+
+	result, err := engine.RenderString(`{% for run in items|group_consecutive:"author" %}{{ run.Key }}:{% for p in run.Items %}{{ p.title }}{% endfor %};{% endfor %}`, ctx)
+	if err != nil {
+		t.Fatalf("RenderString() error: %v", err)
+	}
+
+	// ann appears in two separate runs; the missing authors form their own run
+	expected := "ann:ab;bob:c;ann:d;:ef;bob:g;"
+	if result != expected {
+		t.Errorf("got %q, want %q", result, expected)
+	}
+}
+
+func TestFilterGroupConsecutive_Errors(t *testing.T) {
+	engine, err := NewEngine("")
+	if err != nil {
+		t.Fatalf("Failed to create engine: %v", err)
+	}
+
+	ctx := NewContext(nil, "", nil)
+	ctx.Set("items", []map[string]interface{}{{"title": "a"}})
+
+	if _, err := engine.RenderString(`{% for run in items|group_consecutive:"" %}{% endfor %}`, ctx); err == nil {
+		t.Error("expected an error for an empty field name")
+	}
+
+	result, err := engine.RenderString(`{{ missing|group_consecutive:"author"|length }}`, ctx)
+	if err != nil {
+		t.Fatalf("RenderString() error: %v", err)
+	}
+	if result != "0" {
+		t.Errorf("got %q, want no groups for a non-slice", result)
+	}
+}
+
+func TestFilterToJSON(t *testing.T) {
+	engine, err := NewEngine("")
+	if err != nil {
+		t.Fatalf("Failed to create engine: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		template string
+		input    interface{}
+		expected string
+	}{
+		{"list", "{{ input|to_json }}", []string{"go", "web"}, `["go","web"]`},
+		{"html escaped", "{{ input|to_json }}", "</script><a href='x'>", `"\u003c/script\u003e\u003ca href=\u0027x\u0027\u003e"`},
+		{"indent", "{{ input|to_json:2 }}", map[string]int{"a": 1}, "{\n  \"a\": 1\n}"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := NewContext(nil, "", nil)
+			ctx.Set("input", tt.input)
+			result, err := engine.RenderString(tt.template, ctx)
+			if err != nil {
+				t.Fatalf("RenderString() error: %v", err)
+			}
+			if result != tt.expected {
+				t.Errorf("to_json: got %q, want %q", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestFilterJSONScript(t *testing.T) {
+	engine, err := NewEngine("")
+	if err != nil {
+		t.Fatalf("Failed to create engine: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		template string
+		input    interface{}
+		expected string
+	}{
+		{"map", "{{ input|json_script }}", map[string]interface{}{"a": 1, "b": []string{"x"}}, `{"a":1,"b":["x"]}`},
+		{"slice", "{{ input|json_script }}", []int{1, 2}, `[1,2]`},
+		{"html escaped", "{{ input|json_script }}", "a & <b>", `"a \u0026 \u003cb\u003e"`},
+		{"pretty", `{{ input|json_script:"pretty" }}`, map[string]int{"a": 1}, "{\n  \"a\": 1\n}"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := NewContext(nil, "", nil)
+			ctx.Set("input", tt.input)
+			result, err := engine.RenderString(tt.template, ctx)
+			if err != nil {
+				t.Fatalf("RenderString() error: %v", err)
+			}
+			if result != tt.expected {
+				t.Errorf("json_script: got %q, want %q", result, tt.expected)
+			}
+		})
+	}
+
+	ctx := NewContext(nil, "", nil)
+	ctx.Set("input", "x")
+	if _, err := engine.RenderString(`{{ input|json_script:"fancy" }}`, ctx); err == nil {
+		t.Error("expected error for unknown option")
+	}
+}
+
+func TestFilterJSONScript_ScriptInjection(t *testing.T) {
+	engine, err := NewEngine("")
+	if err != nil {
+		t.Fatalf("Failed to create engine: %v", err)
+	}
+
+	payload := `</script><script>alert("x")</script>`
+	ctx := NewContext(nil, "", nil)
+	ctx.Set("data", map[string]string{"bio": payload})
+
+	got, err := engine.RenderString(`<script type="application/json" id="d">{{ data|json_script }}</script>`, ctx)
+	if err != nil {
+		t.Fatalf("RenderString() error: %v", err)
+	}
+
+	if n := strings.Count(strings.ToLower(got), "</script"); n != 1 {
+		t.Fatalf("output has %d closing script tags, want only the template's own:\n%s", n, got)
+	}
+	inner := strings.TrimSuffix(strings.TrimPrefix(got, `<script type="application/json" id="d">`), "</script>")
+	var decoded map[string]string
+	if err := json.Unmarshal([]byte(inner), &decoded); err != nil {
+		t.Fatalf("embedded JSON does not parse: %v\n%s", err, inner)
+	}
+	if decoded["bio"] != payload {
+		t.Errorf("round trip = %q, want %q", decoded["bio"], payload)
+	}
+}
+
+func TestFilterJSONScript_Post(t *testing.T) {
+	engine, err := NewEngine("")
+	if err != nil {
+		t.Fatalf("Failed to create engine: %v", err)
+	}
+
+	title := "Hello </script>"
+	post := &models.Post{Title: &title, Slug: "hello", Tags: []string{"go"}}
+	got, err := engine.RenderString("{{ post|json_script }}", NewContext(post, "", nil))
+	if err != nil {
+		t.Fatalf("RenderString() error: %v", err)
+	}
+
+	var decoded map[string]interface{}
+	if err := json.Unmarshal([]byte(got), &decoded); err != nil {
+		t.Fatalf("post JSON does not parse: %v\n%s", err, got)
+	}
+	if decoded["title"] != title || decoded["slug"] != "hello" {
+		t.Errorf("post JSON missing fields: %v", decoded)
+	}
+	if strings.Contains(got, "</script>") {
+		t.Errorf("title should be escaped: %s", got)
+	}
+}
+
+func TestFilterReadTime(t *testing.T) {
+	words := func(n int) string {
+		return strings.TrimSpace(strings.Repeat("word ", n))
+	}
+	// A code block of 25 tokens where only the 5 "fn(x)" contain a letter
+	// or digit; the rest are braces and operators
+	code := "<pre><code>" + strings.Repeat("fn(x) => { }\n});\n", 5) + "</code></pre>"
+
+	tests := []struct {
+		name  string
+		input string
+		param *pongo2.Value
+		want  int
+	}{
+		{"empty content is one minute", "", nil, 1},
+		{"exactly 200 words", words(200), nil, 1},
+		{"rounds up", words(201), nil, 2},
+		{"tags are stripped", "<p>" + words(400) + "</p><p>" + words(1) + "</p>", nil, 3},
+		{"adjacent blocks don't join words", strings.Repeat("<li>a</li>", 201), nil, 2},
+		{"custom words per minute", words(530), pongo2.AsValue(265), 2},
+		// Counting every token would give 220 words and 2 minutes
+		{"code punctuation is not counted", "<p>" + words(195) + "</p>" + code, nil, 1},
+		{"code identifiers are counted", "<p>" + words(196) + "</p>" + code, nil, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := filterReadTime(pongo2.AsValue(tt.input), tt.param)
+			if err != nil {
+				t.Fatalf("filterReadTime() error: %v", err)
+			}
+			if got := result.Integer(); got != tt.want || !result.IsInteger() {
+				t.Errorf("filterReadTime() = %v, want int %d", result.Interface(), tt.want)
+			}
+		})
+	}
+
+	if _, err := filterReadTime(pongo2.AsValue("text"), pongo2.AsValue(0)); err == nil {
+		t.Error("expected error for zero words per minute")
+	}
+	if _, err := filterReadTime(pongo2.AsValue("text"), pongo2.AsValue("fast")); err == nil {
+		t.Error("expected error for non-numeric words per minute")
+	}
+}
+
+func TestFilterReadTime_InTemplate(t *testing.T) {
+	engine, err := NewEngine("")
+	if err != nil {
+		t.Fatalf("Failed to create engine: %v", err)
+	}
+	body := "<p>" + strings.Repeat("word ", 800) + "</p>"
+
+	got, err := engine.RenderString("{{ body|read_time }}|{{ body|read_time:400 }}|{{ body|read_time_human }}", NewContext(nil, body, nil))
+	if err != nil {
+		t.Fatalf("RenderString() error = %v", err)
+	}
+	if want := "4|2|4 min read"; got != want {
+		t.Errorf("RenderString() = %q, want %q", got, want)
+	}
+}
+
+func TestFilterFileSizeFormat(t *testing.T) {
+	tests := []struct {
+		name  string
+		input interface{}
+		param *pongo2.Value
+		want  string
+	}{
+		{"zero", 0, nil, "0 bytes"},
+		{"one byte", 1, nil, "1 byte"},
+		{"bytes", 900, nil, "900 bytes"},
+		{"below decimal KB", 999, nil, "999 bytes"},
+		{"decimal KB", 1000, nil, "1 KB"},
+		{"decimal 1023", 1023, nil, "1 KB"},
+		{"decimal 1024", 1024, nil, "1 KB"},
+		{"fraction", 1250, nil, "1.2 KB"},
+		{"kilobytes", 340000, nil, "340 KB"},
+		{"rounds up to next unit", 999960, nil, "1 MB"},
+		{"megabytes", 1234567, nil, "1.2 MB"},
+		{"terabytes", 3.5e12, nil, "3.5 TB"},
+		{"petabytes", 2e15, nil, "2 PB"},
+		{"past petabytes", 5e18, nil, "5000 PB"},
+		{"negative", -1500, nil, "-1.5 KB"},
+		{"negative one byte", -1, nil, "-1 byte"},
+		{"numeric string", "2048", nil, "2 KB"},
+		{"non-numeric string", "large", nil, "0 bytes"},
+		{"nil", nil, nil, "0 bytes"},
+		{"explicit decimal", 1000, pongo2.AsValue("decimal"), "1 KB"},
+		{"binary 1000", 1000, pongo2.AsValue("binary"), "1000 bytes"},
+		{"binary 1023", 1023, pongo2.AsValue("binary"), "1023 bytes"},
+		{"binary 1024", 1024, pongo2.AsValue("binary"), "1 KiB"},
+		{"binary fraction", 1536, pongo2.AsValue("binary"), "1.5 KiB"},
+		{"binary MiB", 1 << 20, pongo2.AsValue("binary"), "1 MiB"},
+		{"binary rounds up", 1048570, pongo2.AsValue("binary"), "1 MiB"},
+		{"binary PiB", int64(3) << 50, pongo2.AsValue("binary"), "3 PiB"},
+		{"binary case-insensitive", 2048, pongo2.AsValue("Binary"), "2 KiB"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := filterFileSizeFormat(pongo2.AsValue(tt.input), tt.param)
+			if err != nil {
+				t.Fatalf("filterFileSizeFormat() error: %v", err)
+			}
+			if got := result.String(); got != tt.want {
+				t.Errorf("filterFileSizeFormat(%v) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+
+	if _, err := filterFileSizeFormat(pongo2.AsValue(1024), pongo2.AsValue("metric")); err == nil {
+		t.Error("expected error for unknown unit system")
+	}
+}
+
+func TestFilterFileSizeFormat_InTemplate(t *testing.T) {
+	engine, err := NewEngine("")
+	if err != nil {
+		t.Fatalf("Failed to create engine: %v", err)
+	}
+	ctx := NewContext(nil, "", nil)
+	ctx.Set("attachment", map[string]interface{}{"size": 1200000})
+
+	got, err := engine.RenderString(`{{ attachment.size|filesizeformat }}|{{ attachment.size|filesizeformat:"binary" }}`, ctx)
+	if err != nil {
+		t.Fatalf("RenderString() error = %v", err)
+	}
+	if want := "1.2 MB|1.1 MiB"; got != want {
+		t.Errorf("RenderString() = %q, want %q", got, want)
+	}
+}
+
+func TestFilterStaticAndSRI(t *testing.T) {
+	engine, err := NewEngine("")
+	if err != nil {
+		t.Fatalf("Failed to create engine: %v", err)
+	}
+
+	SetAssetURLs(map[string]string{"htmx": "/assets/vendor/htmx/htmx.min.js"})
+	SetAssetHashes(map[string]string{"js/static-test.js": "abc12345"})
+	SetStaticPaths([]string{"images/logo.png"})
+	t.Cleanup(func() {
+		SetAssetURLs(nil)
+		SetStaticPaths(nil)
+	})
+
+	tests := []struct {
+		template string
+		want     string
+	}{
+		{`{{ "js/static-test.js"|static }}`, "/js/static-test.abc12345.js"},
+		{`{{ "/images/logo.png"|static }}`, "/images/logo.png"},
+		{`{{ "htmx"|static }}`, "/assets/vendor/htmx/htmx.min.js"},
+		{`{{ "glightbox-js"|static }}`, assets.GetAsset("glightbox-js").URL},
+		{`{{ "js/missing.js"|static }}`, "js/missing.js"},
+		{`{{ "js/static-test.js"|sri }}`, SRIPlaceholderPrefix + "js/static-test.js"},
+		{`{{ "css/missing.css"|sri }}`, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.template, func(t *testing.T) {
+			result, err := engine.RenderString(tt.template, NewContext(nil, "", nil))
+			if err != nil {
+				t.Fatalf("RenderString() error: %v", err)
+			}
+			if result != tt.want {
+				t.Errorf("got %q, want %q", result, tt.want)
+			}
+		})
+	}
+
+	if got, want := UnknownStaticRefs(), []string{"css/missing.css", "js/missing.js"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("UnknownStaticRefs() = %v, want %v", got, want)
+	}
+}
+
+func TestReplaceSRIPlaceholders(t *testing.T) {
+	html := `<script src="/a.js" integrity="` + SRIPlaceholderPrefix + `js/a.js"></script>` +
+		`<link href="/b.css" integrity='` + SRIPlaceholderPrefix + `css/b.css'>`
+	got := ReplaceSRIPlaceholders(html, func(ref string) string { return "sha384-" + ref })
+	want := `<script src="/a.js" integrity="sha384-js/a.js"></script><link href="/b.css" integrity='sha384-css/b.css'>`
+	if got != want {
+		t.Errorf("ReplaceSRIPlaceholders() =\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestFilterRegexReplace(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		param string
+		want  string
+	}{
+		{"collapse whitespace", "Hello   \t world", `\s+, `, "Hello world"},
+		{"capture groups", "2024-01-15", `(\d+)-(\d+)-(\d+),$3/$2/$1`, "15/01/2024"},
+		{"named group", "issue-42", `issue-(?P<id>\d+),#${id}`, "#42"},
+		{"no comma removes matches", "a1b2c3", `\d`, "abc"},
+		{"comma in repetition", "aaaa-b", `a{2,3},x`, "xa-b"},
+		{"comma in class", "a,b;c", `[,;],|`, "a|b|c"},
+		{"escaped comma", "a,b", `\,,+`, "a+b"},
+		{"replacement with comma", "a b", ` ,, `, "a, b"},
+		{"no match", "hello", `\d+,#`, "hello"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := filterRegexReplace(pongo2.AsValue(tt.input), pongo2.AsValue(tt.param))
+			if err != nil {
+				t.Fatalf("filterRegexReplace() error = %v", err)
+			}
+			if got := result.String(); got != tt.want {
+				t.Errorf("filterRegexReplace(%q, %q) = %q, want %q", tt.input, tt.param, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFilterRegexMatch(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		param string
+		want  string
+	}{
+		{"whole match", "release v1.2.3 notes", `v\d+\.\d+\.\d+`, "v1.2.3"},
+		{"first match only", "a1 b22 c333", `\d+`, "1"},
+		{"first capture group", "posts/issue-42", `issue-(\d+)$`, "42"},
+		{"no match", "hello", `\d+`, ""},
+		{"empty input", "", `.+`, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := filterRegexMatch(pongo2.AsValue(tt.input), pongo2.AsValue(tt.param))
+			if err != nil {
+				t.Fatalf("filterRegexMatch() error = %v", err)
+			}
+			if got := result.String(); got != tt.want {
+				t.Errorf("filterRegexMatch(%q, %q) = %q, want %q", tt.input, tt.param, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFilterRegex_InvalidPattern(t *testing.T) {
+	tests := []struct {
+		name    string
+		filter  func(in, param *pongo2.Value) (*pongo2.Value, *pongo2.Error)
+		pattern string
+	}{
+		{"replace unclosed group", filterRegexReplace, `(abc,x`},
+		{"match backreference", filterRegexMatch, `(a)\1`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.filter(pongo2.AsValue("abc"), pongo2.AsValue(tt.pattern))
+			if err == nil {
+				t.Fatal("expected error for invalid pattern")
+			}
+			if !strings.Contains(err.Error(), "invalid pattern") || !strings.Contains(err.Error(), "RE2") {
+				t.Errorf("error = %v, want invalid RE2 pattern message", err)
+			}
+		})
+	}
+}
+
+func TestFilterRegex_InTemplate(t *testing.T) {
+	engine, err := NewEngine("")
+	if err != nil {
+		t.Fatalf("Failed to create engine: %v", err)
+	}
+	ctx := NewContext(nil, "", nil)
+	ctx.Set("title", "Hello   big\tworld")
+	ctx.Set("slug", "notes/issue-42")
+
+	got, err := engine.RenderString(`{{ title|regex_replace:"\\s+, " }}|{{ slug|regex_match:"-(\\d+)$" }}|{{ slug|regex_match:"^x" }}`, ctx)
+	if err != nil {
+		t.Fatalf("RenderString() error = %v", err)
+	}
+	if want := "Hello big world|42|"; got != want {
+		t.Errorf("RenderString() = %q, want %q", got, want)
+	}
+
+	if _, err := engine.RenderString(`{{ title|regex_match:"(" }}`, ctx); err == nil {
+		t.Error("RenderString() with invalid pattern should fail")
+	}
+}
+
+func TestFilterDictSort_Map(t *testing.T) {
+	tags := map[string]interface{}{
+		"go":     map[string]interface{}{"count": 12, "meta": map[string]interface{}{"rank": 2}},
+		"python": map[string]interface{}{"count": 30, "meta": map[string]interface{}{"rank": 1}},
+		"css":    map[string]interface{}{"count": 12, "meta": map[string]interface{}{"rank": 3}},
+		"rust":   map[string]interface{}{"count": 3},
+	}
+
+	tests := []struct {
+		name     string
+		filter   func(in, param *pongo2.Value) (*pongo2.Value, *pongo2.Error)
+		param    *pongo2.Value
+		wantKeys []string
+	}{
+		{"by key", filterDictSort, pongo2.AsValue(nil), []string{"css", "go", "python", "rust"}},
+		{"by key explicitly", filterDictSort, pongo2.AsValue("key"), []string{"css", "go", "python", "rust"}},
+		{"by value field, ties by key", filterDictSort, pongo2.AsValue("count"), []string{"rust", "css", "go", "python"}},
+		{"reversed, ties by key", filterDictSortReversed, pongo2.AsValue("count"), []string{"python", "css", "go", "rust"}},
+		{"nested field, missing last", filterDictSort, pongo2.AsValue("meta.rank"), []string{"python", "go", "css", "rust"}},
+		{"reversed nested, missing last", filterDictSortReversed, pongo2.AsValue("value.meta.rank"), []string{"css", "go", "python", "rust"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.filter(pongo2.AsValue(tags), tt.param)
+			if err != nil {
+				t.Fatalf("dictsort error = %v", err)
+			}
+			entries, ok := got.Interface().([]interface{})
+			if !ok {
+				t.Fatalf("dictsort returned %T, want []interface{}", got.Interface())
+			}
+			var keys []string
+			for _, e := range entries {
+				keys = append(keys, e.(map[string]interface{})["key"].(string))
+			}
+			if strings.Join(keys, ",") != strings.Join(tt.wantKeys, ",") {
+				t.Errorf("keys = %v, want %v", keys, tt.wantKeys)
+			}
+		})
+	}
+}
+
+func TestFilterDictSort_Slice(t *testing.T) {
+	authors := []interface{}{
+		map[string]interface{}{"name": "Zoe", "posts": 2},
+		map[string]interface{}{"name": "Ann", "posts": 10},
+		map[string]interface{}{"name": "Bob", "posts": 2},
+	}
+
+	got, err := filterDictSort(pongo2.AsValue(authors), pongo2.AsValue("posts"))
+	if err != nil {
+		t.Fatalf("dictsort error = %v", err)
+	}
+	var names []string
+	for _, a := range got.Interface().([]interface{}) {
+		names = append(names, a.(map[string]interface{})["name"].(string))
+	}
+	// Numeric, not string, order; ties keep input order
+	if want := "Zoe,Bob,Ann"; strings.Join(names, ",") != want {
+		t.Errorf("names = %v, want %s", names, want)
+	}
+}
+
+func TestFilterDictSort_InTemplate(t *testing.T) {
+	engine, err := NewEngine("")
+	if err != nil {
+		t.Fatalf("Failed to create engine: %v", err)
+	}
+	ctx := NewContext(nil, "", nil)
+	ctx.Set("tags", map[string]int{"b": 1, "a": 1, "c": 5})
+
+	got, err := engine.RenderString(`{% for item in tags|dictsortreversed:"value" %}{{ item.key }}={{ item.value }} {% endfor %}|{% for item in tags|dictsort %}{{ item.key }}{% endfor %}`, ctx)
+	if err != nil {
+		t.Fatalf("RenderString() error = %v", err)
+	}
+	if want := "c=5 a=1 b=1 |abc"; got != want {
+		t.Errorf("RenderString() = %q, want %q", got, want)
+	}
+}
+
+func TestFilterBatch(t *testing.T) {
+	tests := []struct {
+		name  string
+		in    interface{}
+		param *pongo2.Value
+		want  string
+	}{
+		{"exact", []int{1, 2, 3, 4, 5, 6}, pongo2.AsValue(3), "[[1 2 3] [4 5 6]]"},
+		{"partial last row", []string{"a", "b", "c", "d", "e"}, pongo2.AsValue(2), "[[a b] [c d] [e]]"},
+		{"padded last row", []string{"a", "b", "c", "d"}, pongo2.AsValue("3,-"), "[[a b c] [d - -]]"},
+		{"padded exact rows unchanged", []int{1, 2}, pongo2.AsValue("2,x"), "[[1 2]]"},
+		{"empty", []int{}, pongo2.AsValue(3), "[]"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := filterBatch(pongo2.AsValue(tt.in), tt.param)
+			if err != nil {
+				t.Fatalf("batch error = %v", err)
+			}
+			if s := fmt.Sprint(got.Interface()); s != tt.want {
+				t.Errorf("batch = %s, want %s", s, tt.want)
+			}
+		})
+	}
+
+	for _, param := range []interface{}{0, -1, "three"} {
+		if _, err := filterBatch(pongo2.AsValue([]int{1}), pongo2.AsValue(param)); err == nil {
+			t.Errorf("batch:%v error = nil, want an error", param)
+		}
+	}
+}
+
+func TestFilterSlice_Columns(t *testing.T) {
+	tests := []struct {
+		name  string
+		in    interface{}
+		param *pongo2.Value
+		want  string
+	}{
+		{"even", []int{1, 2, 3, 4, 5, 6}, pongo2.AsValue(3), "[[1 2] [3 4] [5 6]]"},
+		{"extra items go to first columns", []int{1, 2, 3, 4, 5, 6, 7}, pongo2.AsValue(3), "[[1 2 3] [4 5] [6 7]]"},
+		{"padded short columns", []int{1, 2, 3, 4, 5, 6, 7}, pongo2.AsValue("3,0"), "[[1 2 3] [4 5 0] [6 7 0]]"},
+		{"more columns than items", []string{"a", "b"}, pongo2.AsValue(3), "[[a] [b] []]"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := filterSlice(pongo2.AsValue(tt.in), tt.param)
+			if err != nil {
+				t.Fatalf("slice error = %v", err)
+			}
+			if s := fmt.Sprint(got.Interface()); s != tt.want {
+				t.Errorf("slice = %s, want %s", s, tt.want)
+			}
+		})
+	}
+}
+
+func TestFilterBatchAndSlice_InTemplate(t *testing.T) {
+	engine, err := NewEngine("")
+	if err != nil {
+		t.Fatalf("Failed to create engine: %v", err)
+	}
+	ctx := NewContext(nil, "", nil)
+	ctx.Set("photos", []string{"a", "b", "c", "d", "e"})
+
+	tests := []struct {
+		tpl  string
+		want string
+	}{
+		{`{% for row in photos|batch:2 %}<{% for p in row %}{{ p }}{% endfor %}>{% endfor %}`, "<ab><cd><e>"},
+		{`{% for col in photos|slice:2 %}<{% for p in col %}{{ p }}{% endfor %}>{% endfor %}`, "<abc><de>"},
+		// The from:to form keeps working
+		{`{% for p in photos|slice:":3" %}{{ p }}{% endfor %}|{% for p in photos|slice:"-2:" %}{{ p }}{% endfor %}`, "abc|de"},
+	}
+	for _, tt := range tests {
+		got, err := engine.RenderString(tt.tpl, ctx)
+		if err != nil {
+			t.Fatalf("RenderString(%q) error = %v", tt.tpl, err)
+		}
+		if got != tt.want {
+			t.Errorf("RenderString(%q) = %q, want %q", tt.tpl, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,150 @@
+package templates
+
+import (
+	"github.com/flosch/pongo2/v6"
+)
+
+// forNode implements the {% for %} tag. It replaces pongo2's built-in tag,
+// behaving identically except that every iteration is charged against the
+// render's Limits, so a runaway loop fails the render instead of the build.
+type forNode struct {
+	token           *pongo2.Token
+	key             string
+	value           string // only for maps: for key, value in map
+	objectEvaluator pongo2.IEvaluator
+	reversed        bool
+	sorted          bool
+
+	bodyWrapper  *pongo2.NodeWrapper
+	emptyWrapper *pongo2.NodeWrapper
+}
+
+// forLoopInfo is exposed to templates as forloop. Field names match
+// pongo2's so existing templates keep working.
+type forLoopInfo struct {
+	Counter     int
+	Counter0    int
+	Revcounter  int
+	Revcounter0 int
+	First       bool
+	Last        bool
+	Parentloop  *forLoopInfo
+}
+
+// Execute renders the loop body once per item, or the {% empty %} body when
+// there is nothing to iterate over.
+func (node *forNode) Execute(ctx *pongo2.ExecutionContext, writer pongo2.TemplateWriter) (forError *pongo2.Error) {
+	forCtx := pongo2.NewChildExecutionContext(ctx)
+	loopInfo := &forLoopInfo{First: true}
+	if parentloop, ok := forCtx.Private["forloop"].(*forLoopInfo); ok {
+		loopInfo.Parentloop = parentloop
+	}
+	forCtx.Private["forloop"] = loopInfo
+
+	obj, err := node.objectEvaluator.Evaluate(forCtx)
+	if err != nil {
+		return err
+	}
+
+	budget, _ := ctx.Public[renderBudgetKey].(*renderBudget)
+
+	obj.IterateOrder(func(idx, count int, key, value *pongo2.Value) bool {
+		if budget != nil {
+			if err := budget.iterate(node.token); err != nil {
+				forError = ctx.OrigError(err, node.token)
+				return false
+			}
+		}
+
+		forCtx.Private[node.key] = key
+		if value != nil {
+			forCtx.Private[node.value] = value
+		}
+		loopInfo.Counter = idx + 1
+		loopInfo.Counter0 = idx
+		if idx == 1 {
+			loopInfo.First = false
+		}
+		if idx+1 == count {
+			loopInfo.Last = true
+		}
+		loopInfo.Revcounter = count - idx
+		loopInfo.Revcounter0 = count - (idx + 1)
+
+		if err := node.bodyWrapper.Execute(forCtx, writer); err != nil {
+			forError = err
+			return false
+		}
+		return true
+	}, func() {
+		if node.emptyWrapper != nil {
+			if err := node.emptyWrapper.Execute(forCtx, writer); err != nil {
+				forError = err
+			}
+		}
+	}, node.reversed, node.sorted)
+
+	return forError
+}
+
+// tagForParser parses {% for key[, value] in expr [reversed] [sorted] %}
+// ... [{% empty %} ...] {% endfor %}.
+func tagForParser(doc *pongo2.Parser, start *pongo2.Token, arguments *pongo2.Parser) (pongo2.INodeTag, *pongo2.Error) {
+	node := &forNode{token: start}
+
+	keyToken := arguments.MatchType(pongo2.TokenIdentifier)
+	if keyToken == nil {
+		return nil, arguments.Error("Expected an key identifier as first argument for 'for'-tag", nil)
+	}
+	node.key = keyToken.Val
+
+	if arguments.Match(pongo2.TokenSymbol, ",") != nil {
+		valueToken := arguments.MatchType(pongo2.TokenIdentifier)
+		if valueToken == nil {
+			return nil, arguments.Error("Value name must be an identifier.", nil)
+		}
+		node.value = valueToken.Val
+	}
+
+	if arguments.Match(pongo2.TokenKeyword, "in") == nil {
+		return nil, arguments.Error("Expected keyword 'in'.", nil)
+	}
+
+	objectEvaluator, err := arguments.ParseExpression()
+	if err != nil {
+		return nil, err
+	}
+	node.objectEvaluator = objectEvaluator
+
+	if arguments.MatchOne(pongo2.TokenIdentifier, "reversed") != nil {
+		node.reversed = true
+	}
+	if arguments.MatchOne(pongo2.TokenIdentifier, "sorted") != nil {
+		node.sorted = true
+	}
+	if arguments.Remaining() > 0 {
+		return nil, arguments.Error("Malformed for-loop arguments.", nil)
+	}
+
+	wrapper, endargs, err := doc.WrapUntilTag("empty", "endfor")
+	if err != nil {
+		return nil, err
+	}
+	node.bodyWrapper = wrapper
+	if endargs.Count() > 0 {
+		return nil, endargs.Error("Arguments not allowed here.", nil)
+	}
+
+	if wrapper.Endtag == "empty" {
+		wrapper, endargs, err = doc.WrapUntilTag("endfor")
+		if err != nil {
+			return nil, err
+		}
+		node.emptyWrapper = wrapper
+		if endargs.Count() > 0 {
+			return nil, endargs.Error("Arguments not allowed here.", nil)
+		}
+	}
+
+	return node, nil
+}
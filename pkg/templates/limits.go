@@ -0,0 +1,153 @@
+package templates
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/flosch/pongo2/v6"
+
+	"github.com/WaylonWalker/markata-go/pkg/models"
+)
+
+// ErrLimitExceeded is returned when a render exceeds the engine's Limits.
+var ErrLimitExceeded = errors.New("template execution limit exceeded")
+
+// Limits caps the work a single render may do, guarding builds against
+// runaway user or theme templates. A zero field disables that limit.
+type Limits struct {
+	// MaxLoopIterations is the total number of {% for %} iterations allowed
+	// in one render, counting nested loops and included templates.
+	MaxLoopIterations int
+
+	// MaxOutputBytes is the largest rendered output allowed, in bytes.
+	MaxOutputBytes int
+}
+
+// DefaultLimits returns the default execution limits. They are far above
+// what real sites need and only stop templates that would otherwise exhaust
+// memory.
+func DefaultLimits() Limits {
+	return Limits{
+		MaxLoopIterations: models.DefaultTemplateMaxLoopIterations,
+		MaxOutputBytes:    models.DefaultTemplateMaxOutputBytes,
+	}
+}
+
+var (
+	limitsMu      sync.RWMutex
+	defaultLimits = DefaultLimits()
+)
+
+// SetDefaultLimits sets the limits that engines created afterwards start
+// with. Use Engine.SetLimits to change the limits of an existing engine.
+func SetDefaultLimits(limits Limits) {
+	limitsMu.Lock()
+	defer limitsMu.Unlock()
+	defaultLimits = limits
+}
+
+func currentDefaultLimits() Limits {
+	limitsMu.RLock()
+	defer limitsMu.RUnlock()
+	return defaultLimits
+}
+
+// renderBudgetKey is the context key that carries a render's budget to the
+// {% for %} tag.
+const renderBudgetKey = "_markata_render_budget"
+
+// renderBudget tracks the loop iterations and output of one render. The
+// first limit hit is kept in err so later loops and writes stop early.
+type renderBudget struct {
+	limits     Limits
+	iterations int
+	written    int
+	err        error
+}
+
+// iterate charges one loop iteration, returning an error once the loop
+// limit is exceeded. token locates the offending loop.
+func (b *renderBudget) iterate(token *pongo2.Token) error {
+	if b.err != nil {
+		return b.err
+	}
+	b.iterations++
+	if b.limits.MaxLoopIterations > 0 && b.iterations > b.limits.MaxLoopIterations {
+		where := "template string"
+		if token != nil && token.Filename != "" {
+			where = token.Filename
+		}
+		if token != nil {
+			where = fmt.Sprintf("%s line %d", where, token.Line)
+		}
+		b.err = fmt.Errorf("%w: more than %d loop iterations (for loop at %s)",
+			ErrLimitExceeded, b.limits.MaxLoopIterations, where)
+	}
+	return b.err
+}
+
+// budgetWriter collects rendered output, refusing writes past the output
+// limit. pongo2 ignores write errors, so the budget records the failure.
+type budgetWriter struct {
+	buf    strings.Builder
+	budget *renderBudget
+}
+
+func (w *budgetWriter) Write(p []byte) (int, error) {
+	return w.WriteString(string(p))
+}
+
+func (w *budgetWriter) WriteString(s string) (int, error) {
+	b := w.budget
+	if b.err != nil {
+		return 0, b.err
+	}
+	if b.limits.MaxOutputBytes > 0 && b.written+len(s) > b.limits.MaxOutputBytes {
+		b.err = fmt.Errorf("%w: output larger than %d bytes", ErrLimitExceeded, b.limits.MaxOutputBytes)
+		return 0, b.err
+	}
+	b.written += len(s)
+	return w.buf.WriteString(s)
+}
+
+// Limits returns the engine's execution limits.
+func (e *Engine) Limits() Limits {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.limits
+}
+
+// SetLimits sets the engine's execution limits. A zero Limits disables
+// them.
+func (e *Engine) SetLimits(limits Limits) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.limits = limits
+}
+
+// execute renders tpl within the engine's limits.
+func (e *Engine) execute(tpl *pongo2.Template, ctx pongo2.Context) (string, error) {
+	ctx = withTemplateFunctions(ctx)
+	limits := e.Limits()
+	if limits == (Limits{}) {
+		return tpl.Execute(ctx)
+	}
+
+	budget := &renderBudget{limits: limits}
+	limited := make(pongo2.Context, len(ctx)+1)
+	limited.Update(ctx)
+	limited[renderBudgetKey] = budget
+
+	w := &budgetWriter{budget: budget}
+	err := tpl.ExecuteWriterUnbuffered(limited, w)
+	if budget.err != nil {
+		// pongo2's error type does not unwrap, so return ours for errors.Is
+		return "", budget.err
+	}
+	if err != nil {
+		return "", err
+	}
+	return w.buf.String(), nil
+}
@@ -0,0 +1,80 @@
+package templates
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/WaylonWalker/markata-go/pkg/models"
+)
+
+func TestEngine_LoopLimitExceeded(t *testing.T) {
+	fsys := fstest.MapFS{
+		"loop.html":  {Data: []byte(`{% include "inner.html" %}`)},
+		"inner.html": {Data: []byte("{% for a in items %}\n{% for b in items %}{{ b }}{% endfor %}{% endfor %}")},
+	}
+	engine, err := NewEngineFromFS(fsys, ".")
+	if err != nil {
+		t.Fatalf("NewEngineFromFS() error: %v", err)
+	}
+	engine.SetLimits(Limits{MaxLoopIterations: 50})
+
+	ctx := NewContext(nil, "", nil)
+	ctx.Set("items", make([]int, 10))
+
+	_, err = engine.Render("loop.html", ctx)
+	if !errors.Is(err, ErrLimitExceeded) {
+		t.Fatalf("Render() error = %v, want ErrLimitExceeded", err)
+	}
+	for _, want := range []string{`"loop.html"`, "50 loop iterations", "inner.html line 2"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Render() error = %q, want it to mention %q", err, want)
+		}
+	}
+}
+
+func TestEngine_OutputLimitExceeded(t *testing.T) {
+	engine, err := NewEngine("")
+	if err != nil {
+		t.Fatalf("NewEngine() error: %v", err)
+	}
+	engine.SetLimits(Limits{MaxOutputBytes: 100})
+
+	ctx := NewContext(nil, "", nil)
+	ctx.Set("items", make([]int, 50))
+
+	_, err = engine.RenderString(`{% for i in items %}0123456789{% endfor %}`, ctx)
+	if !errors.Is(err, ErrLimitExceeded) {
+		t.Fatalf("RenderString() error = %v, want ErrLimitExceeded", err)
+	}
+}
+
+func TestEngine_LimitsDoNotAffectNormalTemplates(t *testing.T) {
+	engine, err := NewEngine("")
+	if err != nil {
+		t.Fatalf("NewEngine() error: %v", err)
+	}
+	if got := engine.Limits(); got != DefaultLimits() {
+		t.Errorf("Limits() = %+v, want defaults %+v", got, DefaultLimits())
+	}
+
+	post := &models.Post{Tags: []string{"go", "web"}}
+	tpl := `{% for tag in post.tags %}{{ forloop.Counter }}:{{ tag }}{% if not forloop.Last %},{% endif %}{% empty %}none{% endfor %}` +
+		`|{% for k, v in pairs sorted %}{{ k }}={{ v }}{% endfor %}` +
+		`|{% for x in missing %}x{% empty %}none{% endfor %}`
+	ctx := NewContext(post, "", nil)
+	ctx.Set("pairs", map[string]int{"b": 2, "a": 1})
+
+	want := "1:go,2:web|a=1b=2|none"
+	for _, limits := range []Limits{DefaultLimits(), {}} {
+		engine.SetLimits(limits)
+		got, err := engine.RenderString(tpl, ctx)
+		if err != nil {
+			t.Fatalf("RenderString() with %+v error: %v", limits, err)
+		}
+		if got != want {
+			t.Errorf("RenderString() with %+v = %q, want %q", limits, got, want)
+		}
+	}
+}
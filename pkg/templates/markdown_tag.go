@@ -0,0 +1,142 @@
+package templates
+
+import (
+	"bytes"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/flosch/pongo2/v6"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/extension"
+)
+
+// MarkdownRenderFunc converts markdown to HTML.
+type MarkdownRenderFunc func(markdown string) (string, error)
+
+var (
+	markdownRendererMu sync.RWMutex
+	markdownRenderer   MarkdownRenderFunc
+	fallbackMarkdown   = goldmark.New(goldmark.WithExtensions(extension.GFM))
+)
+
+// SetMarkdownRenderer sets the function {% markdown %} blocks render with,
+// so they match post content. The render_markdown plugin registers its
+// renderer here; until then a plain GitHub Flavored Markdown renderer is
+// used. A nil fn restores the fallback.
+func SetMarkdownRenderer(fn MarkdownRenderFunc) {
+	markdownRendererMu.Lock()
+	defer markdownRendererMu.Unlock()
+	markdownRenderer = fn
+}
+
+// renderMarkdown converts markdown to HTML with the registered renderer.
+func renderMarkdown(markdown string) (string, error) {
+	markdownRendererMu.RLock()
+	fn := markdownRenderer
+	markdownRendererMu.RUnlock()
+	if fn != nil {
+		return fn(markdown)
+	}
+
+	var buf bytes.Buffer
+	if err := fallbackMarkdown.Convert([]byte(markdown), &buf); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// markdownNode implements the {% markdown %} block tag, which renders its
+// body as markdown and outputs the HTML unescaped.
+type markdownNode struct {
+	token   *pongo2.Token
+	wrapper *pongo2.NodeWrapper
+}
+
+// tagMarkdownParser parses {% markdown [raw] %} ... {% endmarkdown %}.
+// Without raw the body is executed first, so variables and tags inside it
+// are interpolated before the markdown conversion. With raw the body is
+// used literally; see rawMarkdownBlocks.
+func tagMarkdownParser(doc *pongo2.Parser, start *pongo2.Token, arguments *pongo2.Parser) (pongo2.INodeTag, *pongo2.Error) {
+	node := &markdownNode{token: start}
+
+	// raw is handled before parsing by rawMarkdownBlocks
+	arguments.MatchOne(pongo2.TokenIdentifier, "raw")
+	if arguments.Remaining() > 0 {
+		return nil, arguments.Error("Tag 'markdown' only takes the 'raw' argument.", nil)
+	}
+
+	wrapper, endargs, err := doc.WrapUntilTag("endmarkdown")
+	if err != nil {
+		return nil, err
+	}
+	if endargs.Count() > 0 {
+		return nil, endargs.Error("Arguments not allowed here.", nil)
+	}
+	node.wrapper = wrapper
+
+	return node, nil
+}
+
+// Execute renders the body, removes the indentation common to its lines so
+// markdown indented to match the surrounding HTML is not read as a code
+// block, and writes the converted HTML.
+func (node *markdownNode) Execute(ctx *pongo2.ExecutionContext, writer pongo2.TemplateWriter) *pongo2.Error {
+	var body bytes.Buffer
+	if err := node.wrapper.Execute(ctx, &body); err != nil {
+		return err
+	}
+
+	out, err := renderMarkdown(dedent(body.String()))
+	if err != nil {
+		return ctx.OrigError(err, node.token)
+	}
+	_, _ = writer.WriteString(out)
+	return nil
+}
+
+// dedent removes leading and trailing blank lines and the indentation
+// shared by every non-blank line.
+func dedent(s string) string {
+	lines := strings.Split(s, "\n")
+	for len(lines) > 0 && strings.TrimSpace(lines[0]) == "" {
+		lines = lines[1:]
+	}
+	for len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) == "" {
+		lines = lines[:len(lines)-1]
+	}
+
+	prefix := ""
+	first := true
+	for _, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		indent := line[:len(line)-len(strings.TrimLeft(line, " \t"))]
+		if first {
+			prefix, first = indent, false
+			continue
+		}
+		for !strings.HasPrefix(indent, prefix) {
+			prefix = prefix[:len(prefix)-1]
+		}
+	}
+
+	for i, line := range lines {
+		lines[i] = strings.TrimPrefix(line, prefix)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// rawMarkdownBlockRegex matches {% markdown raw %} ... {% endmarkdown %}.
+var rawMarkdownBlockRegex = regexp.MustCompile(`(?s)(\{%-?\s*markdown\s+raw\s*-?%\})(.*?)(\{%-?\s*endmarkdown\s*-?%\})`)
+
+// rawMarkdownBlocks wraps the body of each {% markdown raw %} block in
+// {% verbatim %}, so pongo2 passes it through without interpolation. It runs
+// as part of preprocessTemplate.
+func rawMarkdownBlocks(src []byte) []byte {
+	if !bytes.Contains(src, []byte("markdown")) {
+		return src
+	}
+	return rawMarkdownBlockRegex.ReplaceAll(src, []byte("$1{% verbatim %}$2{% endverbatim %}$3"))
+}
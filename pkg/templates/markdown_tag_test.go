@@ -0,0 +1,104 @@
+package templates
+
+import (
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func TestMarkdownTag_Interpolated(t *testing.T) {
+	engine, err := NewEngine("")
+	if err != nil {
+		t.Fatalf("NewEngine() error: %v", err)
+	}
+
+	ctx := NewContext(nil, "", nil)
+	ctx.Set("site_title", "My Site")
+	ctx.Set("items", []string{"one", "two"})
+
+	tpl := `<div>
+    {% markdown %}
+    # Welcome to {{ site_title }}
+
+    {% for item in items %}- **{{ item }}**
+    {% endfor %}
+    {% endmarkdown %}
+</div>`
+	got, err := engine.RenderString(tpl, ctx)
+	if err != nil {
+		t.Fatalf("RenderString() error: %v", err)
+	}
+
+	for _, want := range []string{
+		"<h1>Welcome to My Site</h1>",
+		"<li><strong>one</strong></li>",
+		"<li><strong>two</strong></li>",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("RenderString() = %q, want it to contain %q", got, want)
+		}
+	}
+	if strings.Contains(got, "<pre>") {
+		t.Errorf("RenderString() = %q, indented markdown rendered as a code block", got)
+	}
+}
+
+func TestMarkdownTag_Raw(t *testing.T) {
+	fsys := fstest.MapFS{
+		"page.html": {Data: []byte(`{% include "raw.html" %}`)},
+		"raw.html":  {Data: []byte("{% markdown raw %}\nUse `{{ site_title }}` and `{% if x %}`.\n{% endmarkdown %}")},
+	}
+	engine, err := NewEngineFromFS(fsys, ".")
+	if err != nil {
+		t.Fatalf("NewEngineFromFS() error: %v", err)
+	}
+
+	ctx := NewContext(nil, "", nil)
+	ctx.Set("site_title", "My Site")
+
+	got, err := engine.Render("page.html", ctx)
+	if err != nil {
+		t.Fatalf("Render() error: %v", err)
+	}
+	want := "<p>Use <code>{{ site_title }}</code> and <code>{% if x %}</code>.</p>"
+	if strings.TrimSpace(got) != want {
+		t.Errorf("Render() = %q, want %q", got, want)
+	}
+
+	got, err = engine.RenderString("{% markdown raw %}*{{ site_title }}*{% endmarkdown %}", ctx)
+	if err != nil {
+		t.Fatalf("RenderString() error: %v", err)
+	}
+	if strings.TrimSpace(got) != "<p><em>{{ site_title }}</em></p>" {
+		t.Errorf("RenderString() = %q, want the variable left literal", got)
+	}
+}
+
+func TestMarkdownTag_UsesRegisteredRenderer(t *testing.T) {
+	SetMarkdownRenderer(func(markdown string) (string, error) {
+		return "<custom>" + markdown + "</custom>", nil
+	})
+	t.Cleanup(func() { SetMarkdownRenderer(nil) })
+
+	engine, err := NewEngine("")
+	if err != nil {
+		t.Fatalf("NewEngine() error: %v", err)
+	}
+	got, err := engine.RenderString("{% markdown %}\n  a\n    b\n{% endmarkdown %}", NewContext(nil, "", nil))
+	if err != nil {
+		t.Fatalf("RenderString() error: %v", err)
+	}
+	if want := "<custom>a\n  b</custom>"; got != want {
+		t.Errorf("RenderString() = %q, want %q", got, want)
+	}
+}
+
+func TestMarkdownTag_RejectsUnknownArgument(t *testing.T) {
+	engine, err := NewEngine("")
+	if err != nil {
+		t.Fatalf("NewEngine() error: %v", err)
+	}
+	if _, err := engine.RenderString("{% markdown safe %}x{% endmarkdown %}", NewContext(nil, "", nil)); err == nil {
+		t.Error("RenderString() error = nil, want an error for an unknown argument")
+	}
+}
@@ -0,0 +1,457 @@
+package templates
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/flosch/pongo2/v6"
+
+	"github.com/WaylonWalker/markata-go/pkg/models"
+)
+
+// Default route settings, matching the auto_feeds and authors defaults.
+const (
+	defaultTagPrefix        = "tags"
+	defaultAuthorURLPattern = "/authors/{author}/"
+)
+
+// RouteConfig holds the site settings the built-in routes build URLs from.
+type RouteConfig struct {
+	// TagPrefix is the URL prefix of tag feeds (default: "tags").
+	TagPrefix string
+
+	// AuthorURLPattern is the author page URL with an {author} or {id}
+	// placeholder (default: "/authors/{author}/").
+	AuthorURLPattern string
+}
+
+// Route builds the canonical URL for one kind of page.
+type Route struct {
+	// Params names the arguments the route requires, in positional order.
+	Params []string
+
+	// Optional names the arguments the route accepts by keyword only.
+	Optional []string
+
+	// Build returns the URL for the given argument values, keyed by name.
+	Build func(args map[string]string) (string, error)
+}
+
+// RouteRegistry maps route names to routes for the url_for template
+// function.
+type RouteRegistry struct {
+	routes map[string]Route
+}
+
+// NewRouteRegistry creates a registry with the built-in routes:
+//
+//	post(slug)          /<slug>/
+//	tag(tag)            /<tag prefix>/<slugified tag>/
+//	author(author)      the author URL pattern with {author} replaced
+//	feed(slug, page=N)  /<slug>/ for page 1, /<slug>/page/N/ after that
+func NewRouteRegistry(cfg RouteConfig) *RouteRegistry {
+	tagPrefix := strings.Trim(cfg.TagPrefix, "/")
+	if tagPrefix == "" {
+		tagPrefix = defaultTagPrefix
+	}
+	authorPattern := cfg.AuthorURLPattern
+	if authorPattern == "" {
+		authorPattern = defaultAuthorURLPattern
+	}
+
+	r := &RouteRegistry{routes: make(map[string]Route)}
+	r.Register("post", Route{
+		Params: []string{"slug"},
+		Build: func(args map[string]string) (string, error) {
+			return slugURL(args["slug"]), nil
+		},
+	})
+	r.Register("tag", Route{
+		Params: []string{"tag"},
+		Build: func(args map[string]string) (string, error) {
+			// auto_feeds makes no feed for tags without URL-safe
+			// characters, so link those to the tag index
+			return slugURL(tagPrefix + "/" + models.Slugify(args["tag"])), nil
+		},
+	})
+	r.Register("author", Route{
+		Params: []string{"author"},
+		Build: func(args map[string]string) (string, error) {
+			if args["author"] == "" {
+				return "", fmt.Errorf("author is empty")
+			}
+			authorPlaceholders := strings.NewReplacer("{author}", args["author"], "{id}", args["author"])
+			return authorPlaceholders.Replace(authorPattern), nil
+		},
+	})
+	r.Register("feed", Route{
+		Params:   []string{"slug"},
+		Optional: []string{"page"},
+		Build: func(args map[string]string) (string, error) {
+			page := 1
+			if raw, ok := args["page"]; ok {
+				n, err := strconv.Atoi(raw)
+				if err != nil || n < 1 {
+					return "", fmt.Errorf("page must be a positive integer, got %q", raw)
+				}
+				page = n
+			}
+			baseURL := strings.TrimSuffix(slugURL(args["slug"]), "/")
+			return models.FeedPageURL(baseURL, page), nil
+		},
+	})
+	return r
+}
+
+// slugURL returns the URL of a page with the given slug, "/" for the
+// empty slug.
+func slugURL(slug string) string {
+	slug = strings.Trim(slug, "/")
+	if slug == "" {
+		return "/"
+	}
+	return "/" + slug + "/"
+}
+
+// Register adds or replaces the route called name.
+func (r *RouteRegistry) Register(name string, route Route) {
+	r.routes[name] = route
+}
+
+// Names returns the registered route names in sorted order.
+func (r *RouteRegistry) Names() []string {
+	names := make([]string, 0, len(r.routes))
+	for name := range r.routes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// URL returns the URL of the route called name. Positional args fill the
+// route's Params in order; kwargs may name any of its Params or Optional
+// arguments.
+func (r *RouteRegistry) URL(name string, args []string, kwargs map[string]string) (string, error) {
+	route, ok := r.routes[name]
+	if !ok {
+		return "", fmt.Errorf("url_for: unknown route %q (known routes: %s)", name, strings.Join(r.Names(), ", "))
+	}
+	if len(args) > len(route.Params) {
+		return "", fmt.Errorf("url_for(%q): takes %d positional argument(s) (%s), got %d",
+			name, len(route.Params), strings.Join(route.Params, ", "), len(args))
+	}
+
+	values := make(map[string]string, len(route.Params)+len(kwargs))
+	for i, arg := range args {
+		values[route.Params[i]] = arg
+	}
+	for key, value := range kwargs {
+		if !containsString(route.Params, key) && !containsString(route.Optional, key) {
+			return "", fmt.Errorf("url_for(%q): unknown argument %q", name, key)
+		}
+		if _, dup := values[key]; dup {
+			return "", fmt.Errorf("url_for(%q): argument %q given twice", name, key)
+		}
+		values[key] = value
+	}
+	for _, param := range route.Params {
+		if _, ok := values[param]; !ok {
+			return "", fmt.Errorf("url_for(%q): missing argument %q", name, param)
+		}
+	}
+
+	u, err := route.Build(values)
+	if err != nil {
+		return "", fmt.Errorf("url_for(%q): %w", name, err)
+	}
+	return u, nil
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
+var (
+	routesMu sync.RWMutex
+	routes   = NewRouteRegistry(RouteConfig{})
+)
+
+// SetRoutes sets the registry the url_for template function resolves
+// routes against. The templates plugin builds it from the site config.
+func SetRoutes(r *RouteRegistry) {
+	if r == nil {
+		r = NewRouteRegistry(RouteConfig{})
+	}
+	routesMu.Lock()
+	defer routesMu.Unlock()
+	routes = r
+}
+
+// Routes returns the registry set via SetRoutes.
+func Routes() *RouteRegistry {
+	routesMu.RLock()
+	defer routesMu.RUnlock()
+	return routes
+}
+
+// urlForKeywordFunc is the context function that marks the keyword
+// arguments of url_for calls; see urlForKeywordArgs.
+const urlForKeywordFunc = "_url_for_keyword"
+
+// urlForKeyword marks the next url_for argument as the value of a keyword.
+type urlForKeyword string
+
+// urlFor implements {{ url_for(route, args...) }}.
+func urlFor(name *pongo2.Value, args ...*pongo2.Value) (*pongo2.Value, error) {
+	var (
+		positional []string
+		kwargs     map[string]string
+	)
+	for i := 0; i < len(args); i++ {
+		kw, ok := args[i].Interface().(urlForKeyword)
+		if !ok {
+			if kwargs != nil {
+				return nil, fmt.Errorf("url_for(%q): positional argument after keyword argument", name.String())
+			}
+			positional = append(positional, args[i].String())
+			continue
+		}
+		if i+1 == len(args) {
+			return nil, fmt.Errorf("url_for(%q): keyword %q has no value", name.String(), string(kw))
+		}
+		if kwargs == nil {
+			kwargs = make(map[string]string)
+		}
+		i++
+		kwargs[string(kw)] = args[i].String()
+	}
+
+	u, err := Routes().URL(name.String(), positional, kwargs)
+	if err != nil {
+		return nil, err
+	}
+	return pongo2.AsValue(u), nil
+}
+
+func urlForKeywordMarker(name string) urlForKeyword {
+	return urlForKeyword(name)
+}
+
+// withTemplateFunctions returns a copy of ctx with the url_for functions
+// added, unless the context already defines them.
+func withTemplateFunctions(ctx pongo2.Context) pongo2.Context {
+	out := make(pongo2.Context, len(ctx)+2)
+	out["url_for"] = urlFor
+	out[urlForKeywordFunc] = urlForKeywordMarker
+	out.Update(ctx)
+	return out
+}
+
+var (
+	verbatimStartRegex = regexp.MustCompile(`^\{%-?\s*verbatim\s*-?%\}`)
+	verbatimEndRegex   = regexp.MustCompile(`\{%-?\s*endverbatim\s*-?%\}`)
+)
+
+// urlForKeywordArgs rewrites keyword arguments in url_for calls, which
+// pongo2 cannot parse, into marked positional arguments:
+//
+//	url_for('feed', 'blog', page=2)
+//	url_for('feed', 'blog', _url_for_keyword("page"), 2)
+//
+// Only {{ }} and {% %} tags are rewritten; text, comments, and verbatim
+// blocks are copied unchanged. The engine applies it to template source
+// before parsing.
+func urlForKeywordArgs(src []byte) []byte {
+	if !bytes.Contains(src, []byte("url_for")) {
+		return src
+	}
+
+	s := string(src)
+	var out strings.Builder
+	out.Grow(len(s))
+	for i := 0; i < len(s); {
+		open := strings.Index(s[i:], "{")
+		if open < 0 || i+open+1 >= len(s) {
+			out.WriteString(s[i:])
+			break
+		}
+		open += i
+		out.WriteString(s[i:open])
+
+		switch s[open+1] {
+		case '#':
+			i = copyThrough(&out, s, open, "#}")
+		case '%':
+			if verbatimStartRegex.MatchString(s[open:]) {
+				end := verbatimEndRegex.FindStringIndex(s[open:])
+				if end == nil {
+					out.WriteString(s[open:])
+					return []byte(out.String())
+				}
+				out.WriteString(s[open : open+end[1]])
+				i = open + end[1]
+				continue
+			}
+			end := tagEnd(s, open+2, "%}")
+			rewriteKeywordArgs(s[open:end], 0, &out, false)
+			i = end
+		case '{':
+			end := tagEnd(s, open+2, "}}")
+			rewriteKeywordArgs(s[open:end], 0, &out, false)
+			i = end
+		default:
+			out.WriteByte('{')
+			i = open + 1
+		}
+	}
+	return []byte(out.String())
+}
+
+// copyThrough writes s[start:] up to and including closer to out and
+// returns the index after it.
+func copyThrough(out *strings.Builder, s string, start int, closer string) int {
+	end := strings.Index(s[start+2:], closer)
+	if end < 0 {
+		out.WriteString(s[start:])
+		return len(s)
+	}
+	end += start + 2 + len(closer)
+	out.WriteString(s[start:end])
+	return end
+}
+
+// tagEnd returns the index after closer in s, skipping string literals,
+// or len(s) if the tag is unterminated.
+func tagEnd(s string, i int, closer string) int {
+	for i < len(s) {
+		switch {
+		case s[i] == '"' || s[i] == '\'':
+			i = stringLiteralEnd(s, i)
+		case strings.HasPrefix(s[i:], closer):
+			return i + len(closer)
+		default:
+			i++
+		}
+	}
+	return len(s)
+}
+
+// stringLiteralEnd returns the index after the string literal starting at
+// s[i], honoring backslash escapes.
+func stringLiteralEnd(s string, i int) int {
+	quote := s[i]
+	for i++; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case quote:
+			return i + 1
+		}
+	}
+	return len(s)
+}
+
+// rewriteKeywordArgs copies expr[i:] to out, rewriting the keyword
+// arguments of url_for calls. Inside a call it stops after the closing
+// parenthesis and returns the index after it.
+func rewriteKeywordArgs(expr string, i int, out *strings.Builder, inCall bool) int {
+	depth := 0
+	argStart := inCall
+	for i < len(expr) {
+		if argStart {
+			argStart = false
+			j := i
+			for j < len(expr) && isTagSpace(expr[j]) {
+				j++
+			}
+			out.WriteString(expr[i:j])
+			i = j
+			if name, next, ok := keywordArg(expr, i); ok {
+				out.WriteString(urlForKeywordFunc + `("` + name + `"), `)
+				i = next
+			}
+			continue
+		}
+
+		c := expr[i]
+		switch {
+		case c == '"' || c == '\'':
+			end := stringLiteralEnd(expr, i)
+			out.WriteString(expr[i:end])
+			i = end
+		case isURLForCall(expr, i):
+			open := i + strings.IndexByte(expr[i:], '(') + 1
+			out.WriteString(expr[i:open])
+			i = rewriteKeywordArgs(expr, open, out, true)
+		case c == '(' || c == '[':
+			depth++
+			out.WriteByte(c)
+			i++
+		case c == ')' || c == ']':
+			out.WriteByte(c)
+			i++
+			if inCall && depth == 0 {
+				return i
+			}
+			depth--
+		case c == ',' && inCall && depth == 0:
+			out.WriteByte(c)
+			i++
+			argStart = true
+		default:
+			out.WriteByte(c)
+			i++
+		}
+	}
+	return i
+}
+
+// isURLForCall reports whether a call to url_for starts at expr[i].
+func isURLForCall(expr string, i int) bool {
+	const name = "url_for"
+	if !strings.HasPrefix(expr[i:], name) {
+		return false
+	}
+	if i > 0 && (isIdentByte(expr[i-1]) || expr[i-1] == '.') {
+		return false
+	}
+	j := i + len(name)
+	for j < len(expr) && isTagSpace(expr[j]) {
+		j++
+	}
+	return j < len(expr) && expr[j] == '('
+}
+
+// keywordArg reports whether a "name=" keyword starts at expr[i],
+// returning the name and the index after the "=".
+func keywordArg(expr string, i int) (name string, next int, ok bool) {
+	j := i
+	for j < len(expr) && isIdentByte(expr[j]) {
+		j++
+	}
+	if j == i || expr[i] >= '0' && expr[i] <= '9' {
+		return "", 0, false
+	}
+	k := j
+	for k < len(expr) && isTagSpace(expr[k]) {
+		k++
+	}
+	if k >= len(expr) || expr[k] != '=' || k+1 < len(expr) && expr[k+1] == '=' {
+		return "", 0, false
+	}
+	return expr[i:j], k + 1, true
+}
+
+func isIdentByte(c byte) bool {
+	return c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9'
+}
@@ -0,0 +1,160 @@
+package templates
+
+import (
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func renderURLFor(t *testing.T, tpl string, vars map[string]interface{}) (string, error) {
+	t.Helper()
+
+	engine, err := NewEngine("")
+	if err != nil {
+		t.Fatalf("NewEngine() error: %v", err)
+	}
+	ctx := NewContext(nil, "", nil)
+	for k, v := range vars {
+		ctx.Set(k, v)
+	}
+	return engine.RenderString(tpl, ctx)
+}
+
+func TestURLFor_Routes(t *testing.T) {
+	SetRoutes(NewRouteRegistry(RouteConfig{TagPrefix: "topics", AuthorURLPattern: "/people/{id}/"}))
+	t.Cleanup(func() { SetRoutes(nil) })
+
+	tests := []struct {
+		name string
+		tpl  string
+		want string
+	}{
+		{"tag", `{{ url_for('tag', tag) }}`, "/topics/go-lang/"},
+		{"tag without slug", `{{ url_for('tag', '!!!') }}`, "/topics/"},
+		{"post", `{{ url_for('post', slug) }}`, "/blog/hello-world/"},
+		{"post home", `{{ url_for('post', '') }}`, "/"},
+		{"author", `{{ url_for('author', 'waylon') }}`, "/people/waylon/"},
+		{"feed first page", `{{ url_for('feed', 'blog') }}`, "/blog/"},
+		{"feed page", `{{ url_for('feed', 'blog', page=2) }}`, "/blog/page/2/"},
+		{"feed page expression", `{{ url_for("feed", "blog", page = n|add:1) }}`, "/blog/page/4/"},
+		{"feed page one", `{{ url_for('feed', 'blog', page=1) }}`, "/blog/"},
+		{"feed keyword slug", `{{ url_for('feed', slug='blog', page=3) }}`, "/blog/page/3/"},
+		{"home feed page", `{{ url_for('feed', '', page=2) }}`, "/page/2/"},
+		{"in tag", `{% with u=url_for('feed', 'blog', page=2) %}{{ u }}{% endwith %}`, "/blog/page/2/"},
+		{"comparison untouched", `{% if url_for('tag', tag) == "/topics/go-lang/" %}ok{% endif %}`, "ok"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := renderURLFor(t, tt.tpl, map[string]interface{}{
+				"tag":  "Go Lang",
+				"slug": "blog/hello-world",
+				"n":    3,
+			})
+			if err != nil {
+				t.Fatalf("RenderString() error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("RenderString() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestURLFor_DefaultRoutes(t *testing.T) {
+	SetRoutes(nil)
+
+	got, err := renderURLFor(t, `{{ url_for('tag', 'Python') }} {{ url_for('author', 'ada') }}`, nil)
+	if err != nil {
+		t.Fatalf("RenderString() error: %v", err)
+	}
+	if want := "/tags/python/ /authors/ada/"; got != want {
+		t.Errorf("RenderString() = %q, want %q", got, want)
+	}
+}
+
+func TestURLFor_Errors(t *testing.T) {
+	SetRoutes(nil)
+
+	tests := []struct {
+		name    string
+		tpl     string
+		wantErr string
+	}{
+		{"unknown route", `{{ url_for('tagz', 'go') }}`, `unknown route "tagz" (known routes: author, feed, post, tag)`},
+		{"missing param", `{{ url_for('tag') }}`, `url_for("tag"): missing argument "tag"`},
+		{"extra param", `{{ url_for('post', 'a', 'b') }}`, `takes 1 positional argument(s) (slug), got 2`},
+		{"unknown keyword", `{{ url_for('tag', 'go', page=2) }}`, `unknown argument "page"`},
+		{"bad page", `{{ url_for('feed', 'blog', page=0) }}`, `page must be a positive integer, got "0"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := renderURLFor(t, tt.tpl, nil)
+			if err == nil {
+				t.Fatal("RenderString() error = nil, want error")
+			}
+			if !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("RenderString() error = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestURLFor_KeywordsInIncludedTemplates(t *testing.T) {
+	SetRoutes(nil)
+
+	fsys := fstest.MapFS{
+		"page.html":  {Data: []byte(`{% include "pager.html" %}`)},
+		"pager.html": {Data: []byte(`<a href="{{ url_for('feed', 'blog', page=2) }}">{% verbatim %}url_for('feed', 'x', page=2){% endverbatim %}</a>`)},
+	}
+	engine, err := NewEngineFromFS(fsys, ".")
+	if err != nil {
+		t.Fatalf("NewEngineFromFS() error: %v", err)
+	}
+
+	got, err := engine.Render("page.html", NewContext(nil, "", nil))
+	if err != nil {
+		t.Fatalf("Render() error: %v", err)
+	}
+	if want := `<a href="/blog/page/2/">url_for('feed', 'x', page=2)</a>`; got != want {
+		t.Errorf("Render() = %q, want %q", got, want)
+	}
+}
+
+func TestURLForKeywordArgs(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want string
+	}{
+		{
+			name: "keyword",
+			src:  `{{ url_for('feed', 'blog', page=2) }}`,
+			want: `{{ url_for('feed', 'blog', _url_for_keyword("page"), 2) }}`,
+		},
+		{
+			name: "text and strings untouched",
+			src:  `url_for(a, page=2) {{ "url_for(x, page=2)" }}`,
+			want: `url_for(a, page=2) {{ "url_for(x, page=2)" }}`,
+		},
+		{
+			name: "other calls untouched",
+			src:  `{{ other(page=2) }}{{ x.url_for(page=2) }}`,
+			want: `{{ other(page=2) }}{{ x.url_for(page=2) }}`,
+		},
+		{
+			name: "equality untouched",
+			src:  `{{ url_for('tag', a == b) }}`,
+			want: `{{ url_for('tag', a == b) }}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := string(urlForKeywordArgs([]byte(tt.src))); got != tt.want {
+				t.Errorf("urlForKeywordArgs() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,156 @@
+package templates
+
+import (
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/WaylonWalker/markata-go/pkg/assets"
+	"github.com/flosch/pongo2/v6"
+)
+
+// Static asset state used by the static and sri filters. It is set by
+// plugins during the configure stage, like the asset hash registry.
+var (
+	staticMu sync.RWMutex
+
+	// staticAssetURLs maps managed asset names to their self-hosted URLs
+	staticAssetURLs map[string]string
+
+	// staticPaths holds the paths of files copied from the static directories
+	staticPaths map[string]bool
+
+	// staticUnknown holds references the filters could not resolve
+	staticUnknown map[string]bool
+)
+
+// SRIPlaceholderPrefix starts the value the sri filter renders. The final
+// asset is only known once the write stage has run minifiers, so the
+// placeholder is replaced with the real hash during cleanup.
+const SRIPlaceholderPrefix = "markata-sri-pending:"
+
+// sriPlaceholderRe matches sri filter placeholders, capturing the reference.
+var sriPlaceholderRe = regexp.MustCompile(regexp.QuoteMeta(SRIPlaceholderPrefix) + `([^"'\s<>]+)`)
+
+// SetAssetURLs sets the self-hosted URLs of managed assets, keyed by asset
+// name, that the static filter resolves names against.
+func SetAssetURLs(urls map[string]string) {
+	staticMu.Lock()
+	defer staticMu.Unlock()
+	staticAssetURLs = urls
+}
+
+// SetStaticPaths sets the paths, relative to the output root, of the files
+// copied from the static directories. It also forgets unknown references
+// recorded by earlier builds.
+func SetStaticPaths(paths []string) {
+	staticMu.Lock()
+	defer staticMu.Unlock()
+	staticPaths = make(map[string]bool, len(paths))
+	for _, p := range paths {
+		staticPaths[filepath.ToSlash(p)] = true
+	}
+	staticUnknown = nil
+}
+
+// UnknownStaticRefs returns the sorted references passed to the static and
+// sri filters that did not match a managed asset or static file.
+func UnknownStaticRefs() []string {
+	staticMu.RLock()
+	defer staticMu.RUnlock()
+	refs := make([]string, 0, len(staticUnknown))
+	for ref := range staticUnknown {
+		refs = append(refs, ref)
+	}
+	sort.Strings(refs)
+	return refs
+}
+
+// ResolveStatic returns the output URL for a static file path such as
+// "js/foo.js" or a managed asset name such as "htmx", and whether it is known.
+// Managed assets resolve to their self-hosted URL, falling back to the CDN.
+// Fingerprinted files get their content hash inserted before the extension.
+// Unknown references are returned unchanged.
+func ResolveStatic(ref string) (string, bool) {
+	if ref == "" {
+		return "", false
+	}
+
+	staticMu.RLock()
+	url, isManaged := staticAssetURLs[ref]
+	path := strings.TrimPrefix(ref, "/")
+	isStatic := staticPaths[path]
+	staticMu.RUnlock()
+
+	if isManaged && url != "" {
+		return url, true
+	}
+	if asset := assets.GetAsset(ref); asset != nil {
+		return asset.URL, true
+	}
+	if hash, ok := GetAssetHash(path); ok && hash != "" {
+		ext := filepath.Ext(path)
+		return "/" + strings.TrimSuffix(path, ext) + "." + hash + ext, true
+	}
+	if isStatic {
+		return "/" + path, true
+	}
+	return ref, false
+}
+
+// ReplaceSRIPlaceholders replaces each sri filter placeholder in content with
+// the value resolve returns for its reference.
+func ReplaceSRIPlaceholders(content string, resolve func(ref string) string) string {
+	if !strings.Contains(content, SRIPlaceholderPrefix) {
+		return content
+	}
+	return sriPlaceholderRe.ReplaceAllStringFunc(content, func(match string) string {
+		return resolve(strings.TrimPrefix(match, SRIPlaceholderPrefix))
+	})
+}
+
+// recordUnknownStatic remembers a reference that did not resolve.
+func recordUnknownStatic(ref string) {
+	staticMu.Lock()
+	defer staticMu.Unlock()
+	if staticUnknown == nil {
+		staticUnknown = make(map[string]bool)
+	}
+	staticUnknown[ref] = true
+}
+
+// filterStatic resolves a static file path or managed asset name to its
+// final output URL, including the content hash when fingerprinted.
+// Usage: {{ 'js/foo.js' | static }} or {{ 'htmx' | static }}
+// Returns: /js/foo.abc12345.js; unknown references are returned unchanged
+// and reported as a build warning.
+func filterStatic(in, _ *pongo2.Value) (*pongo2.Value, *pongo2.Error) {
+	ref := in.String()
+	if ref == "" {
+		return pongo2.AsValue(""), nil
+	}
+	url, ok := ResolveStatic(ref)
+	if !ok {
+		recordUnknownStatic(ref)
+	}
+	return pongo2.AsValue(url), nil
+}
+
+// filterSRI returns the subresource integrity value for a static file or
+// managed asset, for use in an integrity attribute.
+// Usage: <script src="{{ 'js/foo.js' | static }}" integrity="{{ 'js/foo.js' | sri }}">
+// Returns: sha384-... of the file as served; unknown references return ""
+// and are reported as a build warning.
+func filterSRI(in, _ *pongo2.Value) (*pongo2.Value, *pongo2.Error) {
+	ref := in.String()
+	if ref == "" {
+		return pongo2.AsValue(""), nil
+	}
+	if _, ok := ResolveStatic(ref); !ok {
+		recordUnknownStatic(ref)
+		return pongo2.AsValue(""), nil
+	}
+	return pongo2.AsValue(SRIPlaceholderPrefix + ref), nil
+}
@@ -0,0 +1,279 @@
+package templates
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/flosch/pongo2/v6"
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+
+	"github.com/WaylonWalker/markata-go/pkg/models"
+)
+
+// tocNode implements the {% toc %} tag, which builds a table of contents
+// from the rendered body HTML in the template context.
+type tocNode struct {
+	minLevel  pongo2.IEvaluator
+	maxLevel  pongo2.IEvaluator
+	includeH1 pongo2.IEvaluator
+}
+
+// tocHeading is a heading found in the body, with its nested subheadings.
+type tocHeading struct {
+	level    int
+	text     string
+	id       string
+	children []*tocHeading
+}
+
+// tagTocParser parses {% toc [min=N] [max=N] [include_h1=true|false] %}.
+func tagTocParser(_ *pongo2.Parser, _ *pongo2.Token, arguments *pongo2.Parser) (pongo2.INodeTag, *pongo2.Error) {
+	node := &tocNode{}
+	for arguments.Remaining() > 0 {
+		keyToken := arguments.MatchType(pongo2.TokenIdentifier)
+		if keyToken == nil {
+			return nil, arguments.Error("Expected an argument name (min, max, or include_h1).", nil)
+		}
+		if arguments.Match(pongo2.TokenSymbol, "=") == nil {
+			return nil, arguments.Error("Expected '='.", nil)
+		}
+		value, err := arguments.ParseExpression()
+		if err != nil {
+			return nil, err
+		}
+		switch keyToken.Val {
+		case "min":
+			node.minLevel = value
+		case "max":
+			node.maxLevel = value
+		case "include_h1":
+			node.includeH1 = value
+		default:
+			return nil, arguments.Error(fmt.Sprintf("Unknown toc argument %q.", keyToken.Val), keyToken)
+		}
+	}
+	return node, nil
+}
+
+// Execute renders the table of contents. Headings outside the min/max
+// range, and headings inside <pre> or <code>, are skipped. H1 is excluded
+// by default because templates usually render the post title as the page's
+// H1; include_h1 overrides min for H1 either way.
+//
+// Headings without an id get one generated from their text, and body in
+// the context is updated to carry the new ids, so place the tag before
+// {{ body }} when the body HTML may lack heading ids.
+func (node *tocNode) Execute(ctx *pongo2.ExecutionContext, writer pongo2.TemplateWriter) *pongo2.Error {
+	minLevel, maxLevel := 2, 6
+	if node.minLevel != nil {
+		v, err := node.minLevel.Evaluate(ctx)
+		if err != nil {
+			return err
+		}
+		minLevel = v.Integer()
+	}
+	if node.maxLevel != nil {
+		v, err := node.maxLevel.Evaluate(ctx)
+		if err != nil {
+			return err
+		}
+		maxLevel = v.Integer()
+	}
+	if minLevel < 1 || maxLevel > 6 || minLevel > maxLevel {
+		return ctx.Error(fmt.Sprintf("toc: invalid heading range min=%d max=%d (want 1 <= min <= max <= 6)", minLevel, maxLevel), nil)
+	}
+	if node.includeH1 != nil {
+		v, err := node.includeH1.Evaluate(ctx)
+		if err != nil {
+			return err
+		}
+		if v.IsTrue() {
+			minLevel = 1
+		} else if minLevel == 1 {
+			minLevel = 2
+		}
+	}
+
+	body, ok := ctx.Public["body"]
+	if !ok || body == nil {
+		return nil
+	}
+	source := pongo2.AsValue(body).String()
+
+	headings, updated := extractBodyHeadings(source, minLevel, maxLevel)
+	if updated != source {
+		ctx.Public["body"] = updated
+	}
+	if len(headings) == 0 {
+		return nil
+	}
+
+	if _, err := writer.WriteString(renderTOCNav(nestHeadings(headings))); err != nil {
+		return ctx.Error(err.Error(), nil)
+	}
+	return nil
+}
+
+// extractBodyHeadings returns the headings in bodyHTML within the level
+// range, in document order. Missing ids are generated from the heading text;
+// if any were added, the returned HTML includes them, otherwise bodyHTML is
+// returned unchanged.
+func extractBodyHeadings(bodyHTML string, minLevel, maxLevel int) (headings []*tocHeading, updated string) {
+	container := &html.Node{Type: html.ElementNode, Data: "div", DataAtom: atom.Div}
+	nodes, err := html.ParseFragment(strings.NewReader(bodyHTML), container)
+	if err != nil {
+		return nil, bodyHTML
+	}
+
+	// Ids already in the document, so generated ones never collide
+	used := make(map[string]bool)
+	for _, n := range nodes {
+		walkHTML(n, func(n *html.Node) bool {
+			if id := htmlAttr(n, "id"); id != "" {
+				used[id] = true
+			}
+			return true
+		})
+	}
+
+	generated := false
+	for _, n := range nodes {
+		walkHTML(n, func(n *html.Node) bool {
+			if n.Type != html.ElementNode {
+				return true
+			}
+			if n.DataAtom == atom.Pre || n.DataAtom == atom.Code {
+				return false
+			}
+			level := headingLevel(n)
+			if level < minLevel || level > maxLevel {
+				return true
+			}
+			text := strings.TrimSpace(whitespaceRe.ReplaceAllString(htmlText(n), " "))
+			if text == "" {
+				return false
+			}
+			id := htmlAttr(n, "id")
+			if id == "" {
+				id = uniqueHeadingID(text, used)
+				n.Attr = append(n.Attr, html.Attribute{Key: "id", Val: id})
+				generated = true
+			}
+			headings = append(headings, &tocHeading{level: level, text: text, id: id})
+			return false
+		})
+	}
+
+	if !generated {
+		return headings, bodyHTML
+	}
+	var sb strings.Builder
+	for _, n := range nodes {
+		if err := html.Render(&sb, n); err != nil {
+			return headings, bodyHTML
+		}
+	}
+	return headings, sb.String()
+}
+
+// walkHTML calls visit for n and its descendants in document order,
+// skipping the children of nodes for which visit returns false.
+func walkHTML(n *html.Node, visit func(*html.Node) bool) {
+	if !visit(n) {
+		return
+	}
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		walkHTML(c, visit)
+	}
+}
+
+// headingLevel returns 1-6 for <h1>-<h6> elements and 0 otherwise.
+func headingLevel(n *html.Node) int {
+	switch n.DataAtom {
+	case atom.H1, atom.H2, atom.H3, atom.H4, atom.H5, atom.H6:
+		level, _ := strconv.Atoi(n.Data[1:])
+		return level
+	}
+	return 0
+}
+
+// htmlAttr returns the value of the named attribute, or "".
+func htmlAttr(n *html.Node, key string) string {
+	for _, a := range n.Attr {
+		if a.Key == key {
+			return a.Val
+		}
+	}
+	return ""
+}
+
+// htmlText returns the text content of n.
+func htmlText(n *html.Node) string {
+	var sb strings.Builder
+	walkHTML(n, func(n *html.Node) bool {
+		if n.Type == html.TextNode {
+			sb.WriteString(n.Data)
+		}
+		return true
+	})
+	return sb.String()
+}
+
+// uniqueHeadingID slugifies text into an id not yet in used, appending
+// -2, -3, ... on collisions, and records it.
+func uniqueHeadingID(text string, used map[string]bool) string {
+	base := models.Slugify(text)
+	if base == "" {
+		base = "heading"
+	}
+	id := base
+	for i := 2; used[id]; i++ {
+		id = fmt.Sprintf("%s-%d", base, i)
+	}
+	used[id] = true
+	return id
+}
+
+// nestHeadings nests each heading under the closest preceding heading of a
+// higher level.
+func nestHeadings(headings []*tocHeading) []*tocHeading {
+	var roots, stack []*tocHeading
+	for _, h := range headings {
+		for len(stack) > 0 && stack[len(stack)-1].level >= h.level {
+			stack = stack[:len(stack)-1]
+		}
+		if len(stack) == 0 {
+			roots = append(roots, h)
+		} else {
+			parent := stack[len(stack)-1]
+			parent.children = append(parent.children, h)
+		}
+		stack = append(stack, h)
+	}
+	return roots
+}
+
+// renderTOCNav renders headings as nested lists, using the same classes as
+// the components/toc_list.html partial.
+func renderTOCNav(headings []*tocHeading) string {
+	var sb strings.Builder
+	sb.WriteString(`<nav class="toc-nav" aria-label="Table of contents">`)
+	writeTOCList(&sb, headings, "toc-list")
+	sb.WriteString(`</nav>`)
+	return sb.String()
+}
+
+func writeTOCList(sb *strings.Builder, headings []*tocHeading, class string) {
+	fmt.Fprintf(sb, `<ul class="%s">`, class)
+	for _, h := range headings {
+		fmt.Fprintf(sb, `<li class="toc-item toc-item--h%d" data-level="%d"><a href="#%s" class="toc-link">%s</a>`,
+			h.level, h.level, html.EscapeString(h.id), html.EscapeString(h.text))
+		if len(h.children) > 0 {
+			writeTOCList(sb, h.children, "toc-list toc-list--nested")
+		}
+		sb.WriteString(`</li>`)
+	}
+	sb.WriteString(`</ul>`)
+}
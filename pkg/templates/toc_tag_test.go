@@ -0,0 +1,121 @@
+package templates
+
+import (
+	"strings"
+	"testing"
+)
+
+const tocTestBody = `<h1 id="title">Title</h1>
+<h2 id="intro">Intro</h2>
+<h3 id="setup">Setup <em>steps</em></h3>
+<pre><code><h2>Not a heading</h2></code></pre>
+<div>Inline <code><h3>also not</h3></code></div>
+<h4 id="deep">Deep</h4>
+<h2 id="usage">Usage</h2>`
+
+func renderTOC(t *testing.T, template, body string) string {
+	t.Helper()
+	engine, err := NewEngine("")
+	if err != nil {
+		t.Fatalf("Failed to create engine: %v", err)
+	}
+	got, err := engine.RenderString(template, NewContext(nil, body, nil))
+	if err != nil {
+		t.Fatalf("RenderString() error = %v", err)
+	}
+	return got
+}
+
+func TestTocTag_Nesting(t *testing.T) {
+	got := renderTOC(t, "{% toc %}", tocTestBody)
+
+	want := `<nav class="toc-nav" aria-label="Table of contents"><ul class="toc-list">` +
+		`<li class="toc-item toc-item--h2" data-level="2"><a href="#intro" class="toc-link">Intro</a>` +
+		`<ul class="toc-list toc-list--nested"><li class="toc-item toc-item--h3" data-level="3"><a href="#setup" class="toc-link">Setup steps</a>` +
+		`<ul class="toc-list toc-list--nested"><li class="toc-item toc-item--h4" data-level="4"><a href="#deep" class="toc-link">Deep</a></li></ul>` +
+		`</li></ul></li>` +
+		`<li class="toc-item toc-item--h2" data-level="2"><a href="#usage" class="toc-link">Usage</a></li>` +
+		`</ul></nav>`
+	if got != want {
+		t.Errorf("toc =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestTocTag_Arguments(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		want     []string
+		dontWant []string
+	}{
+		{"depth range", "{% toc min=2 max=3 %}", []string{"#intro", "#setup", "#usage"}, []string{"#deep", "#title"}},
+		{"include h1", "{% toc include_h1=true max=2 %}", []string{"#title", "#intro"}, []string{"#setup"}},
+		{"min 1 alone includes h1", "{% toc min=1 max=1 %}", []string{"#title"}, []string{"#intro"}},
+		{"exclude h1 overrides min", "{% toc min=1 include_h1=false %}", []string{"#intro"}, []string{"#title"}},
+		{"code is skipped", "{% toc %}", nil, []string{"Not a heading", "also not"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := renderTOC(t, tt.template, tocTestBody)
+			for _, s := range tt.want {
+				if !strings.Contains(got, s) {
+					t.Errorf("output missing %q:\n%s", s, got)
+				}
+			}
+			for _, s := range tt.dontWant {
+				if strings.Contains(got, s) {
+					t.Errorf("output should not contain %q:\n%s", s, got)
+				}
+			}
+		})
+	}
+}
+
+func TestTocTag_GeneratesMissingIDs(t *testing.T) {
+	body := `<div class="intro"><h2>Getting Started</h2></div><h2 id="getting-started">Taken</h2><h2>Getting Started</h2>`
+
+	got := renderTOC(t, "{% toc %}|{{ body|safe }}", body)
+	toc, rendered, _ := strings.Cut(got, "|")
+
+	for _, id := range []string{"getting-started-2", "getting-started", "getting-started-3"} {
+		if !strings.Contains(toc, `href="#`+id+`"`) {
+			t.Errorf("toc missing link to %q:\n%s", id, toc)
+		}
+		if !strings.Contains(rendered, `id="`+id+`"`) {
+			t.Errorf("body rendered after the tag should carry id %q:\n%s", id, rendered)
+		}
+	}
+}
+
+func TestTocTag_KeepsBodyWithIDs(t *testing.T) {
+	// Markup the HTML parser would normalize must survive when nothing changes
+	body := `<h2 id="a">A</h2><p>unclosed <b>bold`
+	got := renderTOC(t, "{% toc %}{{ body|safe }}", body)
+	if !strings.HasSuffix(got, body) {
+		t.Errorf("body should be untouched when every heading has an id:\n%s", got)
+	}
+}
+
+func TestTocTag_NoHeadings(t *testing.T) {
+	if got := renderTOC(t, "[{% toc %}]", "<p>Just text</p>"); got != "[]" {
+		t.Errorf("toc = %q, want empty output", got)
+	}
+}
+
+func TestTocTag_Errors(t *testing.T) {
+	engine, err := NewEngine("")
+	if err != nil {
+		t.Fatalf("Failed to create engine: %v", err)
+	}
+	for _, template := range []string{
+		"{% toc depth=2 %}",
+		"{% toc min %}",
+		"{% toc min=4 max=2 %}",
+		"{% toc max=7 %}",
+	} {
+		if _, err := engine.RenderString(template, NewContext(nil, tocTestBody, nil)); err == nil {
+			t.Errorf("RenderString(%q) expected error", template)
+		}
+	}
+}
@@ -0,0 +1,271 @@
+package templates
+
+import (
+	"html"
+	"net/url"
+	"strings"
+	"sync"
+
+	nethtml "golang.org/x/net/html"
+)
+
+var (
+	siteURLMu sync.RWMutex
+	siteURL   string
+)
+
+// SetSiteURL sets the base URL used by filters that need the site URL but
+// have no access to the template context, such as absolute_urls.
+func SetSiteURL(u string) {
+	siteURLMu.Lock()
+	defer siteURLMu.Unlock()
+	siteURL = strings.TrimSpace(u)
+}
+
+// GetSiteURL returns the base URL registered via SetSiteURL.
+func GetSiteURL() string {
+	siteURLMu.RLock()
+	defer siteURLMu.RUnlock()
+	return siteURL
+}
+
+// AbsoluteURLs rewrites root-relative and document-relative href, src, and
+// srcset attribute values in an HTML fragment into absolute URLs under baseURL.
+// Absolute URLs, protocol-relative URLs, and anchor links are left alone.
+// Root-relative URLs are placed under the path of baseURL, so a site served
+// from https://example.com/blog/ keeps its /blog prefix.
+//
+// Only the attribute values are touched; everything else, including tag and
+// attribute casing inside inline SVG, is copied through byte for byte.
+func AbsoluteURLs(content, baseURL string) string {
+	baseURL = strings.TrimSuffix(strings.TrimSpace(baseURL), "/")
+	if baseURL == "" || content == "" {
+		return content
+	}
+	base, err := url.Parse(baseURL + "/")
+	if err != nil || !base.IsAbs() {
+		return content
+	}
+
+	var out strings.Builder
+	out.Grow(len(content))
+
+	z := nethtml.NewTokenizer(strings.NewReader(content))
+	for {
+		tt := z.Next()
+		if tt == nethtml.ErrorToken {
+			// io.EOF or a tokenizer error; flush whatever is left unparsed.
+			out.Write(z.Raw())
+			break
+		}
+
+		raw := string(z.Raw())
+		if tt == nethtml.StartTagToken || tt == nethtml.SelfClosingTagToken {
+			raw = rewriteTagURLs(raw, base)
+		}
+		out.WriteString(raw)
+	}
+
+	return out.String()
+}
+
+// rewriteTagURLs rewrites the URL attributes of a single raw start tag,
+// preserving the original quoting style and every other byte of the tag.
+func rewriteTagURLs(tag string, base *url.URL) string {
+	var out strings.Builder
+	last := 0
+
+	i := 1 // skip '<'
+	for i < len(tag) && !isTagSpace(tag[i]) && tag[i] != '>' && tag[i] != '/' {
+		i++
+	}
+
+	for i < len(tag) {
+		for i < len(tag) && (isTagSpace(tag[i]) || tag[i] == '/') {
+			i++
+		}
+		if i >= len(tag) || tag[i] == '>' {
+			break
+		}
+
+		nameStart := i
+		for i < len(tag) && !isTagSpace(tag[i]) && tag[i] != '=' && tag[i] != '>' && tag[i] != '/' {
+			i++
+		}
+		name := strings.ToLower(tag[nameStart:i])
+
+		for i < len(tag) && isTagSpace(tag[i]) {
+			i++
+		}
+		if i >= len(tag) || tag[i] != '=' {
+			continue
+		}
+		i++
+		for i < len(tag) && isTagSpace(tag[i]) {
+			i++
+		}
+		if i >= len(tag) {
+			break
+		}
+
+		quote := byte(0)
+		if tag[i] == '"' || tag[i] == '\'' {
+			quote = tag[i]
+			i++
+		}
+		valueStart := i
+		for i < len(tag) {
+			if quote != 0 && tag[i] == quote {
+				break
+			}
+			if quote == 0 && (isTagSpace(tag[i]) || tag[i] == '>') {
+				break
+			}
+			i++
+		}
+		valueEnd := i
+		if quote != 0 && i < len(tag) {
+			i++
+		}
+
+		if name != "href" && name != "src" && name != "srcset" {
+			continue
+		}
+
+		decoded := html.UnescapeString(tag[valueStart:valueEnd])
+		var rewritten string
+		if name == "srcset" {
+			rewritten = absolutizeSrcset(decoded, base)
+		} else {
+			rewritten = absolutizeURL(decoded, base)
+		}
+		if rewritten == decoded {
+			continue
+		}
+
+		out.WriteString(tag[last:valueStart])
+		if quote == 0 {
+			out.WriteString(`"` + escapeAttrValue(rewritten, '"') + `"`)
+		} else {
+			out.WriteString(escapeAttrValue(rewritten, quote))
+		}
+		last = valueEnd
+	}
+
+	if last == 0 {
+		return tag
+	}
+	out.WriteString(tag[last:])
+	return out.String()
+}
+
+func isTagSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\f'
+}
+
+// absolutizeSrcset rewrites each candidate URL in a srcset value, keeping
+// the width/density descriptors intact.
+func absolutizeSrcset(srcset string, base *url.URL) string {
+	candidates := splitSrcset(srcset)
+	changed := false
+	parts := make([]string, len(candidates))
+	for i, c := range candidates {
+		if abs := absolutizeURL(c.url, base); abs != c.url {
+			c.url = abs
+			changed = true
+		}
+		parts[i] = c.url
+		if c.descriptor != "" {
+			parts[i] += " " + c.descriptor
+		}
+	}
+	if !changed {
+		return srcset
+	}
+	return strings.Join(parts, ", ")
+}
+
+// srcsetCandidate is one image candidate of a srcset value.
+type srcsetCandidate struct {
+	url        string
+	descriptor string
+}
+
+// splitSrcset splits a srcset value into candidates following the HTML
+// grammar: a URL runs to the next whitespace, so commas inside it (as in
+// data: URIs) are kept, and a comma only separates candidates when it ends
+// the URL or follows the descriptors.
+func splitSrcset(srcset string) []srcsetCandidate {
+	var candidates []srcsetCandidate
+	s := srcset
+	for {
+		s = strings.TrimLeft(s, " \t\n\r\f,")
+		if s == "" {
+			return candidates
+		}
+
+		end := strings.IndexAny(s, " \t\n\r\f")
+		if end < 0 {
+			end = len(s)
+		}
+		c := srcsetCandidate{url: s[:end]}
+		s = s[end:]
+
+		if trimmed := strings.TrimRight(c.url, ","); trimmed != c.url {
+			// Trailing commas end the candidate without descriptors
+			c.url = trimmed
+		} else {
+			descriptor := s
+			if comma := strings.IndexByte(s, ','); comma >= 0 {
+				descriptor, s = s[:comma], s[comma+1:]
+			} else {
+				s = ""
+			}
+			c.descriptor = strings.Join(strings.Fields(descriptor), " ")
+		}
+		candidates = append(candidates, c)
+	}
+}
+
+// absolutizeURL resolves a single relative URL against base, which ends in
+// a slash. Root-relative URLs stay under base's path.
+func absolutizeURL(raw string, base *url.URL) string {
+	u := strings.TrimSpace(raw)
+	if u == "" ||
+		strings.HasPrefix(u, "#") ||
+		strings.HasPrefix(u, "//") ||
+		hasURLScheme(u) {
+		return raw
+	}
+
+	ref, err := url.Parse(strings.TrimPrefix(u, "/"))
+	if err != nil {
+		return raw
+	}
+	return base.ResolveReference(ref).String()
+}
+
+// hasURLScheme reports whether u begins with a URL scheme such as
+// "https:", "mailto:", or "data:".
+func hasURLScheme(u string) bool {
+	for i, c := range u {
+		switch {
+		case c == ':':
+			return i > 0
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z':
+		case i > 0 && (c >= '0' && c <= '9' || c == '+' || c == '-' || c == '.'):
+		default:
+			return false
+		}
+	}
+	return false
+}
+
+// escapeAttrValue escapes a value for use inside an attribute quoted with quote.
+func escapeAttrValue(v string, quote byte) string {
+	v = strings.ReplaceAll(v, "&", "&amp;")
+	if quote == '\'' {
+		return strings.ReplaceAll(v, "'", "&#39;")
+	}
+	return strings.ReplaceAll(v, `"`, "&#34;")
+}
@@ -0,0 +1,36 @@
+package themes
+
+import (
+	"io/fs"
+	"sync"
+)
+
+// DefaultThemeName is the name of the built-in theme.
+const DefaultThemeName = "default"
+
+var (
+	registryMu sync.RWMutex
+	registry   = make(map[string]fs.FS)
+)
+
+// RegisterTemplates makes fsys the embedded template set of the theme
+// name, replacing any set registered before. Templates a theme does not
+// ship fall back to the default theme, so a theme only needs the files it
+// changes. Call it from an init function to ship a theme inside the binary.
+func RegisterTemplates(name string, fsys fs.FS) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	registry[name] = fsys
+}
+
+// Templates returns the embedded template set of the theme name. The
+// default theme always has one; other themes only if registered.
+func Templates(name string) (fs.FS, bool) {
+	if name == "" || name == DefaultThemeName {
+		return DefaultTemplates(), true
+	}
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	fsys, ok := registry[name]
+	return fsys, ok
+}
@@ -6,7 +6,9 @@
 // # Features
 //
 //   - Post list with filtering and sorting
-//   - Tag browsing
+//   - Live full-text search of the post list (/)
+//   - Preview pane rendering the selected post (v)
+//   - Tag browsing with post counts and multi-tag drill-down
 //   - Feed navigation
 //   - Vim-like keybindings
 //
@@ -3,22 +3,30 @@ package tui
 import "github.com/charmbracelet/bubbles/key"
 
 type keyMapType struct {
-	Up      key.Binding
-	Down    key.Binding
-	Quit    key.Binding
-	Filter  key.Binding
-	Command key.Binding
-	Help    key.Binding
-	Posts   key.Binding
-	Tags    key.Binding
-	Feeds   key.Binding
-	Config  key.Binding
-	Search  key.Binding
-	Enter   key.Binding
-	Escape  key.Binding
-	Edit    key.Binding
-	Sort    key.Binding
-	Refresh key.Binding
+	Up         key.Binding
+	Down       key.Binding
+	Quit       key.Binding
+	Filter     key.Binding
+	PostSearch key.Binding
+	Command    key.Binding
+	Help       key.Binding
+	Posts      key.Binding
+	Tags       key.Binding
+	Feeds      key.Binding
+	Config     key.Binding
+	Search     key.Binding
+	Enter      key.Binding
+	Escape     key.Binding
+	Edit       key.Binding
+	Sort       key.Binding
+	Refresh    key.Binding
+	SelectTag  key.Binding
+
+	Preview         key.Binding
+	PreviewDown     key.Binding
+	PreviewUp       key.Binding
+	PreviewPageDown key.Binding
+	PreviewPageUp   key.Binding
 }
 
 var keyMap = keyMapType{
@@ -35,8 +43,12 @@ var keyMap = keyMapType{
 		key.WithHelp("q", "quit"),
 	),
 	Filter: key.NewBinding(
+		key.WithKeys("F"),
+		key.WithHelp("F", "filter"),
+	),
+	PostSearch: key.NewBinding(
 		key.WithKeys("/"),
-		key.WithHelp("/", "filter"),
+		key.WithHelp("/", "search posts"),
 	),
 	Command: key.NewBinding(
 		key.WithKeys(":"),
@@ -86,4 +98,28 @@ var keyMap = keyMapType{
 		key.WithKeys("r"),
 		key.WithHelp("r", "refresh"),
 	),
+	SelectTag: key.NewBinding(
+		key.WithKeys(" "),
+		key.WithHelp("space", "select tag"),
+	),
+	Preview: key.NewBinding(
+		key.WithKeys("v"),
+		key.WithHelp("v", "preview"),
+	),
+	PreviewDown: key.NewBinding(
+		key.WithKeys("J"),
+		key.WithHelp("J", "scroll preview down"),
+	),
+	PreviewUp: key.NewBinding(
+		key.WithKeys("K"),
+		key.WithHelp("K", "scroll preview up"),
+	),
+	PreviewPageDown: key.NewBinding(
+		key.WithKeys("ctrl+d"),
+		key.WithHelp("ctrl+d", "preview half page down"),
+	),
+	PreviewPageUp: key.NewBinding(
+		key.WithKeys("ctrl+u"),
+		key.WithHelp("ctrl+u", "preview half page up"),
+	),
 }
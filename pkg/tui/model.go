@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
+	"slices"
 	"strings"
 	"time"
 	"unicode/utf8"
@@ -42,12 +43,14 @@ const (
 	ModeNormal  Mode = "normal"
 	ModeFilter  Mode = "filter"
 	ModeCommand Mode = "command"
+	ModeSearch  Mode = "search"
 )
 
 // FilterContext tracks the active filter for drill-down navigation
 type FilterContext struct {
-	Type string // "tag" or "feed"
-	Name string // The tag name or feed name
+	Type string   // "tag" or "feed"
+	Name string   // The tag name or feed name
+	Tags []string // For tag filters, every tag a post must have
 }
 
 // sortHotkeyMap maps capital letter keys to sort fields (k9s-inspired)
@@ -104,6 +107,10 @@ type Model struct {
 	// Drill-down filter state
 	activeFilter *FilterContext // Active tag/feed filter for drill-down navigation
 
+	// Tag browser state
+	tagSortBy    string   // "count" or "name"
+	selectedTags []string // Tags marked with space, in selection order
+
 	// Footer button tracking for mouse clicks
 	footerButtons []footerButton
 	mouseX        int // Current mouse X position
@@ -134,6 +141,18 @@ type Model struct {
 	searchFuzzy          bool               // Fuzzy matching enabled
 	searchLimit          int                // Max results (0 = default 50)
 
+	// Post list search state (/ in the posts view)
+	postSearchInput  textinput.Model      // Query input shown in the status bar
+	postSearchQuery  string               // Active query; empty shows the plain post list
+	postSearchHits   []services.SearchHit // Hits within the current post list
+	postSearchCursor int                  // Selected hit
+
+	// Preview pane state
+	previewOpen     bool                    // Whether the preview pane is shown in the posts view
+	previewViewport viewport.Model          // Scrollable rendered content of the selected post
+	previewPost     *models.Post            // Post shown (or being rendered) in the pane
+	previewCache    map[*models.Post]string // Rendered content at the current pane width
+
 	// Config view state
 	configSections []configSection // Expanded config data
 	configCursor   int             // Current cursor position in config view
@@ -242,6 +261,7 @@ func NewModelWithTheme(app *services.App, theme *Theme) Model {
 		filterInput:     filterInput,
 		cmdInput:        cmdInput,
 		helpSearchInput: helpSearchInput,
+		postSearchInput: newPostSearchInput(),
 		postsTable:      postsTable,
 		tagsTable:       tagsTable,
 		feedsTable:      feedsTable,
@@ -354,8 +374,7 @@ func (m Model) handleWindowResize(msg tea.WindowSizeMsg) (tea.Model, tea.Cmd) {
 	m.height = msg.Height
 
 	// Update table dimensions with theme
-	m.postsTable = createPostsTableWithTheme(msg.Width, m.theme)
-	m.postsTable.SetHeight(msg.Height - 10) // Leave room for header/footer
+	m.layoutPosts()
 	m.tagsTable = createTagsTableWithTheme(msg.Width, m.theme)
 	m.tagsTable.SetHeight(msg.Height - 10)
 	m.feedsTable = createFeedsTableWithTheme(msg.Width, m.theme)
@@ -368,10 +387,6 @@ func (m Model) handleWindowResize(msg tea.WindowSizeMsg) (tea.Model, tea.Cmd) {
 		}
 	}
 
-	// Repopulate table if we have posts
-	if len(m.posts) > 0 {
-		m.postsTable.SetRows(m.postsToRows())
-	}
 	// Repopulate tags table if we have tags
 	if len(m.tags) > 0 {
 		m.tagsTable.SetRows(m.tagsToRows())
@@ -417,6 +432,15 @@ func (m *Model) updateViewportDimensions(w, h int) {
 
 // Update handles messages
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	next, cmd := m.update(msg)
+	// Keep the preview on the selected post however the selection moved
+	if model, ok := next.(Model); ok && model.previewOpen {
+		return model, tea.Batch(cmd, model.syncPreview())
+	}
+	return next, cmd
+}
+
+func (m Model) update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
 		return m.handleWindowResize(msg)
@@ -430,11 +454,13 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case postsLoadedMsg:
 		m.posts = msg.posts
 		m.postsTable.SetRows(m.postsToRows())
+		if m.postSearchActive() {
+			return m, m.runPostSearch(m.postSearchQuery)
+		}
 		return m, nil
 
 	case tagsLoadedMsg:
-		m.tags = msg.tags
-		m.tagsTable.SetRows(m.tagsToRows())
+		m.setTags(msg.tags)
 		return m, nil
 
 	case feedsLoadedMsg:
@@ -462,10 +488,21 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.refreshing = false
 		m.lastRefresh = time.Now()
 		m.posts = msg.posts
-		m.tags = msg.tags
 		m.feeds = msg.feeds
 		m.postsTable.SetRows(m.postsToRows())
-		m.tagsTable.SetRows(m.tagsToRows())
+		m.setTags(msg.tags)
+		m.resetPreview()
+		if m.postSearchActive() {
+			return m, m.runPostSearch(m.postSearchQuery)
+		}
+		return m, nil
+
+	case postSearchResultsMsg:
+		m.handlePostSearchResults(msg)
+		return m, nil
+
+	case previewRenderedMsg:
+		m.handlePreviewRendered(msg)
 		return m, nil
 
 	case searchResultsMsg:
@@ -508,11 +545,18 @@ func (m Model) feedsToRows() []table.Row {
 
 // tagToRow converts a single tag to a table row with statistics
 func (m Model) tagToRow(t services.TagInfo) table.Row {
-	// Tag name (truncate to 28 chars to leave room for selection indicator)
+	// Tag name with any collapsed synonyms, e.g. "javascript (js)"
 	name := t.Name
+	if len(t.Aliases) > 0 {
+		name += " (" + strings.Join(t.Aliases, ", ") + ")"
+	}
+	// Truncate to 28 chars to leave room for selection indicator
 	if len(name) > 28 {
 		name = name[:25] + "..."
 	}
+	if m.tagSelected(t.Name) {
+		name = "✓ " + name
+	}
 
 	// Count
 	count := fmt.Sprintf("%d", t.Count)
@@ -525,7 +569,7 @@ func (m Model) tagToRow(t services.TagInfo) table.Row {
 	for _, post := range m.posts {
 		hasTag := false
 		for _, tag := range post.Tags {
-			if tag == t.Name {
+			if tag == t.Name || slices.Contains(t.Aliases, tag) {
 				hasTag = true
 				break
 			}
@@ -667,6 +711,8 @@ func (m Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m.handleFilterMode(msg)
 	case ModeCommand:
 		return m.handleCommandMode(msg)
+	case ModeSearch:
+		return m.handlePostSearchMode(msg)
 	case ModeNormal:
 		// Fall through to normal mode handling below
 	}
@@ -818,6 +864,12 @@ func (m Model) handleNormalModeKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		m.filterInput.Focus()
 		return m, textinput.Blink
 
+	case key.Matches(msg, keyMap.PostSearch):
+		if m.view == ViewPosts {
+			return m.startPostSearch()
+		}
+		return m, nil
+
 	case key.Matches(msg, keyMap.Command):
 		m.mode = ModeCommand
 		m.cmdInput.Focus()
@@ -867,6 +919,20 @@ func (m Model) handleNormalModeKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	case key.Matches(msg, keyMap.Refresh):
 		return m.handleRefreshKey()
 
+	case key.Matches(msg, keyMap.Preview):
+		return m.togglePreview()
+
+	case key.Matches(msg, keyMap.SelectTag):
+		if m.view == ViewTags {
+			return m.toggleTagSelection()
+		}
+		return m, nil
+
+	case m.previewOpen && m.view == ViewPosts &&
+		(key.Matches(msg, keyMap.PreviewDown) || key.Matches(msg, keyMap.PreviewUp) ||
+			key.Matches(msg, keyMap.PreviewPageDown) || key.Matches(msg, keyMap.PreviewPageUp)):
+		return m.handlePreviewScroll(msg)
+
 	default:
 		// Handle capital letter hotkeys for sorting (k9s-inspired)
 		if field, ok := sortHotkeyMap[msg.String()]; ok {
@@ -917,6 +983,9 @@ func (m Model) handleConfigKey() (tea.Model, tea.Cmd) {
 }
 
 func (m Model) handleSortKey() (tea.Model, tea.Cmd) {
+	if m.view == ViewTags {
+		return m.toggleTagSort()
+	}
 	if m.view == ViewPosts {
 		m.showSortMenu = true
 		// Set sortMenuIdx to current sort field
@@ -965,6 +1034,15 @@ func (m Model) handleRefreshKey() (tea.Model, tea.Cmd) {
 }
 
 func (m Model) handleNavigation(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	// Search results replace the posts table while a search is active
+	if m.view == ViewPosts && m.postSearchActive() {
+		if key.Matches(msg, keyMap.Up) {
+			m.movePostSearchCursor(-1)
+		} else {
+			m.movePostSearchCursor(1)
+		}
+		return m, nil
+	}
 	// Let the table handle navigation when in posts view
 	if m.view == ViewPosts {
 		var cmd tea.Cmd
@@ -1088,11 +1166,12 @@ func (m Model) handleEnter() (tea.Model, tea.Cmd) {
 
 // handleEnterPostsList handles the Enter key when viewing the posts list.
 func (m Model) handleEnterPostsList() (tea.Model, tea.Cmd) {
-	if len(m.posts) == 0 || m.cursor >= len(m.posts) {
+	post := m.getSelectedPost()
+	if post == nil {
 		return m, nil
 	}
 
-	m.selectedPost = m.posts[m.cursor]
+	m.selectedPost = post
 	m.previousView = m.view
 	m.view = ViewPostDetail
 
@@ -1107,15 +1186,16 @@ func (m Model) handleEnterTagsList() (tea.Model, tea.Cmd) {
 		return m, nil
 	}
 
-	selectedTag := m.tags[m.cursor]
-	m.activeFilter = &FilterContext{
-		Type: "tag",
-		Name: selectedTag.Name,
+	// Multi-selected tags are ANDed; otherwise use the tag under the cursor
+	tags := m.selectedTags
+	if len(tags) == 0 {
+		tags = []string{m.tags[m.cursor].Name}
 	}
+	m.activeFilter = newTagFilter(tags)
 	m.view = ViewPosts
 	m.cursor = 0
 	m.postsTable.SetCursor(0)
-	return m, m.loadPostsForTag(selectedTag.Name)
+	return m, m.loadPostsForTags(tags)
 }
 
 // handleEnterFeedsList handles the Enter key when viewing the feeds list.
@@ -1280,6 +1360,11 @@ func (m Model) handleEscape() (tea.Model, tea.Cmd) {
 	case ViewHelp:
 		m.view = ViewPosts
 	case ViewPosts:
+		// Clear an active search first, then any drill-down filter
+		if m.postSearchActive() {
+			m.clearPostSearch()
+			return m, nil
+		}
 		// If there's an active filter, clear it and reload all posts
 		if m.activeFilter != nil {
 			m.activeFilter = nil
@@ -1287,8 +1372,14 @@ func (m Model) handleEscape() (tea.Model, tea.Cmd) {
 			m.postsTable.SetCursor(0)
 			return m, m.loadPosts()
 		}
-	case ViewTags, ViewFeeds:
-		// Escape does nothing in tag/feed list views
+	case ViewTags:
+		// Clear the tag multi-selection
+		if len(m.selectedTags) > 0 {
+			m.selectedTags = nil
+			m.tagsTable.SetRows(m.tagsToRows())
+		}
+	case ViewFeeds:
+		// Escape does nothing in the feed list view
 	case ViewConfig:
 		// Return to posts view
 		m.view = ViewPosts
@@ -1469,16 +1560,37 @@ Drill-Down Navigation:
              In feeds view: show posts in selected feed
   Esc        Clear active filter, return to all posts
 
+Tags View:
+  s          Sort by post count or name
+  Space      Select tag; Enter shows posts with all selected tags
+  Esc        Clear tag selection
+
 Actions:
   e          Edit selected post in $EDITOR
   s          Sort menu (Date, Title, Word Count, Path)
+  v          Toggle preview pane for the selected post
 
 Modes:
-  /          Filter mode (filter posts with expressions)
+  /          Search posts (title, description, tags, body)
+  F          Filter mode (filter posts with expressions)
   :          Command mode
 
+Post Search:
+  Type to filter the post list as you go; matches are highlighted
+  with the matching context shown under each result.
+  ↑/↓        Navigate results while typing (Ctrl+N/Ctrl+P also work)
+  Enter      Finish typing; then j/k navigate, Enter opens, e edits
+  Esc        Clear search and return to the full post list
+  Queries:   go templates, "exact phrase", tag:go, title:foo
+
+Preview Pane:
+  Shows the selected post rendered beside the list (below it on
+  narrow terminals) and follows the selection.
+  J/K        Scroll preview down/up
+  Ctrl+D/U   Scroll preview half a page down/up
+
 Filter Syntax:
-  Press / to enter filter mode. Filter expressions support:
+  Press F to enter filter mode. Filter expressions support:
 
   Comparison:    published == True, date >= '2024-01-01'
   Membership:    'python' in tags, 'draft' not in tags
@@ -1718,7 +1830,7 @@ func (m Model) refreshData() tea.Cmd {
 			if m.activeFilter != nil {
 				switch m.activeFilter.Type {
 				case "tag":
-					posts, err = m.app.Tags.GetPosts(context.Background(), m.activeFilter.Name, opts)
+					posts, err = m.app.Tags.GetPostsWithAll(context.Background(), m.activeFilter.Tags, opts)
 				case "feed":
 					posts, err = m.app.Feeds.GetPosts(context.Background(), m.activeFilter.Name, opts)
 				default:
@@ -1753,21 +1865,6 @@ func (m Model) refreshData() tea.Cmd {
 	)
 }
 
-// loadPostsForTag loads posts filtered by a specific tag
-func (m Model) loadPostsForTag(tag string) tea.Cmd {
-	return func() tea.Msg {
-		opts := services.ListOptions{
-			SortBy:    m.sortBy,
-			SortOrder: m.sortOrder,
-		}
-		posts, err := m.app.Tags.GetPosts(context.Background(), tag, opts)
-		if err != nil {
-			return errMsg{err}
-		}
-		return postsLoadedMsg{posts}
-	}
-}
-
 // loadPostsForFeed loads posts filtered by a specific feed
 func (m Model) loadPostsForFeed(feedName string) tea.Cmd {
 	return func() tea.Msg {
@@ -1788,6 +1885,9 @@ func (m Model) getSelectedPost() *models.Post {
 	if m.view != ViewPosts || len(m.posts) == 0 {
 		return nil
 	}
+	if m.postSearchActive() {
+		return m.selectedSearchPost()
+	}
 	if m.cursor < 0 || m.cursor >= len(m.posts) {
 		return nil
 	}
@@ -1883,6 +1983,8 @@ func (m Model) renderLayout(content string) string {
 		statusBar = "Filter: " + m.filterInput.View()
 	case ModeCommand:
 		statusBar = ":" + m.cmdInput.View()
+	case ModeSearch:
+		statusBar = "/" + m.postSearchInput.View()
 	default:
 		// Build sort indicator
 		sortArrow := "↓"
@@ -1996,13 +2098,24 @@ func (m *Model) renderFooter(sortIndicator string) string {
 			return *model, model.loadFeeds()
 		})
 
-		addButton("filter", "/", func(model *Model) (tea.Model, tea.Cmd) {
+		addButton("preview", "v", func(model *Model) (tea.Model, tea.Cmd) {
+			return model.togglePreview()
+		})
+
+		addButton("search", "/", func(model *Model) (tea.Model, tea.Cmd) {
+			if model.view != ViewPosts {
+				return *model, nil
+			}
+			return model.startPostSearch()
+		})
+
+		addButton("filter", "F", func(model *Model) (tea.Model, tea.Cmd) {
 			model.mode = ModeFilter
 			model.filterInput.Focus()
 			return *model, textinput.Blink
 		})
 
-		addButton("search", "S", func(model *Model) (tea.Model, tea.Cmd) {
+		addButton("search view", "S", func(model *Model) (tea.Model, tea.Cmd) {
 			model.initSearchView()
 			model.view = ViewSearch
 			model.searchInput.Focus()
@@ -2046,6 +2159,19 @@ func (m Model) renderPosts() string {
 	if len(m.posts) == 0 {
 		return "No posts found."
 	}
+	list := m.renderPostList()
+	if m.previewOpen {
+		return m.renderWithPreview(list)
+	}
+	return list
+}
+
+// renderPostList renders the posts table, or the search results while a
+// search is active.
+func (m Model) renderPostList() string {
+	if m.postSearchActive() {
+		return m.renderPostSearch()
+	}
 
 	var sb strings.Builder
 
@@ -2065,8 +2191,8 @@ func (m Model) renderTags() string {
 
 	var sb strings.Builder
 
-	// Render the table with header showing count
-	header := fmt.Sprintf("Tags (%d)", len(m.tags))
+	// Render the table with header showing count, sort, and selection
+	header := m.tagsHeader()
 	sb.WriteString(m.theme.HeaderStyle.Render(header))
 	sb.WriteString("\n\n")
 	sb.WriteString(m.tagsTable.View())
@@ -0,0 +1,316 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/WaylonWalker/markata-go/pkg/models"
+	"github.com/WaylonWalker/markata-go/pkg/services"
+)
+
+// postSearchResultsMsg carries SearchService hits for a post list query.
+type postSearchResultsMsg struct {
+	query string
+	hits  []services.SearchHit
+}
+
+// newPostSearchInput creates the input shown in the status bar while
+// searching the post list.
+func newPostSearchInput() textinput.Model {
+	input := textinput.New()
+	input.Placeholder = "title, tags, body... (tag:go, title:\"foo bar\")"
+	input.CharLimit = 200
+	return input
+}
+
+// startPostSearch enters search mode on the posts view, keeping any
+// existing query so it can be refined.
+func (m Model) startPostSearch() (tea.Model, tea.Cmd) {
+	m.view = ViewPosts
+	m.mode = ModeSearch
+	m.postSearchInput.SetValue(m.postSearchQuery)
+	m.postSearchInput.CursorEnd()
+	m.postSearchInput.Focus()
+	return m, textinput.Blink
+}
+
+// postSearchActive reports whether search results replace the post list.
+func (m Model) postSearchActive() bool {
+	return m.postSearchQuery != ""
+}
+
+// handlePostSearchMode processes keys while typing a post search.
+// Printable keys always go to the input, so letters like j, k, and q never
+// trigger list shortcuts; results are navigated with the arrow keys or
+// Ctrl+N/Ctrl+P, or with the usual vim keys after pressing Enter.
+func (m Model) handlePostSearchMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.mode = ModeNormal
+		m.postSearchInput.Blur()
+		m.clearPostSearch()
+		return m, nil
+	case "enter":
+		m.mode = ModeNormal
+		m.postSearchInput.Blur()
+		return m, nil
+	case "down", "ctrl+n":
+		m.movePostSearchCursor(1)
+		return m, nil
+	case "up", "ctrl+p":
+		m.movePostSearchCursor(-1)
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.postSearchInput, cmd = m.postSearchInput.Update(msg)
+
+	query := strings.TrimSpace(m.postSearchInput.Value())
+	if query == m.postSearchQuery {
+		return m, cmd
+	}
+	m.postSearchQuery = query
+	if query == "" {
+		m.clearPostSearch()
+		return m, cmd
+	}
+	return m, tea.Batch(cmd, m.runPostSearch(query))
+}
+
+// clearPostSearch drops the query and results, restoring the post list.
+func (m *Model) clearPostSearch() {
+	m.postSearchQuery = ""
+	m.postSearchHits = nil
+	m.postSearchCursor = 0
+	m.postSearchInput.SetValue("")
+}
+
+// movePostSearchCursor moves the selected search result by delta.
+func (m *Model) movePostSearchCursor(delta int) {
+	m.postSearchCursor += delta
+	if m.postSearchCursor >= len(m.postSearchHits) {
+		m.postSearchCursor = len(m.postSearchHits) - 1
+	}
+	if m.postSearchCursor < 0 {
+		m.postSearchCursor = 0
+	}
+}
+
+// runPostSearch queries the app's SearchService so the TUI shares its query
+// syntax, ranking, and snippets.
+func (m Model) runPostSearch(query string) tea.Cmd {
+	if m.app == nil || m.app.Search == nil {
+		return nil
+	}
+	search := m.app.Search
+	return func() tea.Msg {
+		hits, err := search.Search(context.Background(), query, services.SearchOptions{})
+		if err != nil {
+			return errMsg{err}
+		}
+		return postSearchResultsMsg{query: query, hits: hits}
+	}
+}
+
+// handlePostSearchResults keeps the hits that are in the current post list,
+// so searches respect the active filter, tag, or feed. Results for a query
+// that has since changed are dropped.
+func (m *Model) handlePostSearchResults(msg postSearchResultsMsg) {
+	if msg.query != m.postSearchQuery {
+		return
+	}
+
+	listed := make(map[*models.Post]bool, len(m.posts))
+	for _, p := range m.posts {
+		listed[p] = true
+	}
+	hits := make([]services.SearchHit, 0, len(msg.hits))
+	for i := range msg.hits {
+		if listed[msg.hits[i].Post] {
+			hits = append(hits, msg.hits[i])
+		}
+	}
+
+	m.postSearchHits = hits
+	m.postSearchCursor = 0
+}
+
+// selectedSearchPost returns the post under the search result cursor.
+func (m Model) selectedSearchPost() *models.Post {
+	if m.postSearchCursor < 0 || m.postSearchCursor >= len(m.postSearchHits) {
+		return nil
+	}
+	return m.postSearchHits[m.postSearchCursor].Post
+}
+
+// renderPostSearch renders search results as a list with the matching
+// context under each title and query terms highlighted.
+func (m Model) renderPostSearch() string {
+	theme := m.getTheme()
+	terms := searchTerms(m.postSearchQuery)
+
+	var sb strings.Builder
+	header := fmt.Sprintf("Search %q (%d of %d posts)", m.postSearchQuery, len(m.postSearchHits), len(m.posts))
+	sb.WriteString(theme.HeaderStyle.Render(header))
+	sb.WriteString("\n\n")
+
+	if len(m.postSearchHits) == 0 {
+		sb.WriteString(theme.SubtleStyle.Render("No matching posts."))
+		return sb.String()
+	}
+
+	listWidth, listHeight := m.postListSize()
+	width := listWidth - 4
+	if width < 20 {
+		width = 76
+	}
+
+	// Each result takes three lines: title, snippet, blank separator
+	perPage := listHeight / 3
+	if perPage < 1 {
+		perPage = 5
+	}
+	start := 0
+	if m.postSearchCursor >= perPage {
+		start = m.postSearchCursor - perPage + 1
+	}
+	end := start + perPage
+	if end > len(m.postSearchHits) {
+		end = len(m.postSearchHits)
+	}
+
+	selectedStyle := lipgloss.NewStyle().
+		Foreground(theme.Colors.SelectedText).
+		Background(theme.Colors.SelectedBg).
+		Bold(true)
+	snippetStyle := theme.SubtleStyle
+
+	for i := start; i < end; i++ {
+		hit := m.postSearchHits[i]
+
+		title := "(untitled)"
+		if hit.Post.Title != nil && *hit.Post.Title != "" {
+			title = *hit.Post.Title
+		}
+		line := truncateRunes(title, width-2)
+		if i == m.postSearchCursor {
+			sb.WriteString(selectedStyle.Render("> " + line))
+		} else {
+			sb.WriteString("  " + highlightTerms(line, terms))
+		}
+		sb.WriteString("\n")
+
+		snippet := hit.Snippet
+		if snippet == "" && hit.Post.Description != nil {
+			snippet = *hit.Post.Description
+		}
+		snippet = truncateRunes(snippet, width-4)
+		sb.WriteString("    " + highlightTermsWith(snippet, terms, snippetStyle))
+		sb.WriteString("\n\n")
+	}
+
+	return strings.TrimRight(sb.String(), "\n")
+}
+
+// searchTerms extracts the words to highlight from a search query: free
+// text terms, quoted phrases, and filter values such as the "go" in
+// "tag:go".
+func searchTerms(query string) []string {
+	var terms []string
+	var current strings.Builder
+	inQuotes := false
+	flush := func() {
+		term := current.String()
+		current.Reset()
+		if _, value, ok := strings.Cut(term, ":"); ok && !strings.HasPrefix(term, `"`) {
+			term = value
+		}
+		if term = strings.Trim(term, `"`); term != "" {
+			terms = append(terms, term)
+		}
+	}
+	for _, r := range query {
+		switch {
+		case r == '"':
+			inQuotes = !inQuotes
+			current.WriteRune(r)
+		case r == ' ' && !inQuotes:
+			flush()
+		default:
+			current.WriteRune(r)
+		}
+	}
+	flush()
+	return terms
+}
+
+// highlightTerms highlights case-insensitive occurrences of terms in text.
+func highlightTerms(text string, terms []string) string {
+	return highlightTermsWith(text, terms, lipgloss.NewStyle())
+}
+
+// highlightTermsWith is like highlightTerms but renders the text between
+// matches with base.
+func highlightTermsWith(text string, terms []string, base lipgloss.Style) string {
+	lower := strings.ToLower(text)
+	if len(lower) != len(text) || len(terms) == 0 {
+		// Case folding changed byte offsets; render without highlights
+		return base.Render(text)
+	}
+
+	// Mark every byte covered by a match
+	marked := make([]bool, len(text))
+	for _, term := range terms {
+		needle := strings.ToLower(term)
+		if needle == "" {
+			continue
+		}
+		for offset := 0; ; {
+			i := strings.Index(lower[offset:], needle)
+			if i < 0 {
+				break
+			}
+			for j := offset + i; j < offset+i+len(needle); j++ {
+				marked[j] = true
+			}
+			offset += i + len(needle)
+		}
+	}
+
+	highlight := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("0")).
+		Background(lipgloss.Color("11"))
+
+	var sb strings.Builder
+	for start := 0; start < len(text); {
+		end := start
+		for end < len(text) && marked[end] == marked[start] {
+			end++
+		}
+		if marked[start] {
+			sb.WriteString(highlight.Render(text[start:end]))
+		} else {
+			sb.WriteString(base.Render(text[start:end]))
+		}
+		start = end
+	}
+	return sb.String()
+}
+
+// truncateRunes shortens s to at most n runes, adding an ellipsis.
+func truncateRunes(s string, n int) string {
+	runes := []rune(s)
+	if n <= 0 || len(runes) <= n {
+		return s
+	}
+	if n == 1 {
+		return "…"
+	}
+	return string(runes[:n-1]) + "…"
+}
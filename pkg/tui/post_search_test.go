@@ -0,0 +1,201 @@
+package tui
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/WaylonWalker/markata-go/pkg/lifecycle"
+	"github.com/WaylonWalker/markata-go/pkg/models"
+	"github.com/WaylonWalker/markata-go/pkg/services"
+)
+
+func newPostSearchTestModel(t *testing.T) (m Model, posts []*models.Post) {
+	t.Helper()
+
+	post := func(path, title, content string, tags ...string) *models.Post {
+		return &models.Post{Path: path, Title: &title, Content: content, Tags: tags}
+	}
+	posts = []*models.Post{
+		post("go.md", "Writing Go", "Go templates render the site.", "go"),
+		post("python.md", "Python Notes", "Decorators wrap functions."),
+		post("kit.md", "Toolkit", "A jq and kubectl cheat sheet."),
+	}
+
+	manager := lifecycle.NewManager()
+	manager.SetPosts(posts)
+
+	m = NewModel(services.NewApp(manager))
+	m.posts = posts
+	m.width = 80
+	m.height = 30
+	return m, posts
+}
+
+// typeKeys sends printable keys to the model, delivering search results
+// for each query change as the program would.
+func typeKeys(t *testing.T, m Model, keys string) Model {
+	t.Helper()
+	for _, r := range keys {
+		before := m.postSearchQuery
+		next, _ := m.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+		m = next.(Model)
+		if m.postSearchQuery != before && m.postSearchActive() {
+			next, _ = m.Update(m.runPostSearch(m.postSearchQuery)())
+			m = next.(Model)
+		}
+	}
+	return m
+}
+
+func TestPostSearch_SlashStartsSearch(t *testing.T) {
+	m, _ := newPostSearchTestModel(t)
+
+	next, _ := m.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'/'}})
+	m = next.(Model)
+
+	if m.mode != ModeSearch {
+		t.Fatalf("mode = %q, want %q", m.mode, ModeSearch)
+	}
+	if !m.postSearchInput.Focused() {
+		t.Error("search input should be focused")
+	}
+}
+
+func TestPostSearch_TypingFiltersPosts(t *testing.T) {
+	m, posts := newPostSearchTestModel(t)
+	m, _ = startSearch(m)
+
+	m = typeKeys(t, m, "templates")
+
+	if len(m.postSearchHits) != 1 || m.postSearchHits[0].Post != posts[0] {
+		t.Fatalf("hits = %v, want only %s", hitPaths(m.postSearchHits), posts[0].Path)
+	}
+	if got := m.getSelectedPost(); got != posts[0] {
+		t.Errorf("selected post = %v, want %s", got, posts[0].Path)
+	}
+
+	view := m.renderPosts()
+	if !strings.Contains(view, "Writing Go") || !strings.Contains(view, "render the site") {
+		t.Errorf("results should show the title and matching context:\n%s", view)
+	}
+}
+
+func TestPostSearch_TypingNeverTriggersShortcuts(t *testing.T) {
+	m, _ := newPostSearchTestModel(t)
+	m, _ = startSearch(m)
+
+	// j, k, q, e, s and F are list shortcuts in normal mode
+	for _, r := range "jkqesF" {
+		next, cmd := m.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+		m = next.(Model)
+		if m.mode != ModeSearch || m.view != ViewPosts || m.showSortMenu {
+			t.Fatalf("key %q left search mode: mode=%q view=%q", r, m.mode, m.view)
+		}
+		if cmd != nil {
+			if _, quit := cmd().(tea.QuitMsg); quit {
+				t.Fatalf("key %q quit the TUI", r)
+			}
+		}
+	}
+	if got := m.postSearchInput.Value(); got != "jkqesF" {
+		t.Errorf("input = %q, want all keys typed", got)
+	}
+}
+
+func TestPostSearch_VimNavigationAfterEnter(t *testing.T) {
+	m, posts := newPostSearchTestModel(t)
+	m, _ = startSearch(m)
+	m = typeKeys(t, m, "s") // matches every post
+
+	if len(m.postSearchHits) != len(posts) {
+		t.Fatalf("got %d hits, want %d", len(m.postSearchHits), len(posts))
+	}
+
+	next, _ := m.handleKey(tea.KeyMsg{Type: tea.KeyEnter})
+	m = next.(Model)
+	if m.mode != ModeNormal {
+		t.Fatalf("mode = %q after Enter, want normal", m.mode)
+	}
+
+	next, _ = m.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}})
+	m = next.(Model)
+	if m.postSearchCursor != 1 {
+		t.Errorf("cursor = %d after j, want 1", m.postSearchCursor)
+	}
+	next, _ = m.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'k'}})
+	m = next.(Model)
+	if m.postSearchCursor != 0 {
+		t.Errorf("cursor = %d after k, want 0", m.postSearchCursor)
+	}
+
+	next, _ = m.handleKey(tea.KeyMsg{Type: tea.KeyEnter})
+	m = next.(Model)
+	if m.view != ViewPostDetail || m.selectedPost != m.postSearchHits[0].Post {
+		t.Errorf("Enter should open the selected result, view = %q", m.view)
+	}
+}
+
+func TestPostSearch_EscapeClears(t *testing.T) {
+	m, _ := newPostSearchTestModel(t)
+	m, _ = startSearch(m)
+	m = typeKeys(t, m, "go")
+
+	next, _ := m.handleKey(tea.KeyMsg{Type: tea.KeyEscape})
+	m = next.(Model)
+
+	if m.mode != ModeNormal || m.postSearchActive() || m.postSearchHits != nil {
+		t.Errorf("Esc should clear the search: mode=%q query=%q", m.mode, m.postSearchQuery)
+	}
+}
+
+func TestPostSearch_RespectsCurrentPostList(t *testing.T) {
+	m, posts := newPostSearchTestModel(t)
+	m.posts = posts[1:] // e.g. a tag drill-down excluding go.md
+	m.postSearchQuery = "s"
+
+	m.handlePostSearchResults(postSearchResultsMsg{
+		query: "s",
+		hits:  []services.SearchHit{{Post: posts[0]}, {Post: posts[2]}},
+	})
+	if got := hitPaths(m.postSearchHits); !reflect.DeepEqual(got, []string{"kit.md"}) {
+		t.Errorf("hits = %v, want [kit.md]", got)
+	}
+
+	// Results for an outdated query are ignored
+	m.handlePostSearchResults(postSearchResultsMsg{query: "old", hits: nil})
+	if len(m.postSearchHits) != 1 {
+		t.Error("stale results should not replace current hits")
+	}
+}
+
+func TestSearchTerms(t *testing.T) {
+	got := searchTerms(`go "static site" tag:python title:"foo bar"`)
+	want := []string{"go", "static site", "python", "foo bar"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("searchTerms() = %q, want %q", got, want)
+	}
+}
+
+func TestHighlightTerms_KeepsText(t *testing.T) {
+	text := "Go templates and GO modules"
+	got := highlightTerms(text, []string{"go"})
+	if !strings.Contains(got, "templates and ") || !strings.Contains(got, " modules") {
+		t.Errorf("highlightTerms() lost text: %q", got)
+	}
+}
+
+func startSearch(m Model) (Model, tea.Cmd) {
+	next, cmd := m.startPostSearch()
+	return next.(Model), cmd
+}
+
+func hitPaths(hits []services.SearchHit) []string {
+	paths := make([]string, len(hits))
+	for i := range hits {
+		paths[i] = hits[i].Post.Path
+	}
+	return paths
+}
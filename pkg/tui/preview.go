@@ -0,0 +1,198 @@
+package tui
+
+import (
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/WaylonWalker/markata-go/pkg/models"
+)
+
+// previewSideBySideMinWidth is the narrowest terminal that shows the preview
+// beside the post list; narrower terminals stack it below the list.
+const previewSideBySideMinWidth = 100
+
+// previewRenderedMsg carries a post's rendered preview. Rendering runs in a
+// command so long posts never block the UI.
+type previewRenderedMsg struct {
+	post    *models.Post
+	width   int
+	content string
+}
+
+// togglePreview opens or closes the preview pane in the posts view.
+func (m Model) togglePreview() (tea.Model, tea.Cmd) {
+	if m.view != ViewPosts {
+		return m, nil
+	}
+	m.previewOpen = !m.previewOpen
+	m.previewPost = nil
+	m.layoutPosts()
+	return m, m.syncPreview()
+}
+
+// handlePreviewScroll scrolls the preview pane without moving the post
+// selection.
+func (m Model) handlePreviewScroll(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, keyMap.PreviewDown):
+		m.previewViewport.ScrollDown(1)
+	case key.Matches(msg, keyMap.PreviewUp):
+		m.previewViewport.ScrollUp(1)
+	case key.Matches(msg, keyMap.PreviewPageDown):
+		m.previewViewport.HalfPageDown()
+	case key.Matches(msg, keyMap.PreviewPageUp):
+		m.previewViewport.HalfPageUp()
+	}
+	return m, nil
+}
+
+// previewStacked reports whether the preview is shown below the post list
+// rather than beside it.
+func (m Model) previewStacked() bool {
+	return m.width < previewSideBySideMinWidth
+}
+
+// postListSize returns the width and height available to the post list,
+// which shrinks to make room for the preview pane when it is open.
+func (m Model) postListSize() (width, height int) {
+	width, height = m.width, m.height-10
+	if !m.previewOpen {
+		return width, height
+	}
+	if m.previewStacked() {
+		return width, max(height/2, 3)
+	}
+	return m.width * 11 / 20, height
+}
+
+// previewPaneSize returns the outer size of the preview pane, borders
+// included.
+func (m Model) previewPaneSize() (width, height int) {
+	listWidth, listHeight := m.postListSize()
+	// The list is drawn under a two line "Posts (N)" header
+	contentHeight := m.height - 8
+	if m.previewStacked() {
+		return max(m.width, 20), max(contentHeight-listHeight-2, 5)
+	}
+	return max(m.width-listWidth-1, 20), max(contentHeight, 5)
+}
+
+// layoutPosts sizes the posts table and preview viewport for the current
+// terminal size, keeping the selected row.
+func (m *Model) layoutPosts() {
+	width, height := m.postListSize()
+	m.postsTable = createPostsTableWithTheme(width, m.theme)
+	m.postsTable.SetHeight(height)
+	if len(m.posts) > 0 {
+		m.postsTable.SetRows(m.postsToRows())
+		m.postsTable.SetCursor(m.cursor)
+	}
+
+	if !m.previewOpen {
+		return
+	}
+	paneWidth, paneHeight := m.previewPaneSize()
+	// Borders take two cells each way and the post title one line
+	viewportWidth, viewportHeight := paneWidth-2, paneHeight-3
+	if viewportWidth != m.previewViewport.Width {
+		// Cached renders are wrapped to the old width
+		m.previewCache = nil
+		m.previewPost = nil
+	}
+	if m.previewViewport.Width == 0 {
+		m.previewViewport = viewport.New(viewportWidth, viewportHeight)
+	}
+	m.previewViewport.Width = viewportWidth
+	m.previewViewport.Height = viewportHeight
+}
+
+// syncPreview points the preview at the selected post. Posts rendered
+// earlier at the same width come from the cache; others are rendered in the
+// background while the pane shows a placeholder.
+func (m *Model) syncPreview() tea.Cmd {
+	if !m.previewOpen || m.view != ViewPosts {
+		return nil
+	}
+	p := m.getSelectedPost()
+	if p == m.previewPost {
+		return nil
+	}
+	m.previewPost = p
+	m.previewViewport.GotoTop()
+	if p == nil {
+		m.previewViewport.SetContent("")
+		return nil
+	}
+	if content, ok := m.previewCache[p]; ok {
+		m.previewViewport.SetContent(content)
+		return nil
+	}
+	m.previewViewport.SetContent(m.getTheme().SubtleStyle.Render("  Rendering..."))
+	return m.renderPreview(p, m.previewViewport.Width)
+}
+
+// renderPreview renders a post's markdown for the preview pane.
+func (m Model) renderPreview(p *models.Post, width int) tea.Cmd {
+	render := m.renderPostContent
+	return func() tea.Msg {
+		return previewRenderedMsg{post: p, width: width, content: render(p, width)}
+	}
+}
+
+// handlePreviewRendered caches a finished render and shows it if its post is
+// still selected. Renders for an outdated width are dropped.
+func (m *Model) handlePreviewRendered(msg previewRenderedMsg) {
+	if msg.width != m.previewViewport.Width {
+		return
+	}
+	if m.previewCache == nil {
+		m.previewCache = make(map[*models.Post]string)
+	}
+	m.previewCache[msg.post] = msg.content
+	if msg.post == m.previewPost {
+		m.previewViewport.SetContent(msg.content)
+	}
+}
+
+// resetPreview drops cached renders, e.g. after posts are rebuilt, so the
+// pane re-renders the selected post.
+func (m *Model) resetPreview() {
+	m.previewCache = nil
+	m.previewPost = nil
+}
+
+// renderWithPreview places the preview pane beside the post list, or below
+// it on narrow terminals.
+func (m Model) renderWithPreview(list string) string {
+	theme := m.getTheme()
+	paneWidth, paneHeight := m.previewPaneSize()
+
+	title := "(no post selected)"
+	if p := m.previewPost; p != nil {
+		title = p.Path
+		if p.Title != nil && *p.Title != "" {
+			title = *p.Title
+		}
+	}
+	title = truncateRunes(title, paneWidth-4)
+
+	pane := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(theme.Colors.Border).
+		Width(paneWidth - 2).
+		MaxWidth(paneWidth).
+		Height(paneHeight - 2).
+		MaxHeight(paneHeight).
+		Render(theme.HeaderStyle.Render(title) + "\n" + m.previewViewport.View())
+
+	// The posts table keeps its fixed columns, so clip it to its share
+	listWidth, _ := m.postListSize()
+	list = lipgloss.NewStyle().MaxWidth(listWidth).Render(list)
+	if m.previewStacked() {
+		return lipgloss.JoinVertical(lipgloss.Left, list, pane)
+	}
+	list = lipgloss.NewStyle().Width(listWidth).Render(list)
+	return lipgloss.JoinHorizontal(lipgloss.Top, list, " ", pane)
+}
@@ -0,0 +1,185 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/WaylonWalker/markata-go/pkg/models"
+)
+
+func newPreviewTestModel(t *testing.T, width, height int) (m Model, posts []*models.Post) {
+	t.Helper()
+
+	m, posts = newPostSearchTestModel(t)
+	posts[2].Content = strings.Repeat("A line about jq and kubectl.\n\n", 60)
+	next, _ := m.Update(tea.WindowSizeMsg{Width: width, Height: height})
+	m = next.(Model)
+	return m, posts
+}
+
+// pressKey sends a key through Update and delivers any preview render it
+// starts, as the program would once the render finishes.
+func pressKey(t *testing.T, m Model, msg tea.KeyMsg) Model {
+	t.Helper()
+	next, cmd := m.Update(msg)
+	m = next.(Model)
+	if rendered, ok := findPreviewRender(cmd); ok {
+		next, _ = m.Update(rendered)
+		m = next.(Model)
+	}
+	return m
+}
+
+// findPreviewRender runs cmd, looking through batches, and returns the
+// first preview render it produces.
+func findPreviewRender(cmd tea.Cmd) (previewRenderedMsg, bool) {
+	if cmd == nil {
+		return previewRenderedMsg{}, false
+	}
+	switch msg := cmd().(type) {
+	case previewRenderedMsg:
+		return msg, true
+	case tea.BatchMsg:
+		for _, c := range msg {
+			if rendered, ok := findPreviewRender(c); ok {
+				return rendered, true
+			}
+		}
+	}
+	return previewRenderedMsg{}, false
+}
+
+func runeKey(r rune) tea.KeyMsg {
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}}
+}
+
+func TestPreview_ToggleRendersSelectedPost(t *testing.T) {
+	m, posts := newPreviewTestModel(t, 120, 40)
+
+	next, cmd := m.Update(runeKey('v'))
+	m = next.(Model)
+	if !m.previewOpen {
+		t.Fatal("v should open the preview pane")
+	}
+	if cmd == nil {
+		t.Fatal("opening the preview should start an asynchronous render")
+	}
+	if view := m.renderPosts(); !strings.Contains(view, "Rendering...") {
+		t.Errorf("pane should show a placeholder until the render finishes:\n%s", view)
+	}
+
+	rendered, ok := findPreviewRender(cmd)
+	if !ok || rendered.post != posts[0] {
+		t.Fatalf("render = %+v, want the selected post %s", rendered, posts[0].Path)
+	}
+	next, _ = m.Update(rendered)
+	m = next.(Model)
+	if view := m.renderPosts(); !strings.Contains(view, "templates") {
+		t.Errorf("pane should show the rendered post:\n%s", view)
+	}
+
+	m = pressKey(t, m, runeKey('v'))
+	if m.previewOpen {
+		t.Error("v should close the preview pane")
+	}
+}
+
+func TestPreview_FollowsSelection(t *testing.T) {
+	m, posts := newPreviewTestModel(t, 120, 40)
+	m = pressKey(t, m, runeKey('v'))
+
+	m = pressKey(t, m, runeKey('j'))
+	if m.previewPost != posts[1] {
+		t.Fatalf("preview post = %v, want %s", m.previewPost, posts[1].Path)
+	}
+	if view := m.renderPosts(); !strings.Contains(view, "Decorators") {
+		t.Errorf("pane should show the newly selected post:\n%s", view)
+	}
+
+	// Returning to a rendered post uses the cache without re-rendering
+	next, cmd := m.Update(runeKey('k'))
+	m = next.(Model)
+	if _, ok := findPreviewRender(cmd); ok {
+		t.Error("a cached post should not be rendered again")
+	}
+	if !strings.Contains(m.renderPosts(), "templates") {
+		t.Error("pane should show the cached render")
+	}
+}
+
+func TestPreview_IgnoresStaleRenders(t *testing.T) {
+	m, posts := newPreviewTestModel(t, 120, 40)
+	next, cmd := m.Update(runeKey('v'))
+	m = next.(Model)
+	stale, _ := findPreviewRender(cmd)
+
+	// Move on before the first render finishes
+	m = pressKey(t, m, runeKey('j'))
+	next, _ = m.Update(stale)
+	m = next.(Model)
+
+	if m.previewPost != posts[1] || strings.Contains(m.previewViewport.View(), "templates") {
+		t.Error("a render for a previously selected post should not replace the pane")
+	}
+}
+
+func TestPreview_ScrollsIndependently(t *testing.T) {
+	m, posts := newPreviewTestModel(t, 120, 40)
+	m = pressKey(t, m, runeKey('v'))
+	m = pressKey(t, m, runeKey('j'))
+	m = pressKey(t, m, runeKey('j'))
+	if m.previewPost != posts[2] {
+		t.Fatalf("preview post = %v, want the long post", m.previewPost)
+	}
+
+	m = pressKey(t, m, tea.KeyMsg{Type: tea.KeyCtrlD})
+	m = pressKey(t, m, runeKey('J'))
+	if m.previewViewport.YOffset == 0 {
+		t.Error("Ctrl+D and J should scroll the preview")
+	}
+	if m.cursor != 2 {
+		t.Errorf("cursor = %d, scrolling the preview should not move the selection", m.cursor)
+	}
+
+	m = pressKey(t, m, runeKey('K'))
+	m = pressKey(t, m, tea.KeyMsg{Type: tea.KeyCtrlU})
+	if m.previewViewport.YOffset != 0 {
+		t.Errorf("YOffset = %d after scrolling back up, want 0", m.previewViewport.YOffset)
+	}
+}
+
+func TestPreview_Layout(t *testing.T) {
+	tests := []struct {
+		name        string
+		width       int
+		wantStacked bool
+	}{
+		{"wide terminal splits side by side", 140, false},
+		{"narrow terminal stacks vertically", 80, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, _ := newPreviewTestModel(t, tt.width, 40)
+			m = pressKey(t, m, runeKey('v'))
+
+			if got := m.previewStacked(); got != tt.wantStacked {
+				t.Fatalf("previewStacked() = %v, want %v", got, tt.wantStacked)
+			}
+
+			lines := strings.Split(m.renderPosts(), "\n")
+			for i, line := range lines {
+				if w := lipgloss.Width(line); w > tt.width {
+					t.Errorf("line %d is %d cells wide, terminal is %d", i, w, tt.width)
+				}
+			}
+			// Side by side, the pane title sits on the list's second line
+			if second := lines[1]; tt.wantStacked == strings.Contains(second, "Writing Go") {
+				t.Errorf("line %q: the pane title should be beside the list only when split", second)
+			}
+		})
+	}
+}
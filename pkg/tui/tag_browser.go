@@ -0,0 +1,136 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/WaylonWalker/markata-go/pkg/services"
+)
+
+// Tag sort orders for the tags view.
+const (
+	tagSortCount = "count" // Most used first (default)
+	tagSortName  = "name"  // Alphabetical
+)
+
+// sortTags orders the tags by the tags view's sort setting.
+func (m *Model) sortTags() {
+	byName := m.tagSortBy == tagSortName
+	sort.SliceStable(m.tags, func(i, j int) bool {
+		a, b := m.tags[i], m.tags[j]
+		if !byName && a.Count != b.Count {
+			return a.Count > b.Count
+		}
+		return strings.ToLower(a.Name) < strings.ToLower(b.Name)
+	})
+}
+
+// setTags replaces the tag list, keeping the sort order and selections.
+// Selected tags that no longer exist are dropped.
+func (m *Model) setTags(tags []services.TagInfo) {
+	m.tags = tags
+	m.sortTags()
+
+	var selected []string
+	for _, name := range m.selectedTags {
+		if slices.ContainsFunc(m.tags, func(t services.TagInfo) bool { return t.Name == name }) {
+			selected = append(selected, name)
+		}
+	}
+	m.selectedTags = selected
+
+	m.tagsTable.SetRows(m.tagsToRows())
+}
+
+// toggleTagSort switches the tags view between count and name order,
+// keeping the cursor on the same tag.
+func (m Model) toggleTagSort() (tea.Model, tea.Cmd) {
+	var current string
+	if m.cursor < len(m.tags) {
+		current = m.tags[m.cursor].Name
+	}
+
+	if m.tagSortBy == tagSortName {
+		m.tagSortBy = tagSortCount
+	} else {
+		m.tagSortBy = tagSortName
+	}
+	m.sortTags()
+	m.tagsTable.SetRows(m.tagsToRows())
+
+	for i, t := range m.tags {
+		if t.Name == current {
+			m.cursor = i
+			break
+		}
+	}
+	m.tagsTable.SetCursor(m.cursor)
+	return m, nil
+}
+
+// toggleTagSelection adds the tag under the cursor to the multi-select set,
+// or removes it if already selected.
+func (m Model) toggleTagSelection() (tea.Model, tea.Cmd) {
+	if m.cursor >= len(m.tags) {
+		return m, nil
+	}
+	name := m.tags[m.cursor].Name
+	if i := slices.Index(m.selectedTags, name); i >= 0 {
+		m.selectedTags = slices.Delete(slices.Clone(m.selectedTags), i, i+1)
+	} else {
+		m.selectedTags = append(slices.Clone(m.selectedTags), name)
+	}
+	m.tagsTable.SetRows(m.tagsToRows())
+	return m, nil
+}
+
+// tagSelected reports whether name is in the multi-select set.
+func (m Model) tagSelected(name string) bool {
+	return slices.Contains(m.selectedTags, name)
+}
+
+// newTagFilter returns the drill-down filter for posts with all tags.
+func newTagFilter(tags []string) *FilterContext {
+	return &FilterContext{
+		Type: "tag",
+		Name: strings.Join(tags, " + "),
+		Tags: tags,
+	}
+}
+
+// loadPostsForTags loads posts that have every one of the given tags.
+func (m Model) loadPostsForTags(tags []string) tea.Cmd {
+	return func() tea.Msg {
+		opts := services.ListOptions{
+			SortBy:    m.sortBy,
+			SortOrder: m.sortOrder,
+		}
+		posts, err := m.app.Tags.GetPostsWithAll(context.Background(), tags, opts)
+		if err != nil {
+			return errMsg{err}
+		}
+		return postsLoadedMsg{posts}
+	}
+}
+
+// tagsHeader describes the tag list's sort order and selection.
+func (m Model) tagsHeader() string {
+	header := fmt.Sprintf("Tags (%d) by %s", len(m.tags), m.tagSortLabel())
+	if n := len(m.selectedTags); n > 0 {
+		header += fmt.Sprintf(" · %d selected: %s", n, strings.Join(m.selectedTags, " + "))
+	}
+	return header
+}
+
+// tagSortLabel returns the active tag sort order for display.
+func (m Model) tagSortLabel() string {
+	if m.tagSortBy == tagSortName {
+		return tagSortName
+	}
+	return tagSortCount
+}
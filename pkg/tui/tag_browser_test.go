@@ -0,0 +1,128 @@
+package tui
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/WaylonWalker/markata-go/pkg/lifecycle"
+	"github.com/WaylonWalker/markata-go/pkg/models"
+	"github.com/WaylonWalker/markata-go/pkg/services"
+)
+
+func newTagBrowserTestModel(t *testing.T) Model {
+	t.Helper()
+
+	post := func(path string, tags ...string) *models.Post {
+		return &models.Post{Path: path, Slug: strings.TrimSuffix(path, ".md"), Tags: tags}
+	}
+	manager := lifecycle.NewManager()
+	manager.SetPosts([]*models.Post{
+		post("a.md", "go", "web"),
+		post("b.md", "go"),
+		post("c.md", "web", "go", "htmx"),
+	})
+
+	m := NewModel(services.NewApp(manager))
+	m.view = ViewTags
+	m.setTags([]services.TagInfo{
+		{Name: "htmx", Count: 1},
+		{Name: "go", Count: 3},
+		{Name: "web", Count: 2},
+	})
+	return m
+}
+
+func tagNames(tags []services.TagInfo) []string {
+	names := make([]string, len(tags))
+	for i := range tags {
+		names[i] = tags[i].Name
+	}
+	return names
+}
+
+func TestTagBrowser_SortToggle(t *testing.T) {
+	m := newTagBrowserTestModel(t)
+	if got := tagNames(m.tags); !reflect.DeepEqual(got, []string{"go", "web", "htmx"}) {
+		t.Fatalf("default order = %v, want by count", got)
+	}
+
+	// Keep the cursor on "web" across the re-sort
+	m.cursor = 1
+	next, _ := m.handleKey(runeKey('s'))
+	m = next.(Model)
+
+	if got := tagNames(m.tags); !reflect.DeepEqual(got, []string{"go", "htmx", "web"}) {
+		t.Errorf("order after s = %v, want by name", got)
+	}
+	if m.tags[m.cursor].Name != "web" {
+		t.Errorf("cursor on %q, want web", m.tags[m.cursor].Name)
+	}
+	if !strings.Contains(m.renderTags(), "by name") {
+		t.Error("header should show the sort order")
+	}
+}
+
+func TestTagBrowser_MultiSelectDrillDown(t *testing.T) {
+	m := newTagBrowserTestModel(t)
+
+	// Select go and web, then open posts having both
+	next, _ := m.handleKey(tea.KeyMsg{Type: tea.KeySpace, Runes: []rune{' '}})
+	m = next.(Model)
+	m.cursor = 1
+	next, _ = m.handleKey(tea.KeyMsg{Type: tea.KeySpace, Runes: []rune{' '}})
+	m = next.(Model)
+
+	if !reflect.DeepEqual(m.selectedTags, []string{"go", "web"}) {
+		t.Fatalf("selectedTags = %v, want [go web]", m.selectedTags)
+	}
+	if !strings.Contains(m.renderTags(), "✓ go") {
+		t.Error("selected tags should be marked in the table")
+	}
+
+	next, cmd := m.handleEnter()
+	m = next.(Model)
+	if m.view != ViewPosts || m.activeFilter == nil {
+		t.Fatalf("Enter should drill down into posts, view = %q", m.view)
+	}
+	if !reflect.DeepEqual(m.activeFilter.Tags, []string{"go", "web"}) {
+		t.Errorf("filter tags = %v, want [go web]", m.activeFilter.Tags)
+	}
+
+	next, _ = m.Update(cmd())
+	m = next.(Model)
+	var paths []string
+	for _, p := range m.posts {
+		paths = append(paths, p.Path)
+	}
+	sort.Strings(paths)
+	if !reflect.DeepEqual(paths, []string{"a.md", "c.md"}) {
+		t.Errorf("posts = %v, want posts tagged go and web", paths)
+	}
+	if header := m.renderLayout(m.renderPosts()); !strings.Contains(header, "tag: go + web") {
+		t.Errorf("header should show the active tag filter:\n%s", header)
+	}
+}
+
+func TestTagBrowser_EscapeClearsSelection(t *testing.T) {
+	m := newTagBrowserTestModel(t)
+	m.selectedTags = []string{"go"}
+
+	next, _ := m.handleEscape()
+	m = next.(Model)
+	if m.selectedTags != nil {
+		t.Errorf("selectedTags = %v, want cleared", m.selectedTags)
+	}
+}
+
+func TestTagBrowser_ShowsSynonyms(t *testing.T) {
+	m := newTagBrowserTestModel(t)
+	m.setTags([]services.TagInfo{{Name: "javascript", Count: 2, Aliases: []string{"js"}}})
+
+	if !strings.Contains(m.renderTags(), "javascript (js)") {
+		t.Error("collapsed synonyms should be listed next to the tag")
+	}
+}